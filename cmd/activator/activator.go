@@ -0,0 +1,351 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	kfclientset "github.com/google/kf/v2/pkg/client/kf/clientset/versioned"
+	kflisters "github.com/google/kf/v2/pkg/client/kf/listers/kf/v1alpha1"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// recordInterval is the minimum time between two updates of an App's
+	// last request time. The idle timeout is at least a minute so finer
+	// updates wouldn't change when Apps are scaled down.
+	recordInterval = time.Minute
+)
+
+// activationTarget is the App a request is destined for.
+type activationTarget struct {
+	Namespace string
+	Name      string
+	Port      int32
+}
+
+// parseActivationTarget parses the ActivatorTargetHeader value, which has the
+// format NAMESPACE/NAME:PORT.
+func parseActivationTarget(value string) (*activationTarget, error) {
+	namespace, rest, ok := strings.Cut(value, "/")
+	if !ok || namespace == "" {
+		return nil, fmt.Errorf("target %q is missing a namespace", value)
+	}
+
+	name, rawPort, ok := strings.Cut(rest, ":")
+	if !ok || name == "" {
+		return nil, fmt.Errorf("target %q is missing a name or port", value)
+	}
+
+	port, err := strconv.ParseInt(rawPort, 10, 32)
+	if err != nil || port <= 0 {
+		return nil, fmt.Errorf("target %q has an invalid port", value)
+	}
+
+	return &activationTarget{Namespace: namespace, Name: name, Port: int32(port)}, nil
+}
+
+// URL returns the in-cluster URL of the App's Service.
+func (t *activationTarget) URL() *url.URL {
+	return &url.URL{
+		Scheme: "http",
+		Host:   fmt.Sprintf("%s.%s.svc.cluster.local:%d", t.Name, t.Namespace, t.Port),
+	}
+}
+
+// activator records requests for Apps with an idle timeout and holds them
+// until the App has ready instances. Apps and Endpoints are read from
+// informers so held requests don't put load on the API server.
+type activator struct {
+	kfClient          kfclientset.Interface
+	appLister         kflisters.AppLister
+	endpointsLister   corev1listers.EndpointsLister
+	activationTimeout time.Duration
+	logger            *zap.Logger
+
+	// now and targetURL are replaced in tests.
+	now       func() time.Time
+	targetURL func(*activationTarget) *url.URL
+
+	mu       sync.Mutex
+	recorded map[string]time.Time
+	// waiters are closed when the Endpoints with the key get a ready address.
+	waiters map[string][]chan struct{}
+}
+
+func newActivator(
+	kfClient kfclientset.Interface,
+	appLister kflisters.AppLister,
+	endpointsLister corev1listers.EndpointsLister,
+	activationTimeout time.Duration,
+	logger *zap.Logger,
+) *activator {
+	return &activator{
+		kfClient:          kfClient,
+		appLister:         appLister,
+		endpointsLister:   endpointsLister,
+		activationTimeout: activationTimeout,
+		logger:            logger,
+		now:               time.Now,
+		targetURL:         (*activationTarget).URL,
+		recorded:          make(map[string]time.Time),
+		waiters:           make(map[string][]chan struct{}),
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (a *activator) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	target, err := parseActivationTarget(req.Header.Get(v1alpha1.ActivatorTargetHeader))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	logger := a.logger.With(
+		zap.String("namespace", target.Namespace),
+		zap.String("app", target.Name),
+	)
+
+	app, err := a.appLister.Apps(target.Namespace).Get(target.Name)
+	switch {
+	case apierrs.IsNotFound(err):
+		http.Error(w, "App not found", http.StatusNotFound)
+		return
+	case err != nil:
+		logger.Warn("couldn't get App", zap.Error(err))
+		http.Error(w, "couldn't get App", http.StatusServiceUnavailable)
+		return
+	case app.Spec.Instances.IdleTimeout == nil:
+		http.Error(w, "App doesn't have an idle timeout", http.StatusNotFound)
+		return
+	}
+
+	// The VirtualService overwrites the target header sent by clients, but
+	// the activator can be called directly from inside the cluster so only
+	// forward requests a Route mapped to the App would send it.
+	if !routedToApp(app, target, req) {
+		http.Error(w, "request doesn't match a Route mapped to the App", http.StatusForbidden)
+		return
+	}
+
+	if err := a.recordRequest(req.Context(), target); err != nil {
+		// Keep serving, the App is still scaled up if it has instances.
+		logger.Warn("couldn't record request time", zap.Error(err))
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), a.activationTimeout)
+	err = a.waitForEndpoints(ctx, target)
+	cancel()
+	if err != nil {
+		logger.Warn("App didn't become ready", zap.Error(err))
+		http.Error(w, "App didn't become ready in time", http.StatusServiceUnavailable)
+		return
+	}
+
+	a.proxy(target, logger).ServeHTTP(w, req)
+}
+
+// routedToApp returns true if the request's host and path match a Route mapped
+// to the target port of the App.
+func routedToApp(app *v1alpha1.App, target *activationTarget, req *http.Request) bool {
+	host := req.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	for _, binding := range app.Status.Routes {
+		source := binding.Source
+		switch {
+		case binding.Status == v1alpha1.RouteBindingStatusOrphaned,
+			source.IsTCP(),
+			binding.Destination.ServiceName != target.Name,
+			binding.Destination.Port != target.Port:
+			continue
+		}
+
+		if source.IsWildcard() {
+			if !strings.HasSuffix(strings.ToLower(host), "."+strings.ToLower(source.Domain)) {
+				continue
+			}
+		} else if !strings.EqualFold(host, source.Host()) {
+			continue
+		}
+
+		routePath := path.Join("/", source.Path)
+		if routePath == "/" || req.URL.Path == routePath || strings.HasPrefix(req.URL.Path, routePath+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// recordRequest updates the last request time of the App so the App
+// reconciler scales it up, or keeps it from scaling down.
+func (a *activator) recordRequest(ctx context.Context, target *activationTarget) error {
+	key := target.Namespace + "/" + target.Name
+	now := a.now()
+
+	a.mu.Lock()
+	if last, ok := a.recorded[key]; ok && now.Sub(last) < recordInterval {
+		a.mu.Unlock()
+		return nil
+	}
+	a.recorded[key] = now
+	a.mu.Unlock()
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				v1alpha1.LastRequestTimeAnnotation: now.UTC().Format(time.RFC3339),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = a.kfClient.KfV1alpha1().Apps(target.Namespace).Patch(ctx, target.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		// Allow the next request to try again.
+		a.mu.Lock()
+		delete(a.recorded, key)
+		a.mu.Unlock()
+	}
+	return err
+}
+
+// waitForEndpoints blocks until the App's Service has a ready address or the
+// context is done.
+func (a *activator) waitForEndpoints(ctx context.Context, target *activationTarget) error {
+	key := target.Namespace + "/" + target.Name
+
+	// Subscribe before checking the lister so an update between the two
+	// isn't missed.
+	ready := a.subscribe(key)
+	defer a.unsubscribe(key, ready)
+
+	endpoints, err := a.endpointsLister.Endpoints(target.Namespace).Get(target.Name)
+	switch {
+	case err == nil && hasReadyAddress(endpoints):
+		return nil
+	case err != nil && !apierrs.IsNotFound(err):
+		a.logger.Debug("couldn't get Endpoints", zap.Error(err))
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-ready:
+		return nil
+	}
+}
+
+// subscribe returns a channel that's closed once the Endpoints with the key
+// have a ready address.
+func (a *activator) subscribe(key string) chan struct{} {
+	ch := make(chan struct{})
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.waiters[key] = append(a.waiters[key], ch)
+	return ch
+}
+
+// unsubscribe removes the channel from the waiters if it wasn't closed.
+func (a *activator) unsubscribe(key string, ch chan struct{}) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	waiters := a.waiters[key]
+	for i, waiter := range waiters {
+		if waiter == ch {
+			waiters = append(waiters[:i], waiters[i+1:]...)
+			break
+		}
+	}
+
+	if len(waiters) == 0 {
+		delete(a.waiters, key)
+	} else {
+		a.waiters[key] = waiters
+	}
+}
+
+// endpointsChanged is called by the Endpoints informer and releases the
+// requests waiting for the Endpoints if they have a ready address.
+func (a *activator) endpointsChanged(obj interface{}) {
+	endpoints, ok := obj.(*corev1.Endpoints)
+	if !ok || !hasReadyAddress(endpoints) {
+		return
+	}
+
+	key, err := cache.MetaNamespaceKeyFunc(endpoints)
+	if err != nil {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, ch := range a.waiters[key] {
+		close(ch)
+	}
+	delete(a.waiters, key)
+}
+
+// hasReadyAddress returns true if the Endpoints can receive traffic.
+func hasReadyAddress(endpoints *corev1.Endpoints) bool {
+	for _, subset := range endpoints.Subsets {
+		if len(subset.Addresses) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// proxy forwards the request to the App with the activator header removed.
+func (a *activator) proxy(target *activationTarget, logger *zap.Logger) http.Handler {
+	targetURL := a.targetURL(target)
+
+	return &httputil.ReverseProxy{
+		Director: func(req *http.Request) {
+			req.Header.Del(v1alpha1.ActivatorTargetHeader)
+			req.URL.Scheme = targetURL.Scheme
+			req.URL.Host = targetURL.Host
+		},
+		ErrorHandler: func(w http.ResponseWriter, req *http.Request, err error) {
+			logger.Warn("App request failed", zap.Error(err))
+			w.WriteHeader(http.StatusBadGateway)
+		},
+	}
+}
@@ -0,0 +1,341 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	kffake "github.com/google/kf/v2/pkg/client/kf/clientset/versioned/fake"
+	kflisters "github.com/google/kf/v2/pkg/client/kf/listers/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		env      map[string]string
+		wantErr  error
+		expected *activatorConfig
+	}{
+		"defaults": {
+			env: map[string]string{},
+			expected: &activatorConfig{
+				Port:              defaultPort,
+				AdminPort:         defaultAdminPort,
+				ActivationTimeout: defaultActivationTimeout,
+			},
+		},
+		"overrides": {
+			env: map[string]string{
+				portEnv:              "9000",
+				adminPortEnv:         "9001",
+				activationTimeoutEnv: "30s",
+			},
+			expected: &activatorConfig{
+				Port:              "9000",
+				AdminPort:         "9001",
+				ActivationTimeout: 30 * time.Second,
+			},
+		},
+		"zero timeout": {
+			env:     map[string]string{activationTimeoutEnv: "0s"},
+			wantErr: errors.New("couldn't parse ACTIVATION_TIMEOUT: timeout must be positive"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			cfg, err := loadConfig(func(key string) string {
+				return tc.env[key]
+			})
+			testutil.AssertErrorsEqual(t, tc.wantErr, err)
+			testutil.AssertEqual(t, "config", tc.expected, cfg)
+		})
+	}
+}
+
+func TestParseActivationTarget(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		value    string
+		expected *activationTarget
+		wantErr  error
+	}{
+		"valid": {
+			value:    "some-ns/some-app:8080",
+			expected: &activationTarget{Namespace: "some-ns", Name: "some-app", Port: 8080},
+		},
+		"empty": {
+			value:   "",
+			wantErr: errors.New(`target "" is missing a namespace`),
+		},
+		"missing port": {
+			value:   "some-ns/some-app",
+			wantErr: errors.New(`target "some-ns/some-app" is missing a name or port`),
+		},
+		"invalid port": {
+			value:   "some-ns/some-app:http",
+			wantErr: errors.New(`target "some-ns/some-app:http" has an invalid port`),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			actual, err := parseActivationTarget(tc.value)
+			testutil.AssertErrorsEqual(t, tc.wantErr, err)
+			testutil.AssertEqual(t, "target", tc.expected, actual)
+		})
+	}
+}
+
+func activatorTestApp(idleTimeout *metav1.Duration) *v1alpha1.App {
+	app := &v1alpha1.App{}
+	app.Namespace = "some-ns"
+	app.Name = "some-app"
+	app.Spec.Instances.IdleTimeout = idleTimeout
+	app.Status.Routes = []v1alpha1.AppRouteStatus{{
+		QualifiedRouteBinding: v1alpha1.QualifiedRouteBinding{
+			Source:      v1alpha1.RouteSpecFields{Hostname: "some-host", Domain: "example.com", Path: "/some"},
+			Destination: v1alpha1.RouteDestination{ServiceName: "some-app", Port: 80},
+		},
+	}}
+	return app
+}
+
+func readyEndpoints() *corev1.Endpoints {
+	endpoints := &corev1.Endpoints{}
+	endpoints.Namespace = "some-ns"
+	endpoints.Name = "some-app"
+	endpoints.Subsets = []corev1.EndpointSubset{{
+		Addresses: []corev1.EndpointAddress{{IP: "10.0.0.1"}},
+	}}
+	return endpoints
+}
+
+// newTestActivator creates an activator with listers backed by the given
+// objects.
+func newTestActivator(apps []runtime.Object, endpoints []runtime.Object, timeout time.Duration) (*activator, *kffake.Clientset, cache.Indexer) {
+	appIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, app := range apps {
+		appIndexer.Add(app)
+	}
+
+	endpointsIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, ep := range endpoints {
+		endpointsIndexer.Add(ep)
+	}
+
+	kfClient := kffake.NewSimpleClientset(apps...)
+	a := newActivator(
+		kfClient,
+		kflisters.NewAppLister(appIndexer),
+		corev1listers.NewEndpointsLister(endpointsIndexer),
+		timeout,
+		zap.NewNop(),
+	)
+	return a, kfClient, endpointsIndexer
+}
+
+func TestActivator_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	cases := map[string]struct {
+		header         string
+		url            string
+		apps           []runtime.Object
+		endpoints      []runtime.Object
+		wantStatus     int
+		wantAnnotation string
+	}{
+		"bad header": {
+			header:     "invalid",
+			wantStatus: http.StatusBadRequest,
+		},
+		"missing App": {
+			header:     "some-ns/some-app:80",
+			wantStatus: http.StatusNotFound,
+		},
+		"App without idle timeout": {
+			header:     "some-ns/some-app:80",
+			apps:       []runtime.Object{activatorTestApp(nil)},
+			endpoints:  []runtime.Object{readyEndpoints()},
+			wantStatus: http.StatusNotFound,
+		},
+		"App never becomes ready": {
+			header:         "some-ns/some-app:80",
+			apps:           []runtime.Object{activatorTestApp(&metav1.Duration{Duration: time.Hour})},
+			wantStatus:     http.StatusServiceUnavailable,
+			wantAnnotation: "2022-01-02T03:04:05Z",
+		},
+		"host not routed to App": {
+			header:     "some-ns/some-app:80",
+			url:        "http://other-host.example.com/some/path",
+			apps:       []runtime.Object{activatorTestApp(&metav1.Duration{Duration: time.Hour})},
+			endpoints:  []runtime.Object{readyEndpoints()},
+			wantStatus: http.StatusForbidden,
+		},
+		"path not routed to App": {
+			header:     "some-ns/some-app:80",
+			url:        "http://some-host.example.com/other/path",
+			apps:       []runtime.Object{activatorTestApp(&metav1.Duration{Duration: time.Hour})},
+			endpoints:  []runtime.Object{readyEndpoints()},
+			wantStatus: http.StatusForbidden,
+		},
+		"port not routed to App": {
+			header:     "some-ns/some-app:8080",
+			apps:       []runtime.Object{activatorTestApp(&metav1.Duration{Duration: time.Hour})},
+			endpoints:  []runtime.Object{readyEndpoints()},
+			wantStatus: http.StatusForbidden,
+		},
+		"App ready": {
+			header:         "some-ns/some-app:80",
+			apps:           []runtime.Object{activatorTestApp(&metav1.Duration{Duration: time.Hour})},
+			endpoints:      []runtime.Object{readyEndpoints()},
+			wantStatus:     http.StatusOK,
+			wantAnnotation: "2022-01-02T03:04:05Z",
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				testutil.AssertEqual(t, "target header", "", r.Header.Get(v1alpha1.ActivatorTargetHeader))
+				testutil.AssertEqual(t, "path", "/some/path", r.URL.Path)
+				testutil.AssertEqual(t, "host", "some-host.example.com:80", r.Host)
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer backend.Close()
+			backendURL, err := url.Parse(backend.URL)
+			testutil.AssertNil(t, "backend URL err", err)
+
+			a, kfClient, _ := newTestActivator(tc.apps, tc.endpoints, 50*time.Millisecond)
+			a.now = func() time.Time { return now }
+			a.targetURL = func(*activationTarget) *url.URL { return backendURL }
+
+			reqURL := tc.url
+			if reqURL == "" {
+				reqURL = "http://some-host.example.com:80/some/path"
+			}
+			req := httptest.NewRequest(http.MethodGet, reqURL, nil)
+			req.Header.Set(v1alpha1.ActivatorTargetHeader, tc.header)
+			rec := httptest.NewRecorder()
+			a.ServeHTTP(rec, req)
+
+			testutil.AssertEqual(t, "status", tc.wantStatus, rec.Code)
+
+			if tc.wantAnnotation != "" {
+				app, err := kfClient.KfV1alpha1().Apps("some-ns").Get(context.Background(), "some-app", metav1.GetOptions{})
+				testutil.AssertNil(t, "get App err", err)
+				testutil.AssertEqual(t, "annotation", tc.wantAnnotation, app.Annotations[v1alpha1.LastRequestTimeAnnotation])
+			}
+		})
+	}
+}
+
+func TestActivator_recordRequest(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	a, kfClient, _ := newTestActivator([]runtime.Object{activatorTestApp(&metav1.Duration{Duration: time.Hour})}, nil, time.Second)
+	a.now = func() time.Time { return now }
+
+	target := &activationTarget{Namespace: "some-ns", Name: "some-app", Port: 80}
+	patches := func() int {
+		count := 0
+		for _, action := range kfClient.Actions() {
+			if action.GetVerb() == "patch" {
+				count++
+			}
+		}
+		return count
+	}
+
+	testutil.AssertNil(t, "first err", a.recordRequest(context.Background(), target))
+	testutil.AssertEqual(t, "patches after first request", 1, patches())
+
+	now = now.Add(30 * time.Second)
+	testutil.AssertNil(t, "second err", a.recordRequest(context.Background(), target))
+	testutil.AssertEqual(t, "patches within interval", 1, patches())
+
+	now = now.Add(recordInterval)
+	testutil.AssertNil(t, "third err", a.recordRequest(context.Background(), target))
+	testutil.AssertEqual(t, "patches after interval", 2, patches())
+}
+
+func TestActivator_waitForEndpoints(t *testing.T) {
+	t.Parallel()
+
+	a, _, endpointsIndexer := newTestActivator(nil, nil, time.Minute)
+	target := &activationTarget{Namespace: "some-ns", Name: "some-app", Port: 80}
+
+	done := make(chan error)
+	go func() {
+		done <- a.waitForEndpoints(context.Background(), target)
+	}()
+
+	// Wait until the request is held.
+	for {
+		a.mu.Lock()
+		waiting := len(a.waiters["some-ns/some-app"])
+		a.mu.Unlock()
+		if waiting > 0 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// Endpoints without addresses don't release the request.
+	notReady := readyEndpoints()
+	notReady.Subsets = nil
+	a.endpointsChanged(notReady)
+
+	select {
+	case err := <-done:
+		t.Fatalf("request released before the App was ready: %v", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	endpoints := readyEndpoints()
+	endpointsIndexer.Add(endpoints)
+	a.endpointsChanged(endpoints)
+
+	select {
+	case err := <-done:
+		testutil.AssertNil(t, "err", err)
+	case <-time.After(time.Second):
+		t.Fatal("request wasn't released once the App was ready")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	testutil.AssertEqual(t, "waiters", 0, len(a.waiters))
+}
@@ -0,0 +1,223 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	kfclientset "github.com/google/kf/v2/pkg/client/kf/clientset/versioned"
+	kfinformers "github.com/google/kf/v2/pkg/client/kf/informers/externalversions"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/signals"
+)
+
+const (
+	// Environment variables used to configure the activator.
+	portEnv                  = "PORT"
+	adminPortEnv             = "ADMIN_PORT"
+	activationTimeoutEnv     = "ACTIVATION_TIMEOUT"
+	defaultPort              = "8080"
+	defaultAdminPort         = "8081"
+	defaultActivationTimeout = 2 * time.Minute
+	shutdownGracePeriod      = 10 * time.Second
+	serverReadHeaderTimeout  = 10 * time.Second
+	healthzPath              = "/healthz"
+	informerResyncPeriod     = 10 * time.Hour
+)
+
+// activatorConfig holds the runtime configuration of the activator.
+type activatorConfig struct {
+	// Port is the port the activator listens for traffic on.
+	Port string
+	// AdminPort is the port the health endpoint is served on.
+	AdminPort string
+	// ActivationTimeout is the maximum time a request is held while waiting
+	// for an App to scale up.
+	ActivationTimeout time.Duration
+}
+
+func main() {
+	logger, err := zap.NewProduction()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	defer logger.Sync()
+
+	cfg, err := loadConfig(os.Getenv)
+	if err != nil {
+		logger.Fatal("invalid configuration", zap.Error(err))
+	}
+
+	restConfig, err := rest.InClusterConfig()
+	if err != nil {
+		logger.Fatal("couldn't load in-cluster config", zap.Error(err))
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		logger.Fatal("couldn't create Kubernetes client", zap.Error(err))
+	}
+
+	kfClient, err := kfclientset.NewForConfig(restConfig)
+	if err != nil {
+		logger.Fatal("couldn't create kf client", zap.Error(err))
+	}
+
+	ctx := signals.NewContext()
+
+	kfInformers := kfinformers.NewSharedInformerFactory(kfClient, informerResyncPeriod)
+	appInformer := kfInformers.Kf().V1alpha1().Apps()
+
+	// Only watch the Endpoints of App Services, they have the labels of the
+	// Service.
+	kubeInformers := kubeinformers.NewSharedInformerFactoryWithOptions(
+		kubeClient,
+		informerResyncPeriod,
+		kubeinformers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.LabelSelector = labels.SelectorFromSet(labels.Set{
+				v1alpha1.ManagedByLabel: "kf",
+				v1alpha1.ComponentLabel: "service",
+			}).String()
+		}),
+	)
+	endpointsInformer := kubeInformers.Core().V1().Endpoints()
+
+	handler := newActivator(
+		kfClient,
+		appInformer.Lister(),
+		endpointsInformer.Lister(),
+		cfg.ActivationTimeout,
+		logger,
+	)
+	endpointsInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: handler.endpointsChanged,
+		UpdateFunc: func(_, newObj interface{}) {
+			handler.endpointsChanged(newObj)
+		},
+	})
+
+	kfInformers.Start(ctx.Done())
+	kubeInformers.Start(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), appInformer.Informer().HasSynced, endpointsInformer.Informer().HasSynced) {
+		logger.Fatal("couldn't sync informers")
+	}
+
+	if err := run(ctx, cfg, handler, logger); err != nil {
+		logger.Fatal("activator exited", zap.Error(err))
+	}
+}
+
+// loadConfig reads the activator configuration using the given lookup
+// function.
+func loadConfig(getenv func(string) string) (*activatorConfig, error) {
+	cfg := &activatorConfig{
+		Port:              valueOrDefault(getenv(portEnv), defaultPort),
+		AdminPort:         valueOrDefault(getenv(adminPortEnv), defaultAdminPort),
+		ActivationTimeout: defaultActivationTimeout,
+	}
+
+	if raw := getenv(activationTimeoutEnv); raw != "" {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse %s: %v", activationTimeoutEnv, err)
+		}
+		if d <= 0 {
+			return nil, fmt.Errorf("couldn't parse %s: timeout must be positive", activationTimeoutEnv)
+		}
+		cfg.ActivationTimeout = d
+	}
+
+	return cfg, nil
+}
+
+func valueOrDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+// run starts the activator and admin servers and blocks until the context is
+// cancelled or one of the servers fails.
+func run(ctx context.Context, cfg *activatorConfig, handler http.Handler, logger *zap.Logger) error {
+	activatorServer := &http.Server{
+		Addr:              fmt.Sprintf(":%s", cfg.Port),
+		Handler:           handler,
+		ReadHeaderTimeout: serverReadHeaderTimeout,
+	}
+
+	adminServer := &http.Server{
+		Addr:              fmt.Sprintf(":%s", cfg.AdminPort),
+		Handler:           newAdminHandler(),
+		ReadHeaderTimeout: serverReadHeaderTimeout,
+	}
+
+	logger.Info("starting activator",
+		zap.String("port", cfg.Port),
+		zap.String("adminPort", cfg.AdminPort),
+		zap.Duration("activationTimeout", cfg.ActivationTimeout),
+	)
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	for _, server := range []*http.Server{activatorServer, adminServer} {
+		server := server
+		group.Go(func() error {
+			if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		})
+	}
+
+	group.Go(func() error {
+		<-groupCtx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownGracePeriod)
+		defer cancel()
+
+		logger.Info("shutting down activator")
+		activatorErr := activatorServer.Shutdown(shutdownCtx)
+		adminErr := adminServer.Shutdown(shutdownCtx)
+		if activatorErr != nil {
+			return activatorErr
+		}
+		return adminErr
+	})
+
+	return group.Wait()
+}
+
+// newAdminHandler serves the health endpoint.
+func newAdminHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(healthzPath, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintln(w, "ok")
+	})
+	return mux
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/google/kf/v2/pkg/reconciler/space"
 	"github.com/google/kf/v2/pkg/reconciler/task"
 	"github.com/google/kf/v2/pkg/reconciler/taskschedule"
+	"github.com/google/kf/v2/pkg/reconciler/workflowrun"
 	"knative.dev/pkg/webhook"
 	"knative.dev/pkg/webhook/certificates"
 )
@@ -55,6 +56,7 @@ func main() {
 		featureflag.NewController,
 		task.NewController,
 		taskschedule.NewController,
+		workflowrun.NewController,
 		certificates.NewController,
 		apiservercerts.NewController,
 		garbagecollector.NewController,
@@ -32,6 +32,6 @@ func main() {
 		// resolve the issue.
 		cmd.PersistentPostRun(cmd, nil)
 		color.New(color.FgRed, color.Bold).Println("FAIL")
-		os.Exit(1)
+		os.Exit(commands.ExitCode(err))
 	}
 }
@@ -15,46 +15,227 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
-	"net/http/httputil"
 	"net/url"
 	"os"
+	"strconv"
+	"time"
 
-	"github.com/google/kf/v2/pkg/reconciler/route/resources"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	"knative.dev/pkg/signals"
 )
 
+const (
+	// Environment variables used to configure the proxy.
+	routeServiceURLEnv       = "ROUTE_SERVICE_URL"
+	portEnv                  = "PORT"
+	adminPortEnv             = "ADMIN_PORT"
+	caFileEnv                = "ROUTE_SERVICE_CA_FILE"
+	caBundleEnv              = "ROUTE_SERVICE_CA_BUNDLE"
+	insecureSkipVerifyEnv    = "ROUTE_SERVICE_INSECURE_SKIP_VERIFY"
+	requestTimeoutEnv        = "REQUEST_TIMEOUT"
+	idleTimeoutEnv           = "IDLE_TIMEOUT"
+	defaultPort              = "8080"
+	defaultAdminPort         = "8081"
+	defaultRequestTimeout    = 60 * time.Second
+	defaultIdleTimeout       = 90 * time.Second
+	shutdownGracePeriod      = 10 * time.Second
+	serverReadHeaderTimeout  = 10 * time.Second
+	healthzPath              = "/healthz"
+	metricsPath              = "/metrics"
+	forwardedProtoHeaderName = "X-Forwarded-Proto"
+)
+
+// proxyConfig holds the runtime configuration of the proxy.
+type proxyConfig struct {
+	// RouteServiceURL is the URL of the route service requests are sent to.
+	RouteServiceURL *url.URL
+	// Port is the port the proxy listens for traffic on.
+	Port string
+	// AdminPort is the port health and metrics endpoints are served on. It's
+	// separate from Port so the endpoints never shadow paths on the route.
+	AdminPort string
+	// CAFile is an optional PEM bundle trusted in addition to the system roots
+	// when connecting to the route service over TLS.
+	CAFile string
+	// CABundle is an optional inline PEM bundle trusted in addition to the
+	// system roots and CAFile.
+	CABundle string
+	// InsecureSkipVerify disables TLS verification of the route service.
+	InsecureSkipVerify bool
+	// RequestTimeout is the maximum time a request to the route service may
+	// take, zero disables the timeout.
+	RequestTimeout time.Duration
+	// IdleTimeout is the maximum time keep-alive connections are left idle
+	// both for clients and the route service.
+	IdleTimeout time.Duration
+}
+
 func main() {
-	rsURLString := os.Getenv("ROUTE_SERVICE_URL")
+	logger, err := zap.NewProduction()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	defer logger.Sync()
+
+	cfg, err := loadConfig(os.Getenv)
+	if err != nil {
+		logger.Fatal("invalid configuration", zap.Error(err))
+	}
+
+	if err := run(signals.NewContext(), cfg, logger); err != nil {
+		logger.Fatal("proxy exited", zap.Error(err))
+	}
+}
+
+// loadConfig reads the proxy configuration using the given lookup function.
+func loadConfig(getenv func(string) string) (*proxyConfig, error) {
+	rsURLString := getenv(routeServiceURLEnv)
 	if rsURLString == "" {
-		log.Fatal("Route service URL is not set")
+		return nil, errors.New("route service URL is not set")
 	}
 
 	routeServiceURL, err := url.Parse(rsURLString)
 	if err != nil {
-		log.Fatal(err.Error())
+		return nil, err
 	}
 
 	// Add scheme to URL if it does not exist. Defaults to HTTP.
 	// Regenerate and update the URL to have the correct Host. (A URL with an empty scheme has an empty Host.)
 	if routeServiceURL.Scheme == "" {
-		err := updateURLParts(routeServiceURL)
-		if err != nil {
-			log.Fatal(err.Error())
+		if err := updateURLParts(routeServiceURL); err != nil {
+			return nil, err
+		}
+	}
+
+	cfg := &proxyConfig{
+		RouteServiceURL: routeServiceURL,
+		Port:            valueOrDefault(getenv(portEnv), defaultPort),
+		AdminPort:       valueOrDefault(getenv(adminPortEnv), defaultAdminPort),
+		CAFile:          getenv(caFileEnv),
+		CABundle:        getenv(caBundleEnv),
+		RequestTimeout:  defaultRequestTimeout,
+		IdleTimeout:     defaultIdleTimeout,
+	}
+
+	if raw := getenv(insecureSkipVerifyEnv); raw != "" {
+		if cfg.InsecureSkipVerify, err = strconv.ParseBool(raw); err != nil {
+			return nil, fmt.Errorf("couldn't parse %s: %v", insecureSkipVerifyEnv, err)
+		}
+	}
+
+	if raw := getenv(requestTimeoutEnv); raw != "" {
+		if cfg.RequestTimeout, err = parseTimeout(raw); err != nil {
+			return nil, fmt.Errorf("couldn't parse %s: %v", requestTimeoutEnv, err)
+		}
+	}
+
+	if raw := getenv(idleTimeoutEnv); raw != "" {
+		if cfg.IdleTimeout, err = parseTimeout(raw); err != nil {
+			return nil, fmt.Errorf("couldn't parse %s: %v", idleTimeoutEnv, err)
 		}
 	}
 
-	log.Fatal(http.ListenAndServe(hostPort(), newProxy(routeServiceURL)))
+	return cfg, nil
 }
 
-func hostPort() string {
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+func parseTimeout(raw string) (time.Duration, error) {
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, errors.New("timeout must not be negative")
 	}
+	return d, nil
+}
 
-	return fmt.Sprintf(":%s", port)
+func valueOrDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+// run starts the proxy and admin servers and blocks until the context is
+// cancelled or one of the servers fails.
+func run(ctx context.Context, cfg *proxyConfig, logger *zap.Logger) error {
+	transport, err := newTransport(cfg)
+	if err != nil {
+		return err
+	}
+
+	registry := prometheus.NewRegistry()
+	metrics := newProxyMetrics(registry)
+
+	proxyServer := &http.Server{
+		Addr: fmt.Sprintf(":%s", cfg.Port),
+		Handler: withAccessLog(logger, metrics.instrument(
+			newProxy(cfg.RouteServiceURL, transport, cfg.RequestTimeout, logger),
+		)),
+		ReadHeaderTimeout: serverReadHeaderTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+	}
+
+	adminServer := &http.Server{
+		Addr:              fmt.Sprintf(":%s", cfg.AdminPort),
+		Handler:           newAdminHandler(registry),
+		ReadHeaderTimeout: serverReadHeaderTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+	}
+
+	logger.Info("starting route service proxy",
+		zap.String("routeServiceURL", cfg.RouteServiceURL.String()),
+		zap.String("port", cfg.Port),
+		zap.String("adminPort", cfg.AdminPort),
+		zap.Duration("requestTimeout", cfg.RequestTimeout),
+		zap.Duration("idleTimeout", cfg.IdleTimeout),
+		zap.Bool("insecureSkipVerify", cfg.InsecureSkipVerify),
+	)
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	for _, server := range []*http.Server{proxyServer, adminServer} {
+		server := server
+		group.Go(func() error {
+			if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		})
+	}
+
+	group.Go(func() error {
+		<-groupCtx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownGracePeriod)
+		defer cancel()
+
+		logger.Info("shutting down route service proxy")
+		proxyErr := proxyServer.Shutdown(shutdownCtx)
+		adminErr := adminServer.Shutdown(shutdownCtx)
+		if proxyErr != nil {
+			return proxyErr
+		}
+		return adminErr
+	})
+
+	return group.Wait()
+}
+
+// newAdminHandler serves the health and metrics endpoints.
+func newAdminHandler(registry *prometheus.Registry) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(healthzPath, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintln(w, "ok")
+	})
+	mux.Handle(metricsPath, newMetricsHandler(registry))
+	return mux
 }
 
 // updateURLParts modifies a URL in place and returns an error if parsing the updated URL fails.
@@ -69,23 +250,3 @@ func updateURLParts(existingURL *url.URL) error {
 	*existingURL = *updatedURL
 	return nil
 }
-
-// newProxy forwards the original request to the route service URL.
-// The request is modified with an added X-CF-Forwarded-URL header.
-func newProxy(url *url.URL) http.Handler {
-	reverseProxy := &httputil.ReverseProxy{
-		Director: func(req *http.Request) {
-			// Set X-CF-Forwarded-URL header to original route destination URL.
-			// This header is set so that the route service can forward the request to the original destination.
-			forwardedURL := *req.URL
-			forwardedURL.Scheme = "http"
-			forwardedURL.Host = req.Host
-			req.Header[resources.CfForwardedURLHeader] = []string{forwardedURL.String()}
-
-			// Direct the request to the route service.
-			req.URL = url
-			req.Host = url.Hostname()
-		},
-	}
-	return reverseProxy
-}
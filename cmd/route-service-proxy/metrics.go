@@ -0,0 +1,126 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+)
+
+// proxyMetrics holds the Prometheus collectors for proxied requests.
+type proxyMetrics struct {
+	requests *prometheus.CounterVec
+	latency  *prometheus.HistogramVec
+}
+
+func newProxyMetrics(registerer prometheus.Registerer) *proxyMetrics {
+	m := &proxyMetrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "kf",
+			Subsystem: "route_service_proxy",
+			Name:      "requests_total",
+			Help:      "Number of requests proxied to the route service partitioned by status code and method.",
+		}, []string{"code", "method"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "kf",
+			Subsystem: "route_service_proxy",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of requests proxied to the route service partitioned by status code and method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"code", "method"}),
+	}
+
+	registerer.MustRegister(m.requests, m.latency)
+	return m
+}
+
+// instrument records request counts and latencies for the wrapped handler.
+func (m *proxyMetrics) instrument(next http.Handler) http.Handler {
+	return promhttp.InstrumentHandlerCounter(m.requests,
+		promhttp.InstrumentHandlerDuration(m.latency, next),
+	)
+}
+
+// newMetricsHandler exposes the collectors in the registry.
+func newMetricsHandler(registry *prometheus.Registry) http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// statusRecorder captures the status code and size of a response.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += n
+	return n, err
+}
+
+// Flush allows streaming responses to be passed through the recorder.
+func (r *statusRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// withAccessLog writes a structured log line for every request.
+func withAccessLog(logger *zap.Logger, next http.Handler) http.Handler {
+	accessLogger := logger.Named("access")
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w}
+
+		// Capture request fields before the proxy rewrites the URL.
+		host := req.Host
+		path := req.URL.RequestURI()
+		scheme := forwardedScheme(req)
+
+		next.ServeHTTP(recorder, req)
+
+		if recorder.status == 0 {
+			recorder.status = http.StatusOK
+		}
+
+		accessLogger.Info("request",
+			zap.String("method", req.Method),
+			zap.String("scheme", scheme),
+			zap.String("host", host),
+			zap.String("path", path),
+			zap.Int("status", recorder.status),
+			zap.Int("bytes", recorder.bytes),
+			zap.Duration("duration", time.Since(start)),
+			zap.String("remoteAddr", req.RemoteAddr),
+			zap.String("userAgent", req.UserAgent()),
+			zap.String("requestID", req.Header.Get("X-Request-Id")),
+		)
+	})
+}
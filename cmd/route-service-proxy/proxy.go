@@ -0,0 +1,131 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/kf/v2/pkg/reconciler/route/resources"
+	"go.uber.org/zap"
+)
+
+// newTransport creates the transport used to reach the route service with
+// the TLS and idle connection settings from the config.
+func newTransport(cfg *proxyConfig) (*http.Transport, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// Route services may intentionally use self-signed certificates in
+		// development, so operators can turn verification off explicitly.
+		InsecureSkipVerify: cfg.InsecureSkipVerify, // #nosec G402
+	}
+
+	if cfg.CAFile != "" || cfg.CABundle != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+
+		if cfg.CAFile != "" {
+			pem, err := os.ReadFile(cfg.CAFile)
+			if err != nil {
+				return nil, fmt.Errorf("couldn't read CA file: %v", err)
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no certificates found in CA file %q", cfg.CAFile)
+			}
+		}
+
+		if cfg.CABundle != "" && !pool.AppendCertsFromPEM([]byte(cfg.CABundle)) {
+			return nil, fmt.Errorf("no certificates found in %s", caBundleEnv)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	transport.IdleConnTimeout = cfg.IdleTimeout
+	return transport, nil
+}
+
+// forwardedScheme returns the scheme the client originally used to reach the
+// route. The ingress gateway terminates TLS so the scheme is recovered from
+// X-Forwarded-Proto, falling back to the connection the proxy received.
+func forwardedScheme(req *http.Request) string {
+	if proto := req.Header.Get(forwardedProtoHeaderName); proto != "" {
+		// Multiple proxies may append values, the first is the client's.
+		proto = strings.TrimSpace(strings.SplitN(proto, ",", 2)[0])
+		if proto == "http" || proto == "https" {
+			return proto
+		}
+	}
+
+	if req.TLS != nil {
+		return "https"
+	}
+	return "http"
+}
+
+// newProxy forwards the original request to the route service URL.
+// The request is modified with an added X-CF-Forwarded-URL header.
+func newProxy(url *url.URL, transport http.RoundTripper, requestTimeout time.Duration, logger *zap.Logger) http.Handler {
+	reverseProxy := &httputil.ReverseProxy{
+		Transport: transport,
+		Director: func(req *http.Request) {
+			// Set X-CF-Forwarded-URL header to original route destination URL.
+			// This header is set so that the route service can forward the request to the original destination.
+			forwardedURL := *req.URL
+			forwardedURL.Scheme = forwardedScheme(req)
+			forwardedURL.Host = req.Host
+			req.Header[resources.CfForwardedURLHeader] = []string{forwardedURL.String()}
+
+			// Direct the request to the route service.
+			req.URL = url
+			req.Host = url.Hostname()
+		},
+		ErrorHandler: func(w http.ResponseWriter, req *http.Request, err error) {
+			status := http.StatusBadGateway
+			if errors.Is(err, context.DeadlineExceeded) {
+				status = http.StatusGatewayTimeout
+			}
+
+			logger.Warn("route service request failed",
+				zap.String("routeServiceURL", url.String()),
+				zap.Int("status", status),
+				zap.Error(err),
+			)
+			w.WriteHeader(status)
+		},
+	}
+
+	if requestTimeout <= 0 {
+		return reverseProxy
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx, cancel := context.WithTimeout(req.Context(), requestTimeout)
+		defer cancel()
+		reverseProxy.ServeHTTP(w, req.WithContext(ctx))
+	})
+}
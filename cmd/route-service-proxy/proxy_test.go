@@ -0,0 +1,221 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"crypto/tls"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	"github.com/google/kf/v2/pkg/reconciler/route/resources"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		env     map[string]string
+		wantErr error
+		assert  func(t *testing.T, cfg *proxyConfig)
+	}{
+		"missing URL": {
+			env:     map[string]string{},
+			wantErr: errors.New("route service URL is not set"),
+		},
+		"defaults": {
+			env: map[string]string{routeServiceURLEnv: "auth.example.com/path"},
+			assert: func(t *testing.T, cfg *proxyConfig) {
+				testutil.AssertEqual(t, "url", "http://auth.example.com/path", cfg.RouteServiceURL.String())
+				testutil.AssertEqual(t, "port", defaultPort, cfg.Port)
+				testutil.AssertEqual(t, "adminPort", defaultAdminPort, cfg.AdminPort)
+				testutil.AssertEqual(t, "requestTimeout", defaultRequestTimeout, cfg.RequestTimeout)
+				testutil.AssertEqual(t, "idleTimeout", defaultIdleTimeout, cfg.IdleTimeout)
+				testutil.AssertFalse(t, "insecureSkipVerify", cfg.InsecureSkipVerify)
+			},
+		},
+		"overrides": {
+			env: map[string]string{
+				routeServiceURLEnv:    "https://auth.example.com",
+				portEnv:               "9000",
+				adminPortEnv:          "9001",
+				insecureSkipVerifyEnv: "true",
+				requestTimeoutEnv:     "5s",
+				idleTimeoutEnv:        "0s",
+			},
+			assert: func(t *testing.T, cfg *proxyConfig) {
+				testutil.AssertEqual(t, "port", "9000", cfg.Port)
+				testutil.AssertEqual(t, "adminPort", "9001", cfg.AdminPort)
+				testutil.AssertEqual(t, "requestTimeout", 5*time.Second, cfg.RequestTimeout)
+				testutil.AssertEqual(t, "idleTimeout", time.Duration(0), cfg.IdleTimeout)
+				testutil.AssertTrue(t, "insecureSkipVerify", cfg.InsecureSkipVerify)
+			},
+		},
+		"negative timeout": {
+			env: map[string]string{
+				routeServiceURLEnv: "https://auth.example.com",
+				requestTimeoutEnv:  "-1s",
+			},
+			wantErr: errors.New("couldn't parse REQUEST_TIMEOUT: timeout must not be negative"),
+		},
+		"missing CA file": {
+			env: map[string]string{
+				routeServiceURLEnv: "https://auth.example.com",
+				caFileEnv:          "/does/not/exist",
+			},
+			assert: func(t *testing.T, cfg *proxyConfig) {
+				_, err := newTransport(cfg)
+				testutil.AssertErrorContainsAll(t, err, []string{"couldn't read CA file"})
+			},
+		},
+		"invalid CA bundle": {
+			env: map[string]string{
+				routeServiceURLEnv: "https://auth.example.com",
+				caBundleEnv:        "not a certificate",
+			},
+			assert: func(t *testing.T, cfg *proxyConfig) {
+				testutil.AssertEqual(t, "caBundle", "not a certificate", cfg.CABundle)
+				_, err := newTransport(cfg)
+				testutil.AssertErrorsEqual(t, errors.New("no certificates found in ROUTE_SERVICE_CA_BUNDLE"), err)
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := loadConfig(func(key string) string {
+				return tc.env[key]
+			})
+			testutil.AssertErrorsEqual(t, tc.wantErr, err)
+			if tc.assert != nil {
+				tc.assert(t, cfg)
+			}
+		})
+	}
+}
+
+func TestForwardedScheme(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		header string
+		tls    bool
+		want   string
+	}{
+		"no header":        {want: "http"},
+		"no header TLS":    {tls: true, want: "https"},
+		"https header":     {header: "https", want: "https"},
+		"multiple values":  {header: "https, http", want: "https"},
+		"unknown protocol": {header: "gopher", want: "http"},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.header != "" {
+				req.Header.Set(forwardedProtoHeaderName, tc.header)
+			}
+			if tc.tls {
+				req.TLS = &tls.ConnectionState{}
+			}
+
+			testutil.AssertEqual(t, "scheme", tc.want, forwardedScheme(req))
+		})
+	}
+}
+
+func TestNewProxy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("forwards original URL", func(t *testing.T) {
+		t.Parallel()
+
+		var gotForwardedURL string
+		routeService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotForwardedURL = r.Header.Get(resources.CfForwardedURLHeader)
+			w.WriteHeader(http.StatusTeapot)
+		}))
+		defer routeService.Close()
+
+		rsURL, err := url.Parse(routeService.URL)
+		testutil.AssertNil(t, "err", err)
+
+		registry := prometheus.NewRegistry()
+		handler := withAccessLog(zap.NewNop(), newProxyMetrics(registry).instrument(
+			newProxy(rsURL, http.DefaultTransport, time.Minute, zap.NewNop()),
+		))
+
+		req := httptest.NewRequest(http.MethodGet, "http://myapp.example.com/some/path?q=1", nil)
+		req.Header.Set(forwardedProtoHeaderName, "https")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		testutil.AssertEqual(t, "status", http.StatusTeapot, rec.Code)
+		testutil.AssertEqual(t, "forwarded URL", "https://myapp.example.com/some/path?q=1", gotForwardedURL)
+
+		metricsRec := httptest.NewRecorder()
+		newAdminHandler(registry).ServeHTTP(metricsRec, httptest.NewRequest(http.MethodGet, metricsPath, nil))
+		testutil.AssertContainsAll(t, metricsRec.Body.String(), []string{
+			`kf_route_service_proxy_requests_total{code="418",method="get"} 1`,
+			`kf_route_service_proxy_request_duration_seconds_count{code="418",method="get"} 1`,
+		})
+	})
+
+	t.Run("times out", func(t *testing.T) {
+		t.Parallel()
+
+		done := make(chan struct{})
+		routeService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-done:
+			}
+		}))
+		defer routeService.Close()
+		defer close(done)
+
+		rsURL, err := url.Parse(routeService.URL)
+		testutil.AssertNil(t, "err", err)
+
+		rec := httptest.NewRecorder()
+		newProxy(rsURL, http.DefaultTransport, 10*time.Millisecond, zap.NewNop()).
+			ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "http://myapp.example.com/", nil))
+
+		testutil.AssertEqual(t, "status", http.StatusGatewayTimeout, rec.Code)
+	})
+}
+
+func TestAdminHandler_healthz(t *testing.T) {
+	t.Parallel()
+
+	rec := httptest.NewRecorder()
+	newAdminHandler(prometheus.NewRegistry()).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, healthzPath, nil))
+
+	testutil.AssertEqual(t, "status", http.StatusOK, rec.Code)
+	testutil.AssertEqual(t, "body", "ok", strings.TrimSpace(rec.Body.String()))
+}
@@ -23,6 +23,7 @@ import (
 	appinformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/app"
 	serviceinstanceinformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/serviceinstance"
 	serviceinstancebindinginformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/serviceinstancebinding"
+	serviceinstanceshareinformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/serviceinstanceshare"
 	spaceinformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/space"
 	buildconfig "github.com/google/kf/v2/pkg/reconciler/build/config"
 	v1 "k8s.io/api/admission/v1"
@@ -51,9 +52,12 @@ var types = map[schema.GroupVersionKind]resourcesemantics.GenericCRD{
 	v1alpha1.SchemeGroupVersion.WithKind("ServiceBroker"):          &v1alpha1.ServiceBroker{},
 	v1alpha1.SchemeGroupVersion.WithKind("ServiceInstance"):        &v1alpha1.ServiceInstance{},
 	v1alpha1.SchemeGroupVersion.WithKind("ServiceInstanceBinding"): &v1alpha1.ServiceInstanceBinding{},
+	v1alpha1.SchemeGroupVersion.WithKind("ServiceInstanceShare"):   &v1alpha1.ServiceInstanceShare{},
 	autoscaling.SchemeGroupVersion.WithKind("Scale"):               &v1alpha1.Scale{},
 	v1alpha1.SchemeGroupVersion.WithKind("Task"):                   &v1alpha1.Task{},
 	v1alpha1.SchemeGroupVersion.WithKind("TaskSchedule"):           &v1alpha1.TaskSchedule{},
+	v1alpha1.SchemeGroupVersion.WithKind("Workflow"):               &v1alpha1.Workflow{},
+	v1alpha1.SchemeGroupVersion.WithKind("WorkflowRun"):            &v1alpha1.WorkflowRun{},
 	v1alpha1.SchemeGroupVersion.WithKind("SourcePackage"):          &v1alpha1.SourcePackage{},
 }
 
@@ -62,6 +66,7 @@ var callbacks = map[schema.GroupVersionKind]validation.Callback{
 	v1alpha1.SchemeGroupVersion.WithKind("ServiceBroker"):          validation.NewCallback(kfvalidation.ServiceBrokerValidationCallback, v1.Delete),
 	v1alpha1.SchemeGroupVersion.WithKind("ServiceInstance"):        validation.NewCallback(kfvalidation.ServiceInstanceValidationCallback, v1.Delete),
 	v1alpha1.SchemeGroupVersion.WithKind("ServiceInstanceBinding"): validation.NewCallback(kfvalidation.ServiceInstanceBindingValidationCallback, v1.Create, v1.Update),
+	v1alpha1.SchemeGroupVersion.WithKind("ServiceInstanceShare"):   validation.NewCallback(kfvalidation.ServiceInstanceShareValidationCallback, v1.Create, v1.Delete),
 	v1alpha1.SchemeGroupVersion.WithKind("App"):                    validation.NewCallback(kfvalidation.AppValidationCallback, v1.Create, v1.Update),
 	v1alpha1.SchemeGroupVersion.WithKind("Route"):                  validation.NewCallback(kfvalidation.RouteValidationCallback, v1.Create),
 }
@@ -104,6 +109,7 @@ func newValidationAdmissionController(controllerCtx context.Context, cmw configm
 	spaceInformer := spaceinformer.Get(controllerCtx)
 	appInformer := appinformer.Get(controllerCtx)
 	serviceInstanceInformer := serviceinstanceinformer.Get(controllerCtx)
+	serviceInstanceShareInformer := serviceinstanceshareinformer.Get(controllerCtx)
 	return validation.NewAdmissionController(controllerCtx,
 
 		// Name of the resource webhook.
@@ -123,6 +129,7 @@ func newValidationAdmissionController(controllerCtx context.Context, cmw configm
 			ctx = context.WithValue(ctx, kfvalidation.SpaceInformerKey{}, spaceInformer)
 			ctx = context.WithValue(ctx, kfvalidation.AppInformerKey{}, appInformer)
 			ctx = context.WithValue(ctx, kfvalidation.ServiceInstanceInformerKey{}, serviceInstanceInformer)
+			ctx = context.WithValue(ctx, kfvalidation.ServiceInstanceShareInformerKey{}, serviceInstanceShareInformer)
 			return store.ToContext(ctx)
 		},
 
@@ -21,13 +21,15 @@ import (
 
 	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
 	kfinformer "github.com/google/kf/v2/pkg/client/kf/informers/externalversions/kf/v1alpha1"
+	kflisters "github.com/google/kf/v2/pkg/client/kf/listers/kf/v1alpha1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
-// ServiceInstanceValidationCallback validates that an existing ServiceInstance is not part of a binding.
+// ServiceInstanceValidationCallback validates that an existing ServiceInstance is not part of a binding
+// and isn't shared with other Spaces.
 // It is intended to be used as a callback on a delete request.
 func ServiceInstanceValidationCallback(ctx context.Context, unstructured *unstructured.Unstructured) error {
 	serviceinstance := &v1alpha1.ServiceInstance{}
@@ -45,7 +47,7 @@ func ServiceInstanceValidationCallback(ctx context.Context, unstructured *unstru
 	// matchedBindings holds the names of the apps the service instance is bound to.
 	matchedBindings := sets.NewString()
 	for _, binding := range bindings {
-		if binding.Spec.InstanceRef.Name == serviceinstance.Name {
+		if binding.InstanceNamespace() == serviceinstance.Namespace && binding.Spec.InstanceRef.Name == serviceinstance.Name {
 			matchedBindings.Insert(binding.Spec.BindingType.App.Name)
 		}
 	}
@@ -55,5 +57,27 @@ func ServiceInstanceValidationCallback(ctx context.Context, unstructured *unstru
 			serviceinstance.Name, strings.Join(matchedBindings.List(), ", "))
 	}
 
+	serviceInstanceShareInformer := ctx.Value(ServiceInstanceShareInformerKey{}).(kfinformer.ServiceInstanceShareInformer)
+	return validateServiceInstanceNotShared(serviceInstanceShareInformer.Lister(), serviceinstance)
+}
+
+func validateServiceInstanceNotShared(serviceInstanceShareLister kflisters.ServiceInstanceShareLister, serviceinstance *v1alpha1.ServiceInstance) error {
+	shares, err := serviceInstanceShareLister.ServiceInstanceShares(serviceinstance.Namespace).List(labels.Everything())
+	if err != nil {
+		return err
+	}
+
+	targetSpaces := sets.NewString()
+	for _, share := range shares {
+		if share.Spec.InstanceRef.Name == serviceinstance.Name {
+			targetSpaces.Insert(share.Spec.TargetSpace)
+		}
+	}
+
+	if len(targetSpaces) > 0 {
+		return fmt.Errorf("ServiceInstance %q cannot be deleted while it is shared. The service is shared with the Space(s): %s",
+			serviceinstance.Name, strings.Join(targetSpaces.List(), ", "))
+	}
+
 	return nil
 }
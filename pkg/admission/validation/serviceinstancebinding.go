@@ -23,6 +23,7 @@ import (
 	kflisters "github.com/google/kf/v2/pkg/client/kf/listers/kf/v1alpha1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -52,6 +53,13 @@ func ServiceInstanceBindingValidationCallback(ctx context.Context, unstructured
 	if err := validateServiceInstanceExists(serviceInstanceLister, serviceinstancebinding); err != nil {
 		return err
 	}
+
+	if serviceinstancebinding.IsSharedInstanceBinding() {
+		serviceInstanceShareInformer := ctx.Value(ServiceInstanceShareInformerKey{}).(kfinformer.ServiceInstanceShareInformer)
+		if err := validateServiceInstanceShared(serviceInstanceShareInformer.Lister(), serviceinstancebinding); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -64,9 +72,26 @@ func validateAppExists(appLister kflisters.AppLister, serviceinstancebinding *v1
 }
 
 func validateServiceInstanceExists(serviceInstanceLister kflisters.ServiceInstanceLister, serviceinstancebinding *v1alpha1.ServiceInstanceBinding) error {
-	_, err := serviceInstanceLister.ServiceInstances(serviceinstancebinding.Namespace).Get(serviceinstancebinding.Spec.InstanceRef.Name)
+	_, err := serviceInstanceLister.ServiceInstances(serviceinstancebinding.InstanceNamespace()).Get(serviceinstancebinding.Spec.InstanceRef.Name)
 	if errors.IsNotFound(err) {
 		return fmt.Errorf("ServiceInstance %q does not exist. The binding cannot be created", serviceinstancebinding.Spec.InstanceRef.Name)
 	}
 	return err
 }
+
+func validateServiceInstanceShared(serviceInstanceShareLister kflisters.ServiceInstanceShareLister, serviceinstancebinding *v1alpha1.ServiceInstanceBinding) error {
+	instanceName := serviceinstancebinding.Spec.InstanceRef.Name
+	instanceSpace := serviceinstancebinding.InstanceNamespace()
+
+	shares, err := serviceInstanceShareLister.
+		ServiceInstanceShares(instanceSpace).
+		List(labels.SelectorFromSet(v1alpha1.ServiceInstanceShareLabels(instanceName, serviceinstancebinding.Namespace)))
+	if err != nil {
+		return err
+	}
+
+	if len(shares) == 0 {
+		return fmt.Errorf("ServiceInstance %q in Space %q isn't shared with Space %q. The binding cannot be created", instanceName, instanceSpace, serviceinstancebinding.Namespace)
+	}
+	return nil
+}
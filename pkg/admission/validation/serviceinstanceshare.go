@@ -0,0 +1,98 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kfvalidation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	kfinformer "github.com/google/kf/v2/pkg/client/kf/informers/externalversions/kf/v1alpha1"
+	kflisters "github.com/google/kf/v2/pkg/client/kf/listers/kf/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/apis"
+)
+
+// ServiceInstanceShareValidationCallback validates that a shared
+// ServiceInstance and the Space it's shared with exist when the share is
+// created, and that no Apps in the Space are bound to the ServiceInstance when
+// the share is deleted.
+func ServiceInstanceShareValidationCallback(ctx context.Context, unstructured *unstructured.Unstructured) error {
+	share := &v1alpha1.ServiceInstanceShare{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstructured.Object, share); err != nil {
+		return err
+	}
+
+	if apis.IsInDelete(ctx) {
+		serviceBindingInformer := ctx.Value(ServiceInstanceBindingInformerKey{}).(kfinformer.ServiceInstanceBindingInformer)
+		return validateShareUnused(serviceBindingInformer.Lister(), share)
+	}
+
+	serviceInstanceInformer := ctx.Value(ServiceInstanceInformerKey{}).(kfinformer.ServiceInstanceInformer)
+	if err := validateServiceInstanceShareable(serviceInstanceInformer.Lister(), share); err != nil {
+		return err
+	}
+
+	spaceInformer := ctx.Value(SpaceInformerKey{}).(kfinformer.SpaceInformer)
+	_, err := spaceInformer.Lister().Get(share.Spec.TargetSpace)
+	if errors.IsNotFound(err) {
+		return fmt.Errorf("Space %q does not exist. The ServiceInstance cannot be shared", share.Spec.TargetSpace)
+	}
+	return err
+}
+
+func validateServiceInstanceShareable(serviceInstanceLister kflisters.ServiceInstanceLister, share *v1alpha1.ServiceInstanceShare) error {
+	instance, err := serviceInstanceLister.ServiceInstances(share.Namespace).Get(share.Spec.InstanceRef.Name)
+	switch {
+	case errors.IsNotFound(err):
+		return fmt.Errorf("ServiceInstance %q does not exist. The ServiceInstance cannot be shared", share.Spec.InstanceRef.Name)
+	case err != nil:
+		return err
+	case instance.IsKfBrokered(), instance.IsUserProvided() && !instance.IsRouteService():
+		return nil
+	default:
+		return fmt.Errorf("ServiceInstance %q can't be shared, only brokered and user-provided services can be shared", instance.Name)
+	}
+}
+
+func validateShareUnused(serviceInstanceBindingLister kflisters.ServiceInstanceBindingLister, share *v1alpha1.ServiceInstanceShare) error {
+	bindings, err := serviceInstanceBindingLister.ServiceInstanceBindings(share.Spec.TargetSpace).List(labels.Everything())
+	if err != nil {
+		return err
+	}
+
+	// matchedApps holds the names of the Apps in the target Space that are
+	// bound to the shared ServiceInstance.
+	matchedApps := sets.NewString()
+	for _, binding := range bindings {
+		if binding.InstanceNamespace() == share.Namespace &&
+			binding.Spec.InstanceRef.Name == share.Spec.InstanceRef.Name &&
+			binding.IsAppBinding() {
+			matchedApps.Insert(binding.Spec.App.Name)
+		}
+	}
+
+	if len(matchedApps) > 0 {
+		return fmt.Errorf("ServiceInstance %q cannot be unshared from Space %q while Apps are bound to it: %s",
+			share.Spec.InstanceRef.Name, share.Spec.TargetSpace, strings.Join(matchedApps.List(), ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kfvalidation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	kffake "github.com/google/kf/v2/pkg/client/kf/clientset/versioned/fake"
+	"github.com/google/kf/v2/pkg/client/kf/informers/externalversions"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+)
+
+func testShare(instanceName string) *v1alpha1.ServiceInstanceShare {
+	share := &v1alpha1.ServiceInstanceShare{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      v1alpha1.MakeServiceInstanceShareName(instanceName, "target-ns"),
+			Namespace: "origin-ns",
+		},
+		Spec: v1alpha1.ServiceInstanceShareSpec{
+			InstanceRef: corev1.LocalObjectReference{Name: instanceName},
+			TargetSpace: "target-ns",
+		},
+	}
+	share.SetDefaults(context.Background())
+	return share
+}
+
+func testSharedBinding(instanceName string) *v1alpha1.ServiceInstanceBinding {
+	return &v1alpha1.ServiceInstanceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "binding",
+			Namespace: "target-ns",
+		},
+		Spec: v1alpha1.ServiceInstanceBindingSpec{
+			BindingType: v1alpha1.BindingType{
+				App: &v1alpha1.AppRef{Name: "my-app"},
+			},
+			InstanceRef:   corev1.LocalObjectReference{Name: instanceName},
+			InstanceSpace: "origin-ns",
+		},
+	}
+}
+
+func startInformers(ctx context.Context, t *testing.T, objs ...runtime.Object) externalversions.SharedInformerFactory {
+	t.Helper()
+
+	informers := externalversions.NewSharedInformerFactory(kffake.NewSimpleClientset(objs...), 0)
+	synced := []cache.InformerSynced{
+		informers.Kf().V1alpha1().ServiceInstances().Informer().HasSynced,
+		informers.Kf().V1alpha1().ServiceInstanceBindings().Informer().HasSynced,
+		informers.Kf().V1alpha1().ServiceInstanceShares().Informer().HasSynced,
+	}
+
+	informers.Start(ctx.Done())
+	cache.WaitForCacheSync(ctx.Done(), synced...)
+	return informers
+}
+
+func TestValidateServiceInstanceShareable(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	instance := func(name string, spec v1alpha1.ServiceInstanceSpec) *v1alpha1.ServiceInstance {
+		return &v1alpha1.ServiceInstance{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "origin-ns"},
+			Spec:       spec,
+		}
+	}
+
+	informers := startInformers(ctx, t,
+		instance("brokered", v1alpha1.ServiceInstanceSpec{
+			ServiceType: v1alpha1.ServiceType{OSB: &v1alpha1.OSBInstance{}},
+		}),
+		instance("user-provided", v1alpha1.ServiceInstanceSpec{
+			ServiceType: v1alpha1.ServiceType{UPS: &v1alpha1.UPSInstance{}},
+		}),
+		instance("volume", v1alpha1.ServiceInstanceSpec{
+			ServiceType: v1alpha1.ServiceType{Volume: &v1alpha1.OSBInstance{}},
+		}),
+	)
+	lister := informers.Kf().V1alpha1().ServiceInstances().Lister()
+
+	cases := map[string]struct {
+		share *v1alpha1.ServiceInstanceShare
+		want  error
+	}{
+		"brokered": {
+			share: testShare("brokered"),
+		},
+		"user-provided": {
+			share: testShare("user-provided"),
+		},
+		"volume": {
+			share: testShare("volume"),
+			want:  errors.New("ServiceInstance \"volume\" can't be shared, only brokered and user-provided services can be shared"),
+		},
+		"missing": {
+			share: testShare("missing"),
+			want:  errors.New("ServiceInstance \"missing\" does not exist. The ServiceInstance cannot be shared"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got := validateServiceInstanceShareable(lister, tc.share)
+			testutil.AssertErrorsEqual(t, tc.want, got)
+		})
+	}
+}
+
+func TestValidateShareUnused(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	localBinding := testSharedBinding("used")
+	localBinding.Name = "local-binding"
+	localBinding.Spec.InstanceSpace = ""
+
+	informers := startInformers(ctx, t, testSharedBinding("used"), localBinding)
+	lister := informers.Kf().V1alpha1().ServiceInstanceBindings().Lister()
+
+	cases := map[string]struct {
+		share *v1alpha1.ServiceInstanceShare
+		want  error
+	}{
+		"bound": {
+			share: testShare("used"),
+			want:  errors.New("ServiceInstance \"used\" cannot be unshared from Space \"target-ns\" while Apps are bound to it: my-app"),
+		},
+		"not bound": {
+			share: testShare("unused"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got := validateShareUnused(lister, tc.share)
+			testutil.AssertErrorsEqual(t, tc.want, got)
+		})
+	}
+}
+
+func TestValidateServiceInstanceShared(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	informers := startInformers(ctx, t, testShare("shared"))
+	lister := informers.Kf().V1alpha1().ServiceInstanceShares().Lister()
+
+	cases := map[string]struct {
+		binding *v1alpha1.ServiceInstanceBinding
+		want    error
+	}{
+		"shared": {
+			binding: testSharedBinding("shared"),
+		},
+		"not shared": {
+			binding: testSharedBinding("private"),
+			want:    errors.New("ServiceInstance \"private\" in Space \"origin-ns\" isn't shared with Space \"target-ns\". The binding cannot be created"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got := validateServiceInstanceShared(lister, tc.binding)
+			testutil.AssertErrorsEqual(t, tc.want, got)
+		})
+	}
+}
@@ -25,3 +25,6 @@ type AppInformerKey struct{}
 
 // ServiceInstanceInformerKey is used for associating the ServiceInstanceInformer inside the context.Context.
 type ServiceInstanceInformerKey struct{}
+
+// ServiceInstanceShareInformerKey is used for associating the ServiceInstanceShareInformer inside the context.Context.
+type ServiceInstanceShareInformerKey struct{}
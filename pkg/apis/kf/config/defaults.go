@@ -15,6 +15,8 @@
 package config
 
 import (
+	"fmt"
+	"net"
 	"reflect"
 
 	corev1 "k8s.io/api/core/v1"
@@ -32,6 +34,10 @@ const (
 	spaceStacksV3Key                   = "spaceStacksV3"
 	spaceDefaultToV3StackKey           = "spaceDefaultToV3Stack"
 	routeServiceProxyImageKey          = "routeServiceProxyImage"
+	routeServiceProxyCABundleKey       = "routeServiceProxyCABundle"
+	routeServiceProxyInsecureKey       = "routeServiceProxyInsecureSkipVerify"
+	routeServiceProxyReqTimeoutKey     = "routeServiceProxyRequestTimeout"
+	routeServiceProxyIdleTimeoutKey    = "routeServiceProxyIdleTimeout"
 	featureFlagsKey                    = "featureFlags"
 	buildDisableIstioSidecarKey        = "buildDisableIstioSidecar"
 	buildPodResourcesKey               = "buildPodResources"
@@ -49,8 +55,12 @@ const (
 	routeTrackVirtualServiceKey        = "routeTrackVirtualService"
 	routeDisableRetriesKey             = "routeDisableRetries"
 	routeHostIgnoringPortKey           = "routeHostIgnoringPort"
+	routeCanaryPrometheusURLKey        = "routeCanaryPrometheusURL"
+	routeIstioVersionKey               = "routeIstioVersion"
+	routeRateLimitTrustedHopsKey       = "routeRateLimitTrustedHops"
 	taskDefaultTimeoutMinutesKey       = "taskDefaultTimeoutMinutes"
 	taskDisableVolumeMountsKey         = "taskDisableVolumeMounts"
+	taskNotificationAllowedCIDRsKey    = "taskNotificationAllowedCIDRs"
 
 	// Images used for build purposes
 
@@ -94,6 +104,25 @@ type DefaultsConfig struct {
 	// RouteServiceProxyImage is the image URL for the Kf route service proxy deployment.
 	RouteServiceProxyImage string `json:"routeServiceProxyImage,omitempty"`
 
+	// RouteServiceProxyCABundle is a PEM bundle the route service proxy
+	// trusts in addition to the system roots when connecting to route
+	// services over TLS.
+	RouteServiceProxyCABundle string `json:"routeServiceProxyCABundle,omitempty"`
+
+	// RouteServiceProxyInsecureSkipVerify disables TLS verification of route
+	// services in the route service proxy.
+	RouteServiceProxyInsecureSkipVerify bool `json:"routeServiceProxyInsecureSkipVerify,omitempty"`
+
+	// RouteServiceProxyRequestTimeout is the maximum time a request to a
+	// route service may take as a Go duration, e.g. 60s. If unset, the
+	// proxy's default is used.
+	RouteServiceProxyRequestTimeout string `json:"routeServiceProxyRequestTimeout,omitempty"`
+
+	// RouteServiceProxyIdleTimeout is the maximum time keep-alive connections
+	// of the route service proxy are left idle as a Go duration, e.g. 90s. If
+	// unset, the proxy's default is used.
+	RouteServiceProxyIdleTimeout string `json:"routeServiceProxyIdleTimeout,omitempty"`
+
 	BuildKanikoExecutorImage string `json:"buildKanikoExecutorImage,omitempty"`
 	BuildInfoImage           string `json:"buildInfoImage,omitempty"`
 	BuildTokenDownloadImage  string `json:"buildTokenDownloadImage,omitempty"`
@@ -175,6 +204,22 @@ type DefaultsConfig struct {
 	// e.g By default example.com:443 does not match with a route configured with a Host of example.com.
 	RouteHostIgnoringPort bool `json:"routeHostIgnoringPort,omitempty"`
 
+	// RouteCanaryPrometheusURL is the URL of a Prometheus server that
+	// collects Istio metrics. If set, canary rollouts check the error rate of
+	// the canary App before increasing its traffic.
+	RouteCanaryPrometheusURL string `json:"routeCanaryPrometheusURL,omitempty"`
+
+	// RouteIstioVersion is the version of Istio running the ingress gateways
+	// e.g. 1.26.2. Route rate limits need Envoy features only available in
+	// newer versions of Istio and aren't enforced if it's unset or too old.
+	RouteIstioVersion string `json:"routeIstioVersion,omitempty"`
+
+	// RouteRateLimitTrustedHops is the number of proxies, like load
+	// balancers, in front of the ingress gateways that append to the
+	// X-Forwarded-For header. Route rate limits identify clients by the
+	// address the outermost trusted proxy received the request from.
+	RouteRateLimitTrustedHops *int32 `json:"routeRateLimitTrustedHops,omitempty"`
+
 	// TaskDefaultTimeoutMinutes sets the cluster-wide timeout for tasks.
 	// If the value is null, the timeout is inherited from Tekton.
 	// If the value is <= 0, then an infinite timeout is set.
@@ -184,6 +229,12 @@ type DefaultsConfig struct {
 	// Mounting NFS volumes requires FUSE which grants Task Pods additional
 	// system privileges.
 	TaskDisableVolumeMounts bool `json:"taskDisableVolumeMounts,omitempty"`
+
+	// TaskNotificationAllowedCIDRs are private networks, e.g. 10.1.0.0/16,
+	// Task notifications may be sent to. Notifications to other private
+	// addresses are rejected so they can't be used to reach in-cluster
+	// endpoints.
+	TaskNotificationAllowedCIDRs []string `json:"taskNotificationAllowedCIDRs,omitempty"`
 }
 
 // BuiltinDefaultsConfig creates a defaults configuration with default values.
@@ -266,15 +317,20 @@ func (defaultsConfig *DefaultsConfig) PatchConfigMap(cm *corev1.ConfigMap) error
 // getStringValues returns a map of the key/value pairs on a DefaultsConfig that are string values.
 func (defaultsConfig *DefaultsConfig) getStringValues() map[string]*string {
 	return map[string]*string{
-		spaceContainerRegistryKey:     &defaultsConfig.SpaceContainerRegistry,
-		routeServiceProxyImageKey:     &defaultsConfig.RouteServiceProxyImage,
-		buildKanikoExecutorImageKey:   &defaultsConfig.BuildKanikoExecutorImage,
-		buildInfoImageKey:             &defaultsConfig.BuildInfoImage,
-		buildTokenDownloadImageKey:    &defaultsConfig.BuildTokenDownloadImage,
-		buildHelpersImageKey:          &defaultsConfig.BuildHelpersImage,
-		buildpacksV2LifecycleImageKey: &defaultsConfig.BuildpacksV2LifecycleImage,
-		buildTimeoutKey:               &defaultsConfig.BuildTimeout,
-		nopImageKey:                   &defaultsConfig.NopImage,
+		spaceContainerRegistryKey:       &defaultsConfig.SpaceContainerRegistry,
+		routeServiceProxyImageKey:       &defaultsConfig.RouteServiceProxyImage,
+		routeServiceProxyCABundleKey:    &defaultsConfig.RouteServiceProxyCABundle,
+		routeServiceProxyReqTimeoutKey:  &defaultsConfig.RouteServiceProxyRequestTimeout,
+		routeServiceProxyIdleTimeoutKey: &defaultsConfig.RouteServiceProxyIdleTimeout,
+		buildKanikoExecutorImageKey:     &defaultsConfig.BuildKanikoExecutorImage,
+		buildInfoImageKey:               &defaultsConfig.BuildInfoImage,
+		buildTokenDownloadImageKey:      &defaultsConfig.BuildTokenDownloadImage,
+		buildHelpersImageKey:            &defaultsConfig.BuildHelpersImage,
+		buildpacksV2LifecycleImageKey:   &defaultsConfig.BuildpacksV2LifecycleImage,
+		buildTimeoutKey:                 &defaultsConfig.BuildTimeout,
+		nopImageKey:                     &defaultsConfig.NopImage,
+		routeCanaryPrometheusURLKey:     &defaultsConfig.RouteCanaryPrometheusURL,
+		routeIstioVersionKey:            &defaultsConfig.RouteIstioVersion,
 	}
 }
 
@@ -296,7 +352,9 @@ func (defaultsConfig *DefaultsConfig) getInterfaceValues(leaveEmpty bool) map[st
 		terminationGracePeriodSecondsKey:   &defaultsConfig.TerminationGracePeriodSeconds,
 		routeTrackVirtualServiceKey:        &defaultsConfig.RouteTrackVirtualService,
 		routeDisableRetriesKey:             &defaultsConfig.RouteDisableRetries,
+		routeServiceProxyInsecureKey:       &defaultsConfig.RouteServiceProxyInsecureSkipVerify,
 		routeHostIgnoringPortKey:           &defaultsConfig.RouteHostIgnoringPort,
+		routeRateLimitTrustedHopsKey:       &defaultsConfig.RouteRateLimitTrustedHops,
 		taskDefaultTimeoutMinutesKey:       &defaultsConfig.TaskDefaultTimeoutMinutes,
 		taskDisableVolumeMountsKey:         &defaultsConfig.TaskDisableVolumeMounts,
 	}
@@ -307,6 +365,7 @@ func (defaultsConfig *DefaultsConfig) getInterfaceValues(leaveEmpty bool) map[st
 		m[spaceStacksV2Key] = &defaultsConfig.SpaceStacksV2
 		m[spaceStacksV3Key] = &defaultsConfig.SpaceStacksV3
 		m[featureFlagsKey] = &defaultsConfig.FeatureFlags
+		m[taskNotificationAllowedCIDRsKey] = &defaultsConfig.TaskNotificationAllowedCIDRs
 	}
 
 	if len(defaultsConfig.SpaceBuildpacksV2) > 0 {
@@ -324,10 +383,26 @@ func (defaultsConfig *DefaultsConfig) getInterfaceValues(leaveEmpty bool) map[st
 	if len(defaultsConfig.FeatureFlags) > 0 {
 		m[featureFlagsKey] = &defaultsConfig.FeatureFlags
 	}
+	if len(defaultsConfig.TaskNotificationAllowedCIDRs) > 0 {
+		m[taskNotificationAllowedCIDRsKey] = &defaultsConfig.TaskNotificationAllowedCIDRs
+	}
 
 	return m
 }
 
+// TaskNotificationAllowedNetworks parses TaskNotificationAllowedCIDRs.
+func (defaultsConfig *DefaultsConfig) TaskNotificationAllowedNetworks() ([]*net.IPNet, error) {
+	var networks []*net.IPNet
+	for _, cidr := range defaultsConfig.TaskNotificationAllowedCIDRs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %v", taskNotificationAllowedCIDRsKey, err)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
 // IsYAMLEqual returns whether the expexted YAML matches the actual YAML.
 func IsYAMLEqual(expected, actual string) (bool, error) {
 	var em, am interface{}
@@ -55,6 +55,7 @@ func TestPatchConfigMap(t *testing.T) {
 		terminationGracePeriodSecondsKey,
 		routeTrackVirtualServiceKey,
 		routeDisableRetriesKey,
+		routeServiceProxyInsecureKey,
 		routeHostIgnoringPortKey,
 		taskDefaultTimeoutMinutesKey,
 		taskDisableVolumeMountsKey,
@@ -48,6 +48,13 @@ func FromContext(ctx context.Context) *Config {
 	return ctx.Value(cfgKey{}).(*Config)
 }
 
+// TryFromContext gets the *Config from the context or nil if the context
+// doesn't have one.
+func TryFromContext(ctx context.Context) *Config {
+	cfg, _ := ctx.Value(cfgKey{}).(*Config)
+	return cfg
+}
+
 // ToContextForTest adds Config type values to the context. This should be used for tests only.
 func ToContextForTest(ctx context.Context, c *Config) context.Context {
 	return toContext(ctx, c)
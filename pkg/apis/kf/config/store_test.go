@@ -61,6 +61,7 @@ func TestStoreLoadWithContext(t *testing.T) {
 		terminationGracePeriodSecondsKey,
 		routeTrackVirtualServiceKey,
 		routeDisableRetriesKey,
+		routeServiceProxyInsecureKey,
 		routeHostIgnoringPortKey,
 		taskDefaultTimeoutMinutesKey,
 		taskDisableVolumeMountsKey,
@@ -84,7 +85,7 @@ func TestStoreLoadWithContext(t *testing.T) {
 		{Domain: "$(SPACE_NAME).prod.example.com"},
 		{Domain: "$(SPACE_NAME).kf.us-east1.prod.example.com"},
 		{Domain: "$(SPACE_NAME).$(CLUSTER_INGRESS_IP).nip.io"},
-		{Domain: "$(SPACE_NAME)-apps.internal", GatewayName: "kf/internal-gateway"},
+		{Domain: "$(SPACE_NAME)-apps.internal", GatewayName: "kf/internal-gateway", Internal: true},
 	}, configDefaults.SpaceClusterDomains)
 	testutil.AssertEqual(t, "SpaceContainerRegistry", "gcr.io/my-project", configDefaults.SpaceContainerRegistry)
 	testutil.AssertEqual(t, "SpaceBuildpacksV2", BuildpackV2List{
@@ -233,6 +233,21 @@ type DomainTemplate struct {
 	// Only the kf Namespace is allowed e.g. kf/some-gateway.
 	// See https://istio.io/docs/reference/config/networking/gateway/
 	GatewayName string `json:"gatewayName,omitempty"`
+	// RouterGroup configures the domain to accept TCP routes on reserved
+	// ports instead of HTTP routes.
+	RouterGroup *RouterGroupTemplate `json:"routerGroup,omitempty"`
+	// Internal makes the domain only reachable from inside the cluster.
+	Internal bool `json:"internal,omitempty"`
+}
+
+// RouterGroupTemplate mimics the structure of v1alpha1.RouterGroup
+type RouterGroupTemplate struct {
+	// Type is the type of traffic accepted by the router group, only tcp is
+	// supported.
+	Type string `json:"type"`
+	// ReservablePorts is a comma separated list of ports and port ranges that
+	// Routes can reserve e.g. "1024-1033,2000".
+	ReservablePorts string `json:"reservablePorts"`
 }
 
 // FeatureFlagToggles maps a feature name to a bool representing whether the feature is enabled.
@@ -87,7 +87,9 @@ func (in *DefaultsConfig) DeepCopyInto(out *DefaultsConfig) {
 	if in.SpaceClusterDomains != nil {
 		in, out := &in.SpaceClusterDomains, &out.SpaceClusterDomains
 		*out = make([]DomainTemplate, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.SpaceBuildpacksV2 != nil {
 		in, out := &in.SpaceBuildpacksV2, &out.SpaceBuildpacksV2
@@ -157,11 +159,21 @@ func (in *DefaultsConfig) DeepCopyInto(out *DefaultsConfig) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.RouteRateLimitTrustedHops != nil {
+		in, out := &in.RouteRateLimitTrustedHops, &out.RouteRateLimitTrustedHops
+		*out = new(int32)
+		**out = **in
+	}
 	if in.TaskDefaultTimeoutMinutes != nil {
 		in, out := &in.TaskDefaultTimeoutMinutes, &out.TaskDefaultTimeoutMinutes
 		*out = new(int32)
 		**out = **in
 	}
+	if in.TaskNotificationAllowedCIDRs != nil {
+		in, out := &in.TaskNotificationAllowedCIDRs, &out.TaskNotificationAllowedCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -178,6 +190,11 @@ func (in *DefaultsConfig) DeepCopy() *DefaultsConfig {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DomainTemplate) DeepCopyInto(out *DomainTemplate) {
 	*out = *in
+	if in.RouterGroup != nil {
+		in, out := &in.RouterGroup, &out.RouterGroup
+		*out = new(RouterGroupTemplate)
+		**out = **in
+	}
 	return
 }
 
@@ -213,6 +230,22 @@ func (in FeatureFlagToggles) DeepCopy() FeatureFlagToggles {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouterGroupTemplate) DeepCopyInto(out *RouterGroupTemplate) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouterGroupTemplate.
+func (in *RouterGroupTemplate) DeepCopy() *RouterGroupTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(RouterGroupTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StackV2Definition) DeepCopyInto(out *StackV2Definition) {
 	*out = *in
@@ -140,6 +140,7 @@ func containerProbeHandlerMask(in corev1.ProbeHandler) (out corev1.ProbeHandler)
 	// Allowed fields
 	out.HTTPGet = in.HTTPGet
 	out.TCPSocket = in.TCPSocket
+	out.GRPC = in.GRPC
 
 	// Disallowed fields
 	out.Exec = nil // Not supported by the Kf manifest
@@ -174,3 +175,15 @@ func containerProbeHandlerTCPSocketActionMask(in corev1.TCPSocketAction) (out co
 
 	return out
 }
+
+// containerProbeHandlerGRPCActionMask creates a shallow copy of the input with all
+// unsettable top-level fields masked off.
+func containerProbeHandlerGRPCActionMask(in corev1.GRPCAction) (out corev1.GRPCAction) {
+	// Allowed fields
+	out.Service = in.Service
+
+	// Disallowed fields
+	out.Port = 0 // Populated by Kf automatically
+
+	return out
+}
@@ -115,11 +115,13 @@ func TestContainerProbeHandlerMask(t *testing.T) {
 	want := corev1.ProbeHandler{
 		HTTPGet:   &corev1.HTTPGetAction{},
 		TCPSocket: &corev1.TCPSocketAction{},
+		GRPC:      &corev1.GRPCAction{},
 	}
 	in := corev1.ProbeHandler{
 		Exec:      &corev1.ExecAction{},
 		HTTPGet:   &corev1.HTTPGetAction{},
 		TCPSocket: &corev1.TCPSocketAction{},
+		GRPC:      &corev1.GRPCAction{},
 	}
 
 	got := containerProbeHandlerMask(in)
@@ -159,6 +161,20 @@ func TestContainerProbeHandlerTCPSocketActionMask(t *testing.T) {
 	testutil.AssertEqual(t, "masked value", want, got)
 }
 
+func TestContainerProbeHandlerGRPCActionMask(t *testing.T) {
+	service := "liveness"
+	want := corev1.GRPCAction{
+		Service: &service,
+	}
+	in := corev1.GRPCAction{
+		Service: &service,
+		Port:    8080,
+	}
+
+	got := containerProbeHandlerGRPCActionMask(in)
+	testutil.AssertEqual(t, "masked value", want, got)
+}
+
 func TestContainerPortMask(t *testing.T) {
 	want := corev1.ContainerPort{
 		ContainerPort: 42,
@@ -188,6 +188,9 @@ func ValidateContainerProbe(probe *corev1.Probe) *apis.FieldError {
 	if handler.TCPSocket != nil {
 		suppliedHandlers.Insert("tcpSocket")
 	}
+	if handler.GRPC != nil {
+		suppliedHandlers.Insert("grpc")
+	}
 	switch {
 	case suppliedHandlers.Len() > 1:
 		errs = errs.Also(apis.ErrMultipleOneOf(suppliedHandlers.List()...))
@@ -197,6 +200,9 @@ func ValidateContainerProbe(probe *corev1.Probe) *apis.FieldError {
 	case handler.TCPSocket != nil:
 		masked := containerProbeHandlerTCPSocketActionMask(*handler.TCPSocket)
 		errs = errs.Also(apis.CheckDisallowedFields(*handler.TCPSocket, masked)).ViaField("tcpSocket")
+	case handler.GRPC != nil:
+		masked := containerProbeHandlerGRPCActionMask(*handler.GRPC)
+		errs = errs.Also(apis.CheckDisallowedFields(*handler.GRPC, masked)).ViaField("grpc")
 	}
 
 	return errs
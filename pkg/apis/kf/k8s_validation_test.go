@@ -361,6 +361,14 @@ func goodTCPContainerProbe() *corev1.Probe {
 	}
 }
 
+func goodGRPCContainerProbe() *corev1.Probe {
+	return &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			GRPC: &corev1.GRPCAction{},
+		},
+	}
+}
+
 func badContainerProbe() *corev1.Probe {
 	return &corev1.Probe{
 		ProbeHandler: corev1.ProbeHandler{
@@ -382,6 +390,18 @@ func TestValidateContainerProbe(t *testing.T) {
 			field: goodTCPContainerProbe(),
 			want:  nil,
 		},
+		"good gRPC": {
+			field: goodGRPCContainerProbe(),
+			want:  nil,
+		},
+		"gRPC port not allowed": {
+			field: &corev1.Probe{
+				ProbeHandler: corev1.ProbeHandler{
+					GRPC: &corev1.GRPCAction{Port: 8080},
+				},
+			},
+			want: apis.ErrDisallowedFields("grpc.port"),
+		},
 		"multiple probe handlers invalid": {
 			field: &corev1.Probe{
 				ProbeHandler: corev1.ProbeHandler{
@@ -121,6 +121,11 @@ func (d RouteSpecFieldsSlice) Less(i int, j int) bool {
 		return d[i].Domain < d[j].Domain
 	}
 
+	// TCP routes only differ by port.
+	if d[i].Port != d[j].Port {
+		return d[i].Port < d[j].Port
+	}
+
 	if len(d[i].Path) != len(d[j].Path) {
 		return len(d[i].Path) > len(d[j].Path)
 	}
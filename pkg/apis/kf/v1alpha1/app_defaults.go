@@ -62,6 +62,10 @@ func (k *AppSpec) SetDefaults(ctx context.Context) {
 	k.Template.SetDefaults(ctx, k)
 	k.Instances.SetDefaults(ctx)
 	k.SetRouteDefaults(ctx)
+
+	if k.SessionAffinity != nil && k.SessionAffinity.CookieName == "" {
+		k.SessionAffinity.CookieName = DefaultSessionAffinityCookieName
+	}
 }
 
 // SetBuildDefaults implements apis.Defaultable for the embedded BuildSpec.
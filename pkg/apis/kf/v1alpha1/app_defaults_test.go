@@ -42,6 +42,7 @@ func TestAppSpec_SetDefaults(t *testing.T) {
 			// Empty weight
 			{},
 		},
+		SessionAffinity: &AppSessionAffinity{},
 	}
 	spec.SetDefaults(ctx)
 
@@ -71,6 +72,12 @@ func TestAppSpec_SetDefaults(t *testing.T) {
 		// value.
 		testutil.AssertEqual(t, "Routes", spec.Routes[0].Weight, ptr.Int32(1))
 	}
+
+	// SessionAffinity
+	{
+		// An empty cookie name is set to the default.
+		testutil.AssertEqual(t, "SessionAffinity", spec.SessionAffinity.CookieName, DefaultSessionAffinityCookieName)
+	}
 }
 
 func TestAppSpec_SetDefaults_updateRequests(t *testing.T) {
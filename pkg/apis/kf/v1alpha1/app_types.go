@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	autoscaling "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -54,6 +55,32 @@ const (
 	DefaultMaxTaskCount = 500
 	// AppServerComponent is the value used for the App component.
 	AppServerComponent = "app-server"
+	// LastRequestTimeAnnotation holds the RFC 3339 time the activator last
+	// received a request for an App with an idle timeout. The App reconciler
+	// sets it when the idle timeout is first set.
+	LastRequestTimeAnnotation = "kf.dev/last-request-time"
+	// ServiceBindingCredentialsAnnotation is set on Pods to the IDs of the
+	// rotated credentials of the App's service bindings so Pods get replaced
+	// when credentials are rotated.
+	ServiceBindingCredentialsAnnotation = "kf.dev/service-binding-credentials"
+	// ActivatorTargetHeader is set on requests routed through the activator
+	// to the App they're for in the format NAMESPACE/NAME:PORT.
+	ActivatorTargetHeader = "X-Kf-Activator-Target"
+	// InstanceIndexLabel holds the index of an App instance on its Pod, it's
+	// assigned to Apps with instance routing.
+	InstanceIndexLabel = "kf.dev/instance-index"
+	// InstanceHeader is set by clients to send a request to a specific App
+	// instance in the format APP_NAME:INDEX.
+	InstanceHeader = "X-Kf-App-Instance"
+	// DefaultSessionAffinityCookieName is the name of the cookie used for
+	// session affinity if one isn't set.
+	DefaultSessionAffinityCookieName = "KF_SESSION_AFFINITY"
+	// ServiceBindingRootEnvVar holds the directory containing projected
+	// service bindings, see https://servicebinding.io/spec/core/1.0.0/.
+	ServiceBindingRootEnvVar = "SERVICE_BINDING_ROOT"
+	// DefaultServiceBindingRoot is the directory service bindings are
+	// projected into.
+	DefaultServiceBindingRoot = "/bindings"
 )
 
 // RouteBindingStatus represents the status of a RouteBinding.
@@ -138,6 +165,35 @@ type AppSpec struct {
 	// +optional
 	// +patchStrategy=merge
 	Routes []RouteWeightBinding `json:"routes,omitempty"`
+
+	// SessionAffinity sends requests from the same client to the same
+	// instance of the App.
+	// +optional
+	SessionAffinity *AppSessionAffinity `json:"sessionAffinity,omitempty"`
+
+	// InstanceRouting allows clients to send requests to a specific instance
+	// of the App using the X-Kf-App-Instance header.
+	// +optional
+	InstanceRouting bool `json:"instanceRouting,omitempty"`
+
+	// ProjectServiceBindings mounts the credentials of each service binding
+	// as files under $SERVICE_BINDING_ROOT/<name>/ following the
+	// servicebinding.io spec in addition to VCAP_SERVICES.
+	// +optional
+	ProjectServiceBindings bool `json:"projectServiceBindings,omitempty"`
+}
+
+// AppSessionAffinity defines how clients are pinned to an App instance.
+type AppSessionAffinity struct {
+	// CookieName is the name of the cookie used to pin clients to an
+	// instance. The cookie is generated by the gateway if the request doesn't
+	// have one.
+	CookieName string `json:"cookieName,omitempty"`
+
+	// TTL is the lifetime of generated cookies, zero or unset generates
+	// session cookies.
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
 }
 
 // AppSpecBuild defines an app's build configuration.
@@ -192,6 +248,13 @@ type AppSpecInstances struct {
 
 	// DeprecatedExactly value is copied to Replicas.
 	DeprecatedExactly *int32 `json:"exactly,omitempty"`
+
+	// IdleTimeout scales the App to zero instances after it hasn't received
+	// requests for the duration. Requests sent to an idle App are held by the
+	// activator until an instance is ready. The activator only proxies
+	// HTTP/1.1, so it can't be set on Apps with gRPC or HTTP/2 ports.
+	// +optional
+	IdleTimeout *metav1.Duration `json:"idleTimeout,omitempty"`
 }
 
 // AppSpecAutoscaling defines the autoscaling specs for an App.
@@ -336,6 +399,20 @@ func (instances *AppSpecInstances) DeploymentReplicas() (int32, error) {
 	}
 }
 
+// MaxReplicas returns the most instances the App can be scaled to.
+func (instances *AppSpecInstances) MaxReplicas() int32 {
+	switch {
+	case instances.Stopped:
+		return 0
+	case instances.Autoscaling.RequiresHPA():
+		return *instances.Autoscaling.MaxReplicas
+	case instances.Replicas != nil:
+		return *instances.Replicas
+	default:
+		return 1
+	}
+}
+
 // Status returns an InstanceStatus representing this AppSpecInstancces
 // indicating what the real scale factor was.
 func (instances *AppSpecInstances) Status() InstanceStatus {
@@ -362,6 +439,47 @@ func (instances *AppSpecInstances) Status() InstanceStatus {
 	return out
 }
 
+// LastActiveTime returns the last time the activator received a request for
+// the App, or the time the idle timeout was set if it hasn't received any.
+// False is returned if the time hasn't been recorded yet.
+func (app *App) LastActiveTime() (time.Time, bool) {
+	value, ok := app.Annotations[LastRequestTimeAnnotation]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	lastRequest, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return lastRequest, true
+}
+
+// IsIdle returns true if the App has an idle timeout and hasn't received
+// requests within it. If the App isn't idle yet, the time until it becomes
+// idle is also returned.
+func (app *App) IsIdle(now time.Time) (bool, time.Duration) {
+	instances := app.Spec.Instances
+	if instances.IdleTimeout == nil || instances.Stopped {
+		return false, 0
+	}
+
+	// The idle timeout starts once the App reconciler records when it was
+	// set.
+	lastActive, ok := app.LastActiveTime()
+	if !ok {
+		return false, 0
+	}
+
+	remaining := lastActive.Add(instances.IdleTimeout.Duration).Sub(now)
+	if remaining <= 0 {
+		return true, 0
+	}
+
+	return false, remaining
+}
+
 // AppStatus is the current configuration and running state for an App.
 type AppStatus struct {
 	// Pull in the fields from Knative's duckv1beta1 status field.
@@ -456,6 +574,20 @@ type InstanceStatus struct {
 	// DeprecatedEffectiveMax contains the effective maximum number of
 	// instances passed as an annotation.
 	DeprecatedEffectiveMax string `json:"effectiveMax,omitempty"`
+
+	// Idle is true if the App was scaled to zero because it didn't receive
+	// requests within its idle timeout.
+	Idle bool `json:"idle,omitempty"`
+}
+
+// PropagateIdle updates the instance status to show the App was scaled to
+// zero because it's idle.
+func (status *InstanceStatus) PropagateIdle(idle bool) {
+	status.Idle = idle
+	if idle {
+		status.Replicas = 0
+		status.Representation += " (idle)"
+	}
 }
 
 // AppTaskStatus contains the Task status on the App.
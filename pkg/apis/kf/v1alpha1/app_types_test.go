@@ -18,8 +18,10 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/kf/v2/pkg/kf/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/ptr"
 )
 
@@ -221,3 +223,119 @@ func TestAppSpecInstances_DeploymentReplicas(t *testing.T) {
 		})
 	}
 }
+
+func TestAppSpecInstances_MaxReplicas(t *testing.T) {
+	tests := map[string]struct {
+		instances AppSpecInstances
+		want      int32
+	}{
+		"stopped": {
+			instances: AppSpecInstances{
+				Stopped:  true,
+				Replicas: ptr.Int32(3),
+			},
+			want: 0,
+		},
+		"exactly": {
+			instances: AppSpecInstances{
+				Replicas: ptr.Int32(3),
+			},
+			want: 3,
+		},
+		"autoscaled": {
+			instances: AppSpecInstances{
+				Replicas: ptr.Int32(3),
+				Autoscaling: AppSpecAutoscaling{
+					Enabled:     true,
+					MaxReplicas: ptr.Int32(5),
+					Rules:       []AppAutoscalingRule{{RuleType: CPURuleType}},
+				},
+			},
+			want: 5,
+		},
+		"empty": {
+			instances: AppSpecInstances{},
+			want:      1,
+		},
+	}
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			testutil.AssertEqual(t, "count", tc.want, tc.instances.MaxReplicas())
+		})
+	}
+}
+
+func TestApp_IsIdle(t *testing.T) {
+	created := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	lastRequest := created.Add(time.Hour)
+
+	makeApp := func(instances AppSpecInstances, annotations map[string]string) *App {
+		app := &App{}
+		app.CreationTimestamp = metav1.Time{Time: created}
+		app.Annotations = annotations
+		app.Spec.Instances = instances
+		return app
+	}
+
+	idleTimeout := &metav1.Duration{Duration: 10 * time.Minute}
+	requested := map[string]string{
+		LastRequestTimeAnnotation: lastRequest.Format(time.RFC3339),
+	}
+
+	tests := map[string]struct {
+		app           *App
+		now           time.Time
+		wantIdle      bool
+		wantRemaining time.Duration
+	}{
+		"no idle timeout": {
+			app: makeApp(AppSpecInstances{}, nil),
+			now: created.Add(24 * time.Hour),
+		},
+		"stopped": {
+			app: makeApp(AppSpecInstances{Stopped: true, IdleTimeout: idleTimeout}, nil),
+			now: created.Add(24 * time.Hour),
+		},
+		"idle timeout not recorded": {
+			app: makeApp(AppSpecInstances{IdleTimeout: idleTimeout}, nil),
+			now: created.Add(24 * time.Hour),
+		},
+		"idle timeout just set": {
+			app:           makeApp(AppSpecInstances{IdleTimeout: idleTimeout}, requested),
+			now:           lastRequest.Add(time.Minute),
+			wantRemaining: 9 * time.Minute,
+		},
+		"recently requested": {
+			app:           makeApp(AppSpecInstances{IdleTimeout: idleTimeout}, requested),
+			now:           lastRequest.Add(4 * time.Minute),
+			wantRemaining: 6 * time.Minute,
+		},
+		"idle after request": {
+			app:      makeApp(AppSpecInstances{IdleTimeout: idleTimeout}, requested),
+			now:      lastRequest.Add(11 * time.Minute),
+			wantIdle: true,
+		},
+		"malformed annotation": {
+			app: makeApp(AppSpecInstances{IdleTimeout: idleTimeout}, map[string]string{
+				LastRequestTimeAnnotation: "yesterday",
+			}),
+			now: lastRequest,
+		},
+	}
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			idle, remaining := tc.app.IsIdle(tc.now)
+			testutil.AssertEqual(t, "idle", tc.wantIdle, idle)
+			testutil.AssertEqual(t, "remaining", tc.wantRemaining, remaining)
+		})
+	}
+}
+
+func TestInstanceStatus_PropagateIdle(t *testing.T) {
+	status := InstanceStatus{Replicas: 3, Representation: "3"}
+	status.PropagateIdle(false)
+	testutil.AssertEqual(t, "active", InstanceStatus{Replicas: 3, Representation: "3"}, status)
+
+	status.PropagateIdle(true)
+	testutil.AssertEqual(t, "idle", InstanceStatus{Replicas: 0, Representation: "3 (idle)", Idle: true}, status)
+}
@@ -18,11 +18,17 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/google/kf/v2/pkg/apis/kf"
 	"knative.dev/pkg/apis"
 )
 
+// cookieNamePattern matches cookie names that don't need quoting.
+var cookieNamePattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
 // Validate checks for errors in the App's spec or status fields.
 func (app *App) Validate(ctx context.Context) (errs *apis.FieldError) {
 	// If we're specifically updating status, don't reject the change because
@@ -43,9 +49,50 @@ func (spec *AppSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 
 	errs = errs.Also(kf.ValidatePodSpec(spec.Template.Spec).ViaField("template.spec"))
 	errs = errs.Also(spec.Instances.Validate(ctx).ViaField("instances"))
+	errs = errs.Also(spec.validateIdlePorts().ViaField("instances"))
 	errs = errs.Also(spec.Build.Validate(ctx).ViaField("build"))
 	errs = errs.Also(spec.ValidateRoutes(ctx).ViaField("routes"))
 
+	if spec.SessionAffinity != nil {
+		errs = errs.Also(spec.SessionAffinity.Validate(ctx).ViaField("sessionAffinity"))
+	}
+
+	return errs
+}
+
+// Validate checks that the session affinity cookie can be set by the gateway.
+func (affinity *AppSessionAffinity) Validate(ctx context.Context) (errs *apis.FieldError) {
+	switch {
+	case affinity.CookieName == "":
+		errs = errs.Also(apis.ErrMissingField("cookieName"))
+	case !cookieNamePattern.MatchString(affinity.CookieName):
+		errs = errs.Also(apis.ErrInvalidValue(affinity.CookieName, "cookieName", "must only contain letters, digits, '-', '_' and '.'"))
+	}
+
+	if affinity.TTL != nil && affinity.TTL.Duration < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(affinity.TTL.Duration.String(), "ttl", "can't be negative"))
+	}
+
+	return errs
+}
+
+// validateIdlePorts checks that Apps with an idle timeout only serve protocols
+// the activator can proxy. The activator holds requests for idle Apps and
+// only speaks HTTP/1.1, so ports named for gRPC or HTTP/2 would be broken.
+func (spec *AppSpec) validateIdlePorts() (errs *apis.FieldError) {
+	if spec.Instances.IdleTimeout == nil || len(spec.Template.Spec.Containers) == 0 {
+		return nil
+	}
+
+	for _, port := range spec.Template.Spec.Containers[0].Ports {
+		// Istio picks the protocol from the <protocol>[-<suffix>] port name.
+		switch protocol := strings.ToLower(strings.SplitN(port.Name, "-", 2)[0]); protocol {
+		case "grpc", "http2":
+			msg := fmt.Sprintf("idleTimeout can't be used with %s port %q, the activator only proxies HTTP/1.1", protocol, port.Name)
+			errs = errs.Also(apis.ErrGeneric(msg, "idleTimeout"))
+		}
+	}
+
 	return errs
 }
 
@@ -112,6 +159,17 @@ func (instances *AppSpecInstances) Validate(ctx context.Context) (errs *apis.Fie
 
 	errs = errs.Also(instances.Autoscaling.Validate(ctx).ViaField("autoscaling"))
 
+	if instances.IdleTimeout != nil {
+		if instances.IdleTimeout.Duration < time.Minute {
+			errs = errs.Also(apis.ErrInvalidValue(instances.IdleTimeout.Duration.String(), "idleTimeout", "must be at least 1m"))
+		}
+
+		// The autoscaler would scale idle Apps back up.
+		if instances.Autoscaling.Enabled {
+			errs = errs.Also(apis.ErrGeneric("idleTimeout can't be used with autoscaling", "idleTimeout"))
+		}
+	}
+
 	return errs
 }
 
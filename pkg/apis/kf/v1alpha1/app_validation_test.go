@@ -20,6 +20,7 @@ import (
 	"math"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/kf/v2/pkg/apis/kf/config"
 	"github.com/google/kf/v2/pkg/kf/testutil"
@@ -216,6 +217,26 @@ func TestApp_Validate(t *testing.T) {
 			},
 			want: apis.ErrInvalidValue(-1, "spec.routes[0].weight"),
 		},
+		"invalid route mirror percent": {
+			spec: App{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "valid",
+				},
+				Spec: AppSpec{
+					Template:  goodTemplate,
+					Instances: goodInstances,
+					Build:     goodBuild,
+					Routes: []RouteWeightBinding{
+						func() RouteWeightBinding {
+							route := *goodRoute.DeepCopy()
+							route.MirrorPercent = ptr.Int32(101)
+							return route
+						}(),
+					},
+				},
+			},
+			want: apis.ErrOutOfBoundsValue(101, 1, 100, "spec.routes[0].mirrorPercent"),
+		},
 		"route without domain": {
 			spec: App{
 				ObjectMeta: metav1.ObjectMeta{
@@ -231,6 +252,48 @@ func TestApp_Validate(t *testing.T) {
 				},
 			},
 		},
+		"idle timeout with http port": {
+			spec: App{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "valid",
+				},
+				Spec: AppSpec{
+					Template: AppSpecTemplate{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{
+								Ports: []corev1.ContainerPort{{Name: "http-web", ContainerPort: 8080}},
+							}},
+						},
+					},
+					Instances: AppSpecInstances{IdleTimeout: &metav1.Duration{Duration: 15 * time.Minute}},
+					Build:     goodBuild,
+				},
+			},
+		},
+		"idle timeout with grpc port": {
+			spec: App{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "valid",
+				},
+				Spec: AppSpec{
+					Template: AppSpecTemplate{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{
+								Ports: []corev1.ContainerPort{
+									{Name: "http-web", ContainerPort: 8080},
+									{Name: "grpc-api", ContainerPort: 9090},
+									{Name: "HTTP2-api", ContainerPort: 9091},
+								},
+							}},
+						},
+					},
+					Instances: AppSpecInstances{IdleTimeout: &metav1.Duration{Duration: 15 * time.Minute}},
+					Build:     goodBuild,
+				},
+			},
+			want: apis.ErrGeneric(`idleTimeout can't be used with grpc port "grpc-api", the activator only proxies HTTP/1.1`, "spec.instances.idleTimeout").
+				Also(apis.ErrGeneric(`idleTimeout can't be used with http2 port "HTTP2-api", the activator only proxies HTTP/1.1`, "spec.instances.idleTimeout")),
+		},
 		"non-nil buildRef with empty name": {
 			spec: App{
 				ObjectMeta: metav1.ObjectMeta{
@@ -440,6 +503,20 @@ func TestAppSpecInstances_Validate(t *testing.T) {
 			spec: AppSpecInstances{Replicas: ptr.Int32(0)},
 			want: apis.ErrInvalidValue(0, "replicas"),
 		},
+		"valid idle timeout": {
+			spec: AppSpecInstances{IdleTimeout: &metav1.Duration{Duration: 15 * time.Minute}},
+		},
+		"idle timeout too short": {
+			spec: AppSpecInstances{IdleTimeout: &metav1.Duration{Duration: 30 * time.Second}},
+			want: apis.ErrInvalidValue("30s", "idleTimeout", "must be at least 1m"),
+		},
+		"idle timeout with autoscaling": {
+			spec: AppSpecInstances{
+				IdleTimeout: &metav1.Duration{Duration: 15 * time.Minute},
+				Autoscaling: AppSpecAutoscaling{Enabled: true},
+			},
+			want: apis.ErrGeneric("idleTimeout can't be used with autoscaling", "idleTimeout"),
+		},
 	}
 
 	for tn, tc := range cases {
@@ -451,6 +528,43 @@ func TestAppSpecInstances_Validate(t *testing.T) {
 	}
 }
 
+func TestAppSessionAffinity_Validate(t *testing.T) {
+	cases := map[string]struct {
+		affinity AppSessionAffinity
+		want     *apis.FieldError
+	}{
+		"valid": {
+			affinity: AppSessionAffinity{
+				CookieName: "JSESSIONID",
+				TTL:        &metav1.Duration{Duration: time.Hour},
+			},
+		},
+		"missing cookie name": {
+			affinity: AppSessionAffinity{},
+			want:     apis.ErrMissingField("cookieName"),
+		},
+		"invalid cookie name": {
+			affinity: AppSessionAffinity{CookieName: "my cookie"},
+			want:     apis.ErrInvalidValue("my cookie", "cookieName", "must only contain letters, digits, '-', '_' and '.'"),
+		},
+		"negative ttl": {
+			affinity: AppSessionAffinity{
+				CookieName: "JSESSIONID",
+				TTL:        &metav1.Duration{Duration: -time.Second},
+			},
+			want: apis.ErrInvalidValue("-1s", "ttl", "can't be negative"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got := tc.affinity.Validate(context.Background())
+
+			testutil.AssertEqual(t, "validation errors", tc.want.Error(), got.Error())
+		})
+	}
+}
+
 func TestAutoscalingSpec_Validate(t *testing.T) {
 	// These test cases are broken out separately because they're
 	// too extenstive to copy the whole service struct for.
@@ -58,12 +58,18 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&ServiceInstanceList{},
 		&ServiceInstanceBinding{},
 		&ServiceInstanceBindingList{},
+		&ServiceInstanceShare{},
+		&ServiceInstanceShareList{},
 		&Space{},
 		&SpaceList{},
 		&Task{},
 		&TaskList{},
 		&TaskSchedule{},
 		&TaskScheduleList{},
+		&Workflow{},
+		&WorkflowList{},
+		&WorkflowRun{},
+		&WorkflowRunList{},
 		&Route{},
 		&RouteList{},
 		&SourcePackage{},
@@ -16,6 +16,7 @@ package v1alpha1
 
 import (
 	"context"
+	"fmt"
 	"path"
 )
 
@@ -33,6 +34,14 @@ const (
 
 	// DefaultRouteDestinationPort holds the default port route traffic is sent to.
 	DefaultRouteDestinationPort = 80
+
+	// DefaultRouteMaintenanceStatusCode is the status returned by Routes in
+	// maintenance mode.
+	DefaultRouteMaintenanceStatusCode = 503
+
+	// MaxRouteMaintenanceMessageBytes is the longest maintenance message the
+	// ingress gateway can respond with.
+	MaxRouteMaintenanceMessageBytes = 4096
 )
 
 type routeDefaultDomain struct{}
@@ -74,6 +83,17 @@ func GenerateRouteName(hostname, domain, urlPath string) string {
 	return GenerateName(hostname, domain, path.Join("/", urlPath), "")
 }
 
+// GenerateRouteNameFromFields creates the deterministic name for a Route
+// using all of its fields. HTTP Routes have the same name as
+// GenerateRouteName.
+func GenerateRouteNameFromFields(fields RouteSpecFields) string {
+	if fields.IsTCP() {
+		return GenerateName("tcp", fields.Domain, fmt.Sprint(fields.Port))
+	}
+
+	return GenerateRouteName(fields.Hostname, fields.Domain, fields.Path)
+}
+
 // SetDefaults implements apis.Defaultable
 func (k *RouteWeightBinding) SetDefaults(ctx context.Context) {
 	if k.Weight == nil {
@@ -112,4 +132,8 @@ func (k *Route) SetDefaults(ctx context.Context) {
 // SetDefaults implements apis.Defaultable
 func (k *RouteSpec) SetDefaults(ctx context.Context) {
 	k.RouteSpecFields.SetDefaults(ctx)
+
+	if k.Maintenance != nil && k.Maintenance.StatusCode == 0 {
+		k.Maintenance.StatusCode = DefaultRouteMaintenanceStatusCode
+	}
 }
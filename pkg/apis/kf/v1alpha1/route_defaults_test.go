@@ -43,3 +43,13 @@ func ExampleRoute_SetDefaults_labels() {
 	// Output: ManagedBy: kf
 	// Component: route
 }
+
+func ExampleRoute_SetDefaults_maintenance() {
+	r := &Route{}
+	r.Spec.Maintenance = &RouteMaintenance{Message: "Database migration"}
+	r.SetDefaults(context.Background())
+
+	fmt.Println("StatusCode:", r.Spec.Maintenance.StatusCode)
+
+	// Output: StatusCode: 503
+}
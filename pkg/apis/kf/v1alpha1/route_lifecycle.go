@@ -16,15 +16,26 @@ package v1alpha1
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 
 	networking "github.com/google/kf/v2/pkg/apis/networking/v1alpha3"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/apis"
 	"knative.dev/pkg/kmeta"
 )
 
+// RouteConditionMirrorReady is False when more than one App mirrors the Route.
+// It's informational and doesn't affect the Route's readiness.
+const RouteConditionMirrorReady apis.ConditionType = "MirrorReady"
+
+// RouteConditionRateLimitReady is False when the Route's rate limit isn't
+// enforced by the ingress gateway. It's informational and doesn't affect the
+// Route's readiness.
+const RouteConditionRateLimitReady apis.ConditionType = "RateLimitReady"
+
 // GetGroupVersionKind implements kmeta.OwnerRefable.
 func (r *Route) GetGroupVersionKind() schema.GroupVersionKind {
 	return SchemeGroupVersion.WithKind("Route")
@@ -84,6 +95,38 @@ func (status *RouteStatus) PropagateBindings(bindings []RouteDestination) {
 	}
 }
 
+// PropagateMirrors warns when more than one App mirrors the Route. Istio copies
+// traffic to a single destination, so only the first mirror in the sorted
+// bindings receives traffic and the rest are ignored.
+func (status *RouteStatus) PropagateMirrors(bindings []RouteDestination) {
+	var mirrors []string
+	for _, binding := range bindings {
+		if binding.IsMirror() {
+			mirrors = append(mirrors, binding.ServiceName)
+		}
+	}
+
+	if len(mirrors) > 1 {
+		status.manage().MarkFalse(RouteConditionMirrorReady, "MultipleMirrors", "Only App %q receives mirrored traffic, ignored mirrors: [%s]",
+			mirrors[0], strings.Join(mirrors[1:], ", "))
+		return
+	}
+
+	// The condition is only reported while there's a conflict.
+	_ = status.manage().ClearCondition(RouteConditionMirrorReady)
+}
+
+// PropagateRateLimit warns when the Route's rate limit isn't enforced. A nil
+// err means the Route has no rate limit or it's enforced.
+func (status *RouteStatus) PropagateRateLimit(err error) {
+	if err != nil {
+		status.manage().MarkFalse(RouteConditionRateLimitReady, "RateLimitNotEnforced", "%v", err)
+		return
+	}
+
+	_ = status.manage().ClearCondition(RouteConditionRateLimitReady)
+}
+
 // PropagateRouteServiceBinding updates the route service name on the Route.
 func (status *RouteStatus) PropagateRouteServiceBinding(routeServices []RouteServiceDestination) {
 	if len(routeServices) > 1 {
@@ -118,7 +161,24 @@ func (status *RouteStatus) PropagateSpaceDomain(spaceDomain *SpaceDomain) {
 	switch {
 	case spaceDomain == nil:
 		status.SpaceDomainCondition().MarkReconciliationError("InvalidDomain", errors.New("The domain specified on the Route isn't permitted by the Space"))
+	case status.RouteSpecFields.IsTCP() && !spaceDomain.IsTCP():
+		status.SpaceDomainCondition().MarkReconciliationError("InvalidDomain", errors.New("The domain specified on the Route doesn't have a TCP router group so it can't reserve ports"))
+	case !status.RouteSpecFields.IsTCP() && spaceDomain.IsTCP():
+		status.SpaceDomainCondition().MarkReconciliationError("InvalidDomain", errors.New("The domain specified on the Route only accepts TCP Routes with a port"))
+	case status.RouteSpecFields.IsTCP() && !spaceDomain.RouterGroup.CanReservePort(status.RouteSpecFields.Port):
+		status.SpaceDomainCondition().MarkReconciliationError("PortNotReservable", fmt.Errorf("The port %d isn't reservable on the domain, reservable ports are %q", status.RouteSpecFields.Port, spaceDomain.RouterGroup.ReservablePorts))
 	default:
 		status.SpaceDomainCondition().MarkSuccess()
 	}
 }
+
+// PropagatePortHolder marks the Route as failed if the port it reserves is held
+// by a Route in another Space. An empty holderSpace means the port isn't held
+// by another Space.
+func (status *RouteStatus) PropagatePortHolder(holderSpace string) {
+	if holderSpace == "" {
+		return
+	}
+
+	status.SpaceDomainCondition().MarkReconciliationError("PortConflict", fmt.Errorf("The port %d is already reserved by a Route in Space %q", status.RouteSpecFields.Port, holderSpace))
+}
@@ -166,7 +166,16 @@ func TestRouteStatus_PropagateBindings(t *testing.T) {
 func TestRouteStatus_PropagateSpaceDomain(t *testing.T) {
 	t.Parallel()
 
+	tcpDomain := &SpaceDomain{
+		Domain: "tcp.example.com",
+		RouterGroup: &RouterGroup{
+			Type:            RouterGroupTypeTCP,
+			ReservablePorts: "1024-1033",
+		},
+	}
+
 	cases := map[string]struct {
+		fields        RouteSpecFields
 		spaceDomain   *SpaceDomain
 		wantCondition apis.Condition
 	}{
@@ -186,11 +195,50 @@ func TestRouteStatus_PropagateSpaceDomain(t *testing.T) {
 				Status: corev1.ConditionTrue,
 			},
 		},
+		"TCP route on TCP domain": {
+			fields:      RouteSpecFields{Domain: "tcp.example.com", Port: 1024},
+			spaceDomain: tcpDomain,
+			wantCondition: apis.Condition{
+				Type:   RouteConditionSpaceDomainReady,
+				Status: corev1.ConditionTrue,
+			},
+		},
+		"TCP route on HTTP domain": {
+			fields:      RouteSpecFields{Domain: "example.com", Port: 1024},
+			spaceDomain: &SpaceDomain{Domain: "example.com"},
+			wantCondition: apis.Condition{
+				Type:    RouteConditionSpaceDomainReady,
+				Status:  corev1.ConditionFalse,
+				Reason:  "ReconciliationError",
+				Message: "Error occurred while InvalidDomain SpaceDomain: The domain specified on the Route doesn't have a TCP router group so it can't reserve ports",
+			},
+		},
+		"HTTP route on TCP domain": {
+			fields:      RouteSpecFields{Domain: "tcp.example.com"},
+			spaceDomain: tcpDomain,
+			wantCondition: apis.Condition{
+				Type:    RouteConditionSpaceDomainReady,
+				Status:  corev1.ConditionFalse,
+				Reason:  "ReconciliationError",
+				Message: "Error occurred while InvalidDomain SpaceDomain: The domain specified on the Route only accepts TCP Routes with a port",
+			},
+		},
+		"TCP route outside reservable ports": {
+			fields:      RouteSpecFields{Domain: "tcp.example.com", Port: 2000},
+			spaceDomain: tcpDomain,
+			wantCondition: apis.Condition{
+				Type:    RouteConditionSpaceDomainReady,
+				Status:  corev1.ConditionFalse,
+				Reason:  "ReconciliationError",
+				Message: `Error occurred while PortNotReservable SpaceDomain: The port 2000 isn't reservable on the domain, reservable ports are "1024-1033"`,
+			},
+		},
 	}
 
 	for tn, tc := range cases {
 		t.Run(tn, func(t *testing.T) {
 			status := RouteStatus{}
+			status.PropagateRouteSpecFields(tc.fields)
 			status.PropagateSpaceDomain(tc.spaceDomain)
 
 			gotCondition := status.GetCondition(RouteConditionSpaceDomainReady)
@@ -201,6 +249,138 @@ func TestRouteStatus_PropagateSpaceDomain(t *testing.T) {
 	}
 }
 
+func TestRouteStatus_PropagatePortHolder(t *testing.T) {
+	t.Parallel()
+
+	tcpDomain := &SpaceDomain{
+		Domain: "tcp.example.com",
+		RouterGroup: &RouterGroup{
+			Type:            RouterGroupTypeTCP,
+			ReservablePorts: "1024-1033",
+		},
+	}
+
+	cases := map[string]struct {
+		holderSpace   string
+		wantCondition apis.Condition
+	}{
+		"port not held": {
+			wantCondition: apis.Condition{
+				Type:   RouteConditionSpaceDomainReady,
+				Status: corev1.ConditionTrue,
+			},
+		},
+		"port held by another Space": {
+			holderSpace: "other-space",
+			wantCondition: apis.Condition{
+				Type:    RouteConditionSpaceDomainReady,
+				Status:  corev1.ConditionFalse,
+				Reason:  "ReconciliationError",
+				Message: `Error occurred while PortConflict SpaceDomain: The port 1024 is already reserved by a Route in Space "other-space"`,
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			status := RouteStatus{}
+			status.PropagateRouteSpecFields(RouteSpecFields{Domain: "tcp.example.com", Port: 1024})
+			status.PropagateSpaceDomain(tcpDomain)
+			status.PropagatePortHolder(tc.holderSpace)
+
+			gotCondition := status.GetCondition(RouteConditionSpaceDomainReady)
+			testutil.AssertNotNil(t, "condition", gotCondition)
+			gotCondition.LastTransitionTime = apis.VolatileTime{} // clear non-deterministic time
+			testutil.AssertEqual(t, "condition", tc.wantCondition, *gotCondition)
+		})
+	}
+}
+
+func TestRouteStatus_PropagateMirrors(t *testing.T) {
+	t.Parallel()
+
+	app := func(name string, mirrorPercent int32) RouteDestination {
+		return RouteDestination{ServiceName: name, Port: 80, MirrorPercent: mirrorPercent}
+	}
+
+	cases := map[string]struct {
+		bindings      []RouteDestination
+		wantCondition *apis.Condition
+	}{
+		"no mirrors": {
+			bindings: []RouteDestination{app("app-a", 0)},
+		},
+		"one mirror": {
+			bindings: []RouteDestination{app("app-a", 0), app("app-b", 10)},
+		},
+		"multiple mirrors": {
+			bindings: []RouteDestination{app("app-a", 0), app("app-b", 10), app("app-c", 20), app("app-d", 30)},
+			wantCondition: &apis.Condition{
+				Type:     RouteConditionMirrorReady,
+				Status:   corev1.ConditionFalse,
+				Severity: apis.ConditionSeverityInfo,
+				Reason:   "MultipleMirrors",
+				Message:  `Only App "app-b" receives mirrored traffic, ignored mirrors: [app-c, app-d]`,
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			status := RouteStatus{}
+			status.InitializeConditions()
+			status.PropagateMirrors(tc.bindings)
+
+			gotCondition := status.GetCondition(RouteConditionMirrorReady)
+			if gotCondition != nil {
+				gotCondition.LastTransitionTime = apis.VolatileTime{} // clear non-deterministic time
+			}
+			testutil.AssertEqual(t, "condition", tc.wantCondition, gotCondition)
+		})
+	}
+}
+
+func TestRouteStatus_PropagateMirrors_cleared(t *testing.T) {
+	t.Parallel()
+
+	mirrors := []RouteDestination{
+		{ServiceName: "app-a", MirrorPercent: 10},
+		{ServiceName: "app-b", MirrorPercent: 10},
+	}
+
+	status := RouteStatus{}
+	status.InitializeConditions()
+	status.PropagateMirrors(mirrors)
+	testutil.AssertNotNil(t, "condition", status.GetCondition(RouteConditionMirrorReady))
+	testutil.AssertEqual(t, "ready", corev1.ConditionUnknown, status.GetCondition(RouteConditionReady).Status)
+
+	status.PropagateMirrors(mirrors[:1])
+	testutil.AssertEqual(t, "condition", (*apis.Condition)(nil), status.GetCondition(RouteConditionMirrorReady))
+	testutil.AssertEqual(t, "ready", corev1.ConditionUnknown, status.GetCondition(RouteConditionReady).Status)
+}
+
+func TestRouteStatus_PropagateRateLimit(t *testing.T) {
+	t.Parallel()
+
+	status := RouteStatus{}
+	status.InitializeConditions()
+	status.PropagateRateLimit(errors.New("unsupported Istio version"))
+
+	gotCondition := status.GetCondition(RouteConditionRateLimitReady)
+	gotCondition.LastTransitionTime = apis.VolatileTime{} // clear non-deterministic time
+	testutil.AssertEqual(t, "condition", &apis.Condition{
+		Type:     RouteConditionRateLimitReady,
+		Status:   corev1.ConditionFalse,
+		Severity: apis.ConditionSeverityInfo,
+		Reason:   "RateLimitNotEnforced",
+		Message:  "unsupported Istio version",
+	}, gotCondition)
+	testutil.AssertEqual(t, "ready", corev1.ConditionUnknown, status.GetCondition(RouteConditionReady).Status)
+
+	status.PropagateRateLimit(nil)
+	testutil.AssertEqual(t, "condition", (*apis.Condition)(nil), status.GetCondition(RouteConditionRateLimitReady))
+}
+
 func TestRouteStatus_PropagateRouteServiceBinding(t *testing.T) {
 	t.Parallel()
 
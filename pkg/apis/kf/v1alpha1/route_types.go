@@ -16,6 +16,7 @@ package v1alpha1
 
 import (
 	"context"
+	"fmt"
 	"net/url"
 	"path"
 	"reflect"
@@ -94,6 +95,12 @@ type RouteSpecFields struct {
 	// Path is the URL path of the route.
 	// +optional
 	Path string `json:"path,omitempty"`
+
+	// Port is the port reserved for a TCP route. Routes with a port can only
+	// be created on domains with a TCP router group and can't have a hostname
+	// or path.
+	// +optional
+	Port int32 `json:"port,omitempty"`
 }
 
 // RouteWeightBinding contains the fields of a route.
@@ -113,6 +120,12 @@ type RouteWeightBinding struct {
 	// before it was a required field, in which case it will be defaulted to 80
 	// at runtime.
 	DestinationPort *int32 `json:"destinationPort,omitempty"`
+
+	// MirrorPercent makes the App receive a copy of the given percentage of
+	// the Route's traffic instead of serving it. Responses from the App are
+	// discarded. Only one App can mirror a Route's traffic.
+	// +optional
+	MirrorPercent *int32 `json:"mirrorPercent,omitempty"`
 }
 
 // Merge adds the weights of two RouteWeightBindings that are equal.
@@ -222,17 +235,29 @@ func (rwb *RouteWeightBinding) Qualify(defaultDomain, serviceName string) (out Q
 		out.Destination.Weight = *rwb.Weight
 	}
 
+	if rwb.MirrorPercent != nil {
+		out.Destination.MirrorPercent = *rwb.MirrorPercent
+	}
+
 	return
 }
 
 // String returns a RouteSpecFields converted into an address.
 func (route RouteSpecFields) String() string {
+	if route.IsTCP() {
+		return fmt.Sprintf("%s:%d", route.Host(), route.Port)
+	}
 	if len(route.Path) == 0 || route.Path == "/" {
 		return route.Host()
 	}
 	return route.Host() + path.Join("/", route.Path)
 }
 
+// IsTCP returns whether or not the route is a TCP route on a reserved port.
+func (route RouteSpecFields) IsTCP() bool {
+	return route.Port != 0
+}
+
 // IsWildcard returns whether or not the route is a wildcard e.g. *.example.com.
 func (route RouteSpecFields) IsWildcard() bool {
 	return route.Hostname == "*"
@@ -254,6 +279,12 @@ func (route RouteSpecFields) Equals(cmpRoute RouteSpecFields) bool {
 
 // ToURL creates a URL from the RouteSpecFields
 func (route RouteSpecFields) ToURL() url.URL {
+	if route.IsTCP() {
+		return url.URL{
+			Host: fmt.Sprintf("%s:%d", route.Host(), route.Port),
+		}
+	}
+
 	return url.URL{
 		Host: route.Host(),
 		Path: route.Path,
@@ -264,6 +295,238 @@ func (route RouteSpecFields) ToURL() url.URL {
 type RouteSpec struct {
 	// RouteSpecFields contains the fields of a route.
 	RouteSpecFields `json:",inline"`
+
+	// Policy contains traffic settings for HTTP requests sent to Apps bound to
+	// the Route.
+	// +optional
+	Policy *RoutePolicy `json:"policy,omitempty"`
+
+	// Canary sends a percentage of the Route's traffic to a single App and
+	// optionally increases it over time.
+	// +optional
+	Canary *RouteCanary `json:"canary,omitempty"`
+
+	// Maintenance makes the Route respond with an error status instead of
+	// forwarding requests to the Apps bound to it. Apps stay bound so traffic
+	// resumes once it's removed.
+	// +optional
+	Maintenance *RouteMaintenance `json:"maintenance,omitempty"`
+
+	// SharedSpaces are other Spaces whose Apps can be mapped to the Route.
+	// The VirtualService in the Route's Space sends traffic to Apps in all
+	// of them. A shared Space must accept the Route by setting SharedFrom on
+	// a Route with the same fields.
+	// +optional
+	SharedSpaces []string `json:"sharedSpaces,omitempty"`
+
+	// SharedFrom accepts a Route with the same fields shared by the given
+	// Space. The Route is served by the VirtualService of that Space instead
+	// of this one's.
+	// +optional
+	SharedFrom string `json:"sharedFrom,omitempty"`
+}
+
+// IsSharedWith returns true if Apps in the given Space can be mapped to the
+// Route.
+func (r *RouteSpec) IsSharedWith(space string) bool {
+	for _, s := range r.SharedSpaces {
+		if s == space {
+			return true
+		}
+	}
+	return false
+}
+
+// AcceptsShareFrom returns true if the Route accepts being served by a Route
+// with the same fields in the given Space.
+func (r *RouteSpec) AcceptsShareFrom(space string) bool {
+	return r.SharedFrom != "" && r.SharedFrom == space
+}
+
+// RouteMaintenance puts a Route in maintenance mode.
+type RouteMaintenance struct {
+	// StatusCode is the HTTP status returned for requests to the Route,
+	// defaults to 503.
+	// +optional
+	StatusCode int32 `json:"statusCode,omitempty"`
+
+	// Message explains why the Route is in maintenance mode. It's the body of
+	// responses from the ingress gateway and is displayed when listing Routes.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// RouteCanary splits the traffic of a Route between a canary App and the
+// other Apps bound to the Route.
+type RouteCanary struct {
+	// AppName is the name of the canary App, it must be bound to the Route.
+	AppName string `json:"appName"`
+
+	// Weight is the percentage of traffic sent to the canary App, the
+	// remaining traffic is split between the other Apps using their weights.
+	// If a rollout is set, this is the starting percentage.
+	Weight int32 `json:"weight"`
+
+	// Rollout progressively increases the canary App's traffic until it
+	// receives all of it.
+	// +optional
+	Rollout *RouteCanaryRollout `json:"rollout,omitempty"`
+}
+
+// RouteCanaryRollout configures a progressive rollout of a canary App.
+type RouteCanaryRollout struct {
+	// StepWeight is the percentage of traffic added to the canary App on
+	// each step.
+	StepWeight int32 `json:"stepWeight"`
+
+	// Interval is the time between steps.
+	Interval metav1.Duration `json:"interval"`
+
+	// MaxErrorPercent aborts the rollout if the percentage of requests to
+	// the canary App that fail with a 5xx status code is higher. It's only
+	// checked if the cluster has a metrics source configured.
+	// +optional
+	MaxErrorPercent *int32 `json:"maxErrorPercent,omitempty"`
+}
+
+// RouteCanaryPhase is the state of a canary.
+type RouteCanaryPhase string
+
+const (
+	// RouteCanaryPhaseProgressing is set while a rollout increases the weight
+	// of the canary App.
+	RouteCanaryPhaseProgressing RouteCanaryPhase = "Progressing"
+	// RouteCanaryPhaseHolding is set when the canary App's weight is fixed.
+	RouteCanaryPhaseHolding RouteCanaryPhase = "Holding"
+	// RouteCanaryPhasePromoted is set once a rollout sends all traffic to
+	// the canary App.
+	RouteCanaryPhasePromoted RouteCanaryPhase = "Promoted"
+	// RouteCanaryPhaseAborted is set if a rollout was stopped because the
+	// canary App degraded, all traffic is sent to the other Apps.
+	RouteCanaryPhaseAborted RouteCanaryPhase = "Aborted"
+)
+
+// RouteCanaryStatus is the observed state of a canary.
+type RouteCanaryStatus struct {
+	// AppName is the name of the canary App.
+	AppName string `json:"appName"`
+
+	// Weight is the percentage of traffic currently sent to the canary App.
+	Weight int32 `json:"weight"`
+
+	// Phase is the state of the canary.
+	Phase RouteCanaryPhase `json:"phase"`
+
+	// LastStepTime is the time the weight was last changed.
+	// +optional
+	LastStepTime *metav1.Time `json:"lastStepTime,omitempty"`
+
+	// Message is a human readable explanation of the phase.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// ObservedCanary is the canary from the Route's spec the status was
+	// computed from. The rollout restarts if the weight or rollout settings
+	// change, other edits to the Route don't affect it.
+	// +optional
+	ObservedCanary *RouteCanary `json:"observedCanary,omitempty"`
+}
+
+// RoutePolicy contains the traffic settings for a Route.
+type RoutePolicy struct {
+	// Timeout is the maximum time a request can take, including retries.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Retries configures how failed requests are retried. Overrides the
+	// cluster-wide routeDisableRetries setting.
+	// +optional
+	Retries *RouteRetryPolicy `json:"retries,omitempty"`
+
+	// CORS configures Cross-Origin Resource Sharing for the Route.
+	// +optional
+	CORS *RouteCORSPolicy `json:"cors,omitempty"`
+
+	// RequestHeaders are modifications made to requests before they reach
+	// the App.
+	// +optional
+	RequestHeaders *RouteHeaderOperations `json:"requestHeaders,omitempty"`
+
+	// ResponseHeaders are modifications made to responses before they're
+	// returned to the client.
+	// +optional
+	ResponseHeaders *RouteHeaderOperations `json:"responseHeaders,omitempty"`
+
+	// RateLimit limits the rate of requests each client can make to the
+	// Route. It's enforced by the ingress gateway before requests reach the
+	// route service or Apps bound to the Route.
+	// +optional
+	RateLimit *RouteRateLimit `json:"rateLimit,omitempty"`
+}
+
+// RouteRateLimit limits the rate of requests clients can make to a Route.
+type RouteRateLimit struct {
+	// RequestsPerSecond is the number of requests each client can make per
+	// second, requests over the limit get a 429 response.
+	RequestsPerSecond int32 `json:"requestsPerSecond"`
+
+	// Header identifies clients by the value of the given request header,
+	// e.g. an API key. Clients are identified by their IP address if unset.
+	// +optional
+	Header string `json:"header,omitempty"`
+}
+
+// RouteRetryPolicy configures retries for a Route.
+type RouteRetryPolicy struct {
+	// Attempts is the number of retries for a request, 0 disables retries.
+	Attempts int32 `json:"attempts"`
+
+	// PerTryTimeout is the timeout for each attempt.
+	// +optional
+	PerTryTimeout *metav1.Duration `json:"perTryTimeout,omitempty"`
+
+	// RetryOn is the list of conditions that cause a request to be retried
+	// e.g. 5xx, connect-failure, reset or an HTTP status code.
+	// +optional
+	RetryOn []string `json:"retryOn,omitempty"`
+}
+
+// RouteCORSPolicy configures Cross-Origin Resource Sharing for a Route.
+type RouteCORSPolicy struct {
+	// AllowOrigins is the list of origins allowed to make requests, * allows
+	// all origins.
+	AllowOrigins []string `json:"allowOrigins"`
+
+	// AllowMethods is the list of methods allowed in requests.
+	// +optional
+	AllowMethods []string `json:"allowMethods,omitempty"`
+
+	// AllowHeaders is the list of headers allowed in requests.
+	// +optional
+	AllowHeaders []string `json:"allowHeaders,omitempty"`
+
+	// ExposeHeaders is the list of headers browsers are allowed to access.
+	// +optional
+	ExposeHeaders []string `json:"exposeHeaders,omitempty"`
+
+	// MaxAge is how long the results of a preflight request can be cached.
+	// +optional
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+
+	// AllowCredentials indicates whether the caller can send credentials.
+	// +optional
+	AllowCredentials bool `json:"allowCredentials,omitempty"`
+}
+
+// RouteHeaderOperations are modifications made to HTTP headers.
+type RouteHeaderOperations struct {
+	// Add appends the values to the given headers.
+	// +optional
+	Add map[string]string `json:"add,omitempty"`
+
+	// Remove removes the given headers.
+	// +optional
+	Remove []string `json:"remove,omitempty"`
 }
 
 // RouteStatus is the current configuration for a Route.
@@ -285,6 +548,10 @@ type RouteStatus struct {
 
 	// RouteService is the Route Service instance bound to the route, if one exists.
 	RouteService corev1.LocalObjectReference `json:"routeService,omitempty"`
+
+	// Canary is the state of the canary set on the Route, if one exists.
+	// +optional
+	Canary *RouteCanaryStatus `json:"canary,omitempty"`
 }
 
 // RouteServiceBinding represents a binding between a route and a route service.
@@ -308,6 +575,15 @@ type RouteDestination struct {
 
 	// Weight is the proportion of traffic to send to this binding.
 	Weight int32 `json:"weight"` // always encode because zero is meaningful
+
+	// MirrorPercent is the percentage of traffic copied to this binding, if
+	// set the binding doesn't serve traffic.
+	MirrorPercent int32 `json:"mirrorPercent,omitempty"`
+}
+
+// IsMirror returns true if the destination only receives copies of traffic.
+func (rd RouteDestination) IsMirror() bool {
+	return rd.MirrorPercent > 0
 }
 
 // QualifiedRouteBinding contains a fully qualified route binding with
@@ -326,11 +602,17 @@ var _ map[QualifiedRouteBinding]interface{}
 // ToUnqualified converts the QualifiedRouteBinding back into an unqualified
 // one.
 func (qrb *QualifiedRouteBinding) ToUnqualified() RouteWeightBinding {
-	return RouteWeightBinding{
+	out := RouteWeightBinding{
 		RouteSpecFields: qrb.Source,
 		DestinationPort: ptr.Int32(qrb.Destination.Port),
 		Weight:          &qrb.Destination.Weight,
 	}
+
+	if qrb.Destination.IsMirror() {
+		out.MirrorPercent = ptr.Int32(qrb.Destination.MirrorPercent)
+	}
+
+	return out
 }
 
 // MergableWith returns true if the binding has matching fields across the board
@@ -338,7 +620,8 @@ func (qrb *QualifiedRouteBinding) ToUnqualified() RouteWeightBinding {
 func (qrb *QualifiedRouteBinding) MergableWith(other QualifiedRouteBinding) bool {
 	return qrb.Source == other.Source &&
 		qrb.Destination.Port == other.Destination.Port &&
-		qrb.Destination.ServiceName == other.Destination.ServiceName
+		qrb.Destination.ServiceName == other.Destination.ServiceName &&
+		qrb.Destination.MirrorPercent == other.Destination.MirrorPercent
 }
 
 // Merge adds the weight of two QualifiedRouteBindings that have all other
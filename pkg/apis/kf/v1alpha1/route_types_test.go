@@ -377,6 +377,26 @@ func TestRouteWeightBinding_Qualify(t *testing.T) {
 				},
 			},
 		},
+		"mirror binding": {
+			binding: RouteWeightBinding{
+				MirrorPercent: ptr.Int32(10),
+				RouteSpecFields: RouteSpecFields{
+					Hostname: "host",
+				},
+			},
+			wantQualified: QualifiedRouteBinding{
+				Source: RouteSpecFields{
+					Hostname: "host",
+					Domain:   defaultDomain,
+				},
+				Destination: RouteDestination{
+					Port:          DefaultRouteDestinationPort,
+					ServiceName:   serviceName,
+					Weight:        defaultRouteWeight,
+					MirrorPercent: 10,
+				},
+			},
+		},
 	}
 
 	for tn, tc := range cases {
@@ -413,6 +433,29 @@ func ExampleQualifiedRouteBinding_ToUnqualified() {
 	// Weight 33
 }
 
+func ExampleQualifiedRouteBinding_ToUnqualified_mirror() {
+	qrb := QualifiedRouteBinding{
+		Source: RouteSpecFields{
+			Hostname: "host",
+			Domain:   "some.domain",
+		},
+		Destination: RouteDestination{
+			Port:          8080,
+			ServiceName:   "my-service",
+			Weight:        1,
+			MirrorPercent: 25,
+		},
+	}
+
+	unqualified := qrb.ToUnqualified()
+
+	fmt.Println("URL", unqualified.String())
+	fmt.Println("MirrorPercent", *unqualified.MirrorPercent)
+
+	// Output: URL host.some.domain
+	// MirrorPercent 25
+}
+
 func TestMergeQualifiedBindings(t *testing.T) {
 	t.Parallel()
 
@@ -459,6 +502,24 @@ func TestMergeQualifiedBindings(t *testing.T) {
 				qrb("test", 7),
 			},
 		},
+		"mirrors aren't merged with serving bindings": {
+			in: []QualifiedRouteBinding{
+				qrb("test", 1),
+				func() QualifiedRouteBinding {
+					mirror := qrb("test", 1)
+					mirror.Destination.MirrorPercent = 10
+					return mirror
+				}(),
+			},
+			want: []QualifiedRouteBinding{
+				qrb("test", 1),
+				func() QualifiedRouteBinding {
+					mirror := qrb("test", 1)
+					mirror.Destination.MirrorPercent = 10
+					return mirror
+				}(),
+			},
+		},
 		"merge preserves first encounter order": {
 			in: []QualifiedRouteBinding{
 				qrb("a", 1),
@@ -16,9 +16,12 @@ package v1alpha1
 
 import (
 	"context"
+	"strconv"
+	"strings"
 
 	"github.com/google/kf/v2/pkg/apis/kf"
 	"github.com/gorilla/mux"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/pkg/apis"
 )
@@ -55,6 +58,15 @@ func (r *RouteWeightBinding) Validate(ctx context.Context) (errs *apis.FieldErro
 		errs = errs.Also(kf.ValidatePortNumberBounds(*r.DestinationPort, "destinationPort"))
 	}
 
+	if r.MirrorPercent != nil {
+		if r.Port != 0 {
+			// TCP connections can't be mirrored.
+			errs = errs.Also(apis.ErrDisallowedFields("mirrorPercent"))
+		} else if *r.MirrorPercent < 1 || *r.MirrorPercent > 100 {
+			errs = errs.Also(apis.ErrOutOfBoundsValue(*r.MirrorPercent, 1, 100, "mirrorPercent"))
+		}
+	}
+
 	// don't include a ViaField because the field is embedded
 	return errs.Also(r.RouteSpecFields.Validate(ctx))
 }
@@ -99,6 +111,19 @@ func (r *RouteSpecFields) Validate(ctx context.Context) (errs *apis.FieldError)
 		errs = errs.Also(apis.ErrInvalidValue(r.Path, "path"))
 	}
 
+	if r.Port != 0 {
+		errs = errs.Also(kf.ValidatePortNumberBounds(r.Port, "port"))
+
+		// TCP routes are matched only by domain and port.
+		if r.Hostname != "" {
+			errs = errs.Also(apis.ErrDisallowedFields("hostname"))
+		}
+
+		if r.Path != "" && r.Path != "/" {
+			errs = errs.Also(apis.ErrDisallowedFields("path"))
+		}
+	}
+
 	return errs
 }
 
@@ -113,13 +138,277 @@ func (r *Route) Validate(ctx context.Context) (errs *apis.FieldError) {
 	errs = errs.Also(apis.ValidateObjectMetadata(r.GetObjectMeta()).ViaField("metadata"))
 	errs = errs.Also(r.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
 
+	// Routes are always reachable from their own Space.
+	for idx, space := range r.Spec.SharedSpaces {
+		if space == r.Namespace {
+			errs = errs.Also(apis.ErrInvalidArrayValue(space, "sharedSpaces", idx).ViaField("spec"))
+		}
+	}
+
+	if r.Spec.SharedFrom != "" && r.Spec.SharedFrom == r.Namespace {
+		errs = errs.Also(apis.ErrInvalidValue(r.Spec.SharedFrom, "sharedFrom").ViaField("spec"))
+	}
+
 	return errs
 }
 
 // Validate validates a RouteSpec.
 func (r *RouteSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	// don't include a ViaField because the field is embedded
-	return errs.Also(r.RouteSpecFields.Validate(ctx))
+	errs = errs.Also(r.RouteSpecFields.Validate(ctx))
+
+	if r.Policy != nil {
+		if r.Port != 0 {
+			// Policies only apply to HTTP requests.
+			errs = errs.Also(apis.ErrDisallowedFields("policy"))
+		} else {
+			errs = errs.Also(r.Policy.Validate(ctx).ViaField("policy"))
+		}
+	}
+
+	if r.Canary != nil {
+		errs = errs.Also(r.Canary.Validate(ctx).ViaField("canary"))
+	}
+
+	if r.Maintenance != nil {
+		if r.Port != 0 {
+			// TCP connections can't be answered with an HTTP status.
+			errs = errs.Also(apis.ErrDisallowedFields("maintenance"))
+		} else {
+			errs = errs.Also(r.Maintenance.Validate(ctx).ViaField("maintenance"))
+		}
+	}
+
+	if len(r.SharedSpaces) > 0 {
+		if r.Port != 0 {
+			// TCP ports are reserved by a single Space.
+			errs = errs.Also(apis.ErrDisallowedFields("sharedSpaces"))
+		} else {
+			seen := sets.NewString()
+			for idx, space := range r.SharedSpaces {
+				if len(validation.IsDNS1123Label(space)) > 0 || seen.Has(space) {
+					errs = errs.Also(apis.ErrInvalidArrayValue(space, "sharedSpaces", idx))
+				}
+				seen.Insert(space)
+			}
+		}
+	}
+
+	if r.SharedFrom != "" {
+		switch {
+		case r.Port != 0:
+			errs = errs.Also(apis.ErrDisallowedFields("sharedFrom"))
+		case len(r.SharedSpaces) > 0:
+			// A Route is either served by its own Space or another one.
+			errs = errs.Also(apis.ErrMultipleOneOf("sharedFrom", "sharedSpaces"))
+		case len(validation.IsDNS1123Label(r.SharedFrom)) > 0:
+			errs = errs.Also(apis.ErrInvalidValue(r.SharedFrom, "sharedFrom"))
+		}
+	}
+
+	return errs
+}
+
+// Validate validates a RouteMaintenance.
+func (m *RouteMaintenance) Validate(ctx context.Context) (errs *apis.FieldError) {
+	// Zero is defaulted to 503.
+	if m.StatusCode != 0 && (m.StatusCode < 400 || m.StatusCode > 599) {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(m.StatusCode, 400, 599, "statusCode"))
+	}
+
+	// Envoy doesn't serve direct responses with larger bodies.
+	if len(m.Message) > MaxRouteMaintenanceMessageBytes {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(len(m.Message), 0, MaxRouteMaintenanceMessageBytes, "message"))
+	}
+
+	return errs
+}
+
+// Validate validates a RouteCanary.
+func (c *RouteCanary) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if c.AppName == "" {
+		errs = errs.Also(apis.ErrMissingField("appName"))
+	}
+
+	if c.Weight < 0 || c.Weight > 100 {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(c.Weight, 0, 100, "weight"))
+	}
+
+	if c.Rollout != nil {
+		errs = errs.Also(c.Rollout.Validate(ctx).ViaField("rollout"))
+	}
+
+	return errs
+}
+
+// Validate validates a RouteCanaryRollout.
+func (r *RouteCanaryRollout) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if r.StepWeight < 1 || r.StepWeight > 100 {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(r.StepWeight, 1, 100, "stepWeight"))
+	}
+
+	if r.Interval.Duration <= 0 {
+		errs = errs.Also(apis.ErrInvalidValue(r.Interval.Duration.String(), "interval"))
+	}
+
+	if r.MaxErrorPercent != nil && (*r.MaxErrorPercent < 0 || *r.MaxErrorPercent > 100) {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(*r.MaxErrorPercent, 0, 100, "maxErrorPercent"))
+	}
+
+	return errs
+}
+
+// retryConditions holds the retry conditions supported by Istio in addition
+// to HTTP status codes.
+var retryConditions = sets.NewString(
+	"5xx",
+	"gateway-error",
+	"reset",
+	"connect-failure",
+	"retriable-4xx",
+	"refused-stream",
+	"retriable-status-codes",
+	"retriable-headers",
+	"cancelled",
+	"deadline-exceeded",
+	"internal",
+	"resource-exhausted",
+	"unavailable",
+)
+
+// Validate validates a RoutePolicy.
+func (p *RoutePolicy) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if p.Timeout != nil && p.Timeout.Duration <= 0 {
+		errs = errs.Also(apis.ErrInvalidValue(p.Timeout.Duration.String(), "timeout"))
+	}
+
+	if p.Retries != nil {
+		errs = errs.Also(p.Retries.Validate(ctx).ViaField("retries"))
+	}
+
+	if p.CORS != nil {
+		errs = errs.Also(p.CORS.Validate(ctx).ViaField("cors"))
+	}
+
+	if p.RequestHeaders != nil {
+		errs = errs.Also(p.RequestHeaders.Validate(ctx).ViaField("requestHeaders"))
+	}
+
+	if p.ResponseHeaders != nil {
+		errs = errs.Also(p.ResponseHeaders.Validate(ctx).ViaField("responseHeaders"))
+	}
+
+	if p.RateLimit != nil {
+		errs = errs.Also(p.RateLimit.Validate(ctx).ViaField("rateLimit"))
+	}
+
+	return errs
+}
+
+// Validate validates a RouteRateLimit.
+func (l *RouteRateLimit) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if l.RequestsPerSecond < 1 {
+		errs = errs.Also(apis.ErrInvalidValue(l.RequestsPerSecond, "requestsPerSecond"))
+	}
+
+	if l.Header != "" && !isHTTPToken(l.Header) {
+		errs = errs.Also(apis.ErrInvalidValue(l.Header, "header"))
+	}
+
+	return errs
+}
+
+// Validate validates a RouteRetryPolicy.
+func (p *RouteRetryPolicy) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if p.Attempts < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(p.Attempts, "attempts"))
+	}
+
+	if p.PerTryTimeout != nil && p.PerTryTimeout.Duration <= 0 {
+		errs = errs.Also(apis.ErrInvalidValue(p.PerTryTimeout.Duration.String(), "perTryTimeout"))
+	}
+
+	for idx, condition := range p.RetryOn {
+		if retryConditions.Has(condition) {
+			continue
+		}
+
+		if code, err := strconv.Atoi(condition); err == nil && code >= 100 && code <= 599 {
+			continue
+		}
+
+		errs = errs.Also(apis.ErrInvalidArrayValue(condition, "retryOn", idx))
+	}
+
+	return errs
+}
+
+// Validate validates a RouteCORSPolicy.
+func (p *RouteCORSPolicy) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if len(p.AllowOrigins) == 0 {
+		errs = errs.Also(apis.ErrMissingField("allowOrigins"))
+	}
+
+	for idx, origin := range p.AllowOrigins {
+		if origin == "" {
+			errs = errs.Also(apis.ErrInvalidArrayValue(origin, "allowOrigins", idx))
+		}
+	}
+
+	for idx, method := range p.AllowMethods {
+		if !isHTTPToken(method) {
+			errs = errs.Also(apis.ErrInvalidArrayValue(method, "allowMethods", idx))
+		}
+	}
+
+	errs = errs.Also(validateHeaderNames(p.AllowHeaders, "allowHeaders"))
+	errs = errs.Also(validateHeaderNames(p.ExposeHeaders, "exposeHeaders"))
+
+	if p.MaxAge != nil && p.MaxAge.Duration < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(p.MaxAge.Duration.String(), "maxAge"))
+	}
+
+	return errs
+}
+
+// Validate validates a RouteHeaderOperations.
+func (h *RouteHeaderOperations) Validate(ctx context.Context) (errs *apis.FieldError) {
+	for _, name := range sets.StringKeySet(h.Add).List() {
+		if !isHTTPToken(name) {
+			errs = errs.Also(apis.ErrInvalidKeyName(name, "add"))
+		}
+	}
+
+	return errs.Also(validateHeaderNames(h.Remove, "remove"))
+}
+
+func validateHeaderNames(names []string, field string) (errs *apis.FieldError) {
+	for idx, name := range names {
+		if !isHTTPToken(name) {
+			errs = errs.Also(apis.ErrInvalidArrayValue(name, field, idx))
+		}
+	}
+
+	return errs
+}
+
+// isHTTPToken returns true if the value is a valid HTTP token as defined by
+// RFC 7230, which is used for header names and methods.
+func isHTTPToken(value string) bool {
+	if value == "" {
+		return false
+	}
+
+	for _, r := range value {
+		if !strings.ContainsRune("!#$%&'*+-.^_`|~", r) &&
+			!('0' <= r && r <= '9') &&
+			!('a' <= r && r <= 'z') &&
+			!('A' <= r && r <= 'Z') {
+			return false
+		}
+	}
+
+	return true
 }
 
 // BuildPathRegexp uses gorilla/mux to convert a path into regular expression
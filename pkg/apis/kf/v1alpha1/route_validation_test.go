@@ -18,6 +18,7 @@ import (
 	"context"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/kf/v2/pkg/apis/networking/v1alpha3"
 	"github.com/google/kf/v2/pkg/client/networking/clientset/versioned/typed/networking/v1alpha3/fake"
@@ -26,6 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	ktesting "k8s.io/client-go/testing"
 	"knative.dev/pkg/apis"
+	"knative.dev/pkg/ptr"
 )
 
 func TestRouteValidation(t *testing.T) {
@@ -136,6 +138,341 @@ func TestRouteValidation(t *testing.T) {
 			},
 			want: nil,
 		},
+		"tcp route": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: RouteSpecFields{
+						Domain: "tcp.example.com",
+						Port:   1024,
+					},
+				},
+			},
+			want: nil,
+		},
+		"tcp route with hostname and path": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: RouteSpecFields{
+						Hostname: "some-hostname",
+						Domain:   "tcp.example.com",
+						Path:     "/myapp",
+						Port:     1024,
+					},
+				},
+			},
+			want: apis.ErrDisallowedFields("spec.hostname", "spec.path"),
+		},
+		"tcp route with out of bounds port": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: RouteSpecFields{
+						Domain: "tcp.example.com",
+						Port:   70000,
+					},
+				},
+			},
+			want: apis.ErrOutOfBoundsValue(70000, 1, 65535, "spec.port"),
+		},
+		"valid policy": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: RouteSpecFields{
+						Hostname: "some-hostname",
+						Domain:   "example.com",
+					},
+					Policy: &RoutePolicy{
+						Timeout: &metav1.Duration{Duration: 30 * time.Second},
+						Retries: &RouteRetryPolicy{
+							Attempts: 3,
+							RetryOn:  []string{"5xx", "connect-failure", "503"},
+						},
+						CORS: &RouteCORSPolicy{
+							AllowOrigins: []string{"*"},
+							AllowMethods: []string{"GET", "POST"},
+							AllowHeaders: []string{"Content-Type"},
+						},
+						RequestHeaders: &RouteHeaderOperations{
+							Add:    map[string]string{"X-Env": "prod"},
+							Remove: []string{"X-Debug"},
+						},
+						RateLimit: &RouteRateLimit{
+							RequestsPerSecond: 10,
+							Header:            "X-Api-Key",
+						},
+					},
+				},
+			},
+			want: nil,
+		},
+		"invalid policy": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: RouteSpecFields{
+						Hostname: "some-hostname",
+						Domain:   "example.com",
+					},
+					Policy: &RoutePolicy{
+						Timeout: &metav1.Duration{Duration: -1 * time.Second},
+						Retries: &RouteRetryPolicy{
+							Attempts: -1,
+							RetryOn:  []string{"sometimes", "600"},
+						},
+						CORS: &RouteCORSPolicy{
+							AllowMethods: []string{"GET POST"},
+						},
+						ResponseHeaders: &RouteHeaderOperations{
+							Add:    map[string]string{"bad header": "value"},
+							Remove: []string{""},
+						},
+						RateLimit: &RouteRateLimit{
+							Header: "bad header",
+						},
+					},
+				},
+			},
+			want: apis.ErrInvalidValue("-1s", "spec.policy.timeout").Also(
+				apis.ErrInvalidValue(-1, "spec.policy.retries.attempts"),
+				apis.ErrInvalidArrayValue("sometimes", "spec.policy.retries.retryOn", 0),
+				apis.ErrInvalidArrayValue("600", "spec.policy.retries.retryOn", 1),
+				apis.ErrMissingField("spec.policy.cors.allowOrigins"),
+				apis.ErrInvalidArrayValue("GET POST", "spec.policy.cors.allowMethods", 0),
+				apis.ErrInvalidKeyName("bad header", "spec.policy.responseHeaders.add"),
+				apis.ErrInvalidArrayValue("", "spec.policy.responseHeaders.remove", 0),
+				apis.ErrInvalidValue(0, "spec.policy.rateLimit.requestsPerSecond"),
+				apis.ErrInvalidValue("bad header", "spec.policy.rateLimit.header"),
+			),
+		},
+		"tcp route with policy": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: RouteSpecFields{
+						Domain: "tcp.example.com",
+						Port:   1024,
+					},
+					Policy: &RoutePolicy{
+						Timeout: &metav1.Duration{Duration: time.Second},
+					},
+				},
+			},
+			want: apis.ErrDisallowedFields("spec.policy"),
+		},
+		"valid maintenance": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: RouteSpecFields{
+						Hostname: "some-hostname",
+						Domain:   "example.com",
+					},
+					Maintenance: &RouteMaintenance{
+						StatusCode: 503,
+						Message:    "Database migration",
+					},
+				},
+			},
+			want: nil,
+		},
+		"maintenance with invalid status": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: RouteSpecFields{
+						Hostname: "some-hostname",
+						Domain:   "example.com",
+					},
+					Maintenance: &RouteMaintenance{
+						StatusCode: 200,
+					},
+				},
+			},
+			want: apis.ErrOutOfBoundsValue(200, 400, 599, "spec.maintenance.statusCode"),
+		},
+		"maintenance message too long": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: RouteSpecFields{
+						Hostname: "some-hostname",
+						Domain:   "example.com",
+					},
+					Maintenance: &RouteMaintenance{
+						Message: strings.Repeat("a", 4097),
+					},
+				},
+			},
+			want: apis.ErrOutOfBoundsValue(4097, 0, 4096, "spec.maintenance.message"),
+		},
+		"tcp route with maintenance": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: RouteSpecFields{
+						Domain: "tcp.example.com",
+						Port:   1024,
+					},
+					Maintenance: &RouteMaintenance{},
+				},
+			},
+			want: apis.ErrDisallowedFields("spec.maintenance"),
+		},
+		"shared spaces": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: RouteSpecFields{
+						Hostname: "some-hostname",
+						Domain:   "example.com",
+					},
+					SharedSpaces: []string{"other-space", "third-space"},
+				},
+			},
+			want: nil,
+		},
+		"invalid shared spaces": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: RouteSpecFields{
+						Hostname: "some-hostname",
+						Domain:   "example.com",
+					},
+					SharedSpaces: []string{"Not_A_Space", "other-space", "other-space", "valid"},
+				},
+			},
+			want: apis.ErrInvalidArrayValue("Not_A_Space", "spec.sharedSpaces", 0).Also(
+				apis.ErrInvalidArrayValue("other-space", "spec.sharedSpaces", 2),
+				apis.ErrInvalidArrayValue("valid", "spec.sharedSpaces", 3),
+			),
+		},
+		"tcp route with shared spaces": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: RouteSpecFields{
+						Domain: "tcp.example.com",
+						Port:   1024,
+					},
+					SharedSpaces: []string{"other-space"},
+				},
+			},
+			want: apis.ErrDisallowedFields("spec.sharedSpaces"),
+		},
+		"shared from": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: RouteSpecFields{
+						Hostname: "some-hostname",
+						Domain:   "example.com",
+					},
+					SharedFrom: "other-space",
+				},
+			},
+			want: nil,
+		},
+		"shared from own space": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: RouteSpecFields{
+						Hostname: "some-hostname",
+						Domain:   "example.com",
+					},
+					SharedFrom: "valid",
+				},
+			},
+			want: apis.ErrInvalidValue("valid", "spec.sharedFrom"),
+		},
+		"invalid shared from": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: RouteSpecFields{
+						Hostname: "some-hostname",
+						Domain:   "example.com",
+					},
+					SharedFrom: "Not_A_Space",
+				},
+			},
+			want: apis.ErrInvalidValue("Not_A_Space", "spec.sharedFrom"),
+		},
+		"shared from and shared spaces": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: RouteSpecFields{
+						Hostname: "some-hostname",
+						Domain:   "example.com",
+					},
+					SharedSpaces: []string{"third-space"},
+					SharedFrom:   "other-space",
+				},
+			},
+			want: apis.ErrMultipleOneOf("spec.sharedFrom", "spec.sharedSpaces"),
+		},
+		"tcp route with shared from": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: RouteSpecFields{
+						Domain: "tcp.example.com",
+						Port:   1024,
+					},
+					SharedFrom: "other-space",
+				},
+			},
+			want: apis.ErrDisallowedFields("spec.sharedFrom"),
+		},
+		"valid canary": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: RouteSpecFields{
+						Hostname: "some-hostname",
+						Domain:   "example.com",
+					},
+					Canary: &RouteCanary{
+						AppName: "my-app-v2",
+						Weight:  10,
+						Rollout: &RouteCanaryRollout{
+							StepWeight:      10,
+							Interval:        metav1.Duration{Duration: time.Minute},
+							MaxErrorPercent: ptr.Int32(5),
+						},
+					},
+				},
+			},
+			want: nil,
+		},
+		"invalid canary": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: RouteSpecFields{
+						Hostname: "some-hostname",
+						Domain:   "example.com",
+					},
+					Canary: &RouteCanary{
+						Weight: 101,
+						Rollout: &RouteCanaryRollout{
+							MaxErrorPercent: ptr.Int32(-1),
+						},
+					},
+				},
+			},
+			want: apis.ErrMissingField("spec.canary.appName").Also(
+				apis.ErrOutOfBoundsValue(101, 0, 100, "spec.canary.weight"),
+				apis.ErrOutOfBoundsValue(0, 1, 100, "spec.canary.rollout.stepWeight"),
+				apis.ErrInvalidValue("0s", "spec.canary.rollout.interval"),
+				apis.ErrOutOfBoundsValue(-1, 0, 100, "spec.canary.rollout.maxErrorPercent"),
+			),
+		},
 		"hostname is missing": {
 			route: &Route{
 				ObjectMeta: goodObjMeta,
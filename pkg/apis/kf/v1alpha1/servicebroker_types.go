@@ -17,6 +17,7 @@ package v1alpha1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"knative.dev/pkg/apis"
 	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
@@ -229,6 +230,27 @@ type ServicePlan struct {
 	UID string `json:"uid"`
 	// Description is a human readable description of the plan.
 	Description string `json:"description"`
+	// Schemas contains the JSON schemas the broker published for parameters
+	// accepted by the plan.
+	// +optional
+	Schemas *ServicePlanSchemas `json:"schemas,omitempty"`
+}
+
+// ServicePlanSchemas holds the JSON schemas for parameters accepted by a plan.
+// https://github.com/openservicebrokerapi/servicebroker/blob/master/spec.md#schemas-object
+type ServicePlanSchemas struct {
+	// InstanceCreate is the schema for parameters used to create a service
+	// instance.
+	// +optional
+	InstanceCreate *runtime.RawExtension `json:"instanceCreate,omitempty"`
+	// InstanceUpdate is the schema for parameters used to update a service
+	// instance.
+	// +optional
+	InstanceUpdate *runtime.RawExtension `json:"instanceUpdate,omitempty"`
+	// BindingCreate is the schema for parameters used to bind a service
+	// instance.
+	// +optional
+	BindingCreate *runtime.RawExtension `json:"bindingCreate,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -19,10 +19,17 @@ import (
 	"fmt"
 
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
 	osbclient "sigs.k8s.io/go-open-service-broker-client/v2"
 )
 
+// ServiceInstanceBindingConditionCredentialsRotated reports the outcome of the
+// latest credential rotation. It's informational and doesn't affect the
+// binding's readiness because failed rotations keep the previous credentials.
+const ServiceInstanceBindingConditionCredentialsRotated apis.ConditionType = "CredentialsRotated"
+
 // GetGroupVersionKind returns the GroupVersionKind.
 func (r *ServiceInstanceBinding) GetGroupVersionKind() schema.GroupVersionKind {
 	return SchemeGroupVersion.WithKind("ServiceInstanceBinding")
@@ -129,6 +136,82 @@ func (status *ServiceInstanceBindingStatus) PropagateServiceFieldsStatus(service
 	status.ServiceFields = *serviceInstance.Status.ServiceFields.DeepCopy()
 }
 
+// PropagateRotationStarted resets the OSB state of the binding so new
+// credentials get bound with bindingID. The binding stays ready with the
+// credentials of previousBindingID until the new ones are written, and those
+// stay valid until they're unbound after the rotation grace period.
+func (status *ServiceInstanceBindingStatus) PropagateRotationStarted(
+	rotateRequests int,
+	previousBindingID string,
+	bindingID string,
+) {
+	status.RotateRequests = rotateRequests
+	status.PreviousBindingID = previousBindingID
+	status.PreviousBindingUnbindAfter = nil
+	status.BindingID = bindingID
+	status.OSBStatus = BindingOSBStatus{}
+	status.manage().MarkUnknown(
+		ServiceInstanceBindingConditionCredentialsRotated,
+		"Rotating",
+		"binding new credentials",
+	)
+}
+
+// PropagateRotatedCredentialsWritten records that the credentials Secret holds
+// the credentials of a rotation. The previous credentials are unbound after
+// unbindAfter.
+func (status *ServiceInstanceBindingStatus) PropagateRotatedCredentialsWritten(unbindAfter metav1.Time) {
+	status.PreviousBindingUnbindAfter = &unbindAfter
+	status.manage().MarkTrue(ServiceInstanceBindingConditionCredentialsRotated)
+}
+
+// PropagateRotationFailed rolls back a rotation whose new credentials couldn't
+// be bound. The binding keeps using the previous credentials, which are still
+// bound, so the rotation can be requested again.
+func (status *ServiceInstanceBindingStatus) PropagateRotationFailed(err error) {
+	status.manage().MarkFalse(
+		ServiceInstanceBindingConditionCredentialsRotated,
+		"RotationFailed",
+		"%v",
+		err,
+	)
+	status.BindingID = status.PreviousBindingID
+	status.PreviousBindingID = ""
+	status.PreviousBindingUnbindAfter = nil
+	status.OSBStatus = BindingOSBStatus{
+		Bound: &OSBState{},
+	}
+}
+
+// IsRotating returns true if new credentials are being bound to replace the
+// existing ones.
+func (status *ServiceInstanceBindingStatus) IsRotating() bool {
+	return status.PreviousBindingID != "" &&
+		(status.OSBStatus.IsBlank() || status.OSBStatus.Binding != nil)
+}
+
+// IsWaitingForRotatedCredentials returns true if the credentials Secret still
+// holds the credentials replaced by a rotation.
+func (status *ServiceInstanceBindingStatus) IsWaitingForRotatedCredentials() bool {
+	return status.PreviousBindingID != "" && status.PreviousBindingUnbindAfter == nil
+}
+
+// PropagatePreviousUnbindStatus propagates the result of unbinding the
+// credentials replaced by a rotation. The previous binding is forgotten once
+// the broker accepts the request or reports it's already gone, otherwise it's
+// kept so the unbind can be retried.
+func (status *ServiceInstanceBindingStatus) PropagatePreviousUnbindStatus(
+	response *osbclient.UnbindResponse,
+	err error,
+) {
+	if err != nil && !isDeletedOSBError(err) {
+		return
+	}
+
+	status.PreviousBindingID = ""
+	status.PreviousBindingUnbindAfter = nil
+}
+
 // PropagateServiceInstanceStatus propagates the Service Instance status to the Service Binding status.
 func (status *ServiceInstanceBindingStatus) PropagateServiceInstanceStatus(serviceInstance *ServiceInstance) {
 	cond := serviceInstance.Status.GetCondition(ServiceInstanceConditionReady)
@@ -277,6 +360,9 @@ func (status *ServiceInstanceBindingStatus) PropagateBindStatus(
 	condition := status.BackingResourceCondition()
 
 	switch {
+	case err != nil && status.IsRotating():
+		status.PropagateRotationFailed(err)
+
 	case err != nil:
 		condition.MarkReconciliationError("Binding", err)
 		status.OSBStatus = BindingOSBStatus{
@@ -284,7 +370,11 @@ func (status *ServiceInstanceBindingStatus) PropagateBindStatus(
 		}
 
 	case response.Async:
-		condition.MarkUnknown("BindingAsync", "operation is pending")
+		// Rotations keep the previous credentials ready until the new ones
+		// are bound.
+		if !status.IsRotating() {
+			condition.MarkUnknown("BindingAsync", "operation is pending")
+		}
 		status.OSBStatus = BindingOSBStatus{
 			Binding: &OSBState{
 				OperationKey: (*string)(response.OperationKey),
@@ -314,6 +404,17 @@ func (status *ServiceInstanceBindingStatus) PropagateBindLastOperationStatus(
 
 	condition := status.BackingResourceCondition()
 	switch {
+	case status.IsRotating() && (isRetryableOSBError(err) ||
+		(err == nil && osbclient.StateInProgress == response.State)):
+		// Rotations keep the previous credentials ready until the new ones
+		// are bound.
+
+	case status.IsRotating() && err != nil:
+		status.PropagateRotationFailed(err)
+
+	case status.IsRotating() && osbclient.StateSucceeded != response.State:
+		status.PropagateRotationFailed(fmt.Errorf("bind failed: %s", formatOperationMessage(response)))
+
 	case isRetryableOSBError(err):
 		condition.MarkUnknown(
 			reasonBindingAsync,
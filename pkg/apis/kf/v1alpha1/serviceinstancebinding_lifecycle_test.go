@@ -21,6 +21,7 @@ import (
 
 	"github.com/google/kf/v2/pkg/kf/testutil"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	osbclient "sigs.k8s.io/go-open-service-broker-client/v2"
 )
 
@@ -294,6 +295,204 @@ func TestServiceInstanceBindingStatus_PropagateBindStatus(t *testing.T) {
 	}
 }
 
+func TestServiceInstanceBindingStatus_PropagateRotationStarted(t *testing.T) {
+	t.Parallel()
+
+	status := &ServiceInstanceBindingStatus{}
+	status.InitializeConditions()
+	status.PropagateBindStatus(&osbclient.BindResponse{}, nil)
+	status.PropagateCredentialsSecretStatus(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "credentials"}})
+	testutil.AssertFalse(t, "IsRotating before", status.IsRotating())
+	original := status.DeepCopy()
+
+	status.PropagateRotationStarted(2, "old-id", "new-id")
+
+	testutil.AssertEqual(t, "RotateRequests", 2, status.RotateRequests)
+	testutil.AssertEqual(t, "PreviousBindingID", "old-id", status.PreviousBindingID)
+	testutil.AssertEqual(t, "BindingID", "new-id", status.BindingID)
+	testutil.AssertTrue(t, "OSBStatus blank", status.OSBStatus.IsBlank())
+	testutil.AssertTrue(t, "IsRotating", status.IsRotating())
+	testutil.AssertTrue(t, "IsWaitingForRotatedCredentials", status.IsWaitingForRotatedCredentials())
+
+	// The binding keeps serving the previous credentials while rotating.
+	testutil.AssertEqual(t, "CredentialsSecretRef", original.CredentialsSecretRef, status.CredentialsSecretRef)
+	testutil.AssertEqual(t, "Ready", original.GetCondition(ServiceInstanceBindingConditionReady), status.GetCondition(ServiceInstanceBindingConditionReady))
+	testutil.AssertEqual(
+		t,
+		"CredentialsRotated",
+		corev1.ConditionUnknown,
+		status.GetCondition(ServiceInstanceBindingConditionCredentialsRotated).Status,
+	)
+
+	status.PropagateBindStatus(&osbclient.BindResponse{}, nil)
+	testutil.AssertFalse(t, "IsRotating after bind", status.IsRotating())
+	testutil.AssertTrue(t, "IsWaitingForRotatedCredentials after bind", status.IsWaitingForRotatedCredentials())
+}
+
+func TestServiceInstanceBindingStatus_PropagateBindLastOperationStatusRotating(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		response      *osbclient.LastOperationResponse
+		err           error
+		wantCondition corev1.ConditionStatus
+		wantRotating  bool
+		wantRollback  bool
+	}{
+		"500 error stays ready": {
+			err:           &osbclient.HTTPStatusCodeError{StatusCode: 500},
+			wantCondition: corev1.ConditionTrue,
+			wantRotating:  true,
+		},
+		"in-progress operation stays ready": {
+			response:      &osbclient.LastOperationResponse{State: osbclient.StateInProgress},
+			wantCondition: corev1.ConditionTrue,
+			wantRotating:  true,
+		},
+		"successful operation completes": {
+			response:      &osbclient.LastOperationResponse{State: osbclient.StateSucceeded},
+			wantCondition: corev1.ConditionTrue,
+		},
+		"failed operation keeps previous credentials": {
+			response:      &osbclient.LastOperationResponse{State: osbclient.StateFailed},
+			wantCondition: corev1.ConditionTrue,
+			wantRollback:  true,
+		},
+		"other error keeps previous credentials": {
+			err:           errors.New("other"),
+			wantCondition: corev1.ConditionTrue,
+			wantRollback:  true,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			status := &ServiceInstanceBindingStatus{}
+			status.InitializeConditions()
+			status.PropagateBindStatus(&osbclient.BindResponse{}, nil)
+			status.PropagateRotationStarted(1, "old-id", "new-id")
+
+			status.PropagateBindStatus(&osbclient.BindResponse{Async: true}, nil)
+			testutil.AssertNotNil(t, "OSBStatus.Binding", status.OSBStatus.Binding)
+			testutil.AssertEqual(
+				t,
+				"condition after bind",
+				corev1.ConditionTrue,
+				status.manage().GetCondition(ServiceInstanceBindingConditionBackingResourceReady).Status,
+			)
+
+			status.PropagateBindLastOperationStatus(tc.response, tc.err)
+
+			actualCondition := status.manage().GetCondition(ServiceInstanceBindingConditionBackingResourceReady)
+			testutil.AssertEqual(t, "condition", tc.wantCondition, actualCondition.Status)
+			testutil.AssertEqual(t, "IsRotating", tc.wantRotating, status.IsRotating())
+			if tc.wantRollback {
+				assertRotationRolledBack(t, status)
+			}
+		})
+	}
+}
+
+func TestServiceInstanceBindingStatus_PropagateBindStatusRotationFailed(t *testing.T) {
+	t.Parallel()
+
+	status := &ServiceInstanceBindingStatus{}
+	status.InitializeConditions()
+	status.PropagateBindStatus(&osbclient.BindResponse{}, nil)
+	status.PropagateCredentialsSecretStatus(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "credentials"}})
+	status.PropagateRotationStarted(1, "old-id", "new-id")
+
+	status.PropagateBindStatus(nil, errors.New("broker-error"))
+
+	assertRotationRolledBack(t, status)
+	testutil.AssertEqual(
+		t,
+		"CredentialsRotated message",
+		"broker-error",
+		status.GetCondition(ServiceInstanceBindingConditionCredentialsRotated).Message,
+	)
+
+	// The rotation can be requested again from the previous credentials.
+	status.PropagateRotationStarted(2, status.BindingID, "newer-id")
+	testutil.AssertEqual(t, "PreviousBindingID after retry", "old-id", status.PreviousBindingID)
+	testutil.AssertTrue(t, "IsRotating after retry", status.IsRotating())
+	testutil.AssertEqual(
+		t,
+		"CredentialsRotated after retry",
+		corev1.ConditionUnknown,
+		status.GetCondition(ServiceInstanceBindingConditionCredentialsRotated).Status,
+	)
+}
+
+// assertRotationRolledBack checks that a failed rotation from old-id left the
+// binding bound with the previous credentials.
+func assertRotationRolledBack(t *testing.T, status *ServiceInstanceBindingStatus) {
+	t.Helper()
+
+	testutil.AssertEqual(t, "BindingID", "old-id", status.BindingID)
+	testutil.AssertEqual(t, "PreviousBindingID", "", status.PreviousBindingID)
+	testutil.AssertNotNil(t, "OSBStatus.Bound", status.OSBStatus.Bound)
+	testutil.AssertFalse(t, "IsWaitingForRotatedCredentials", status.IsWaitingForRotatedCredentials())
+	testutil.AssertEqual(
+		t,
+		"BackingResource",
+		corev1.ConditionTrue,
+		status.GetCondition(ServiceInstanceBindingConditionBackingResourceReady).Status,
+	)
+	testutil.AssertEqual(
+		t,
+		"CredentialsRotated",
+		corev1.ConditionFalse,
+		status.GetCondition(ServiceInstanceBindingConditionCredentialsRotated).Status,
+	)
+}
+
+func TestServiceInstanceBindingStatus_PropagatePreviousUnbindStatus(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		response     *osbclient.UnbindResponse
+		err          error
+		wantPrevious string
+	}{
+		"500 error retries": {
+			err:          &osbclient.HTTPStatusCodeError{StatusCode: 500},
+			wantPrevious: "old-id",
+		},
+		"other error retries": {
+			err:          errors.New("other"),
+			wantPrevious: "old-id",
+		},
+		"410 error completes": {
+			err:          &osbclient.HTTPStatusCodeError{StatusCode: 410},
+			wantPrevious: "",
+		},
+		"async operation completes": {
+			response:     &osbclient.UnbindResponse{Async: true},
+			wantPrevious: "",
+		},
+		"successful operation completes": {
+			response:     &osbclient.UnbindResponse{},
+			wantPrevious: "",
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			status := &ServiceInstanceBindingStatus{}
+			status.InitializeConditions()
+			status.PropagateRotationStarted(1, "old-id", "new-id")
+			original := status.DeepCopy()
+
+			status.PropagatePreviousUnbindStatus(tc.response, tc.err)
+
+			testutil.AssertEqual(t, "PreviousBindingID", tc.wantPrevious, status.PreviousBindingID)
+			testutil.AssertEqual(t, "BindingID", "new-id", status.BindingID)
+			testutil.AssertEqual(t, "OSBStatus", original.OSBStatus, status.OSBStatus)
+		})
+	}
+}
+
 func TestServiceInstanceBindingStatus_PropagateBindLastOperationStatus(t *testing.T) {
 	t.Parallel()
 
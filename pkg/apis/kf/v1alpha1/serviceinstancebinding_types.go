@@ -18,7 +18,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"time"
 
+	"github.com/google/uuid"
 	core "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
@@ -32,6 +34,11 @@ const (
 	// DefaultServiceInstanceBindingProgressDeadlineSeconds contains the default
 	// amount of time bindings can take before timing out.
 	DefaultServiceInstanceBindingProgressDeadlineSeconds = DefaultServiceInstanceProgressDeadlineSeconds
+
+	// DefaultServiceInstanceBindingRotationGracePeriodSeconds contains the
+	// default amount of time the previous credentials of a rotated binding
+	// stay valid.
+	DefaultServiceInstanceBindingRotationGracePeriodSeconds = 10 * 60
 )
 
 // MakeServiceBindingName returns a deterministic name for a service instance binding.
@@ -101,6 +108,12 @@ type ServiceInstanceBindingSpec struct {
 	// InstanceRef is the service instance that is bound to the App or Route.
 	InstanceRef core.LocalObjectReference `json:"instanceRef"`
 
+	// InstanceSpace is the Space of the service instance if it's shared from
+	// another Space with a ServiceInstanceShare. Defaults to the binding's
+	// Space.
+	// +optional
+	InstanceSpace string `json:"instanceSpace,omitempty"`
+
 	// ParametersFrom contains a reference to a secret containing parameters for
 	// the service instance binding.
 	ParametersFrom core.LocalObjectReference `json:"parametersFrom,omitempty"`
@@ -117,6 +130,18 @@ type ServiceInstanceBindingSpec struct {
 	// UnbindRequests is a unique identifier for an ServiceInstanceBindingSpec.
 	// Updating sub-values will trigger an additional unbind retry.
 	UnbindRequests int `json:"unbindRequests,omitempty"`
+
+	// RotateRequests is incremented to request new credentials from the
+	// service broker. The previous credentials are unbound after the
+	// rotation grace period.
+	// +optional
+	RotateRequests int `json:"rotateRequests,omitempty"`
+
+	// RotationGracePeriodSeconds is how long the previous credentials stay
+	// valid after a rotation so Apps can restart with the new ones.
+	// Defaults to DefaultServiceInstanceBindingRotationGracePeriodSeconds.
+	// +optional
+	RotationGracePeriodSeconds int64 `json:"rotationGracePeriodSeconds,omitempty"`
 }
 
 // BindingType is the type of the service instance binding.
@@ -162,6 +187,22 @@ type ServiceInstanceBindingStatus struct {
 
 	// UnbindRequests is the last processed UnbindRequests value
 	UnbindRequests int `json:"unbindRequests,omitempty"`
+
+	// RotateRequests is the last processed RotateRequests value.
+	RotateRequests int `json:"rotateRequests,omitempty"`
+
+	// BindingID is the OSB binding ID of the current credentials. It's only
+	// set after the credentials have been rotated, before that the binding's
+	// UID is used.
+	BindingID string `json:"bindingID,omitempty"`
+
+	// PreviousBindingID is the OSB binding ID of credentials replaced by a
+	// rotation that haven't been unbound yet.
+	PreviousBindingID string `json:"previousBindingID,omitempty"`
+
+	// PreviousBindingUnbindAfter is when the previous credentials are unbound.
+	// It's set once the new credentials are ready.
+	PreviousBindingUnbindAfter *metav1.Time `json:"previousBindingUnbindAfter,omitempty"`
 }
 
 // BindingVolumeParams are the volume related fields stored in the binding's secret.
@@ -280,6 +321,64 @@ func (binding *ServiceInstanceBinding) IsAppBinding() bool {
 	return binding.Spec.BindingType.App != nil
 }
 
+// InstanceNamespace returns the namespace of the service instance the
+// binding references.
+func (binding *ServiceInstanceBinding) InstanceNamespace() string {
+	if binding.Spec.InstanceSpace != "" {
+		return binding.Spec.InstanceSpace
+	}
+	return binding.Namespace
+}
+
+// OSBBindingID returns the ID used for the binding's credentials in OSB
+// requests.
+func (binding *ServiceInstanceBinding) OSBBindingID() string {
+	if binding.Status.BindingID != "" {
+		return binding.Status.BindingID
+	}
+	return string(binding.UID)
+}
+
+// RotatedBindingID returns the OSB binding ID for the credentials of the
+// requested rotation. It's derived from the binding's UID and rotation count
+// so retried reconciliations use the same ID and don't create extra bindings
+// at the broker.
+func (binding *ServiceInstanceBinding) RotatedBindingID() string {
+	name := fmt.Sprintf("%s/rotate/%d", binding.UID, binding.Spec.RotateRequests)
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(name)).String()
+}
+
+// CredentialsBindingID returns the OSB binding ID of the credentials currently
+// stored in the binding's credentials Secret.
+func (binding *ServiceInstanceBinding) CredentialsBindingID() string {
+	if binding.Status.IsWaitingForRotatedCredentials() {
+		return binding.Status.PreviousBindingID
+	}
+	return binding.OSBBindingID()
+}
+
+// RotationGracePeriod returns how long previous credentials stay valid after
+// a rotation.
+func (binding *ServiceInstanceBinding) RotationGracePeriod() time.Duration {
+	seconds := binding.Spec.RotationGracePeriodSeconds
+	if seconds == 0 {
+		seconds = DefaultServiceInstanceBindingRotationGracePeriodSeconds
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// IsRotationRequested returns true if new credentials were requested and
+// haven't been bound yet.
+func (binding *ServiceInstanceBinding) IsRotationRequested() bool {
+	return binding.Spec.RotateRequests != binding.Status.RotateRequests
+}
+
+// IsSharedInstanceBinding returns true if the service instance is shared from
+// another Space.
+func (binding *ServiceInstanceBinding) IsSharedInstanceBinding() bool {
+	return binding.InstanceNamespace() != binding.Namespace
+}
+
 // IsRouteBinding returns true if the service instance binding binds a service to a Route.
 func (binding *ServiceInstanceBinding) IsRouteBinding() bool {
 	return binding.Spec.BindingType.Route != nil
@@ -16,8 +16,10 @@ package v1alpha1
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/kf/v2/pkg/kf/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestBindingOSBStatus_IsBlank(t *testing.T) {
@@ -65,3 +67,134 @@ func TestBindingOSBStatus_IsBlank(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceInstanceBinding_InstanceNamespace(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		instanceSpace string
+		wantNamespace string
+		wantShared    bool
+	}{
+		"same Space": {
+			wantNamespace: "my-space",
+			wantShared:    false,
+		},
+		"shared from other Space": {
+			instanceSpace: "other-space",
+			wantNamespace: "other-space",
+			wantShared:    true,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			binding := &ServiceInstanceBinding{}
+			binding.Namespace = "my-space"
+			binding.Spec.InstanceSpace = tc.instanceSpace
+
+			testutil.AssertEqual(t, "namespace", tc.wantNamespace, binding.InstanceNamespace())
+			testutil.AssertEqual(t, "shared", tc.wantShared, binding.IsSharedInstanceBinding())
+		})
+	}
+}
+
+func TestServiceInstanceBinding_OSBBindingID(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		bindingID string
+		want      string
+	}{
+		"never rotated": {
+			want: "some-uid",
+		},
+		"rotated": {
+			bindingID: "rotated-id",
+			want:      "rotated-id",
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			binding := &ServiceInstanceBinding{}
+			binding.UID = "some-uid"
+			binding.Status.BindingID = tc.bindingID
+
+			testutil.AssertEqual(t, "bindingID", tc.want, binding.OSBBindingID())
+		})
+	}
+}
+
+func TestServiceInstanceBinding_RotatedBindingID(t *testing.T) {
+	t.Parallel()
+
+	binding := &ServiceInstanceBinding{}
+	binding.UID = "some-uid"
+	binding.Spec.RotateRequests = 1
+	first := binding.RotatedBindingID()
+
+	testutil.AssertEqual(t, "same rotation", first, binding.DeepCopy().RotatedBindingID())
+	testutil.AssertTrue(t, "differs from uid", first != string(binding.UID))
+
+	binding.Spec.RotateRequests = 2
+	testutil.AssertTrue(t, "next rotation differs", first != binding.RotatedBindingID())
+
+	other := binding.DeepCopy()
+	other.UID = "other-uid"
+	testutil.AssertTrue(t, "other binding differs", binding.RotatedBindingID() != other.RotatedBindingID())
+}
+
+func TestServiceInstanceBinding_CredentialsBindingID(t *testing.T) {
+	t.Parallel()
+
+	binding := &ServiceInstanceBinding{}
+	binding.UID = "some-uid"
+	testutil.AssertEqual(t, "never rotated", "some-uid", binding.CredentialsBindingID())
+
+	binding.Status.PropagateRotationStarted(1, "some-uid", "new-id")
+	testutil.AssertEqual(t, "rotating", "some-uid", binding.CredentialsBindingID())
+
+	unbindAfter := metav1.Now()
+	binding.Status.PreviousBindingUnbindAfter = &unbindAfter
+	testutil.AssertEqual(t, "rotated", "new-id", binding.CredentialsBindingID())
+}
+
+func TestServiceInstanceBinding_RotationGracePeriod(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		seconds int64
+		want    time.Duration
+	}{
+		"default": {
+			want: 10 * time.Minute,
+		},
+		"custom": {
+			seconds: 30,
+			want:    30 * time.Second,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			binding := &ServiceInstanceBinding{}
+			binding.Spec.RotationGracePeriodSeconds = tc.seconds
+
+			testutil.AssertEqual(t, "gracePeriod", tc.want, binding.RotationGracePeriod())
+		})
+	}
+}
+
+func TestServiceInstanceBinding_IsRotationRequested(t *testing.T) {
+	t.Parallel()
+
+	binding := &ServiceInstanceBinding{}
+	testutil.AssertFalse(t, "new binding", binding.IsRotationRequested())
+
+	binding.Spec.RotateRequests = 1
+	testutil.AssertTrue(t, "requested", binding.IsRotationRequested())
+
+	binding.Status.RotateRequests = 1
+	testutil.AssertFalse(t, "completed", binding.IsRotationRequested())
+}
@@ -19,6 +19,7 @@ import (
 	"math"
 
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/kmp"
 )
@@ -33,10 +34,16 @@ func (binding *ServiceInstanceBinding) Validate(ctx context.Context) (errs *apis
 
 	errs = errs.Also(apis.ValidateObjectMetadata(binding.GetObjectMeta()).ViaField("metadata"))
 
-	// Deny changes to spec except UnbindRequests
+	// Deny changes to spec except UnbindRequests and rotation
 	if apis.IsInUpdate(ctx) {
+		if v := binding.Spec.RotationGracePeriodSeconds; v < 0 {
+			errs = errs.Also(apis.ErrOutOfBoundsValue(v, 0, math.MaxInt64, "spec.rotationGracePeriodSeconds"))
+		}
+
 		original := apis.GetBaseline(ctx).(*ServiceInstanceBinding)
 		binding.Spec.UnbindRequests = original.Spec.UnbindRequests
+		binding.Spec.RotateRequests = original.Spec.RotateRequests
+		binding.Spec.RotationGracePeriodSeconds = original.Spec.RotationGracePeriodSeconds
 		if diff, err := kmp.ShortDiff(original.Spec, binding.Spec); err != nil {
 			return errs.Also(&apis.FieldError{
 				Message: "Failed to diff",
@@ -65,6 +72,12 @@ func (spec *ServiceInstanceBindingSpec) Validate(ctx context.Context) (errs *api
 		errs = errs.Also(apis.ErrMissingField("instanceRef.name"))
 	}
 
+	if spec.InstanceSpace != "" {
+		if msgs := validation.IsDNS1123Label(spec.InstanceSpace); len(msgs) > 0 {
+			errs = errs.Also(apis.ErrInvalidValue(spec.InstanceSpace, "instanceSpace", msgs...))
+		}
+	}
+
 	if spec.ParametersFrom.Name == "" {
 		errs = errs.Also(apis.ErrMissingField("parametersFrom.name"))
 	}
@@ -76,6 +89,10 @@ func (spec *ServiceInstanceBindingSpec) Validate(ctx context.Context) (errs *api
 		errs = errs.Also(apis.ErrOutOfBoundsValue(v, 1, math.MaxInt64, "progressDeadlineSeconds"))
 	}
 
+	if v := spec.RotationGracePeriodSeconds; v < 0 {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(v, 0, math.MaxInt64, "rotationGracePeriodSeconds"))
+	}
+
 	return
 }
 
@@ -16,11 +16,13 @@ package v1alpha1
 
 import (
 	"context"
+	"math"
 	"testing"
 
 	"github.com/google/kf/v2/pkg/kf/testutil"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/pkg/apis"
 )
 
@@ -107,6 +109,31 @@ func TestServiceInstanceBinding_Validate(t *testing.T) {
 			},
 			Want: nil,
 		},
+		"update ok if rotation in spec changes": {
+			Context: apis.WithinUpdate(context.Background(), &ServiceInstanceBinding{}),
+			Input: &ServiceInstanceBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+				Spec: ServiceInstanceBindingSpec{
+					RotateRequests:             1,
+					RotationGracePeriodSeconds: 60,
+				},
+			},
+			Want: nil,
+		},
+		"update rejected if rotation grace period is negative": {
+			Context: apis.WithinUpdate(context.Background(), &ServiceInstanceBinding{}),
+			Input: &ServiceInstanceBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+				Spec: ServiceInstanceBindingSpec{
+					RotationGracePeriodSeconds: -1,
+				},
+			},
+			Want: apis.ErrOutOfBoundsValue(-1, 0, math.MaxInt64, "spec.rotationGracePeriodSeconds"),
+		},
 	}
 
 	cases.Run(t)
@@ -137,6 +164,33 @@ func TestServiceInstanceBindingSpec_Validate(t *testing.T) {
 			}()),
 			Want: apis.ErrMissingField("instanceRef.name"),
 		},
+		"negative rotation grace period": {
+			Context: context.Background(),
+			Input: (func() *ServiceInstanceBindingSpec {
+				spec := validAppServiceInstanceBindingSpec()
+				spec.RotationGracePeriodSeconds = -1
+				return spec
+			}()),
+			Want: apis.ErrOutOfBoundsValue(-1, 0, math.MaxInt64, "rotationGracePeriodSeconds"),
+		},
+		"shared instance": {
+			Context: context.Background(),
+			Input: (func() *ServiceInstanceBindingSpec {
+				spec := validAppServiceInstanceBindingSpec()
+				spec.InstanceSpace = "other-space"
+				return spec
+			}()),
+			Want: nil,
+		},
+		"invalid instanceSpace": {
+			Context: context.Background(),
+			Input: (func() *ServiceInstanceBindingSpec {
+				spec := validAppServiceInstanceBindingSpec()
+				spec.InstanceSpace = "Other_Space"
+				return spec
+			}()),
+			Want: apis.ErrInvalidValue("Other_Space", "instanceSpace", validation.IsDNS1123Label("Other_Space")...),
+		},
 	}
 
 	cases.Run(t)
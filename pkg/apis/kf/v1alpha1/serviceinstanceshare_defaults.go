@@ -0,0 +1,47 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import "context"
+
+const (
+	serviceInstanceShareComponentName = "service-instance-share"
+
+	// ServiceInstanceShareTargetSpaceLabel holds the Space a
+	// ServiceInstanceShare shares its ServiceInstance with so shares can be
+	// listed by Space.
+	ServiceInstanceShareTargetSpaceLabel = "serviceinstanceshares.kf.dev/target-space"
+)
+
+// SetDefaults implements apis.Defaultable.
+func (s *ServiceInstanceShare) SetDefaults(ctx context.Context) {
+	s.Labels = UnionMaps(
+		s.Labels,
+		map[string]string{
+			ManagedByLabel: "kf",
+			ComponentLabel: serviceInstanceShareComponentName,
+		},
+		ServiceInstanceShareLabels(s.Spec.InstanceRef.Name, s.Spec.TargetSpace),
+	)
+}
+
+// ServiceInstanceShareLabels returns the labels that select the shares of a
+// ServiceInstance with a Space.
+func ServiceInstanceShareLabels(instanceName, targetSpace string) map[string]string {
+	return map[string]string{
+		NameLabel:                            instanceName,
+		ServiceInstanceShareTargetSpaceLabel: targetSpace,
+	}
+}
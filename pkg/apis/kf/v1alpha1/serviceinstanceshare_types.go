@@ -0,0 +1,72 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+)
+
+// MakeServiceInstanceShareName returns a deterministic name for sharing a
+// ServiceInstance with a Space.
+func MakeServiceInstanceShareName(instanceName, targetSpace string) string {
+	return GenerateName("share", instanceName, targetSpace)
+}
+
+// GetGroupVersionKind returns the GroupVersionKind.
+func (s *ServiceInstanceShare) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("ServiceInstanceShare")
+}
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ServiceInstanceShare shares a ServiceInstance with another Space so Apps
+// in that Space can bind to it. It lives in the ServiceInstance's Space.
+type ServiceInstanceShare struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Spec ServiceInstanceShareSpec `json:"spec,omitempty"`
+}
+
+var _ apis.Validatable = (*ServiceInstanceShare)(nil)
+var _ apis.Defaultable = (*ServiceInstanceShare)(nil)
+
+// ServiceInstanceShareSpec contains the specification of a
+// ServiceInstanceShare.
+type ServiceInstanceShareSpec struct {
+	// InstanceRef is the ServiceInstance that's shared.
+	InstanceRef corev1.LocalObjectReference `json:"instanceRef"`
+
+	// TargetSpace is the Space the ServiceInstance is shared with.
+	TargetSpace string `json:"targetSpace"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ServiceInstanceShareList is a list of ServiceInstanceShare resources.
+type ServiceInstanceShareList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ServiceInstanceShare `json:"items"`
+}
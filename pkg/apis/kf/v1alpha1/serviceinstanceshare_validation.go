@@ -0,0 +1,71 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/kmp"
+)
+
+// Validate makes sure that ServiceInstanceShare is properly configured.
+func (s *ServiceInstanceShare) Validate(ctx context.Context) (errs *apis.FieldError) {
+	errs = errs.Also(apis.ValidateObjectMetadata(s.GetObjectMeta()).ViaField("metadata"))
+
+	// Shares are replaced rather than updated so bindings never silently
+	// point at a different ServiceInstance.
+	if apis.IsInUpdate(ctx) {
+		original := apis.GetBaseline(ctx).(*ServiceInstanceShare)
+		if diff, err := kmp.ShortDiff(original.Spec, s.Spec); err != nil {
+			return errs.Also(&apis.FieldError{
+				Message: "Failed to diff",
+				Paths:   []string{"spec"},
+				Details: err.Error(),
+			})
+		} else if diff != "" {
+			return errs.Also(&apis.FieldError{
+				Message: "Immutable fields changed (-old +new)",
+				Paths:   []string{"spec"},
+				Details: diff,
+			})
+		}
+		return errs
+	}
+
+	errs = errs.Also(s.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
+
+	if s.Spec.TargetSpace != "" && s.Spec.TargetSpace == s.Namespace {
+		errs = errs.Also(apis.ErrInvalidValue(s.Spec.TargetSpace, "spec.targetSpace", "can't be the ServiceInstance's own Space"))
+	}
+
+	return errs
+}
+
+// Validate implements apis.Validatable.
+func (spec *ServiceInstanceShareSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if spec.InstanceRef.Name == "" {
+		errs = errs.Also(apis.ErrMissingField("instanceRef.name"))
+	}
+
+	if spec.TargetSpace == "" {
+		errs = errs.Also(apis.ErrMissingField("targetSpace"))
+	} else if msgs := validation.IsDNS1123Label(spec.TargetSpace); len(msgs) > 0 {
+		errs = errs.Also(apis.ErrInvalidValue(spec.TargetSpace, "targetSpace", msgs...))
+	}
+
+	return errs
+}
@@ -0,0 +1,96 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func validServiceInstanceShare() *ServiceInstanceShare {
+	return &ServiceInstanceShare{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      MakeServiceInstanceShareName("my-db", "other-space"),
+			Namespace: "my-space",
+		},
+		Spec: ServiceInstanceShareSpec{
+			InstanceRef: corev1.LocalObjectReference{Name: "my-db"},
+			TargetSpace: "other-space",
+		},
+	}
+}
+
+func TestServiceInstanceShare_Validate(t *testing.T) {
+	cases := testutil.ApisValidatableTestSuite{
+		"nominal": {
+			Context: context.Background(),
+			Input:   validServiceInstanceShare(),
+		},
+		"missing fields": {
+			Context: context.Background(),
+			Input: (func() *ServiceInstanceShare {
+				share := validServiceInstanceShare()
+				share.Spec = ServiceInstanceShareSpec{}
+				return share
+			}()),
+			Want: apis.ErrMissingField("spec.instanceRef.name", "spec.targetSpace"),
+		},
+		"shared with own Space": {
+			Context: context.Background(),
+			Input: (func() *ServiceInstanceShare {
+				share := validServiceInstanceShare()
+				share.Spec.TargetSpace = "my-space"
+				return share
+			}()),
+			Want: apis.ErrInvalidValue("my-space", "spec.targetSpace", "can't be the ServiceInstance's own Space"),
+		},
+		"update changes spec": {
+			Context: apis.WithinUpdate(context.Background(), validServiceInstanceShare()),
+			Input: (func() *ServiceInstanceShare {
+				share := validServiceInstanceShare()
+				share.Spec.TargetSpace = "third-space"
+				return share
+			}()),
+			Want: &apis.FieldError{
+				Message: "Immutable fields changed (-old +new)",
+				Paths:   []string{"spec"},
+				Details: "{v1alpha1.ServiceInstanceShareSpec}.TargetSpace:\n\t-: \"other-space\"\n\t+: \"third-space\"\n",
+			},
+		},
+		"update keeps spec": {
+			Context: apis.WithinUpdate(context.Background(), validServiceInstanceShare()),
+			Input:   validServiceInstanceShare(),
+		},
+	}
+
+	cases.Run(t)
+}
+
+func TestServiceInstanceShare_SetDefaults(t *testing.T) {
+	share := validServiceInstanceShare()
+	share.SetDefaults(context.Background())
+
+	testutil.AssertEqual(t, "labels", map[string]string{
+		ManagedByLabel:                       "kf",
+		ComponentLabel:                       "service-instance-share",
+		NameLabel:                            "my-db",
+		ServiceInstanceShareTargetSpaceLabel: "other-space",
+	}, share.Labels)
+}
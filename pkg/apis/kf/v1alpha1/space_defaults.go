@@ -28,6 +28,10 @@ const (
 
 	// KfExternalIngressGateway holds the gateway for Kf's external HTTP ingress.
 	KfExternalIngressGateway = "kf/external-gateway"
+
+	// KfInternalIngressGateway is the gateway name used by internal domains,
+	// traffic to them is routed by the mesh rather than an ingress gateway.
+	KfInternalIngressGateway = "kf/internal-gateway"
 )
 
 // SetDefaults implements apis.Defaultable
@@ -70,12 +74,23 @@ func (k *SpaceSpecNetworkConfig) SetDefaults(ctx context.Context) {
 }
 
 // DefaultSpaceDomainGateways replaces missing gatewayNames with the kf
-// default external gateway.
+// default external gateway, or the internal gateway for internal domains.
 func (k *SpaceSpecNetworkConfig) DefaultSpaceDomainGateways(ctx context.Context) {
 	for i := range k.Domains {
-		if k.Domains[i].GatewayName == "" {
-			k.Domains[i].GatewayName = KfExternalIngressGateway
-		}
+		k.Domains[i].DefaultGatewayName()
+	}
+}
+
+// DefaultGatewayName sets the gatewayName if it's missing.
+func (d *SpaceDomain) DefaultGatewayName() {
+	if d.GatewayName != "" {
+		return
+	}
+
+	if d.Internal {
+		d.GatewayName = KfInternalIngressGateway
+	} else {
+		d.GatewayName = KfExternalIngressGateway
 	}
 }
 
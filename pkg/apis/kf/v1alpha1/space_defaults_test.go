@@ -123,6 +123,25 @@ func TestSpaceSpecNetworkConfig_SetDefaults(t *testing.T) {
 				BuildNetworkPolicy: defaultPolicy,
 			},
 		},
+		"internal domains use the internal gateway": {
+			Context: sampleConfig(),
+			Input: &SpaceSpecNetworkConfig{
+				Domains: []SpaceDomain{
+					{Domain: "apps.internal", Internal: true},
+				},
+			},
+			Want: &SpaceSpecNetworkConfig{
+				Domains: []SpaceDomain{
+					{
+						Domain:      "apps.internal",
+						GatewayName: "kf/internal-gateway",
+						Internal:    true,
+					},
+				},
+				AppNetworkPolicy:   defaultPolicy,
+				BuildNetworkPolicy: defaultPolicy,
+			},
+		},
 		"don't override set policies": {
 			Context: sampleConfig(),
 			Input: &SpaceSpecNetworkConfig{
@@ -126,10 +126,20 @@ func (status *SpaceStatus) PropagateNetworkConfigStatus(networkConfig SpaceSpecN
 		}
 
 		for _, defaultDomain := range defaultsConfig.SpaceClusterDomains {
-			domains = append(domains, SpaceDomain{
+			domain := SpaceDomain{
 				Domain:      defaultDomain.Domain,
 				GatewayName: defaultDomain.GatewayName,
-			})
+				Internal:    defaultDomain.Internal,
+			}
+
+			if rg := defaultDomain.RouterGroup; rg != nil {
+				domain.RouterGroup = &RouterGroup{
+					Type:            RouterGroupType(rg.Type),
+					ReservablePorts: rg.ReservablePorts,
+				}
+			}
+
+			domains = append(domains, domain)
 		}
 
 		// Replace variables
@@ -151,14 +161,8 @@ func (status *SpaceStatus) PropagateNetworkConfigStatus(networkConfig SpaceSpecN
 	{
 		var domains []SpaceDomain
 		for _, d := range status.NetworkConfig.Domains {
-			if d.GatewayName == "" {
-				domains = append(domains, SpaceDomain{
-					Domain:      d.Domain,
-					GatewayName: KfExternalIngressGateway,
-				})
-			} else {
-				domains = append(domains, d)
-			}
+			d.DefaultGatewayName()
+			domains = append(domains, d)
 		}
 		status.NetworkConfig.Domains = domains
 	}
@@ -166,6 +170,27 @@ func (status *SpaceStatus) PropagateNetworkConfigStatus(networkConfig SpaceSpecN
 	status.NetworkConfigCondition().MarkSuccess()
 }
 
+// PropagateDomainCertificates records the state of the certificates for
+// domains with TLS. The condition is Unknown until every certificate is
+// available.
+func (status *SpaceStatus) PropagateDomainCertificates(certificates []SpaceDomainCertificate) {
+	status.NetworkConfig.Certificates = certificates
+
+	for _, cert := range certificates {
+		if cert.NotAfter == nil {
+			status.DomainTLSCondition().MarkUnknown(
+				"CertificatePending",
+				"Certificate for domain %q isn't available: %s",
+				cert.Domain,
+				cert.Message,
+			)
+			return
+		}
+	}
+
+	status.DomainTLSCondition().MarkSuccess()
+}
+
 func applyDomainReplacements(input string, replacements map[string]string) string {
 	var oldnew []string
 	for k, v := range replacements {
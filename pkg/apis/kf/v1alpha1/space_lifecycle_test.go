@@ -213,6 +213,7 @@ func TestSpaceStatus_lifecycle(t *testing.T) {
 				status.ClusterRoleCondition().MarkSuccess()
 				status.ClusterRoleBindingsCondition().MarkSuccess()
 				status.IAMPolicyCondition().MarkSuccess()
+				status.PropagateDomainCertificates(nil)
 			},
 			ExpectSucceeded: []apis.ConditionType{
 				SpaceConditionReady,
@@ -229,6 +230,7 @@ func TestSpaceStatus_lifecycle(t *testing.T) {
 				SpaceConditionClusterRoleReady,
 				SpaceConditionClusterRoleBindingsReady,
 				SpaceConditionIAMPolicyReady,
+				SpaceConditionDomainTLSReady,
 			},
 		},
 		"terminating namespace": {
@@ -290,6 +292,17 @@ func TestSpaceStatus_lifecycle(t *testing.T) {
 				SpaceConditionBuildSecretReady,
 			},
 		},
+		"pending certificate": {
+			Init: func(status *SpaceStatus) {
+				status.PropagateDomainCertificates([]SpaceDomainCertificate{
+					{Domain: "example.com", SecretName: "example-tls", Message: "Secret not found"},
+				})
+			},
+			ExpectOngoing: []apis.ConditionType{
+				SpaceConditionReady,
+				SpaceConditionDomainTLSReady,
+			},
+		},
 	}
 
 	// XXX: if we start copying state from subresources back to the parent,
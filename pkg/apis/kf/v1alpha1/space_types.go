@@ -15,6 +15,12 @@
 package v1alpha1
 
 import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -165,6 +171,155 @@ type SpaceDomain struct {
 	// Only the kf Namespace is allowed e.g. kf/some-gateway.
 	// See https://istio.io/docs/reference/config/networking/gateway/
 	GatewayName string `json:"gatewayName,omitempty"`
+
+	// RouterGroup configures the domain to accept TCP routes on reserved
+	// ports instead of HTTP routes.
+	// +optional
+	RouterGroup *RouterGroup `json:"routerGroup,omitempty"`
+
+	// Internal makes the domain only reachable from inside the cluster.
+	// Routes on internal domains are served by the mesh rather than an
+	// ingress gateway and resolve to the Apps' cluster Services.
+	// +optional
+	Internal bool `json:"internal,omitempty"`
+
+	// TLS configures HTTPS for the domain using a certificate from a Secret
+	// or one requested from cert-manager.
+	// +optional
+	TLS *SpaceDomainTLS `json:"tls,omitempty"`
+}
+
+// SpaceDomainTLS configures the certificate used to serve HTTPS traffic for a
+// domain.
+type SpaceDomainTLS struct {
+	// SecretName is the name of a kubernetes.io/tls Secret holding the
+	// certificate for the domain. The Secret must be in the Namespace of the
+	// ingress gateway. If IssuerName is set, the issued certificate is stored
+	// in the Secret.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// IssuerName is the name of a cert-manager ClusterIssuer used to request a
+	// certificate for the domain and its subdomains.
+	// +optional
+	IssuerName string `json:"issuerName,omitempty"`
+}
+
+// TLSSecretName returns the name of the Secret holding the domain's
+// certificate in the given Space, empty if the domain doesn't use TLS.
+func (d *SpaceDomain) TLSSecretName(spaceName string) string {
+	switch {
+	case d.TLS == nil:
+		return ""
+	case d.TLS.SecretName != "":
+		return d.TLS.SecretName
+	default:
+		return GenerateName("kf-tls", spaceName, d.Domain)
+	}
+}
+
+// TLSGatewayName returns the name of the Gateway in the kf Namespace that
+// serves HTTPS traffic for the domain in the given Space.
+func (d *SpaceDomain) TLSGatewayName(spaceName string) string {
+	return GenerateName(spaceName, d.Domain, "tls")
+}
+
+// IsTCP returns true if the domain accepts TCP routes.
+func (d *SpaceDomain) IsTCP() bool {
+	return d.RouterGroup != nil && d.RouterGroup.Type == RouterGroupTypeTCP
+}
+
+// IsInternal returns true if the domain is only reachable from inside the
+// cluster. Domains using the internal gateway are internal for backwards
+// compatibility.
+func (d *SpaceDomain) IsInternal() bool {
+	return d.Internal || d.GatewayName == KfInternalIngressGateway
+}
+
+// RouterGroupType is the type of traffic a router group accepts.
+type RouterGroupType string
+
+const (
+	// RouterGroupTypeTCP accepts TCP traffic on reserved ports.
+	RouterGroupTypeTCP RouterGroupType = "tcp"
+)
+
+// RouterGroup mimics Cloud Foundry router groups. It defines the type of
+// traffic a domain accepts and the ports Routes on the domain can reserve.
+type RouterGroup struct {
+	// Type is the type of traffic accepted by the router group, only tcp is
+	// supported.
+	Type RouterGroupType `json:"type"`
+
+	// ReservablePorts is a comma separated list of ports and port ranges that
+	// Routes can reserve e.g. "1024-1033,2000". The ports must be exposed by
+	// the ingress gateway's Kubernetes Service.
+	ReservablePorts string `json:"reservablePorts"`
+}
+
+// ParseReservablePorts parses a comma separated list of ports and inclusive
+// port ranges e.g. "1024-1033,2000".
+func ParseReservablePorts(reservablePorts string) ([]PortRange, error) {
+	var out []PortRange
+	for _, part := range strings.Split(reservablePorts, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		var portRange PortRange
+		bounds := strings.SplitN(part, "-", 2)
+		start, err := strconv.ParseInt(strings.TrimSpace(bounds[0]), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port %q", bounds[0])
+		}
+		portRange.Start = int32(start)
+		portRange.End = portRange.Start
+
+		if len(bounds) == 2 {
+			end, err := strconv.ParseInt(strings.TrimSpace(bounds[1]), 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid port %q", bounds[1])
+			}
+			portRange.End = int32(end)
+		}
+
+		if portRange.Start < 1 || portRange.End > math.MaxUint16 || portRange.Start > portRange.End {
+			return nil, fmt.Errorf("invalid port range %q", part)
+		}
+
+		out = append(out, portRange)
+	}
+
+	if len(out) == 0 {
+		return nil, errors.New("at least one port must be reservable")
+	}
+
+	return out, nil
+}
+
+// PortRange is an inclusive range of ports.
+// +k8s:deepcopy-gen=false
+type PortRange struct {
+	Start int32
+	End   int32
+}
+
+// CanReservePort returns true if the router group allows Routes to reserve
+// the given port.
+func (rg *RouterGroup) CanReservePort(port int32) bool {
+	ranges, err := ParseReservablePorts(rg.ReservablePorts)
+	if err != nil {
+		return false
+	}
+
+	for _, r := range ranges {
+		if port >= r.Start && port <= r.End {
+			return true
+		}
+	}
+
+	return false
 }
 
 // StableDeduplicateSpaceDomainList removes SpaceDomain with duplicate domain fields
@@ -254,6 +409,29 @@ type SpaceStatusNetworkConfig struct {
 	// Domains sets valid domains that can be used for routes in the space.
 	// +optional
 	Domains []SpaceDomain `json:"domains,omitempty"`
+
+	// Certificates holds the state of the certificates for domains with TLS.
+	// +optional
+	Certificates []SpaceDomainCertificate `json:"certificates,omitempty"`
+}
+
+// SpaceDomainCertificate reflects the certificate used to serve a domain.
+type SpaceDomainCertificate struct {
+	// Domain is the domain the certificate is for.
+	Domain string `json:"domain"`
+
+	// SecretName is the name of the Secret holding the certificate.
+	SecretName string `json:"secretName"`
+
+	// NotAfter is the time the certificate expires, unset if the certificate
+	// isn't available.
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+
+	// Message is a human readable explanation of why the certificate isn't
+	// available.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // SpaceStatusBuildConfig reflects the actual build configuration for the
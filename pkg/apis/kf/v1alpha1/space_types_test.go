@@ -15,6 +15,7 @@
 package v1alpha1
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -104,6 +105,55 @@ func TestSpaceStatus_FindIngressIP(t *testing.T) {
 	}
 }
 
+func TestParseReservablePorts(t *testing.T) {
+	cases := map[string]struct {
+		reservablePorts string
+		expected        []PortRange
+		expectedErr     error
+	}{
+		"single port": {
+			reservablePorts: "1024",
+			expected:        []PortRange{{Start: 1024, End: 1024}},
+		},
+		"ranges and ports": {
+			reservablePorts: "1024-1033, 2000,",
+			expected: []PortRange{
+				{Start: 1024, End: 1033},
+				{Start: 2000, End: 2000},
+			},
+		},
+		"empty": {
+			reservablePorts: "",
+			expectedErr:     errors.New("at least one port must be reservable"),
+		},
+		"not a number": {
+			reservablePorts: "http",
+			expectedErr:     errors.New(`invalid port "http"`),
+		},
+		"out of bounds": {
+			reservablePorts: "1024-70000",
+			expectedErr:     errors.New(`invalid port range "1024-70000"`),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			actual, err := ParseReservablePorts(tc.reservablePorts)
+			testutil.AssertErrorsEqual(t, tc.expectedErr, err)
+			testutil.AssertEqual(t, "port ranges", tc.expected, actual)
+		})
+	}
+}
+
+func ExampleRouterGroup_CanReservePort() {
+	rg := &RouterGroup{Type: RouterGroupTypeTCP, ReservablePorts: "1024-1033"}
+	fmt.Println("1024:", rg.CanReservePort(1024))
+	fmt.Println("2000:", rg.CanReservePort(2000))
+
+	// Output: 1024: true
+	// 2000: false
+}
+
 func ExampleSpace_DefaultDomainOrBlank() {
 	s := Space{}
 	fmt.Printf("No Domain: %q\n", s.DefaultDomainOrBlank())
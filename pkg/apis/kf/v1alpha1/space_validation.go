@@ -82,6 +82,25 @@ func (s *SpaceSpecNetworkConfig) Validate(ctx context.Context) (errs *apis.Field
 		}
 
 		foundDomains.Insert(domain.Domain)
+
+		if domain.RouterGroup != nil {
+			errs = errs.Also(domain.RouterGroup.Validate(ctx).ViaField("routerGroup").ViaFieldIndex("domains", idx))
+		}
+
+		if domain.Internal {
+			errs = errs.Also(domain.validateInternal().ViaFieldIndex("domains", idx))
+		}
+
+		if domain.TLS != nil {
+			errs = errs.Also(domain.TLS.Validate(ctx).ViaField("tls").ViaFieldIndex("domains", idx))
+
+			if domain.IsInternal() || domain.IsTCP() {
+				errs = errs.Also((&apis.FieldError{
+					Message: "TLS can only be configured on external HTTP domains",
+					Paths:   []string{"tls"},
+				}).ViaFieldIndex("domains", idx))
+			}
+		}
 	}
 
 	errs = errs.Also(s.ValidateDomainGateways(ctx))
@@ -91,6 +110,27 @@ func (s *SpaceSpecNetworkConfig) Validate(ctx context.Context) (errs *apis.Field
 	return errs
 }
 
+// validateInternal ensures internal domains aren't exposed through an ingress
+// gateway.
+func (d *SpaceDomain) validateInternal() (errs *apis.FieldError) {
+	if d.RouterGroup != nil {
+		errs = errs.Also(&apis.FieldError{
+			Message: "Internal domains can't have a routerGroup",
+			Paths:   []string{"routerGroup"},
+		})
+	}
+
+	if d.GatewayName != "" && d.GatewayName != KfInternalIngressGateway {
+		errs = errs.Also(&apis.FieldError{
+			Message: "Invalid gatewayName",
+			Details: fmt.Sprintf("Internal domains must use %s", KfInternalIngressGateway),
+			Paths:   []string{"gatewayName"},
+		})
+	}
+
+	return errs
+}
+
 // ValidateDomainGateways ensures the Istio gateway names for domains are valid.
 func (s *SpaceSpecNetworkConfig) ValidateDomainGateways(ctx context.Context) (errs *apis.FieldError) {
 
@@ -185,3 +225,44 @@ func (s *SpaceSpecRuntimeConfig) Validate(ctx context.Context) (errs *apis.Field
 	// nothing to validate
 	return errs
 }
+
+// Validate implements apis.Validatable.
+func (rg *RouterGroup) Validate(ctx context.Context) (errs *apis.FieldError) {
+	switch rg.Type {
+	case RouterGroupTypeTCP:
+		// Valid
+	case "":
+		errs = errs.Also(apis.ErrMissingField("type"))
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(rg.Type, "type"))
+	}
+
+	if _, err := ParseReservablePorts(rg.ReservablePorts); err != nil {
+		errs = errs.Also(&apis.FieldError{
+			Message: "Invalid Value",
+			Details: err.Error(),
+			Paths:   []string{"reservablePorts"},
+		})
+	}
+
+	return errs
+}
+
+// Validate implements apis.Validatable.
+func (t *SpaceDomainTLS) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if t.SecretName == "" && t.IssuerName == "" {
+		errs = errs.Also(apis.ErrMissingOneOf("secretName", "issuerName"))
+	}
+
+	if t.SecretName != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(t.SecretName) {
+			errs = errs.Also(&apis.FieldError{
+				Message: "Invalid Value",
+				Details: msg,
+				Paths:   []string{"secretName"},
+			})
+		}
+	}
+
+	return errs
+}
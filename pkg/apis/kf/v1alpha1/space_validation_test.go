@@ -167,6 +167,173 @@ func TestSpaceValidation(t *testing.T) {
 			},
 			want: errDuplicateValue("example.com", "spec.networkConfig.domains[2].domain"),
 		},
+		"tcp router group": {
+			space: &Space{
+				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+				Spec: SpaceSpec{
+					NetworkConfig: SpaceSpecNetworkConfig{
+						Domains: []SpaceDomain{
+							{
+								Domain:      "tcp.example.com",
+								GatewayName: "kf/some-gateway",
+								RouterGroup: &RouterGroup{
+									Type:            RouterGroupTypeTCP,
+									ReservablePorts: "1024-1033, 2000",
+								},
+							},
+						},
+						AppNetworkPolicy:   goodNetworkPolicy,
+						BuildNetworkPolicy: goodNetworkPolicy,
+					},
+					BuildConfig: goodBuildConfig,
+				},
+			},
+		},
+		"bad router group": {
+			space: &Space{
+				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+				Spec: SpaceSpec{
+					NetworkConfig: SpaceSpecNetworkConfig{
+						Domains: []SpaceDomain{
+							{
+								Domain:      "tcp.example.com",
+								GatewayName: "kf/some-gateway",
+								RouterGroup: &RouterGroup{
+									Type:            "udp",
+									ReservablePorts: "2000-1000",
+								},
+							},
+						},
+						AppNetworkPolicy:   goodNetworkPolicy,
+						BuildNetworkPolicy: goodNetworkPolicy,
+					},
+					BuildConfig: goodBuildConfig,
+				},
+			},
+			want: (*apis.FieldError)(nil).Also(
+				apis.ErrInvalidValue("udp", "spec.networkConfig.domains[0].routerGroup.type"),
+				&apis.FieldError{
+					Message: "Invalid Value",
+					Details: `invalid port range "2000-1000"`,
+					Paths:   []string{"spec.networkConfig.domains[0].routerGroup.reservablePorts"},
+				},
+			),
+		},
+		"internal domain": {
+			space: &Space{
+				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+				Spec: SpaceSpec{
+					NetworkConfig: SpaceSpecNetworkConfig{
+						Domains: []SpaceDomain{
+							{
+								Domain:      "apps.internal",
+								GatewayName: KfInternalIngressGateway,
+								Internal:    true,
+							},
+						},
+						AppNetworkPolicy:   goodNetworkPolicy,
+						BuildNetworkPolicy: goodNetworkPolicy,
+					},
+					BuildConfig: goodBuildConfig,
+				},
+			},
+		},
+		"bad internal domain": {
+			space: &Space{
+				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+				Spec: SpaceSpec{
+					NetworkConfig: SpaceSpecNetworkConfig{
+						Domains: []SpaceDomain{
+							{
+								Domain:      "apps.internal",
+								GatewayName: "kf/some-gateway",
+								Internal:    true,
+								RouterGroup: &RouterGroup{
+									Type:            RouterGroupTypeTCP,
+									ReservablePorts: "1024",
+								},
+							},
+						},
+						AppNetworkPolicy:   goodNetworkPolicy,
+						BuildNetworkPolicy: goodNetworkPolicy,
+					},
+					BuildConfig: goodBuildConfig,
+				},
+			},
+			want: (*apis.FieldError)(nil).Also(
+				&apis.FieldError{
+					Message: "Internal domains can't have a routerGroup",
+					Paths:   []string{"spec.networkConfig.domains[0].routerGroup"},
+				},
+				&apis.FieldError{
+					Message: "Invalid gatewayName",
+					Details: "Internal domains must use kf/internal-gateway",
+					Paths:   []string{"spec.networkConfig.domains[0].gatewayName"},
+				},
+			),
+		},
+		"tls domain": {
+			space: &Space{
+				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+				Spec: SpaceSpec{
+					NetworkConfig: SpaceSpecNetworkConfig{
+						Domains: []SpaceDomain{
+							{
+								Domain:      "example.com",
+								GatewayName: "kf/external-gateway",
+								TLS: &SpaceDomainTLS{
+									IssuerName: "letsencrypt",
+								},
+							},
+						},
+						AppNetworkPolicy:   goodNetworkPolicy,
+						BuildNetworkPolicy: goodNetworkPolicy,
+					},
+					BuildConfig: goodBuildConfig,
+				},
+			},
+		},
+		"bad tls domain": {
+			space: &Space{
+				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+				Spec: SpaceSpec{
+					NetworkConfig: SpaceSpecNetworkConfig{
+						Domains: []SpaceDomain{
+							{
+								Domain:      "apps.internal",
+								GatewayName: KfInternalIngressGateway,
+								TLS:         &SpaceDomainTLS{},
+							},
+							{
+								Domain:      "example.com",
+								GatewayName: "kf/external-gateway",
+								TLS: &SpaceDomainTLS{
+									SecretName: "Bad_Name",
+								},
+							},
+						},
+						AppNetworkPolicy:   goodNetworkPolicy,
+						BuildNetworkPolicy: goodNetworkPolicy,
+					},
+					BuildConfig: goodBuildConfig,
+				},
+			},
+			want: (*apis.FieldError)(nil).Also(
+				apis.ErrMissingOneOf(
+					"spec.networkConfig.domains[0].tls.secretName",
+					"spec.networkConfig.domains[0].tls.issuerName",
+				),
+				&apis.FieldError{
+					Message: "TLS can only be configured on external HTTP domains",
+					Paths:   []string{"spec.networkConfig.domains[0].tls"},
+				},
+				&apis.FieldError{
+					Message: "Invalid Value",
+					Details: "a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')",
+					Paths:   []string{"spec.networkConfig.domains[1].tls.secretName"},
+				},
+			),
+		},
 		"bad network policy": {
 			space: &Space{
 				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
@@ -15,6 +15,8 @@
 package v1alpha1
 
 import (
+	"time"
+
 	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -33,7 +35,10 @@ func (status *TaskStatus) PropagateTaskStatus(tr *tektonv1beta1.TaskRun) {
 		return
 	}
 
-	status.StartTime = tr.Status.StartTime
+	// Retried Tasks keep the start time of their first run.
+	if status.StartTime == nil || status.Retries == 0 {
+		status.StartTime = tr.Status.StartTime
+	}
 	status.CompletionTime = tr.Status.CompletionTime
 
 	// Generate a task duration for easy printing.
@@ -43,10 +48,31 @@ func (status *TaskStatus) PropagateTaskStatus(tr *tektonv1beta1.TaskRun) {
 		}
 	}
 
+	status.ExitCode = nil
+	status.TerminationReason = ""
+	for _, step := range tr.Status.Steps {
+		if step.Terminated != nil {
+			exitCode := step.Terminated.ExitCode
+			status.ExitCode = &exitCode
+			status.TerminationReason = step.Terminated.Reason
+			break
+		}
+	}
+
 	cond := tr.Status.GetCondition(apis.ConditionSucceeded)
+	if cond != nil && cond.Reason == string(tektonv1beta1.TaskRunReasonTimedOut) {
+		status.TerminationReason = TaskTerminationReasonDeadlineExceeded
+	}
+
 	PropagateCondition(status.manage(), TaskConditionTaskRunReady, cond)
 }
 
+// MarkRetrying notes that the last run of the Task failed and it will be run
+// again after the delay.
+func (status *TaskStatus) MarkRetrying(retry int32, delay time.Duration) {
+	status.TaskRunCondition().MarkUnknown("Retrying", "Task failed, starting retry %d in %s", retry, delay.Round(time.Second))
+}
+
 // PropagateTerminatingStatus updates the ready status of the Task to False if the Task received a delete request.
 func (status *TaskStatus) PropagateTerminatingStatus() {
 	status.manage().MarkFalse(TaskConditionSucceeded, "Terminating", "Task is terminating")
@@ -62,3 +88,23 @@ func (status *TaskStatus) MarkSpaceHealthy() {
 func (status *TaskStatus) MarkSpaceUnhealthy(reason, message string) {
 	status.SpaceCondition().MarkFalse(reason, message)
 }
+
+// PropagateIndexStatus updates the readiness of a Task with multiple
+// completions from the counts of its active, succeeded and failed indexed
+// runs. Once an indexed run failed, the runs still active finish but no new
+// ones are started.
+func (status *TaskStatus) PropagateIndexStatus(completions int32, terminated bool) {
+	cond := status.TaskRunCondition()
+	switch {
+	case status.SucceededIndexes >= completions:
+		cond.MarkSuccess()
+	case status.ActiveIndexes > 0:
+		cond.MarkUnknown("Running", "%d of %d indexes succeeded", status.SucceededIndexes, completions)
+	case status.FailedIndexes > 0:
+		cond.MarkFalse("IndexFailed", "%d of %d indexes failed", status.FailedIndexes, completions)
+	case terminated:
+		cond.MarkFalse(string(tektonv1beta1.TaskRunReasonCancelled), "Task was terminated")
+	default:
+		cond.MarkUnknown("Pending", "Waiting to start %d indexes", completions-status.SucceededIndexes)
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/ptr"
+)
+
+func TestTaskStatus_PropagateTaskStatus(t *testing.T) {
+	start := metav1.NewTime(time.Unix(1000, 0))
+	completion := metav1.NewTime(time.Unix(1060, 0))
+
+	makeTaskRun := func(reason string, steps ...tektonv1beta1.StepState) *tektonv1beta1.TaskRun {
+		tr := &tektonv1beta1.TaskRun{}
+		tr.Status.StartTime = &start
+		tr.Status.CompletionTime = &completion
+		tr.Status.Steps = steps
+		tr.Status.SetCondition(&apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionFalse,
+			Reason: reason,
+		})
+		return tr
+	}
+
+	terminated := func(exitCode int32, reason string) tektonv1beta1.StepState {
+		return tektonv1beta1.StepState{
+			ContainerState: corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{
+					ExitCode: exitCode,
+					Reason:   reason,
+				},
+			},
+		}
+	}
+
+	cases := map[string]struct {
+		status        TaskStatus
+		taskRun       *tektonv1beta1.TaskRun
+		wantExitCode  *int32
+		wantReason    string
+		wantStartTime *metav1.Time
+		wantSucceeded corev1.ConditionStatus
+	}{
+		"oom killed": {
+			taskRun:       makeTaskRun("Failed", terminated(137, "OOMKilled")),
+			wantExitCode:  ptr.Int32(137),
+			wantReason:    "OOMKilled",
+			wantStartTime: &start,
+			wantSucceeded: corev1.ConditionFalse,
+		},
+		"timed out": {
+			taskRun:       makeTaskRun(string(tektonv1beta1.TaskRunReasonTimedOut)),
+			wantReason:    TaskTerminationReasonDeadlineExceeded,
+			wantStartTime: &start,
+			wantSucceeded: corev1.ConditionFalse,
+		},
+		"retries keep first start time": {
+			status: TaskStatus{
+				TaskStatusFields: TaskStatusFields{
+					StartTime: &metav1.Time{Time: time.Unix(10, 0)},
+					Retries:   1,
+					ExitCode:  ptr.Int32(1),
+				},
+			},
+			taskRun:       makeTaskRun("Failed", terminated(2, "Error")),
+			wantExitCode:  ptr.Int32(2),
+			wantReason:    "Error",
+			wantStartTime: &metav1.Time{Time: time.Unix(10, 0)},
+			wantSucceeded: corev1.ConditionFalse,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			status := tc.status
+			status.InitializeConditions()
+			status.PropagateTaskStatus(tc.taskRun)
+
+			testutil.AssertEqual(t, "exitCode", tc.wantExitCode, status.ExitCode)
+			testutil.AssertEqual(t, "terminationReason", tc.wantReason, status.TerminationReason)
+			testutil.AssertEqual(t, "startTime", tc.wantStartTime, status.StartTime)
+			testutil.AssertEqual(t, "succeeded", tc.wantSucceeded, status.GetCondition(apis.ConditionSucceeded).Status)
+		})
+	}
+}
+
+func TestTaskStatus_MarkRetrying(t *testing.T) {
+	status := TaskStatus{}
+	status.InitializeConditions()
+	status.MarkRetrying(2, 20*time.Second)
+
+	cond := status.GetCondition(TaskConditionTaskRunReady)
+	testutil.AssertEqual(t, "status", corev1.ConditionUnknown, cond.Status)
+	testutil.AssertEqual(t, "reason", "Retrying", cond.Reason)
+	testutil.AssertEqual(t, "message", "Task failed, starting retry 2 in 20s", cond.Message)
+	testutil.AssertFalse(t, "final", IsStatusFinal(status.Status))
+}
+
+func TestTaskStatus_PropagateIndexStatus(t *testing.T) {
+	cases := map[string]struct {
+		fields     TaskStatusFields
+		terminated bool
+		wantStatus corev1.ConditionStatus
+		wantReason string
+	}{
+		"all succeeded": {
+			fields:     TaskStatusFields{SucceededIndexes: 3},
+			wantStatus: corev1.ConditionTrue,
+		},
+		"running": {
+			fields:     TaskStatusFields{SucceededIndexes: 1, ActiveIndexes: 2},
+			wantStatus: corev1.ConditionUnknown,
+			wantReason: "Running",
+		},
+		"failure waits for active indexes": {
+			fields:     TaskStatusFields{FailedIndexes: 1, ActiveIndexes: 1},
+			wantStatus: corev1.ConditionUnknown,
+			wantReason: "Running",
+		},
+		"failed": {
+			fields:     TaskStatusFields{SucceededIndexes: 2, FailedIndexes: 1},
+			wantStatus: corev1.ConditionFalse,
+			wantReason: "IndexFailed",
+		},
+		"terminated": {
+			fields:     TaskStatusFields{SucceededIndexes: 1},
+			terminated: true,
+			wantStatus: corev1.ConditionFalse,
+			wantReason: "TaskRunCancelled",
+		},
+		"pending": {
+			wantStatus: corev1.ConditionUnknown,
+			wantReason: "Pending",
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			status := TaskStatus{TaskStatusFields: tc.fields}
+			status.InitializeConditions()
+			status.PropagateIndexStatus(3, tc.terminated)
+
+			cond := status.GetCondition(TaskConditionTaskRunReady)
+			testutil.AssertEqual(t, "status", tc.wantStatus, cond.Status)
+			testutil.AssertEqual(t, "reason", tc.wantReason, cond.Reason)
+		})
+	}
+}
@@ -15,6 +15,8 @@
 package v1alpha1
 
 import (
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -25,6 +27,55 @@ import (
 const (
 	// TaskComponentName holds the component label anme for Task.
 	TaskComponentName = "task"
+
+	// TaskNameLabel holds the name of the Task on the TaskRuns and Pods
+	// created for it.
+	TaskNameLabel = "tasks.kf.dev/name"
+
+	// TaskIndexLabel holds the index of the TaskRuns and Pods created for
+	// Tasks with multiple completions.
+	TaskIndexLabel = "tasks.kf.dev/index"
+
+	// TaskIndexEnvVar is the environment variable holding the index of each
+	// run of a Task with multiple completions.
+	TaskIndexEnvVar = "KF_TASK_INDEX"
+
+	// DefaultTaskRetryBackoff is the delay before the first retry of a
+	// failed Task if none is set.
+	DefaultTaskRetryBackoff = 10 * time.Second
+
+	// MaxTaskRetryBackoff is the longest delay between retries of a failed
+	// Task.
+	MaxTaskRetryBackoff = 5 * time.Minute
+
+	// MaxTaskRetries is the maximum number of times a failed Task can be
+	// retried.
+	MaxTaskRetries = 10
+
+	// MaxTaskCompletions is the maximum number of indexed runs a Task can
+	// have.
+	MaxTaskCompletions = 1000
+
+	// TaskTerminationReasonDeadlineExceeded is the termination reason of
+	// Tasks that ran for longer than their timeout.
+	TaskTerminationReasonDeadlineExceeded = "DeadlineExceeded"
+
+	// TaskNotificationSucceeded notifies when a Task succeeds.
+	TaskNotificationSucceeded = "Succeeded"
+
+	// TaskNotificationFailed notifies when a Task fails.
+	TaskNotificationFailed = "Failed"
+
+	// TaskNotificationMissed notifies when a TaskSchedule misses a scheduled
+	// run.
+	TaskNotificationMissed = "Missed"
+
+	// TaskNotificationFormatJSON sends notifications as a plain JSON POST.
+	TaskNotificationFormatJSON = "JSON"
+
+	// TaskNotificationFormatCloudEvent sends notifications as binary mode
+	// CloudEvents.
+	TaskNotificationFormatCloudEvent = "CloudEvent"
 )
 
 // GetGroupVersionKind returns the GroupVersionKind.
@@ -90,6 +141,115 @@ type TaskSpec struct {
 	// Terminated determines if the Task should have been terminated or not.
 	// +optional
 	Terminated bool `json:"terminated,omitempty"`
+
+	// Timeout is how long each run of the Task can take before it's stopped.
+	// It overrides the cluster's default timeout, a zero value means there's
+	// no timeout.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Retries is the number of times the Task is run again if it fails.
+	// +optional
+	Retries int32 `json:"retries,omitempty"`
+
+	// RetryBackoff is the delay before the first retry, it doubles for each
+	// following retry. Defaults to 10 seconds.
+	// +optional
+	RetryBackoff *metav1.Duration `json:"retryBackoff,omitempty"`
+
+	// Image is a container image to run instead of the App's current image,
+	// e.g. a newer version of the App that hasn't been rolled out yet. The
+	// Task still gets the App's environment and service bindings.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// BuildRef references a successful Build of the App whose image is run
+	// instead of the App's current image. At most one of Image and BuildRef
+	// can be set.
+	// +optional
+	BuildRef *corev1.LocalObjectReference `json:"buildRef,omitempty"`
+
+	// Completions is the number of indexed runs of the Task. Each run gets
+	// its index in the KF_TASK_INDEX and CF_INSTANCE_INDEX environment
+	// variables and the Task succeeds once all of them succeed. Failed
+	// runs are retried separately. Defaults to 1.
+	// +optional
+	Completions int32 `json:"completions,omitempty"`
+
+	// Parallelism is the maximum number of indexed runs of the Task that
+	// run at the same time. Defaults to Completions.
+	// +optional
+	Parallelism int32 `json:"parallelism,omitempty"`
+
+	// Notifications are called when the Task finishes or, for Tasks created
+	// by a TaskSchedule, when the schedule misses a run.
+	// +optional
+	Notifications []TaskNotification `json:"notifications,omitempty"`
+}
+
+// TaskNotification is a target that gets an HTTP POST describing the result
+// of a Task, e.g. a chat webhook, a CloudEvents sink or an email relay
+// service.
+type TaskNotification struct {
+	// URL is the HTTP or HTTPS endpoint the notification is sent to. It can't
+	// be a loopback, link-local or in-cluster address.
+	URL string `json:"url"`
+
+	// Format is either JSON (default) to POST the notification as a JSON
+	// object or CloudEvent to send it as a binary mode CloudEvent.
+	// +optional
+	Format string `json:"format,omitempty"`
+
+	// On is the list of events that trigger the notification: Succeeded,
+	// Failed and Missed. Defaults to Failed and Missed.
+	// +optional
+	On []string `json:"on,omitempty"`
+}
+
+// NotifiesOn returns true if the notification is triggered by the event.
+func (n *TaskNotification) NotifiesOn(event string) bool {
+	if len(n.On) == 0 {
+		return event == TaskNotificationFailed || event == TaskNotificationMissed
+	}
+
+	for _, on := range n.On {
+		if on == event {
+			return true
+		}
+	}
+	return false
+}
+
+// IsIndexed returns true if the Task has multiple indexed runs.
+func (spec *TaskSpec) IsIndexed() bool {
+	return spec.Completions > 1
+}
+
+// MaxParallel gets the maximum number of indexed runs of the Task that run
+// at the same time.
+func (spec *TaskSpec) MaxParallel() int32 {
+	if spec.Parallelism <= 0 || spec.Parallelism > spec.Completions {
+		return spec.Completions
+	}
+	return spec.Parallelism
+}
+
+// RetryDelay gets how long to wait after a failed run of the Task before
+// starting the given retry. Retries are numbered from 1.
+func (spec *TaskSpec) RetryDelay(retry int32) time.Duration {
+	delay := DefaultTaskRetryBackoff
+	if spec.RetryBackoff != nil {
+		delay = spec.RetryBackoff.Duration
+	}
+
+	for i := int32(1); i < retry && delay < MaxTaskRetryBackoff; i++ {
+		delay *= 2
+	}
+
+	if delay > MaxTaskRetryBackoff {
+		return MaxTaskRetryBackoff
+	}
+	return delay
 }
 
 // TaskStatus represents information about the status of a Task.
@@ -115,4 +275,38 @@ type TaskStatusFields struct {
 	// Duration is the time duration of how long did it take for the
 	// Task to transition from start to completion.
 	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// Retries is the number of times the Task has been retried.
+	Retries int32 `json:"retries,omitempty"`
+
+	// ExitCode is the exit code of the Task's container once it terminates.
+	ExitCode *int32 `json:"exitCode,omitempty"`
+
+	// TerminationReason is why the Task's container terminated, e.g.
+	// Completed, Error, OOMKilled or DeadlineExceeded.
+	TerminationReason string `json:"terminationReason,omitempty"`
+
+	// Image is the container image the Task runs, it's resolved when the
+	// Task starts so retries run the same image.
+	Image string `json:"image,omitempty"`
+
+	// ActiveIndexes is the number of indexed runs that are running or
+	// waiting to be retried. Only set for Tasks with multiple completions.
+	ActiveIndexes int32 `json:"activeIndexes,omitempty"`
+
+	// SucceededIndexes is the number of indexed runs that succeeded. Only set
+	// for Tasks with multiple completions.
+	SucceededIndexes int32 `json:"succeededIndexes,omitempty"`
+
+	// FailedIndexes is the number of indexed runs that failed and won't be
+	// retried. Only set for Tasks with multiple completions.
+	FailedIndexes int32 `json:"failedIndexes,omitempty"`
+
+	// DeliveredNotifications are the URLs of the notifications that were
+	// delivered, they aren't sent again when other notifications are retried.
+	// +optional
+	DeliveredNotifications []string `json:"deliveredNotifications,omitempty"`
+
+	// NotificationsSent is true once the Task's notifications were sent.
+	NotificationsSent bool `json:"notificationsSent,omitempty"`
 }
@@ -16,7 +16,12 @@ package v1alpha1
 
 import (
 	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
 
+	"github.com/google/kf/v2/pkg/apis/kf/config"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"knative.dev/pkg/apis"
 )
@@ -56,5 +61,134 @@ func (spec *TaskSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 		}
 	}
 
+	if spec.Timeout != nil && spec.Timeout.Duration < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(spec.Timeout.Duration.String(), "timeout", "can't be negative"))
+	}
+
+	if spec.Retries < 0 || spec.Retries > MaxTaskRetries {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(spec.Retries, 0, MaxTaskRetries, "retries"))
+	}
+
+	if spec.RetryBackoff != nil && spec.RetryBackoff.Duration <= 0 {
+		errs = errs.Also(apis.ErrInvalidValue(spec.RetryBackoff.Duration.String(), "retryBackoff", "must be positive"))
+	}
+
+	if spec.Image != "" && spec.BuildRef != nil {
+		errs = errs.Also(apis.ErrMultipleOneOf("image", "buildRef"))
+	}
+
+	if spec.BuildRef != nil && spec.BuildRef.Name == "" {
+		errs = errs.Also(apis.ErrMissingField("buildRef.name"))
+	}
+
+	if spec.Completions < 0 || spec.Completions > MaxTaskCompletions {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(spec.Completions, 0, MaxTaskCompletions, "completions"))
+	}
+
+	if spec.Parallelism < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(spec.Parallelism, "parallelism", "can't be negative"))
+	}
+
+	for i := range spec.Notifications {
+		errs = errs.Also(spec.Notifications[i].Validate(ctx).ViaFieldIndex("notifications", i))
+	}
+
 	return errs
 }
+
+// Validate implements apis.Validatable.
+func (n *TaskNotification) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if n.URL == "" {
+		errs = errs.Also(apis.ErrMissingField("url"))
+	} else if u, err := url.Parse(n.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs = errs.Also(apis.ErrInvalidValue(n.URL, "url", "must be an absolute HTTP or HTTPS URL"))
+	} else if err := ValidateNotificationHost(u.Hostname(), NotificationAllowedNetworks(ctx)); err != nil {
+		errs = errs.Also(apis.ErrInvalidValue(n.URL, "url", err.Error()))
+	}
+
+	switch n.Format {
+	case "", TaskNotificationFormatJSON, TaskNotificationFormatCloudEvent:
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(n.Format, "format", "must be JSON or CloudEvent"))
+	}
+
+	for i, on := range n.On {
+		switch on {
+		case TaskNotificationSucceeded, TaskNotificationFailed, TaskNotificationMissed:
+		default:
+			errs = errs.Also(apis.ErrInvalidArrayValue(on, "on", i))
+		}
+	}
+
+	return errs
+}
+
+// clusterDomainSuffixes are the DNS suffixes of names that resolve inside the
+// cluster.
+var clusterDomainSuffixes = []string{".svc", ".cluster.local", ".localhost"}
+
+// sharedAddressSpace is the RFC 6598 range some clusters use for Pods and
+// Services.
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// NotificationAllowedNetworks gets the private networks operators allow
+// notifications to be sent to from the config in the context. Invalid config
+// allows none.
+func NotificationAllowedNetworks(ctx context.Context) []*net.IPNet {
+	cfg := config.TryFromContext(ctx)
+	if cfg == nil {
+		return nil
+	}
+
+	defaults, err := cfg.Defaults()
+	if err != nil {
+		return nil
+	}
+
+	networks, err := defaults.TaskNotificationAllowedNetworks()
+	if err != nil {
+		return nil
+	}
+	return networks
+}
+
+// ValidateNotificationHost returns an error if notifications can't be sent to
+// the host. Loopback and link-local addresses, which include cloud metadata
+// servers, and names that resolve inside the cluster are rejected so
+// notifications can't be used to reach internal endpoints. Private addresses
+// are rejected unless they're in one of the allowed networks.
+func ValidateNotificationHost(host string, allowed []*net.IPNet) error {
+	if ip := net.ParseIP(host); ip != nil {
+		if ip.IsLoopback() ||
+			ip.IsLinkLocalUnicast() ||
+			ip.IsLinkLocalMulticast() ||
+			ip.IsInterfaceLocalMulticast() ||
+			ip.IsUnspecified() {
+			return fmt.Errorf("%s is a loopback or link-local address", host)
+		}
+
+		if ip.IsPrivate() || sharedAddressSpace.Contains(ip) {
+			for _, network := range allowed {
+				if network.Contains(ip) {
+					return nil
+				}
+			}
+			return fmt.Errorf("%s is a private address", host)
+		}
+
+		return nil
+	}
+
+	name := strings.TrimSuffix(strings.ToLower(host), ".")
+	if name == "localhost" || !strings.Contains(name, ".") {
+		return fmt.Errorf("%s must be a fully qualified domain name", host)
+	}
+
+	for _, suffix := range clusterDomainSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return fmt.Errorf("%s is an in-cluster address", host)
+		}
+	}
+
+	return nil
+}
@@ -16,9 +16,12 @@ package v1alpha1
 
 import (
 	"context"
+	"net"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/kf/v2/pkg/apis/kf/config"
 	"github.com/google/kf/v2/pkg/kf/testutil"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -105,6 +108,168 @@ func TestTask_Validate(t *testing.T) {
 			},
 			want: apis.ErrInvalidValue("invalid", "spec.disk"),
 		},
+		"negative timeout": {
+			spec: Task{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "valid",
+				},
+				Spec: TaskSpec{
+					AppRef: corev1.LocalObjectReference{
+						Name: "appRef",
+					},
+					Timeout: &metav1.Duration{Duration: -time.Minute},
+				},
+			},
+			want: apis.ErrInvalidValue("-1m0s", "spec.timeout", "can't be negative"),
+		},
+		"too many retries": {
+			spec: Task{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "valid",
+				},
+				Spec: TaskSpec{
+					AppRef: corev1.LocalObjectReference{
+						Name: "appRef",
+					},
+					Retries: MaxTaskRetries + 1,
+				},
+			},
+			want: apis.ErrOutOfBoundsValue(MaxTaskRetries+1, 0, MaxTaskRetries, "spec.retries"),
+		},
+		"zero retry backoff": {
+			spec: Task{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "valid",
+				},
+				Spec: TaskSpec{
+					AppRef: corev1.LocalObjectReference{
+						Name: "appRef",
+					},
+					Retries:      1,
+					RetryBackoff: &metav1.Duration{},
+				},
+			},
+			want: apis.ErrInvalidValue("0s", "spec.retryBackoff", "must be positive"),
+		},
+		"image and build": {
+			spec: Task{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "valid",
+				},
+				Spec: TaskSpec{
+					AppRef: corev1.LocalObjectReference{
+						Name: "appRef",
+					},
+					Image:    "gcr.io/my-project/my-app:v2",
+					BuildRef: &corev1.LocalObjectReference{Name: "my-build"},
+				},
+			},
+			want: apis.ErrMultipleOneOf("spec.buildRef", "spec.image"),
+		},
+		"build without name": {
+			spec: Task{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "valid",
+				},
+				Spec: TaskSpec{
+					AppRef: corev1.LocalObjectReference{
+						Name: "appRef",
+					},
+					BuildRef: &corev1.LocalObjectReference{},
+				},
+			},
+			want: apis.ErrMissingField("spec.buildRef.name"),
+		},
+		"too many completions": {
+			spec: Task{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "valid",
+				},
+				Spec: TaskSpec{
+					AppRef: corev1.LocalObjectReference{
+						Name: "appRef",
+					},
+					Completions: MaxTaskCompletions + 1,
+				},
+			},
+			want: apis.ErrOutOfBoundsValue(MaxTaskCompletions+1, 0, MaxTaskCompletions, "spec.completions"),
+		},
+		"negative parallelism": {
+			spec: Task{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "valid",
+				},
+				Spec: TaskSpec{
+					AppRef: corev1.LocalObjectReference{
+						Name: "appRef",
+					},
+					Completions: 4,
+					Parallelism: -1,
+				},
+			},
+			want: apis.ErrInvalidValue(-1, "spec.parallelism", "can't be negative"),
+		},
+		"valid notifications": {
+			spec: Task{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "valid",
+				},
+				Spec: TaskSpec{
+					AppRef: corev1.LocalObjectReference{
+						Name: "appRef",
+					},
+					Notifications: []TaskNotification{
+						{URL: "https://hooks.example.com/billing"},
+						{
+							URL:    "http://sink.example.com",
+							Format: TaskNotificationFormatCloudEvent,
+							On:     []string{TaskNotificationSucceeded, TaskNotificationFailed},
+						},
+					},
+				},
+			},
+		},
+		"invalid notifications": {
+			spec: Task{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "valid",
+				},
+				Spec: TaskSpec{
+					AppRef: corev1.LocalObjectReference{
+						Name: "appRef",
+					},
+					Notifications: []TaskNotification{
+						{URL: "mailto:ops@example.com"},
+						{URL: "https://hooks.example.com", Format: "XML", On: []string{"Started"}},
+						{},
+					},
+				},
+			},
+			want: apis.ErrInvalidValue("mailto:ops@example.com", "spec.notifications[0].url", "must be an absolute HTTP or HTTPS URL").
+				Also(apis.ErrInvalidValue("XML", "spec.notifications[1].format", "must be JSON or CloudEvent")).
+				Also(apis.ErrInvalidArrayValue("Started", "spec.notifications[1].on", 0)).
+				Also(apis.ErrMissingField("spec.notifications[2].url")),
+		},
+		"internal notification destinations": {
+			spec: Task{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "valid",
+				},
+				Spec: TaskSpec{
+					AppRef: corev1.LocalObjectReference{
+						Name: "appRef",
+					},
+					Notifications: []TaskNotification{
+						{URL: "http://169.254.169.254/computeMetadata/v1"},
+						{URL: "http://sink.default.svc.cluster.local"},
+						{URL: "http://sink"},
+					},
+				},
+			},
+			want: apis.ErrInvalidValue("http://169.254.169.254/computeMetadata/v1", "spec.notifications[0].url", "169.254.169.254 is a loopback or link-local address").
+				Also(apis.ErrInvalidValue("http://sink.default.svc.cluster.local", "spec.notifications[1].url", "sink.default.svc.cluster.local is an in-cluster address")).
+				Also(apis.ErrInvalidValue("http://sink", "spec.notifications[2].url", "sink must be a fully qualified domain name")),
+		},
 		"multi params invalid": {
 			spec: Task{
 				ObjectMeta: metav1.ObjectMeta{
@@ -131,3 +296,185 @@ func TestTask_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskSpec_RetryDelay(t *testing.T) {
+	cases := map[string]struct {
+		spec  TaskSpec
+		retry int32
+		want  time.Duration
+	}{
+		"default first retry": {
+			retry: 1,
+			want:  DefaultTaskRetryBackoff,
+		},
+		"doubles for each retry": {
+			spec:  TaskSpec{RetryBackoff: &metav1.Duration{Duration: time.Second}},
+			retry: 3,
+			want:  4 * time.Second,
+		},
+		"capped": {
+			spec:  TaskSpec{RetryBackoff: &metav1.Duration{Duration: time.Minute}},
+			retry: MaxTaskRetries,
+			want:  MaxTaskRetryBackoff,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			testutil.AssertEqual(t, "delay", tc.want, tc.spec.RetryDelay(tc.retry))
+		})
+	}
+}
+
+func TestTaskSpec_MaxParallel(t *testing.T) {
+	cases := map[string]struct {
+		spec TaskSpec
+		want int32
+	}{
+		"defaults to completions": {
+			spec: TaskSpec{Completions: 5},
+			want: 5,
+		},
+		"limited by parallelism": {
+			spec: TaskSpec{Completions: 5, Parallelism: 2},
+			want: 2,
+		},
+		"capped at completions": {
+			spec: TaskSpec{Completions: 5, Parallelism: 10},
+			want: 5,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			testutil.AssertEqual(t, "parallel", tc.want, tc.spec.MaxParallel())
+		})
+	}
+}
+
+func TestTaskNotification_NotifiesOn(t *testing.T) {
+	cases := map[string]struct {
+		on    []string
+		event string
+		want  bool
+	}{
+		"default failed": {
+			event: TaskNotificationFailed,
+			want:  true,
+		},
+		"default missed": {
+			event: TaskNotificationMissed,
+			want:  true,
+		},
+		"default succeeded": {
+			event: TaskNotificationSucceeded,
+			want:  false,
+		},
+		"listed": {
+			on:    []string{TaskNotificationSucceeded},
+			event: TaskNotificationSucceeded,
+			want:  true,
+		},
+		"not listed": {
+			on:    []string{TaskNotificationSucceeded},
+			event: TaskNotificationFailed,
+			want:  false,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			n := TaskNotification{On: tc.on}
+			testutil.AssertEqual(t, "notifies", tc.want, n.NotifiesOn(tc.event))
+		})
+	}
+}
+
+func TestValidateNotificationHost(t *testing.T) {
+	t.Parallel()
+
+	_, allowed, err := net.ParseCIDR("10.1.0.0/16")
+	testutil.AssertNil(t, "err", err)
+
+	cases := map[string]struct {
+		host    string
+		allowed []*net.IPNet
+		wantErr bool
+	}{
+		"public name":          {host: "hooks.example.com"},
+		"public IPv4":          {host: "203.0.113.10"},
+		"public IPv6":          {host: "2001:db8::1"},
+		"metadata server":      {host: "169.254.169.254", wantErr: true},
+		"IPv6 link-local":      {host: "fe80::1", wantErr: true},
+		"loopback":             {host: "127.0.0.1", wantErr: true},
+		"IPv6 loopback":        {host: "::1", wantErr: true},
+		"unspecified":          {host: "0.0.0.0", wantErr: true},
+		"localhost":            {host: "localhost", wantErr: true},
+		"single label":         {host: "sink", wantErr: true},
+		"Service":              {host: "sink.default.svc", wantErr: true},
+		"Service FQDN":         {host: "sink.default.svc.cluster.local.", wantErr: true},
+		"localhost subdomain":  {host: "app.localhost", wantErr: true},
+		"cluster domain upper": {host: "SINK.DEFAULT.SVC.CLUSTER.LOCAL", wantErr: true},
+		"RFC 1918 10/8":        {host: "10.1.2.3", wantErr: true},
+		"RFC 1918 172.16/12":   {host: "172.20.0.10", wantErr: true},
+		"RFC 1918 192.168/16":  {host: "192.168.1.1", wantErr: true},
+		"IPv6 ULA":             {host: "fd00::1", wantErr: true},
+		"shared address space": {host: "100.64.0.10", wantErr: true},
+		"allowed private":      {host: "10.1.2.3", allowed: []*net.IPNet{allowed}},
+		"private outside allowed": {
+			host:    "10.2.0.1",
+			allowed: []*net.IPNet{allowed},
+			wantErr: true,
+		},
+		"allowed doesn't permit loopback": {
+			host:    "127.0.0.1",
+			allowed: []*net.IPNet{{IP: net.IPv4(127, 0, 0, 0), Mask: net.CIDRMask(8, 32)}},
+			wantErr: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			err := ValidateNotificationHost(tc.host, tc.allowed)
+			testutil.AssertEqual(t, "error", tc.wantErr, err != nil)
+		})
+	}
+}
+
+func TestTaskNotification_Validate_allowedNetworks(t *testing.T) {
+	t.Parallel()
+
+	notification := TaskNotification{URL: "http://10.1.2.3/hooks"}
+
+	cases := map[string]struct {
+		ctx     context.Context
+		wantErr bool
+	}{
+		"no config": {
+			ctx:     context.Background(),
+			wantErr: true,
+		},
+		"not allowed": {
+			ctx:     config.DefaultConfigContext(context.Background()),
+			wantErr: true,
+		},
+		"allowed": {
+			ctx: config.ToContextForTest(context.Background(), config.CreateConfigForTest(&config.DefaultsConfig{
+				TaskNotificationAllowedCIDRs: []string{"10.1.0.0/16"},
+			})),
+		},
+		"invalid allowed networks": {
+			ctx: config.ToContextForTest(context.Background(), config.CreateConfigForTest(&config.DefaultsConfig{
+				TaskNotificationAllowedCIDRs: []string{"10.1.0.0/16", "not-a-cidr"},
+			})),
+			wantErr: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			err := notification.Validate(tc.ctx)
+			testutil.AssertEqual(t, "error", tc.wantErr, err != nil)
+		})
+	}
+}
@@ -15,6 +15,12 @@
 package v1alpha1
 
 import (
+	"time"
+	// Embed the time zone database so schedules can be evaluated in any time
+	// zone, even if the container image doesn't have one.
+	_ "time/tzdata"
+
+	cron "github.com/robfig/cron/v3"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
@@ -64,6 +70,28 @@ type TaskScheduleSpec struct {
 	// Schedule is the interval to start Tasks in Cron format, see https://en.wikipedia.org/wiki/Cron.
 	Schedule string `json:"schedule,omitempty"`
 
+	// TimeZone is the IANA name of the time zone the Schedule is evaluated
+	// in, e.g. "America/New_York". Defaults to the controller's time zone.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// StartingDeadlineSeconds is how long after its scheduled time a run can
+	// still be started, e.g. after the controller was unavailable. Runs that
+	// miss their deadline are skipped and counted in the status. If unset,
+	// the latest missed run is always started.
+	// +optional
+	StartingDeadlineSeconds *int64 `json:"startingDeadlineSeconds,omitempty"`
+
+	// SuccessfulHistoryLimit is the number of successful Tasks to keep. If
+	// unset, all successful Tasks are kept.
+	// +optional
+	SuccessfulHistoryLimit *int32 `json:"successfulHistoryLimit,omitempty"`
+
+	// FailedHistoryLimit is the number of failed Tasks to keep. If unset, all
+	// failed Tasks are kept.
+	// +optional
+	FailedHistoryLimit *int32 `json:"failedHistoryLimit,omitempty"`
+
 	// Suspend tells the controller to suspend subsequent executions. It does
 	// not apply to already started executions.
 	// +optional
@@ -73,6 +101,29 @@ type TaskScheduleSpec struct {
 	TaskTemplate TaskSpec `json:"taskTemplate,omitempty"`
 }
 
+// ParseSchedule parses the Schedule in the TaskSchedule's time zone.
+func (spec *TaskScheduleSpec) ParseSchedule() (cron.Schedule, error) {
+	if spec.TimeZone == "" {
+		return cron.ParseStandard(spec.Schedule)
+	}
+
+	if _, err := time.LoadLocation(spec.TimeZone); err != nil {
+		return nil, err
+	}
+
+	return cron.ParseStandard("CRON_TZ=" + spec.TimeZone + " " + spec.Schedule)
+}
+
+// StartingDeadline returns how long after its scheduled time a run can still
+// be started. The second value is false if there's no deadline.
+func (spec *TaskScheduleSpec) StartingDeadline() (time.Duration, bool) {
+	if spec.StartingDeadlineSeconds == nil {
+		return 0, false
+	}
+
+	return time.Duration(*spec.StartingDeadlineSeconds) * time.Second, true
+}
+
 // TaskScheduleStatus represents information about the status of a TaskSchedule.
 type TaskScheduleStatus struct {
 	// Pull in the fields from Knative's duckv1beta1 status field.
@@ -90,6 +141,14 @@ type TaskScheduleStatusFields struct {
 	// LastScheduleTime is the timestamp of when a Task was last scheduled.
 	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
 
+	// MissedRuns is the number of scheduled runs that weren't started
+	// because they missed their starting deadline or a later run was due.
+	MissedRuns int64 `json:"missedRuns,omitempty"`
+
+	// LastMissedTime is the scheduled time of the latest run that wasn't
+	// started.
+	LastMissedTime *metav1.Time `json:"lastMissedTime,omitempty"`
+
 	// TODO(b/193059618): Consider adding additional Status fields (enumerated in bug).
 }
 
@@ -0,0 +1,59 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+
+	"knative.dev/pkg/ptr"
+)
+
+func ExampleTaskScheduleSpec_ParseSchedule() {
+	spec := TaskScheduleSpec{
+		Schedule: "0 3 * * *",
+		TimeZone: "America/New_York",
+	}
+
+	schedule, err := spec.ParseSchedule()
+	if err != nil {
+		panic(err)
+	}
+
+	// The run stays at 3AM local time when daylight saving time ends.
+	start := time.Date(2022, time.November, 5, 12, 0, 0, 0, time.UTC)
+	first := schedule.Next(start)
+	second := schedule.Next(first)
+	fmt.Println(first.UTC())
+	fmt.Println(second.UTC())
+
+	_, err = (&TaskScheduleSpec{Schedule: "* * * * *", TimeZone: "Nowhere"}).ParseSchedule()
+	fmt.Println("Invalid time zone:", err != nil)
+
+	// Output: 2022-11-06 08:00:00 +0000 UTC
+	// 2022-11-07 08:00:00 +0000 UTC
+	// Invalid time zone: true
+}
+
+func ExampleTaskScheduleSpec_StartingDeadline() {
+	spec := TaskScheduleSpec{}
+	fmt.Println(spec.StartingDeadline())
+
+	spec.StartingDeadlineSeconds = ptr.Int64(90)
+	fmt.Println(spec.StartingDeadline())
+
+	// Output: 0s false
+	// 1m30s true
+}
@@ -16,6 +16,8 @@ package v1alpha1
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	cron "github.com/robfig/cron/v3"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -38,10 +40,29 @@ var validConcurrencyPolicies = sets.NewString(ConcurrencyPolicyAlways, Concurren
 
 // Validate implements apis.Validatable.
 func (spec *TaskScheduleSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
-	if _, err := cron.ParseStandard(spec.Schedule); err != nil {
+	if spec.TimeZone != "" {
+		if _, err := time.LoadLocation(spec.TimeZone); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(spec.TimeZone, "timeZone"))
+		}
+	}
+
+	// Time zones must be set using the timeZone field so they're validated.
+	if _, err := cron.ParseStandard(spec.Schedule); err != nil || strings.Contains(spec.Schedule, "TZ=") {
 		errs = errs.Also(apis.ErrInvalidValue(spec.Schedule, "schedule"))
 	}
 
+	if deadline := spec.StartingDeadlineSeconds; deadline != nil && *deadline < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(*deadline, "startingDeadlineSeconds", "can't be negative"))
+	}
+
+	if limit := spec.SuccessfulHistoryLimit; limit != nil && *limit < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(*limit, "successfulHistoryLimit", "can't be negative"))
+	}
+
+	if limit := spec.FailedHistoryLimit; limit != nil && *limit < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(*limit, "failedHistoryLimit", "can't be negative"))
+	}
+
 	if !validConcurrencyPolicies.Has(spec.ConcurrencyPolicy) {
 		errs = errs.Also(apis.ErrInvalidValue(spec.ConcurrencyPolicy, "concurrencyPolicy"))
 	}
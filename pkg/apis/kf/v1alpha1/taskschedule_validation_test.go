@@ -23,6 +23,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
+	"knative.dev/pkg/ptr"
 )
 
 func TestTaskSchedule_Validate_statusUpdate(t *testing.T) {
@@ -114,6 +115,59 @@ func TestTaskSchedule_Validate(t *testing.T) {
 			},
 			want: apis.ErrInvalidValue("badPolicy", "spec.concurrencyPolicy"),
 		},
+		"valid time zone and limits": {
+			spec: TaskSchedule{
+				ObjectMeta: goodMeta,
+				Spec: TaskScheduleSpec{
+					ConcurrencyPolicy:       "Always",
+					Schedule:                "0 3 * * *",
+					TimeZone:                "America/New_York",
+					StartingDeadlineSeconds: ptr.Int64(300),
+					SuccessfulHistoryLimit:  ptr.Int32(3),
+					FailedHistoryLimit:      ptr.Int32(0),
+					TaskTemplate:            goodTaskTemplate,
+				},
+			},
+		},
+		"invalid time zone": {
+			spec: TaskSchedule{
+				ObjectMeta: goodMeta,
+				Spec: TaskScheduleSpec{
+					ConcurrencyPolicy: "Always",
+					Schedule:          "* * * * *",
+					TimeZone:          "Mars/Olympus_Mons",
+					TaskTemplate:      goodTaskTemplate,
+				},
+			},
+			want: apis.ErrInvalidValue("Mars/Olympus_Mons", "spec.timeZone"),
+		},
+		"time zone in schedule": {
+			spec: TaskSchedule{
+				ObjectMeta: goodMeta,
+				Spec: TaskScheduleSpec{
+					ConcurrencyPolicy: "Always",
+					Schedule:          "CRON_TZ=UTC * * * * *",
+					TaskTemplate:      goodTaskTemplate,
+				},
+			},
+			want: apis.ErrInvalidValue("CRON_TZ=UTC * * * * *", "spec.schedule"),
+		},
+		"negative starting deadline and history limits": {
+			spec: TaskSchedule{
+				ObjectMeta: goodMeta,
+				Spec: TaskScheduleSpec{
+					ConcurrencyPolicy:       "Always",
+					Schedule:                "* * * * *",
+					StartingDeadlineSeconds: ptr.Int64(-1),
+					SuccessfulHistoryLimit:  ptr.Int32(-1),
+					FailedHistoryLimit:      ptr.Int32(-2),
+					TaskTemplate:            goodTaskTemplate,
+				},
+			},
+			want: apis.ErrInvalidValue(int64(-1), "spec.startingDeadlineSeconds", "can't be negative").
+				Also(apis.ErrInvalidValue(int32(-1), "spec.successfulHistoryLimit", "can't be negative")).
+				Also(apis.ErrInvalidValue(int32(-2), "spec.failedHistoryLimit", "can't be negative")),
+		},
 	}
 
 	for tn, tc := range cases {
@@ -0,0 +1,64 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import "context"
+
+const (
+	workflowComponentName    = "workflow"
+	workflowRunComponentName = "workflow-run"
+)
+
+// SetDefaults implements apis.Defaultable.
+func (w *Workflow) SetDefaults(ctx context.Context) {
+	w.Spec.SetDefaults(ctx)
+	w.Labels = UnionMaps(
+		w.Labels,
+		map[string]string{
+			ManagedByLabel: "kf",
+			ComponentLabel: workflowComponentName,
+		},
+	)
+}
+
+// SetDefaults implements apis.Defaultable.
+func (spec *WorkflowSpec) SetDefaults(ctx context.Context) {
+	setWorkflowStepDefaults(ctx, spec.Steps)
+}
+
+// SetDefaults implements apis.Defaultable.
+func (r *WorkflowRun) SetDefaults(ctx context.Context) {
+	setWorkflowStepDefaults(ctx, r.Spec.Steps)
+	r.Labels = UnionMaps(
+		r.Labels,
+		map[string]string{
+			ManagedByLabel: "kf",
+			ComponentLabel: workflowRunComponentName,
+			NameLabel:      r.Spec.WorkflowRef.Name,
+		},
+	)
+}
+
+func setWorkflowStepDefaults(ctx context.Context, steps []WorkflowStep) {
+	for i := range steps {
+		if steps[i].RunIf == "" {
+			steps[i].RunIf = WorkflowStepRunIfSucceeded
+		}
+
+		if steps[i].TaskTemplate != nil {
+			steps[i].TaskTemplate.SetDefaults(ctx)
+		}
+	}
+}
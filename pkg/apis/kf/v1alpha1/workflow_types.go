@@ -0,0 +1,109 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+)
+
+const (
+	// WorkflowStepRunIfSucceeded runs a step if all its dependencies
+	// succeeded.
+	WorkflowStepRunIfSucceeded = "Succeeded"
+
+	// WorkflowStepRunIfFailed runs a step if any of its dependencies failed,
+	// it's used for failure handlers.
+	WorkflowStepRunIfFailed = "Failed"
+
+	// WorkflowStepRunIfCompleted runs a step once all its dependencies
+	// completed, regardless of their result.
+	WorkflowStepRunIfCompleted = "Completed"
+)
+
+// GetGroupVersionKind returns the GroupVersionKind.
+func (w *Workflow) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("Workflow")
+}
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Workflow is a configuration to run a graph of Tasks. Each run of a Workflow
+// is a WorkflowRun.
+type Workflow struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Spec WorkflowSpec `json:"spec,omitempty"`
+}
+
+var _ apis.Validatable = (*Workflow)(nil)
+var _ apis.Defaultable = (*Workflow)(nil)
+
+// WorkflowSpec contains the specification of a Workflow.
+type WorkflowSpec struct {
+	// Steps are the Tasks run by the Workflow. Steps without dependencies
+	// start in parallel when the Workflow is run.
+	Steps []WorkflowStep `json:"steps,omitempty"`
+}
+
+// WorkflowStep is a Task run as part of a Workflow.
+type WorkflowStep struct {
+	// Name identifies the step within the Workflow.
+	Name string `json:"name"`
+
+	// JobRef references a Job (TaskSchedule) whose Task template is run for
+	// the step.
+	// +optional
+	JobRef *corev1.LocalObjectReference `json:"jobRef,omitempty"`
+
+	// TaskTemplate is the Task run for the step, e.g. a command on an App.
+	// Exactly one of JobRef and TaskTemplate must be set.
+	// +optional
+	TaskTemplate *TaskSpec `json:"taskTemplate,omitempty"`
+
+	// DependsOn are the names of the steps that must complete before this
+	// step can start.
+	// +optional
+	DependsOn []string `json:"dependsOn,omitempty"`
+
+	// RunIf decides whether the step runs once its dependencies completed.
+	// Valid values are
+	//
+	// - "Succeeded" (default): all dependencies succeeded;
+	// - "Failed": any dependency failed, used for failure handlers;
+	// - "Completed": always.
+	//
+	// Steps that don't run are skipped.
+	// +optional
+	RunIf string `json:"runIf,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// WorkflowList is a list of Workflow resources.
+type WorkflowList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []Workflow `json:"items"`
+}
@@ -0,0 +1,170 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"knative.dev/pkg/apis"
+)
+
+var validWorkflowStepRunIfs = sets.NewString(
+	WorkflowStepRunIfSucceeded,
+	WorkflowStepRunIfFailed,
+	WorkflowStepRunIfCompleted,
+)
+
+// Validate makes sure that Workflow is properly configured.
+func (w *Workflow) Validate(ctx context.Context) (errs *apis.FieldError) {
+	errs = errs.Also(apis.ValidateObjectMetadata(w.GetObjectMeta()).ViaField("metadata"))
+	errs = errs.Also(w.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
+	return
+}
+
+// Validate implements apis.Validatable.
+func (spec *WorkflowSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
+	return validateWorkflowSteps(ctx, spec.Steps)
+}
+
+// Validate makes sure that WorkflowRun is properly configured.
+func (r *WorkflowRun) Validate(ctx context.Context) (errs *apis.FieldError) {
+	// If we're specifically updating status, don't reject the change because
+	// of a spec issue.
+	if apis.IsInStatusUpdate(ctx) {
+		return
+	}
+
+	errs = errs.Also(apis.ValidateObjectMetadata(r.GetObjectMeta()).ViaField("metadata"))
+	errs = errs.Also(r.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
+	return
+}
+
+// Validate implements apis.Validatable.
+func (spec *WorkflowRunSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if spec.WorkflowRef.Name == "" {
+		errs = errs.Also(apis.ErrMissingField("workflowRef.name"))
+	}
+
+	return errs.Also(validateWorkflowSteps(ctx, spec.Steps))
+}
+
+func validateWorkflowSteps(ctx context.Context, steps []WorkflowStep) (errs *apis.FieldError) {
+	if len(steps) == 0 {
+		return apis.ErrMissingField("steps")
+	}
+
+	names := sets.NewString()
+	for _, step := range steps {
+		names.Insert(step.Name)
+	}
+
+	seen := sets.NewString()
+	for i, step := range steps {
+		errs = errs.Also(step.validate(ctx, names).ViaFieldIndex("steps", i))
+
+		if seen.Has(step.Name) {
+			errs = errs.Also(apis.ErrInvalidValue(step.Name, "name", "duplicate step name").ViaFieldIndex("steps", i))
+		}
+		seen.Insert(step.Name)
+	}
+
+	if cycle := findWorkflowCycle(steps); cycle != "" {
+		errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("step %q depends on itself", cycle), "steps"))
+	}
+
+	return errs
+}
+
+func (step *WorkflowStep) validate(ctx context.Context, names sets.String) (errs *apis.FieldError) {
+	if step.Name == "" {
+		errs = errs.Also(apis.ErrMissingField("name"))
+	} else if msgs := validation.IsDNS1123Label(step.Name); len(msgs) > 0 {
+		errs = errs.Also(apis.ErrInvalidValue(step.Name, "name", msgs...))
+	}
+
+	switch {
+	case step.JobRef == nil && step.TaskTemplate == nil:
+		errs = errs.Also(apis.ErrMissingOneOf("jobRef", "taskTemplate"))
+	case step.JobRef != nil && step.TaskTemplate != nil:
+		errs = errs.Also(apis.ErrMultipleOneOf("jobRef", "taskTemplate"))
+	case step.JobRef != nil && step.JobRef.Name == "":
+		errs = errs.Also(apis.ErrMissingField("jobRef.name"))
+	case step.TaskTemplate != nil:
+		errs = errs.Also(step.TaskTemplate.Validate(ctx).ViaField("taskTemplate"))
+	}
+
+	for i, dep := range step.DependsOn {
+		if dep == step.Name || !names.Has(dep) {
+			errs = errs.Also(apis.ErrInvalidArrayValue(dep, "dependsOn", i))
+		}
+	}
+
+	if !validWorkflowStepRunIfs.Has(step.RunIf) {
+		errs = errs.Also(apis.ErrInvalidValue(step.RunIf, "runIf"))
+	} else if len(step.DependsOn) == 0 && step.RunIf == WorkflowStepRunIfFailed {
+		errs = errs.Also(apis.ErrInvalidValue(step.RunIf, "runIf", "steps without dependencies can't run on failure"))
+	}
+
+	return errs
+}
+
+// findWorkflowCycle returns the name of a step that's part of a dependency
+// cycle or an empty string if there are none. Unknown dependencies are
+// ignored.
+func findWorkflowCycle(steps []WorkflowStep) string {
+	deps := make(map[string][]string)
+	for _, step := range steps {
+		deps[step.Name] = step.DependsOn
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int)
+
+	var visit func(name string) string
+	visit = func(name string) string {
+		switch state[name] {
+		case visiting:
+			return name
+		case visited:
+			return ""
+		}
+
+		state[name] = visiting
+		for _, dep := range deps[name] {
+			if _, ok := deps[dep]; !ok {
+				continue
+			}
+			if cycle := visit(dep); cycle != "" {
+				return cycle
+			}
+		}
+		state[name] = visited
+		return ""
+	}
+
+	for _, step := range steps {
+		if cycle := visit(step.Name); cycle != "" {
+			return cycle
+		}
+	}
+
+	return ""
+}
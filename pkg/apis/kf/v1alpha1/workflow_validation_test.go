@@ -0,0 +1,158 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestWorkflow_Validate(t *testing.T) {
+	goodMeta := metav1.ObjectMeta{
+		Name: "valid",
+	}
+
+	jobStep := func(name string, dependsOn ...string) WorkflowStep {
+		return WorkflowStep{
+			Name:      name,
+			JobRef:    &corev1.LocalObjectReference{Name: "my-job"},
+			DependsOn: dependsOn,
+			RunIf:     WorkflowStepRunIfSucceeded,
+		}
+	}
+
+	cases := map[string]struct {
+		steps []WorkflowStep
+		want  *apis.FieldError
+	}{
+		"valid fan-out and fan-in": {
+			steps: []WorkflowStep{
+				jobStep("extract"),
+				jobStep("transform-a", "extract"),
+				jobStep("transform-b", "extract"),
+				jobStep("load", "transform-a", "transform-b"),
+				func() WorkflowStep {
+					step := jobStep("cleanup", "load")
+					step.RunIf = WorkflowStepRunIfFailed
+					return step
+				}(),
+			},
+		},
+		"no steps": {
+			want: apis.ErrMissingField("spec.steps"),
+		},
+		"step with job and template": {
+			steps: []WorkflowStep{
+				func() WorkflowStep {
+					step := jobStep("extract")
+					step.TaskTemplate = &TaskSpec{AppRef: corev1.LocalObjectReference{Name: "my-app"}}
+					return step
+				}(),
+			},
+			want: apis.ErrMultipleOneOf("spec.steps[0].jobRef", "spec.steps[0].taskTemplate"),
+		},
+		"step without job or template": {
+			steps: []WorkflowStep{
+				{Name: "extract", RunIf: WorkflowStepRunIfSucceeded},
+			},
+			want: apis.ErrMissingOneOf("spec.steps[0].jobRef", "spec.steps[0].taskTemplate"),
+		},
+		"invalid task template": {
+			steps: []WorkflowStep{
+				{Name: "extract", TaskTemplate: &TaskSpec{}, RunIf: WorkflowStepRunIfSucceeded},
+			},
+			want: apis.ErrMissingField("spec.steps[0].taskTemplate.appRef"),
+		},
+		"duplicate step": {
+			steps: []WorkflowStep{
+				jobStep("extract"),
+				jobStep("extract"),
+			},
+			want: apis.ErrInvalidValue("extract", "spec.steps[1].name", "duplicate step name"),
+		},
+		"unknown dependency": {
+			steps: []WorkflowStep{
+				jobStep("load", "extract"),
+			},
+			want: apis.ErrInvalidArrayValue("extract", "spec.steps[0].dependsOn", 0),
+		},
+		"dependency cycle": {
+			steps: []WorkflowStep{
+				jobStep("extract", "load"),
+				jobStep("load", "extract"),
+			},
+			want: apis.ErrGeneric(`step "extract" depends on itself`, "spec.steps"),
+		},
+		"failure handler without dependencies": {
+			steps: []WorkflowStep{
+				func() WorkflowStep {
+					step := jobStep("cleanup")
+					step.RunIf = WorkflowStepRunIfFailed
+					return step
+				}(),
+			},
+			want: apis.ErrInvalidValue(WorkflowStepRunIfFailed, "spec.steps[0].runIf", "steps without dependencies can't run on failure"),
+		},
+		"invalid run if": {
+			steps: []WorkflowStep{
+				func() WorkflowStep {
+					step := jobStep("extract")
+					step.RunIf = "Sometimes"
+					return step
+				}(),
+			},
+			want: apis.ErrInvalidValue("Sometimes", "spec.steps[0].runIf"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			workflow := &Workflow{
+				ObjectMeta: goodMeta,
+				Spec:       WorkflowSpec{Steps: tc.steps},
+			}
+
+			got := workflow.Validate(context.Background())
+			testutil.AssertEqual(t, "validation errors", tc.want.Error(), got.Error())
+		})
+	}
+}
+
+func TestWorkflowRun_Validate(t *testing.T) {
+	run := &WorkflowRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+		Spec: WorkflowRunSpec{
+			Steps: []WorkflowStep{
+				{
+					Name:   "extract",
+					JobRef: &corev1.LocalObjectReference{Name: "my-job"},
+					RunIf:  WorkflowStepRunIfSucceeded,
+				},
+			},
+		},
+	}
+
+	got := run.Validate(context.Background())
+	testutil.AssertEqual(t, "validation errors", apis.ErrMissingField("spec.workflowRef.name").Error(), got.Error())
+
+	// Validation should be skipped while updating the status.
+	ctx := apis.WithinSubResourceUpdate(context.Background(), run, "status")
+	testutil.AssertTrue(t, "status update errors", run.Validate(ctx) == nil)
+}
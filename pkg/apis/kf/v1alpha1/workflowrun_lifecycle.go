@@ -0,0 +1,123 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MarkSpaceHealthy notes that the Space was able to be retrieved.
+func (status *WorkflowRunStatus) MarkSpaceHealthy() {
+	status.SpaceCondition().MarkSuccess()
+}
+
+// MarkSpaceUnhealthy notes that the Space was could not be retrieved.
+func (status *WorkflowRunStatus) MarkSpaceUnhealthy(reason, message string) {
+	status.SpaceCondition().MarkFalse(reason, message)
+}
+
+// PropagateTerminatingStatus updates the status of the WorkflowRun to False
+// if it received a delete request.
+func (status *WorkflowRunStatus) PropagateTerminatingStatus() {
+	status.manage().MarkFalse(WorkflowRunConditionSucceeded, "Terminating", "WorkflowRun is terminating")
+}
+
+// InitializeSteps adds a Pending status for each step that doesn't have one
+// yet.
+func (status *WorkflowRunStatus) InitializeSteps(steps []WorkflowStep) {
+	for _, step := range steps {
+		if status.StepStatus(step.Name) == nil {
+			status.Steps = append(status.Steps, WorkflowStepStatus{
+				Name:  step.Name,
+				Phase: WorkflowStepPending,
+			})
+		}
+	}
+}
+
+// StepStatus gets the status of the step with the given name or nil if it
+// doesn't exist.
+func (status *WorkflowRunStatus) StepStatus(name string) *WorkflowStepStatus {
+	for i := range status.Steps {
+		if status.Steps[i].Name == name {
+			return &status.Steps[i]
+		}
+	}
+
+	return nil
+}
+
+// IsCompleted returns true if the step won't change phase anymore.
+func (step *WorkflowStepStatus) IsCompleted() bool {
+	switch step.Phase {
+	case WorkflowStepSucceeded, WorkflowStepFailed, WorkflowStepSkipped:
+		return true
+	default:
+		return false
+	}
+}
+
+// PropagateTaskStatus updates the phase of the step from the Task created
+// for it.
+func (step *WorkflowStepStatus) PropagateTaskStatus(task *Task) {
+	step.TaskName = task.Name
+
+	cond := task.Status.GetCondition(TaskConditionSucceeded)
+	switch {
+	case cond.IsTrue():
+		step.Phase = WorkflowStepSucceeded
+		step.Message = ""
+	case cond.IsFalse():
+		step.Phase = WorkflowStepFailed
+		step.Message = cond.Message
+	default:
+		step.Phase = WorkflowStepRunning
+		step.Message = ""
+	}
+}
+
+// PropagateStepStatus updates the WorkflowRun's readiness from the phases of
+// its steps. The run succeeds once all steps completed and none failed.
+func (status *WorkflowRunStatus) PropagateStepStatus(terminated bool, now metav1.Time) {
+	completed := 0
+	var failed *WorkflowStepStatus
+	for i := range status.Steps {
+		step := &status.Steps[i]
+		if step.IsCompleted() {
+			completed++
+		}
+		if step.Phase == WorkflowStepFailed && failed == nil {
+			failed = step
+		}
+	}
+
+	if completed < len(status.Steps) {
+		status.StepsCondition().MarkUnknown("Running", "%d of %d steps completed", completed, len(status.Steps))
+		return
+	}
+
+	if status.CompletionTime == nil {
+		status.CompletionTime = &now
+	}
+
+	switch {
+	case failed != nil:
+		status.StepsCondition().MarkFalse("StepFailed", "Step %q failed: %s", failed.Name, failed.Message)
+	case terminated:
+		status.StepsCondition().MarkFalse("Terminated", "WorkflowRun was terminated")
+	default:
+		status.StepsCondition().MarkSuccess()
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestWorkflowRunStatus_PropagateStepStatus(t *testing.T) {
+	now := metav1.NewTime(time.Unix(1000, 0))
+
+	makeStatus := func(phases ...string) WorkflowRunStatus {
+		status := WorkflowRunStatus{}
+		status.InitializeConditions()
+		status.MarkSpaceHealthy()
+		for i, phase := range phases {
+			status.Steps = append(status.Steps, WorkflowStepStatus{
+				Name:    string(rune('a' + i)),
+				Phase:   phase,
+				Message: "exit status 1",
+			})
+		}
+		return status
+	}
+
+	cases := map[string]struct {
+		status         WorkflowRunStatus
+		terminated     bool
+		wantStatus     corev1.ConditionStatus
+		wantReason     string
+		wantCompletion bool
+	}{
+		"steps running": {
+			status:     makeStatus(WorkflowStepSucceeded, WorkflowStepRunning, WorkflowStepPending),
+			wantStatus: corev1.ConditionUnknown,
+			wantReason: "Running",
+		},
+		"all steps succeeded or skipped": {
+			status:         makeStatus(WorkflowStepSucceeded, WorkflowStepSkipped),
+			wantStatus:     corev1.ConditionTrue,
+			wantCompletion: true,
+		},
+		"handled failure still fails the run": {
+			status:         makeStatus(WorkflowStepFailed, WorkflowStepSucceeded),
+			wantStatus:     corev1.ConditionFalse,
+			wantReason:     "StepFailed",
+			wantCompletion: true,
+		},
+		"terminated": {
+			status:         makeStatus(WorkflowStepSucceeded, WorkflowStepSkipped),
+			terminated:     true,
+			wantStatus:     corev1.ConditionFalse,
+			wantReason:     "Terminated",
+			wantCompletion: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			tc.status.PropagateStepStatus(tc.terminated, now)
+
+			cond := tc.status.GetCondition(WorkflowRunConditionSucceeded)
+			testutil.AssertEqual(t, "status", tc.wantStatus, cond.Status)
+			testutil.AssertEqual(t, "reason", tc.wantReason, cond.Reason)
+			testutil.AssertEqual(t, "completion set", tc.wantCompletion, tc.status.CompletionTime != nil)
+		})
+	}
+}
+
+func TestWorkflowRunStatus_InitializeSteps(t *testing.T) {
+	status := WorkflowRunStatus{}
+	status.Steps = []WorkflowStepStatus{
+		{Name: "extract", Phase: WorkflowStepRunning},
+	}
+
+	status.InitializeSteps([]WorkflowStep{{Name: "extract"}, {Name: "load"}})
+
+	testutil.AssertEqual(t, "steps", []WorkflowStepStatus{
+		{Name: "extract", Phase: WorkflowStepRunning},
+		{Name: "load", Phase: WorkflowStepPending},
+	}, status.Steps)
+	testutil.AssertTrue(t, "missing step", status.StepStatus("transform") == nil)
+}
+
+func TestWorkflowStepStatus_PropagateTaskStatus(t *testing.T) {
+	makeTask := func(status corev1.ConditionStatus, message string) *Task {
+		task := &Task{}
+		task.Name = "run-extract"
+		task.Status.Conditions = []apis.Condition{
+			{Type: TaskConditionSucceeded, Status: status, Message: message},
+		}
+		return task
+	}
+
+	cases := map[string]struct {
+		task        *Task
+		wantPhase   string
+		wantMessage string
+	}{
+		"running": {
+			task:      makeTask(corev1.ConditionUnknown, "Pending"),
+			wantPhase: WorkflowStepRunning,
+		},
+		"succeeded": {
+			task:      makeTask(corev1.ConditionTrue, ""),
+			wantPhase: WorkflowStepSucceeded,
+		},
+		"failed": {
+			task:        makeTask(corev1.ConditionFalse, "exit status 1"),
+			wantPhase:   WorkflowStepFailed,
+			wantMessage: "exit status 1",
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			step := &WorkflowStepStatus{Name: "extract", Phase: WorkflowStepPending}
+			step.PropagateTaskStatus(tc.task)
+
+			testutil.AssertEqual(t, "task name", "run-extract", step.TaskName)
+			testutil.AssertEqual(t, "phase", tc.wantPhase, step.Phase)
+			testutil.AssertEqual(t, "message", tc.wantMessage, step.Message)
+		})
+	}
+}
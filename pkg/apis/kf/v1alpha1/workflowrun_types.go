@@ -0,0 +1,129 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+)
+
+const (
+	// WorkflowStepPending is the phase of steps waiting on dependencies.
+	WorkflowStepPending = "Pending"
+
+	// WorkflowStepRunning is the phase of steps with a running Task.
+	WorkflowStepRunning = "Running"
+
+	// WorkflowStepSucceeded is the phase of steps whose Task succeeded.
+	WorkflowStepSucceeded = "Succeeded"
+
+	// WorkflowStepFailed is the phase of steps whose Task failed or
+	// couldn't be created.
+	WorkflowStepFailed = "Failed"
+
+	// WorkflowStepSkipped is the phase of steps that didn't run because of
+	// their RunIf policy or because the WorkflowRun was terminated.
+	WorkflowStepSkipped = "Skipped"
+)
+
+// GetGroupVersionKind returns the GroupVersionKind.
+func (r *WorkflowRun) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("WorkflowRun")
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// WorkflowRun is a single run of a Workflow.
+type WorkflowRun struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Spec WorkflowRunSpec `json:"spec,omitempty"`
+
+	// +optional
+	Status WorkflowRunStatus `json:"status,omitempty"`
+}
+
+var _ apis.Validatable = (*WorkflowRun)(nil)
+var _ apis.Defaultable = (*WorkflowRun)(nil)
+
+// WorkflowRunSpec contains the specification of a WorkflowRun.
+type WorkflowRunSpec struct {
+	// WorkflowRef references the Workflow that's run.
+	WorkflowRef corev1.LocalObjectReference `json:"workflowRef,omitempty"`
+
+	// Steps are copied from the Workflow when the run is created so changes
+	// to the Workflow don't affect runs in progress.
+	Steps []WorkflowStep `json:"steps,omitempty"`
+
+	// Terminated stops the run. Running steps are terminated and steps that
+	// haven't started are skipped.
+	// +optional
+	Terminated bool `json:"terminated,omitempty"`
+}
+
+// WorkflowRunStatus represents information about the status of a
+// WorkflowRun.
+type WorkflowRunStatus struct {
+	// Pull in the fields from Knative's duckv1beta1 status field.
+	duckv1beta1.Status `json:",inline"`
+
+	WorkflowRunStatusFields `json:",inline"`
+}
+
+// WorkflowRunStatusFields hold the fields of WorkflowRun's status that
+// are shared.
+type WorkflowRunStatusFields struct {
+	// StartTime is the timestamp of when the WorkflowRun started.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the timestamp of when the last step completed.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Steps holds the progress of each step, in the same order as the spec.
+	Steps []WorkflowStepStatus `json:"steps,omitempty"`
+}
+
+// WorkflowStepStatus is the progress of a single step of a WorkflowRun.
+type WorkflowStepStatus struct {
+	// Name of the step.
+	Name string `json:"name"`
+
+	// Phase is one of Pending, Running, Succeeded, Failed or Skipped.
+	Phase string `json:"phase,omitempty"`
+
+	// TaskName is the name of the Task created for the step.
+	TaskName string `json:"taskName,omitempty"`
+
+	// Message explains the phase, e.g. why the step failed or was skipped.
+	Message string `json:"message,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// WorkflowRunList is a list of WorkflowRun resources.
+type WorkflowRunList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []WorkflowRun `json:"items"`
+}
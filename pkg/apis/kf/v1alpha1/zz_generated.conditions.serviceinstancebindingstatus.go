@@ -50,6 +50,10 @@ const (
 	// ServiceInstanceBindingConditionVolumeParamsPopulatedReady is set when the child
 	// resource(s) VolumeParamsPopulated is/are ready.
 	ServiceInstanceBindingConditionVolumeParamsPopulatedReady apis.ConditionType = "VolumeParamsPopulatedReady"
+
+	// ServiceInstanceBindingConditionParamsValidReady is set when the child
+	// resource(s) ParamsValid is/are ready.
+	ServiceInstanceBindingConditionParamsValidReady apis.ConditionType = "ParamsValidReady"
 )
 
 func (status *ServiceInstanceBindingStatus) manage() apis.ConditionManager {
@@ -60,6 +64,7 @@ func (status *ServiceInstanceBindingStatus) manage() apis.ConditionManager {
 		ServiceInstanceBindingConditionParamsSecretPopulatedReady,
 		ServiceInstanceBindingConditionCredentialsSecretReady,
 		ServiceInstanceBindingConditionVolumeParamsPopulatedReady,
+		ServiceInstanceBindingConditionParamsValidReady,
 	).Manage(status)
 }
 
@@ -114,6 +119,11 @@ func (status *ServiceInstanceBindingStatus) VolumeParamsPopulatedCondition() Sin
 	return NewSingleConditionManager(status.manage(), ServiceInstanceBindingConditionVolumeParamsPopulatedReady, "VolumeParamsPopulated")
 }
 
+// ParamsValidCondition gets a manager for the state of the child resource.
+func (status *ServiceInstanceBindingStatus) ParamsValidCondition() SingleConditionManager {
+	return NewSingleConditionManager(status.manage(), ServiceInstanceBindingConditionParamsValidReady, "ParamsValid")
+}
+
 func (status *ServiceInstanceBindingStatus) duck() *duckv1beta1.Status {
 	return &status.Status
 }
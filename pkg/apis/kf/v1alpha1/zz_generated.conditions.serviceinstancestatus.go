@@ -42,6 +42,10 @@ const (
 	// ServiceInstanceConditionParamsSecretPopulatedReady is set when the child
 	// resource(s) ParamsSecretPopulated is/are ready.
 	ServiceInstanceConditionParamsSecretPopulatedReady apis.ConditionType = "ParamsSecretPopulatedReady"
+
+	// ServiceInstanceConditionParamsValidReady is set when the child
+	// resource(s) ParamsValid is/are ready.
+	ServiceInstanceConditionParamsValidReady apis.ConditionType = "ParamsValidReady"
 )
 
 func (status *ServiceInstanceStatus) manage() apis.ConditionManager {
@@ -50,6 +54,7 @@ func (status *ServiceInstanceStatus) manage() apis.ConditionManager {
 		ServiceInstanceConditionBackingResourceReady,
 		ServiceInstanceConditionParamsSecretReady,
 		ServiceInstanceConditionParamsSecretPopulatedReady,
+		ServiceInstanceConditionParamsValidReady,
 	).Manage(status)
 }
 
@@ -94,6 +99,11 @@ func (status *ServiceInstanceStatus) ParamsSecretPopulatedCondition() SingleCond
 	return NewSingleConditionManager(status.manage(), ServiceInstanceConditionParamsSecretPopulatedReady, "ParamsSecretPopulated")
 }
 
+// ParamsValidCondition gets a manager for the state of the child resource.
+func (status *ServiceInstanceStatus) ParamsValidCondition() SingleConditionManager {
+	return NewSingleConditionManager(status.manage(), ServiceInstanceConditionParamsValidReady, "ParamsValid")
+}
+
 func (status *ServiceInstanceStatus) duck() *duckv1beta1.Status {
 	return &status.Status
 }
@@ -86,6 +86,10 @@ const (
 	// SpaceConditionIAMPolicyReady is set when the child
 	// resource(s) IAMPolicy is/are ready.
 	SpaceConditionIAMPolicyReady apis.ConditionType = "IAMPolicyReady"
+
+	// SpaceConditionDomainTLSReady is set when the child
+	// resource(s) DomainTLS is/are ready.
+	SpaceConditionDomainTLSReady apis.ConditionType = "DomainTLSReady"
 )
 
 func (status *SpaceStatus) manage() apis.ConditionManager {
@@ -105,6 +109,7 @@ func (status *SpaceStatus) manage() apis.ConditionManager {
 		SpaceConditionClusterRoleReady,
 		SpaceConditionClusterRoleBindingsReady,
 		SpaceConditionIAMPolicyReady,
+		SpaceConditionDomainTLSReady,
 	).Manage(status)
 }
 
@@ -204,6 +209,11 @@ func (status *SpaceStatus) IAMPolicyCondition() SingleConditionManager {
 	return NewSingleConditionManager(status.manage(), SpaceConditionIAMPolicyReady, "IAMPolicy")
 }
 
+// DomainTLSCondition gets a manager for the state of the child resource.
+func (status *SpaceStatus) DomainTLSCondition() SingleConditionManager {
+	return NewSingleConditionManager(status.manage(), SpaceConditionDomainTLSReady, "DomainTLS")
+}
+
 func (status *SpaceStatus) duck() *duckv1beta1.Status {
 	return &status.Status
 }
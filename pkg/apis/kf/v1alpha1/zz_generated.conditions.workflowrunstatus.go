@@ -0,0 +1,73 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file was generated with conditiongen/generator.go, DO NOT EDIT IT.
+
+package v1alpha1
+
+import (
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+)
+
+// ConditionType represents a Service condition value
+const (
+
+	// WorkflowRunConditionSucceeded is set when the CRD is completed.
+	WorkflowRunConditionSucceeded = apis.ConditionSucceeded
+
+	// WorkflowRunConditionSpaceReady is set when the child
+	// resource(s) Space is/are ready.
+	WorkflowRunConditionSpaceReady apis.ConditionType = "SpaceReady"
+
+	// WorkflowRunConditionStepsReady is set when the child
+	// resource(s) Steps is/are ready.
+	WorkflowRunConditionStepsReady apis.ConditionType = "StepsReady"
+)
+
+func (status *WorkflowRunStatus) manage() apis.ConditionManager {
+	return apis.NewBatchConditionSet(
+		WorkflowRunConditionSpaceReady,
+		WorkflowRunConditionStepsReady,
+	).Manage(status)
+}
+
+// Succeeded returns if the type successfully completed.
+func (status *WorkflowRunStatus) Succeeded() bool {
+	return status.manage().IsHappy()
+}
+
+// GetCondition returns the condition by name.
+func (status *WorkflowRunStatus) GetCondition(t apis.ConditionType) *apis.Condition {
+	return status.manage().GetCondition(t)
+}
+
+// InitializeConditions sets the initial values to the conditions.
+func (status *WorkflowRunStatus) InitializeConditions() {
+	status.manage().InitializeConditions()
+}
+
+// SpaceCondition gets a manager for the state of the child resource.
+func (status *WorkflowRunStatus) SpaceCondition() SingleConditionManager {
+	return NewSingleConditionManager(status.manage(), WorkflowRunConditionSpaceReady, "Space")
+}
+
+// StepsCondition gets a manager for the state of the child resource.
+func (status *WorkflowRunStatus) StepsCondition() SingleConditionManager {
+	return NewSingleConditionManager(status.manage(), WorkflowRunConditionStepsReady, "Steps")
+}
+
+func (status *WorkflowRunStatus) duck() *duckv1beta1.Status {
+	return &status.Status
+}
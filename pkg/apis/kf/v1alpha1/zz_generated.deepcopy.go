@@ -21,8 +21,8 @@ package v1alpha1
 
 import (
 	config "github.com/google/kf/v2/pkg/apis/kf/config"
-	v1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	v1beta1 "knative.dev/pkg/apis/duck/v1beta1"
 )
@@ -143,6 +143,27 @@ func (in *AppRouteStatus) DeepCopy() *AppRouteStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppSessionAffinity) DeepCopyInto(out *AppSessionAffinity) {
+	*out = *in
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppSessionAffinity.
+func (in *AppSessionAffinity) DeepCopy() *AppSessionAffinity {
+	if in == nil {
+		return nil
+	}
+	out := new(AppSessionAffinity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AppSpec) DeepCopyInto(out *AppSpec) {
 	*out = *in
@@ -156,6 +177,11 @@ func (in *AppSpec) DeepCopyInto(out *AppSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.SessionAffinity != nil {
+		in, out := &in.SessionAffinity, &out.SessionAffinity
+		*out = new(AppSessionAffinity)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -217,7 +243,7 @@ func (in *AppSpecBuild) DeepCopyInto(out *AppSpecBuild) {
 	}
 	if in.BuildRef != nil {
 		in, out := &in.BuildRef, &out.BuildRef
-		*out = new(v1.LocalObjectReference)
+		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
 	return
@@ -247,6 +273,11 @@ func (in *AppSpecInstances) DeepCopyInto(out *AppSpecInstances) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.IdleTimeout != nil {
+		in, out := &in.IdleTimeout, &out.IdleTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -615,7 +646,7 @@ func (in *BuildSpec) DeepCopyInto(out *BuildSpec) {
 	}
 	if in.Env != nil {
 		in, out := &in.Env, &out.Env
-		*out = make([]v1.EnvVar, len(*in))
+		*out = make([]corev1.EnvVar, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -671,7 +702,7 @@ func (in *BuildStatusFields) DeepCopyInto(out *BuildStatusFields) {
 	}
 	if in.Duration != nil {
 		in, out := &in.Duration, &out.Duration
-		*out = new(metav1.Duration)
+		*out = new(v1.Duration)
 		**out = **in
 	}
 	return
@@ -1012,7 +1043,7 @@ func (in *Route) DeepCopyInto(out *Route) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 	return
 }
@@ -1035,6 +1066,115 @@ func (in *Route) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteCORSPolicy) DeepCopyInto(out *RouteCORSPolicy) {
+	*out = *in
+	if in.AllowOrigins != nil {
+		in, out := &in.AllowOrigins, &out.AllowOrigins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowMethods != nil {
+		in, out := &in.AllowMethods, &out.AllowMethods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowHeaders != nil {
+		in, out := &in.AllowHeaders, &out.AllowHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExposeHeaders != nil {
+		in, out := &in.ExposeHeaders, &out.ExposeHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteCORSPolicy.
+func (in *RouteCORSPolicy) DeepCopy() *RouteCORSPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteCORSPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteCanary) DeepCopyInto(out *RouteCanary) {
+	*out = *in
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RouteCanaryRollout)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteCanary.
+func (in *RouteCanary) DeepCopy() *RouteCanary {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteCanary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteCanaryRollout) DeepCopyInto(out *RouteCanaryRollout) {
+	*out = *in
+	out.Interval = in.Interval
+	if in.MaxErrorPercent != nil {
+		in, out := &in.MaxErrorPercent, &out.MaxErrorPercent
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteCanaryRollout.
+func (in *RouteCanaryRollout) DeepCopy() *RouteCanaryRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteCanaryRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteCanaryStatus) DeepCopyInto(out *RouteCanaryStatus) {
+	*out = *in
+	if in.LastStepTime != nil {
+		in, out := &in.LastStepTime, &out.LastStepTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ObservedCanary != nil {
+		in, out := &in.ObservedCanary, &out.ObservedCanary
+		*out = new(RouteCanary)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteCanaryStatus.
+func (in *RouteCanaryStatus) DeepCopy() *RouteCanaryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteCanaryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteDestination) DeepCopyInto(out *RouteDestination) {
 	*out = *in
@@ -1051,6 +1191,34 @@ func (in *RouteDestination) DeepCopy() *RouteDestination {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteHeaderOperations) DeepCopyInto(out *RouteHeaderOperations) {
+	*out = *in
+	if in.Add != nil {
+		in, out := &in.Add, &out.Add
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Remove != nil {
+		in, out := &in.Remove, &out.Remove
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteHeaderOperations.
+func (in *RouteHeaderOperations) DeepCopy() *RouteHeaderOperations {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteHeaderOperations)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteList) DeepCopyInto(out *RouteList) {
 	*out = *in
@@ -1084,6 +1252,84 @@ func (in *RouteList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteMaintenance) DeepCopyInto(out *RouteMaintenance) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMaintenance.
+func (in *RouteMaintenance) DeepCopy() *RouteMaintenance {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteMaintenance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoutePolicy) DeepCopyInto(out *RoutePolicy) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = new(RouteRetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CORS != nil {
+		in, out := &in.CORS, &out.CORS
+		*out = new(RouteCORSPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RequestHeaders != nil {
+		in, out := &in.RequestHeaders, &out.RequestHeaders
+		*out = new(RouteHeaderOperations)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResponseHeaders != nil {
+		in, out := &in.ResponseHeaders, &out.ResponseHeaders
+		*out = new(RouteHeaderOperations)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(RouteRateLimit)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoutePolicy.
+func (in *RoutePolicy) DeepCopy() *RoutePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RoutePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteRateLimit) DeepCopyInto(out *RouteRateLimit) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteRateLimit.
+func (in *RouteRateLimit) DeepCopy() *RouteRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteRef) DeepCopyInto(out *RouteRef) {
 	*out = *in
@@ -1100,6 +1346,32 @@ func (in *RouteRef) DeepCopy() *RouteRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteRetryPolicy) DeepCopyInto(out *RouteRetryPolicy) {
+	*out = *in
+	if in.PerTryTimeout != nil {
+		in, out := &in.PerTryTimeout, &out.PerTryTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RetryOn != nil {
+		in, out := &in.RetryOn, &out.RetryOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteRetryPolicy.
+func (in *RouteRetryPolicy) DeepCopy() *RouteRetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteRetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteServiceBinding) DeepCopyInto(out *RouteServiceBinding) {
 	*out = *in
@@ -1163,6 +1435,26 @@ func (in *RouteServiceURL) DeepCopy() *RouteServiceURL {
 func (in *RouteSpec) DeepCopyInto(out *RouteSpec) {
 	*out = *in
 	out.RouteSpecFields = in.RouteSpecFields
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(RoutePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(RouteCanary)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Maintenance != nil {
+		in, out := &in.Maintenance, &out.Maintenance
+		*out = new(RouteMaintenance)
+		**out = **in
+	}
+	if in.SharedSpaces != nil {
+		in, out := &in.SharedSpaces, &out.SharedSpaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1229,6 +1521,11 @@ func (in *RouteStatus) DeepCopyInto(out *RouteStatus) {
 		copy(*out, *in)
 	}
 	out.RouteService = in.RouteService
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(RouteCanaryStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1256,6 +1553,11 @@ func (in *RouteWeightBinding) DeepCopyInto(out *RouteWeightBinding) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.MirrorPercent != nil {
+		in, out := &in.MirrorPercent, &out.MirrorPercent
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -1269,6 +1571,22 @@ func (in *RouteWeightBinding) DeepCopy() *RouteWeightBinding {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouterGroup) DeepCopyInto(out *RouterGroup) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouterGroup.
+func (in *RouterGroup) DeepCopy() *RouterGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(RouterGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Scale) DeepCopyInto(out *Scale) {
 	*out = *in
@@ -1552,6 +1870,10 @@ func (in *ServiceInstanceBindingStatus) DeepCopyInto(out *ServiceInstanceBinding
 		**out = **in
 	}
 	in.OSBStatus.DeepCopyInto(&out.OSBStatus)
+	if in.PreviousBindingUnbindAfter != nil {
+		in, out := &in.PreviousBindingUnbindAfter, &out.PreviousBindingUnbindAfter
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -1599,59 +1921,136 @@ func (in *ServiceInstanceList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServiceInstanceSpec) DeepCopyInto(out *ServiceInstanceSpec) {
+func (in *ServiceInstanceShare) DeepCopyInto(out *ServiceInstanceShare) {
 	*out = *in
-	in.ServiceType.DeepCopyInto(&out.ServiceType)
-	if in.Tags != nil {
-		in, out := &in.Tags, &out.Tags
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	out.ParametersFrom = in.ParametersFrom
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceInstanceSpec.
-func (in *ServiceInstanceSpec) DeepCopy() *ServiceInstanceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceInstanceShare.
+func (in *ServiceInstanceShare) DeepCopy() *ServiceInstanceShare {
 	if in == nil {
 		return nil
 	}
-	out := new(ServiceInstanceSpec)
+	out := new(ServiceInstanceShare)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ServiceInstanceShare) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServiceInstanceStatus) DeepCopyInto(out *ServiceInstanceStatus) {
+func (in *ServiceInstanceShareList) DeepCopyInto(out *ServiceInstanceShareList) {
 	*out = *in
-	in.Status.DeepCopyInto(&out.Status)
-	in.ServiceFields.DeepCopyInto(&out.ServiceFields)
-	if in.RouteServiceURL != nil {
-		in, out := &in.RouteServiceURL, &out.RouteServiceURL
-		*out = new(RouteServiceURL)
-		**out = **in
-	}
-	in.OSBStatus.DeepCopyInto(&out.OSBStatus)
-	if in.VolumeStatus != nil {
-		in, out := &in.VolumeStatus, &out.VolumeStatus
-		*out = new(VolumeStatus)
-		**out = **in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ServiceInstanceShare, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceInstanceStatus.
-func (in *ServiceInstanceStatus) DeepCopy() *ServiceInstanceStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceInstanceShareList.
+func (in *ServiceInstanceShareList) DeepCopy() *ServiceInstanceShareList {
 	if in == nil {
 		return nil
 	}
-	out := new(ServiceInstanceStatus)
+	out := new(ServiceInstanceShareList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ServiceInstanceShareList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServiceOffering) DeepCopyInto(out *ServiceOffering) {
+func (in *ServiceInstanceShareSpec) DeepCopyInto(out *ServiceInstanceShareSpec) {
+	*out = *in
+	out.InstanceRef = in.InstanceRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceInstanceShareSpec.
+func (in *ServiceInstanceShareSpec) DeepCopy() *ServiceInstanceShareSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceInstanceShareSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceInstanceSpec) DeepCopyInto(out *ServiceInstanceSpec) {
+	*out = *in
+	in.ServiceType.DeepCopyInto(&out.ServiceType)
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.ParametersFrom = in.ParametersFrom
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceInstanceSpec.
+func (in *ServiceInstanceSpec) DeepCopy() *ServiceInstanceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceInstanceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceInstanceStatus) DeepCopyInto(out *ServiceInstanceStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	in.ServiceFields.DeepCopyInto(&out.ServiceFields)
+	if in.RouteServiceURL != nil {
+		in, out := &in.RouteServiceURL, &out.RouteServiceURL
+		*out = new(RouteServiceURL)
+		**out = **in
+	}
+	in.OSBStatus.DeepCopyInto(&out.OSBStatus)
+	if in.VolumeStatus != nil {
+		in, out := &in.VolumeStatus, &out.VolumeStatus
+		*out = new(VolumeStatus)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceInstanceStatus.
+func (in *ServiceInstanceStatus) DeepCopy() *ServiceInstanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceInstanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceOffering) DeepCopyInto(out *ServiceOffering) {
 	*out = *in
 	if in.Tags != nil {
 		in, out := &in.Tags, &out.Tags
@@ -1661,7 +2060,9 @@ func (in *ServiceOffering) DeepCopyInto(out *ServiceOffering) {
 	if in.Plans != nil {
 		in, out := &in.Plans, &out.Plans
 		*out = make([]ServicePlan, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
@@ -1679,6 +2080,11 @@ func (in *ServiceOffering) DeepCopy() *ServiceOffering {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServicePlan) DeepCopyInto(out *ServicePlan) {
 	*out = *in
+	if in.Schemas != nil {
+		in, out := &in.Schemas, &out.Schemas
+		*out = new(ServicePlanSchemas)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1692,6 +2098,37 @@ func (in *ServicePlan) DeepCopy() *ServicePlan {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServicePlanSchemas) DeepCopyInto(out *ServicePlanSchemas) {
+	*out = *in
+	if in.InstanceCreate != nil {
+		in, out := &in.InstanceCreate, &out.InstanceCreate
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InstanceUpdate != nil {
+		in, out := &in.InstanceUpdate, &out.InstanceUpdate
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BindingCreate != nil {
+		in, out := &in.BindingCreate, &out.BindingCreate
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServicePlanSchemas.
+func (in *ServicePlanSchemas) DeepCopy() *ServicePlanSchemas {
+	if in == nil {
+		return nil
+	}
+	out := new(ServicePlanSchemas)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceType) DeepCopyInto(out *ServiceType) {
 	*out = *in
@@ -1871,6 +2308,16 @@ func (in *Space) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SpaceDomain) DeepCopyInto(out *SpaceDomain) {
 	*out = *in
+	if in.RouterGroup != nil {
+		in, out := &in.RouterGroup, &out.RouterGroup
+		*out = new(RouterGroup)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(SpaceDomainTLS)
+		**out = **in
+	}
 	return
 }
 
@@ -1884,12 +2331,50 @@ func (in *SpaceDomain) DeepCopy() *SpaceDomain {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpaceDomainCertificate) DeepCopyInto(out *SpaceDomainCertificate) {
+	*out = *in
+	if in.NotAfter != nil {
+		in, out := &in.NotAfter, &out.NotAfter
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpaceDomainCertificate.
+func (in *SpaceDomainCertificate) DeepCopy() *SpaceDomainCertificate {
+	if in == nil {
+		return nil
+	}
+	out := new(SpaceDomainCertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpaceDomainTLS) DeepCopyInto(out *SpaceDomainTLS) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpaceDomainTLS.
+func (in *SpaceDomainTLS) DeepCopy() *SpaceDomainTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(SpaceDomainTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in SpaceDomains) DeepCopyInto(out *SpaceDomains) {
 	{
 		in := &in
 		*out = make(SpaceDomains, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 		return
 	}
 }
@@ -1961,7 +2446,7 @@ func (in *SpaceSpecBuildConfig) DeepCopyInto(out *SpaceSpecBuildConfig) {
 	*out = *in
 	if in.Env != nil {
 		in, out := &in.Env, &out.Env
-		*out = make([]v1.EnvVar, len(*in))
+		*out = make([]corev1.EnvVar, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1990,7 +2475,9 @@ func (in *SpaceSpecNetworkConfig) DeepCopyInto(out *SpaceSpecNetworkConfig) {
 	if in.Domains != nil {
 		in, out := &in.Domains, &out.Domains
 		*out = make([]SpaceDomain, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	out.AppNetworkPolicy = in.AppNetworkPolicy
 	out.BuildNetworkPolicy = in.BuildNetworkPolicy
@@ -2028,7 +2515,7 @@ func (in *SpaceSpecRuntimeConfig) DeepCopyInto(out *SpaceSpecRuntimeConfig) {
 	*out = *in
 	if in.Env != nil {
 		in, out := &in.Env, &out.Env
-		*out = make([]v1.EnvVar, len(*in))
+		*out = make([]corev1.EnvVar, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -2062,7 +2549,7 @@ func (in *SpaceStatus) DeepCopyInto(out *SpaceStatus) {
 	in.BuildConfig.DeepCopyInto(&out.BuildConfig)
 	if in.IngressGateways != nil {
 		in, out := &in.IngressGateways, &out.IngressGateways
-		*out = make([]v1.LoadBalancerIngress, len(*in))
+		*out = make([]corev1.LoadBalancerIngress, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -2104,7 +2591,7 @@ func (in *SpaceStatusBuildConfig) DeepCopyInto(out *SpaceStatusBuildConfig) {
 	}
 	if in.Env != nil {
 		in, out := &in.Env, &out.Env
-		*out = make([]v1.EnvVar, len(*in))
+		*out = make([]corev1.EnvVar, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -2128,7 +2615,16 @@ func (in *SpaceStatusNetworkConfig) DeepCopyInto(out *SpaceStatusNetworkConfig)
 	if in.Domains != nil {
 		in, out := &in.Domains, &out.Domains
 		*out = make([]SpaceDomain, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = make([]SpaceDomainCertificate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
@@ -2148,7 +2644,7 @@ func (in *SpaceStatusRuntimeConfig) DeepCopyInto(out *SpaceStatusRuntimeConfig)
 	*out = *in
 	if in.Env != nil {
 		in, out := &in.Env, &out.Env
-		*out = make([]v1.EnvVar, len(*in))
+		*out = make([]corev1.EnvVar, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -2217,7 +2713,7 @@ func (in *Task) DeepCopyInto(out *Task) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 	return
 }
@@ -2273,12 +2769,33 @@ func (in *TaskList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskNotification) DeepCopyInto(out *TaskNotification) {
+	*out = *in
+	if in.On != nil {
+		in, out := &in.On, &out.On
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskNotification.
+func (in *TaskNotification) DeepCopy() *TaskNotification {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskNotification)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskSchedule) DeepCopyInto(out *TaskSchedule) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 	return
 }
@@ -2337,7 +2854,22 @@ func (in *TaskScheduleList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskScheduleSpec) DeepCopyInto(out *TaskScheduleSpec) {
 	*out = *in
-	out.TaskTemplate = in.TaskTemplate
+	if in.StartingDeadlineSeconds != nil {
+		in, out := &in.StartingDeadlineSeconds, &out.StartingDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SuccessfulHistoryLimit != nil {
+		in, out := &in.SuccessfulHistoryLimit, &out.SuccessfulHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailedHistoryLimit != nil {
+		in, out := &in.FailedHistoryLimit, &out.FailedHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	in.TaskTemplate.DeepCopyInto(&out.TaskTemplate)
 	return
 }
 
@@ -2374,13 +2906,17 @@ func (in *TaskScheduleStatusFields) DeepCopyInto(out *TaskScheduleStatusFields)
 	*out = *in
 	if in.Active != nil {
 		in, out := &in.Active, &out.Active
-		*out = make([]v1.LocalObjectReference, len(*in))
+		*out = make([]corev1.LocalObjectReference, len(*in))
 		copy(*out, *in)
 	}
 	if in.LastScheduleTime != nil {
 		in, out := &in.LastScheduleTime, &out.LastScheduleTime
 		*out = (*in).DeepCopy()
 	}
+	if in.LastMissedTime != nil {
+		in, out := &in.LastMissedTime, &out.LastMissedTime
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -2398,6 +2934,28 @@ func (in *TaskScheduleStatusFields) DeepCopy() *TaskScheduleStatusFields {
 func (in *TaskSpec) DeepCopyInto(out *TaskSpec) {
 	*out = *in
 	out.AppRef = in.AppRef
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RetryBackoff != nil {
+		in, out := &in.RetryBackoff, &out.RetryBackoff
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.BuildRef != nil {
+		in, out := &in.BuildRef, &out.BuildRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = make([]TaskNotification, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -2442,9 +3000,19 @@ func (in *TaskStatusFields) DeepCopyInto(out *TaskStatusFields) {
 	}
 	if in.Duration != nil {
 		in, out := &in.Duration, &out.Duration
-		*out = new(metav1.Duration)
+		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.ExitCode != nil {
+		in, out := &in.ExitCode, &out.ExitCode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DeliveredNotifications != nil {
+		in, out := &in.DeliveredNotifications, &out.DeliveredNotifications
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -2565,3 +3133,265 @@ func (in *VolumeStatus) DeepCopy() *VolumeStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Workflow) DeepCopyInto(out *Workflow) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Workflow.
+func (in *Workflow) DeepCopy() *Workflow {
+	if in == nil {
+		return nil
+	}
+	out := new(Workflow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Workflow) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowList) DeepCopyInto(out *WorkflowList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Workflow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowList.
+func (in *WorkflowList) DeepCopy() *WorkflowList {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkflowList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowRun) DeepCopyInto(out *WorkflowRun) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowRun.
+func (in *WorkflowRun) DeepCopy() *WorkflowRun {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowRun)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkflowRun) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowRunList) DeepCopyInto(out *WorkflowRunList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]WorkflowRun, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowRunList.
+func (in *WorkflowRunList) DeepCopy() *WorkflowRunList {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowRunList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkflowRunList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowRunSpec) DeepCopyInto(out *WorkflowRunSpec) {
+	*out = *in
+	out.WorkflowRef = in.WorkflowRef
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]WorkflowStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowRunSpec.
+func (in *WorkflowRunSpec) DeepCopy() *WorkflowRunSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowRunSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowRunStatus) DeepCopyInto(out *WorkflowRunStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	in.WorkflowRunStatusFields.DeepCopyInto(&out.WorkflowRunStatusFields)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowRunStatus.
+func (in *WorkflowRunStatus) DeepCopy() *WorkflowRunStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowRunStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowRunStatusFields) DeepCopyInto(out *WorkflowRunStatusFields) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]WorkflowStepStatus, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowRunStatusFields.
+func (in *WorkflowRunStatusFields) DeepCopy() *WorkflowRunStatusFields {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowRunStatusFields)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowSpec) DeepCopyInto(out *WorkflowSpec) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]WorkflowStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowSpec.
+func (in *WorkflowSpec) DeepCopy() *WorkflowSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowStep) DeepCopyInto(out *WorkflowStep) {
+	*out = *in
+	if in.JobRef != nil {
+		in, out := &in.JobRef, &out.JobRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.TaskTemplate != nil {
+		in, out := &in.TaskTemplate, &out.TaskTemplate
+		*out = new(TaskSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowStep.
+func (in *WorkflowStep) DeepCopy() *WorkflowStep {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowStepStatus) DeepCopyInto(out *WorkflowStepStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowStepStatus.
+func (in *WorkflowStepStatus) DeepCopy() *WorkflowStepStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowStepStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -47,6 +47,7 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(
 		SchemeGroupVersion,
 		&VirtualService{},
+		&Gateway{},
 	)
 
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
@@ -58,6 +58,10 @@ func (c *FakeKfV1alpha1) ServiceInstanceBindings(namespace string) v1alpha1.Serv
 	return &FakeServiceInstanceBindings{c, namespace}
 }
 
+func (c *FakeKfV1alpha1) ServiceInstanceShares(namespace string) v1alpha1.ServiceInstanceShareInterface {
+	return &FakeServiceInstanceShares{c, namespace}
+}
+
 func (c *FakeKfV1alpha1) SourcePackages(namespace string) v1alpha1.SourcePackageInterface {
 	return &FakeSourcePackages{c, namespace}
 }
@@ -74,6 +78,14 @@ func (c *FakeKfV1alpha1) TaskSchedules(namespace string) v1alpha1.TaskScheduleIn
 	return &FakeTaskSchedules{c, namespace}
 }
 
+func (c *FakeKfV1alpha1) Workflows(namespace string) v1alpha1.WorkflowInterface {
+	return &FakeWorkflows{c, namespace}
+}
+
+func (c *FakeKfV1alpha1) WorkflowRuns(namespace string) v1alpha1.WorkflowRunInterface {
+	return &FakeWorkflowRuns{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeKfV1alpha1) RESTClient() rest.Interface {
@@ -32,6 +32,8 @@ type ServiceInstanceExpansion interface{}
 
 type ServiceInstanceBindingExpansion interface{}
 
+type ServiceInstanceShareExpansion interface{}
+
 type SourcePackageExpansion interface{}
 
 type SpaceExpansion interface{}
@@ -39,3 +41,7 @@ type SpaceExpansion interface{}
 type TaskExpansion interface{}
 
 type TaskScheduleExpansion interface{}
+
+type WorkflowExpansion interface{}
+
+type WorkflowRunExpansion interface{}
@@ -34,10 +34,13 @@ type KfV1alpha1Interface interface {
 	ServiceBrokersGetter
 	ServiceInstancesGetter
 	ServiceInstanceBindingsGetter
+	ServiceInstanceSharesGetter
 	SourcePackagesGetter
 	SpacesGetter
 	TasksGetter
 	TaskSchedulesGetter
+	WorkflowsGetter
+	WorkflowRunsGetter
 }
 
 // KfV1alpha1Client is used to interact with features provided by the kf.dev group.
@@ -77,6 +80,10 @@ func (c *KfV1alpha1Client) ServiceInstanceBindings(namespace string) ServiceInst
 	return newServiceInstanceBindings(c, namespace)
 }
 
+func (c *KfV1alpha1Client) ServiceInstanceShares(namespace string) ServiceInstanceShareInterface {
+	return newServiceInstanceShares(c, namespace)
+}
+
 func (c *KfV1alpha1Client) SourcePackages(namespace string) SourcePackageInterface {
 	return newSourcePackages(c, namespace)
 }
@@ -93,6 +100,14 @@ func (c *KfV1alpha1Client) TaskSchedules(namespace string) TaskScheduleInterface
 	return newTaskSchedules(c, namespace)
 }
 
+func (c *KfV1alpha1Client) Workflows(namespace string) WorkflowInterface {
+	return newWorkflows(c, namespace)
+}
+
+func (c *KfV1alpha1Client) WorkflowRuns(namespace string) WorkflowRunInterface {
+	return newWorkflowRuns(c, namespace)
+}
+
 // NewForConfig creates a new KfV1alpha1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
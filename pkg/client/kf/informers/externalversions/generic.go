@@ -67,6 +67,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kf().V1alpha1().ServiceInstances().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("serviceinstancebindings"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kf().V1alpha1().ServiceInstanceBindings().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("serviceinstanceshares"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kf().V1alpha1().ServiceInstanceShares().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("sourcepackages"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kf().V1alpha1().SourcePackages().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("spaces"):
@@ -75,6 +77,10 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kf().V1alpha1().Tasks().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("taskschedules"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kf().V1alpha1().TaskSchedules().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("workflows"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kf().V1alpha1().Workflows().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("workflowruns"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kf().V1alpha1().WorkflowRuns().Informer()}, nil
 
 	}
 
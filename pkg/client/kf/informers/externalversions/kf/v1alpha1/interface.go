@@ -38,6 +38,8 @@ type Interface interface {
 	ServiceInstances() ServiceInstanceInformer
 	// ServiceInstanceBindings returns a ServiceInstanceBindingInformer.
 	ServiceInstanceBindings() ServiceInstanceBindingInformer
+	// ServiceInstanceShares returns a ServiceInstanceShareInformer.
+	ServiceInstanceShares() ServiceInstanceShareInformer
 	// SourcePackages returns a SourcePackageInformer.
 	SourcePackages() SourcePackageInformer
 	// Spaces returns a SpaceInformer.
@@ -46,6 +48,10 @@ type Interface interface {
 	Tasks() TaskInformer
 	// TaskSchedules returns a TaskScheduleInformer.
 	TaskSchedules() TaskScheduleInformer
+	// Workflows returns a WorkflowInformer.
+	Workflows() WorkflowInformer
+	// WorkflowRuns returns a WorkflowRunInformer.
+	WorkflowRuns() WorkflowRunInformer
 }
 
 type version struct {
@@ -99,6 +105,11 @@ func (v *version) ServiceInstanceBindings() ServiceInstanceBindingInformer {
 	return &serviceInstanceBindingInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// ServiceInstanceShares returns a ServiceInstanceShareInformer.
+func (v *version) ServiceInstanceShares() ServiceInstanceShareInformer {
+	return &serviceInstanceShareInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // SourcePackages returns a SourcePackageInformer.
 func (v *version) SourcePackages() SourcePackageInformer {
 	return &sourcePackageInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -118,3 +129,13 @@ func (v *version) Tasks() TaskInformer {
 func (v *version) TaskSchedules() TaskScheduleInformer {
 	return &taskScheduleInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// Workflows returns a WorkflowInformer.
+func (v *version) Workflows() WorkflowInformer {
+	return &workflowInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// WorkflowRuns returns a WorkflowRunInformer.
+func (v *version) WorkflowRuns() WorkflowRunInformer {
+	return &workflowRunInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
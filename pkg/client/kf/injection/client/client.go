@@ -1153,6 +1153,137 @@ func (w *wrapKfV1alpha1ServiceInstanceBindingImpl) Watch(ctx context.Context, op
 	return nil, errors.New("NYI: Watch")
 }
 
+func (w *wrapKfV1alpha1) ServiceInstanceShares(namespace string) typedkfv1alpha1.ServiceInstanceShareInterface {
+	return &wrapKfV1alpha1ServiceInstanceShareImpl{
+		dyn: w.dyn.Resource(schema.GroupVersionResource{
+			Group:    "kf.dev",
+			Version:  "v1alpha1",
+			Resource: "serviceinstanceshares",
+		}),
+
+		namespace: namespace,
+	}
+}
+
+type wrapKfV1alpha1ServiceInstanceShareImpl struct {
+	dyn dynamic.NamespaceableResourceInterface
+
+	namespace string
+}
+
+var _ typedkfv1alpha1.ServiceInstanceShareInterface = (*wrapKfV1alpha1ServiceInstanceShareImpl)(nil)
+
+func (w *wrapKfV1alpha1ServiceInstanceShareImpl) Create(ctx context.Context, in *v1alpha1.ServiceInstanceShare, opts v1.CreateOptions) (*v1alpha1.ServiceInstanceShare, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "kf.dev",
+		Version: "v1alpha1",
+		Kind:    "ServiceInstanceShare",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Create(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.ServiceInstanceShare{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1ServiceInstanceShareImpl) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return w.dyn.Namespace(w.namespace).Delete(ctx, name, opts)
+}
+
+func (w *wrapKfV1alpha1ServiceInstanceShareImpl) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	return w.dyn.Namespace(w.namespace).DeleteCollection(ctx, opts, listOpts)
+}
+
+func (w *wrapKfV1alpha1ServiceInstanceShareImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.ServiceInstanceShare, error) {
+	uo, err := w.dyn.Namespace(w.namespace).Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.ServiceInstanceShare{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1ServiceInstanceShareImpl) List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.ServiceInstanceShareList, error) {
+	uo, err := w.dyn.Namespace(w.namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.ServiceInstanceShareList{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1ServiceInstanceShareImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ServiceInstanceShare, err error) {
+	uo, err := w.dyn.Namespace(w.namespace).Patch(ctx, name, pt, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.ServiceInstanceShare{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1ServiceInstanceShareImpl) Update(ctx context.Context, in *v1alpha1.ServiceInstanceShare, opts v1.UpdateOptions) (*v1alpha1.ServiceInstanceShare, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "kf.dev",
+		Version: "v1alpha1",
+		Kind:    "ServiceInstanceShare",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Update(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.ServiceInstanceShare{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1ServiceInstanceShareImpl) UpdateStatus(ctx context.Context, in *v1alpha1.ServiceInstanceShare, opts v1.UpdateOptions) (*v1alpha1.ServiceInstanceShare, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "kf.dev",
+		Version: "v1alpha1",
+		Kind:    "ServiceInstanceShare",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).UpdateStatus(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.ServiceInstanceShare{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1ServiceInstanceShareImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return nil, errors.New("NYI: Watch")
+}
+
 func (w *wrapKfV1alpha1) SourcePackages(namespace string) typedkfv1alpha1.SourcePackageInterface {
 	return &wrapKfV1alpha1SourcePackageImpl{
 		dyn: w.dyn.Resource(schema.GroupVersionResource{
@@ -1672,3 +1803,265 @@ func (w *wrapKfV1alpha1TaskScheduleImpl) UpdateStatus(ctx context.Context, in *v
 func (w *wrapKfV1alpha1TaskScheduleImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
 	return nil, errors.New("NYI: Watch")
 }
+
+func (w *wrapKfV1alpha1) Workflows(namespace string) typedkfv1alpha1.WorkflowInterface {
+	return &wrapKfV1alpha1WorkflowImpl{
+		dyn: w.dyn.Resource(schema.GroupVersionResource{
+			Group:    "kf.dev",
+			Version:  "v1alpha1",
+			Resource: "workflows",
+		}),
+
+		namespace: namespace,
+	}
+}
+
+type wrapKfV1alpha1WorkflowImpl struct {
+	dyn dynamic.NamespaceableResourceInterface
+
+	namespace string
+}
+
+var _ typedkfv1alpha1.WorkflowInterface = (*wrapKfV1alpha1WorkflowImpl)(nil)
+
+func (w *wrapKfV1alpha1WorkflowImpl) Create(ctx context.Context, in *v1alpha1.Workflow, opts v1.CreateOptions) (*v1alpha1.Workflow, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "kf.dev",
+		Version: "v1alpha1",
+		Kind:    "Workflow",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Create(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.Workflow{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1WorkflowImpl) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return w.dyn.Namespace(w.namespace).Delete(ctx, name, opts)
+}
+
+func (w *wrapKfV1alpha1WorkflowImpl) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	return w.dyn.Namespace(w.namespace).DeleteCollection(ctx, opts, listOpts)
+}
+
+func (w *wrapKfV1alpha1WorkflowImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.Workflow, error) {
+	uo, err := w.dyn.Namespace(w.namespace).Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.Workflow{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1WorkflowImpl) List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.WorkflowList, error) {
+	uo, err := w.dyn.Namespace(w.namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.WorkflowList{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1WorkflowImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.Workflow, err error) {
+	uo, err := w.dyn.Namespace(w.namespace).Patch(ctx, name, pt, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.Workflow{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1WorkflowImpl) Update(ctx context.Context, in *v1alpha1.Workflow, opts v1.UpdateOptions) (*v1alpha1.Workflow, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "kf.dev",
+		Version: "v1alpha1",
+		Kind:    "Workflow",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Update(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.Workflow{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1WorkflowImpl) UpdateStatus(ctx context.Context, in *v1alpha1.Workflow, opts v1.UpdateOptions) (*v1alpha1.Workflow, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "kf.dev",
+		Version: "v1alpha1",
+		Kind:    "Workflow",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).UpdateStatus(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.Workflow{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1WorkflowImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return nil, errors.New("NYI: Watch")
+}
+
+func (w *wrapKfV1alpha1) WorkflowRuns(namespace string) typedkfv1alpha1.WorkflowRunInterface {
+	return &wrapKfV1alpha1WorkflowRunImpl{
+		dyn: w.dyn.Resource(schema.GroupVersionResource{
+			Group:    "kf.dev",
+			Version:  "v1alpha1",
+			Resource: "workflowruns",
+		}),
+
+		namespace: namespace,
+	}
+}
+
+type wrapKfV1alpha1WorkflowRunImpl struct {
+	dyn dynamic.NamespaceableResourceInterface
+
+	namespace string
+}
+
+var _ typedkfv1alpha1.WorkflowRunInterface = (*wrapKfV1alpha1WorkflowRunImpl)(nil)
+
+func (w *wrapKfV1alpha1WorkflowRunImpl) Create(ctx context.Context, in *v1alpha1.WorkflowRun, opts v1.CreateOptions) (*v1alpha1.WorkflowRun, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "kf.dev",
+		Version: "v1alpha1",
+		Kind:    "WorkflowRun",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Create(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.WorkflowRun{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1WorkflowRunImpl) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return w.dyn.Namespace(w.namespace).Delete(ctx, name, opts)
+}
+
+func (w *wrapKfV1alpha1WorkflowRunImpl) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	return w.dyn.Namespace(w.namespace).DeleteCollection(ctx, opts, listOpts)
+}
+
+func (w *wrapKfV1alpha1WorkflowRunImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.WorkflowRun, error) {
+	uo, err := w.dyn.Namespace(w.namespace).Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.WorkflowRun{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1WorkflowRunImpl) List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.WorkflowRunList, error) {
+	uo, err := w.dyn.Namespace(w.namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.WorkflowRunList{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1WorkflowRunImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.WorkflowRun, err error) {
+	uo, err := w.dyn.Namespace(w.namespace).Patch(ctx, name, pt, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.WorkflowRun{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1WorkflowRunImpl) Update(ctx context.Context, in *v1alpha1.WorkflowRun, opts v1.UpdateOptions) (*v1alpha1.WorkflowRun, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "kf.dev",
+		Version: "v1alpha1",
+		Kind:    "WorkflowRun",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Update(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.WorkflowRun{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1WorkflowRunImpl) UpdateStatus(ctx context.Context, in *v1alpha1.WorkflowRun, opts v1.UpdateOptions) (*v1alpha1.WorkflowRun, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "kf.dev",
+		Version: "v1alpha1",
+		Kind:    "WorkflowRun",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).UpdateStatus(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.WorkflowRun{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1WorkflowRunImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return nil, errors.New("NYI: Watch")
+}
@@ -76,6 +76,14 @@ type ServiceInstanceBindingListerExpansion interface{}
 // ServiceInstanceBindingNamespaceLister.
 type ServiceInstanceBindingNamespaceListerExpansion interface{}
 
+// ServiceInstanceShareListerExpansion allows custom methods to be added to
+// ServiceInstanceShareLister.
+type ServiceInstanceShareListerExpansion interface{}
+
+// ServiceInstanceShareNamespaceListerExpansion allows custom methods to be added to
+// ServiceInstanceShareNamespaceLister.
+type ServiceInstanceShareNamespaceListerExpansion interface{}
+
 // SourcePackageListerExpansion allows custom methods to be added to
 // SourcePackageLister.
 type SourcePackageListerExpansion interface{}
@@ -103,3 +111,19 @@ type TaskScheduleListerExpansion interface{}
 // TaskScheduleNamespaceListerExpansion allows custom methods to be added to
 // TaskScheduleNamespaceLister.
 type TaskScheduleNamespaceListerExpansion interface{}
+
+// WorkflowListerExpansion allows custom methods to be added to
+// WorkflowLister.
+type WorkflowListerExpansion interface{}
+
+// WorkflowNamespaceListerExpansion allows custom methods to be added to
+// WorkflowNamespaceLister.
+type WorkflowNamespaceListerExpansion interface{}
+
+// WorkflowRunListerExpansion allows custom methods to be added to
+// WorkflowRunLister.
+type WorkflowRunListerExpansion interface{}
+
+// WorkflowRunNamespaceListerExpansion allows custom methods to be added to
+// WorkflowRunNamespaceLister.
+type WorkflowRunNamespaceListerExpansion interface{}
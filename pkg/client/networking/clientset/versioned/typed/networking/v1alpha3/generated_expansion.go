@@ -16,6 +16,12 @@
 
 package v1alpha3
 
+type DestinationRuleExpansion interface{}
+
+type EnvoyFilterExpansion interface{}
+
+type GatewayExpansion interface{}
+
 type ServiceEntryExpansion interface{}
 
 type VirtualServiceExpansion interface{}
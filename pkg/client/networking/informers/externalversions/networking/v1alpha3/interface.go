@@ -22,6 +22,12 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// DestinationRules returns a DestinationRuleInformer.
+	DestinationRules() DestinationRuleInformer
+	// EnvoyFilters returns a EnvoyFilterInformer.
+	EnvoyFilters() EnvoyFilterInformer
+	// Gateways returns a GatewayInformer.
+	Gateways() GatewayInformer
 	// ServiceEntries returns a ServiceEntryInformer.
 	ServiceEntries() ServiceEntryInformer
 	// VirtualServices returns a VirtualServiceInformer.
@@ -39,6 +45,21 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// DestinationRules returns a DestinationRuleInformer.
+func (v *version) DestinationRules() DestinationRuleInformer {
+	return &destinationRuleInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// EnvoyFilters returns a EnvoyFilterInformer.
+func (v *version) EnvoyFilters() EnvoyFilterInformer {
+	return &envoyFilterInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// Gateways returns a GatewayInformer.
+func (v *version) Gateways() GatewayInformer {
+	return &gatewayInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // ServiceEntries returns a ServiceEntryInformer.
 func (v *version) ServiceEntries() ServiceEntryInformer {
 	return &serviceEntryInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -140,6 +140,7 @@ func MapOSBToKfCatalog(catalog *osbclient.CatalogResponse) (out []v1alpha1.Servi
 				UID:         osbPlan.ID,
 				Free:        isFree,
 				Description: osbPlan.Description,
+				Schemas:     mapOSBSchemas(osbPlan.Schemas),
 			})
 		}
 
@@ -181,6 +181,43 @@ func TestMapOSBToKfCatalog(t *testing.T) {
 		"minibroker": {
 			response: &minibrokerCatalog,
 		},
+		"schemas": {
+			response: &osbclient.CatalogResponse{
+				Services: []osbclient.Service{{
+					ID:   "db-uid",
+					Name: "db",
+					Plans: []osbclient.Plan{
+						{
+							ID:   "no-schemas-uid",
+							Name: "no-schemas",
+						},
+						{
+							ID:   "schemas-uid",
+							Name: "schemas",
+							Schemas: &osbclient.Schemas{
+								ServiceInstance: &osbclient.ServiceInstanceSchema{
+									Create: &osbclient.InputParametersSchema{
+										Parameters: map[string]interface{}{"type": "object"},
+									},
+								},
+								ServiceBinding: &osbclient.ServiceBindingSchema{
+									Create: &osbclient.InputParametersSchema{
+										Parameters: map[string]interface{}{"type": "object", "required": []string{"role"}},
+									},
+								},
+							},
+						},
+						{
+							ID:   "empty-schemas-uid",
+							Name: "empty-schemas",
+							Schemas: &osbclient.Schemas{
+								ServiceInstance: &osbclient.ServiceInstanceSchema{},
+							},
+						},
+					},
+				}},
+			},
+		},
 	}
 
 	for tn, tc := range cases {
@@ -0,0 +1,106 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package osbutil
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+	osbclient "sigs.k8s.io/go-open-service-broker-client/v2"
+)
+
+// mapOSBSchemas converts the schemas of an OSB plan to Kf's version, it
+// returns nil if the broker didn't publish any.
+func mapOSBSchemas(schemas *osbclient.Schemas) *v1alpha1.ServicePlanSchemas {
+	if schemas == nil {
+		return nil
+	}
+
+	out := &v1alpha1.ServicePlanSchemas{}
+	if instance := schemas.ServiceInstance; instance != nil {
+		out.InstanceCreate = mapOSBInputParameters(instance.Create)
+		out.InstanceUpdate = mapOSBInputParameters(instance.Update)
+	}
+	if binding := schemas.ServiceBinding; binding != nil {
+		out.BindingCreate = mapOSBInputParameters(binding.Create)
+	}
+
+	if out.InstanceCreate == nil && out.InstanceUpdate == nil && out.BindingCreate == nil {
+		return nil
+	}
+
+	return out
+}
+
+func mapOSBInputParameters(params *osbclient.InputParametersSchema) *runtime.RawExtension {
+	if params == nil || params.Parameters == nil {
+		return nil
+	}
+
+	raw, err := json.Marshal(params.Parameters)
+	if err != nil {
+		// Parameters was unmarshaled from JSON so this can't happen in
+		// practice, skipping the schema only turns off validation.
+		return nil
+	}
+
+	return &runtime.RawExtension{Raw: raw}
+}
+
+// ValidateParameters checks that params, a JSON object, matches a JSON schema
+// published by a service broker. A nil schema accepts any parameters.
+func ValidateParameters(schema *runtime.RawExtension, params []byte) error {
+	if schema == nil || len(schema.Raw) == 0 {
+		return nil
+	}
+
+	parsedSchema := &spec.Schema{}
+	if err := json.Unmarshal(schema.Raw, parsedSchema); err != nil {
+		return fmt.Errorf("couldn't parse the parameters schema from the service broker: %v", err)
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(params, &value); err != nil {
+		return fmt.Errorf("couldn't parse parameters: %v", err)
+	}
+
+	result := validate.NewSchemaValidator(parsedSchema, nil, "", strfmt.Default).Validate(value)
+	if result.IsValid() {
+		return nil
+	}
+
+	var problems []string
+	for _, err := range result.Errors {
+		problems = append(problems, formatSchemaError(err))
+	}
+	sort.Strings(problems)
+
+	return errors.New("invalid parameters: " + strings.Join(problems, "; "))
+}
+
+// formatSchemaError removes the parts of validation errors that refer to the
+// HTTP request they're usually reported for.
+func formatSchemaError(err error) string {
+	msg := strings.Replace(err.Error(), " in body", "", 1)
+	return strings.TrimPrefix(msg, ".")
+}
@@ -0,0 +1,48 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package osbutil
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func ExampleValidateParameters() {
+	schema := &runtime.RawExtension{Raw: []byte(`{
+		"$schema": "http://json-schema.org/draft-04/schema#",
+		"type": "object",
+		"properties": {
+			"ram_gb": {"type": "integer", "minimum": 1, "maximum": 8},
+			"tier": {"type": "string", "enum": ["standard", "premium"]}
+		},
+		"required": ["ram_gb"],
+		"additionalProperties": false
+	}`)}
+
+	fmt.Println("Valid:", ValidateParameters(schema, []byte(`{"ram_gb": 4}`)))
+	fmt.Println("No schema:", ValidateParameters(nil, []byte(`{"anything": true}`)))
+	fmt.Println(ValidateParameters(schema, []byte(`{"ram_gb": "4", "tier": "gold", "region": "us"}`)))
+	fmt.Println(ValidateParameters(schema, []byte(`{"ram_gb": 16}`)))
+	fmt.Println(ValidateParameters(schema, []byte(`{}`)))
+	fmt.Println(ValidateParameters(schema, []byte(`not-json`)))
+
+	// Output: Valid: <nil>
+	// No schema: <nil>
+	// invalid parameters: ram_gb must be of type integer: "string"; region is a forbidden property; tier should be one of [standard premium]
+	// invalid parameters: ram_gb should be less than or equal to 8
+	// invalid parameters: ram_gb is required
+	// couldn't parse parameters: invalid character 'o' in literal null (expecting 'u')
+}
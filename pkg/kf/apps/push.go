@@ -433,6 +433,12 @@ func mergeApps(cfg pushConfig, hasDefaultRoutes bool) func(newapp, oldapp *v1alp
 			newapp.Spec.Instances.Replicas = ptr.Int32(1)
 		}
 
+		// Session affinity, instance routing and service binding projection
+		// are set with their own commands rather than the manifest.
+		newapp.Spec.SessionAffinity = oldapp.Spec.SessionAffinity
+		newapp.Spec.InstanceRouting = oldapp.Spec.InstanceRouting
+		newapp.Spec.ProjectServiceBindings = oldapp.Spec.ProjectServiceBindings
+
 		newapp.ResourceVersion = oldapp.ResourceVersion
 
 		// Envs
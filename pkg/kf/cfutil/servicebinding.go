@@ -0,0 +1,72 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cfutil
+
+import (
+	"encoding/json"
+
+	kfv1alpha1 "github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// ServiceBindingTypeKey is the entry holding the type of a projected
+	// service binding.
+	ServiceBindingTypeKey = "type"
+
+	// ServiceBindingProviderKey is the entry holding the provider of a
+	// projected service binding.
+	ServiceBindingProviderKey = "provider"
+
+	// DefaultServiceBindingProvider is the provider of projected service
+	// bindings whose credentials don't include one.
+	DefaultServiceBindingProvider = "kf"
+)
+
+// NewServiceBindingFiles creates the entries of a binding projected following
+// the servicebinding.io spec given the binding and its credentials secret.
+// See https://servicebinding.io/spec/core/1.0.0/#workload-projection for the
+// format.
+func NewServiceBindingFiles(binding kfv1alpha1.ServiceInstanceBinding, credentialsSecret corev1.Secret) map[string][]byte {
+	files := make(map[string][]byte)
+
+	// Credentials are stored as JSON values so they can be inlined into
+	// VCAP_SERVICES. Strings are written as their raw value so apps don't
+	// need to decode them, everything else is left as JSON.
+	for k, v := range credentialsSecret.Data {
+		var str string
+		if err := json.Unmarshal(v, &str); err == nil {
+			files[k] = []byte(str)
+		} else {
+			files[k] = v
+		}
+	}
+
+	// The type and provider from the credentials take precedence because
+	// brokers may return them to match what client libraries expect.
+	if _, ok := files[ServiceBindingTypeKey]; !ok {
+		bindingType := binding.Status.ClassName
+		if bindingType == "" {
+			bindingType = kfv1alpha1.UserProvidedServiceClassName
+		}
+		files[ServiceBindingTypeKey] = []byte(bindingType)
+	}
+
+	if _, ok := files[ServiceBindingProviderKey]; !ok {
+		files[ServiceBindingProviderKey] = []byte(DefaultServiceBindingProvider)
+	}
+
+	return files
+}
@@ -0,0 +1,83 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cfutil_test
+
+import (
+	"fmt"
+	"sort"
+
+	v1alpha1 "github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/cfutil"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func printServiceBindingFiles(files map[string][]byte) {
+	var keys []string
+	for k := range files {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		fmt.Printf("%s: %s\n", k, files[k])
+	}
+}
+
+func ExampleNewServiceBindingFiles() {
+	binding := v1alpha1.ServiceInstanceBinding{}
+	binding.Status.ClassName = "postgresql"
+
+	credentialsSecret := corev1.Secret{
+		Data: map[string][]byte{
+			"username": []byte(`"admin"`),
+			"port":     []byte(`5432`),
+			"options":  []byte(`{"ssl":true}`),
+		},
+	}
+
+	printServiceBindingFiles(cfutil.NewServiceBindingFiles(binding, credentialsSecret))
+
+	// Output: options: {"ssl":true}
+	// port: 5432
+	// provider: kf
+	// type: postgresql
+	// username: admin
+}
+
+func ExampleNewServiceBindingFiles_credentialsType() {
+	binding := v1alpha1.ServiceInstanceBinding{}
+	binding.Status.ClassName = "cloudsql"
+
+	credentialsSecret := corev1.Secret{
+		Data: map[string][]byte{
+			"type":     []byte(`"mysql"`),
+			"provider": []byte(`"google"`),
+		},
+	}
+
+	printServiceBindingFiles(cfutil.NewServiceBindingFiles(binding, credentialsSecret))
+
+	// Output: provider: google
+	// type: mysql
+}
+
+func ExampleNewServiceBindingFiles_userProvided() {
+	binding := v1alpha1.ServiceInstanceBinding{}
+
+	printServiceBindingFiles(cfutil.NewServiceBindingFiles(binding, corev1.Secret{}))
+
+	// Output: provider: kf
+	// type: user-provided
+}
@@ -0,0 +1,123 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/apps"
+	"github.com/google/kf/v2/pkg/kf/commands/completion"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	utils "github.com/google/kf/v2/pkg/kf/internal/utils/cli"
+	"github.com/spf13/cobra"
+)
+
+// NewEnableInstanceRoutingCommand creates a command that allows requests to
+// be sent to a specific instance of an App.
+func NewEnableInstanceRoutingCommand(
+	p *config.KfParams,
+	client apps.Client,
+) *cobra.Command {
+	var async utils.AsyncIfStoppedFlags
+
+	cmd := &cobra.Command{
+		Use:   "enable-instance-routing APP_NAME",
+		Short: "Allow requests to target a specific App instance.",
+		Long: fmt.Sprintf(`
+		Instance routing gives each App instance an index from 0 to the number
+		of instances minus one. Requests with the %s header set to
+		APP_NAME:INDEX are sent to the instance with that index.
+
+		Indexes are reused when instances are replaced.
+		`, v1alpha1.InstanceHeader),
+		Example: fmt.Sprintf(`
+		kf enable-instance-routing myapp
+		curl -H "%s: myapp:1" https://myapp.example.com
+		`, v1alpha1.InstanceHeader),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.AppCompletionFn(p),
+		SilenceUsage:      true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setInstanceRouting(cmd, p, client, &async, args[0], true)
+		},
+	}
+
+	async.Add(cmd)
+
+	return cmd
+}
+
+// NewDisableInstanceRoutingCommand creates a command that stops requests from
+// targeting specific instances of an App.
+func NewDisableInstanceRoutingCommand(
+	p *config.KfParams,
+	client apps.Client,
+) *cobra.Command {
+	var async utils.AsyncIfStoppedFlags
+
+	cmd := &cobra.Command{
+		Use:               "disable-instance-routing APP_NAME",
+		Short:             "Stop requests from targeting a specific App instance.",
+		Example:           `kf disable-instance-routing myapp`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.AppCompletionFn(p),
+		SilenceUsage:      true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setInstanceRouting(cmd, p, client, &async, args[0], false)
+		},
+	}
+
+	async.Add(cmd)
+
+	return cmd
+}
+
+func setInstanceRouting(
+	cmd *cobra.Command,
+	p *config.KfParams,
+	client apps.Client,
+	async *utils.AsyncIfStoppedFlags,
+	appName string,
+	enabled bool,
+) error {
+	if err := p.ValidateSpaceTargeted(); err != nil {
+		return err
+	}
+
+	verb := "Disabling"
+	if enabled {
+		verb = "Enabling"
+	}
+
+	mutator := func(app *v1alpha1.App) error {
+		app.Spec.InstanceRouting = enabled
+		return nil
+	}
+
+	app, err := client.Transform(cmd.Context(), p.Space, appName, mutator)
+	if err != nil {
+		return fmt.Errorf("failed to update instance routing for App: %s", err)
+	}
+
+	stopped := app != nil && app.Spec.Instances.Stopped
+	action := fmt.Sprintf("%s instance routing for App %q in Space %q", verb, appName, p.Space)
+	return async.AwaitAndLog(stopped, cmd.OutOrStdout(), action, func() error {
+		_, err := client.WaitForConditionKnativeServiceReadyTrue(context.Background(), p.Space, appName, 1*time.Second)
+		return err
+	})
+}
@@ -0,0 +1,109 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	v1alpha1 "github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/apps"
+	"github.com/google/kf/v2/pkg/kf/apps/fake"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	"github.com/spf13/cobra"
+)
+
+func TestInstanceRouting(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		NewCommand  func(*config.KfParams, apps.Client) *cobra.Command
+		Args        []string
+		ExpectedErr error
+		Setup       func(t *testing.T, fake *fake.FakeClient)
+	}{
+		"enables instance routing": {
+			NewCommand: NewEnableInstanceRoutingCommand,
+			Args:       []string{"my-app"},
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().
+					Transform(gomock.Any(), "default", "my-app", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _, _ string, mutator apps.Mutator) (*v1alpha1.App, error) {
+						app := &v1alpha1.App{}
+						testutil.AssertNil(t, "mutator error", mutator(app))
+						testutil.AssertTrue(t, "app.spec.instanceRouting", app.Spec.InstanceRouting)
+						return app, nil
+					})
+				fake.EXPECT().WaitForConditionKnativeServiceReadyTrue(gomock.Any(), "default", "my-app", gomock.Any())
+			},
+		},
+		"disables instance routing": {
+			NewCommand: NewDisableInstanceRoutingCommand,
+			Args:       []string{"my-app", "--async"},
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().
+					Transform(gomock.Any(), "default", "my-app", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _, _ string, mutator apps.Mutator) (*v1alpha1.App, error) {
+						app := &v1alpha1.App{}
+						app.Spec.InstanceRouting = true
+						testutil.AssertNil(t, "mutator error", mutator(app))
+						testutil.AssertFalse(t, "app.spec.instanceRouting", app.Spec.InstanceRouting)
+						return app, nil
+					})
+			},
+		},
+		"no app name": {
+			NewCommand:  NewEnableInstanceRoutingCommand,
+			Args:        []string{},
+			ExpectedErr: errors.New("accepts 1 arg(s), received 0"),
+		},
+		"updating app fails": {
+			NewCommand:  NewEnableInstanceRoutingCommand,
+			Args:        []string{"my-app"},
+			ExpectedErr: errors.New("failed to update instance routing for App: some-error"),
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().
+					Transform(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("some-error"))
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fake := fake.NewFakeClient(ctrl)
+
+			if tc.Setup != nil {
+				tc.Setup(t, fake)
+			}
+
+			buf := new(bytes.Buffer)
+			p := &config.KfParams{
+				Space: "default",
+			}
+
+			cmd := tc.NewCommand(p, fake)
+			cmd.SetOutput(buf)
+			cmd.SetArgs(tc.Args)
+			_, actualErr := cmd.ExecuteC()
+			testutil.AssertErrorsEqual(t, tc.ExpectedErr, actualErr)
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -463,7 +464,7 @@ func NewPushCommand(
 		"health-check-type",
 		"u",
 		"",
-		"App health check type: http, port (default) or process.",
+		"App health check type: http, port (default), grpc or process.",
 	)
 
 	pushCmd.Flags().IntVarP(
@@ -613,17 +614,13 @@ func createServiceInstanceBinding(appName, serviceInstance, namespace string) v1
 }
 
 func createRoute(route manifest.Route, namespace string) (v1alpha1.RouteWeightBinding, error) {
-	hostname, domain, path, err := parseRouteStr(route.Route)
+	fields, err := parseRouteStr(route.Route)
 	if err != nil {
 		return v1alpha1.RouteWeightBinding{}, err
 	}
 
 	rwb := v1alpha1.RouteWeightBinding{
-		RouteSpecFields: v1alpha1.RouteSpecFields{
-			Hostname: hostname,
-			Domain:   domain,
-			Path:     path,
-		},
+		RouteSpecFields: fields,
 	}
 
 	if route.AppPort != 0 {
@@ -633,19 +630,31 @@ func createRoute(route manifest.Route, namespace string) (v1alpha1.RouteWeightBi
 	return rwb, nil
 }
 
-// parseRouteStr parses a route URL into a hostname, domain, and path
-func parseRouteStr(routeStr string) (string, string, string, error) {
+// parseRouteStr parses a route URL into a hostname, domain, and path. Routes
+// with a port (e.g. tcp.example.com:1024) are TCP Routes, the whole host is
+// used as the domain because TCP Routes can't have a hostname.
+func parseRouteStr(routeStr string) (v1alpha1.RouteSpecFields, error) {
 	u, err := url.Parse(routeStr)
-	if err != nil {
-		return "", "", "", fmt.Errorf("failed to parse route: %s", err)
-	}
-	if u.Scheme == "" {
+	if err != nil || u.Scheme == "" || u.Host == "" {
 		// Parsing URLs without schemes causes the hostname and domain to incorrectly be empty.
 		// We handle this by assuming the route has a HTTP scheme if scheme is not provided.
 		u, err = url.Parse("http://" + routeStr)
 		if err != nil {
-			return "", "", "", fmt.Errorf("failed to parse route: %s", err)
+			return v1alpha1.RouteSpecFields{}, fmt.Errorf("failed to parse route: %s", err)
+		}
+	}
+
+	if portStr := u.Port(); portStr != "" {
+		port, err := strconv.ParseInt(portStr, 10, 32)
+		if err != nil {
+			return v1alpha1.RouteSpecFields{}, fmt.Errorf("failed to parse route port: %s", err)
 		}
+
+		return v1alpha1.RouteSpecFields{
+			Domain: u.Hostname(),
+			Path:   u.EscapedPath(),
+			Port:   int32(port),
+		}, nil
 	}
 
 	parts := strings.SplitN(u.Hostname(), ".", 3)
@@ -674,7 +683,11 @@ func parseRouteStr(routeStr string) (string, string, string, error) {
 
 	path = u.EscapedPath()
 
-	return hostname, domain, path, nil
+	return v1alpha1.RouteSpecFields{
+		Hostname: hostname,
+		Domain:   domain,
+		Path:     path,
+	}, nil
 }
 
 func setupRoutes(space *v1alpha1.Space, app manifest.Application) (routes []v1alpha1.RouteWeightBinding, err error) {
@@ -579,6 +579,7 @@ func TestPushCommand(t *testing.T) {
 				"routes-app",
 				"--route=https://withscheme.example.com/path1",
 				"--route=noscheme.example.com",
+				"--route=tcp.example.com:1024",
 			},
 			wantOpts: append(defaultOptions,
 				apps.WithPushSpace("some-namespace"),
@@ -586,6 +587,12 @@ func TestPushCommand(t *testing.T) {
 				apps.WithPushRoutes([]v1alpha1.RouteWeightBinding{
 					buildRoute("withscheme", "example.com", "/path1"),
 					buildRoute("noscheme", "example.com", ""),
+					{
+						RouteSpecFields: v1alpha1.RouteSpecFields{
+							Domain: "tcp.example.com",
+							Port:   1024,
+						},
+					},
 				}),
 				apps.WithPushGenerateDefaultRoute(false),
 				cwdSourcePathOption,
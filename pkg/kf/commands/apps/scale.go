@@ -26,6 +26,7 @@ import (
 	"github.com/google/kf/v2/pkg/kf/describe"
 	utils "github.com/google/kf/v2/pkg/kf/internal/utils/cli"
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // NewScaleCommand creates a command capable of scaling an app.
@@ -36,7 +37,8 @@ func NewScaleCommand(
 	var (
 		async utils.AsyncIfStoppedFlags
 
-		instances int32
+		instances   int32
+		idleTimeout time.Duration
 	)
 
 	cmd := &cobra.Command{
@@ -57,6 +59,10 @@ func NewScaleCommand(
 		kf scale myapp
 		# Scale to exactly 3 instances
 		kf scale myapp --instances 3
+		# Scale to zero after 30 minutes without requests
+		kf scale myapp --idle-timeout 30m
+		# Turn off scaling to zero
+		kf scale myapp --idle-timeout 0
 		`,
 		Args:              cobra.ExactArgs(1),
 		ValidArgsFunction: completion.AppCompletionFn(p),
@@ -67,8 +73,9 @@ func NewScaleCommand(
 			}
 
 			appName := args[0]
+			setIdleTimeout := cmd.Flags().Changed("idle-timeout")
 
-			if instances <= 0 {
+			if instances <= 0 && !setIdleTimeout {
 				// Display current scaling properties.
 				app, err := client.Get(cmd.Context(), p.Space, appName)
 				if err != nil {
@@ -90,13 +97,18 @@ func NewScaleCommand(
 					return fmt.Errorf("cannot scale App manually when autoscaling is turned on")
 				}
 
-				app.Spec.Instances.Replicas = nil
-
 				if instances > 0 {
 					// Exact
 					app.Spec.Instances.Replicas = &instances
 				}
 
+				if setIdleTimeout {
+					app.Spec.Instances.IdleTimeout = nil
+					if idleTimeout > 0 {
+						app.Spec.Instances.IdleTimeout = &metav1.Duration{Duration: idleTimeout}
+					}
+				}
+
 				if err := app.Spec.Instances.Validate(context.Background()); err != nil {
 					return err
 				}
@@ -130,5 +142,12 @@ func NewScaleCommand(
 		"Number of instances, must be >= 1.",
 	)
 
+	cmd.Flags().DurationVar(
+		&idleTimeout,
+		"idle-timeout",
+		0,
+		"Scale the App to zero instances after it hasn't received requests for the duration, must be >= 1m. Set to 0 to turn off.",
+	)
+
 	return cmd
 }
@@ -19,6 +19,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
@@ -26,6 +27,7 @@ import (
 	"github.com/google/kf/v2/pkg/kf/apps/fake"
 	"github.com/google/kf/v2/pkg/kf/commands/config"
 	"github.com/google/kf/v2/pkg/kf/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/ptr"
 )
 
@@ -58,6 +60,48 @@ func TestNewScaleCommand(t *testing.T) {
 				fake.EXPECT().WaitForConditionKnativeServiceReadyTrue(gomock.Any(), "default", "my-app", gomock.Any())
 			},
 		},
+		"sets idle timeout": {
+			Space:           "default",
+			Args:            []string{"my-app", "--idle-timeout=30m", "--async"},
+			ExpectedStrings: []string{"Replicas:", "2", "Idle Timeout:", "30m0s"},
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().
+					Transform(gomock.Any(), "default", "my-app", gomock.Any()).
+					Do(func(_ context.Context, _, _ string, m apps.Mutator) {
+						app := v1alpha1.App{}
+						app.Spec.Instances.Replicas = ptr.Int32(2)
+						testutil.AssertNil(t, "mutator error", m(&app))
+						testutil.AssertEqual(t, "app.spec.instances.idleTimeout", &metav1.Duration{Duration: 30 * time.Minute}, app.Spec.Instances.IdleTimeout)
+						testutil.AssertEqual(t, "app.spec.instances.replicas", int32(2), *app.Spec.Instances.Replicas)
+					})
+			},
+		},
+		"removes idle timeout": {
+			Space: "default",
+			Args:  []string{"my-app", "--idle-timeout=0", "--async"},
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().
+					Transform(gomock.Any(), "default", "my-app", gomock.Any()).
+					Do(func(_ context.Context, _, _ string, m apps.Mutator) {
+						app := v1alpha1.App{}
+						app.Spec.Instances.IdleTimeout = &metav1.Duration{Duration: time.Hour}
+						testutil.AssertNil(t, "mutator error", m(&app))
+						testutil.AssertTrue(t, "app.spec.instances.idleTimeout", app.Spec.Instances.IdleTimeout == nil)
+					})
+			},
+		},
+		"idle timeout too short": {
+			Space:       "default",
+			Args:        []string{"my-app", "--idle-timeout=30s"},
+			ExpectedErr: errors.New("failed to scale App: invalid value: 30s: idleTimeout\nmust be at least 1m"),
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().
+					Transform(gomock.Any(), "default", "my-app", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _, _ string, m apps.Mutator) (*v1alpha1.App, error) {
+						return nil, m(&v1alpha1.App{})
+					})
+			},
+		},
 		"async does not wait": {
 			Space: "default",
 			Args:  []string{"my-app", "--instances=3", "--async"},
@@ -0,0 +1,123 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/apps"
+	"github.com/google/kf/v2/pkg/kf/commands/completion"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	utils "github.com/google/kf/v2/pkg/kf/internal/utils/cli"
+	"github.com/spf13/cobra"
+)
+
+// NewEnableServiceBindingProjectionCommand creates a command that mounts an
+// App's service bindings as files.
+func NewEnableServiceBindingProjectionCommand(
+	p *config.KfParams,
+	client apps.Client,
+) *cobra.Command {
+	var async utils.AsyncIfStoppedFlags
+
+	cmd := &cobra.Command{
+		Use:   "enable-service-binding-projection APP_NAME",
+		Short: "Mount the App's service bindings as files.",
+		Long: fmt.Sprintf(`
+		Service binding projection mounts the credentials of each service
+		binding as files under $%s/BINDING_NAME/ following the
+		servicebinding.io spec. Each binding also gets type and provider
+		entries.
+
+		Bindings are still injected into VCAP_SERVICES. Unlike VCAP_SERVICES,
+		the files are updated when credentials change without restarting the
+		App.
+		`, v1alpha1.ServiceBindingRootEnvVar),
+		Example:           `kf enable-service-binding-projection myapp`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.AppCompletionFn(p),
+		SilenceUsage:      true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setServiceBindingProjection(cmd, p, client, &async, args[0], true)
+		},
+	}
+
+	async.Add(cmd)
+
+	return cmd
+}
+
+// NewDisableServiceBindingProjectionCommand creates a command that stops
+// mounting an App's service bindings as files.
+func NewDisableServiceBindingProjectionCommand(
+	p *config.KfParams,
+	client apps.Client,
+) *cobra.Command {
+	var async utils.AsyncIfStoppedFlags
+
+	cmd := &cobra.Command{
+		Use:               "disable-service-binding-projection APP_NAME",
+		Short:             "Stop mounting the App's service bindings as files.",
+		Example:           `kf disable-service-binding-projection myapp`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.AppCompletionFn(p),
+		SilenceUsage:      true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setServiceBindingProjection(cmd, p, client, &async, args[0], false)
+		},
+	}
+
+	async.Add(cmd)
+
+	return cmd
+}
+
+func setServiceBindingProjection(
+	cmd *cobra.Command,
+	p *config.KfParams,
+	client apps.Client,
+	async *utils.AsyncIfStoppedFlags,
+	appName string,
+	enabled bool,
+) error {
+	if err := p.ValidateSpaceTargeted(); err != nil {
+		return err
+	}
+
+	verb := "Disabling"
+	if enabled {
+		verb = "Enabling"
+	}
+
+	mutator := func(app *v1alpha1.App) error {
+		app.Spec.ProjectServiceBindings = enabled
+		return nil
+	}
+
+	app, err := client.Transform(cmd.Context(), p.Space, appName, mutator)
+	if err != nil {
+		return fmt.Errorf("failed to update service binding projection for App: %s", err)
+	}
+
+	stopped := app != nil && app.Spec.Instances.Stopped
+	action := fmt.Sprintf("%s service binding projection for App %q in Space %q", verb, appName, p.Space)
+	return async.AwaitAndLog(stopped, cmd.OutOrStdout(), action, func() error {
+		_, err := client.WaitForConditionKnativeServiceReadyTrue(context.Background(), p.Space, appName, 1*time.Second)
+		return err
+	})
+}
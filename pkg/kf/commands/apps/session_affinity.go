@@ -0,0 +1,160 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/apps"
+	"github.com/google/kf/v2/pkg/kf/commands/completion"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	utils "github.com/google/kf/v2/pkg/kf/internal/utils/cli"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NewEnableSessionAffinityCommand creates a command that pins clients of an
+// App to a single instance.
+func NewEnableSessionAffinityCommand(
+	p *config.KfParams,
+	client apps.Client,
+) *cobra.Command {
+	var (
+		async      utils.AsyncIfStoppedFlags
+		cookieName string
+		cookieTTL  time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "enable-session-affinity APP_NAME",
+		Short: "Send requests from the same client to the same App instance.",
+		Long: `
+		Session affinity hashes a cookie to pick the App instance that serves a
+		request. If the request doesn't have the cookie, the ingress gateway
+		generates one so following requests reach the same instance.
+
+		Affinity is best effort: clients move to another instance if theirs is
+		removed or the App is scaled.
+		`,
+		Example: `
+		# Use a generated session cookie
+		kf enable-session-affinity myapp
+
+		# Keep clients on the same instance for an hour
+		kf enable-session-affinity myapp --cookie-ttl 1h
+		`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.AppCompletionFn(p),
+		SilenceUsage:      true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := p.ValidateSpaceTargeted(); err != nil {
+				return err
+			}
+
+			appName := args[0]
+
+			mutator := func(app *v1alpha1.App) error {
+				affinity := &v1alpha1.AppSessionAffinity{
+					CookieName: cookieName,
+				}
+				if cookieTTL > 0 {
+					affinity.TTL = &metav1.Duration{Duration: cookieTTL}
+				}
+
+				app.Spec.SessionAffinity = affinity
+				return nil
+			}
+
+			app, err := client.Transform(cmd.Context(), p.Space, appName, mutator)
+			if err != nil {
+				return fmt.Errorf("failed to enable session affinity for App: %s", err)
+			}
+
+			stopped := app != nil && app.Spec.Instances.Stopped
+			action := fmt.Sprintf("Enabling session affinity for App %q in Space %q", appName, p.Space)
+			return async.AwaitAndLog(stopped, cmd.OutOrStdout(), action, func() error {
+				_, err := client.WaitForConditionKnativeServiceReadyTrue(context.Background(), p.Space, appName, 1*time.Second)
+				return err
+			})
+		},
+	}
+
+	async.Add(cmd)
+
+	cmd.Flags().StringVar(
+		&cookieName,
+		"cookie-name",
+		v1alpha1.DefaultSessionAffinityCookieName,
+		"Name of the cookie used to pick the App instance.",
+	)
+
+	cmd.Flags().DurationVar(
+		&cookieTTL,
+		"cookie-ttl",
+		0,
+		"Lifetime of generated cookies. If unset, generated cookies expire when the client's session ends.",
+	)
+
+	return cmd
+}
+
+// NewDisableSessionAffinityCommand creates a command that spreads requests
+// to an App across all instances again.
+func NewDisableSessionAffinityCommand(
+	p *config.KfParams,
+	client apps.Client,
+) *cobra.Command {
+	var async utils.AsyncIfStoppedFlags
+
+	cmd := &cobra.Command{
+		Use:               "disable-session-affinity APP_NAME",
+		Short:             "Spread requests from the same client across App instances.",
+		Example:           `kf disable-session-affinity myapp`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.AppCompletionFn(p),
+		SilenceUsage:      true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := p.ValidateSpaceTargeted(); err != nil {
+				return err
+			}
+
+			appName := args[0]
+
+			mutator := func(app *v1alpha1.App) error {
+				app.Spec.SessionAffinity = nil
+				return nil
+			}
+
+			app, err := client.Transform(cmd.Context(), p.Space, appName, mutator)
+			if err != nil {
+				return fmt.Errorf("failed to disable session affinity for App: %s", err)
+			}
+
+			stopped := app != nil && app.Spec.Instances.Stopped
+			action := fmt.Sprintf("Disabling session affinity for App %q in Space %q", appName, p.Space)
+			return async.AwaitAndLog(stopped, cmd.OutOrStdout(), action, func() error {
+				_, err := client.WaitForConditionKnativeServiceReadyTrue(context.Background(), p.Space, appName, 1*time.Second)
+				return err
+			})
+		},
+	}
+
+	async.Add(cmd)
+
+	return cmd
+}
@@ -0,0 +1,140 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	v1alpha1 "github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/apps"
+	"github.com/google/kf/v2/pkg/kf/apps/fake"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEnableSessionAffinity(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		Args        []string
+		ExpectedErr error
+		Setup       func(t *testing.T, fake *fake.FakeClient)
+	}{
+		"defaults": {
+			Args: []string{"my-app"},
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().
+					Transform(gomock.Any(), "default", "my-app", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _, _ string, mutator apps.Mutator) (*v1alpha1.App, error) {
+						app := &v1alpha1.App{}
+						testutil.AssertNil(t, "mutator error", mutator(app))
+						testutil.AssertEqual(t, "app.spec.sessionAffinity", &v1alpha1.AppSessionAffinity{
+							CookieName: v1alpha1.DefaultSessionAffinityCookieName,
+						}, app.Spec.SessionAffinity)
+						return app, nil
+					})
+				fake.EXPECT().WaitForConditionKnativeServiceReadyTrue(gomock.Any(), "default", "my-app", gomock.Any())
+			},
+		},
+		"custom cookie": {
+			Args: []string{"my-app", "--cookie-name", "JSESSIONID", "--cookie-ttl", "1h"},
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().
+					Transform(gomock.Any(), "default", "my-app", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _, _ string, mutator apps.Mutator) (*v1alpha1.App, error) {
+						app := &v1alpha1.App{}
+						testutil.AssertNil(t, "mutator error", mutator(app))
+						testutil.AssertEqual(t, "app.spec.sessionAffinity", &v1alpha1.AppSessionAffinity{
+							CookieName: "JSESSIONID",
+							TTL:        &metav1.Duration{Duration: time.Hour},
+						}, app.Spec.SessionAffinity)
+						return app, nil
+					})
+				fake.EXPECT().WaitForConditionKnativeServiceReadyTrue(gomock.Any(), "default", "my-app", gomock.Any())
+			},
+		},
+		"stopped app does not wait": {
+			Args: []string{"my-app"},
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				app := &v1alpha1.App{}
+				app.Spec.Instances.Stopped = true
+				fake.EXPECT().
+					Transform(gomock.Any(), "default", "my-app", gomock.Any()).
+					Return(app, nil)
+			},
+		},
+		"updating app fails": {
+			Args:        []string{"my-app"},
+			ExpectedErr: errors.New("failed to enable session affinity for App: some-error"),
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().
+					Transform(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("some-error"))
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fake := fake.NewFakeClient(ctrl)
+
+			if tc.Setup != nil {
+				tc.Setup(t, fake)
+			}
+
+			buf := new(bytes.Buffer)
+			p := &config.KfParams{
+				Space: "default",
+			}
+
+			cmd := NewEnableSessionAffinityCommand(p, fake)
+			cmd.SetOutput(buf)
+			cmd.SetArgs(tc.Args)
+			_, actualErr := cmd.ExecuteC()
+			testutil.AssertErrorsEqual(t, tc.ExpectedErr, actualErr)
+		})
+	}
+}
+
+func TestDisableSessionAffinity(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	fake := fake.NewFakeClient(ctrl)
+
+	fake.EXPECT().
+		Transform(gomock.Any(), "default", "my-app", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ string, mutator apps.Mutator) (*v1alpha1.App, error) {
+			app := &v1alpha1.App{}
+			app.Spec.SessionAffinity = &v1alpha1.AppSessionAffinity{}
+			testutil.AssertNil(t, "mutator error", mutator(app))
+			testutil.AssertTrue(t, "app.spec.sessionAffinity removed", app.Spec.SessionAffinity == nil)
+			return app, nil
+		})
+	fake.EXPECT().WaitForConditionKnativeServiceReadyTrue(gomock.Any(), "default", "my-app", gomock.Any())
+
+	cmd := NewDisableSessionAffinityCommand(&config.KfParams{Space: "default"}, fake)
+	cmd.SetOutput(new(bytes.Buffer))
+	cmd.SetArgs([]string{"my-app"})
+	_, actualErr := cmd.ExecuteC()
+	testutil.AssertNil(t, "error", actualErr)
+}
@@ -0,0 +1,161 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"fmt"
+
+	kfconfig "github.com/google/kf/v2/pkg/apis/kf/config"
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	"github.com/google/kf/v2/pkg/kf/configmaps"
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+)
+
+// spaceClusterDomainsKey is the key of the shared domains in the
+// config-defaults ConfigMap.
+const spaceClusterDomainsKey = "spaceClusterDomains"
+
+// NewCreateSharedDomainCommand creates a command to add a domain to every
+// Space in the cluster.
+func NewCreateSharedDomainCommand(p *config.KfParams, client configmaps.Client) *cobra.Command {
+	var (
+		gatewayName string
+		internal    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create-shared-domain DOMAIN [--gateway-name GATEWAY] [--internal]",
+		Short: "Add a domain to every Space in the cluster.",
+		Long: `
+		Shared domains are templates added to the domains of every Space. The
+		domain may contain the following variables:
+
+		* $(SPACE_NAME) is replaced by the name of the Space.
+		* $(CLUSTER_INGRESS_IP) is replaced by the IP address of the ingress gateway.
+
+		Domains without variables are the same in every Space, Routes on them
+		can be shared between Spaces using share-route.
+
+		This command modifies the kf/config-defaults ConfigMap and requires
+		cluster admin permissions.
+		`,
+		Example: `
+		# Give every Space its own subdomain.
+		kf create-shared-domain '$(SPACE_NAME).apps.example.com'
+
+		# Add a domain that's only reachable from inside the cluster.
+		kf create-shared-domain apps.internal --gateway-name kf/internal-gateway --internal
+		`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			domain := args[0]
+
+			mutator := func(cm *v1.ConfigMap) error {
+				defaultsConfig, err := kfconfig.NewDefaultsConfigFromConfigMap(cm)
+				if err != nil {
+					return err
+				}
+
+				for _, existing := range defaultsConfig.SpaceClusterDomains {
+					if existing.Domain == domain {
+						return fmt.Errorf("shared domain %q already exists", domain)
+					}
+				}
+
+				defaultsConfig.SpaceClusterDomains = append(defaultsConfig.SpaceClusterDomains, kfconfig.DomainTemplate{
+					Domain:      domain,
+					GatewayName: gatewayName,
+					Internal:    internal,
+				})
+
+				return defaultsConfig.PatchConfigMap(cm)
+			}
+
+			if _, err := client.Transform(cmd.Context(), v1alpha1.KfNamespace, kfconfig.DefaultsConfigName, DiffWrapper(cmd.OutOrStdout(), mutator)); err != nil {
+				return fmt.Errorf("failed to create shared domain: %s", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Created shared domain %q.\n", domain)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&gatewayName, "gateway-name", "", "Istio Gateway the domain is served by, defaults to the Kf ingress gateway.")
+	cmd.Flags().BoolVar(&internal, "internal", false, "Only make the domain reachable from inside the cluster.")
+
+	return cmd
+}
+
+// NewDeleteSharedDomainCommand creates a command to remove a domain from
+// every Space in the cluster.
+func NewDeleteSharedDomainCommand(p *config.KfParams, client configmaps.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete-shared-domain DOMAIN",
+		Short: "Remove a domain from every Space in the cluster.",
+		Long: `
+		Removes a shared domain template from the kf/config-defaults ConfigMap.
+		Routes on the domain stop receiving traffic unless a Space declares the
+		domain itself.
+
+		This command requires cluster admin permissions.
+		`,
+		Example:      `kf delete-shared-domain '$(SPACE_NAME).apps.example.com'`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			domain := args[0]
+
+			mutator := func(cm *v1.ConfigMap) error {
+				defaultsConfig, err := kfconfig.NewDefaultsConfigFromConfigMap(cm)
+				if err != nil {
+					return err
+				}
+
+				var domains []kfconfig.DomainTemplate
+				for _, existing := range defaultsConfig.SpaceClusterDomains {
+					if existing.Domain != domain {
+						domains = append(domains, existing)
+					}
+				}
+
+				if len(domains) == len(defaultsConfig.SpaceClusterDomains) {
+					return fmt.Errorf("shared domain %q doesn't exist", domain)
+				}
+
+				// PatchConfigMap leaves empty lists alone, so the last domain
+				// has to be removed by hand.
+				if len(domains) == 0 {
+					delete(cm.Data, spaceClusterDomainsKey)
+					return nil
+				}
+
+				defaultsConfig.SpaceClusterDomains = domains
+				return defaultsConfig.PatchConfigMap(cm)
+			}
+
+			if _, err := client.Transform(cmd.Context(), v1alpha1.KfNamespace, kfconfig.DefaultsConfigName, DiffWrapper(cmd.OutOrStdout(), mutator)); err != nil {
+				return fmt.Errorf("failed to delete shared domain: %s", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Deleted shared domain %q.\n", domain)
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,143 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	kfconfig "github.com/google/kf/v2/pkg/apis/kf/config"
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	"github.com/google/kf/v2/pkg/kf/configmaps"
+	"github.com/google/kf/v2/pkg/kf/configmaps/fake"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestSharedDomainCommands(t *testing.T) {
+	defaultsConfigMap := configMapFromTestFile(t, kfconfig.DefaultsConfigName)
+
+	singleDomainConfigMap := defaultsConfigMap.DeepCopy()
+	singleDomainConfigMap.Data[spaceClusterDomainsKey] = "- domain: example.com\n"
+
+	newCreate := func(client configmaps.Client) *cobra.Command {
+		return NewCreateSharedDomainCommand(&config.KfParams{}, client)
+	}
+	newDelete := func(client configmaps.Client) *cobra.Command {
+		return NewDeleteSharedDomainCommand(&config.KfParams{}, client)
+	}
+
+	cases := map[string]struct {
+		newCommand func(configmaps.Client) *cobra.Command
+		args       []string
+		configMap  *v1.ConfigMap
+		wantErr    error
+		validate   func(*testing.T, *v1.ConfigMap)
+	}{
+		"create missing domain": {
+			newCommand: newCreate,
+			args:       []string{},
+			wantErr:    errors.New("accepts 1 arg(s), received 0"),
+		},
+		"create shared domain": {
+			newCommand: newCreate,
+			args:       []string{"mesh.internal", "--gateway-name=kf/internal-gateway", "--internal"},
+			configMap:  defaultsConfigMap,
+			validate: func(t *testing.T, cm *v1.ConfigMap) {
+				defaultsConfig, err := kfconfig.NewDefaultsConfigFromConfigMap(cm)
+				testutil.AssertNil(t, "err", err)
+				domains := defaultsConfig.SpaceClusterDomains
+				testutil.AssertEqual(t, "count", 3, len(domains))
+				testutil.AssertEqual(t, "new domain", kfconfig.DomainTemplate{
+					Domain:      "mesh.internal",
+					GatewayName: "kf/internal-gateway",
+					Internal:    true,
+				}, domains[2])
+			},
+		},
+		"create existing domain": {
+			newCommand: newCreate,
+			args:       []string{"apps.internal"},
+			configMap:  defaultsConfigMap,
+			wantErr:    errors.New(`failed to create shared domain: shared domain "apps.internal" already exists`),
+		},
+		"delete shared domain": {
+			newCommand: newDelete,
+			args:       []string{"$(SPACE_NAME).$(CLUSTER_INGRESS_IP).nip.io"},
+			configMap:  defaultsConfigMap,
+			validate: func(t *testing.T, cm *v1.ConfigMap) {
+				defaultsConfig, err := kfconfig.NewDefaultsConfigFromConfigMap(cm)
+				testutil.AssertNil(t, "err", err)
+				domains := defaultsConfig.SpaceClusterDomains
+				testutil.AssertEqual(t, "count", 1, len(domains))
+				testutil.AssertEqual(t, "remaining domain", "apps.internal", domains[0].Domain)
+			},
+		},
+		"delete last shared domain": {
+			newCommand: newDelete,
+			args:       []string{"example.com"},
+			configMap:  singleDomainConfigMap,
+			validate: func(t *testing.T, cm *v1.ConfigMap) {
+				_, ok := cm.Data[spaceClusterDomainsKey]
+				testutil.AssertFalse(t, "has domains", ok)
+			},
+		},
+		"delete missing domain": {
+			newCommand: newDelete,
+			args:       []string{"missing.example.com"},
+			configMap:  defaultsConfigMap,
+			wantErr:    errors.New(`failed to delete shared domain: shared domain "missing.example.com" doesn't exist`),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fakeConfigs := fake.NewFakeClient(ctrl)
+
+			var output *v1.ConfigMap
+			if tc.configMap != nil {
+				output = tc.configMap.DeepCopy()
+				fakeConfigs.EXPECT().
+					Transform(gomock.Any(), v1alpha1.KfNamespace, kfconfig.DefaultsConfigName, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, namespace, configMapName string, transformer configmaps.Mutator) (*v1.ConfigMap, error) {
+						if err := transformer(output); err != nil {
+							return nil, err
+						}
+						return output, nil
+					})
+			}
+
+			c := tc.newCommand(fakeConfigs)
+			c.SetOutput(&bytes.Buffer{})
+			c.SetArgs(tc.args)
+
+			gotErr := c.Execute()
+			if tc.wantErr != nil || gotErr != nil {
+				testutil.AssertErrorsEqual(t, tc.wantErr, gotErr)
+				return
+			}
+
+			if tc.validate != nil {
+				tc.validate(t, output)
+			}
+		})
+	}
+}
@@ -553,5 +553,7 @@ func rewriteProbe(p *corev1.Probe, defaultPort int32) {
 		p.HTTPGet.Port = intstr.FromInt(int(defaultPort))
 	case p.TCPSocket != nil:
 		p.TCPSocket.Port = intstr.FromInt(int(defaultPort))
+	case p.GRPC != nil:
+		p.GRPC.Port = defaultPort
 	}
 }
@@ -140,6 +140,10 @@ func NewRawKfCommand() *cobra.Command {
 				InjectScale(p),
 				InjectLogs(p),
 				InjectProxy(p),
+				InjectEnableSessionAffinity(p),
+				InjectDisableSessionAffinity(p),
+				InjectEnableInstanceRouting(p),
+				InjectDisableInstanceRouting(p),
 			},
 		},
 		{
@@ -177,13 +181,21 @@ func NewRawKfCommand() *cobra.Command {
 			Name: "Routing",
 			Commands: []*cobra.Command{
 				InjectRoutes(p),
+				InjectRoute(p),
 				InjectCreateRoute(p),
+				InjectUpdateRoute(p),
 				InjectDeleteRoute(p),
 				InjectDeleteOrphanedRoutes(p),
 				InjectMapRoute(p),
 				InjectUnmapRoute(p),
+				InjectCanary(p),
+				InjectMaintenanceMode(p),
 				InjectProxyRoute(p),
+				InjectShareRoute(p),
 				InjectDomains(p),
+				InjectCreatePrivateDomain(p),
+				InjectCreateSharedDomain(p),
+				InjectDeleteSharedDomain(p),
 			},
 		},
 		{
@@ -193,6 +205,8 @@ func NewRawKfCommand() *cobra.Command {
 				InjectCreateUserProvidedService(p),
 				InjectUpdateUserProvidedService(p),
 				InjectDeleteService(p),
+				InjectShareService(p),
+				InjectUnshareService(p),
 				InjectGetService(p),
 				InjectListServices(p),
 				InjectMarketplace(p),
@@ -205,7 +219,10 @@ func NewRawKfCommand() *cobra.Command {
 				InjectBindService(p),
 				InjectListBindings(p),
 				InjectUnbindService(p),
+				InjectRotateBinding(p),
 				InjectVcapServices(p),
+				InjectEnableServiceBindingProjection(p),
+				InjectDisableServiceBindingProjection(p),
 			},
 		},
 		{
@@ -272,6 +289,14 @@ func NewRawKfCommand() *cobra.Command {
 				InjectDeleteJobSchedule(p),
 			},
 		},
+		utils.PreviewCommandGroup(
+			"Workflows",
+			InjectCreateWorkflow(p),
+			InjectRunWorkflow(p),
+			InjectListWorkflows(p),
+			InjectWorkflowHistory(p),
+			InjectDeleteWorkflow(p),
+		),
 		utils.PreviewCommandGroup(
 			"Route Services",
 			InjectBindRouteService(p),
@@ -326,6 +351,12 @@ func NewRawKfCommand() *cobra.Command {
 	return rootCmd
 }
 
+// ExitCode gets the code the kf process should exit with after a command
+// returned the error.
+func ExitCode(err error) int {
+	return utils.ExitCode(err)
+}
+
 func getKfNamespace(ctx context.Context, k kubernetes.Interface) *v1.Namespace {
 	ns, err := k.CoreV1().Namespaces().Get(ctx, v1alpha1.KfNamespace, metav1.GetOptions{})
 	if err != nil {
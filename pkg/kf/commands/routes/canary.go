@@ -0,0 +1,178 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/apps"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	utils "github.com/google/kf/v2/pkg/kf/internal/utils/cli"
+	"github.com/google/kf/v2/pkg/kf/routes"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/ptr"
+)
+
+// NewCanaryCommand creates a command to split a Route's traffic with a canary
+// App.
+func NewCanaryCommand(
+	p *config.KfParams,
+	c routes.Client,
+	appsClient apps.Client,
+) *cobra.Command {
+	var (
+		routeFlags      RouteFlags
+		async           utils.AsyncFlags
+		appName         string
+		weight          int32
+		stepWeight      int32
+		interval        time.Duration
+		maxErrorPercent int32
+		remove          bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "canary DOMAIN [--hostname HOSTNAME] [--path PATH] --app APP_NAME [--weight WEIGHT]",
+		Short: "Send a percentage of a Route's traffic to a canary App.",
+		Long: `
+		Sends a percentage of the traffic on a Route to the canary App and splits
+		the remaining traffic between the other Apps mapped to the Route using
+		their weights. The canary App is mapped to the Route if it isn't already.
+
+		If --step-weight and --interval are set, Kf progressively increases the
+		canary App's traffic by the step weight after each interval until it
+		receives all traffic. The rollout is aborted and all traffic is sent to
+		the other Apps if the canary App becomes unhealthy, or if its error rate
+		exceeds --max-error-percent. Checking error rates requires the
+		routeCanaryPrometheusURL setting in config-defaults.
+
+		Once a rollout is promoted, unmap the old Apps from the Route and remove
+		the canary with --remove.
+		`,
+		Example: `
+		# Send 10% of traffic to myapp-v2.
+		kf canary example.com --hostname myapp --app myapp-v2 --weight 10
+
+		# Start at 10% and add 20% every 5 minutes while myapp-v2 is healthy.
+		kf canary example.com --hostname myapp --app myapp-v2 --weight 10 --step-weight 20 --interval 5m --max-error-percent 5
+
+		# Stop splitting traffic with the canary App.
+		kf canary example.com --hostname myapp --remove
+		`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			if err := p.ValidateSpaceTargeted(); err != nil {
+				return err
+			}
+
+			fields := routeFlags.RouteSpecFields(args[0])
+			instanceName := v1alpha1.GenerateRouteNameFromFields(fields)
+
+			if remove {
+				if _, err := c.Transform(ctx, p.Space, instanceName, func(r *v1alpha1.Route) error {
+					r.Spec.Canary = nil
+					return nil
+				}); err != nil {
+					return fmt.Errorf("failed to update Route: %s", err)
+				}
+
+				logging.FromContext(ctx).Infof("Removing canary from Route %q", instanceName)
+			} else {
+				if appName == "" {
+					return errors.New("--app is required")
+				}
+
+				canary := &v1alpha1.RouteCanary{
+					AppName: appName,
+					Weight:  weight,
+				}
+
+				if cmd.Flags().Changed("step-weight") || cmd.Flags().Changed("interval") {
+					canary.Rollout = &v1alpha1.RouteCanaryRollout{
+						StepWeight: stepWeight,
+						Interval:   metav1.Duration{Duration: interval},
+					}
+
+					if cmd.Flags().Changed("max-error-percent") {
+						canary.Rollout.MaxErrorPercent = ptr.Int32(maxErrorPercent)
+					}
+				} else if cmd.Flags().Changed("max-error-percent") {
+					return errors.New("--max-error-percent requires --step-weight and --interval")
+				}
+
+				// The Route is created if it doesn't exist yet so the canary is
+				// in place before the App receives any traffic.
+				desired := &v1alpha1.Route{
+					TypeMeta: metav1.TypeMeta{
+						Kind: "Route",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: p.Space,
+						Name:      instanceName,
+					},
+					Spec: v1alpha1.RouteSpec{
+						RouteSpecFields: fields,
+						Canary:          canary,
+					},
+				}
+
+				if _, err := c.Upsert(ctx, p.Space, desired, func(newObj, oldObj *v1alpha1.Route) *v1alpha1.Route {
+					oldObj.Spec.Canary = newObj.Spec.Canary
+					return oldObj
+				}); err != nil {
+					return fmt.Errorf("failed to update Route: %s", err)
+				}
+
+				if _, err := appsClient.Transform(ctx, p.Space, appName, func(app *v1alpha1.App) error {
+					kfapp := apps.NewFromApp(app)
+					if !kfapp.HasMatchingRoutes(fields) {
+						kfapp.MergeRoute(v1alpha1.RouteWeightBinding{RouteSpecFields: fields})
+					}
+					return nil
+				}); err != nil {
+					return fmt.Errorf("failed to map Route to canary App: %s", err)
+				}
+
+				logging.FromContext(ctx).Infof("Sending %d%% of traffic on Route %q to App %q", weight, instanceName, appName)
+			}
+
+			return async.AwaitAndLog(cmd.ErrOrStderr(), "Waiting for Route to become ready", func() (err error) {
+				_, err = c.WaitForConditionReadyTrue(context.Background(), p.Space, instanceName, 1*time.Second)
+				return
+			})
+		},
+	}
+
+	async.Add(cmd)
+	routeFlags.Add(cmd)
+
+	cmd.Flags().StringVar(&appName, "app", "", "Name of the canary App.")
+	cmd.Flags().Int32Var(&weight, "weight", 10, "Percentage of traffic to send to the canary App, or the starting percentage of a rollout.")
+	cmd.Flags().Int32Var(&stepWeight, "step-weight", 10, "Percentage of traffic added to the canary App on each rollout step.")
+	cmd.Flags().DurationVar(&interval, "interval", 5*time.Minute, "Time between rollout steps.")
+	cmd.Flags().Int32Var(&maxErrorPercent, "max-error-percent", 0, "Abort the rollout if the percentage of the canary App's requests that fail with a 5xx status is higher.")
+	cmd.Flags().BoolVar(&remove, "remove", false, "Remove the canary from the Route.")
+
+	return cmd
+}
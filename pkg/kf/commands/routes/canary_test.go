@@ -0,0 +1,202 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routes_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	kfapps "github.com/google/kf/v2/pkg/kf/apps"
+	appsfake "github.com/google/kf/v2/pkg/kf/apps/fake"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	"github.com/google/kf/v2/pkg/kf/commands/routes"
+	kfroutes "github.com/google/kf/v2/pkg/kf/routes"
+	routesfake "github.com/google/kf/v2/pkg/kf/routes/fake"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/ptr"
+)
+
+func TestCanary(t *testing.T) {
+	t.Parallel()
+
+	fields := v1alpha1.RouteSpecFields{
+		Hostname: "myapp",
+		Domain:   "example.com",
+		Path:     "/",
+	}
+	routeName := v1alpha1.GenerateRouteNameFromFields(fields)
+
+	// expectUpsert asserts the canary set on an existing Route.
+	expectUpsert := func(t *testing.T, routesfake *routesfake.FakeClient, expected *v1alpha1.RouteCanary) {
+		routesfake.EXPECT().
+			Upsert(gomock.Any(), "some-space", gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, newObj *v1alpha1.Route, merge kfroutes.Merger) (*v1alpha1.Route, error) {
+				testutil.AssertEqual(t, "name", routeName, newObj.Name)
+				testutil.AssertEqual(t, "fields", fields, newObj.Spec.RouteSpecFields)
+
+				existing := &v1alpha1.Route{}
+				existing.Spec.RouteSpecFields = fields
+				existing.Spec.Policy = &v1alpha1.RoutePolicy{}
+				merged := merge(newObj, existing)
+				testutil.AssertEqual(t, "canary", expected, merged.Spec.Canary)
+				testutil.AssertEqual(t, "policy", &v1alpha1.RoutePolicy{}, merged.Spec.Policy)
+				return merged, nil
+			})
+	}
+
+	// expectMap asserts the canary App is mapped to the Route.
+	expectMap := func(t *testing.T, appsfake *appsfake.FakeClient, existing []v1alpha1.RouteWeightBinding, expected []v1alpha1.RouteWeightBinding) {
+		appsfake.EXPECT().
+			Transform(gomock.Any(), "some-space", "myapp-v2", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _ string, m kfapps.Mutator) (*v1alpha1.App, error) {
+				app := &v1alpha1.App{}
+				app.Spec.Routes = existing
+				testutil.AssertNil(t, "mutator error", m(app))
+				testutil.AssertEqual(t, "routes", expected, app.Spec.Routes)
+				return app, nil
+			})
+	}
+
+	for tn, tc := range map[string]struct {
+		Space       string
+		Args        []string
+		Setup       func(t *testing.T, routesfake *routesfake.FakeClient, appsfake *appsfake.FakeClient)
+		ExpectedErr error
+	}{
+		"wrong number of args": {
+			Space:       "some-space",
+			Args:        []string{"example.com", "extra", "--app=myapp-v2"},
+			ExpectedErr: errors.New("accepts 1 arg(s), received 2"),
+		},
+		"without space": {
+			Args:        []string{"example.com", "--app=myapp-v2"},
+			ExpectedErr: errors.New(config.EmptySpaceError),
+		},
+		"missing app": {
+			Space:       "some-space",
+			Args:        []string{"example.com", "--hostname=myapp"},
+			ExpectedErr: errors.New("--app is required"),
+		},
+		"max error percent without rollout": {
+			Space:       "some-space",
+			Args:        []string{"example.com", "--hostname=myapp", "--app=myapp-v2", "--max-error-percent=5"},
+			ExpectedErr: errors.New("--max-error-percent requires --step-weight and --interval"),
+		},
+		"updating route fails": {
+			Space: "some-space",
+			Args:  []string{"example.com", "--hostname=myapp", "--app=myapp-v2"},
+			Setup: func(t *testing.T, routesfake *routesfake.FakeClient, appsfake *appsfake.FakeClient) {
+				routesfake.EXPECT().
+					Upsert(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("some-error"))
+			},
+			ExpectedErr: errors.New("failed to update Route: some-error"),
+		},
+		"mapping app fails": {
+			Space: "some-space",
+			Args:  []string{"example.com", "--hostname=myapp", "--app=myapp-v2"},
+			Setup: func(t *testing.T, routesfake *routesfake.FakeClient, appsfake *appsfake.FakeClient) {
+				routesfake.EXPECT().Upsert(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+				appsfake.EXPECT().
+					Transform(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("some-error"))
+			},
+			ExpectedErr: errors.New("failed to map Route to canary App: some-error"),
+		},
+		"fixed weight maps app": {
+			Space: "some-space",
+			Args:  []string{"example.com", "--hostname=myapp", "--app=myapp-v2", "--weight=25"},
+			Setup: func(t *testing.T, routesfake *routesfake.FakeClient, appsfake *appsfake.FakeClient) {
+				expectUpsert(t, routesfake, &v1alpha1.RouteCanary{
+					AppName: "myapp-v2",
+					Weight:  25,
+				})
+				expectMap(t, appsfake, nil, []v1alpha1.RouteWeightBinding{
+					{RouteSpecFields: fields},
+				})
+				routesfake.EXPECT().WaitForConditionReadyTrue(gomock.Any(), "some-space", routeName, gomock.Any())
+			},
+		},
+		"rollout keeps existing mapping": {
+			Space: "some-space",
+			Args: []string{
+				"example.com", "--hostname=myapp", "--app=myapp-v2",
+				"--step-weight=20", "--interval=1m", "--max-error-percent=5",
+			},
+			Setup: func(t *testing.T, routesfake *routesfake.FakeClient, appsfake *appsfake.FakeClient) {
+				expectUpsert(t, routesfake, &v1alpha1.RouteCanary{
+					AppName: "myapp-v2",
+					Weight:  10,
+					Rollout: &v1alpha1.RouteCanaryRollout{
+						StepWeight:      20,
+						Interval:        metav1.Duration{Duration: time.Minute},
+						MaxErrorPercent: ptr.Int32(5),
+					},
+				})
+				existing := []v1alpha1.RouteWeightBinding{
+					{RouteSpecFields: fields, Weight: ptr.Int32(3)},
+				}
+				expectMap(t, appsfake, existing, existing)
+				routesfake.EXPECT().WaitForConditionReadyTrue(gomock.Any(), "some-space", routeName, gomock.Any())
+			},
+		},
+		"remove canary": {
+			Space: "some-space",
+			Args:  []string{"example.com", "--hostname=myapp", "--remove"},
+			Setup: func(t *testing.T, routesfake *routesfake.FakeClient, appsfake *appsfake.FakeClient) {
+				routesfake.EXPECT().
+					Transform(gomock.Any(), "some-space", routeName, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _, _ string, m kfroutes.Mutator) (*v1alpha1.Route, error) {
+						route := &v1alpha1.Route{}
+						route.Spec.Canary = &v1alpha1.RouteCanary{AppName: "myapp-v2"}
+						testutil.AssertNil(t, "mutator error", m(route))
+						testutil.AssertTrue(t, "canary removed", route.Spec.Canary == nil)
+						return route, nil
+					})
+				routesfake.EXPECT().WaitForConditionReadyTrue(gomock.Any(), "some-space", routeName, gomock.Any())
+			},
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			routesfake := routesfake.NewFakeClient(ctrl)
+			appsfake := appsfake.NewFakeClient(ctrl)
+
+			if tc.Setup != nil {
+				tc.Setup(t, routesfake, appsfake)
+			}
+
+			var buffer bytes.Buffer
+			cmd := routes.NewCanaryCommand(
+				&config.KfParams{
+					Space: tc.Space,
+				},
+				routesfake,
+				appsfake,
+			)
+			cmd.SetArgs(tc.Args)
+			cmd.SetOutput(&buffer)
+
+			_, err := cmd.ExecuteC()
+			testutil.AssertErrorsEqual(t, tc.ExpectedErr, err)
+		})
+	}
+}
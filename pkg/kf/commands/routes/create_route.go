@@ -39,7 +39,7 @@ func NewCreateRouteCommand(
 	)
 
 	cmd := &cobra.Command{
-		Use:   "create-route DOMAIN [--hostname HOSTNAME] [--path PATH]",
+		Use:   "create-route DOMAIN [--hostname HOSTNAME] [--path PATH] [--port PORT]",
 		Short: "Create a traffic routing rule for a host+path pair.",
 		Long: `
 		Creating a Route allows Apps to declare they want to receive traffic on
@@ -48,6 +48,10 @@ func NewCreateRouteCommand(
 		Routes without any bound Apps (or with only stopped Apps) will return a 404
 		HTTP status code.
 
+		TCP Routes reserve a port on a domain that has a TCP router group instead
+		of matching a hostname and path. Connections to TCP Routes without any
+		running Apps are refused.
+
 		Kf doesn't enforce Route uniqueness between Spaces. It's recommended
 		to provide each Space with its own subdomain instead.
 		`,
@@ -56,6 +60,7 @@ func NewCreateRouteCommand(
 		kf create-route --space myspace example.com --hostname myapp # myapp.example.com
 		kf create-route example.com --hostname myapp --path /mypath # myapp.example.com/mypath
 		kf create-route --space myspace myapp.example.com # myapp.example.com
+		kf create-route tcp.example.com --port 1024 # tcp.example.com:1024
 
 		# Using SPACE to match 'cf'
 		kf create-route myspace example.com --hostname myapp # myapp.example.com
@@ -82,11 +87,7 @@ func NewCreateRouteCommand(
 
 			fields := routeFlags.RouteSpecFields(domain)
 
-			instanceName := v1alpha1.GenerateRouteName(
-				fields.Hostname,
-				fields.Domain,
-				fields.Path,
-			)
+			instanceName := v1alpha1.GenerateRouteNameFromFields(fields)
 
 			r := &v1alpha1.Route{
 				TypeMeta: metav1.TypeMeta{
@@ -114,6 +115,7 @@ func NewCreateRouteCommand(
 	}
 	async.Add(cmd)
 	routeFlags.Add(cmd)
+	routeFlags.AddPort(cmd)
 
 	return cmd
 }
@@ -43,13 +43,15 @@ func NewDeleteRouteCommand(
 	)
 
 	cmd := &cobra.Command{
-		Use:   "delete-route DOMAIN [--hostname HOSTNAME] [--path PATH]",
+		Use:   "delete-route DOMAIN [--hostname HOSTNAME] [--path PATH] [--port PORT]",
 		Short: "Delete a Route in the targeted Space.",
 		Example: `
   # Delete the Route myapp.example.com
   kf delete-route example.com --hostname myapp
   # Delete a Route on a path myapp.example.com/mypath
   kf delete-route example.com --hostname myapp --path /mypath
+  # Delete a TCP Route tcp.example.com:1024
+  kf delete-route tcp.example.com --port 1024
   `,
 		Args:         cobra.ExactArgs(1),
 		SilenceUsage: true,
@@ -88,11 +90,7 @@ func NewDeleteRouteCommand(
 				}
 			}
 
-			instanceName := v1alpha1.GenerateRouteName(
-				routeFlags.Hostname,
-				domain,
-				routeFlags.Path,
-			)
+			instanceName := v1alpha1.GenerateRouteNameFromFields(route)
 
 			action := fmt.Sprintf("Deleting Route %q in Space %q", instanceName, p.Space)
 
@@ -109,6 +107,7 @@ func NewDeleteRouteCommand(
 	}
 	async.Add(cmd)
 	routeFlags.Add(cmd)
+	routeFlags.AddPort(cmd)
 
 	return cmd
 }
@@ -22,10 +22,11 @@ import (
 	"knative.dev/pkg/ptr"
 )
 
-// RouteFlags includes commonly passed in flags to define a HTTP route.
+// RouteFlags includes commonly passed in flags to define a HTTP or TCP route.
 type RouteFlags struct {
 	Hostname string
 	Path     string
+	Port     int32
 }
 
 // Add appends the flags to the given command
@@ -45,8 +46,30 @@ func (flags *RouteFlags) Add(cmd *cobra.Command) {
 	)
 }
 
+// AddPort appends the flag to reserve a port for TCP Routes to the given
+// command.
+func (flags *RouteFlags) AddPort(cmd *cobra.Command) {
+	cmd.Flags().Int32Var(
+		&flags.Port,
+		"port",
+		0,
+		"Port to reserve for a TCP Route, the domain must have a TCP router group.",
+	)
+}
+
 // RouteSpecFields converts the flags to a RouteSpecFields instance
 func (flags *RouteFlags) RouteSpecFields(domain string) v1alpha1.RouteSpecFields {
+	if flags.Port != 0 {
+		// TCP Routes don't have paths, leave it as-is so validation can
+		// reject it if it was set.
+		return v1alpha1.RouteSpecFields{
+			Hostname: flags.Hostname,
+			Domain:   domain,
+			Path:     flags.Path,
+			Port:     flags.Port,
+		}
+	}
+
 	return v1alpha1.RouteSpecFields{
 		Hostname: flags.Hostname,
 		Domain:   domain,
@@ -63,6 +86,7 @@ type routeBindingFlags struct {
 // Add appends the flags to the given command
 func (flags *routeBindingFlags) Add(cmd *cobra.Command) {
 	flags.RouteFlags.Add(cmd)
+	flags.RouteFlags.AddPort(cmd)
 
 	cmd.Flags().Int32Var(
 		&flags.destinationPort,
@@ -0,0 +1,127 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	utils "github.com/google/kf/v2/pkg/kf/internal/utils/cli"
+	"github.com/google/kf/v2/pkg/kf/routes"
+	"github.com/spf13/cobra"
+	"knative.dev/pkg/logging"
+)
+
+// NewMaintenanceModeCommand creates a command to turn maintenance mode on or
+// off for a Route.
+func NewMaintenanceModeCommand(
+	p *config.KfParams,
+	c routes.Client,
+) *cobra.Command {
+	var (
+		routeFlags RouteFlags
+		async      utils.AsyncFlags
+		on         bool
+		off        bool
+		message    string
+		statusCode int32
+	)
+
+	cmd := &cobra.Command{
+		Use:   "maintenance-mode DOMAIN [--hostname HOSTNAME] [--path PATH] (--on [--message MESSAGE] | --off)",
+		Short: "Turn maintenance mode on or off for a Route.",
+		Long: `
+		Routes in maintenance mode respond to every request with an error
+		status, 503 by default, instead of forwarding it to the Apps mapped to
+		the Route. Apps stay mapped so traffic resumes as soon as maintenance
+		mode is turned off.
+
+		The message is sent as the body of responses from the ingress gateway
+		and displayed when listing Routes so users know why the Route is
+		unavailable.
+		`,
+		Example: `
+		# Put a Route in maintenance mode during a database migration.
+		kf maintenance-mode example.com --hostname myapp --on --message "Database migration"
+
+		# Send traffic to the Apps again.
+		kf maintenance-mode example.com --hostname myapp --off
+		`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			if err := p.ValidateSpaceTargeted(); err != nil {
+				return err
+			}
+
+			if on == off {
+				return errors.New("exactly one of --on or --off must be set")
+			}
+
+			if off && (cmd.Flags().Changed("message") || cmd.Flags().Changed("status-code")) {
+				return errors.New("--message and --status-code can only be used with --on")
+			}
+
+			fields := routeFlags.RouteSpecFields(args[0])
+			instanceName := v1alpha1.GenerateRouteNameFromFields(fields)
+
+			var maintenance *v1alpha1.RouteMaintenance
+			if on {
+				maintenance = &v1alpha1.RouteMaintenance{
+					StatusCode: statusCode,
+					Message:    message,
+				}
+
+				if err := maintenance.Validate(ctx); err != nil {
+					return err
+				}
+			}
+
+			if _, err := c.Transform(ctx, p.Space, instanceName, func(r *v1alpha1.Route) error {
+				r.Spec.Maintenance = maintenance
+				return nil
+			}); err != nil {
+				return fmt.Errorf("failed to update Route: %s", err)
+			}
+
+			if on {
+				logging.FromContext(ctx).Infof("Turning on maintenance mode for Route %q", instanceName)
+			} else {
+				logging.FromContext(ctx).Infof("Turning off maintenance mode for Route %q", instanceName)
+			}
+
+			return async.AwaitAndLog(cmd.ErrOrStderr(), "Waiting for Route to become ready", func() (err error) {
+				_, err = c.WaitForConditionReadyTrue(context.Background(), p.Space, instanceName, 1*time.Second)
+				return
+			})
+		},
+	}
+
+	async.Add(cmd)
+	routeFlags.Add(cmd)
+
+	cmd.Flags().BoolVar(&on, "on", false, "Turn maintenance mode on.")
+	cmd.Flags().BoolVar(&off, "off", false, "Turn maintenance mode off.")
+	cmd.Flags().StringVar(&message, "message", "Down for maintenance", "Reason the Route is in maintenance mode, sent as the response body.")
+	cmd.Flags().Int32Var(&statusCode, "status-code", v1alpha1.DefaultRouteMaintenanceStatusCode, "HTTP status returned for requests to the Route.")
+
+	return cmd
+}
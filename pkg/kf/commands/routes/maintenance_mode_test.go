@@ -0,0 +1,153 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routes_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	"github.com/google/kf/v2/pkg/kf/commands/routes"
+	kfroutes "github.com/google/kf/v2/pkg/kf/routes"
+	routesfake "github.com/google/kf/v2/pkg/kf/routes/fake"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	"knative.dev/pkg/apis"
+)
+
+func TestMaintenanceMode(t *testing.T) {
+	t.Parallel()
+
+	fields := v1alpha1.RouteSpecFields{
+		Hostname: "myapp",
+		Domain:   "example.com",
+		Path:     "/",
+	}
+	routeName := v1alpha1.GenerateRouteNameFromFields(fields)
+
+	// expectTransform asserts the maintenance settings on the Route.
+	expectTransform := func(t *testing.T, routesfake *routesfake.FakeClient, expected *v1alpha1.RouteMaintenance) {
+		routesfake.EXPECT().
+			Transform(gomock.Any(), "some-space", routeName, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _ string, m kfroutes.Mutator) (*v1alpha1.Route, error) {
+				route := &v1alpha1.Route{}
+				route.Spec.RouteSpecFields = fields
+				route.Spec.Maintenance = &v1alpha1.RouteMaintenance{StatusCode: 500}
+				testutil.AssertNil(t, "mutator error", m(route))
+				testutil.AssertEqual(t, "maintenance", expected, route.Spec.Maintenance)
+				return route, nil
+			})
+	}
+
+	for tn, tc := range map[string]struct {
+		Space       string
+		Args        []string
+		Setup       func(t *testing.T, routesfake *routesfake.FakeClient)
+		ExpectedErr error
+	}{
+		"wrong number of args": {
+			Space:       "some-space",
+			Args:        []string{"example.com", "extra", "--on"},
+			ExpectedErr: errors.New("accepts 1 arg(s), received 2"),
+		},
+		"without space": {
+			Args:        []string{"example.com", "--on"},
+			ExpectedErr: errors.New(config.EmptySpaceError),
+		},
+		"neither on nor off": {
+			Space:       "some-space",
+			Args:        []string{"example.com", "--hostname=myapp"},
+			ExpectedErr: errors.New("exactly one of --on or --off must be set"),
+		},
+		"both on and off": {
+			Space:       "some-space",
+			Args:        []string{"example.com", "--hostname=myapp", "--on", "--off"},
+			ExpectedErr: errors.New("exactly one of --on or --off must be set"),
+		},
+		"message with off": {
+			Space:       "some-space",
+			Args:        []string{"example.com", "--hostname=myapp", "--off", "--message=done"},
+			ExpectedErr: errors.New("--message and --status-code can only be used with --on"),
+		},
+		"invalid status code": {
+			Space:       "some-space",
+			Args:        []string{"example.com", "--hostname=myapp", "--on", "--status-code=200"},
+			ExpectedErr: apis.ErrOutOfBoundsValue(200, 400, 599, "statusCode"),
+		},
+		"updating route fails": {
+			Space: "some-space",
+			Args:  []string{"example.com", "--hostname=myapp", "--on"},
+			Setup: func(t *testing.T, routesfake *routesfake.FakeClient) {
+				routesfake.EXPECT().
+					Transform(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("some-error"))
+			},
+			ExpectedErr: errors.New("failed to update Route: some-error"),
+		},
+		"turn on with message": {
+			Space: "some-space",
+			Args:  []string{"example.com", "--hostname=myapp", "--on", "--message=Database migration"},
+			Setup: func(t *testing.T, routesfake *routesfake.FakeClient) {
+				expectTransform(t, routesfake, &v1alpha1.RouteMaintenance{
+					StatusCode: 503,
+					Message:    "Database migration",
+				})
+				routesfake.EXPECT().WaitForConditionReadyTrue(gomock.Any(), "some-space", routeName, gomock.Any())
+			},
+		},
+		"turn on with defaults": {
+			Space: "some-space",
+			Args:  []string{"example.com", "--hostname=myapp", "--on", "--async"},
+			Setup: func(t *testing.T, routesfake *routesfake.FakeClient) {
+				expectTransform(t, routesfake, &v1alpha1.RouteMaintenance{
+					StatusCode: 503,
+					Message:    "Down for maintenance",
+				})
+			},
+		},
+		"turn off": {
+			Space: "some-space",
+			Args:  []string{"example.com", "--hostname=myapp", "--off", "--async"},
+			Setup: func(t *testing.T, routesfake *routesfake.FakeClient) {
+				expectTransform(t, routesfake, nil)
+			},
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			routesfake := routesfake.NewFakeClient(ctrl)
+
+			if tc.Setup != nil {
+				tc.Setup(t, routesfake)
+			}
+
+			var buffer bytes.Buffer
+			cmd := routes.NewMaintenanceModeCommand(
+				&config.KfParams{
+					Space: tc.Space,
+				},
+				routesfake,
+			)
+			cmd.SetArgs(tc.Args)
+			cmd.SetOutput(&buffer)
+
+			_, err := cmd.ExecuteC()
+			testutil.AssertErrorsEqual(t, tc.ExpectedErr, err)
+		})
+	}
+}
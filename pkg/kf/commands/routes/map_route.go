@@ -33,13 +33,14 @@ func NewMapRouteCommand(
 	appsClient apps.Client,
 ) *cobra.Command {
 	var (
-		async        utils.AsyncIfStoppedFlags
-		bindingFlags routeBindingFlags
-		weight       int32
+		async         utils.AsyncIfStoppedFlags
+		bindingFlags  routeBindingFlags
+		weight        int32
+		mirrorPercent int32
 	)
 
 	cmd := &cobra.Command{
-		Use:   "map-route APP_NAME DOMAIN [--hostname HOSTNAME] [--path PATH] [--weight WEIGHT]",
+		Use:   "map-route APP_NAME DOMAIN [--hostname HOSTNAME] [--path PATH] [--port PORT] [--weight WEIGHT] [--mirror-percent PERCENT]",
 		Short: "Grant an App access to receive traffic from the Route.",
 		Long: `
 		Mapping an App to a Route will cause traffic to be forwarded to the App if
@@ -50,6 +51,10 @@ func NewMapRouteCommand(
 		gateways which update their routing tables with slight delays and route
 		independently. Because of this, traffic routing may not appear even but it
 		will converge over time.
+
+		Apps mapped with --mirror-percent receive a copy of the given percentage
+		of the Route's traffic. Responses from mirrored Apps are discarded, so
+		they can be used to test new versions against live traffic.
 		`,
 		Example: `
 		kf map-route myapp example.com --hostname myapp # myapp.example.com
@@ -57,6 +62,8 @@ func NewMapRouteCommand(
 		kf map-route myapp example.com --hostname myapp --weight 2 # myapp.example.com, myapp receives 2x traffic
 		kf map-route --space myspace myapp example.com --hostname myapp # myapp.example.com
 		kf map-route myapp example.com --hostname myapp --path /mypath # myapp.example.com/mypath
+		kf map-route myapp-v2 example.com --hostname myapp --mirror-percent 10 # myapp-v2 receives a copy of 10% of traffic
+		kf map-route myapp tcp.example.com --port 1024 --destination-port 1883 # tcp.example.com:1024
 		`,
 		Args:              cobra.ExactArgs(2),
 		ValidArgsFunction: completion.AppCompletionFn(p),
@@ -70,6 +77,9 @@ func NewMapRouteCommand(
 			mutator := func(app *v1alpha1.App) error {
 				toAdd := bindingFlags.RouteWeightBinding(domain)
 				toAdd.Weight = &weight
+				if cmd.Flags().Changed("mirror-percent") {
+					toAdd.MirrorPercent = &mirrorPercent
+				}
 
 				apps.NewFromApp(app).MergeRoute(toAdd)
 				return nil
@@ -99,5 +109,12 @@ func NewMapRouteCommand(
 		"Weight for the Route.",
 	)
 
+	cmd.Flags().Int32Var(
+		&mirrorPercent,
+		"mirror-percent",
+		0,
+		"Percentage of the Route's traffic to copy to the App, responses are discarded.",
+	)
+
 	return cmd
 }
@@ -134,6 +134,22 @@ func TestMapRoute(t *testing.T) {
 				appsfake.EXPECT().WaitForConditionRoutesReadyTrue(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
 			},
 		},
+		"transform App by adding mirrored route": {
+			Args:  []string{"some-app", "example.com", "--hostname=some-host", "--mirror-percent=10"},
+			Space: "some-space",
+			Setup: func(t *testing.T, appsfake *appsfake.FakeClient) {
+				appsfake.EXPECT().
+					Transform(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, _, _ string, m apps.Mutator) {
+						oldApp := v1alpha1.App{}
+						testutil.AssertNil(t, "err", m(&oldApp))
+
+						testutil.AssertEqual(t, "Hostname", "some-host", oldApp.Spec.Routes[0].Hostname)
+						testutil.AssertEqual(t, "MirrorPercent", ptr.Int32(10), oldApp.Spec.Routes[0].MirrorPercent)
+					})
+				appsfake.EXPECT().WaitForConditionRoutesReadyTrue(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+			},
+		},
 	} {
 		t.Run(tn, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
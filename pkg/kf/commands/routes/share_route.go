@@ -0,0 +1,169 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/commands/completion"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	utils "github.com/google/kf/v2/pkg/kf/internal/utils/cli"
+	"github.com/google/kf/v2/pkg/kf/routes"
+	"github.com/google/kf/v2/pkg/kf/spaces"
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/logging"
+)
+
+// NewShareRouteCommand creates a command to share a Route with another
+// Space.
+func NewShareRouteCommand(
+	p *config.KfParams,
+	c routes.Client,
+	spacesClient spaces.Client,
+) *cobra.Command {
+	var (
+		routeFlags RouteFlags
+		async      utils.AsyncFlags
+		toSpace    string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "share-route DOMAIN --to-space SPACE [--hostname HOSTNAME] [--path PATH]",
+		Short: "Allow Apps in another Space to be mapped to a Route.",
+		Long: `
+		Sharing a Route lets Apps in another Space serve the same hostname and
+		path. Apps in the other Space are mapped to the Route with
+		map-route as usual, and traffic is split between the Apps in both
+		Spaces.
+
+		The Route stays owned by the targeted Space, which must be able to
+		reach the Apps in the other Space if network policies are used. The
+		domain must be available in both Spaces.
+
+		The other Space accepts the share with a Route of its own that sets
+		sharedFrom, so sharing requires permission to manage Routes in both
+		Spaces.
+		`,
+		Example: `
+		# Let Apps in the "blue" Space serve myapp.example.com.
+		kf share-route example.com --hostname myapp --to-space blue
+		`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			if err := p.ValidateSpaceTargeted(); err != nil {
+				return err
+			}
+
+			if toSpace == "" {
+				return errors.New("--to-space is required")
+			}
+
+			if toSpace == p.Space {
+				return errors.New("a Route can't be shared with the Space that owns it")
+			}
+
+			fields := routeFlags.RouteSpecFields(args[0])
+			instanceName := v1alpha1.GenerateRouteNameFromFields(fields)
+
+			space, err := spacesClient.Get(ctx, toSpace)
+			if err != nil {
+				return fmt.Errorf("failed to get Space: %s", err)
+			}
+
+			if !hasDomain(space, fields.Domain) {
+				return fmt.Errorf("the domain %q isn't available in Space %q", fields.Domain, toSpace)
+			}
+
+			if err := acceptShare(ctx, c, toSpace, p.Space, fields); err != nil {
+				return fmt.Errorf("failed to accept Route in Space %q: %s", toSpace, err)
+			}
+
+			if _, err := c.Transform(ctx, p.Space, instanceName, func(r *v1alpha1.Route) error {
+				if !r.Spec.IsSharedWith(toSpace) {
+					r.Spec.SharedSpaces = append(r.Spec.SharedSpaces, toSpace)
+				}
+				return nil
+			}); err != nil {
+				return fmt.Errorf("failed to update Route: %s", err)
+			}
+
+			logging.FromContext(ctx).Infof("Sharing Route %q with Space %q", instanceName, toSpace)
+
+			return async.AwaitAndLog(cmd.ErrOrStderr(), "Waiting for Route to become ready", func() (err error) {
+				_, err = c.WaitForConditionReadyTrue(context.Background(), p.Space, instanceName, 1*time.Second)
+				return
+			})
+		},
+	}
+
+	async.Add(cmd)
+	routeFlags.Add(cmd)
+
+	cmd.Flags().StringVar(&toSpace, "to-space", "", "Space to share the Route with.")
+	cmd.RegisterFlagCompletionFunc("to-space", completion.SpaceCompletionFn(p))
+
+	return cmd
+}
+
+// acceptShare creates or updates the Route with the given fields in space so
+// it accepts being served by the Route in the owner Space.
+func acceptShare(ctx context.Context, c routes.Client, space, owner string, fields v1alpha1.RouteSpecFields) error {
+	name := v1alpha1.GenerateRouteNameFromFields(fields)
+
+	_, err := c.Transform(ctx, space, name, func(r *v1alpha1.Route) error {
+		if r.Spec.SharedFrom != "" && r.Spec.SharedFrom != owner {
+			return fmt.Errorf("the Route is already shared from Space %q", r.Spec.SharedFrom)
+		}
+		r.Spec.SharedFrom = owner
+		return nil
+	})
+	if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	_, err = c.Create(ctx, space, &v1alpha1.Route{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "Route",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: space,
+			Name:      name,
+		},
+		Spec: v1alpha1.RouteSpec{
+			RouteSpecFields: fields,
+			SharedFrom:      owner,
+		},
+	})
+	return err
+}
+
+// hasDomain returns true if the Space permits Routes on the domain.
+func hasDomain(space *v1alpha1.Space, domain string) bool {
+	for _, sd := range space.Status.NetworkConfig.Domains {
+		if sd.Domain == domain {
+			return true
+		}
+	}
+	return false
+}
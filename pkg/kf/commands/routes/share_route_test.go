@@ -0,0 +1,216 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routes_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	"github.com/google/kf/v2/pkg/kf/commands/routes"
+	kfroutes "github.com/google/kf/v2/pkg/kf/routes"
+	routesfake "github.com/google/kf/v2/pkg/kf/routes/fake"
+	spacesfake "github.com/google/kf/v2/pkg/kf/spaces/fake"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+func TestShareRoute(t *testing.T) {
+	t.Parallel()
+
+	fields := v1alpha1.RouteSpecFields{
+		Hostname: "myapp",
+		Domain:   "example.com",
+		Path:     "/",
+	}
+	routeName := v1alpha1.GenerateRouteNameFromFields(fields)
+
+	otherSpace := &v1alpha1.Space{}
+	otherSpace.Name = "other-space"
+	otherSpace.Status.NetworkConfig.Domains = []v1alpha1.SpaceDomain{
+		{Domain: "example.com"},
+	}
+
+	// expectTransform asserts the shared Spaces on the Route.
+	expectTransform := func(t *testing.T, routesfake *routesfake.FakeClient, initial, expected []string) {
+		routesfake.EXPECT().
+			Transform(gomock.Any(), "some-space", routeName, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _ string, m kfroutes.Mutator) (*v1alpha1.Route, error) {
+				route := &v1alpha1.Route{}
+				route.Spec.RouteSpecFields = fields
+				route.Spec.SharedSpaces = initial
+				testutil.AssertNil(t, "mutator error", m(route))
+				testutil.AssertEqual(t, "sharedSpaces", expected, route.Spec.SharedSpaces)
+				return route, nil
+			})
+	}
+
+	// expectAccept asserts the Route in the other Space accepts the share.
+	expectAccept := func(t *testing.T, routesfake *routesfake.FakeClient, initial string) {
+		routesfake.EXPECT().
+			Transform(gomock.Any(), "other-space", routeName, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _ string, m kfroutes.Mutator) (*v1alpha1.Route, error) {
+				route := &v1alpha1.Route{}
+				route.Spec.RouteSpecFields = fields
+				route.Spec.SharedFrom = initial
+				if err := m(route); err != nil {
+					return nil, err
+				}
+				testutil.AssertEqual(t, "sharedFrom", "some-space", route.Spec.SharedFrom)
+				return route, nil
+			})
+	}
+
+	for tn, tc := range map[string]struct {
+		Space       string
+		Args        []string
+		Setup       func(t *testing.T, routesfake *routesfake.FakeClient, spacesfake *spacesfake.FakeClient)
+		ExpectedErr error
+	}{
+		"wrong number of args": {
+			Space:       "some-space",
+			Args:        []string{"example.com", "extra", "--to-space=other-space"},
+			ExpectedErr: errors.New("accepts 1 arg(s), received 2"),
+		},
+		"without space": {
+			Args:        []string{"example.com", "--to-space=other-space"},
+			ExpectedErr: errors.New(config.EmptySpaceError),
+		},
+		"missing to-space": {
+			Space:       "some-space",
+			Args:        []string{"example.com", "--hostname=myapp"},
+			ExpectedErr: errors.New("--to-space is required"),
+		},
+		"sharing with own space": {
+			Space:       "some-space",
+			Args:        []string{"example.com", "--hostname=myapp", "--to-space=some-space"},
+			ExpectedErr: errors.New("a Route can't be shared with the Space that owns it"),
+		},
+		"getting space fails": {
+			Space: "some-space",
+			Args:  []string{"example.com", "--hostname=myapp", "--to-space=other-space"},
+			Setup: func(t *testing.T, routesfake *routesfake.FakeClient, spacesfake *spacesfake.FakeClient) {
+				spacesfake.EXPECT().Get(gomock.Any(), "other-space").Return(nil, errors.New("some-error"))
+			},
+			ExpectedErr: errors.New("failed to get Space: some-error"),
+		},
+		"domain missing in other space": {
+			Space: "some-space",
+			Args:  []string{"other.example.com", "--hostname=myapp", "--to-space=other-space"},
+			Setup: func(t *testing.T, routesfake *routesfake.FakeClient, spacesfake *spacesfake.FakeClient) {
+				spacesfake.EXPECT().Get(gomock.Any(), "other-space").Return(otherSpace, nil)
+			},
+			ExpectedErr: errors.New(`the domain "other.example.com" isn't available in Space "other-space"`),
+		},
+		"updating route fails": {
+			Space: "some-space",
+			Args:  []string{"example.com", "--hostname=myapp", "--to-space=other-space"},
+			Setup: func(t *testing.T, routesfake *routesfake.FakeClient, spacesfake *spacesfake.FakeClient) {
+				spacesfake.EXPECT().Get(gomock.Any(), "other-space").Return(otherSpace, nil)
+				expectAccept(t, routesfake, "")
+				routesfake.EXPECT().
+					Transform(gomock.Any(), "some-space", gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("some-error"))
+			},
+			ExpectedErr: errors.New("failed to update Route: some-error"),
+		},
+		"accepting route fails": {
+			Space: "some-space",
+			Args:  []string{"example.com", "--hostname=myapp", "--to-space=other-space"},
+			Setup: func(t *testing.T, routesfake *routesfake.FakeClient, spacesfake *spacesfake.FakeClient) {
+				spacesfake.EXPECT().Get(gomock.Any(), "other-space").Return(otherSpace, nil)
+				routesfake.EXPECT().
+					Transform(gomock.Any(), "other-space", gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("some-error"))
+			},
+			ExpectedErr: errors.New(`failed to accept Route in Space "other-space": some-error`),
+		},
+		"route in other space shared from a third space": {
+			Space: "some-space",
+			Args:  []string{"example.com", "--hostname=myapp", "--to-space=other-space"},
+			Setup: func(t *testing.T, routesfake *routesfake.FakeClient, spacesfake *spacesfake.FakeClient) {
+				spacesfake.EXPECT().Get(gomock.Any(), "other-space").Return(otherSpace, nil)
+				expectAccept(t, routesfake, "third-space")
+			},
+			ExpectedErr: errors.New(`failed to accept Route in Space "other-space": the Route is already shared from Space "third-space"`),
+		},
+		"creates accepting route": {
+			Space: "some-space",
+			Args:  []string{"example.com", "--hostname=myapp", "--to-space=other-space", "--async"},
+			Setup: func(t *testing.T, routesfake *routesfake.FakeClient, spacesfake *spacesfake.FakeClient) {
+				spacesfake.EXPECT().Get(gomock.Any(), "other-space").Return(otherSpace, nil)
+				routesfake.EXPECT().
+					Transform(gomock.Any(), "other-space", routeName, gomock.Any()).
+					Return(nil, apierrors.NewNotFound(v1alpha1.Resource("routes"), routeName))
+				routesfake.EXPECT().
+					Create(gomock.Any(), "other-space", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, r *v1alpha1.Route) (*v1alpha1.Route, error) {
+						testutil.AssertEqual(t, "name", routeName, r.Name)
+						testutil.AssertEqual(t, "fields", fields, r.Spec.RouteSpecFields)
+						testutil.AssertEqual(t, "sharedFrom", "some-space", r.Spec.SharedFrom)
+						return r, nil
+					})
+				expectTransform(t, routesfake, nil, []string{"other-space"})
+			},
+		},
+		"shares route": {
+			Space: "some-space",
+			Args:  []string{"example.com", "--hostname=myapp", "--to-space=other-space"},
+			Setup: func(t *testing.T, routesfake *routesfake.FakeClient, spacesfake *spacesfake.FakeClient) {
+				spacesfake.EXPECT().Get(gomock.Any(), "other-space").Return(otherSpace, nil)
+				expectAccept(t, routesfake, "")
+				expectTransform(t, routesfake, []string{"third-space"}, []string{"third-space", "other-space"})
+				routesfake.EXPECT().WaitForConditionReadyTrue(gomock.Any(), "some-space", routeName, gomock.Any())
+			},
+		},
+		"already shared": {
+			Space: "some-space",
+			Args:  []string{"example.com", "--hostname=myapp", "--to-space=other-space", "--async"},
+			Setup: func(t *testing.T, routesfake *routesfake.FakeClient, spacesfake *spacesfake.FakeClient) {
+				spacesfake.EXPECT().Get(gomock.Any(), "other-space").Return(otherSpace, nil)
+				expectAccept(t, routesfake, "some-space")
+				expectTransform(t, routesfake, []string{"other-space"}, []string{"other-space"})
+			},
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			routesfake := routesfake.NewFakeClient(ctrl)
+			spacesfake := spacesfake.NewFakeClient(ctrl)
+
+			if tc.Setup != nil {
+				tc.Setup(t, routesfake, spacesfake)
+			}
+
+			var buffer bytes.Buffer
+			cmd := routes.NewShareRouteCommand(
+				&config.KfParams{
+					Space: tc.Space,
+				},
+				routesfake,
+				spacesfake,
+			)
+			cmd.SetArgs(tc.Args)
+			cmd.SetOutput(&buffer)
+
+			_, err := cmd.ExecuteC()
+			testutil.AssertErrorsEqual(t, tc.ExpectedErr, err)
+		})
+	}
+}
@@ -36,7 +36,7 @@ func NewUnmapRouteCommand(
 	var bindingFlags routeBindingFlags
 
 	cmd := &cobra.Command{
-		Use:   "unmap-route APP_NAME DOMAIN [--hostname HOSTNAME] [--path PATH]",
+		Use:   "unmap-route APP_NAME DOMAIN [--hostname HOSTNAME] [--path PATH] [--port PORT]",
 		Short: "Revoke an App's access to receive traffic from the Route.",
 		Long: `
 		Unmapping an App from a Route will cause traffic matching the Route to no
@@ -58,6 +58,9 @@ func NewUnmapRouteCommand(
 
 		# Unmap a Route with a path
 		kf unmap-route myapp example.com --hostname myapp --path /mypath
+
+		# Unmap a TCP Route
+		kf unmap-route myapp tcp.example.com --port 1024
 		`,
 		Args:              cobra.ExactArgs(2),
 		ValidArgsFunction: completion.AppCompletionFn(p),
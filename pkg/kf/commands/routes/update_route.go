@@ -0,0 +1,369 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	utils "github.com/google/kf/v2/pkg/kf/internal/utils/cli"
+	"github.com/google/kf/v2/pkg/kf/routes"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/logging"
+)
+
+// policyFlags contains the flags used to modify a RoutePolicy.
+type policyFlags struct {
+	clear bool
+
+	timeout       time.Duration
+	retries       int32
+	perTryTimeout time.Duration
+	retryOn       []string
+
+	corsAllowOrigins     []string
+	corsAllowMethods     []string
+	corsAllowHeaders     []string
+	corsExposeHeaders    []string
+	corsMaxAge           time.Duration
+	corsAllowCredentials bool
+	removeCORS           bool
+
+	addRequestHeaders     map[string]string
+	removeRequestHeaders  []string
+	addResponseHeaders    map[string]string
+	removeResponseHeaders []string
+
+	rateLimit       int32
+	rateLimitHeader string
+
+	flags *pflag.FlagSet
+}
+
+// Add appends the flags to the given command.
+func (f *policyFlags) Add(cmd *cobra.Command) {
+	f.flags = cmd.Flags()
+
+	f.flags.BoolVar(&f.clear, "clear-policy", false, "Remove the existing policy before applying other flags.")
+
+	f.flags.DurationVar(&f.timeout, "timeout", 0, "Maximum time a request can take including retries, 0 removes the timeout.")
+	f.flags.Int32Var(&f.retries, "retries", 0, "Number of times failed requests are retried, 0 disables retries.")
+	f.flags.DurationVar(&f.perTryTimeout, "retry-timeout", 0, "Timeout for each retry attempt.")
+	f.flags.StringSliceVar(&f.retryOn, "retry-on", nil, "Conditions that cause a request to be retried e.g. 5xx, connect-failure, reset or an HTTP status code.")
+
+	f.flags.StringSliceVar(&f.corsAllowOrigins, "cors-allow-origin", nil, "Origin allowed to make cross-origin requests, * allows all origins.")
+	f.flags.StringSliceVar(&f.corsAllowMethods, "cors-allow-method", nil, "Method allowed in cross-origin requests.")
+	f.flags.StringSliceVar(&f.corsAllowHeaders, "cors-allow-header", nil, "Header allowed in cross-origin requests.")
+	f.flags.StringSliceVar(&f.corsExposeHeaders, "cors-expose-header", nil, "Header browsers are allowed to access.")
+	f.flags.DurationVar(&f.corsMaxAge, "cors-max-age", 0, "How long the results of a preflight request can be cached.")
+	f.flags.BoolVar(&f.corsAllowCredentials, "cors-allow-credentials", false, "Allow cross-origin requests to include credentials.")
+	f.flags.BoolVar(&f.removeCORS, "remove-cors", false, "Remove the CORS policy.")
+
+	f.flags.StringToStringVar(&f.addRequestHeaders, "add-request-header", nil, "Header to add to requests before they reach the App, in the form NAME=VALUE.")
+	f.flags.StringSliceVar(&f.removeRequestHeaders, "remove-request-header", nil, "Header to remove from requests before they reach the App.")
+	f.flags.StringToStringVar(&f.addResponseHeaders, "add-response-header", nil, "Header to add to responses, in the form NAME=VALUE.")
+	f.flags.StringSliceVar(&f.removeResponseHeaders, "remove-response-header", nil, "Header to remove from responses.")
+
+	f.flags.Int32Var(&f.rateLimit, "rate-limit", 0, "Requests per second each client can make, 0 removes the rate limit.")
+	f.flags.StringVar(&f.rateLimitHeader, "rate-limit-header", "", "Header that identifies clients for the rate limit instead of their IP address.")
+}
+
+func (f *policyFlags) changed(names ...string) bool {
+	for _, name := range names {
+		if f.flags.Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks that the flags are consistent.
+func (f *policyFlags) Validate() error {
+	if !f.changed(
+		"clear-policy",
+		"timeout", "retries", "retry-timeout", "retry-on",
+		"cors-allow-origin", "cors-allow-method", "cors-allow-header",
+		"cors-expose-header", "cors-max-age", "cors-allow-credentials", "remove-cors",
+		"add-request-header", "remove-request-header",
+		"add-response-header", "remove-response-header",
+		"rate-limit", "rate-limit-header",
+	) {
+		return errors.New("at least one policy flag must be set")
+	}
+
+	if f.removeCORS && f.changed(
+		"cors-allow-origin", "cors-allow-method", "cors-allow-header",
+		"cors-expose-header", "cors-max-age", "cors-allow-credentials",
+	) {
+		return errors.New("--remove-cors can't be combined with other CORS flags")
+	}
+
+	return nil
+}
+
+// Apply merges the changed flags into the existing policy and returns the
+// result, or nil if the policy is empty.
+func (f *policyFlags) Apply(policy *v1alpha1.RoutePolicy) *v1alpha1.RoutePolicy {
+	if policy == nil || f.clear {
+		policy = &v1alpha1.RoutePolicy{}
+	}
+
+	if f.changed("timeout") {
+		policy.Timeout = nil
+		if f.timeout > 0 {
+			policy.Timeout = &metav1.Duration{Duration: f.timeout}
+		}
+	}
+
+	if f.changed("retries", "retry-timeout", "retry-on") {
+		if policy.Retries == nil {
+			policy.Retries = &v1alpha1.RouteRetryPolicy{}
+		}
+
+		if f.changed("retries") {
+			policy.Retries.Attempts = f.retries
+		}
+
+		if f.changed("retry-timeout") {
+			policy.Retries.PerTryTimeout = nil
+			if f.perTryTimeout > 0 {
+				policy.Retries.PerTryTimeout = &metav1.Duration{Duration: f.perTryTimeout}
+			}
+		}
+
+		if f.changed("retry-on") {
+			policy.Retries.RetryOn = f.retryOn
+		}
+	}
+
+	if f.removeCORS {
+		policy.CORS = nil
+	}
+
+	if f.changed("cors-allow-origin", "cors-allow-method", "cors-allow-header", "cors-expose-header", "cors-max-age", "cors-allow-credentials") {
+		if policy.CORS == nil {
+			policy.CORS = &v1alpha1.RouteCORSPolicy{}
+		}
+
+		if f.changed("cors-allow-origin") {
+			policy.CORS.AllowOrigins = f.corsAllowOrigins
+		}
+
+		if f.changed("cors-allow-method") {
+			policy.CORS.AllowMethods = f.corsAllowMethods
+		}
+
+		if f.changed("cors-allow-header") {
+			policy.CORS.AllowHeaders = f.corsAllowHeaders
+		}
+
+		if f.changed("cors-expose-header") {
+			policy.CORS.ExposeHeaders = f.corsExposeHeaders
+		}
+
+		if f.changed("cors-max-age") {
+			policy.CORS.MaxAge = nil
+			if f.corsMaxAge > 0 {
+				policy.CORS.MaxAge = &metav1.Duration{Duration: f.corsMaxAge}
+			}
+		}
+
+		if f.changed("cors-allow-credentials") {
+			policy.CORS.AllowCredentials = f.corsAllowCredentials
+		}
+	}
+
+	policy.RequestHeaders = mergeHeaderOperations(
+		policy.RequestHeaders,
+		f.addRequestHeaders,
+		f.removeRequestHeaders,
+	)
+
+	policy.ResponseHeaders = mergeHeaderOperations(
+		policy.ResponseHeaders,
+		f.addResponseHeaders,
+		f.removeResponseHeaders,
+	)
+
+	if f.changed("rate-limit", "rate-limit-header") {
+		if policy.RateLimit == nil {
+			policy.RateLimit = &v1alpha1.RouteRateLimit{}
+		}
+
+		if f.changed("rate-limit") {
+			policy.RateLimit.RequestsPerSecond = f.rateLimit
+		}
+
+		if f.changed("rate-limit-header") {
+			policy.RateLimit.Header = f.rateLimitHeader
+		}
+
+		if policy.RateLimit.RequestsPerSecond == 0 {
+			policy.RateLimit = nil
+		}
+	}
+
+	if *policy == (v1alpha1.RoutePolicy{}) {
+		return nil
+	}
+
+	return policy
+}
+
+// mergeHeaderOperations adds the given operations to the existing ones.
+// Adding a header cancels a previous removal and vice versa.
+func mergeHeaderOperations(
+	ops *v1alpha1.RouteHeaderOperations,
+	add map[string]string,
+	remove []string,
+) *v1alpha1.RouteHeaderOperations {
+	if len(add) == 0 && len(remove) == 0 {
+		return ops
+	}
+
+	if ops == nil {
+		ops = &v1alpha1.RouteHeaderOperations{}
+	}
+
+	for name, value := range add {
+		if ops.Add == nil {
+			ops.Add = make(map[string]string)
+		}
+		ops.Add[name] = value
+		ops.Remove = removeString(ops.Remove, name)
+	}
+
+	for _, name := range remove {
+		delete(ops.Add, name)
+		ops.Remove = append(removeString(ops.Remove, name), name)
+	}
+
+	if len(ops.Add) == 0 {
+		ops.Add = nil
+	}
+
+	if len(ops.Add) == 0 && len(ops.Remove) == 0 {
+		return nil
+	}
+
+	return ops
+}
+
+func removeString(values []string, value string) []string {
+	var out []string
+	for _, v := range values {
+		if v != value {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// NewUpdateRouteCommand creates a command to update the traffic policy of a
+// Route.
+func NewUpdateRouteCommand(
+	p *config.KfParams,
+	c routes.Client,
+) *cobra.Command {
+	var (
+		routeFlags RouteFlags
+		policy     policyFlags
+		async      utils.AsyncFlags
+	)
+
+	cmd := &cobra.Command{
+		Use:   "update-route DOMAIN [--hostname HOSTNAME] [--path PATH] [POLICY_FLAGS...]",
+		Short: "Update the traffic policy of a Route.",
+		Long: `
+		Updates the timeouts, retries, CORS policy, header modifications, and
+		rate limit applied to HTTP requests sent to Apps bound to the Route.
+
+		Only the policies set with flags are changed, other policies on the
+		Route are kept. Use --clear-policy to remove all existing policies.
+
+		Retries set on a Route override the cluster-wide setting that disables
+		retries.
+
+		Rate limits are enforced by each ingress gateway instance. Clients that
+		exceed the limit get a 429 response. Rate limits need Istio 1.26 or
+		later, operators set the version of Istio in the routeIstioVersion
+		config-defaults setting. Clients behind load balancers are identified
+		by their IP address if operators set routeRateLimitTrustedHops.
+		`,
+		Example: `
+		# Time out requests after 30 seconds.
+		kf update-route example.com --hostname myapp --timeout 30s
+
+		# Retry failed requests up to 3 times.
+		kf update-route example.com --hostname myapp --retries 3 --retry-on 5xx,connect-failure
+
+		# Allow cross-origin requests from any origin.
+		kf update-route example.com --hostname myapp --cors-allow-origin '*' --cors-allow-method GET,POST
+
+		# Add a header to requests and remove a header from responses.
+		kf update-route example.com --hostname myapp --add-request-header X-Env=prod --remove-response-header Server
+
+		# Allow each client IP 10 requests per second.
+		kf update-route example.com --hostname myapp --rate-limit 10
+
+		# Allow each API key 100 requests per second.
+		kf update-route example.com --hostname myapp --rate-limit 100 --rate-limit-header X-Api-Key
+
+		# Remove all policies.
+		kf update-route example.com --hostname myapp --clear-policy
+		`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			if err := p.ValidateSpaceTargeted(); err != nil {
+				return err
+			}
+
+			if err := policy.Validate(); err != nil {
+				return err
+			}
+
+			fields := routeFlags.RouteSpecFields(args[0])
+			instanceName := v1alpha1.GenerateRouteNameFromFields(fields)
+
+			if _, err := c.Transform(ctx, p.Space, instanceName, func(r *v1alpha1.Route) error {
+				r.Spec.Policy = policy.Apply(r.Spec.Policy)
+				return nil
+			}); err != nil {
+				return fmt.Errorf("failed to update Route: %s", err)
+			}
+
+			logging.FromContext(ctx).Infof("Updating Route %q in Space %q", instanceName, p.Space)
+			return async.AwaitAndLog(cmd.ErrOrStderr(), "Waiting for Route to become ready", func() (err error) {
+				_, err = c.WaitForConditionReadyTrue(context.Background(), p.Space, instanceName, 1*time.Second)
+				return
+			})
+		},
+	}
+
+	async.Add(cmd)
+	routeFlags.Add(cmd)
+	policy.Add(cmd)
+
+	return cmd
+}
@@ -0,0 +1,226 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routes_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	"github.com/google/kf/v2/pkg/kf/commands/routes"
+	kfroutes "github.com/google/kf/v2/pkg/kf/routes"
+	routesfake "github.com/google/kf/v2/pkg/kf/routes/fake"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestUpdateRoute(t *testing.T) {
+	t.Parallel()
+
+	routeName := v1alpha1.GenerateRouteName("myapp", "example.com", "/")
+
+	// expectTransform asserts the policy after the mutator is applied to a
+	// Route with the existing policy.
+	expectTransform := func(existing, expected *v1alpha1.RoutePolicy) func(t *testing.T, routesfake *routesfake.FakeClient) {
+		return func(t *testing.T, routesfake *routesfake.FakeClient) {
+			routesfake.EXPECT().
+				Transform(gomock.Any(), "some-space", routeName, gomock.Any()).
+				DoAndReturn(func(_ context.Context, _, _ string, m kfroutes.Mutator) (*v1alpha1.Route, error) {
+					route := &v1alpha1.Route{}
+					route.Spec.Policy = existing
+					testutil.AssertNil(t, "mutator error", m(route))
+					testutil.AssertEqual(t, "policy", expected, route.Spec.Policy)
+					return route, nil
+				})
+			routesfake.EXPECT().WaitForConditionReadyTrue(gomock.Any(), "some-space", routeName, gomock.Any())
+		}
+	}
+
+	for tn, tc := range map[string]struct {
+		Space       string
+		Args        []string
+		Setup       func(t *testing.T, routesfake *routesfake.FakeClient)
+		ExpectedErr error
+	}{
+		"wrong number of args": {
+			Space:       "some-space",
+			Args:        []string{"example.com", "extra", "--timeout=1s"},
+			ExpectedErr: errors.New("accepts 1 arg(s), received 2"),
+		},
+		"without space": {
+			Args:        []string{"example.com", "--timeout=1s"},
+			ExpectedErr: errors.New(config.EmptySpaceError),
+		},
+		"no policy flags": {
+			Space:       "some-space",
+			Args:        []string{"example.com", "--hostname=myapp"},
+			ExpectedErr: errors.New("at least one policy flag must be set"),
+		},
+		"remove cors with other cors flags": {
+			Space:       "some-space",
+			Args:        []string{"example.com", "--hostname=myapp", "--remove-cors", "--cors-allow-origin=*"},
+			ExpectedErr: errors.New("--remove-cors can't be combined with other CORS flags"),
+		},
+		"updating route fails": {
+			Space: "some-space",
+			Args:  []string{"example.com", "--hostname=myapp", "--timeout=1s"},
+			Setup: func(t *testing.T, routesfake *routesfake.FakeClient) {
+				routesfake.EXPECT().
+					Transform(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("some-error"))
+			},
+			ExpectedErr: errors.New("failed to update Route: some-error"),
+		},
+		"sets timeout and retries": {
+			Space: "some-space",
+			Args: []string{
+				"example.com", "--hostname=myapp",
+				"--timeout=30s", "--retries=3", "--retry-timeout=5s", "--retry-on=5xx,connect-failure",
+			},
+			Setup: expectTransform(nil, &v1alpha1.RoutePolicy{
+				Timeout: &metav1.Duration{Duration: 30 * time.Second},
+				Retries: &v1alpha1.RouteRetryPolicy{
+					Attempts:      3,
+					PerTryTimeout: &metav1.Duration{Duration: 5 * time.Second},
+					RetryOn:       []string{"5xx", "connect-failure"},
+				},
+			}),
+		},
+		"sets cors": {
+			Space: "some-space",
+			Args: []string{
+				"example.com", "--hostname=myapp",
+				"--cors-allow-origin=*", "--cors-allow-method=GET,POST", "--cors-max-age=1h", "--cors-allow-credentials",
+			},
+			Setup: expectTransform(nil, &v1alpha1.RoutePolicy{
+				CORS: &v1alpha1.RouteCORSPolicy{
+					AllowOrigins:     []string{"*"},
+					AllowMethods:     []string{"GET", "POST"},
+					MaxAge:           &metav1.Duration{Duration: time.Hour},
+					AllowCredentials: true,
+				},
+			}),
+		},
+		"merges headers with existing policy": {
+			Space: "some-space",
+			Args: []string{
+				"example.com", "--hostname=myapp",
+				"--add-request-header=X-Env=prod", "--remove-request-header=X-Old",
+				"--remove-response-header=Server",
+			},
+			Setup: expectTransform(
+				&v1alpha1.RoutePolicy{
+					Timeout: &metav1.Duration{Duration: time.Second},
+					RequestHeaders: &v1alpha1.RouteHeaderOperations{
+						Add: map[string]string{"X-Old": "old"},
+					},
+				},
+				&v1alpha1.RoutePolicy{
+					Timeout: &metav1.Duration{Duration: time.Second},
+					RequestHeaders: &v1alpha1.RouteHeaderOperations{
+						Add:    map[string]string{"X-Env": "prod"},
+						Remove: []string{"X-Old"},
+					},
+					ResponseHeaders: &v1alpha1.RouteHeaderOperations{
+						Remove: []string{"Server"},
+					},
+				},
+			),
+		},
+		"sets rate limit": {
+			Space: "some-space",
+			Args:  []string{"example.com", "--hostname=myapp", "--rate-limit=100", "--rate-limit-header=X-Api-Key"},
+			Setup: expectTransform(
+				nil,
+				&v1alpha1.RoutePolicy{
+					RateLimit: &v1alpha1.RouteRateLimit{
+						RequestsPerSecond: 100,
+						Header:            "X-Api-Key",
+					},
+				},
+			),
+		},
+		"zero rate limit removes it": {
+			Space: "some-space",
+			Args:  []string{"example.com", "--hostname=myapp", "--rate-limit=0"},
+			Setup: expectTransform(
+				&v1alpha1.RoutePolicy{
+					RateLimit: &v1alpha1.RouteRateLimit{RequestsPerSecond: 10},
+				},
+				nil,
+			),
+		},
+		"zero timeout removes it": {
+			Space: "some-space",
+			Args:  []string{"example.com", "--hostname=myapp", "--timeout=0s"},
+			Setup: expectTransform(
+				&v1alpha1.RoutePolicy{
+					Timeout: &metav1.Duration{Duration: time.Second},
+				},
+				nil,
+			),
+		},
+		"clear policy": {
+			Space: "some-space",
+			Args:  []string{"example.com", "--hostname=myapp", "--clear-policy", "--retries=0"},
+			Setup: expectTransform(
+				&v1alpha1.RoutePolicy{
+					Timeout: &metav1.Duration{Duration: time.Second},
+					CORS:    &v1alpha1.RouteCORSPolicy{AllowOrigins: []string{"*"}},
+				},
+				&v1alpha1.RoutePolicy{
+					Retries: &v1alpha1.RouteRetryPolicy{},
+				},
+			),
+		},
+		"remove cors": {
+			Space: "some-space",
+			Args:  []string{"example.com", "--hostname=myapp", "--remove-cors"},
+			Setup: expectTransform(
+				&v1alpha1.RoutePolicy{
+					CORS: &v1alpha1.RouteCORSPolicy{AllowOrigins: []string{"*"}},
+				},
+				nil,
+			),
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			routesfake := routesfake.NewFakeClient(ctrl)
+
+			if tc.Setup != nil {
+				tc.Setup(t, routesfake)
+			}
+
+			var buffer bytes.Buffer
+			cmd := routes.NewUpdateRouteCommand(
+				&config.KfParams{
+					Space: tc.Space,
+				},
+				routesfake,
+			)
+			cmd.SetArgs(tc.Args)
+			cmd.SetOutput(&buffer)
+
+			_, err := cmd.ExecuteC()
+			testutil.AssertErrorsEqual(t, tc.ExpectedErr, err)
+		})
+	}
+}
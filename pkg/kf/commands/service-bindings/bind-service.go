@@ -20,13 +20,16 @@ import (
 	"time"
 
 	v1alpha1 "github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/internal/osbutil"
 	"github.com/google/kf/v2/pkg/kf/apps"
 	"github.com/google/kf/v2/pkg/kf/commands/completion"
 	"github.com/google/kf/v2/pkg/kf/commands/config"
 	"github.com/google/kf/v2/pkg/kf/describe"
 	utils "github.com/google/kf/v2/pkg/kf/internal/utils/cli"
+	"github.com/google/kf/v2/pkg/kf/marketplace"
 	"github.com/google/kf/v2/pkg/kf/secrets"
 	"github.com/google/kf/v2/pkg/kf/serviceinstancebindings"
+	"github.com/google/kf/v2/pkg/kf/serviceinstances"
 	"github.com/spf13/cobra"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -35,10 +38,18 @@ import (
 )
 
 // NewBindServiceCommand allows users to bind apps to service instances.
-func NewBindServiceCommand(p *config.KfParams, client serviceinstancebindings.Client, secretsClient secrets.Client, appClient apps.Client) *cobra.Command {
+func NewBindServiceCommand(
+	p *config.KfParams,
+	client serviceinstancebindings.Client,
+	secretsClient secrets.Client,
+	appClient apps.Client,
+	instancesClient serviceinstances.Client,
+	marketplaceClient marketplace.ClientInterface,
+) *cobra.Command {
 	var (
 		bindingOverride string
 		configAsJSON    string
+		serviceSpace    string
 		async           utils.AsyncFlags
 		timeout         time.Duration
 	)
@@ -75,6 +86,34 @@ func NewBindServiceCommand(p *config.KfParams, client serviceinstancebindings.Cl
 				return err
 			}
 
+			instanceSpace := p.Space
+			if serviceSpace != "" {
+				instanceSpace = serviceSpace
+			}
+
+			// Check the parameters against the plan's schema so errors are reported
+			// before the broker is contacted. Only given parameters are checked so
+			// binding with the defaults doesn't need to read the instance.
+			if cmd.Flags().Changed("parameters") {
+				instance, err := instancesClient.Get(ctx, instanceSpace, instanceName)
+				if err != nil {
+					return fmt.Errorf("failed to get service instance for binding: %s", err)
+				}
+
+				if instance.IsKfBrokered() {
+					catalog, err := marketplaceClient.Marketplace(ctx, instanceSpace)
+					if err != nil {
+						return err
+					}
+
+					if lineage := catalog.FindPlanForInstance(instance); lineage != nil && lineage.ServicePlan.Schemas != nil {
+						if err := osbutil.ValidateParameters(lineage.ServicePlan.Schemas.BindingCreate, paramBytes); err != nil {
+							return err
+						}
+					}
+				}
+			}
+
 			paramsSecretName := v1alpha1.MakeServiceBindingParamsSecretName(appName, instanceName)
 
 			desiredBinding := &v1alpha1.ServiceInstanceBinding{
@@ -94,6 +133,7 @@ func NewBindServiceCommand(p *config.KfParams, client serviceinstancebindings.Cl
 					InstanceRef: v1.LocalObjectReference{
 						Name: instanceName,
 					},
+					InstanceSpace: serviceSpace,
 					ParametersFrom: v1.LocalObjectReference{
 						Name: paramsSecretName,
 					},
@@ -151,6 +191,12 @@ func NewBindServiceCommand(p *config.KfParams, client serviceinstancebindings.Cl
 		"",
 		"Name of the binding injected into the app, defaults to the service instance name.")
 
+	createCmd.Flags().StringVar(
+		&serviceSpace,
+		"service-space",
+		"",
+		"Space of a service instance shared with the App's Space. Defaults to the App's Space.")
+
 	createCmd.Flags().DurationVar(
 		&timeout,
 		"timeout",
@@ -27,19 +27,25 @@ import (
 	"github.com/google/kf/v2/pkg/kf/commands/config"
 	configlogging "github.com/google/kf/v2/pkg/kf/commands/config/logging"
 	servicebindingscmd "github.com/google/kf/v2/pkg/kf/commands/service-bindings"
+	"github.com/google/kf/v2/pkg/kf/marketplace"
+	marketplacefake "github.com/google/kf/v2/pkg/kf/marketplace/fake"
 	secretsfake "github.com/google/kf/v2/pkg/kf/secrets/fake"
 	serviceinstancebindingsfake "github.com/google/kf/v2/pkg/kf/serviceinstancebindings/fake"
+	serviceinstancesfake "github.com/google/kf/v2/pkg/kf/serviceinstances/fake"
 	"github.com/google/kf/v2/pkg/kf/testutil"
 	v1 "k8s.io/api/core/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"knative.dev/pkg/ptr"
 )
 
 type fakes struct {
-	servicebindings *serviceinstancebindingsfake.FakeClient
-	secrets         *secretsfake.FakeClient
-	apps            *appsfake.FakeClient
+	servicebindings  *serviceinstancebindingsfake.FakeClient
+	secrets          *secretsfake.FakeClient
+	apps             *appsfake.FakeClient
+	serviceinstances *serviceinstancesfake.FakeClient
+	marketplace      *marketplacefake.FakeClientInterface
 }
 
 type bindingTest struct {
@@ -57,12 +63,16 @@ func runBindingTest(t *testing.T, tc bindingTest) {
 	sbClient := serviceinstancebindingsfake.NewFakeClient(ctrl)
 	secretClient := secretsfake.NewFakeClient(ctrl)
 	appsClient := appsfake.NewFakeClient(ctrl)
+	instancesClient := serviceinstancesfake.NewFakeClient(ctrl)
+	marketplaceClient := marketplacefake.NewFakeClientInterface(ctrl)
 
 	if tc.Setup != nil {
 		tc.Setup(t, fakes{
-			servicebindings: sbClient,
-			secrets:         secretClient,
-			apps:            appsClient,
+			servicebindings:  sbClient,
+			secrets:          secretClient,
+			apps:             appsClient,
+			serviceinstances: instancesClient,
+			marketplace:      marketplaceClient,
 		})
 	}
 
@@ -72,7 +82,7 @@ func runBindingTest(t *testing.T, tc bindingTest) {
 	}
 	ctx := configlogging.SetupLogger(context.Background(), buf)
 
-	cmd := servicebindingscmd.NewBindServiceCommand(p, sbClient, secretClient, appsClient)
+	cmd := servicebindingscmd.NewBindServiceCommand(p, sbClient, secretClient, appsClient, instancesClient, marketplaceClient)
 	cmd.SetOutput(buf)
 	cmd.SetArgs(tc.Args)
 	cmd.SetContext(ctx)
@@ -102,6 +112,46 @@ func TestNewBindServiceCommand(t *testing.T) {
 		},
 	}
 
+	userProvidedInstance := &v1alpha1.ServiceInstance{
+		Spec: v1alpha1.ServiceInstanceSpec{
+			ServiceType: v1alpha1.ServiceType{
+				UPS: &v1alpha1.UPSInstance{},
+			},
+		},
+	}
+
+	broker := &v1alpha1.ClusterServiceBroker{}
+	broker.Name = "some-broker"
+	broker.Status.Services = []v1alpha1.ServiceOffering{
+		{
+			DisplayName: "db-service",
+			UID:         "db-service-uid",
+			Plans: []v1alpha1.ServicePlan{
+				{
+					DisplayName: "small",
+					UID:         "small-uid",
+					Schemas: &v1alpha1.ServicePlanSchemas{
+						BindingCreate: &runtime.RawExtension{
+							Raw: []byte(`{"type":"object","properties":{"role":{"enum":["reader","writer"]}}}`),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	brokeredInstance := &v1alpha1.ServiceInstance{
+		Spec: v1alpha1.ServiceInstanceSpec{
+			ServiceType: v1alpha1.ServiceType{
+				OSB: &v1alpha1.OSBInstance{
+					BrokerName: "some-broker",
+					ClassUID:   "db-service-uid",
+					PlanUID:    "small-uid",
+				},
+			},
+		},
+	}
+
 	cases := map[string]bindingTest{
 		"wrong number of args": {
 			Args:        []string{},
@@ -140,6 +190,7 @@ func TestNewBindServiceCommand(t *testing.T) {
 				fakes.servicebindings.EXPECT().WaitForConditionReadyTrue(gomock.Any(), "custom-ns",
 					bindingName, gomock.Any())
 				fakes.apps.EXPECT().Get(gomock.Any(), "custom-ns", "APP_NAME").Return(sampleApp, nil)
+				fakes.serviceinstances.EXPECT().Get(gomock.Any(), "custom-ns", "SERVICE_INSTANCE").Return(userProvidedInstance, nil)
 			},
 			ExpectedStrings: []string{"Success", "kf restart"},
 		},
@@ -180,6 +231,40 @@ func TestNewBindServiceCommand(t *testing.T) {
 				fakes.apps.EXPECT().Get(gomock.Any(), "custom-ns", "APP_NAME").Return(sampleApp, nil)
 			},
 		},
+		"binds shared service instance": {
+			Args:  []string{"APP_NAME", "SERVICE_INSTANCE", "--service-space=other-space"},
+			Space: "custom-ns",
+			Setup: func(t *testing.T, fakes fakes) {
+				bindingName := v1alpha1.MakeServiceBindingName("APP_NAME", "SERVICE_INSTANCE")
+				secretName := v1alpha1.MakeServiceBindingParamsSecretName("APP_NAME", "SERVICE_INSTANCE")
+				fakes.servicebindings.EXPECT().Create(gomock.Any(), "custom-ns", &v1alpha1.ServiceInstanceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            bindingName,
+						Namespace:       "custom-ns",
+						OwnerReferences: ownerRefs,
+					},
+					Spec: v1alpha1.ServiceInstanceBindingSpec{
+						BindingType: v1alpha1.BindingType{
+							App: &v1alpha1.AppRef{
+								Name: "APP_NAME",
+							},
+						},
+						InstanceRef: v1.LocalObjectReference{
+							Name: "SERVICE_INSTANCE",
+						},
+						InstanceSpace: "other-space",
+						ParametersFrom: v1.LocalObjectReference{
+							Name: secretName,
+						},
+						ProgressDeadlineSeconds: v1alpha1.DefaultServiceInstanceBindingProgressDeadlineSeconds,
+					},
+				})
+				fakes.secrets.EXPECT().CreateParamsSecret(gomock.Any(), gomock.Any(), secretName, json.RawMessage("{}"))
+				fakes.servicebindings.EXPECT().WaitForConditionReadyTrue(gomock.Any(), "custom-ns",
+					bindingName, gomock.Any())
+				fakes.apps.EXPECT().Get(gomock.Any(), "custom-ns", "APP_NAME").Return(sampleApp, nil)
+			},
+		},
 		"bad config path": {
 			Args:  []string{"APP_NAME", "SERVICE_INSTANCE", `-c=/some/bad/path`},
 			Space: "custom-ns",
@@ -205,6 +290,40 @@ func TestNewBindServiceCommand(t *testing.T) {
 			},
 			ExpectedErr: errors.New("failed to get App for binding: apps.kf.dev \"APP_NAME\" not found"),
 		},
+		"service instance doesn't exist": {
+			Args:  []string{"APP_NAME", "SERVICE_INSTANCE", `-c={"role":"reader"}`},
+			Space: "custom-ns",
+			Setup: func(t *testing.T, fakes fakes) {
+				fakes.apps.EXPECT().Get(gomock.Any(), "custom-ns", "APP_NAME").Return(sampleApp, nil)
+				fakes.serviceinstances.EXPECT().Get(gomock.Any(), "custom-ns", "SERVICE_INSTANCE").Return(nil, apierrs.NewNotFound(v1alpha1.Resource("serviceinstances"), "SERVICE_INSTANCE"))
+			},
+			ExpectedErr: errors.New("failed to get service instance for binding: serviceinstances.kf.dev \"SERVICE_INSTANCE\" not found"),
+		},
+		"params don't match plan schema": {
+			Args:  []string{"APP_NAME", "SERVICE_INSTANCE", `-c={"role":"admin"}`},
+			Space: "custom-ns",
+			Setup: func(t *testing.T, fakes fakes) {
+				fakes.apps.EXPECT().Get(gomock.Any(), "custom-ns", "APP_NAME").Return(sampleApp, nil)
+				fakes.serviceinstances.EXPECT().Get(gomock.Any(), "custom-ns", "SERVICE_INSTANCE").Return(brokeredInstance, nil)
+				fakes.marketplace.EXPECT().Marketplace(gomock.Any(), "custom-ns").Return(&marketplace.KfMarketplace{
+					Brokers: []v1alpha1.CommonServiceBroker{broker},
+				}, nil)
+			},
+			ExpectedErr: errors.New("invalid parameters: role should be one of [reader writer]"),
+		},
+		"params match plan schema": {
+			Args:  []string{"--async", "APP_NAME", "SERVICE_INSTANCE", `-c={"role":"reader"}`},
+			Space: "custom-ns",
+			Setup: func(t *testing.T, fakes fakes) {
+				fakes.apps.EXPECT().Get(gomock.Any(), "custom-ns", "APP_NAME").Return(sampleApp, nil)
+				fakes.serviceinstances.EXPECT().Get(gomock.Any(), "custom-ns", "SERVICE_INSTANCE").Return(brokeredInstance, nil)
+				fakes.marketplace.EXPECT().Marketplace(gomock.Any(), "custom-ns").Return(&marketplace.KfMarketplace{
+					Brokers: []v1alpha1.CommonServiceBroker{broker},
+				}, nil)
+				fakes.servicebindings.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any())
+				fakes.secrets.EXPECT().CreateParamsSecret(gomock.Any(), gomock.Any(), gomock.Any(), json.RawMessage(`{"role":"reader"}`))
+			},
+		},
 		"async": {
 			Args:  []string{"--async", "APP_NAME", "SERVICE_INSTANCE"},
 			Space: "default",
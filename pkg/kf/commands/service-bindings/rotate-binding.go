@@ -0,0 +1,145 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicebindings
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	v1alpha1 "github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/commands/completion"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	utils "github.com/google/kf/v2/pkg/kf/internal/utils/cli"
+	"github.com/google/kf/v2/pkg/kf/serviceinstancebindings"
+	"github.com/spf13/cobra"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/logging"
+)
+
+// NewRotateBindingCommand allows users to rotate the credentials of a binding.
+func NewRotateBindingCommand(p *config.KfParams, client serviceinstancebindings.Client) *cobra.Command {
+	var (
+		async       utils.AsyncFlags
+		gracePeriod time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "rotate-binding APP_NAME SERVICE_INSTANCE [--grace-period DURATION]",
+		Short: "Replace the credentials an App uses for a service instance.",
+		Long: `Rotate binding requests new credentials from the service broker that
+		created the instance without unbinding it.
+
+		Once the new credentials are ready, the App's VCAP_SERVICES environment
+		variable is updated and its instances are replaced. The previous
+		credentials are unbound after the grace period so instances still using
+		them have time to restart.
+
+		Only bindings to service instances created by a service broker can be
+		rotated.
+		`,
+		Example: `
+		kf rotate-binding myapp mydb
+		kf rotate-binding myapp mydb --grace-period 30m
+		`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completion.AppCompletionFn(p),
+		SilenceUsage:      true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			logger := logging.FromContext(ctx)
+			appName := args[0]
+			instanceName := args[1]
+			bindingName := v1alpha1.MakeServiceBindingName(appName, instanceName)
+
+			if err := p.ValidateSpaceTargeted(); err != nil {
+				return err
+			}
+
+			if gracePeriod < 0 {
+				return errors.New("--grace-period can't be negative")
+			}
+
+			mutator := func(b *v1alpha1.ServiceInstanceBinding) error {
+				if b.Status.OSBStatus.Bound == nil {
+					return fmt.Errorf("binding %q doesn't have credentials from a service broker to rotate", bindingName)
+				}
+
+				b.Spec.RotateRequests++
+				if cmd.Flags().Changed("grace-period") {
+					b.Spec.RotationGracePeriodSeconds = int64(gracePeriod.Seconds())
+				}
+
+				return nil
+			}
+
+			binding, err := client.Transform(ctx, p.Space, bindingName, mutator)
+			if err != nil {
+				return fmt.Errorf("Failed to request credential rotation: %s", err)
+			}
+
+			rotateRequests := binding.Spec.RotateRequests
+			action := fmt.Sprintf("Rotating credentials of binding %q in Space %q", bindingName, p.Space)
+			return async.AwaitAndLog(cmd.ErrOrStderr(), action, func() error {
+				rotated, err := client.WaitFor(ctx, p.Space, bindingName, 1*time.Second, isRotationDone(rotateRequests))
+				if err != nil {
+					return fmt.Errorf("rotation failed: %s", err)
+				}
+
+				for _, t := range []apis.ConditionType{
+					v1alpha1.ServiceInstanceBindingConditionReady,
+					v1alpha1.ServiceInstanceBindingConditionCredentialsRotated,
+				} {
+					if cond := rotated.Status.GetCondition(t); cond.IsFalse() {
+						return fmt.Errorf("rotation failed: %s", cond.Message)
+					}
+				}
+
+				logger.Infof("App %q is restarting with the new credentials", appName)
+				logger.Infof("The previous credentials will be unbound in %v", rotated.RotationGracePeriod())
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().DurationVar(
+		&gracePeriod,
+		"grace-period",
+		0,
+		`Amount of time the previous credentials stay valid after the new ones are ready. Defaults to 10m. Valid units are "s", "m", "h".`,
+	)
+
+	async.Add(cmd)
+
+	return cmd
+}
+
+// isRotationDone returns a predicate that checks whether the binding has
+// finished processing the given rotation request, either by writing the new
+// credentials or by failing.
+func isRotationDone(rotateRequests int) serviceinstancebindings.Predicate {
+	return func(b *v1alpha1.ServiceInstanceBinding) bool {
+		if b.Status.RotateRequests < rotateRequests {
+			return false
+		}
+
+		if b.Status.GetCondition(v1alpha1.ServiceInstanceBindingConditionReady).IsFalse() ||
+			b.Status.GetCondition(v1alpha1.ServiceInstanceBindingConditionCredentialsRotated).IsFalse() {
+			return true
+		}
+
+		return !b.Status.IsRotating() && !b.Status.IsWaitingForRotatedCredentials()
+	}
+}
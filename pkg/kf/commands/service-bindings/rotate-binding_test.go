@@ -0,0 +1,190 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicebindings_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	v1alpha1 "github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	servicebindingscmd "github.com/google/kf/v2/pkg/kf/commands/service-bindings"
+	"github.com/google/kf/v2/pkg/kf/serviceinstancebindings"
+	serviceinstancebindingsfake "github.com/google/kf/v2/pkg/kf/serviceinstancebindings/fake"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	osbclient "sigs.k8s.io/go-open-service-broker-client/v2"
+)
+
+func runRotateTest(t *testing.T, tc bindingTest) {
+	ctrl := gomock.NewController(t)
+
+	sbClient := serviceinstancebindingsfake.NewFakeClient(ctrl)
+
+	if tc.Setup != nil {
+		tc.Setup(t, fakes{
+			servicebindings: sbClient,
+		})
+	}
+
+	buf := new(bytes.Buffer)
+	p := &config.KfParams{
+		Space: tc.Space,
+	}
+
+	cmd := servicebindingscmd.NewRotateBindingCommand(p, sbClient)
+	cmd.SetOutput(buf)
+	cmd.SetArgs(tc.Args)
+	_, actualErr := cmd.ExecuteC()
+	if tc.ExpectedErr != nil || actualErr != nil {
+		testutil.AssertErrorsEqual(t, tc.ExpectedErr, actualErr)
+		return
+	}
+
+	testutil.AssertContainsAll(t, buf.String(), tc.ExpectedStrings)
+}
+
+func boundBinding() *v1alpha1.ServiceInstanceBinding {
+	binding := &v1alpha1.ServiceInstanceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      v1alpha1.MakeServiceBindingName("my-app", "my-db"),
+			Namespace: "custom-ns",
+			UID:       "binding-uid",
+		},
+	}
+	binding.Status.InitializeConditions()
+	binding.Status.PropagateBindStatus(&osbclient.BindResponse{}, nil)
+
+	return binding
+}
+
+func expectRotate(t *testing.T, fakes fakes, binding *v1alpha1.ServiceInstanceBinding) {
+	fakes.servicebindings.EXPECT().
+		Transform(gomock.Any(), "custom-ns", binding.Name, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ string, m serviceinstancebindings.Mutator) (*v1alpha1.ServiceInstanceBinding, error) {
+			if err := m(binding); err != nil {
+				return nil, err
+			}
+			return binding, nil
+		})
+}
+
+func TestNewRotateBindingCommand(t *testing.T) {
+	cases := map[string]bindingTest{
+		"wrong number of args": {
+			Args:        []string{},
+			ExpectedErr: errors.New("accepts 2 arg(s), received 0"),
+		},
+		"empty namespace": {
+			Args:        []string{"my-app", "my-db"},
+			ExpectedErr: errors.New(config.EmptySpaceError),
+		},
+		"negative grace period": {
+			Args:        []string{"my-app", "my-db", "--grace-period=-1m"},
+			Space:       "custom-ns",
+			ExpectedErr: errors.New("--grace-period can't be negative"),
+		},
+		"binding without broker credentials": {
+			Args:  []string{"my-app", "my-db", "--async"},
+			Space: "custom-ns",
+			Setup: func(t *testing.T, fakes fakes) {
+				binding := boundBinding()
+				binding.Status.OSBStatus = v1alpha1.BindingOSBStatus{}
+				expectRotate(t, fakes, binding)
+			},
+			ExpectedErr: errors.New(`Failed to request credential rotation: binding "binding-my-app-my-db" doesn't have credentials from a service broker to rotate`),
+		},
+		"async requests rotation": {
+			Args:  []string{"my-app", "my-db", "--async"},
+			Space: "custom-ns",
+			Setup: func(t *testing.T, fakes fakes) {
+				binding := boundBinding()
+				expectRotate(t, fakes, binding)
+				t.Cleanup(func() {
+					testutil.AssertEqual(t, "RotateRequests", 1, binding.Spec.RotateRequests)
+					testutil.AssertEqual(t, "RotationGracePeriodSeconds", int64(0), binding.Spec.RotationGracePeriodSeconds)
+				})
+			},
+		},
+		"custom grace period": {
+			Args:  []string{"my-app", "my-db", "--async", "--grace-period=30m"},
+			Space: "custom-ns",
+			Setup: func(t *testing.T, fakes fakes) {
+				binding := boundBinding()
+				binding.Spec.RotateRequests = 3
+				expectRotate(t, fakes, binding)
+				t.Cleanup(func() {
+					testutil.AssertEqual(t, "RotateRequests", 4, binding.Spec.RotateRequests)
+					testutil.AssertEqual(t, "RotationGracePeriodSeconds", int64(1800), binding.Spec.RotationGracePeriodSeconds)
+				})
+			},
+		},
+		"waits for rotation": {
+			Args:  []string{"my-app", "my-db"},
+			Space: "custom-ns",
+			Setup: func(t *testing.T, fakes fakes) {
+				binding := boundBinding()
+				expectRotate(t, fakes, binding)
+
+				fakes.servicebindings.EXPECT().
+					WaitFor(gomock.Any(), "custom-ns", binding.Name, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _, _ string, _ time.Duration, done serviceinstancebindings.Predicate) (*v1alpha1.ServiceInstanceBinding, error) {
+						testutil.AssertFalse(t, "done before reconcile", done(binding))
+
+						binding.Status.PropagateRotationStarted(1, "binding-uid", "new-id")
+						testutil.AssertFalse(t, "done while rotating", done(binding))
+
+						binding.Status.PropagateBindStatus(&osbclient.BindResponse{}, nil)
+						testutil.AssertFalse(t, "done before credentials are written", done(binding))
+
+						unbindAfter := metav1.Now()
+						binding.Status.PreviousBindingUnbindAfter = &unbindAfter
+						testutil.AssertTrue(t, "done after credentials are written", done(binding))
+
+						return binding, nil
+					})
+			},
+		},
+		"rotation fails": {
+			Args:  []string{"my-app", "my-db"},
+			Space: "custom-ns",
+			Setup: func(t *testing.T, fakes fakes) {
+				binding := boundBinding()
+				expectRotate(t, fakes, binding)
+
+				fakes.servicebindings.EXPECT().
+					WaitFor(gomock.Any(), "custom-ns", binding.Name, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _, _ string, _ time.Duration, done serviceinstancebindings.Predicate) (*v1alpha1.ServiceInstanceBinding, error) {
+						binding.Status.PropagateRotationStarted(1, "binding-uid", "new-id")
+						binding.Status.PropagateBindStatus(nil, errors.New("broker-error"))
+						testutil.AssertTrue(t, "done after failure", done(binding))
+
+						return binding, nil
+					})
+			},
+			ExpectedErr: errors.New("rotation failed: broker-error"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			runRotateTest(t, tc)
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	v1alpha1 "github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/internal/osbutil"
 	"github.com/google/kf/v2/pkg/kf/commands/config"
 	"github.com/google/kf/v2/pkg/kf/describe"
 	utils "github.com/google/kf/v2/pkg/kf/internal/utils/cli"
@@ -111,6 +112,12 @@ func NewCreateServiceCommand(p *config.KfParams, client serviceinstances.Client,
 				return fmt.Errorf("no plan %s found for class %s for all service-brokers", planName, serviceName)
 			}
 
+			if schemas := lineage.ServicePlan.Schemas; schemas != nil {
+				if err := osbutil.ValidateParameters(schemas.InstanceCreate, paramBytes); err != nil {
+					return err
+				}
+			}
+
 			tagSet := sets.NewString(lineage.ServiceOffering.Tags...)
 			tagSet.Insert(utils.SplitTags(tags)...)
 			mergedTags := tagSet.List()
@@ -31,6 +31,7 @@ import (
 	"github.com/google/kf/v2/pkg/kf/testutil"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func TestNewCreateServiceCommand(t *testing.T) {
@@ -67,6 +68,15 @@ func TestNewCreateServiceCommand(t *testing.T) {
 			Tags:        []string{"cluster", "db"},
 			Plans: []v1alpha1.ServicePlan{
 				{DisplayName: "free", UID: mockPlanUID},
+				{
+					DisplayName: "sized",
+					UID:         "22222222-2222-2222-2222-222222222222",
+					Schemas: &v1alpha1.ServicePlanSchemas{
+						InstanceCreate: &runtime.RawExtension{
+							Raw: []byte(`{"type":"object","properties":{"size":{"type":"integer"}}}`),
+						},
+					},
+				},
 			},
 		},
 	}
@@ -139,6 +149,16 @@ func TestNewCreateServiceCommand(t *testing.T) {
 			expectErr: errors.New("plans matched from multiple brokers, specify a broker with --broker"),
 		},
 
+		"params don't match plan schema": {
+			namespace: mockNs,
+			args:      []string{"db-service", "sized", "mydb", "-c", `{"size":"large"}`},
+			enableOSB: true,
+			setup: func(t *testing.T, fakes fakes) {
+				fakes.marketplace.EXPECT().Marketplace(gomock.Any(), gomock.Any()).Return(mockMarketplace, nil)
+			},
+			expectErr: errors.New(`invalid parameters: size must be of type integer: "string"`),
+		},
+
 		// good results
 		"cluster": {
 			namespace: mockNs,
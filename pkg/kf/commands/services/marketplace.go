@@ -15,21 +15,29 @@
 package services
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
 	"github.com/google/kf/v2/pkg/kf/commands/config"
 	"github.com/google/kf/v2/pkg/kf/describe"
 	"github.com/google/kf/v2/pkg/kf/marketplace"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // NewMarketplaceCommand allows users to get a service instance.
 func NewMarketplaceCommand(p *config.KfParams, marketplaceClient marketplace.ClientInterface) *cobra.Command {
-	var serviceName string
+	var (
+		serviceName string
+		planName    string
+	)
 
 	marketplaceCommand := &cobra.Command{
-		Use:     "marketplace [-s SERVICE]",
+		Use:     "marketplace [-s SERVICE [--plan PLAN]]",
 		Aliases: []string{"m"},
 		Short:   "List service classes available in the cluster.",
 		Example: `
@@ -38,6 +46,9 @@ func NewMarketplaceCommand(p *config.KfParams, marketplaceClient marketplace.Cli
 
 		# Show the plans available to a particular service class
 		kf marketplace -s google-storage
+
+		# Show the parameters a plan accepts
+		kf marketplace -s google-storage --plan standard
 		`,
 		Args:         cobra.ExactArgs(0),
 		SilenceUsage: true,
@@ -47,11 +58,34 @@ func NewMarketplaceCommand(p *config.KfParams, marketplaceClient marketplace.Cli
 				return err
 			}
 
+			if planName != "" && serviceName == "" {
+				return errors.New("--plan requires --service")
+			}
+
 			catalog, err := marketplaceClient.Marketplace(cmd.Context(), p.Space)
 			if err != nil {
 				return err
 			}
 
+			if planName != "" {
+				var plans []marketplace.PlanLineage
+				catalog.WalkServicePlans(func(lineage marketplace.PlanLineage) {
+					if lineage.ServiceOffering.DisplayName == serviceName && lineage.ServicePlan.DisplayName == planName {
+						plans = append(plans, lineage)
+					}
+				})
+
+				if len(plans) == 0 {
+					return fmt.Errorf("no plan %s found for class %s", planName, serviceName)
+				}
+
+				for _, lineage := range plans {
+					describePlan(cmd.OutOrStdout(), lineage)
+				}
+
+				return nil
+			}
+
 			describe.TabbedWriter(cmd.OutOrStdout(), func(w io.Writer) {
 				if serviceName == "" {
 					fmt.Fprintf(w, "Listing services that can be used in Space %q, use the --service flag to list the plans for a service\n", p.Space)
@@ -95,5 +129,54 @@ func NewMarketplaceCommand(p *config.KfParams, marketplaceClient marketplace.Cli
 		"",
 		"List plans for the service class.")
 
+	marketplaceCommand.Flags().StringVar(
+		&planName,
+		"plan",
+		"",
+		"Show details and parameter schemas for the plan, requires --service.")
+
 	return marketplaceCommand
 }
+
+// describePlan writes information about a plan including the schemas of the
+// parameters it accepts.
+func describePlan(w io.Writer, lineage marketplace.PlanLineage) {
+	plan := lineage.ServicePlan
+
+	describe.TabbedWriter(w, func(w io.Writer) {
+		fmt.Fprintf(w, "Broker:\t%s\n", lineage.Broker.GetName())
+		fmt.Fprintf(w, "Namespace:\t%s\n", lineage.Broker.GetNamespace())
+		fmt.Fprintf(w, "Service:\t%s\n", lineage.ServiceOffering.DisplayName)
+		fmt.Fprintf(w, "Plan:\t%s\n", plan.DisplayName)
+		fmt.Fprintf(w, "Free:\t%t\n", plan.Free)
+		fmt.Fprintf(w, "Description:\t%s\n", plan.Description)
+	})
+
+	schemas := plan.Schemas
+	if schemas == nil {
+		schemas = &v1alpha1.ServicePlanSchemas{}
+	}
+
+	describe.SectionWriter(w, "Parameter Schemas", func(w io.Writer) {
+		schemaSection(w, "Create Service", schemas.InstanceCreate)
+		schemaSection(w, "Update Service", schemas.InstanceUpdate)
+		schemaSection(w, "Bind Service", schemas.BindingCreate)
+	})
+}
+
+// schemaSection writes a JSON schema as indented JSON.
+func schemaSection(w io.Writer, name string, schema *runtime.RawExtension) {
+	describe.SectionWriter(w, name, func(w io.Writer) {
+		if schema == nil || len(schema.Raw) == 0 {
+			return
+		}
+
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, schema.Raw, "", "  "); err != nil {
+			fmt.Fprintln(w, err.Error())
+			return
+		}
+
+		fmt.Fprintln(w, buf.String())
+	})
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/google/kf/v2/pkg/kf/marketplace"
 	"github.com/google/kf/v2/pkg/kf/marketplace/fake"
 	"github.com/google/kf/v2/pkg/kf/testutil"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func TestNewMarketplaceCommand(t *testing.T) {
@@ -44,6 +45,15 @@ func TestNewMarketplaceCommand(t *testing.T) {
 				Plans: []v1alpha1.ServicePlan{
 					{DisplayName: "fake-plan", Description: "description"},
 					{DisplayName: "long-plan", Description: longDescription},
+					{
+						DisplayName: "sized-plan",
+						Description: "sized-description",
+						Schemas: &v1alpha1.ServicePlanSchemas{
+							InstanceCreate: &runtime.RawExtension{
+								Raw: []byte(`{"type":"object","properties":{"size_gb":{"type":"integer"}}}`),
+							},
+						},
+					},
 				},
 			},
 		},
@@ -99,6 +109,33 @@ func TestNewMarketplaceCommand(t *testing.T) {
 			},
 			ExpectedStrings: []string{"long-plan", longDescription},
 		},
+		"plan requires service": {
+			Args:        []string{"--plan=sized-plan"},
+			Space:       "custom-ns",
+			ExpectedErr: errors.New("--plan requires --service"),
+		},
+		"missing plan": {
+			Args:  []string{"--service=fake-service", "--plan=missing"},
+			Space: "custom-ns",
+			Setup: func(t *testing.T, f *fake.FakeClientInterface) {
+				f.EXPECT().Marketplace(gomock.Any(), gomock.Any()).Return(mockMarketplace, nil)
+			},
+			ExpectedErr: errors.New("no plan missing found for class fake-service"),
+		},
+		"command output outputs plan schemas": {
+			Args:  []string{"--service=fake-service", "--plan=sized-plan"},
+			Space: "custom-ns",
+			Setup: func(t *testing.T, f *fake.FakeClientInterface) {
+				f.EXPECT().Marketplace(gomock.Any(), gomock.Any()).Return(mockMarketplace, nil)
+			},
+			ExpectedStrings: []string{
+				"sized-description",
+				"Create Service:",
+				`"size_gb": {`,
+				"Update Service: <empty>",
+				"Bind Service: <empty>",
+			},
+		},
 		"blank marketplace": {
 			Args:  []string{},
 			Space: "custom-ns",
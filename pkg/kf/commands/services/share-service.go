@@ -0,0 +1,116 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/client/kf/injection/client"
+	"github.com/google/kf/v2/pkg/kf/commands/completion"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	utils "github.com/google/kf/v2/pkg/kf/internal/utils/cli"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/logging"
+)
+
+// NewShareServiceCommand allows users to share a service instance with
+// another Space.
+func NewShareServiceCommand(p *config.KfParams) *cobra.Command {
+	var toSpace string
+
+	cmd := &cobra.Command{
+		Use:   "share-service SERVICE_INSTANCE -s SPACE",
+		Short: "Share a service instance with another Space.",
+		Long: `
+		Sharing a service instance allows Apps in the target Space to bind to it
+		using the --service-space flag of bind-service.
+
+		Only Kf managed service instances and non-route user-provided service
+		instances can be shared. A service instance can't be deleted while it's
+		shared.
+		`,
+		Example:      `kf share-service mydb -s other-space`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			if err := p.ValidateSpaceTargeted(); err != nil {
+				return err
+			}
+
+			instanceName := args[0]
+			if toSpace == "" {
+				return errors.New("--to-space is required")
+			}
+
+			if toSpace == p.Space {
+				return errors.New("a service instance can't be shared with the Space that owns it")
+			}
+
+			client := client.Get(ctx)
+
+			if _, err := client.KfV1alpha1().
+				ServiceInstances(p.Space).
+				Get(ctx, instanceName, metav1.GetOptions{}); err != nil {
+				return fmt.Errorf("failed to get service instance: %s", err)
+			}
+
+			desiredShare := &v1alpha1.ServiceInstanceShare{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      v1alpha1.MakeServiceInstanceShareName(instanceName, toSpace),
+					Namespace: p.Space,
+				},
+				Spec: v1alpha1.ServiceInstanceShareSpec{
+					InstanceRef: corev1.LocalObjectReference{
+						Name: instanceName,
+					},
+					TargetSpace: toSpace,
+				},
+			}
+
+			if _, err := client.KfV1alpha1().
+				ServiceInstanceShares(p.Space).
+				Create(ctx, desiredShare, metav1.CreateOptions{}); err != nil {
+				return fmt.Errorf("failed to share service instance: %s", err)
+			}
+
+			logging.FromContext(ctx).Infof("Shared service instance %q with Space %q", instanceName, toSpace)
+			utils.SuggestNextAction(utils.NextAction{
+				Description: "Bind the service instance in the target Space",
+				Commands: []string{
+					fmt.Sprintf("kf bind-service APP_NAME %s --space %s --service-space %s", instanceName, toSpace, p.Space),
+				},
+			})
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(
+		&toSpace,
+		"to-space",
+		"s",
+		"",
+		"Space to share the service instance with.",
+	)
+
+	cmd.RegisterFlagCompletionFunc("to-space", completion.SpaceCompletionFn(p))
+
+	return cmd
+}
@@ -0,0 +1,167 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	fakeclient "github.com/google/kf/v2/pkg/client/kf/injection/client/fake"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	configlogging "github.com/google/kf/v2/pkg/kf/commands/config/logging"
+	"github.com/google/kf/v2/pkg/kf/commands/services"
+	fakeinjection "github.com/google/kf/v2/pkg/kf/injection/fake"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type shareTest struct {
+	name      string
+	space     string
+	args      []string
+	setup     func(ctx context.Context, t *testing.T)
+	assert    func(ctx context.Context, t *testing.T, buffer *bytes.Buffer, err error)
+	expectErr error
+}
+
+func runShareTests(t *testing.T, cases []shareTest, newCommand func(*config.KfParams) *cobra.Command) {
+	t.Helper()
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := newCommand(&config.KfParams{
+				Space: tc.space,
+			})
+
+			var buffer bytes.Buffer
+
+			ctx := fakeinjection.WithInjection(context.Background(), t)
+			ctx = configlogging.SetupLogger(ctx, &buffer)
+
+			cmd.SetContext(ctx)
+			cmd.SetArgs(tc.args)
+			cmd.SetOutput(&buffer)
+
+			if tc.setup != nil {
+				tc.setup(ctx, t)
+			}
+
+			gotErr := cmd.Execute()
+
+			if tc.expectErr != nil {
+				testutil.AssertErrorsEqual(t, tc.expectErr, gotErr)
+			}
+
+			if tc.assert != nil {
+				tc.assert(ctx, t, &buffer, gotErr)
+			}
+		})
+	}
+}
+
+func TestShareService(t *testing.T) {
+	t.Parallel()
+
+	const (
+		spaceName    = "my-space"
+		otherSpace   = "other-space"
+		instanceName = "my-db"
+	)
+
+	instance := &v1alpha1.ServiceInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      instanceName,
+			Namespace: spaceName,
+		},
+	}
+
+	runShareTests(t, []shareTest{
+		{
+			name:      "missing args",
+			expectErr: errors.New("accepts 1 arg(s), received 0"),
+		},
+		{
+			name:      "no target space",
+			args:      []string{instanceName, "--to-space", otherSpace},
+			expectErr: errors.New("no space targeted, use 'kf target --space SPACE' to target a space"),
+		},
+		{
+			name:      "missing to-space",
+			space:     spaceName,
+			args:      []string{instanceName},
+			expectErr: errors.New("--to-space is required"),
+		},
+		{
+			name:      "to-space is the owning Space",
+			space:     spaceName,
+			args:      []string{instanceName, "--to-space", spaceName},
+			expectErr: errors.New("a service instance can't be shared with the Space that owns it"),
+		},
+		{
+			name:      "service instance does not exist",
+			space:     spaceName,
+			args:      []string{instanceName, "--to-space", otherSpace},
+			expectErr: errors.New("failed to get service instance: serviceinstances.kf.dev \"my-db\" not found"),
+		},
+		{
+			name:  "creates share",
+			space: spaceName,
+			args:  []string{instanceName, "-s", otherSpace},
+			setup: func(ctx context.Context, t *testing.T) {
+				fakeclient.Get(ctx).KfV1alpha1().
+					ServiceInstances(spaceName).
+					Create(ctx, instance, metav1.CreateOptions{})
+			},
+			assert: func(ctx context.Context, t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertContainsAll(t, buffer.String(), []string{"Shared service instance", otherSpace})
+
+				share, err := fakeclient.Get(ctx).KfV1alpha1().
+					ServiceInstanceShares(spaceName).
+					Get(ctx, v1alpha1.MakeServiceInstanceShareName(instanceName, otherSpace), metav1.GetOptions{})
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "instanceRef", instanceName, share.Spec.InstanceRef.Name)
+				testutil.AssertEqual(t, "targetSpace", otherSpace, share.Spec.TargetSpace)
+			},
+		},
+		{
+			name:  "already shared",
+			space: spaceName,
+			args:  []string{instanceName, "--to-space", otherSpace},
+			setup: func(ctx context.Context, t *testing.T) {
+				client := fakeclient.Get(ctx)
+				client.KfV1alpha1().
+					ServiceInstances(spaceName).
+					Create(ctx, instance, metav1.CreateOptions{})
+				client.KfV1alpha1().
+					ServiceInstanceShares(spaceName).
+					Create(ctx, &v1alpha1.ServiceInstanceShare{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      v1alpha1.MakeServiceInstanceShareName(instanceName, otherSpace),
+							Namespace: spaceName,
+						},
+					}, metav1.CreateOptions{})
+			},
+			assert: func(ctx context.Context, t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNotNil(t, "err", err)
+				testutil.AssertContainsAll(t, err.Error(), []string{"failed to share service instance", "already exists"})
+			},
+		},
+	}, services.NewShareServiceCommand)
+}
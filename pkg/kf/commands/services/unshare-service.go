@@ -0,0 +1,81 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/client/kf/injection/client"
+	"github.com/google/kf/v2/pkg/kf/commands/completion"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/logging"
+)
+
+// NewUnshareServiceCommand allows users to stop sharing a service instance
+// with another Space.
+func NewUnshareServiceCommand(p *config.KfParams) *cobra.Command {
+	var toSpace string
+
+	cmd := &cobra.Command{
+		Use:   "unshare-service SERVICE_INSTANCE -s SPACE",
+		Short: "Stop sharing a service instance with another Space.",
+		Long: `
+		Unsharing a service instance removes access to it from the target Space.
+		All bindings to the service instance in the target Space must be deleted
+		first.
+		`,
+		Example:      `kf unshare-service mydb -s other-space`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			if err := p.ValidateSpaceTargeted(); err != nil {
+				return err
+			}
+
+			instanceName := args[0]
+			if toSpace == "" {
+				return errors.New("--to-space is required")
+			}
+
+			shareName := v1alpha1.MakeServiceInstanceShareName(instanceName, toSpace)
+			if err := client.Get(ctx).KfV1alpha1().
+				ServiceInstanceShares(p.Space).
+				Delete(ctx, shareName, metav1.DeleteOptions{}); err != nil {
+				return fmt.Errorf("failed to unshare service instance: %s", err)
+			}
+
+			logging.FromContext(ctx).Infof("Unshared service instance %q from Space %q", instanceName, toSpace)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(
+		&toSpace,
+		"to-space",
+		"s",
+		"",
+		"Space to stop sharing the service instance with.",
+	)
+
+	cmd.RegisterFlagCompletionFunc("to-space", completion.SpaceCompletionFn(p))
+
+	return cmd
+}
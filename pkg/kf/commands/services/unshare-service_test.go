@@ -0,0 +1,92 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	fakeclient "github.com/google/kf/v2/pkg/client/kf/injection/client/fake"
+	"github.com/google/kf/v2/pkg/kf/commands/services"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestUnshareService(t *testing.T) {
+	t.Parallel()
+
+	const (
+		spaceName    = "my-space"
+		otherSpace   = "other-space"
+		instanceName = "my-db"
+	)
+
+	shareName := v1alpha1.MakeServiceInstanceShareName(instanceName, otherSpace)
+
+	runShareTests(t, []shareTest{
+		{
+			name:      "missing args",
+			expectErr: errors.New("accepts 1 arg(s), received 0"),
+		},
+		{
+			name:      "no target space",
+			args:      []string{instanceName, "--to-space", otherSpace},
+			expectErr: errors.New("no space targeted, use 'kf target --space SPACE' to target a space"),
+		},
+		{
+			name:      "missing to-space",
+			space:     spaceName,
+			args:      []string{instanceName},
+			expectErr: errors.New("--to-space is required"),
+		},
+		{
+			name:  "not shared",
+			space: spaceName,
+			args:  []string{instanceName, "--to-space", otherSpace},
+			assert: func(ctx context.Context, t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNotNil(t, "err", err)
+				testutil.AssertContainsAll(t, err.Error(), []string{"failed to unshare service instance", "not found"})
+			},
+		},
+		{
+			name:  "deletes share",
+			space: spaceName,
+			args:  []string{instanceName, "--to-space", otherSpace},
+			setup: func(ctx context.Context, t *testing.T) {
+				fakeclient.Get(ctx).KfV1alpha1().
+					ServiceInstanceShares(spaceName).
+					Create(ctx, &v1alpha1.ServiceInstanceShare{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      shareName,
+							Namespace: spaceName,
+						},
+					}, metav1.CreateOptions{})
+			},
+			assert: func(ctx context.Context, t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertContainsAll(t, buffer.String(), []string{"Unshared service instance", otherSpace})
+
+				_, err = fakeclient.Get(ctx).KfV1alpha1().
+					ServiceInstanceShares(spaceName).
+					Get(ctx, shareName, metav1.GetOptions{})
+				testutil.AssertEqual(t, "not found", true, apierrors.IsNotFound(err))
+			},
+		},
+	}, services.NewUnshareServiceCommand)
+}
@@ -30,6 +30,7 @@ import (
 	utils "github.com/google/kf/v2/pkg/kf/internal/utils/cli"
 	"github.com/google/kf/v2/pkg/kf/spaces"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	corev1 "k8s.io/api/core/v1"
 	"knative.dev/pkg/kmp"
 	k8syaml "sigs.k8s.io/yaml"
@@ -99,6 +100,8 @@ type spaceMutator struct {
 	Args        []string
 	ExampleArgs []string
 	Init        func(args []string) (spaces.Mutator, error)
+	// Flags optionally registers extra flags that Init reads.
+	Flags func(flags *pflag.FlagSet)
 }
 
 func (sm spaceMutator) exampleCommands() string {
@@ -152,6 +155,9 @@ func (sm spaceMutator) toCommand(p *config.KfParams, client spaces.Client) *cobr
 		},
 	}
 	async.Add(cmd)
+	if sm.Flags != nil {
+		sm.Flags(cmd.Flags())
+	}
 
 	return cmd
 }
@@ -289,18 +295,34 @@ func newUnsetBuildpackEnvMutator() spaceMutator {
 }
 
 func newAppendDomainMutator() spaceMutator {
+	var gatewayName string
+
 	return spaceMutator{
 		Name:        "append-domain",
 		Short:       "Append a domain for a Space.",
 		Args:        []string{"DOMAIN"},
 		ExampleArgs: []string{"myspace.mycompany.com"},
+		Flags: func(flags *pflag.FlagSet) {
+			flags.StringVar(
+				&gatewayName,
+				"gateway-name",
+				"",
+				"Istio Gateway the domain is served by, defaults to the Kf ingress gateway.",
+			)
+		},
 		Init: func(args []string) (spaces.Mutator, error) {
 			domain := args[0]
 
 			return func(space *v1alpha1.Space) error {
+				for _, existing := range space.Spec.NetworkConfig.Domains {
+					if existing.Domain == domain {
+						return fmt.Errorf("domain %q already exists in Space %q", domain, space.Name)
+					}
+				}
+
 				space.Spec.NetworkConfig.Domains = append(
 					space.Spec.NetworkConfig.Domains,
-					v1alpha1.SpaceDomain{Domain: domain},
+					v1alpha1.SpaceDomain{Domain: domain, GatewayName: gatewayName},
 				)
 
 				return nil
@@ -154,6 +154,14 @@ func TestNewConfigSpaceCommand(t *testing.T) {
 			},
 		},
 
+		"append-domain with gateway": {
+			args: []string{"append-domain", space, "example.com", "--gateway-name", "kf/private-gateway"},
+			validate: func(t *testing.T, space *v1alpha1.Space) {
+				testutil.AssertEqual(t, "len(domains)", 1, len(space.Spec.NetworkConfig.Domains))
+				testutil.AssertEqual(t, "gatewayName", "kf/private-gateway", space.Spec.NetworkConfig.Domains[0].GatewayName)
+			},
+		},
+
 		"set-default-domain valid": {
 			space: v1alpha1.Space{
 				Spec: v1alpha1.SpaceSpec{
@@ -17,7 +17,9 @@ package spaces
 import (
 	"fmt"
 	"io"
+	"time"
 
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
 	"github.com/google/kf/v2/pkg/kf/commands/config"
 	"github.com/google/kf/v2/pkg/kf/describe"
 	utils "github.com/google/kf/v2/pkg/kf/internal/utils/cli"
@@ -45,18 +47,27 @@ func NewDomainsCommand(p *config.KfParams, client spaces.Client) *cobra.Command
 			logging.FromContext(ctx).Infof("Listing domains in Space: %s", p.Space)
 
 			describe.TabbedWriter(cmd.OutOrStdout(), func(w io.Writer) {
-				fmt.Fprintln(w, "Domain\tGateway")
+				fmt.Fprintln(w, "Domain\tGateway\tInternal\tRouter Group\tReservable Ports\tCertificate Expires")
 
 				// Space status has domains in a deterministic order.
 				for _, domain := range space.Status.NetworkConfig.Domains {
-					fmt.Fprintf(w, "%s\t%s\n", domain.Domain, domain.GatewayName)
+					var routerGroupType, reservablePorts string
+					if domain.RouterGroup != nil {
+						routerGroupType = string(domain.RouterGroup.Type)
+						reservablePorts = domain.RouterGroup.ReservablePorts
+					}
+
+					certificateExpires := formatCertificateExpiry(space.Status.NetworkConfig, domain)
+
+					fmt.Fprintf(w, "%s\t%s\t%t\t%s\t%s\t%s\n", domain.Domain, domain.GatewayName, domain.IsInternal(), routerGroupType, reservablePorts, certificateExpires)
 				}
 			})
 
 			utils.SuggestNextAction(utils.NextAction{
 				Description: "Add a domain",
 				Commands: []string{
-					"kf configure-space append-domain",
+					"kf create-private-domain",
+					"kf create-shared-domain",
 				},
 			})
 
@@ -66,3 +77,25 @@ func NewDomainsCommand(p *config.KfParams, client spaces.Client) *cobra.Command
 
 	return cmd
 }
+
+// formatCertificateExpiry returns when the certificate for a TLS domain
+// expires, or why it isn't available yet.
+func formatCertificateExpiry(networkConfig v1alpha1.SpaceStatusNetworkConfig, domain v1alpha1.SpaceDomain) string {
+	if domain.TLS == nil {
+		return ""
+	}
+
+	for _, cert := range networkConfig.Certificates {
+		if cert.Domain != domain.Domain {
+			continue
+		}
+
+		if cert.NotAfter == nil {
+			return cert.Message
+		}
+
+		return cert.NotAfter.UTC().Format(time.RFC3339)
+	}
+
+	return "Unknown"
+}
@@ -19,6 +19,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
@@ -26,6 +27,7 @@ import (
 	configlogging "github.com/google/kf/v2/pkg/kf/commands/config/logging"
 	"github.com/google/kf/v2/pkg/kf/spaces/fake"
 	"github.com/google/kf/v2/pkg/kf/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestNewDomainsCommand(t *testing.T) {
@@ -48,6 +50,35 @@ func TestNewDomainsCommand(t *testing.T) {
 				space.Status.NetworkConfig.Domains = []v1alpha1.SpaceDomain{
 					{Domain: "test.example.com", GatewayName: "kf/external-gateway"},
 					{Domain: "kf.internal", GatewayName: "kf/internal-gateway"},
+					{
+						Domain:      "tcp.example.com",
+						GatewayName: "kf/external-gateway",
+						RouterGroup: &v1alpha1.RouterGroup{
+							Type:            v1alpha1.RouterGroupTypeTCP,
+							ReservablePorts: "1024-1033",
+						},
+					},
+					{
+						Domain:      "secure.example.com",
+						GatewayName: "kf/external-gateway",
+						TLS:         &v1alpha1.SpaceDomainTLS{SecretName: "secure-tls"},
+					},
+					{
+						Domain:      "pending.example.com",
+						GatewayName: "kf/external-gateway",
+						TLS:         &v1alpha1.SpaceDomainTLS{IssuerName: "letsencrypt"},
+					},
+				}
+				space.Status.NetworkConfig.Certificates = []v1alpha1.SpaceDomainCertificate{
+					{
+						Domain:     "secure.example.com",
+						SecretName: "secure-tls",
+						NotAfter:   &metav1.Time{Time: time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)},
+					},
+					{
+						Domain:  "pending.example.com",
+						Message: "Waiting for the certificate to be issued",
+					},
 				}
 
 				fakeSpaces.EXPECT().Get(gomock.Any(), "default").Return(space, nil)
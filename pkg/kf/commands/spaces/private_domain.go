@@ -0,0 +1,45 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spaces
+
+import (
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	"github.com/google/kf/v2/pkg/kf/spaces"
+	"github.com/spf13/cobra"
+)
+
+// NewCreatePrivateDomainCommand creates a command to add a domain to the
+// targeted Space. It's an alias of configure-space append-domain for users
+// familiar with the cf CLI.
+func NewCreatePrivateDomainCommand(p *config.KfParams, client spaces.Client) *cobra.Command {
+	cmd := newAppendDomainMutator().toCommand(p, client)
+	cmd.Use = "create-private-domain DOMAIN [--gateway-name GATEWAY]"
+	cmd.Short = "Add a domain to the targeted Space."
+	cmd.Long = `
+	Private domains can only be used by Routes in the Space that declares
+	them. This is an alias of configure-space append-domain for the targeted
+	Space.
+	`
+	cmd.Example = `
+	kf create-private-domain myspace.example.com
+
+	# Serve the domain from a custom ingress gateway.
+	kf create-private-domain myspace.example.com --gateway-name kf/private-gateway
+	`
+	cmd.Args = cobra.ExactArgs(1)
+	cmd.ValidArgsFunction = nil
+
+	return cmd
+}
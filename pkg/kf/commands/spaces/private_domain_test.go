@@ -0,0 +1,113 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spaces
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	"github.com/google/kf/v2/pkg/kf/spaces"
+	"github.com/google/kf/v2/pkg/kf/spaces/fake"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+)
+
+func TestNewCreatePrivateDomainCommand(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		space      string
+		args       []string
+		existing   []v1alpha1.SpaceDomain
+		wantErr    error
+		wantDomain []v1alpha1.SpaceDomain
+	}{
+		"invalid number of args": {
+			space:   "my-space",
+			args:    []string{},
+			wantErr: errors.New("accepts 1 arg(s), received 0"),
+		},
+		"no space targeted": {
+			args:    []string{"example.com"},
+			wantErr: errors.New(config.EmptySpaceError),
+		},
+		"space name isn't accepted": {
+			space:   "my-space",
+			args:    []string{"other-space", "example.com"},
+			wantErr: errors.New("accepts 1 arg(s), received 2"),
+		},
+		"adds domain": {
+			space:    "my-space",
+			args:     []string{"new.example.com", "--async"},
+			existing: []v1alpha1.SpaceDomain{{Domain: "example.com"}},
+			wantDomain: []v1alpha1.SpaceDomain{
+				{Domain: "example.com"},
+				{Domain: "new.example.com"},
+			},
+		},
+		"adds domain with gateway": {
+			space:    "my-space",
+			args:     []string{"new.example.com", "--gateway-name", "kf/private-gateway", "--async"},
+			existing: []v1alpha1.SpaceDomain{{Domain: "example.com"}},
+			wantDomain: []v1alpha1.SpaceDomain{
+				{Domain: "example.com"},
+				{Domain: "new.example.com", GatewayName: "kf/private-gateway"},
+			},
+		},
+		"domain already exists": {
+			space:    "my-space",
+			args:     []string{"example.com", "--async"},
+			existing: []v1alpha1.SpaceDomain{{Domain: "example.com"}},
+			wantErr:  errors.New(`domain "example.com" already exists in Space "my-space"`),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fakeSpaces := fake.NewFakeClient(ctrl)
+
+			space := &v1alpha1.Space{}
+			space.Name = tc.space
+			space.Spec.NetworkConfig.Domains = tc.existing
+
+			fakeSpaces.EXPECT().
+				Transform(gomock.Any(), tc.space, gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ string, m spaces.Mutator) (*v1alpha1.Space, error) {
+					if err := m(space); err != nil {
+						return nil, err
+					}
+					return space, nil
+				}).
+				AnyTimes()
+
+			c := NewCreatePrivateDomainCommand(&config.KfParams{Space: tc.space}, fakeSpaces)
+			c.SetOutput(&bytes.Buffer{})
+			c.SetArgs(tc.args)
+
+			gotErr := c.Execute()
+			testutil.AssertErrorsEqual(t, tc.wantErr, gotErr)
+			if gotErr != nil {
+				return
+			}
+
+			testutil.AssertEqual(t, "domains", tc.wantDomain, space.Spec.NetworkConfig.Domains)
+		})
+	}
+}
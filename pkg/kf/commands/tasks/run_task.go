@@ -15,14 +15,23 @@
 package tasks
 
 import (
+	"bufio"
+	"context"
 	"errors"
 	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"time"
 
 	v1alpha1 "github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
 	"github.com/google/kf/v2/pkg/kf/apps"
+	"github.com/google/kf/v2/pkg/kf/builds"
 	"github.com/google/kf/v2/pkg/kf/commands/completion"
 	"github.com/google/kf/v2/pkg/kf/commands/config"
 	utils "github.com/google/kf/v2/pkg/kf/internal/utils/cli"
+	"github.com/google/kf/v2/pkg/kf/logs"
 	"github.com/google/kf/v2/pkg/kf/manifest"
 	"github.com/google/kf/v2/pkg/kf/tasks"
 	"github.com/spf13/cobra"
@@ -33,18 +42,36 @@ import (
 )
 
 // NewRunTaskCommand creates a short-running Task run on a given App.
-func NewRunTaskCommand(p *config.KfParams, client tasks.Client, appClient apps.Client) *cobra.Command {
+func NewRunTaskCommand(p *config.KfParams, client tasks.Client, appClient apps.Client, buildClient builds.Client, tailer logs.Tailer) *cobra.Command {
 	var (
 		command       string
 		name          string
+		wait          bool
+		image         string
+		buildName     string
 		resourceFlags utils.ResourceFlags
+		taskRunFlags  utils.TaskRunFlags
 	)
 	cmd := &cobra.Command{
-		Use:               "run-task APP_NAME",
-		Short:             "Run a short-lived Task on the App.",
-		Example:           `kf run-task my-app --command "sleep 100" --name my-task`,
-		Args:              cobra.ExactArgs(1),
-		Long:              `The run-task sub-command lets operators run a short-lived Task on the App.`,
+		Use:   "run-task APP_NAME",
+		Short: "Run a short-lived Task on the App.",
+		Example: `
+		kf run-task my-app --command "sleep 100" --name my-task
+
+		# Stream the Task's logs and exit with its exit code
+		kf run-task my-app --command "bin/migrate" --wait
+
+		# Run migrations from a new Build before restarting the App onto it
+		kf run-task my-app --command "bin/migrate" --build my-app-build-2 --wait
+		`,
+		Args: cobra.ExactArgs(1),
+		Long: `
+		The run-task sub-command lets operators run a short-lived Task on the App.
+
+		With --wait, the command streams the Task's logs until it completes and
+		exits with the Task's exit code. Interrupting the command offers to
+		terminate the Task.
+		`,
 		ValidArgsFunction: completion.AppCompletionFn(p),
 		SilenceUsage:      true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -78,13 +105,34 @@ func NewRunTaskCommand(p *config.KfParams, client tasks.Client, appClient apps.C
 					},
 					// CPU is not converted to SI because it's not a normal CF field
 					// and is therefore expected to be in SI to begin with.
-					CPU:     resourceFlags.CPU(),
-					Memory:  manifest.CFToSIUnits(resourceFlags.Memory()),
-					Disk:    manifest.CFToSIUnits(resourceFlags.Disk()),
-					Command: command,
+					CPU:           resourceFlags.CPU(),
+					Memory:        manifest.CFToSIUnits(resourceFlags.Memory()),
+					Disk:          manifest.CFToSIUnits(resourceFlags.Disk()),
+					Command:       command,
+					Timeout:       taskRunFlags.Timeout(),
+					Retries:       taskRunFlags.Retries(),
+					RetryBackoff:  taskRunFlags.RetryBackoff(),
+					Completions:   taskRunFlags.Completions(),
+					Parallelism:   taskRunFlags.Parallelism(),
+					Notifications: taskRunFlags.Notifications(),
+					Image:         image,
 				},
 			}
 
+			if buildName != "" {
+				build, err := buildClient.Get(ctx, p.Space, buildName)
+				if err != nil {
+					return fmt.Errorf("failed to get Build: %s", err)
+				}
+				if !metav1.IsControlledBy(build, app) {
+					return fmt.Errorf("Build %q doesn't belong to App %q", buildName, appName)
+				}
+
+				desiredTask.Spec.BuildRef = &corev1.LocalObjectReference{
+					Name: buildName,
+				}
+			}
+
 			if len(name) > 0 {
 				desiredTask.Spec.DisplayName = name
 			}
@@ -97,6 +145,14 @@ func NewRunTaskCommand(p *config.KfParams, client tasks.Client, appClient apps.C
 
 			logging.FromContext(ctx).Infof("Task %s is submitted successfully for execution.", task.Name)
 
+			if wait {
+				interrupts := make(chan os.Signal, 1)
+				signal.Notify(interrupts, os.Interrupt)
+				defer signal.Stop(interrupts)
+
+				return waitForTask(ctx, cmd, p, client, tailer, task, interrupts)
+			}
+
 			utils.SuggestNextAction(utils.NextAction{
 				Description: "View Task logs",
 				Commands: []string{
@@ -131,7 +187,147 @@ func NewRunTaskCommand(p *config.KfParams, client tasks.Client, appClient apps.C
 		"Display name to give the Task (auto generated if omitted).",
 	)
 
+	cmd.Flags().BoolVar(
+		&wait,
+		"wait",
+		false,
+		"Stream the Task's logs until it completes and exit with its exit code.",
+	)
+
+	cmd.Flags().StringVar(
+		&image,
+		"image",
+		"",
+		"Container image to run instead of the App's current image. The Task still gets the App's environment and service bindings.",
+	)
+
+	cmd.Flags().StringVar(
+		&buildName,
+		"build",
+		"",
+		"Name of a successful Build of the App to run instead of the App's current image.",
+	)
+
 	resourceFlags.Add(cmd)
+	taskRunFlags.Add(cmd)
 
 	return cmd
 }
+
+// waitForTask follows the logs of the Task until it completes. If the Task
+// fails the returned error holds its exit code. Receiving an interrupt asks
+// the user whether to terminate the Task or stop waiting for it.
+func waitForTask(
+	ctx context.Context,
+	cmd *cobra.Command,
+	p *config.KfParams,
+	client tasks.Client,
+	tailer logs.Tailer,
+	task *v1alpha1.Task,
+	interrupts <-chan os.Signal,
+) error {
+	logger := logging.FromContext(ctx)
+
+	// Stop tailing before returning so no logs are written after the result.
+	var tailing sync.WaitGroup
+	defer tailing.Wait()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	tailing.Add(1)
+	go func() {
+		defer tailing.Done()
+
+		// Each retry of the Task gets its own Pod, so Pods are selected using
+		// the Task's name rather than the TaskRun's.
+		if err := tailer.Tail(
+			ctx,
+			task.Spec.AppRef.Name,
+			cmd.OutOrStdout(),
+			logs.WithTailSpace(p.Space),
+			logs.WithTailFollow(true),
+			logs.WithTailComponentName(v1alpha1.TaskComponentName),
+			logs.WithTailContainerName(fmt.Sprintf("step-%s", v1alpha1.DefaultUserContainerName)),
+			logs.WithTailLabels(map[string]string{
+				v1alpha1.TaskNameLabel: task.Name,
+			}),
+		); err != nil {
+			logger.Warnf("Failed to tail Task logs: %s", err)
+		}
+	}()
+
+	type waitResult struct {
+		task *v1alpha1.Task
+		err  error
+	}
+
+	results := make(chan waitResult, 1)
+	go func() {
+		final, err := client.WaitFor(ctx, p.Space, task.Name, 1*time.Second, func(t *v1alpha1.Task) bool {
+			return v1alpha1.IsStatusFinal(t.Status.Status)
+		})
+		results <- waitResult{task: final, err: err}
+	}()
+
+	for {
+		select {
+		case result := <-results:
+			// WaitFor returns an error alongside the Task if it failed, the
+			// Task's status is more useful so prefer it.
+			if result.task == nil {
+				return fmt.Errorf("failed waiting for Task: %s", result.err)
+			}
+			return taskExitError(result.task)
+
+		case <-interrupts:
+			if !confirmTerminate(cmd, task.Name) {
+				logger.Infof("Stopped waiting, Task %s is still running.", task.Name)
+				return nil
+			}
+
+			if _, err := client.Transform(ctx, p.Space, task.Name, func(t *v1alpha1.Task) error {
+				t.Spec.Terminated = true
+				return nil
+			}); err != nil {
+				return fmt.Errorf("Failed to terminate Task: %s", err)
+			}
+
+			logger.Infof("Task %s is submitted for termination.", task.Name)
+		}
+	}
+}
+
+// confirmTerminate asks the user whether the Task should be terminated.
+func confirmTerminate(cmd *cobra.Command, taskName string) bool {
+	fmt.Fprintf(cmd.ErrOrStderr(), "\nTerminate Task %s? [y/N]: ", taskName)
+
+	answer, _ := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
+
+// taskExitError returns nil if the Task succeeded, otherwise an error holding
+// the exit code of the Task.
+func taskExitError(task *v1alpha1.Task) error {
+	cond := task.Status.GetCondition(v1alpha1.TaskConditionSucceeded)
+	if cond == nil || cond.IsTrue() {
+		return nil
+	}
+
+	reason := fmt.Sprintf("Task %s failed: %s", task.Name, cond.Message)
+	if task.Status.TerminationReason != "" {
+		reason = fmt.Sprintf("Task %s failed (%s): %s", task.Name, task.Status.TerminationReason, cond.Message)
+	}
+
+	code := 1
+	if task.Status.ExitCode != nil && *task.Status.ExitCode != 0 {
+		code = int(*task.Status.ExitCode)
+	}
+
+	return utils.ExitCodeErr{Code: code, Reason: reason}
+}
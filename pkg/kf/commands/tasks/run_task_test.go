@@ -16,17 +16,28 @@ package tasks_test
 
 import (
 	"bytes"
+	"context"
 	"errors"
+	"io"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	v1alpha1 "github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
 	appsfake "github.com/google/kf/v2/pkg/kf/apps/fake"
+	buildsfake "github.com/google/kf/v2/pkg/kf/builds/fake"
 	"github.com/google/kf/v2/pkg/kf/commands/config"
 	"github.com/google/kf/v2/pkg/kf/commands/tasks"
+	utils "github.com/google/kf/v2/pkg/kf/internal/utils/cli"
+	"github.com/google/kf/v2/pkg/kf/logs"
+	logsfake "github.com/google/kf/v2/pkg/kf/logs/fake"
 	tasksfake "github.com/google/kf/v2/pkg/kf/tasks/fake"
 	"github.com/google/kf/v2/pkg/kf/testutil"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/ptr"
 )
 
 func TestRunTask(t *testing.T) {
@@ -55,6 +66,13 @@ func TestRunTask(t *testing.T) {
 			},
 		},
 	}
+	appBuild := &v1alpha1.Build{
+		ObjectMeta: metav1.ObjectMeta{
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(sampleApp),
+			},
+		},
+	}
 	sampleTask := &v1alpha1.Task{
 		Spec: v1alpha1.TaskSpec{
 			AppRef: corev1.LocalObjectReference{
@@ -74,6 +92,8 @@ func TestRunTask(t *testing.T) {
 		Space     string
 		Args      []string
 		Setup     func(t *testing.T, fakeTasks *tasksfake.FakeClient, fakeApps *appsfake.FakeClient)
+		Builds    func(t *testing.T, fakeBuilds *buildsfake.FakeClient)
+		Tailer    func(t *testing.T, fakeTailer *logsfake.FakeTailer)
 		expectErr error
 		Assert    func(t *testing.T, buffer *bytes.Buffer, err error)
 	}{
@@ -136,6 +156,179 @@ func TestRunTask(t *testing.T) {
 				testutil.AssertNil(t, "err", err)
 			},
 		},
+		"create Task with timeout and retries": {
+			Space: spaceName,
+			Args:  []string{appName, "--command", command, "--timeout", "30m", "--retries", "3", "--retry-backoff", "1m"},
+			Setup: func(t *testing.T, fakeTasks *tasksfake.FakeClient, fakeApps *appsfake.FakeClient) {
+				fakeApps.EXPECT().
+					Get(gomock.Any(), spaceName, appName).
+					Return(sampleApp, nil)
+				fakeTasks.EXPECT().
+					Create(gomock.Any(), spaceName, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, task *v1alpha1.Task) (*v1alpha1.Task, error) {
+						testutil.AssertEqual(t, "timeout", &metav1.Duration{Duration: 30 * time.Minute}, task.Spec.Timeout)
+						testutil.AssertEqual(t, "retries", int32(3), task.Spec.Retries)
+						testutil.AssertEqual(t, "retryBackoff", &metav1.Duration{Duration: time.Minute}, task.Spec.RetryBackoff)
+						return sampleTask, nil
+					})
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+			},
+		},
+		"create indexed Task": {
+			Space: spaceName,
+			Args:  []string{appName, "--command", command, "--completions", "10", "--parallelism", "3"},
+			Setup: func(t *testing.T, fakeTasks *tasksfake.FakeClient, fakeApps *appsfake.FakeClient) {
+				fakeApps.EXPECT().
+					Get(gomock.Any(), spaceName, appName).
+					Return(sampleApp, nil)
+				fakeTasks.EXPECT().
+					Create(gomock.Any(), spaceName, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, task *v1alpha1.Task) (*v1alpha1.Task, error) {
+						testutil.AssertEqual(t, "completions", int32(10), task.Spec.Completions)
+						testutil.AssertEqual(t, "parallelism", int32(3), task.Spec.Parallelism)
+						return sampleTask, nil
+					})
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+			},
+		},
+		"create Task from Build": {
+			Space: spaceName,
+			Args:  []string{appName, "--command", command, "--build", "my-build"},
+			Builds: func(t *testing.T, fakeBuilds *buildsfake.FakeClient) {
+				fakeBuilds.EXPECT().
+					Get(gomock.Any(), spaceName, "my-build").
+					Return(appBuild, nil)
+			},
+			Setup: func(t *testing.T, fakeTasks *tasksfake.FakeClient, fakeApps *appsfake.FakeClient) {
+				fakeApps.EXPECT().
+					Get(gomock.Any(), spaceName, appName).
+					Return(sampleApp, nil)
+				fakeTasks.EXPECT().
+					Create(gomock.Any(), spaceName, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, task *v1alpha1.Task) (*v1alpha1.Task, error) {
+						testutil.AssertEqual(t, "buildRef", &corev1.LocalObjectReference{Name: "my-build"}, task.Spec.BuildRef)
+						testutil.AssertEqual(t, "image", "", task.Spec.Image)
+						return sampleTask, nil
+					})
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+			},
+		},
+		"Build not found": {
+			Space: spaceName,
+			Args:  []string{appName, "--command", command, "--build", "my-build"},
+			Builds: func(t *testing.T, fakeBuilds *buildsfake.FakeClient) {
+				fakeBuilds.EXPECT().
+					Get(gomock.Any(), spaceName, "my-build").
+					Return(nil, errors.New("not found"))
+			},
+			Setup: func(t *testing.T, fakeTasks *tasksfake.FakeClient, fakeApps *appsfake.FakeClient) {
+				fakeApps.EXPECT().
+					Get(gomock.Any(), spaceName, appName).
+					Return(sampleApp, nil)
+			},
+			expectErr: errors.New("failed to get Build: not found"),
+		},
+		"Build of another App": {
+			Space: spaceName,
+			Args:  []string{appName, "--command", command, "--build", "my-build"},
+			Builds: func(t *testing.T, fakeBuilds *buildsfake.FakeClient) {
+				fakeBuilds.EXPECT().
+					Get(gomock.Any(), spaceName, "my-build").
+					Return(&v1alpha1.Build{}, nil)
+			},
+			Setup: func(t *testing.T, fakeTasks *tasksfake.FakeClient, fakeApps *appsfake.FakeClient) {
+				fakeApps.EXPECT().
+					Get(gomock.Any(), spaceName, appName).
+					Return(sampleApp, nil)
+			},
+			expectErr: errors.New(`Build "my-build" doesn't belong to App "my-app"`),
+		},
+		"create Task with image": {
+			Space: spaceName,
+			Args:  []string{appName, "--command", command, "--image", "gcr.io/my-app:v2"},
+			Setup: func(t *testing.T, fakeTasks *tasksfake.FakeClient, fakeApps *appsfake.FakeClient) {
+				fakeApps.EXPECT().
+					Get(gomock.Any(), spaceName, appName).
+					Return(sampleApp, nil)
+				fakeTasks.EXPECT().
+					Create(gomock.Any(), spaceName, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, task *v1alpha1.Task) (*v1alpha1.Task, error) {
+						testutil.AssertEqual(t, "image", "gcr.io/my-app:v2", task.Spec.Image)
+						testutil.AssertTrue(t, "buildRef", task.Spec.BuildRef == nil)
+						return sampleTask, nil
+					})
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+			},
+		},
+		"wait for Task success": {
+			Space: spaceName,
+			Args:  []string{appName, "--command", command, "--wait"},
+			Setup: func(t *testing.T, fakeTasks *tasksfake.FakeClient, fakeApps *appsfake.FakeClient) {
+				fakeApps.EXPECT().
+					Get(gomock.Any(), spaceName, appName).
+					Return(sampleApp, nil)
+				fakeTasks.EXPECT().
+					Create(gomock.Any(), spaceName, gomock.Any()).
+					Return(sampleTask, nil)
+
+				succeeded := sampleTask.DeepCopy()
+				succeeded.Status.Conditions = []apis.Condition{
+					{Type: v1alpha1.TaskConditionSucceeded, Status: corev1.ConditionTrue},
+				}
+				fakeTasks.EXPECT().
+					WaitFor(gomock.Any(), spaceName, sampleTask.Name, gomock.Any(), gomock.Any()).
+					Return(succeeded, nil)
+			},
+			Tailer: func(t *testing.T, fakeTailer *logsfake.FakeTailer) {
+				fakeTailer.EXPECT().
+					Tail(gomock.Any(), appName, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, _ io.Writer, opts ...logs.TailOption) error {
+						tailOpts := logs.TailOptions(opts)
+						testutil.AssertEqual(t, "follow", true, tailOpts.Follow())
+						testutil.AssertEqual(t, "component", v1alpha1.TaskComponentName, tailOpts.ComponentName())
+						testutil.AssertEqual(t, "labels", map[string]string{v1alpha1.TaskNameLabel: sampleTask.Name}, tailOpts.Labels())
+						return nil
+					})
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+			},
+		},
+		"wait for Task propagates exit code": {
+			Space: spaceName,
+			Args:  []string{appName, "--command", command, "--wait"},
+			Setup: func(t *testing.T, fakeTasks *tasksfake.FakeClient, fakeApps *appsfake.FakeClient) {
+				fakeApps.EXPECT().
+					Get(gomock.Any(), spaceName, appName).
+					Return(sampleApp, nil)
+				fakeTasks.EXPECT().
+					Create(gomock.Any(), spaceName, gomock.Any()).
+					Return(sampleTask, nil)
+
+				failed := sampleTask.DeepCopy()
+				failed.Status.Conditions = []apis.Condition{
+					{Type: v1alpha1.TaskConditionSucceeded, Status: corev1.ConditionFalse, Message: "exit status 3"},
+				}
+				failed.Status.ExitCode = ptr.Int32(3)
+				fakeTasks.EXPECT().
+					WaitFor(gomock.Any(), spaceName, sampleTask.Name, gomock.Any(), gomock.Any()).
+					Return(failed, errors.New("Reason: \"Failed\""))
+			},
+			Tailer: func(t *testing.T, fakeTailer *logsfake.FakeTailer) {
+				fakeTailer.EXPECT().Tail(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertEqual(t, "exit code", 3, utils.ExitCode(err))
+			},
+		},
 		"create Task succeeds with auto generated Task name": {
 			Space: spaceName,
 			Args:  []string{appName, "--command", command},
@@ -159,17 +352,29 @@ func TestRunTask(t *testing.T) {
 
 			aClient := appsfake.NewFakeClient(ctrl)
 			tClient := tasksfake.NewFakeClient(ctrl)
+			bClient := buildsfake.NewFakeClient(ctrl)
+			tailer := logsfake.NewFakeTailer(ctrl)
 
 			if tc.Setup != nil {
 				tc.Setup(t, tClient, aClient)
 			}
 
+			if tc.Builds != nil {
+				tc.Builds(t, bClient)
+			}
+
+			if tc.Tailer != nil {
+				tc.Tailer(t, tailer)
+			}
+
 			cmd := tasks.NewRunTaskCommand(
 				&config.KfParams{
 					Space: tc.Space,
 				},
 				tClient,
-				aClient)
+				aClient,
+				bClient,
+				tailer)
 
 			cmd.SetArgs(tc.Args)
 			cmd.SetOutput(&buffer)
@@ -40,8 +40,10 @@ const placeholderCron = "0 0 30 2 *"
 func NewCreateJobCommand(p *config.KfParams) *cobra.Command {
 	var (
 		resourceFlags     utils.ResourceFlags
+		taskRunFlags      utils.TaskRunFlags
 		schedule          string
 		concurrencyPolicy string
+		timeZone          string
 		async             utils.AsyncFlags
 	)
 
@@ -83,14 +85,21 @@ func NewCreateJobCommand(p *config.KfParams) *cobra.Command {
 					Schedule:          placeholderCron,
 					Suspend:           true,
 					ConcurrencyPolicy: concurrencyPolicy,
+					TimeZone:          timeZone,
 					TaskTemplate: v1alpha1.TaskSpec{
 						AppRef: corev1.LocalObjectReference{
 							Name: appName,
 						},
-						CPU:     resourceFlags.CPU(),
-						Memory:  manifest.CFToSIUnits(resourceFlags.Memory()),
-						Disk:    manifest.CFToSIUnits(resourceFlags.Disk()),
-						Command: command,
+						CPU:           resourceFlags.CPU(),
+						Memory:        manifest.CFToSIUnits(resourceFlags.Memory()),
+						Disk:          manifest.CFToSIUnits(resourceFlags.Disk()),
+						Command:       command,
+						Timeout:       taskRunFlags.Timeout(),
+						Retries:       taskRunFlags.Retries(),
+						RetryBackoff:  taskRunFlags.RetryBackoff(),
+						Completions:   taskRunFlags.Completions(),
+						Parallelism:   taskRunFlags.Parallelism(),
+						Notifications: taskRunFlags.Notifications(),
 					},
 				},
 			}
@@ -129,6 +138,7 @@ func NewCreateJobCommand(p *config.KfParams) *cobra.Command {
 	}
 
 	resourceFlags.Add(cmd)
+	taskRunFlags.Add(cmd)
 	async.Add(cmd)
 
 	// The default is left as "" here to determine if the schedule flag was
@@ -149,5 +159,12 @@ func NewCreateJobCommand(p *config.KfParams) *cobra.Command {
 		"Specifies how to treat concurrent executions of a Job: Always (default), Replace, or Forbid.",
 	)
 
+	cmd.Flags().StringVar(
+		&timeZone,
+		"time-zone",
+		"",
+		"IANA time zone to evaluate the schedule in, e.g. America/New_York. Defaults to the controller's time zone.",
+	)
+
 	return cmd
 }
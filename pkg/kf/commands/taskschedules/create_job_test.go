@@ -124,6 +124,36 @@ func TestCreateJob(t *testing.T) {
 				testutil.AssertEqual(t, "suspend", true, ts.Spec.Suspend)
 			},
 		},
+		{
+			name:  "sets notifications",
+			space: spaceName,
+			args: []string{
+				appName, jobName, command, "--async",
+				"--notify-url", "https://hooks.example.com/a",
+				"--notify-url", "https://hooks.example.com/b",
+				"--notify-on", "Failed,Succeeded",
+				"--notify-format", "CloudEvent",
+			},
+			setup: func(ctx context.Context, t *testing.T) {
+				client := fakeclient.Get(ctx)
+				client.KfV1alpha1().
+					Apps(spaceName).
+					Create(ctx, app, metav1.CreateOptions{})
+			},
+			assert: func(ctx context.Context, t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+				client := fakeclient.Get(ctx)
+				ts, err := client.KfV1alpha1().
+					TaskSchedules(spaceName).
+					Get(context.Background(), jobName, metav1.GetOptions{})
+				testutil.AssertNil(t, "err", err)
+				on := []string{v1alpha1.TaskNotificationFailed, v1alpha1.TaskNotificationSucceeded}
+				testutil.AssertEqual(t, "notifications", []v1alpha1.TaskNotification{
+					{URL: "https://hooks.example.com/a", Format: v1alpha1.TaskNotificationFormatCloudEvent, On: on},
+					{URL: "https://hooks.example.com/b", Format: v1alpha1.TaskNotificationFormatCloudEvent, On: on},
+				}, ts.Spec.TaskTemplate.Notifications)
+			},
+		},
 		{
 			name:  "sets schedule if provided",
 			space: spaceName,
@@ -16,6 +16,7 @@ package taskschedules
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/google/kf/v2/pkg/client/kf/injection/client"
 	"github.com/google/kf/v2/pkg/kf/commands/config"
@@ -27,10 +28,17 @@ import (
 // NewScheduleJobCommand schedules the specified TaskSchedule with the given
 // cron expression.
 func NewScheduleJobCommand(p *config.KfParams) *cobra.Command {
+	var timeZone string
+
 	cmd := &cobra.Command{
-		Use:          "schedule-job JOB_NAME SCHEDULE",
-		Short:        "Schedule the Job for execution on a cron schedule.",
-		Example:      `kf schedule-job my-job "* * * * *"`,
+		Use:   "schedule-job JOB_NAME SCHEDULE",
+		Short: "Schedule the Job for execution on a cron schedule.",
+		Example: `
+		kf schedule-job my-job "* * * * *"
+
+		# Run at 3AM New York time, including during daylight saving time
+		kf schedule-job my-job "0 3 * * *" --time-zone America/New_York
+		`,
 		Args:         cobra.ExactArgs(2),
 		Long:         `The schedule-job sub-command lets operators schedule a Job for execution on a cron schedule.`,
 		SilenceUsage: true,
@@ -46,6 +54,12 @@ func NewScheduleJobCommand(p *config.KfParams) *cobra.Command {
 				return fmt.Errorf("Schedule %q is not a valid cron schedule: %s", schedule, err)
 			}
 
+			if timeZone != "" {
+				if _, err := time.LoadLocation(timeZone); err != nil {
+					return fmt.Errorf("Time zone %q is not valid: %s", timeZone, err)
+				}
+			}
+
 			client := client.Get(cmd.Context())
 			ts, err := client.KfV1alpha1().
 				TaskSchedules(p.Space).
@@ -63,6 +77,9 @@ func NewScheduleJobCommand(p *config.KfParams) *cobra.Command {
 
 			ts.Spec.Schedule = schedule
 			ts.Spec.Suspend = false
+			if cmd.Flags().Changed("time-zone") {
+				ts.Spec.TimeZone = timeZone
+			}
 
 			_, err = client.KfV1alpha1().
 				TaskSchedules(p.Space).
@@ -75,5 +92,13 @@ func NewScheduleJobCommand(p *config.KfParams) *cobra.Command {
 			return nil
 		},
 	}
+
+	cmd.Flags().StringVar(
+		&timeZone,
+		"time-zone",
+		"",
+		"IANA time zone to evaluate the schedule in, e.g. America/New_York. Defaults to the controller's time zone.",
+	)
+
 	return cmd
 }
@@ -131,6 +131,31 @@ func TestScheduleJob(t *testing.T) {
 				testutil.AssertContainsAll(t, buffer.String(), []string{fmt.Sprintf("Job %s scheduled", jobName)})
 			},
 		},
+		{
+			name:  "TaskSchedule with time zone",
+			space: spaceName,
+			args:  []string{jobName, schedule, "--time-zone", "Europe/Berlin"},
+			setup: func(ctx context.Context, t *testing.T) {
+				client := fakeclient.Get(ctx)
+				client.KfV1alpha1().
+					TaskSchedules(spaceName).
+					Create(ctx, suspendedTaskSchedule, metav1.CreateOptions{})
+			},
+			assert: func(ctx context.Context, t *testing.T, buffer *bytes.Buffer, err error) {
+				client := fakeclient.Get(ctx)
+				ts, err := client.KfV1alpha1().
+					TaskSchedules(spaceName).
+					Get(ctx, jobName, metav1.GetOptions{})
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "timeZone", "Europe/Berlin", ts.Spec.TimeZone)
+			},
+		},
+		{
+			name:      "invalid time zone",
+			space:     spaceName,
+			args:      []string{jobName, schedule, "--time-zone", "Nowhere"},
+			expectErr: errors.New(`Time zone "Nowhere" is not valid: unknown time zone Nowhere`),
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -28,6 +28,7 @@ import (
 	spaces2 "github.com/google/kf/v2/pkg/kf/commands/spaces"
 	tasks2 "github.com/google/kf/v2/pkg/kf/commands/tasks"
 	"github.com/google/kf/v2/pkg/kf/commands/taskschedules"
+	"github.com/google/kf/v2/pkg/kf/commands/workflows"
 	"github.com/google/kf/v2/pkg/kf/configmaps"
 	"github.com/google/kf/v2/pkg/kf/logs"
 	"github.com/google/kf/v2/pkg/kf/marketplace"
@@ -111,6 +112,84 @@ func InjectScale(p *config.KfParams) *cobra.Command {
 	return command
 }
 
+func InjectEnableSessionAffinity(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	appsGetter := provideAppsGetter(kfV1alpha1Interface)
+	buildsGetter := provideKfBuilds(kfV1alpha1Interface)
+	kubernetesInterface := config.GetKubernetes(p)
+	buildTailer := builds.TektonLoggingShim(kubernetesInterface)
+	client := builds.NewClient(p, buildsGetter, buildTailer)
+	tailer := logs.NewTailer(kubernetesInterface)
+	appsClient := apps.NewClient(appsGetter, client, tailer)
+	command := apps2.NewEnableSessionAffinityCommand(p, appsClient)
+	return command
+}
+
+func InjectDisableSessionAffinity(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	appsGetter := provideAppsGetter(kfV1alpha1Interface)
+	buildsGetter := provideKfBuilds(kfV1alpha1Interface)
+	kubernetesInterface := config.GetKubernetes(p)
+	buildTailer := builds.TektonLoggingShim(kubernetesInterface)
+	client := builds.NewClient(p, buildsGetter, buildTailer)
+	tailer := logs.NewTailer(kubernetesInterface)
+	appsClient := apps.NewClient(appsGetter, client, tailer)
+	command := apps2.NewDisableSessionAffinityCommand(p, appsClient)
+	return command
+}
+
+func InjectEnableInstanceRouting(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	appsGetter := provideAppsGetter(kfV1alpha1Interface)
+	buildsGetter := provideKfBuilds(kfV1alpha1Interface)
+	kubernetesInterface := config.GetKubernetes(p)
+	buildTailer := builds.TektonLoggingShim(kubernetesInterface)
+	client := builds.NewClient(p, buildsGetter, buildTailer)
+	tailer := logs.NewTailer(kubernetesInterface)
+	appsClient := apps.NewClient(appsGetter, client, tailer)
+	command := apps2.NewEnableInstanceRoutingCommand(p, appsClient)
+	return command
+}
+
+func InjectDisableInstanceRouting(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	appsGetter := provideAppsGetter(kfV1alpha1Interface)
+	buildsGetter := provideKfBuilds(kfV1alpha1Interface)
+	kubernetesInterface := config.GetKubernetes(p)
+	buildTailer := builds.TektonLoggingShim(kubernetesInterface)
+	client := builds.NewClient(p, buildsGetter, buildTailer)
+	tailer := logs.NewTailer(kubernetesInterface)
+	appsClient := apps.NewClient(appsGetter, client, tailer)
+	command := apps2.NewDisableInstanceRoutingCommand(p, appsClient)
+	return command
+}
+
+func InjectEnableServiceBindingProjection(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	appsGetter := provideAppsGetter(kfV1alpha1Interface)
+	buildsGetter := provideKfBuilds(kfV1alpha1Interface)
+	kubernetesInterface := config.GetKubernetes(p)
+	buildTailer := builds.TektonLoggingShim(kubernetesInterface)
+	client := builds.NewClient(p, buildsGetter, buildTailer)
+	tailer := logs.NewTailer(kubernetesInterface)
+	appsClient := apps.NewClient(appsGetter, client, tailer)
+	command := apps2.NewEnableServiceBindingProjectionCommand(p, appsClient)
+	return command
+}
+
+func InjectDisableServiceBindingProjection(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	appsGetter := provideAppsGetter(kfV1alpha1Interface)
+	buildsGetter := provideKfBuilds(kfV1alpha1Interface)
+	kubernetesInterface := config.GetKubernetes(p)
+	buildTailer := builds.TektonLoggingShim(kubernetesInterface)
+	client := builds.NewClient(p, buildsGetter, buildTailer)
+	tailer := logs.NewTailer(kubernetesInterface)
+	appsClient := apps.NewClient(appsGetter, client, tailer)
+	command := apps2.NewDisableServiceBindingProjectionCommand(p, appsClient)
+	return command
+}
+
 func InjectCreateAutoscalingRule(p *config.KfParams) *cobra.Command {
 	kfV1alpha1Interface := config.GetKfClient(p)
 	appsGetter := provideAppsGetter(kfV1alpha1Interface)
@@ -334,6 +413,16 @@ func InjectDeleteService(p *config.KfParams) *cobra.Command {
 	return command
 }
 
+func InjectShareService(p *config.KfParams) *cobra.Command {
+	command := services.NewShareServiceCommand(p)
+	return command
+}
+
+func InjectUnshareService(p *config.KfParams) *cobra.Command {
+	command := services.NewUnshareServiceCommand(p)
+	return command
+}
+
 func InjectGetService(p *config.KfParams) *cobra.Command {
 	command := services.NewGetServiceCommand(p)
 	return command
@@ -364,7 +453,10 @@ func InjectBindService(p *config.KfParams) *cobra.Command {
 	buildsClient := builds.NewClient(p, buildsGetter, buildTailer)
 	tailer := logs.NewTailer(kubernetesInterface)
 	appsClient := apps.NewClient(appsGetter, buildsClient, tailer)
-	command := servicebindings.NewBindServiceCommand(p, client, secretsClient, appsClient)
+	serviceInstancesGetter := provideServiceInstancesGetter(kfV1alpha1Interface)
+	serviceinstancesClient := serviceinstances.NewClient(serviceInstancesGetter)
+	clientInterface := marketplace.NewClient(kfV1alpha1Interface)
+	command := servicebindings.NewBindServiceCommand(p, client, secretsClient, appsClient, serviceinstancesClient, clientInterface)
 	return command
 }
 
@@ -392,6 +484,14 @@ func InjectUnbindService(p *config.KfParams) *cobra.Command {
 	return command
 }
 
+func InjectRotateBinding(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	serviceInstanceBindingsGetter := provideServiceInstanceBindingsGetter(kfV1alpha1Interface)
+	client := serviceinstancebindings.NewClient(serviceInstanceBindingsGetter)
+	command := servicebindings.NewRotateBindingCommand(p, client)
+	return command
+}
+
 func InjectUnbindRouteService(p *config.KfParams) *cobra.Command {
 	kfV1alpha1Interface := config.GetKfClient(p)
 	serviceInstanceBindingsGetter := provideServiceInstanceBindingsGetter(kfV1alpha1Interface)
@@ -519,6 +619,14 @@ func InjectDomains(p *config.KfParams) *cobra.Command {
 	return command
 }
 
+func InjectCreatePrivateDomain(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	spacesGetter := provideKfSpaces(kfV1alpha1Interface)
+	client := spaces.NewClient(spacesGetter)
+	command := spaces2.NewCreatePrivateDomainCommand(p, client)
+	return command
+}
+
 func InjectTarget(p *config.KfParams) *cobra.Command {
 	kfV1alpha1Interface := config.GetKfClient(p)
 	spacesGetter := provideKfSpaces(kfV1alpha1Interface)
@@ -535,11 +643,32 @@ func InjectConfigCluster(p *config.KfParams) *cobra.Command {
 	return command
 }
 
+func InjectCreateSharedDomain(p *config.KfParams) *cobra.Command {
+	kubernetesInterface := config.GetKubernetes(p)
+	configMapsGetter := provideConfigMapsGetter(kubernetesInterface)
+	client := configmaps.NewClient(configMapsGetter)
+	command := cluster2.NewCreateSharedDomainCommand(p, client)
+	return command
+}
+
+func InjectDeleteSharedDomain(p *config.KfParams) *cobra.Command {
+	kubernetesInterface := config.GetKubernetes(p)
+	configMapsGetter := provideConfigMapsGetter(kubernetesInterface)
+	client := configmaps.NewClient(configMapsGetter)
+	command := cluster2.NewDeleteSharedDomainCommand(p, client)
+	return command
+}
+
 func InjectRoutes(p *config.KfParams) *cobra.Command {
 	command := routes.NewRoutesCommand(p)
 	return command
 }
 
+func InjectRoute(p *config.KfParams) *cobra.Command {
+	command := routes.NewRouteCommand(p)
+	return command
+}
+
 func InjectCreateRoute(p *config.KfParams) *cobra.Command {
 	kfV1alpha1Interface := config.GetKfClient(p)
 	client := routes2.NewClient(kfV1alpha1Interface)
@@ -547,6 +676,43 @@ func InjectCreateRoute(p *config.KfParams) *cobra.Command {
 	return command
 }
 
+func InjectUpdateRoute(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	client := routes2.NewClient(kfV1alpha1Interface)
+	command := routes.NewUpdateRouteCommand(p, client)
+	return command
+}
+
+func InjectMaintenanceMode(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	client := routes2.NewClient(kfV1alpha1Interface)
+	command := routes.NewMaintenanceModeCommand(p, client)
+	return command
+}
+
+func InjectShareRoute(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	client := routes2.NewClient(kfV1alpha1Interface)
+	spacesGetter := provideKfSpaces(kfV1alpha1Interface)
+	spacesClient := spaces.NewClient(spacesGetter)
+	command := routes.NewShareRouteCommand(p, client, spacesClient)
+	return command
+}
+
+func InjectCanary(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	client := routes2.NewClient(kfV1alpha1Interface)
+	appsGetter := provideAppsGetter(kfV1alpha1Interface)
+	buildsGetter := provideKfBuilds(kfV1alpha1Interface)
+	kubernetesInterface := config.GetKubernetes(p)
+	buildTailer := builds.TektonLoggingShim(kubernetesInterface)
+	buildsClient := builds.NewClient(p, buildsGetter, buildTailer)
+	tailer := logs.NewTailer(kubernetesInterface)
+	appsClient := apps.NewClient(appsGetter, buildsClient, tailer)
+	command := routes.NewCanaryCommand(p, client, appsClient)
+	return command
+}
+
 func InjectDeleteRoute(p *config.KfParams) *cobra.Command {
 	kfV1alpha1Interface := config.GetKfClient(p)
 	client := routes2.NewClient(kfV1alpha1Interface)
@@ -630,7 +796,7 @@ func InjectRunTask(p *config.KfParams) *cobra.Command {
 	buildsClient := builds.NewClient(p, buildsGetter, buildTailer)
 	tailer := logs.NewTailer(kubernetesInterface)
 	appsClient := apps.NewClient(appsGetter, buildsClient, tailer)
-	command := tasks2.NewRunTaskCommand(p, client, appsClient)
+	command := tasks2.NewRunTaskCommand(p, client, appsClient, buildsClient, tailer)
 	return command
 }
 
@@ -687,6 +853,31 @@ func InjectDeleteJobSchedule(p *config.KfParams) *cobra.Command {
 	return command
 }
 
+func InjectCreateWorkflow(p *config.KfParams) *cobra.Command {
+	command := workflows.NewCreateWorkflowCommand(p)
+	return command
+}
+
+func InjectRunWorkflow(p *config.KfParams) *cobra.Command {
+	command := workflows.NewRunWorkflowCommand(p)
+	return command
+}
+
+func InjectListWorkflows(p *config.KfParams) *cobra.Command {
+	command := workflows.NewListWorkflowsCommand(p)
+	return command
+}
+
+func InjectWorkflowHistory(p *config.KfParams) *cobra.Command {
+	command := workflows.NewWorkflowHistoryCommand(p)
+	return command
+}
+
+func InjectDeleteWorkflow(p *config.KfParams) *cobra.Command {
+	command := workflows.NewDeleteWorkflowCommand(p)
+	return command
+}
+
 func InjectDependencyCommand(p *config.KfParams) *cobra.Command {
 	command := dependencies.NewDependencyCommand()
 	return command
@@ -40,6 +40,7 @@ import (
 	cspaces "github.com/google/kf/v2/pkg/kf/commands/spaces"
 	ctasks "github.com/google/kf/v2/pkg/kf/commands/tasks"
 	ctaskschedules "github.com/google/kf/v2/pkg/kf/commands/taskschedules"
+	cworkflows "github.com/google/kf/v2/pkg/kf/commands/workflows"
 	"github.com/google/kf/v2/pkg/kf/configmaps"
 	kflogs "github.com/google/kf/v2/pkg/kf/logs"
 	"github.com/google/kf/v2/pkg/kf/marketplace"
@@ -128,6 +129,36 @@ func InjectScale(p *config.KfParams) *cobra.Command {
 	return nil
 }
 
+func InjectEnableSessionAffinity(p *config.KfParams) *cobra.Command {
+	wire.Build(capps.NewEnableSessionAffinityCommand, AppsSet)
+	return nil
+}
+
+func InjectDisableSessionAffinity(p *config.KfParams) *cobra.Command {
+	wire.Build(capps.NewDisableSessionAffinityCommand, AppsSet)
+	return nil
+}
+
+func InjectEnableInstanceRouting(p *config.KfParams) *cobra.Command {
+	wire.Build(capps.NewEnableInstanceRoutingCommand, AppsSet)
+	return nil
+}
+
+func InjectDisableInstanceRouting(p *config.KfParams) *cobra.Command {
+	wire.Build(capps.NewDisableInstanceRoutingCommand, AppsSet)
+	return nil
+}
+
+func InjectEnableServiceBindingProjection(p *config.KfParams) *cobra.Command {
+	wire.Build(capps.NewEnableServiceBindingProjectionCommand, AppsSet)
+	return nil
+}
+
+func InjectDisableServiceBindingProjection(p *config.KfParams) *cobra.Command {
+	wire.Build(capps.NewDisableServiceBindingProjectionCommand, AppsSet)
+	return nil
+}
+
 func InjectCreateAutoscalingRule(p *config.KfParams) *cobra.Command {
 	wire.Build(autoscaling.NewCreateAutoscalingRule, AppsSet)
 	return nil
@@ -272,6 +303,16 @@ func InjectDeleteService(p *config.KfParams) *cobra.Command {
 	return nil
 }
 
+func InjectShareService(p *config.KfParams) *cobra.Command {
+	wire.Build(servicescmd.NewShareServiceCommand)
+	return nil
+}
+
+func InjectUnshareService(p *config.KfParams) *cobra.Command {
+	wire.Build(servicescmd.NewUnshareServiceCommand)
+	return nil
+}
+
 func InjectGetService(p *config.KfParams) *cobra.Command {
 	wire.Build(
 		servicescmd.NewGetServiceCommand,
@@ -313,6 +354,9 @@ func InjectBindService(p *config.KfParams) *cobra.Command {
 	wire.Build(
 		servicebindingscmd.NewBindServiceCommand,
 		ServiceBindingsSet,
+		provideServiceInstancesGetter,
+		serviceinstances.NewClient,
+		marketplace.NewClient,
 	)
 	return nil
 }
@@ -340,6 +384,14 @@ func InjectUnbindService(p *config.KfParams) *cobra.Command {
 	return nil
 }
 
+func InjectRotateBinding(p *config.KfParams) *cobra.Command {
+	wire.Build(
+		servicebindingscmd.NewRotateBindingCommand,
+		ServiceBindingsSet,
+	)
+	return nil
+}
+
 func InjectUnbindRouteService(p *config.KfParams) *cobra.Command {
 	wire.Build(
 		servicebindingscmd.NewUnbindRouteServiceCommand,
@@ -490,6 +542,12 @@ func InjectDomains(p *config.KfParams) *cobra.Command {
 	return nil
 }
 
+func InjectCreatePrivateDomain(p *config.KfParams) *cobra.Command {
+	wire.Build(cspaces.NewCreatePrivateDomainCommand, SpacesSet)
+
+	return nil
+}
+
 func InjectTarget(p *config.KfParams) *cobra.Command {
 	wire.Build(NewTargetCommand, SpacesSet)
 
@@ -512,6 +570,18 @@ func InjectConfigCluster(p *config.KfParams) *cobra.Command {
 	return nil
 }
 
+func InjectCreateSharedDomain(p *config.KfParams) *cobra.Command {
+	wire.Build(ccluster.NewCreateSharedDomainCommand, ConfigMapsSet)
+
+	return nil
+}
+
+func InjectDeleteSharedDomain(p *config.KfParams) *cobra.Command {
+	wire.Build(ccluster.NewDeleteSharedDomainCommand, ConfigMapsSet)
+
+	return nil
+}
+
 ////////////
 // Routes //
 ///////////
@@ -521,6 +591,11 @@ func InjectRoutes(p *config.KfParams) *cobra.Command {
 	return nil
 }
 
+func InjectRoute(p *config.KfParams) *cobra.Command {
+	wire.Build(croutes.NewRouteCommand)
+	return nil
+}
+
 func InjectCreateRoute(p *config.KfParams) *cobra.Command {
 	wire.Build(
 		croutes.NewCreateRouteCommand,
@@ -530,6 +605,42 @@ func InjectCreateRoute(p *config.KfParams) *cobra.Command {
 	return nil
 }
 
+func InjectUpdateRoute(p *config.KfParams) *cobra.Command {
+	wire.Build(
+		croutes.NewUpdateRouteCommand,
+		routes.NewClient,
+		config.GetKfClient,
+	)
+	return nil
+}
+
+func InjectMaintenanceMode(p *config.KfParams) *cobra.Command {
+	wire.Build(
+		croutes.NewMaintenanceModeCommand,
+		routes.NewClient,
+		config.GetKfClient,
+	)
+	return nil
+}
+
+func InjectShareRoute(p *config.KfParams) *cobra.Command {
+	wire.Build(
+		croutes.NewShareRouteCommand,
+		routes.NewClient,
+		SpacesSet,
+	)
+	return nil
+}
+
+func InjectCanary(p *config.KfParams) *cobra.Command {
+	wire.Build(
+		croutes.NewCanaryCommand,
+		routes.NewClient,
+		AppsSet,
+	)
+	return nil
+}
+
 func InjectDeleteRoute(p *config.KfParams) *cobra.Command {
 	wire.Build(
 		croutes.NewDeleteRouteCommand,
@@ -689,6 +800,40 @@ func InjectDeleteJobSchedule(p *config.KfParams) *cobra.Command {
 	return nil
 }
 
+///////////////
+// Workflows //
+///////////////
+
+func InjectCreateWorkflow(p *config.KfParams) *cobra.Command {
+	wire.Build(cworkflows.NewCreateWorkflowCommand)
+
+	return nil
+}
+
+func InjectRunWorkflow(p *config.KfParams) *cobra.Command {
+	wire.Build(cworkflows.NewRunWorkflowCommand)
+
+	return nil
+}
+
+func InjectListWorkflows(p *config.KfParams) *cobra.Command {
+	wire.Build(cworkflows.NewListWorkflowsCommand)
+
+	return nil
+}
+
+func InjectWorkflowHistory(p *config.KfParams) *cobra.Command {
+	wire.Build(cworkflows.NewWorkflowHistoryCommand)
+
+	return nil
+}
+
+func InjectDeleteWorkflow(p *config.KfParams) *cobra.Command {
+	wire.Build(cworkflows.NewDeleteWorkflowCommand)
+
+	return nil
+}
+
 ///////////////////////
 // Other commands
 ///////////////////////
@@ -0,0 +1,114 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflows
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/client/kf/injection/client"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/logging"
+	"sigs.k8s.io/yaml"
+)
+
+// NewCreateWorkflowCommand creates a Workflow from a file describing its
+// steps.
+func NewCreateWorkflowCommand(p *config.KfParams) *cobra.Command {
+	var stepsFile string
+
+	cmd := &cobra.Command{
+		Use:   "create-workflow WORKFLOW_NAME --steps-file FILE",
+		Short: "Create a Workflow that runs a graph of Tasks.",
+		Example: `
+		# Contents of etl.yaml:
+		# steps:
+		# - name: extract
+		#   jobRef:
+		#     name: extract-job
+		# - name: transform
+		#   dependsOn: [extract]
+		#   taskTemplate:
+		#     appRef:
+		#       name: my-app
+		#     command: bin/transform
+		# - name: cleanup
+		#   dependsOn: [transform]
+		#   runIf: Failed
+		#   jobRef:
+		#     name: cleanup-job
+		kf create-workflow etl --steps-file etl.yaml
+		`,
+		Args: cobra.ExactArgs(1),
+		Long: `
+		The create-workflow sub-command lets operators create a Workflow that runs
+		a graph of Tasks.
+
+		Each step runs either the Task template of a Job or a command on an App.
+		Steps start once the steps they depend on have completed, steps without
+		dependencies start in parallel. The runIf field of a step decides whether
+		it runs when its dependencies completed: Succeeded (default) if they all
+		succeeded, Failed if any of them failed, or Completed to always run.
+		`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			if err := p.ValidateSpaceTargeted(); err != nil {
+				return err
+			}
+
+			workflowName := args[0]
+
+			contents, err := ioutil.ReadFile(stepsFile)
+			if err != nil {
+				return fmt.Errorf("couldn't read steps file %q: %v", stepsFile, err)
+			}
+
+			desiredWorkflow := &v1alpha1.Workflow{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      workflowName,
+					Namespace: p.Space,
+				},
+			}
+			if err := yaml.UnmarshalStrict(contents, &desiredWorkflow.Spec); err != nil {
+				return fmt.Errorf("invalid steps file %q: %v", stepsFile, err)
+			}
+
+			workflow, err := client.Get(ctx).KfV1alpha1().
+				Workflows(p.Space).
+				Create(ctx, desiredWorkflow, metav1.CreateOptions{})
+			if err != nil {
+				return fmt.Errorf("failed to create Workflow: %s", err)
+			}
+
+			logging.FromContext(ctx).Infof("Workflow %s created.", workflow.Name)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(
+		&stepsFile,
+		"steps-file",
+		"f",
+		"",
+		"YAML or JSON file containing the steps of the Workflow.",
+	)
+	cmd.MarkFlagRequired("steps-file")
+
+	return cmd
+}
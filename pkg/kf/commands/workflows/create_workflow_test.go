@@ -0,0 +1,152 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflows_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	fakeclient "github.com/google/kf/v2/pkg/client/kf/injection/client/fake"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	"github.com/google/kf/v2/pkg/kf/commands/workflows"
+	fakeinjection "github.com/google/kf/v2/pkg/kf/injection/fake"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCreateWorkflow(t *testing.T) {
+	t.Parallel()
+
+	const (
+		spaceName    = "my-space"
+		workflowName = "etl"
+	)
+
+	writeSteps := func(t *testing.T, contents string) string {
+		path := filepath.Join(t.TempDir(), "steps.yaml")
+		testutil.AssertNil(t, "write err", ioutil.WriteFile(path, []byte(contents), 0600))
+		return path
+	}
+
+	cases := []struct {
+		name      string
+		space     string
+		args      func(t *testing.T) []string
+		assert    func(ctx context.Context, t *testing.T)
+		expectErr error
+	}{
+		{
+			name:      "missing args",
+			args:      func(t *testing.T) []string { return nil },
+			expectErr: errors.New("accepts 1 arg(s), received 0"),
+		},
+		{
+			name: "no target space",
+			args: func(t *testing.T) []string {
+				return []string{workflowName, "--steps-file", writeSteps(t, "steps: []")}
+			},
+			expectErr: errors.New("no space targeted, use 'kf target --space SPACE' to target a space"),
+		},
+		{
+			name:  "unknown field",
+			space: spaceName,
+			args: func(t *testing.T) []string {
+				return []string{workflowName, "--steps-file", writeSteps(t, "stages: []")}
+			},
+			expectErr: errors.New(`unknown field "stages"`),
+		},
+		{
+			name:  "creates Workflow",
+			space: spaceName,
+			args: func(t *testing.T) []string {
+				return []string{workflowName, "--steps-file", writeSteps(t, `
+steps:
+- name: extract
+  jobRef:
+    name: extract-job
+- name: load
+  dependsOn: [extract]
+  taskTemplate:
+    appRef:
+      name: my-app
+    command: bin/load
+- name: cleanup
+  dependsOn: [load]
+  runIf: Failed
+  jobRef:
+    name: cleanup-job
+`)}
+			},
+			assert: func(ctx context.Context, t *testing.T) {
+				workflow, err := fakeclient.Get(ctx).KfV1alpha1().
+					Workflows(spaceName).
+					Get(ctx, workflowName, metav1.GetOptions{})
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "steps", []v1alpha1.WorkflowStep{
+					{
+						Name:   "extract",
+						JobRef: &corev1.LocalObjectReference{Name: "extract-job"},
+					},
+					{
+						Name:      "load",
+						DependsOn: []string{"extract"},
+						TaskTemplate: &v1alpha1.TaskSpec{
+							AppRef:  corev1.LocalObjectReference{Name: "my-app"},
+							Command: "bin/load",
+						},
+					},
+					{
+						Name:      "cleanup",
+						DependsOn: []string{"load"},
+						RunIf:     v1alpha1.WorkflowStepRunIfFailed,
+						JobRef:    &corev1.LocalObjectReference{Name: "cleanup-job"},
+					},
+				}, workflow.Spec.Steps)
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := workflows.NewCreateWorkflowCommand(&config.KfParams{
+				Space: tc.space,
+			})
+
+			var buffer bytes.Buffer
+			ctx := fakeinjection.WithInjection(context.Background(), t)
+			cmd.SetContext(ctx)
+			cmd.SetArgs(tc.args(t))
+			cmd.SetOutput(&buffer)
+
+			gotErr := cmd.Execute()
+
+			if tc.expectErr != nil {
+				testutil.AssertErrorContainsAll(t, gotErr, []string{tc.expectErr.Error()})
+				return
+			}
+			testutil.AssertNil(t, "err", gotErr)
+
+			if tc.assert != nil {
+				tc.assert(ctx, t)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflows
+
+import "github.com/google/kf/v2/pkg/kf/internal/genericcli"
+
+var resourceInfo = &genericcli.KubernetesType{
+	Group:    "kf.dev",
+	Version:  "v1alpha1",
+	Resource: "workflows",
+	NsScoped: true,
+	KfName:   "Workflow",
+}
+
+var runResourceInfo = &genericcli.KubernetesType{
+	Group:    "kf.dev",
+	Version:  "v1alpha1",
+	Resource: "workflowruns",
+	NsScoped: true,
+	KfName:   "WorkflowRun",
+}
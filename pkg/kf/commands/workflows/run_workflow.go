@@ -0,0 +1,72 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflows
+
+import (
+	"fmt"
+
+	"github.com/google/kf/v2/pkg/client/kf/injection/client"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	"github.com/google/kf/v2/pkg/reconciler/workflowrun/resources"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NewRunWorkflowCommand starts a run of the given Workflow.
+func NewRunWorkflowCommand(p *config.KfParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "run-workflow WORKFLOW_NAME",
+		Short:        "Run the Workflow once.",
+		Example:      `kf run-workflow etl`,
+		Args:         cobra.ExactArgs(1),
+		Long:         `The run-workflow sub-command lets operators start a run of a Workflow.`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			if err := p.ValidateSpaceTargeted(); err != nil {
+				return err
+			}
+
+			workflowName := args[0]
+
+			client := client.Get(ctx)
+			workflow, err := client.KfV1alpha1().
+				Workflows(p.Space).
+				Get(ctx, workflowName, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+
+			run, err := client.KfV1alpha1().
+				WorkflowRuns(p.Space).
+				Create(ctx, resources.MakeWorkflowRun(workflow), metav1.CreateOptions{})
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(
+				cmd.OutOrStdout(),
+				"WorkflowRun %s is submitted successfully for execution.\n",
+				run.Name)
+			fmt.Fprintf(
+				cmd.OutOrStdout(),
+				"Use 'kf workflow-history %s' to follow its progress.\n",
+				workflow.Name)
+			return nil
+		},
+	}
+
+	return cmd
+}
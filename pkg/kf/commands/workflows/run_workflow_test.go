@@ -0,0 +1,155 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflows_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	fakeclient "github.com/google/kf/v2/pkg/client/kf/injection/client/fake"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	"github.com/google/kf/v2/pkg/kf/commands/workflows"
+	fakeinjection "github.com/google/kf/v2/pkg/kf/injection/fake"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	"github.com/google/kf/v2/pkg/reconciler/workflowrun/resources"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	krand "k8s.io/apimachinery/pkg/util/rand"
+	ktesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func generateNameReactor(action ktesting.Action) (handled bool, ret runtime.Object, err error) {
+	obj := action.(ktesting.CreateAction).GetObject().(controllerutil.Object)
+	if obj.GetName() == "" && obj.GetGenerateName() != "" {
+		obj.SetName(fmt.Sprintf("%s%s", obj.GetGenerateName(), krand.String(8)))
+	}
+	return false, nil, nil
+}
+
+func TestRunWorkflow(t *testing.T) {
+	t.Parallel()
+
+	const (
+		spaceName    = "my-space"
+		workflowName = "etl"
+	)
+
+	workflow := &v1alpha1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      workflowName,
+			Namespace: spaceName,
+		},
+		Spec: v1alpha1.WorkflowSpec{
+			Steps: []v1alpha1.WorkflowStep{
+				{Name: "extract", JobRef: &corev1.LocalObjectReference{Name: "extract-job"}},
+				{Name: "load", JobRef: &corev1.LocalObjectReference{Name: "load-job"}, DependsOn: []string{"extract"}},
+			},
+		},
+	}
+
+	cases := []struct {
+		name      string
+		space     string
+		args      []string
+		setup     func(ctx context.Context, t *testing.T)
+		assert    func(ctx context.Context, t *testing.T, buffer *bytes.Buffer)
+		expectErr error
+	}{
+		{
+			name:      "missing args",
+			expectErr: errors.New("accepts 1 arg(s), received 0"),
+		},
+		{
+			name:      "no target space",
+			args:      []string{workflowName},
+			expectErr: errors.New("no space targeted, use 'kf target --space SPACE' to target a space"),
+		},
+		{
+			name:      "Workflow does not exist",
+			space:     spaceName,
+			args:      []string{"non-existent"},
+			expectErr: errors.New("workflows.kf.dev \"non-existent\" not found"),
+		},
+		{
+			name:  "creates WorkflowRun",
+			space: spaceName,
+			args:  []string{workflowName},
+			setup: func(ctx context.Context, t *testing.T) {
+				fakeclient.Get(ctx).KfV1alpha1().
+					Workflows(spaceName).
+					Create(ctx, workflow, metav1.CreateOptions{})
+			},
+			assert: func(ctx context.Context, t *testing.T, buffer *bytes.Buffer) {
+				output := buffer.String()
+				testutil.AssertRegexp(
+					t,
+					"stdout",
+					"WorkflowRun etl-.* is submitted successfully for execution\\.",
+					output)
+				runName := strings.Fields(output)[1]
+				run, err := fakeclient.Get(ctx).KfV1alpha1().
+					WorkflowRuns(spaceName).
+					Get(ctx, runName, metav1.GetOptions{})
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "workflowRef", workflowName, run.Spec.WorkflowRef.Name)
+				testutil.AssertEqual(t, "owner label", workflowName, run.Labels[resources.OwningWorkflow])
+				testutil.AssertEqual(t, "steps", workflow.Spec.Steps, run.Spec.Steps)
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := workflows.NewRunWorkflowCommand(&config.KfParams{
+				Space: tc.space,
+			})
+
+			var buffer bytes.Buffer
+
+			ctx := fakeinjection.WithInjection(context.Background(), t)
+
+			// The fake client does not automatically generate names, so we add
+			// a reactor to mimic that behavior.
+			fakeclient.Get(ctx).PrependReactor("create", "workflowruns", generateNameReactor)
+
+			cmd.SetContext(ctx)
+			cmd.SetArgs(tc.args)
+			cmd.SetOutput(&buffer)
+
+			if tc.setup != nil {
+				tc.setup(ctx, t)
+			}
+
+			gotErr := cmd.Execute()
+
+			if tc.expectErr != nil {
+				testutil.AssertErrorsEqual(t, tc.expectErr, gotErr)
+				return
+			}
+			testutil.AssertNil(t, "err", gotErr)
+
+			if tc.assert != nil {
+				tc.assert(ctx, t, &buffer)
+			}
+		})
+	}
+}
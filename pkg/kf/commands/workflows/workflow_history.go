@@ -0,0 +1,42 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflows
+
+import (
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	"github.com/google/kf/v2/pkg/kf/internal/genericcli"
+	"github.com/google/kf/v2/pkg/reconciler/workflowrun/resources"
+	"github.com/spf13/cobra"
+)
+
+// NewWorkflowHistoryCommand lists all runs of the given Workflow.
+func NewWorkflowHistoryCommand(p *config.KfParams) *cobra.Command {
+	return genericcli.NewListCommand(
+		runResourceInfo,
+		p,
+		genericcli.WithListCommandName("workflow-history"),
+		genericcli.WithListExample("kf workflow-history etl"),
+		genericcli.WithListShort("List the runs of a Workflow."),
+		genericcli.WithListLong("The workflow-history sub-command lets operators view the runs of a Workflow. Use 'kubectl get workflowrun RUN_NAME -o yaml' to see the progress of each step."),
+		genericcli.WithListPluralFriendlyName("workflow history"),
+		genericcli.WithListArgumentFilters([]genericcli.ListArgumentFilter{
+			{
+				Name:     "WORKFLOW_NAME",
+				Handler:  genericcli.NewAddLabelFilter(resources.OwningWorkflow),
+				Required: true,
+			},
+		}),
+	)
+}
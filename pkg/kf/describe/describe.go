@@ -125,6 +125,10 @@ func AppSpecInstances(w io.Writer, instances kfv1alpha1.AppSpecInstances) {
 		if hasReplicas {
 			fmt.Fprintf(w, "Replicas:\t%d\n", *instances.Replicas)
 		}
+
+		if instances.IdleTimeout != nil {
+			fmt.Fprintf(w, "Idle Timeout:\t%s\n", instances.IdleTimeout.Duration)
+		}
 	})
 }
 
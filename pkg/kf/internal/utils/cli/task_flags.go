@@ -0,0 +1,139 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"time"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TaskRunFlags is a flag set for intaking how a Task is run (e.g.
+// timeout/retries).
+type TaskRunFlags struct {
+	timeout      time.Duration
+	retries      int32
+	retryBackoff time.Duration
+	completions  int32
+	parallelism  int32
+	notifyURLs   []string
+	notifyOn     []string
+	notifyFormat string
+}
+
+// Add adds the Task run flags to the Cobra command.
+func (flags *TaskRunFlags) Add(cmd *cobra.Command) {
+	cmd.Flags().DurationVar(
+		&flags.timeout,
+		"timeout",
+		0,
+		"Maximum duration of each run of the Task (for example 30m, 2h). Defaults to the cluster's Task timeout.",
+	)
+
+	cmd.Flags().Int32Var(
+		&flags.retries,
+		"retries",
+		0,
+		"Number of times to run the Task again if it fails.",
+	)
+
+	cmd.Flags().DurationVar(
+		&flags.retryBackoff,
+		"retry-backoff",
+		0,
+		"Delay before the first retry, doubled for each following retry. Defaults to 10s.",
+	)
+
+	cmd.Flags().Int32Var(
+		&flags.completions,
+		"completions",
+		0,
+		"Number of indexed runs of the Task, each gets its index in KF_TASK_INDEX and CF_INSTANCE_INDEX. Defaults to 1.",
+	)
+
+	cmd.Flags().Int32Var(
+		&flags.parallelism,
+		"parallelism",
+		0,
+		"Maximum number of indexed runs of the Task to run at the same time. Defaults to the number of completions.",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&flags.notifyURLs,
+		"notify-url",
+		nil,
+		"URL to POST a notification to when the Task finishes, e.g. a chat webhook or email relay service. Can be repeated.",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&flags.notifyOn,
+		"notify-on",
+		nil,
+		"Events that trigger notifications: Succeeded, Failed or Missed. Defaults to Failed and Missed.",
+	)
+
+	cmd.Flags().StringVar(
+		&flags.notifyFormat,
+		"notify-format",
+		"",
+		"Format of notifications, JSON or CloudEvent. Defaults to JSON.",
+	)
+}
+
+// Timeout returns the timeout flag value or nil if it wasn't set.
+func (flags *TaskRunFlags) Timeout() *metav1.Duration {
+	if flags.timeout == 0 {
+		return nil
+	}
+	return &metav1.Duration{Duration: flags.timeout}
+}
+
+// Retries returns the retries flag value.
+func (flags *TaskRunFlags) Retries() int32 {
+	return flags.retries
+}
+
+// RetryBackoff returns the retry-backoff flag value or nil if it wasn't set.
+func (flags *TaskRunFlags) RetryBackoff() *metav1.Duration {
+	if flags.retryBackoff == 0 {
+		return nil
+	}
+	return &metav1.Duration{Duration: flags.retryBackoff}
+}
+
+// Completions returns the completions flag value.
+func (flags *TaskRunFlags) Completions() int32 {
+	return flags.completions
+}
+
+// Parallelism returns the parallelism flag value.
+func (flags *TaskRunFlags) Parallelism() int32 {
+	return flags.parallelism
+}
+
+// Notifications returns a TaskNotification for each notify-url flag value.
+func (flags *TaskRunFlags) Notifications() []v1alpha1.TaskNotification {
+	var out []v1alpha1.TaskNotification
+	for _, url := range flags.notifyURLs {
+		out = append(out, v1alpha1.TaskNotification{
+			URL:    url,
+			Format: flags.notifyFormat,
+			On:     flags.notifyOn,
+		})
+	}
+	return out
+}
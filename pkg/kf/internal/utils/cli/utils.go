@@ -17,6 +17,7 @@ package utils
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -54,6 +55,30 @@ func ConfigError(err error) bool {
 	return ok
 }
 
+// ExitCodeErr is used to indicate that the CLI should exit with a specific
+// code, for example to forward the exit code of a Task.
+type ExitCodeErr struct {
+	// Code holds the exit code.
+	Code int
+	// Reason holds the error message.
+	Reason string
+}
+
+// Error implements error.
+func (e ExitCodeErr) Error() string {
+	return e.Reason
+}
+
+// ExitCode returns the code the CLI should exit with for the error. Errors
+// that aren't an ExitCodeErr exit with 1.
+func ExitCode(err error) int {
+	var exitErr ExitCodeErr
+	if errors.As(err, &exitErr) && exitErr.Code != 0 {
+		return exitErr.Code
+	}
+	return 1
+}
+
 type Config struct {
 	Namespace   string
 	Args        []string
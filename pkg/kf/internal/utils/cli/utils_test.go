@@ -282,3 +282,13 @@ func ExampleStatusUpdateConditionReporter() {
 	// Expected: [status update] Foo
 	// [status update] Bar
 }
+
+func ExampleExitCode() {
+	fmt.Println(ExitCode(errors.New("some error")))
+	fmt.Println(ExitCode(ExitCodeErr{Code: 42, Reason: "task failed"}))
+	fmt.Println(ExitCode(fmt.Errorf("wrapped: %w", ExitCodeErr{Code: 3})))
+
+	// Output: 1
+	// 42
+	// 3
+}
@@ -62,7 +62,7 @@ type Application struct {
 	HealthCheckTimeout int `json:"timeout,omitempty"`
 
 	// HealthCheckType holds the type of health check that will be performed to
-	// determine if the app is alive. Either port or http, blank means port.
+	// determine if the app is alive. Either port, http, grpc or process, blank means port.
 	HealthCheckType string `json:"health-check-type,omitempty"`
 
 	// HealthCheckHTTPEndpoint holds the HTTP endpoint that will receive the
@@ -111,7 +111,8 @@ type KfApplicationExtension struct {
 type AppPort struct {
 	// Port is the port number to open on the App. It's an int32 to match K8s.
 	Port int32 `json:"port"`
-	// Protocol is the protocol name of the port, either tcp, http or http2.
+	// Protocol is the protocol name of the port, either tcp, http, http2 or
+	// grpc. Routes to http2 and grpc ports use HTTP/2 to reach the App.
 	// It's not an L4 protocol, but instead an L7 protocol.
 	// The protocol name gets turned into port label that can be tracked
 	// by Anthos Service Mesh so they have to be valid Istio protocols:
@@ -329,8 +330,8 @@ func (app *Application) WriteWarnings(ctx context.Context) error {
 
 	for _, port := range app.Ports {
 		if port.Protocol == protocolTCP {
-			logger.Warn("Kf supports TCP ports but currently only HTTP Routes. " +
-				"TCP ports can be reached on the App's cluster-internal app-<name>.<space>.svc.cluster.local address.")
+			logger.Warn("TCP ports can be reached on the App's cluster-internal app-<name>.<space>.svc.cluster.local address. " +
+				"To expose them outside the cluster, map a TCP Route on a domain with a TCP router group.")
 			break // only show once
 		}
 	}
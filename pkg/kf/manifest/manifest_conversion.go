@@ -199,6 +199,15 @@ func (source *Application) ToStartupHealthCheck() (*corev1.Probe, error) {
 		probe.ProbeHandler.TCPSocket = &corev1.TCPSocketAction{}
 		return probe, nil
 
+	case "grpc":
+		if source.HealthCheckHTTPEndpoint != "" {
+			return nil, errors.New("health check endpoints can only be used with http checks")
+		}
+
+		// The port is filled in by the App reconciler, the same as port checks.
+		probe.ProbeHandler.GRPC = &corev1.GRPCAction{}
+		return probe, nil
+
 	case "process", "none":
 		// A process check implies there isn't a probe but instead just rely
 		// on the process failing.
@@ -255,6 +264,15 @@ func (source *Application) ToPostStartupHealthCheck() (*corev1.Probe, error) {
 		probe.ProbeHandler.TCPSocket = &corev1.TCPSocketAction{}
 		return probe, nil
 
+	case "grpc":
+		if source.HealthCheckHTTPEndpoint != "" {
+			return nil, errors.New("health check endpoints can only be used with http checks")
+		}
+
+		// The port is filled in by the App reconciler, the same as port checks.
+		probe.ProbeHandler.GRPC = &corev1.GRPCAction{}
+		return probe, nil
+
 	case "process", "none":
 		// A process check implies there isn't a probe but instead just rely
 		// on the process failing.
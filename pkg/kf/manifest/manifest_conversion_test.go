@@ -468,6 +468,23 @@ func TestApplication_ToStartupHealthCheck(t *testing.T) {
 			endpoint:  "/healthz",
 			expectErr: errors.New("health check endpoints can only be used with http checks"),
 		},
+		"grpc": {
+			checkType: "grpc",
+			expectProbe: &corev1.Probe{
+				TimeoutSeconds:   1,
+				SuccessThreshold: 1,
+				FailureThreshold: 30, // ceil(60/2),
+				PeriodSeconds:    2,
+				ProbeHandler: corev1.ProbeHandler{
+					GRPC: &corev1.GRPCAction{},
+				},
+			},
+		},
+		"grpc with endpoint": {
+			checkType: "grpc",
+			endpoint:  "/healthz",
+			expectErr: errors.New("health check endpoints can only be used with http checks"),
+		},
 	}
 
 	for tn, tc := range cases {
@@ -582,6 +599,23 @@ func TestApplication_ToPostStartupHealthCheck(t *testing.T) {
 			endpoint:  "/healthz",
 			expectErr: errors.New("health check endpoints can only be used with http checks"),
 		},
+		"grpc": {
+			checkType: "grpc",
+			expectProbe: &corev1.Probe{
+				TimeoutSeconds:   1,
+				SuccessThreshold: 1,
+				FailureThreshold: 1,
+				PeriodSeconds:    30,
+				ProbeHandler: corev1.ProbeHandler{
+					GRPC: &corev1.GRPCAction{},
+				},
+			},
+		},
+		"grpc with endpoint": {
+			checkType: "grpc",
+			endpoint:  "/healthz",
+			expectErr: errors.New("health check endpoints can only be used with http checks"),
+		},
 	}
 
 	for tn, tc := range cases {
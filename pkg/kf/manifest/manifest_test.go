@@ -457,7 +457,7 @@ func ExampleApplication_WriteWarnings() {
 
 	// Output:
 	// WARN The field(s) [no-start ports] are Kf-specific manifest extensions and may change.
-	// WARN Kf supports TCP ports but currently only HTTP Routes. TCP ports can be reached on the App's cluster-internal app-<name>.<space>.svc.cluster.local address.
+	// WARN TCP ports can be reached on the App's cluster-internal app-<name>.<space>.svc.cluster.local address. To expose them outside the cluster, map a TCP Route on a domain with a TCP router group.
 	// WARN Underscores ('_') in names are not allowed in Kubernetes. Replacing with hyphens ('-')...
 }
 
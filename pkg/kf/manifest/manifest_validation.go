@@ -32,6 +32,7 @@ const (
 	protocolHTTP2 = "http2"
 	protocolHTTP  = "http"
 	protocolTCP   = "tcp"
+	protocolGRPC  = "grpc"
 )
 
 // Validate checks for errors in the Application's fields.
@@ -100,7 +101,7 @@ func (app *Application) Validate(ctx context.Context) (errs *apis.FieldError) {
 				`field can only be set if health-check-type is "http"`))
 		}
 
-		allowedHealthCheckTypes := sets.NewString("http", "port", "", "process", "none")
+		allowedHealthCheckTypes := sets.NewString("http", "port", "", "process", "none", "grpc")
 		if !allowedHealthCheckTypes.Has(app.HealthCheckType) {
 			errs = errs.Also(apis.ErrInvalidValue(
 				app.HealthCheckType,
@@ -137,7 +138,7 @@ func (a *AppPort) Validate(ctx context.Context) (errs *apis.FieldError) {
 	errs = errs.Also(kfapis.ValidatePortNumberBounds(a.Port, "port"))
 
 	// Validate protocol
-	validProtocols := sets.NewString(protocolHTTP, protocolTCP, protocolHTTP2)
+	validProtocols := sets.NewString(protocolHTTP, protocolTCP, protocolHTTP2, protocolGRPC)
 	if !validProtocols.Has(a.Protocol) {
 		msg := fmt.Sprintf("must be one of: %v", validProtocols.List())
 		errs = errs.Also(apis.ErrInvalidValue(msg, "protocol"))
@@ -102,7 +102,7 @@ func TestApplication_Validation(t *testing.T) {
 					},
 				},
 			},
-			want: apis.ErrInvalidValue("must be one of: [http http2 tcp]", "ports[0].protocol"),
+			want: apis.ErrInvalidValue("must be one of: [grpc http http2 tcp]", "ports[0].protocol"),
 		},
 		"bad port": {
 			spec: Application{
@@ -147,7 +147,7 @@ func TestApplication_Validation(t *testing.T) {
 				},
 			},
 		},
-		"grpc disabled": {
+		"grpc probes": {
 			spec: Application{
 				KfApplicationExtension: KfApplicationExtension{
 					StartupProbe: &corev1.Probe{
@@ -167,7 +167,7 @@ func TestApplication_Validation(t *testing.T) {
 					},
 				},
 			},
-			want: apis.ErrDisallowedFields("livenessProbe.grpc", "readinessProbe.grpc", "startupProbe.grpc"),
+			want: nil,
 		},
 		"exec disabled": {
 			spec: Application{
@@ -286,7 +286,7 @@ func TestApplication_Validation(t *testing.T) {
 			spec: Application{
 				HealthCheckType: "foo",
 			},
-			want: apis.ErrInvalidValue("foo", "health-check-type", `valid values are: ["" "http" "none" "port" "process"]`),
+			want: apis.ErrInvalidValue("foo", "health-check-type", `valid values are: ["" "grpc" "http" "none" "port" "process"]`),
 		},
 		"http endpoint is only valid with http": {
 			spec: Application{
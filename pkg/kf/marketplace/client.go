@@ -116,6 +116,40 @@ func (m *KfMarketplace) ListNamespacedPlans(namespace string, filter ListPlanOpt
 	return out
 }
 
+// FindPlanForInstance gets the plan the ServiceInstance was provisioned with,
+// returns nil if the instance isn't backed by a broker or the plan isn't in the
+// catalog.
+func (m *KfMarketplace) FindPlanForInstance(instance *v1alpha1.ServiceInstance) *PlanLineage {
+	if !instance.IsKfBrokered() {
+		return nil
+	}
+
+	osb := instance.Spec.OSB
+	brokerNamespace := ""
+	if osb.Namespaced {
+		brokerNamespace = instance.Namespace
+	}
+
+	var out *PlanLineage
+	m.WalkServicePlans(func(lineage PlanLineage) {
+		if out != nil || lineage.Broker.GetNamespace() != brokerNamespace {
+			return
+		}
+
+		if lineage.Broker.GetName() != osb.BrokerName {
+			return
+		}
+
+		if lineage.ServiceOffering.UID != osb.ClassUID || lineage.ServicePlan.UID != osb.PlanUID {
+			return
+		}
+
+		out = &lineage
+	})
+
+	return out
+}
+
 // ClientInterface is a client capable of interacting with service catalog services
 // and mapping the CF to Kubernetes concepts.
 type ClientInterface interface {
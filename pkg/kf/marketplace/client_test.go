@@ -309,3 +309,82 @@ func TestMarketplace_ListNamespacedPlans(t *testing.T) {
 		})
 	}
 }
+
+func TestMarketplace_FindPlanForInstance(t *testing.T) {
+	t.Parallel()
+
+	const namespace = "test-ns"
+
+	nsBroker := &v1alpha1.ServiceBroker{}
+	nsBroker.Name = "broker-a"
+	nsBroker.Namespace = namespace
+	nsBroker.Status.Services = []v1alpha1.ServiceOffering{
+		{
+			DisplayName: "db-service",
+			UID:         "db-service-uid",
+			Plans: []v1alpha1.ServicePlan{
+				{DisplayName: "free", UID: "free-uid"},
+			},
+		},
+	}
+
+	clusterBroker := &v1alpha1.ClusterServiceBroker{}
+	clusterBroker.Name = "broker-a"
+	clusterBroker.Status.Services = []v1alpha1.ServiceOffering{
+		{
+			DisplayName: "db-service",
+			UID:         "db-service-uid",
+			Plans: []v1alpha1.ServicePlan{
+				{DisplayName: "free", UID: "free-uid"},
+				{DisplayName: "paid", UID: "paid-uid"},
+			},
+		},
+	}
+
+	catalog := &KfMarketplace{
+		Brokers: []v1alpha1.CommonServiceBroker{nsBroker, clusterBroker},
+	}
+
+	instance := func(namespaced bool, planUID string) *v1alpha1.ServiceInstance {
+		out := &v1alpha1.ServiceInstance{}
+		out.Namespace = namespace
+		out.Spec.OSB = &v1alpha1.OSBInstance{
+			BrokerName: "broker-a",
+			ClassUID:   "db-service-uid",
+			PlanUID:    planUID,
+			Namespaced: namespaced,
+		}
+		return out
+	}
+
+	cases := map[string]struct {
+		instance *v1alpha1.ServiceInstance
+		want     string
+	}{
+		"user provided": {
+			instance: &v1alpha1.ServiceInstance{},
+		},
+		"cluster plan": {
+			instance: instance(false, "paid-uid"),
+			want:     "/broker-a/db-service/paid",
+		},
+		"namespaced plan": {
+			instance: instance(true, "free-uid"),
+			want:     "test-ns/broker-a/db-service/free",
+		},
+		"missing plan": {
+			instance: instance(true, "paid-uid"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got := ""
+			if lineage := catalog.FindPlanForInstance(tc.instance); lineage != nil {
+				got = lineage.String()
+			}
+
+			testutil.AssertEqual(t, "plan", tc.want, got)
+		})
+	}
+}
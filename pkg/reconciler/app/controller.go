@@ -28,6 +28,8 @@ import (
 	serviceinstancebindinginformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/serviceinstancebinding"
 	spaceinformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/space"
 	kflisters "github.com/google/kf/v2/pkg/client/kf/listers/kf/v1alpha1"
+	networkingclient "github.com/google/kf/v2/pkg/client/networking/injection/client"
+	destinationruleinformer "github.com/google/kf/v2/pkg/client/networking/injection/informers/networking/v1alpha3/destinationrule"
 	"github.com/google/kf/v2/pkg/reconciler"
 	"github.com/google/kf/v2/pkg/reconciler/reconcilerutil"
 	"go.uber.org/zap"
@@ -37,7 +39,9 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic/dynamicinformer"
+	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/tools/cache"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	deploymentinformer "knative.dev/pkg/client/injection/kube/informers/apps/v1/deployment"
 	autoscalinginformer "knative.dev/pkg/client/injection/kube/informers/autoscaling/v1/horizontalpodautoscaler"
 	serviceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/service"
@@ -61,6 +65,7 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 	serviceInformer := serviceinformer.Get(ctx)
 	serviceAccountInformer := serviceaccountinformer.Get(ctx)
 	hpaInformer := autoscalinginformer.Get(ctx)
+	destinationRuleInformer := destinationruleinformer.Get(ctx)
 
 	appLister := appInformer.Lister()
 
@@ -78,6 +83,20 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 	}
 	adxBuildInformer := dynamicInformer.ForResource(buildsGVR)
 
+	// Only watch the Pods of Apps, watching every Pod in the cluster would be
+	// expensive.
+	podInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(
+		kubeclient.Get(ctx),
+		controller.GetResyncPeriod(ctx),
+		kubeinformers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.LabelSelector = labels.SelectorFromSet(labels.Set{
+				v1alpha1.ManagedByLabel: "kf",
+				v1alpha1.ComponentLabel: v1alpha1.AppServerComponent,
+			}).String()
+		}),
+	)
+	podInformer := podInformerFactory.Core().V1().Pods()
+
 	// Create reconciler
 	c := &Reconciler{
 		Base:                         reconciler.NewBase(ctx, cmw),
@@ -91,6 +110,9 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		serviceAccountLister:         serviceAccountInformer.Lister(),
 		autoscalingLister:            hpaInformer.Lister(),
 		adxBuildLister:               adxBuildInformer.Lister(),
+		podLister:                    podInformer.Lister(),
+		destinationRuleLister:        destinationRuleInformer.Lister(),
+		networkingClientSet:          networkingclient.Get(ctx),
 	}
 
 	// We only want to start this informer if the ADX build type is installed.
@@ -134,6 +156,7 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		serviceInformer.Informer(),
 		serviceAccountInformer.Informer(),
 		hpaInformer.Informer(),
+		destinationRuleInformer.Informer(),
 	} {
 		informer.AddEventHandler(cache.FilteringResourceEventHandler{
 			FilterFunc: controller.Filter(v1alpha1.SchemeGroupVersion.WithKind("App")),
@@ -141,6 +164,35 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		})
 	}
 
+	// Watch for new App Pods so they get an instance index.
+	podInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: func(obj interface{}) bool {
+			pod, ok := obj.(*corev1.Pod)
+			if !ok {
+				return false
+			}
+			return pod.Labels[v1alpha1.InstanceIndexLabel] == ""
+		},
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				pod, ok := obj.(*corev1.Pod)
+				if !ok {
+					logger.Error("failed to cast obj to a Pod")
+					return
+				}
+				impl.EnqueueKey(types.NamespacedName{
+					Namespace: pod.Namespace,
+					Name:      pod.Labels[v1alpha1.NameLabel],
+				})
+			},
+		},
+	})
+
+	podInformerFactory.Start(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), podInformer.Informer().HasSynced) {
+		logger.Fatal("couldn't sync App Pod informer")
+	}
+
 	c.SecretInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 		FilterFunc: controller.Filter(v1alpha1.SchemeGroupVersion.WithKind("ServiceInstanceBinding")),
 		Handler: controller.HandleAll(reconciler.LogEnqueueError(
@@ -16,13 +16,17 @@ package app
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
+	"time"
 
 	kfconfig "github.com/google/kf/v2/pkg/apis/kf/config"
 	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
 	kflisters "github.com/google/kf/v2/pkg/client/kf/listers/kf/v1alpha1"
+	networkingclientset "github.com/google/kf/v2/pkg/client/networking/clientset/versioned"
+	networkinglisters "github.com/google/kf/v2/pkg/client/networking/listers/networking/v1alpha3"
 	"github.com/google/kf/v2/pkg/kf/cfutil"
 	"github.com/google/kf/v2/pkg/kf/dynamicutils"
 	"github.com/google/kf/v2/pkg/reconciler"
@@ -37,12 +41,14 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	appsv1listers "k8s.io/client-go/listers/apps/v1"
 	autoscalingv1listers "k8s.io/client-go/listers/autoscaling/v1"
 	v1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
+	"knative.dev/pkg/ptr"
 )
 
 var (
@@ -68,6 +74,9 @@ type Reconciler struct {
 	serviceAccountLister         v1listers.ServiceAccountLister
 	autoscalingLister            autoscalingv1listers.HorizontalPodAutoscalerLister
 	adxBuildLister               cache.GenericLister
+	podLister                    v1listers.PodLister
+	destinationRuleLister        networkinglisters.DestinationRuleLister
+	networkingClientSet          networkingclientset.Interface
 
 	kfConfigStore *kfconfig.Store
 }
@@ -233,6 +242,16 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, app *v1alpha1.App) error
 		app.Status.PropagateEnvVarSecretStatus(actual)
 	}
 
+	// Reconcile projected service bindings before the Deployment so the
+	// Secrets it mounts exist.
+	{
+		logger.Debug("reconciling service binding secrets")
+		condition := app.Status.EnvVarSecretCondition()
+		if err := r.reconcileServiceBindingSecrets(ctx, app, bindingsWithApp); err != nil {
+			return condition.MarkReconciliationError("projecting service bindings", err)
+		}
+	}
+
 	// reconcile service
 	{
 		logger.Debug("reconciling service")
@@ -474,6 +493,14 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, app *v1alpha1.App) error
 
 	instanceStatus := app.Spec.Instances.Status()
 
+	// Apps with an idle timeout are scaled to zero once they stop receiving
+	// requests. The activator records requests on the App which causes it to
+	// be scaled back up.
+	if err := r.startIdleClock(ctx, app); err != nil {
+		return err
+	}
+	idle, untilIdle := app.IsIdle(time.Now())
+
 	// reconcile HorizontalPodAutoscaler
 	{
 		logger.Debug("reconciling HorizontalPodAutoscaler")
@@ -500,6 +527,15 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, app *v1alpha1.App) error
 		if err != nil {
 			return condition.MarkTemplateError(err)
 		}
+		if idle {
+			desired.Spec.Replicas = ptr.Int32(0)
+		}
+
+		// Replace Pods when binding credentials are rotated so they don't keep
+		// using the previous ones.
+		if credentials := resources.RotatedServiceBindingCredentials(bindingsWithApp); credentials != "" {
+			desired.Spec.Template.Annotations[v1alpha1.ServiceBindingCredentialsAnnotation] = credentials
+		}
 
 		actual, err := r.deploymentLister.Deployments(desired.GetNamespace()).Get(desired.Name)
 		if apierrs.IsNotFound(err) {
@@ -518,6 +554,22 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, app *v1alpha1.App) error
 		app.Status.PropagateDeploymentStatus(actual)
 	}
 
+	// Reconcile the DestinationRule after the Deployment so the instance
+	// subsets cover all the Pods it creates.
+	{
+		logger.Debug("reconciling DestinationRule")
+		if err := r.reconcileDestinationRule(ctx, app); err != nil {
+			return fmt.Errorf("reconciling DestinationRule: %v", err)
+		}
+
+		if app.Spec.InstanceRouting {
+			logger.Debug("reconciling instance indexes")
+			if err := r.reconcileInstanceIndexes(ctx, app); err != nil {
+				return fmt.Errorf("labeling instances: %v", err)
+			}
+		}
+	}
+
 	// Update the human-readable app instances after the backing service has been
 	// synchronized so we always display the current configuration.
 	{
@@ -526,9 +578,15 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, app *v1alpha1.App) error
 			SelectorFromSet(resources.PodLabels(app)).
 			String()
 
+		instanceStatus.PropagateIdle(idle)
 		app.Status.PropagateInstanceStatus(instanceStatus)
 	}
 
+	// Check again once the App could become idle.
+	if untilIdle > 0 {
+		return controller.NewRequeueAfter(untilIdle)
+	}
+
 	return nil
 }
 
@@ -568,9 +626,10 @@ func (r *Reconciler) reconcileRoute(
 	logger := logging.FromContext(ctx)
 
 	// Check for differences, if none we don't need to reconcile.
+	// The policy is managed by users with update-route so it's preserved.
 	if reconciler.NewSemanticEqualityBuilder(logger, "Route").
 		Append("metadata.labels", desired.ObjectMeta.Labels, actual.ObjectMeta.Labels).
-		Append("spec", desired.Spec, actual.Spec).
+		Append("spec", desired.Spec.RouteSpecFields, actual.Spec.RouteSpecFields).
 		IsSemanticallyEqual() {
 		return actual, nil
 	}
@@ -580,7 +639,7 @@ func (r *Reconciler) reconcileRoute(
 
 	// Preserve the rest of the object (e.g. ObjectMeta except for labels).
 	existing.ObjectMeta.Labels = desired.ObjectMeta.Labels
-	existing.Spec = desired.Spec
+	existing.Spec.RouteSpecFields = desired.Spec.RouteSpecFields
 	return r.KfClientSet.
 		KfV1alpha1().
 		Routes(existing.Namespace).
@@ -658,6 +717,211 @@ func (r *Reconciler) reconcileAutoscaler(ctx context.Context, desired, actual *a
 	return r.KubeClientSet.AutoscalingV1().HorizontalPodAutoscalers(existing.Namespace).Update(ctx, existing, metav1.UpdateOptions{})
 }
 
+// reconcileServiceBindingSecrets syncs the Secrets holding the App's service
+// bindings projected following the servicebinding.io spec and removes the ones
+// that are no longer needed.
+func (r *Reconciler) reconcileServiceBindingSecrets(
+	ctx context.Context,
+	app *v1alpha1.App,
+	bindings []v1alpha1.ServiceInstanceBinding,
+) error {
+	logger := logging.FromContext(ctx)
+
+	desiredNames := make(map[string]bool)
+	if app.Spec.ProjectServiceBindings {
+		for _, binding := range bindings {
+			credentialsSecret, err := r.SecretLister.
+				Secrets(binding.Namespace).
+				Get(binding.Status.CredentialsSecretRef.Name)
+			if err != nil {
+				return fmt.Errorf("getting credentials for binding %q: %v", binding.Name, err)
+			}
+
+			desired := resources.MakeServiceBindingSecret(app, binding, *credentialsSecret)
+			desiredNames[desired.Name] = true
+
+			actual, err := r.SecretLister.Secrets(desired.Namespace).Get(desired.Name)
+			switch {
+			case apierrs.IsNotFound(err):
+				if _, err := r.KubeClientSet.CoreV1().Secrets(desired.Namespace).Create(ctx, desired, metav1.CreateOptions{}); err != nil {
+					return err
+				}
+			case err != nil:
+				return err
+			case !metav1.IsControlledBy(actual, app):
+				return fmt.Errorf("Secret %q is not owned by the App", desired.Name)
+			default:
+				if _, err := r.ReconcileSecret(ctx, desired, actual); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	existing, err := r.SecretLister.
+		Secrets(app.Namespace).
+		List(labels.SelectorFromSet(app.ComponentLabels(resources.ServiceBindingSecretComponent)))
+	if err != nil {
+		return err
+	}
+
+	for _, secret := range existing {
+		if desiredNames[secret.Name] || !metav1.IsControlledBy(secret, app) {
+			continue
+		}
+
+		logger.Infof("Deleting Secret %q for a removed service binding", secret.Name)
+		err := r.KubeClientSet.
+			CoreV1().
+			Secrets(secret.Namespace).
+			Delete(ctx, secret.Name, metav1.DeleteOptions{})
+		if err != nil && !apierrs.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reconcileDestinationRule syncs the DestinationRule that configures session
+// affinity and per-instance subsets for the App's Service.
+func (r *Reconciler) reconcileDestinationRule(ctx context.Context, app *v1alpha1.App) error {
+	logger := logging.FromContext(ctx)
+	name := resources.DestinationRuleName(app)
+
+	desired := resources.MakeDestinationRule(app)
+	if desired == nil {
+		actual, err := r.destinationRuleLister.DestinationRules(app.Namespace).Get(name)
+		if apierrs.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return err
+		} else if !metav1.IsControlledBy(actual, app) {
+			// Don't remove DestinationRules Kf didn't create.
+			return nil
+		}
+
+		logger.Info("Deleting DestinationRule because the App doesn't need one")
+		err = r.networkingClientSet.
+			NetworkingV1alpha3().
+			DestinationRules(app.Namespace).
+			Delete(ctx, name, metav1.DeleteOptions{})
+		if apierrs.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	actual, err := r.destinationRuleLister.DestinationRules(desired.Namespace).Get(desired.Name)
+	switch {
+	case apierrs.IsNotFound(err):
+		_, err = r.networkingClientSet.
+			NetworkingV1alpha3().
+			DestinationRules(desired.Namespace).
+			Create(ctx, desired, metav1.CreateOptions{})
+		return err
+	case err != nil:
+		return err
+	case !metav1.IsControlledBy(actual, app):
+		return fmt.Errorf("DestinationRule %q is not owned by the App", desired.Name)
+	}
+
+	if reconciler.NewSemanticEqualityBuilder(logger, "DestinationRule").
+		Append("metadata.labels", desired.ObjectMeta.Labels, actual.ObjectMeta.Labels).
+		Append("spec", &desired.Spec, &actual.Spec).
+		IsSemanticallyEqual() {
+		return nil
+	}
+
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+	existing.ObjectMeta.Labels = desired.ObjectMeta.Labels
+	existing.Spec.Host = desired.Spec.Host
+	existing.Spec.TrafficPolicy = desired.Spec.TrafficPolicy
+	existing.Spec.Subsets = desired.Spec.Subsets
+
+	_, err = r.networkingClientSet.
+		NetworkingV1alpha3().
+		DestinationRules(existing.Namespace).
+		Update(ctx, existing, metav1.UpdateOptions{})
+	return err
+}
+
+// startIdleClock records the current time as the App's last request time if
+// it has an idle timeout without one so the timeout counts from when it was
+// set rather than when the App was created. The time is removed if the App no
+// longer has an idle timeout so setting one again restarts the clock.
+func (r *Reconciler) startIdleClock(ctx context.Context, app *v1alpha1.App) error {
+	_, recorded := app.LastActiveTime()
+	_, annotated := app.Annotations[v1alpha1.LastRequestTimeAnnotation]
+
+	var value *string
+	switch {
+	case app.Spec.Instances.IdleTimeout != nil && !recorded:
+		value = ptr.String(time.Now().UTC().Format(time.RFC3339))
+	case app.Spec.Instances.IdleTimeout == nil && annotated:
+		// A nil value removes the annotation.
+	default:
+		return nil
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]*string{
+				v1alpha1.LastRequestTimeAnnotation: value,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.KfClientSet.
+		KfV1alpha1().
+		Apps(app.Namespace).
+		Patch(ctx, app.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return err
+	}
+
+	if value == nil {
+		delete(app.Annotations, v1alpha1.LastRequestTimeAnnotation)
+		return nil
+	}
+
+	if app.Annotations == nil {
+		app.Annotations = make(map[string]string)
+	}
+	app.Annotations[v1alpha1.LastRequestTimeAnnotation] = *value
+	return nil
+}
+
+// reconcileInstanceIndexes labels each of the App's Pods with a unique
+// instance index so requests can be routed to a specific instance.
+func (r *Reconciler) reconcileInstanceIndexes(ctx context.Context, app *v1alpha1.App) error {
+	pods, err := r.podLister.
+		Pods(app.Namespace).
+		List(labels.SelectorFromSet(resources.PodLabels(app)))
+	if err != nil {
+		return err
+	}
+
+	for name, index := range resources.AssignInstanceIndexes(pods) {
+		patch := fmt.Sprintf(`{"metadata":{"labels":{%q:"%d"}}}`, v1alpha1.InstanceIndexLabel, index)
+		_, err := r.KubeClientSet.
+			CoreV1().
+			Pods(app.Namespace).
+			Patch(ctx, name, types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+		if apierrs.IsNotFound(err) {
+			// The Pod was deleted, its index is free again.
+			continue
+		} else if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (r *Reconciler) updateStatus(ctx context.Context, desired *v1alpha1.App) (*v1alpha1.App, error) {
 	logger := logging.FromContext(ctx)
 	logger.Info("updating status")
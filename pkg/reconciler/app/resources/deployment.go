@@ -102,7 +102,7 @@ func MakeDeployment(
 						}),
 					Annotations: v1alpha1.UnionMaps(
 						// Add in the App's annotations, which may be user-defined.
-						app.GetAnnotations(),
+						podAnnotations(app),
 
 						map[string]string{
 							// Inject the Envoy sidecar on all apps so networking rules
@@ -131,6 +131,20 @@ func MakeDeployment(
 	}, nil
 }
 
+// podAnnotations returns the App's annotations that are copied to its Pods.
+func podAnnotations(app *v1alpha1.App) map[string]string {
+	out := make(map[string]string)
+	for k, v := range app.GetAnnotations() {
+		// The activator updates the last request time while the App is
+		// running, copying it would restart the App's Pods.
+		if k == v1alpha1.LastRequestTimeAnnotation {
+			continue
+		}
+		out[k] = v
+	}
+	return out
+}
+
 func makePodSpec(app *v1alpha1.App, space *v1alpha1.Space) (*corev1.PodSpec, error) {
 	// don't modify the spec on the app
 	spec := app.Spec.Template.Spec.DeepCopy()
@@ -170,6 +184,13 @@ func makePodSpec(app *v1alpha1.App, space *v1alpha1.Space) (*corev1.PodSpec, err
 		Value: strconv.FormatInt(int64(app.Spec.Template.UpdateRequests), 10),
 	})
 
+	if app.Spec.ProjectServiceBindings {
+		containerEnv = append(containerEnv, corev1.EnvVar{
+			Name:  v1alpha1.ServiceBindingRootEnvVar,
+			Value: v1alpha1.DefaultServiceBindingRoot,
+		})
+	}
+
 	userContainer.Env = containerEnv
 
 	// Explicitly disable stdin and tty allocation
@@ -193,6 +214,12 @@ func makePodSpec(app *v1alpha1.App, space *v1alpha1.Space) (*corev1.PodSpec, err
 	// If the client provides a node selector, we should fill in the corresponding field in the podspec.
 	spec.NodeSelector = selectorutil.GetNodeSelector(app.Spec.Build.Spec, space)
 
+	if app.Spec.ProjectServiceBindings {
+		volumes, mounts := buildServiceBindingVolumes(app)
+		spec.Volumes = append(spec.Volumes, volumes...)
+		userContainer.VolumeMounts = append(userContainer.VolumeMounts, mounts...)
+	}
+
 	if len(app.Status.Volumes) > 0 {
 		// mapfs for volumes needs the extra permission.
 		userContainer.SecurityContext = &corev1.SecurityContext{
@@ -344,5 +371,7 @@ func rewriteUserProbe(p *corev1.Probe, userPort int32) {
 		p.HTTPGet.Port = intstr.FromInt(int(userPort))
 	case p.TCPSocket != nil:
 		p.TCPSocket.Port = intstr.FromInt(int(userPort))
+	case p.GRPC != nil:
+		p.GRPC.Port = userPort
 	}
 }
@@ -135,6 +135,8 @@ func TestMakeDeployment(t *testing.T) {
 					Annotations: map[string]string{
 						"user-annotation1": "annotation1-value",
 						"user-annotation2": "annotation2-value",
+						// Not copied so requests don't restart the Pods.
+						v1alpha1.LastRequestTimeAnnotation: "2022-01-01T00:00:00Z",
 					},
 				},
 				Spec: v1alpha1.AppSpec{
@@ -364,6 +366,68 @@ func Test_makePodSpec(t *testing.T) {
 				}
 			},
 		},
+		"projected service bindings": {
+			app: &v1alpha1.App{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-app",
+				},
+				Spec: v1alpha1.AppSpec{
+					ProjectServiceBindings: true,
+				},
+				Status: v1alpha1.AppStatus{
+					ServiceBindingNames: []string{"my-db"},
+				},
+			},
+			space: &v1alpha1.Space{
+				Status: v1alpha1.SpaceStatus{
+					RuntimeConfig: v1alpha1.SpaceStatusRuntimeConfig{
+						TerminationGracePeriodSeconds: ptr.Int64(30),
+					},
+				},
+			},
+			want: func(app *v1alpha1.App) corev1.PodSpec {
+				var wantEnv []corev1.EnvVar
+
+				wantEnv = append(wantEnv, BuildRuntimeEnvVars(CFRunning, app)...)
+				wantEnv = append(wantEnv, corev1.EnvVar{Name: "KF_UPDATE_REQUESTS_", Value: "0"})
+				wantEnv = append(wantEnv, corev1.EnvVar{Name: "SERVICE_BINDING_ROOT", Value: "/bindings"})
+
+				return corev1.PodSpec{
+					EnableServiceLinks: ptr.Bool(false),
+					Containers: []corev1.Container{
+						{
+							Name:                     "user-container",
+							Ports:                    buildContainerPorts(DefaultUserPort),
+							Env:                      wantEnv,
+							Stdin:                    false,
+							TTY:                      false,
+							ImagePullPolicy:          corev1.PullIfNotPresent,
+							TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+							TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+							VolumeMounts: []corev1.VolumeMount{
+								{Name: "binding-my-db", MountPath: "/bindings/my-db", ReadOnly: true},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "binding-my-db",
+							VolumeSource: corev1.VolumeSource{
+								Secret: &corev1.SecretVolumeSource{
+									SecretName: "kf-binding-my-app-my-db",
+								},
+							},
+						},
+					},
+					NodeSelector:                  map[string]string{},
+					RestartPolicy:                 corev1.RestartPolicyAlways,
+					TerminationGracePeriodSeconds: ptr.Int64(30),
+					DNSPolicy:                     corev1.DNSClusterFirst,
+					SecurityContext:               &corev1.PodSecurityContext{},
+					SchedulerName:                 corev1.DefaultSchedulerName,
+				}
+			},
+		},
 		"populated": {
 			app: &v1alpha1.App{
 				ObjectMeta: metav1.ObjectMeta{
@@ -1052,6 +1116,19 @@ func Test_rewriteUserProbe(t *testing.T) {
 				},
 			},
 		},
+		"GRPC probe": {
+			probe: &corev1.Probe{
+				ProbeHandler: corev1.ProbeHandler{
+					GRPC: &corev1.GRPCAction{},
+				},
+			},
+			userPort: 3000,
+			want: &corev1.Probe{
+				ProbeHandler: corev1.ProbeHandler{
+					GRPC: &corev1.GRPCAction{Port: 3000},
+				},
+			},
+		},
 	}
 	for tn, tc := range tests {
 		t.Run(tn, func(t *testing.T) {
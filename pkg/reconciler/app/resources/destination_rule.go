@@ -0,0 +1,152 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	networking "github.com/google/kf/v2/pkg/apis/networking/v1alpha3"
+	"google.golang.org/protobuf/types/known/durationpb"
+	istio "istio.io/api/networking/v1alpha3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/kmeta"
+)
+
+// DestinationRuleName gets the name of the DestinationRule for the App.
+func DestinationRuleName(app *v1alpha1.App) string {
+	return ServiceName(app)
+}
+
+// InstanceSubsetName gets the name of the DestinationRule subset holding the
+// App instance with the given index.
+func InstanceSubsetName(index int32) string {
+	return fmt.Sprintf("instance-%d", index)
+}
+
+// MakeDestinationRule creates a DestinationRule for the App's Service that
+// pins clients to an instance with a cookie and has a subset for each instance
+// index. Returns nil if the App uses neither session affinity nor instance
+// routing.
+func MakeDestinationRule(app *v1alpha1.App) *networking.DestinationRule {
+	if app.Spec.SessionAffinity == nil && !app.Spec.InstanceRouting {
+		return nil
+	}
+
+	dr := &networking.DestinationRule{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "networking.istio.io/v1alpha3",
+			Kind:       "DestinationRule",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DestinationRuleName(app),
+			Namespace: app.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(app),
+			},
+			Labels: v1alpha1.UnionMaps(app.GetLabels(), app.ComponentLabels("destinationrule")),
+		},
+	}
+	dr.Spec.Host = fmt.Sprintf("%s.%s.svc.cluster.local", ServiceName(app), app.Namespace)
+
+	if affinity := app.Spec.SessionAffinity; affinity != nil {
+		// Envoy generates the cookie if the request doesn't have one. A zero
+		// TTL generates session cookies.
+		ttl := durationpb.New(0)
+		if affinity.TTL != nil {
+			ttl = durationpb.New(affinity.TTL.Duration)
+		}
+
+		dr.Spec.TrafficPolicy = &istio.TrafficPolicy{
+			LoadBalancer: &istio.LoadBalancerSettings{
+				LbPolicy: &istio.LoadBalancerSettings_ConsistentHash{
+					ConsistentHash: &istio.LoadBalancerSettings_ConsistentHashLB{
+						HashKey: &istio.LoadBalancerSettings_ConsistentHashLB_HttpCookie{
+							HttpCookie: &istio.LoadBalancerSettings_ConsistentHashLB_HTTPCookie{
+								Name: affinity.CookieName,
+								Path: "/",
+								Ttl:  ttl,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	if app.Spec.InstanceRouting {
+		for i := int32(0); i < app.Spec.Instances.MaxReplicas(); i++ {
+			dr.Spec.Subsets = append(dr.Spec.Subsets, &istio.Subset{
+				Name: InstanceSubsetName(i),
+				Labels: map[string]string{
+					v1alpha1.InstanceIndexLabel: strconv.Itoa(int(i)),
+				},
+			})
+		}
+	}
+
+	return dr
+}
+
+// AssignInstanceIndexes gives each running Pod of an App a unique instance
+// index between 0 and the number of running Pods. Pods keep their index while
+// it's in that range and other Pods get the lowest free index, so indexes are
+// compacted after scaling down. The returned map contains the names of the
+// Pods that need their index label set and the index to set.
+func AssignInstanceIndexes(pods []*corev1.Pod) map[string]int32 {
+	var running []*corev1.Pod
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil ||
+			pod.Status.Phase == corev1.PodSucceeded ||
+			pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		running = append(running, pod)
+	}
+
+	// Older Pods keep their index if two Pods claim the same one.
+	sort.Slice(running, func(i, j int) bool {
+		if !running[i].CreationTimestamp.Equal(&running[j].CreationTimestamp) {
+			return running[i].CreationTimestamp.Before(&running[j].CreationTimestamp)
+		}
+		return running[i].Name < running[j].Name
+	})
+
+	used := make(map[int32]bool)
+	var unassigned []*corev1.Pod
+	for _, pod := range running {
+		index, err := strconv.ParseInt(pod.Labels[v1alpha1.InstanceIndexLabel], 10, 32)
+		if err != nil || index < 0 || index >= int64(len(running)) || used[int32(index)] {
+			unassigned = append(unassigned, pod)
+			continue
+		}
+		used[int32(index)] = true
+	}
+
+	assignments := make(map[string]int32)
+	next := int32(0)
+	for _, pod := range unassigned {
+		for used[next] {
+			next++
+		}
+		used[next] = true
+		assignments[pod.Name] = next
+	}
+
+	return assignments
+}
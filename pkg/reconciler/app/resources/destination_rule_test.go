@@ -0,0 +1,165 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/ptr"
+)
+
+func ExampleInstanceSubsetName() {
+	fmt.Println(InstanceSubsetName(2))
+
+	// Output: instance-2
+}
+
+func TestMakeDestinationRule(t *testing.T) {
+	t.Parallel()
+
+	makeApp := func(mutator func(app *v1alpha1.App)) *v1alpha1.App {
+		app := &v1alpha1.App{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-app",
+				Namespace: "my-ns",
+			},
+		}
+		app.Spec.Instances.Replicas = ptr.Int32(3)
+		mutator(app)
+		return app
+	}
+
+	cases := map[string]struct {
+		app *v1alpha1.App
+	}{
+		"session affinity": {
+			app: makeApp(func(app *v1alpha1.App) {
+				app.Spec.SessionAffinity = &v1alpha1.AppSessionAffinity{
+					CookieName: "JSESSIONID",
+					TTL:        &metav1.Duration{Duration: time.Hour},
+				}
+			}),
+		},
+		"instance routing": {
+			app: makeApp(func(app *v1alpha1.App) {
+				app.Spec.InstanceRouting = true
+			}),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			dr := MakeDestinationRule(tc.app)
+
+			testutil.AssertGoldenJSONContext(t, "destinationrule", dr, map[string]interface{}{
+				"app": tc.app,
+			})
+		})
+	}
+
+	t.Run("neither", func(t *testing.T) {
+		dr := MakeDestinationRule(makeApp(func(*v1alpha1.App) {}))
+		testutil.AssertTrue(t, "nil DestinationRule", dr == nil)
+	})
+}
+
+func TestAssignInstanceIndexes(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	makePod := func(name string, age time.Duration, index string) *corev1.Pod {
+		pod := &corev1.Pod{}
+		pod.Name = name
+		pod.CreationTimestamp = metav1.NewTime(now.Add(-age))
+		if index != "" {
+			pod.Labels = map[string]string{v1alpha1.InstanceIndexLabel: index}
+		}
+		return pod
+	}
+
+	cases := map[string]struct {
+		pods []*corev1.Pod
+		want map[string]int32
+	}{
+		"new pods get lowest free index by age": {
+			pods: []*corev1.Pod{
+				makePod("newest", time.Minute, ""),
+				makePod("labeled", time.Hour, "1"),
+				makePod("oldest", 2*time.Hour, ""),
+			},
+			want: map[string]int32{
+				"oldest": 0,
+				"newest": 2,
+			},
+		},
+		"duplicate index goes to older pod": {
+			pods: []*corev1.Pod{
+				makePod("older", time.Hour, "0"),
+				makePod("newer", time.Minute, "0"),
+			},
+			want: map[string]int32{
+				"newer": 1,
+			},
+		},
+		"indexes are compacted after scaling down": {
+			pods: []*corev1.Pod{
+				makePod("first", 2*time.Hour, "0"),
+				makePod("out-of-range", time.Hour, "4"),
+				makePod("in-range", time.Minute, "1"),
+			},
+			want: map[string]int32{
+				"out-of-range": 2,
+			},
+		},
+		"invalid index is replaced": {
+			pods: []*corev1.Pod{
+				makePod("invalid", time.Hour, "abc"),
+			},
+			want: map[string]int32{
+				"invalid": 0,
+			},
+		},
+		"terminating pods release their index": {
+			pods: []*corev1.Pod{
+				func() *corev1.Pod {
+					pod := makePod("terminating", time.Hour, "0")
+					pod.DeletionTimestamp = &metav1.Time{Time: now}
+					return pod
+				}(),
+				func() *corev1.Pod {
+					pod := makePod("failed", time.Hour, "1")
+					pod.Status.Phase = corev1.PodFailed
+					return pod
+				}(),
+				makePod("replacement", time.Minute, ""),
+			},
+			want: map[string]int32{
+				"replacement": 0,
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			testutil.AssertEqual(t, "assignments", tc.want, AssignInstanceIndexes(tc.pods))
+		})
+	}
+}
@@ -55,11 +55,7 @@ func MakeRoutes(
 	// Build claims, only one claim per name will be built
 	claimNames := sets.NewString()
 	for _, binding := range bindings {
-		name := v1alpha1.GenerateRouteName(
-			binding.Source.Hostname,
-			binding.Source.Domain,
-			binding.Source.Path,
-		)
+		name := v1alpha1.GenerateRouteNameFromFields(binding.Source)
 
 		if claimNames.Has(name) {
 			continue
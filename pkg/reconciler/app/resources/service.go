@@ -15,6 +15,8 @@
 package resources
 
 import (
+	"strings"
+
 	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
 	kfv1alpha1 "github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
@@ -30,6 +32,13 @@ const (
 
 	// DefaultUserPort is the default port for a container to listen on.
 	DefaultUserPort = 8080
+
+	// PortProtocolGRPC is the protocol prefix of ports serving gRPC.
+	PortProtocolGRPC = "grpc"
+
+	// PortProtocolHTTP2 is the protocol prefix of ports serving cleartext
+	// HTTP/2 (h2c).
+	PortProtocolHTTP2 = "http2"
 )
 
 // PodLabels returns the labels for selecting pods of the deployment.
@@ -71,14 +80,17 @@ func MakeService(app *kfv1alpha1.App) *corev1.Service {
 // makeServicePorts creates ports on the Kubernetes Service for each exposed port
 // on the App container. If no user-defined port has the name http-user-port or
 // the port number 80, a default ServicePort is injected. This value allows
-// existing Istio VirtualServices to continue working.
+// existing Istio VirtualServices to continue working. The default port is
+// named grpc-user-port or http2-user-port instead if the App's user port
+// serves gRPC or HTTP/2.
 func makeServicePorts(app *kfv1alpha1.App) (ports []corev1.ServicePort) {
 	containers := app.Spec.Template.Spec.Containers
+	defaultPortName := userServicePortName(app)
 	injectDefaultPort := true
 	if len(containers) != 0 {
 		for _, containerPort := range containers[0].Ports {
 			// don't inject the default port if there's a conflicting port
-			if containerPort.Name == UserPortName || containerPort.ContainerPort == 80 {
+			if containerPort.Name == defaultPortName || containerPort.ContainerPort == 80 {
 				injectDefaultPort = false
 			}
 			ports = append(ports, corev1.ServicePort{
@@ -94,7 +106,7 @@ func makeServicePorts(app *kfv1alpha1.App) (ports []corev1.ServicePort) {
 	// for reverse compatibility.
 	if injectDefaultPort {
 		ports = append(ports, corev1.ServicePort{
-			Name:     UserPortName,
+			Name:     defaultPortName,
 			Protocol: corev1.ProtocolTCP,
 			Port:     v1alpha1.DefaultRouteDestinationPort,
 			// This one is matching the public one, since this is the
@@ -106,6 +118,42 @@ func makeServicePorts(app *kfv1alpha1.App) (ports []corev1.ServicePort) {
 	return
 }
 
+// userServicePortName returns the name of the injected default Service port.
+// Istio picks the protocol used to reach the App from the port name, so Apps
+// whose user port serves gRPC or HTTP/2 get a matching prefix instead of
+// http.
+func userServicePortName(app *v1alpha1.App) string {
+	containers := app.Spec.Template.Spec.Containers
+	if len(containers) == 0 || len(containers[0].Ports) == 0 {
+		return UserPortName
+	}
+
+	switch protocol := PortProtocol(containers[0].Ports[0].Name); protocol {
+	case PortProtocolGRPC, PortProtocolHTTP2:
+		return protocol + "-user-port"
+	default:
+		return UserPortName
+	}
+}
+
+// PortProtocol returns the protocol prefix of a port name using Istio's
+// <protocol>[-<suffix>] naming convention.
+func PortProtocol(portName string) string {
+	return strings.ToLower(strings.SplitN(portName, "-", 2)[0])
+}
+
+// ServicePortProtocol returns the protocol prefix of the App's Service port
+// with the given number, or an empty string if the Service has no such port.
+func ServicePortProtocol(app *v1alpha1.App, port int32) string {
+	for _, servicePort := range makeServicePorts(app) {
+		if servicePort.Port == port {
+			return PortProtocol(servicePort.Name)
+		}
+	}
+
+	return ""
+}
+
 func getUserPort(app *v1alpha1.App) int32 {
 	containers := app.Spec.Template.Spec.Containers
 	if len(containers) == 0 {
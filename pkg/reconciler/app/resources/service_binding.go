@@ -0,0 +1,114 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/cfutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/kmeta"
+)
+
+// ServiceBindingSecretComponent is the component label value of Secrets
+// holding projected service bindings.
+const ServiceBindingSecretComponent = "service-binding"
+
+// ServiceBindingSecretName gets the name of the Secret holding the projection
+// of a service binding for the App.
+func ServiceBindingSecretName(app *v1alpha1.App, bindingName string) string {
+	return v1alpha1.GenerateName("kf-binding", app.Name, bindingName)
+}
+
+// RotatedServiceBindingCredentials returns a stable summary of the credentials
+// of bindings that have been rotated in the format NAME=ID,NAME=ID or a blank
+// string if none have been rotated.
+func RotatedServiceBindingCredentials(bindings []v1alpha1.ServiceInstanceBinding) string {
+	var out []string
+	for _, binding := range bindings {
+		id := binding.CredentialsBindingID()
+		if id == string(binding.UID) {
+			continue
+		}
+		out = append(out, fmt.Sprintf("%s=%s", binding.Name, id))
+	}
+
+	sort.Strings(out)
+	return strings.Join(out, ",")
+}
+
+// MakeServiceBindingSecret creates a Secret containing the service binding
+// projected following the servicebinding.io spec.
+func MakeServiceBindingSecret(
+	app *v1alpha1.App,
+	binding v1alpha1.ServiceInstanceBinding,
+	credentialsSecret corev1.Secret,
+) *corev1.Secret {
+	return &corev1.Secret{
+		Type: corev1.SecretTypeOpaque,
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ServiceBindingSecretName(app, binding.Status.BindingName),
+			Namespace: app.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(app),
+			},
+			Labels: v1alpha1.UnionMaps(app.GetLabels(), app.ComponentLabels(ServiceBindingSecretComponent)),
+		},
+		Data: cfutil.NewServiceBindingFiles(binding, credentialsSecret),
+	}
+}
+
+// buildServiceBindingVolumes creates a Secret volume for each of the App's
+// service bindings mounted under the service binding root.
+func buildServiceBindingVolumes(app *v1alpha1.App) ([]corev1.Volume, []corev1.VolumeMount) {
+	var volumes []corev1.Volume
+	var mounts []corev1.VolumeMount
+
+	for _, bindingName := range app.Status.ServiceBindingNames {
+		volumeName := v1alpha1.GenerateName("binding", bindingName)
+
+		volumes = append(volumes, corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: ServiceBindingSecretName(app, bindingName),
+				},
+			},
+		})
+
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      volumeName,
+			MountPath: path.Join(v1alpha1.DefaultServiceBindingRoot, serviceBindingDirName(bindingName)),
+			ReadOnly:  true,
+		})
+	}
+
+	return volumes, mounts
+}
+
+// serviceBindingDirName returns the directory a binding is projected into.
+// Binding names are set by users so they're sanitized if they could escape the
+// service binding root.
+func serviceBindingDirName(bindingName string) string {
+	if strings.Contains(bindingName, "/") || bindingName == "." || bindingName == ".." {
+		return v1alpha1.GenerateName(bindingName)
+	}
+	return bindingName
+}
@@ -0,0 +1,139 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func ExampleServiceBindingSecretName() {
+	app := &v1alpha1.App{}
+	app.Name = "my-app"
+
+	fmt.Println(ServiceBindingSecretName(app, "my-db"))
+
+	// Output: kf-binding-my-app-my-db
+}
+
+func ExampleServiceBindingSecretName_invalidCharacters() {
+	app := &v1alpha1.App{}
+	app.Name = "my-app"
+
+	fmt.Println(ServiceBindingSecretName(app, "My_DB"))
+
+	// Output: kf-binding-my-app-my-db631da334d4b3787d0e0f9d4be3d71b5b
+}
+
+func ExampleRotatedServiceBindingCredentials() {
+	makeBinding := func(name, previousID, bindingID string) v1alpha1.ServiceInstanceBinding {
+		binding := v1alpha1.ServiceInstanceBinding{}
+		binding.Name = name
+		binding.UID = "uid-" + types.UID(name)
+		if bindingID != "" {
+			binding.Status.PropagateRotationStarted(1, previousID, bindingID)
+			unbindAfter := metav1.Now()
+			binding.Status.PreviousBindingUnbindAfter = &unbindAfter
+		}
+		return binding
+	}
+
+	fmt.Printf("None rotated: %q\n", RotatedServiceBindingCredentials([]v1alpha1.ServiceInstanceBinding{
+		makeBinding("db", "", ""),
+	}))
+	fmt.Printf("Rotated: %q\n", RotatedServiceBindingCredentials([]v1alpha1.ServiceInstanceBinding{
+		makeBinding("queue", "uid-queue", "new-queue-id"),
+		makeBinding("db", "", ""),
+		makeBinding("cache", "uid-cache", "new-cache-id"),
+	}))
+
+	// Output: None rotated: ""
+	// Rotated: "cache=new-cache-id,queue=new-queue-id"
+}
+
+func TestMakeServiceBindingSecret(t *testing.T) {
+	t.Parallel()
+
+	app := &v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "some-namespace",
+			Name:      "some-app-name",
+			Labels:    map[string]string{"a": "1"},
+		},
+	}
+
+	binding := v1alpha1.ServiceInstanceBinding{}
+	binding.Status.BindingName = "my-db"
+	binding.Status.ClassName = "postgresql"
+
+	credentialsSecret := corev1.Secret{
+		Data: map[string][]byte{
+			"password": []byte(`"secret"`),
+		},
+	}
+
+	secret := MakeServiceBindingSecret(app, binding, credentialsSecret)
+
+	testutil.AssertEqual(t, "secret.Name", "kf-binding-some-app-name-my-db", secret.Name)
+	testutil.AssertEqual(t, "secret.Namespace", "some-namespace", secret.Namespace)
+	testutil.AssertEqual(t, "secret.Labels", map[string]string{
+		"a":                     "1",
+		v1alpha1.NameLabel:      "some-app-name",
+		v1alpha1.ManagedByLabel: "kf",
+		v1alpha1.ComponentLabel: ServiceBindingSecretComponent,
+	}, secret.Labels)
+	testutil.AssertEqual(t, "secret.OwnerReferences", "some-app-name", secret.OwnerReferences[0].Name)
+	testutil.AssertEqual(t, "secret.Data", map[string][]byte{
+		"password": []byte("secret"),
+		"type":     []byte("postgresql"),
+		"provider": []byte("kf"),
+	}, secret.Data)
+}
+
+func Test_buildServiceBindingVolumes(t *testing.T) {
+	t.Parallel()
+
+	app := &v1alpha1.App{}
+	app.Name = "my-app"
+	app.Status.ServiceBindingNames = []string{"my-db", "../escape"}
+
+	volumes, mounts := buildServiceBindingVolumes(app)
+
+	testutil.AssertEqual(t, "volumes", []corev1.Volume{
+		{
+			Name: "binding-my-db",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: "kf-binding-my-app-my-db"},
+			},
+		},
+		{
+			Name: v1alpha1.GenerateName("binding", "../escape"),
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: ServiceBindingSecretName(app, "../escape")},
+			},
+		},
+	}, volumes)
+
+	testutil.AssertEqual(t, "mounts", []corev1.VolumeMount{
+		{Name: "binding-my-db", MountPath: "/bindings/my-db", ReadOnly: true},
+		{Name: v1alpha1.GenerateName("binding", "../escape"), MountPath: "/bindings/" + v1alpha1.GenerateName("../escape"), ReadOnly: true},
+	}, mounts)
+}
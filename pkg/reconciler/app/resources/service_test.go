@@ -120,6 +120,25 @@ func TestMakeService(t *testing.T) {
 				return tmp
 			})(),
 		},
+		"grpc port": {
+			app: (func() *kfv1alpha1.App {
+				tmp := appWithoutPorts.DeepCopy()
+				tmp.Spec.Template.Spec.Containers[0].Ports = []corev1.ContainerPort{
+					{Name: "grpc-9000", ContainerPort: 9000},
+				}
+				return tmp
+			})(),
+		},
+		"http2 port": {
+			app: (func() *kfv1alpha1.App {
+				tmp := appWithoutPorts.DeepCopy()
+				tmp.Spec.Template.Spec.Containers[0].Ports = []corev1.ContainerPort{
+					{Name: "http2-9000", ContainerPort: 9000},
+					{Name: "http-8000", ContainerPort: 8000},
+				}
+				return tmp
+			})(),
+		},
 		"custom ports mixed names": {
 			app: (func() *kfv1alpha1.App {
 				tmp := appWithoutPorts.DeepCopy()
@@ -163,3 +182,47 @@ func Test_getUserPort(t *testing.T) {
 		})
 	}
 }
+
+func TestServicePortProtocol(t *testing.T) {
+	appWithPorts := func(ports ...corev1.ContainerPort) *kfv1alpha1.App {
+		app := &kfv1alpha1.App{}
+		app.Spec.Template.Spec.Containers = []corev1.Container{{Ports: ports}}
+		return app
+	}
+
+	cases := map[string]struct {
+		app  *kfv1alpha1.App
+		port int32
+		want string
+	}{
+		"default port": {
+			app:  appWithPorts(),
+			port: 80,
+			want: "http",
+		},
+		"default port with grpc user port": {
+			app:  appWithPorts(corev1.ContainerPort{Name: "grpc-9000", ContainerPort: 9000}),
+			port: 80,
+			want: PortProtocolGRPC,
+		},
+		"declared http2 port": {
+			app: appWithPorts(
+				corev1.ContainerPort{Name: "http-8080", ContainerPort: 8080},
+				corev1.ContainerPort{Name: "http2-9000", ContainerPort: 9000},
+			),
+			port: 9000,
+			want: PortProtocolHTTP2,
+		},
+		"unknown port": {
+			app:  appWithPorts(),
+			port: 9000,
+			want: "",
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			testutil.AssertEqual(t, "protocol", tc.want, ServicePortProtocol(tc.app, tc.port))
+		})
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcilertesting
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// FakeBroker is an OSB broker that provisions and binds synchronously and
+// records the instance IDs it was asked to provision and the binding IDs it
+// was asked to bind and unbind. Unbinding can be made asynchronous, in which
+// case polls report LastOperationState.
+type FakeBroker struct {
+	*httptest.Server
+
+	mu sync.Mutex
+	// FailBind holds binding IDs that can't be bound.
+	FailBind map[string]bool
+	// FailUnbind holds binding IDs that can't be unbound.
+	FailUnbind map[string]bool
+	// AsyncUnbind makes unbind requests return an operation to poll.
+	AsyncUnbind bool
+	// LastOperationState is the state reported for polled operations.
+	LastOperationState string
+	provisioned        []string
+	bound              []string
+	unbound            []string
+	polled             []string
+}
+
+// NewFakeBroker starts a FakeBroker that's closed when the test finishes.
+func NewFakeBroker(t *testing.T) *FakeBroker {
+	b := &FakeBroker{FailBind: make(map[string]bool), FailUnbind: make(map[string]bool)}
+	b.Server = httptest.NewServer(http.HandlerFunc(b.serveHTTP))
+	t.Cleanup(b.Close)
+	return b
+}
+
+func (b *FakeBroker) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// Paths look like /v2/service_instances/ID for instances and
+	// /v2/service_instances/ID/service_bindings/ID with an optional
+	// /last_operation suffix for bindings.
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) == 3 && parts[1] == "service_instances" && r.Method == http.MethodPut {
+		b.provisioned = append(b.provisioned, parts[2])
+		writeJSON(w, http.StatusCreated, map[string]interface{}{})
+		return
+	}
+	if len(parts) < 5 || len(parts) > 6 || parts[3] != "service_bindings" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	bindingID := parts[4]
+
+	if len(parts) == 6 {
+		if parts[5] != "last_operation" || r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		b.polled = append(b.polled, r.URL.Query().Get("operation"))
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"state": b.LastOperationState,
+		})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		if b.FailBind[bindingID] {
+			writeJSON(w, http.StatusInternalServerError, map[string]interface{}{})
+			return
+		}
+		b.bound = append(b.bound, bindingID)
+		writeJSON(w, http.StatusCreated, map[string]interface{}{
+			"credentials": map[string]interface{}{"password": bindingID},
+		})
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"credentials": map[string]interface{}{"password": bindingID},
+		})
+	case http.MethodDelete:
+		if b.FailUnbind[bindingID] {
+			writeJSON(w, http.StatusInternalServerError, map[string]interface{}{})
+			return
+		}
+		b.unbound = append(b.unbound, bindingID)
+		if b.AsyncUnbind {
+			writeJSON(w, http.StatusAccepted, map[string]interface{}{
+				"operation": "unbind-" + bindingID,
+			})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// Provisioned returns the IDs of the instances that were provisioned.
+func (b *FakeBroker) Provisioned() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.provisioned
+}
+
+// Bindings returns the IDs of the bindings that were bound and unbound.
+func (b *FakeBroker) Bindings() (bound, unbound []string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.bound, b.unbound
+}
+
+// PolledOperations returns the operations whose state was polled.
+func (b *FakeBroker) PolledOperations() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.polled
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,92 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcilertesting
+
+import (
+	"testing"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	kflisters "github.com/google/kf/v2/pkg/client/kf/listers/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/internal/osbutil"
+	"github.com/google/kf/v2/pkg/reconciler"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	v1listers "k8s.io/client-go/listers/core/v1"
+)
+
+// TestInstance returns an OSB ServiceInstance in my-space on the plan of the
+// broker created by NewServiceCatalogBase.
+func TestInstance() *v1alpha1.ServiceInstance {
+	return &v1alpha1.ServiceInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "my-space",
+			UID:       "instance-uid",
+		},
+		Spec: v1alpha1.ServiceInstanceSpec{
+			ServiceType: v1alpha1.ServiceType{
+				OSB: &v1alpha1.OSBInstance{
+					BrokerName: "my-broker",
+					ClassUID:   "class-uid",
+					PlanUID:    "plan-uid",
+				},
+			},
+		},
+	}
+}
+
+// NewServiceCatalogBase creates a ServiceCatalogBase with my-space and a
+// ClusterServiceBroker backed by the broker. The broker's plan publishes the
+// given schemas. The secrets are added to the Kubernetes client and the
+// Secret lister, which also has the broker's credentials.
+func NewServiceCatalogBase(t *testing.T, broker *FakeBroker, schemas *v1alpha1.ServicePlanSchemas, secrets ...runtime.Object) (*reconciler.ServiceCatalogBase, *k8sfake.Clientset) {
+	t.Helper()
+
+	clusterBroker := &v1alpha1.ClusterServiceBroker{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-broker"},
+		Spec: v1alpha1.ClusterServiceBrokerSpec{
+			Credentials: v1alpha1.NamespacedObjectReference{
+				Namespace: v1alpha1.KfNamespace,
+				Name:      "broker-creds",
+			},
+		},
+	}
+	clusterBroker.Status.Services = []v1alpha1.ServiceOffering{{
+		UID: "class-uid",
+		Plans: []v1alpha1.ServicePlan{{
+			UID:     "plan-uid",
+			Schemas: schemas,
+		}},
+	}}
+
+	secrets = append([]runtime.Object{
+		osbutil.NewBasicAuthSecret("broker-creds", "user", "pass", broker.URL, clusterBroker),
+	}, secrets...)
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "my-space", UID: "namespace-uid"}}
+
+	kubeClient := k8sfake.NewSimpleClientset(secrets...)
+	base := &reconciler.ServiceCatalogBase{
+		Base: &reconciler.Base{
+			KubeClientSet:   kubeClient,
+			NamespaceLister: v1listers.NewNamespaceLister(NewIndexer(t, namespace)),
+			SecretLister:    v1listers.NewSecretLister(NewIndexer(t, secrets...)),
+		},
+		KfClusterServiceBrokerLister: kflisters.NewClusterServiceBrokerLister(NewIndexer(t, clusterBroker)),
+	}
+
+	return base, kubeClient
+}
@@ -0,0 +1,341 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcilerutil
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/google/kf/v2/pkg/apis/kf/config"
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// deliveryResultTTL is how long the result of a background delivery is kept
+// if it's never collected, e.g. because the object was deleted.
+const deliveryResultTTL = time.Hour
+
+// TaskNotificationEvent is the body of the notifications sent when a Task
+// finishes or a TaskSchedule misses a run.
+type TaskNotificationEvent struct {
+	// Event is Succeeded, Failed or Missed.
+	Event string `json:"event"`
+
+	Namespace    string `json:"namespace"`
+	App          string `json:"app,omitempty"`
+	TaskSchedule string `json:"taskSchedule,omitempty"`
+	Task         string `json:"task,omitempty"`
+
+	StartTime      *metav1.Time `json:"startTime,omitempty"`
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	Duration       string       `json:"duration,omitempty"`
+
+	ExitCode          *int32 `json:"exitCode,omitempty"`
+	TerminationReason string `json:"terminationReason,omitempty"`
+	Reason            string `json:"reason,omitempty"`
+	Message           string `json:"message,omitempty"`
+
+	// ScheduledTime and MissedRuns are set for Missed events.
+	ScheduledTime *metav1.Time `json:"scheduledTime,omitempty"`
+	MissedRuns    int64        `json:"missedRuns,omitempty"`
+
+	// id uniquely identifies the event so receivers can drop duplicates.
+	id string
+	// source is the path of the object that caused the event.
+	source string
+}
+
+// NewTaskFinishedEvent creates the notification for a Task that succeeded or
+// failed.
+func NewTaskFinishedEvent(task *v1alpha1.Task) TaskNotificationEvent {
+	event := v1alpha1.TaskNotificationFailed
+	succeeded := task.Status.GetCondition(v1alpha1.TaskConditionSucceeded)
+	if succeeded.IsTrue() {
+		event = v1alpha1.TaskNotificationSucceeded
+	}
+
+	out := TaskNotificationEvent{
+		Event:             event,
+		Namespace:         task.Namespace,
+		App:               task.Spec.AppRef.Name,
+		Task:              task.Name,
+		StartTime:         task.Status.StartTime,
+		CompletionTime:    task.Status.CompletionTime,
+		ExitCode:          task.Status.ExitCode,
+		TerminationReason: task.Status.TerminationReason,
+		id:                fmt.Sprintf("%s-%s", task.UID, strings.ToLower(event)),
+		source:            fmt.Sprintf("/namespaces/%s/tasks/%s", task.Namespace, task.Name),
+	}
+
+	if owner := metav1.GetControllerOf(task); owner != nil && owner.Kind == "TaskSchedule" {
+		out.TaskSchedule = owner.Name
+	}
+
+	if task.Status.Duration != nil {
+		out.Duration = task.Status.Duration.Duration.String()
+	}
+
+	if succeeded != nil {
+		out.Reason = succeeded.Reason
+		out.Message = succeeded.Message
+	}
+
+	return out
+}
+
+// NewMissedRunsEvent creates the notification for runs of a TaskSchedule
+// that weren't started. The latest missed run was scheduled at lastMissed.
+func NewMissedRunsEvent(ts *v1alpha1.TaskSchedule, missed int64, lastMissed time.Time) TaskNotificationEvent {
+	return TaskNotificationEvent{
+		Event:         v1alpha1.TaskNotificationMissed,
+		Namespace:     ts.Namespace,
+		App:           ts.Spec.TaskTemplate.AppRef.Name,
+		TaskSchedule:  ts.Name,
+		ScheduledTime: &metav1.Time{Time: lastMissed},
+		MissedRuns:    missed,
+		Reason:        "MissedSchedule",
+		Message:       fmt.Sprintf("%d run(s) of schedule %q weren't started", missed, ts.Spec.Schedule),
+		id:            fmt.Sprintf("%s-missed-%d", ts.UID, lastMissed.Unix()),
+		source:        fmt.Sprintf("/namespaces/%s/taskschedules/%s", ts.Namespace, ts.Name),
+	}
+}
+
+// TaskNotifier sends TaskNotificationEvents to the notification targets of
+// Tasks and TaskSchedules.
+type TaskNotifier struct {
+	// Client is the HTTP client used to send notifications.
+	// Must be set before Notify is called.
+	Client *http.Client
+
+	// Clock is an optional clock.
+	Clock func() time.Time
+
+	// Enqueue is called with the key of the object a background delivery was
+	// started for once it finishes so the result can be collected.
+	// Must be set before Deliver is called.
+	Enqueue func(types.NamespacedName)
+
+	mu         sync.Mutex
+	deliveries map[string]*delivery
+}
+
+// NotificationDelivery is the result of sending notifications.
+type NotificationDelivery struct {
+	// Delivered are the URLs of the notifications that were delivered.
+	Delivered []string
+
+	// Err holds the errors of the notifications that failed.
+	Err error
+}
+
+// delivery is a background delivery of an event.
+type delivery struct {
+	done     bool
+	finished time.Time
+	result   NotificationDelivery
+}
+
+// NewTaskNotifier creates a TaskNotifier with a default timeout that refuses
+// to connect to destinations rejected by v1alpha1.ValidateNotificationHost.
+// Private networks allowed by the config in the store can be reached.
+func NewTaskNotifier(configStore *config.Store) *TaskNotifier {
+	return &TaskNotifier{
+		Client: newNotificationClient(func() []*net.IPNet {
+			return v1alpha1.NotificationAllowedNetworks(configStore.ToContext(context.Background()))
+		}),
+	}
+}
+
+// newNotificationClient creates an HTTP client that checks the host of every
+// request, including redirects, and the address of every connection, so names
+// that resolve to internal addresses are rejected too.
+func newNotificationClient(allowedNetworks func() []*net.IPNet) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: dialControl(allowedNetworks),
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &hostCheckingTransport{
+			next:            transport,
+			allowedNetworks: allowedNetworks,
+		},
+	}
+}
+
+// dialControl checks the address connections are made to after names are
+// resolved.
+func dialControl(allowedNetworks func() []*net.IPNet) func(network, address string, c syscall.RawConn) error {
+	return func(_, address string, _ syscall.RawConn) error {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return err
+		}
+		return v1alpha1.ValidateNotificationHost(host, allowedNetworks())
+	}
+}
+
+// hostCheckingTransport rejects requests to hosts notifications can't be sent
+// to before they're sent.
+type hostCheckingTransport struct {
+	next            http.RoundTripper
+	allowedNetworks func() []*net.IPNet
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *hostCheckingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := v1alpha1.ValidateNotificationHost(req.URL.Hostname(), t.allowedNetworks()); err != nil {
+		return nil, err
+	}
+	return t.next.RoundTrip(req)
+}
+
+// now uses the system clock or the overridden clock
+func (n *TaskNotifier) now() time.Time {
+	if n.Clock == nil {
+		return time.Now()
+	}
+
+	return n.Clock()
+}
+
+// Deliver sends the event to the notifications in the background so slow
+// targets don't block reconciliation. Enqueue is called with the key once the
+// delivery finishes and the next call for the same event returns its result
+// with done set to true. Until then done is false and no new delivery is
+// started.
+func (n *TaskNotifier) Deliver(key types.NamespacedName, notifications []v1alpha1.TaskNotification, event TaskNotificationEvent) (result NotificationDelivery, done bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.deliveries == nil {
+		n.deliveries = make(map[string]*delivery)
+	}
+
+	for id, d := range n.deliveries {
+		if d.done && n.now().Sub(d.finished) > deliveryResultTTL {
+			delete(n.deliveries, id)
+		}
+	}
+
+	if d, ok := n.deliveries[event.id]; ok {
+		if !d.done {
+			return NotificationDelivery{}, false
+		}
+
+		delete(n.deliveries, event.id)
+		return d.result, true
+	}
+
+	d := &delivery{}
+	n.deliveries[event.id] = d
+	go func() {
+		delivered, err := n.Notify(context.Background(), notifications, event)
+
+		n.mu.Lock()
+		d.done = true
+		d.finished = n.now()
+		d.result = NotificationDelivery{Delivered: delivered, Err: err}
+		n.mu.Unlock()
+
+		n.Enqueue(key)
+	}()
+
+	return NotificationDelivery{}, false
+}
+
+// NotifyInBackground sends the event to the notifications without waiting
+// for the result, failures are logged.
+func (n *TaskNotifier) NotifyInBackground(logger *zap.SugaredLogger, notifications []v1alpha1.TaskNotification, event TaskNotificationEvent) {
+	go func() {
+		if _, err := n.Notify(context.Background(), notifications, event); err != nil {
+			logger.Warnw("Failed to send notifications", zap.Error(err))
+		}
+	}()
+}
+
+// Notify sends the event to each of the notifications triggered by it. All
+// targets are tried even if some of them fail. The URLs of the notifications
+// that were delivered are returned so retries can skip them.
+func (n *TaskNotifier) Notify(ctx context.Context, notifications []v1alpha1.TaskNotification, event TaskNotificationEvent) ([]string, error) {
+	var delivered []string
+	var errs []error
+	for _, notification := range notifications {
+		if !notification.NotifiesOn(event.Event) {
+			continue
+		}
+
+		if err := n.send(ctx, notification, event); err != nil {
+			errs = append(errs, fmt.Errorf("notifying %s: %w", notification.URL, err))
+			continue
+		}
+		delivered = append(delivered, notification.URL)
+	}
+
+	return delivered, errors.Join(errs...)
+}
+
+func (n *TaskNotifier) send(ctx context.Context, notification v1alpha1.TaskNotification, event TaskNotificationEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, notification.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if notification.Format == v1alpha1.TaskNotificationFormatCloudEvent {
+		// Binary content mode, see
+		// https://github.com/cloudevents/spec/blob/v1.0.2/cloudevents/bindings/http-protocol-binding.md
+		req.Header.Set("ce-specversion", "1.0")
+		req.Header.Set("ce-id", event.id)
+		req.Header.Set("ce-source", event.source)
+		req.Header.Set("ce-type", "dev.kf.task."+strings.ToLower(event.Event))
+		req.Header.Set("ce-time", n.now().UTC().Format(time.RFC3339))
+		if event.Task != "" {
+			req.Header.Set("ce-subject", event.Task)
+		}
+	}
+
+	resp, err := n.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,305 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcilerutil
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/kf/v2/pkg/apis/kf/config"
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+func failedTask() *v1alpha1.Task {
+	exitCode := int32(3)
+	return &v1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "billing-1600000000",
+			Namespace: "my-space",
+			UID:       "task-uid",
+			OwnerReferences: []metav1.OwnerReference{{
+				Kind:       "TaskSchedule",
+				Name:       "billing",
+				Controller: func(b bool) *bool { return &b }(true),
+			}},
+		},
+		Spec: v1alpha1.TaskSpec{
+			AppRef: corev1.LocalObjectReference{Name: "billing-app"},
+		},
+		Status: v1alpha1.TaskStatus{
+			Status: duckv1beta1.Status{
+				Conditions: duckv1beta1.Conditions{{
+					Type:    v1alpha1.TaskConditionSucceeded,
+					Status:  corev1.ConditionFalse,
+					Reason:  "Failed",
+					Message: "exit status 3",
+				}},
+			},
+			TaskStatusFields: v1alpha1.TaskStatusFields{
+				Duration:          &metav1.Duration{Duration: 90 * time.Second},
+				ExitCode:          &exitCode,
+				TerminationReason: "Error",
+			},
+		},
+	}
+}
+
+func TestNewTaskFinishedEvent(t *testing.T) {
+	t.Parallel()
+
+	t.Run("failed", func(t *testing.T) {
+		event := NewTaskFinishedEvent(failedTask())
+
+		testutil.AssertEqual(t, "event", v1alpha1.TaskNotificationFailed, event.Event)
+		testutil.AssertEqual(t, "taskSchedule", "billing", event.TaskSchedule)
+		testutil.AssertEqual(t, "app", "billing-app", event.App)
+		testutil.AssertEqual(t, "duration", "1m30s", event.Duration)
+		testutil.AssertEqual(t, "exitCode", int32(3), *event.ExitCode)
+		testutil.AssertEqual(t, "reason", "Failed", event.Reason)
+		testutil.AssertEqual(t, "message", "exit status 3", event.Message)
+	})
+
+	t.Run("succeeded", func(t *testing.T) {
+		task := failedTask()
+		task.Status.Conditions[0].Status = corev1.ConditionTrue
+
+		event := NewTaskFinishedEvent(task)
+		testutil.AssertEqual(t, "event", v1alpha1.TaskNotificationSucceeded, event.Event)
+	})
+}
+
+func TestTaskNotifier_Notify(t *testing.T) {
+	t.Parallel()
+
+	type request struct {
+		header http.Header
+		body   map[string]interface{}
+	}
+
+	cases := map[string]struct {
+		notification v1alpha1.TaskNotification
+		status       int
+		wantRequests int
+		wantErr      bool
+		assert       func(t *testing.T, req request)
+	}{
+		"json": {
+			notification: v1alpha1.TaskNotification{},
+			status:       http.StatusOK,
+			wantRequests: 1,
+			assert: func(t *testing.T, req request) {
+				testutil.AssertEqual(t, "content-type", "application/json", req.header.Get("Content-Type"))
+				testutil.AssertEqual(t, "ce-specversion", "", req.header.Get("ce-specversion"))
+				testutil.AssertEqual(t, "event", "Failed", req.body["event"])
+				testutil.AssertEqual(t, "task", "billing-1600000000", req.body["task"])
+				testutil.AssertEqual(t, "duration", "1m30s", req.body["duration"])
+				testutil.AssertEqual(t, "exitCode", float64(3), req.body["exitCode"])
+			},
+		},
+		"cloudevent": {
+			notification: v1alpha1.TaskNotification{
+				Format: v1alpha1.TaskNotificationFormatCloudEvent,
+			},
+			status:       http.StatusAccepted,
+			wantRequests: 1,
+			assert: func(t *testing.T, req request) {
+				testutil.AssertEqual(t, "ce-specversion", "1.0", req.header.Get("ce-specversion"))
+				testutil.AssertEqual(t, "ce-id", "task-uid-failed", req.header.Get("ce-id"))
+				testutil.AssertEqual(t, "ce-type", "dev.kf.task.failed", req.header.Get("ce-type"))
+				testutil.AssertEqual(t, "ce-source", "/namespaces/my-space/tasks/billing-1600000000", req.header.Get("ce-source"))
+				testutil.AssertEqual(t, "ce-subject", "billing-1600000000", req.header.Get("ce-subject"))
+				testutil.AssertEqual(t, "ce-time", "1970-01-01T00:00:00Z", req.header.Get("ce-time"))
+			},
+		},
+		"not triggered": {
+			notification: v1alpha1.TaskNotification{
+				On: []string{v1alpha1.TaskNotificationSucceeded},
+			},
+			wantRequests: 0,
+		},
+		"error status": {
+			notification: v1alpha1.TaskNotification{},
+			status:       http.StatusInternalServerError,
+			wantRequests: 1,
+			wantErr:      true,
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+
+			var requests []request
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				testutil.AssertNil(t, "read err", err)
+
+				req := request{header: r.Header}
+				testutil.AssertNil(t, "unmarshal err", json.Unmarshal(body, &req.body))
+				requests = append(requests, req)
+
+				w.WriteHeader(tc.status)
+			}))
+			defer server.Close()
+
+			tc.notification.URL = server.URL
+			notifier := &TaskNotifier{
+				Client: server.Client(),
+				Clock:  func() time.Time { return time.Unix(0, 0) },
+			}
+
+			delivered, err := notifier.Notify(
+				context.Background(),
+				[]v1alpha1.TaskNotification{tc.notification},
+				NewTaskFinishedEvent(failedTask()),
+			)
+
+			testutil.AssertEqual(t, "error", tc.wantErr, err != nil)
+			testutil.AssertEqual(t, "delivered", !tc.wantErr && tc.wantRequests > 0, len(delivered) == 1)
+			testutil.AssertEqual(t, "requests", tc.wantRequests, len(requests))
+			if tc.assert != nil && len(requests) > 0 {
+				tc.assert(t, requests[0])
+			}
+		})
+	}
+}
+
+func TestTaskNotifier_Deliver(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	enqueued := make(chan types.NamespacedName, 1)
+	notifier := &TaskNotifier{
+		Client:  server.Client(),
+		Enqueue: func(key types.NamespacedName) { enqueued <- key },
+	}
+	key := types.NamespacedName{Namespace: "my-space", Name: "billing-1600000000"}
+	notifications := []v1alpha1.TaskNotification{{URL: server.URL}}
+	event := NewTaskFinishedEvent(failedTask())
+
+	_, done := notifier.Deliver(key, notifications, event)
+	testutil.AssertFalse(t, "done before sending", done)
+
+	select {
+	case got := <-enqueued:
+		testutil.AssertEqual(t, "enqueued key", key, got)
+	case <-time.After(10 * time.Second):
+		t.Fatal("delivery never finished")
+	}
+
+	result, done := notifier.Deliver(key, notifications, event)
+	testutil.AssertTrue(t, "done after sending", done)
+	testutil.AssertNil(t, "err", result.Err)
+	testutil.AssertEqual(t, "delivered", []string{server.URL}, result.Delivered)
+
+	// Collecting the result allows the event to be sent again.
+	_, done = notifier.Deliver(key, notifications, event)
+	testutil.AssertFalse(t, "done after collecting", done)
+	<-enqueued
+}
+
+func TestNewTaskNotifier_internalDestinations(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("notification was sent to a loopback address")
+	}))
+	defer server.Close()
+
+	for _, url := range []string{
+		server.URL,
+		"http://169.254.169.254/computeMetadata/v1",
+		"http://sink.default.svc.cluster.local",
+	} {
+		_, err := NewTaskNotifier(config.NewDefaultConfigStore(logtesting.TestLogger(t))).Notify(
+			context.Background(),
+			[]v1alpha1.TaskNotification{{URL: url}},
+			NewTaskFinishedEvent(failedTask()),
+		)
+		if err == nil {
+			t.Errorf("Notify(%q) got no error, want one", url)
+		}
+	}
+}
+
+func TestDialControl(t *testing.T) {
+	t.Parallel()
+
+	_, allowed, err := net.ParseCIDR("10.1.0.0/16")
+	testutil.AssertNil(t, "err", err)
+
+	// The dialer checks the addresses names resolve to, so names of private
+	// endpoints are rejected like IP literals.
+	cases := map[string]struct {
+		address string
+		allowed []*net.IPNet
+		wantErr bool
+	}{
+		"public":             {address: "203.0.113.10:443"},
+		"private":            {address: "10.1.2.3:443", wantErr: true},
+		"private IPv6":       {address: "[fd00::1]:443", wantErr: true},
+		"allowed private":    {address: "10.1.2.3:443", allowed: []*net.IPNet{allowed}},
+		"private not listed": {address: "192.168.0.1:443", allowed: []*net.IPNet{allowed}, wantErr: true},
+		"metadata server":    {address: "169.254.169.254:80", allowed: []*net.IPNet{allowed}, wantErr: true},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			control := dialControl(func() []*net.IPNet { return tc.allowed })
+			err := control("tcp", tc.address, nil)
+			testutil.AssertEqual(t, "error", tc.wantErr, err != nil)
+		})
+	}
+}
+
+func TestNewMissedRunsEvent(t *testing.T) {
+	t.Parallel()
+
+	ts := &v1alpha1.TaskSchedule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "billing",
+			Namespace: "my-space",
+			UID:       "schedule-uid",
+		},
+		Spec: v1alpha1.TaskScheduleSpec{
+			Schedule: "0 2 * * *",
+		},
+	}
+
+	event := NewMissedRunsEvent(ts, 2, time.Unix(3600, 0))
+
+	testutil.AssertEqual(t, "event", v1alpha1.TaskNotificationMissed, event.Event)
+	testutil.AssertEqual(t, "missedRuns", int64(2), event.MissedRuns)
+	testutil.AssertEqual(t, "scheduledTime", time.Unix(3600, 0), event.ScheduledTime.Time)
+	testutil.AssertEqual(t, "id", "schedule-uid-missed-3600", event.id)
+	testutil.AssertEqual(t, "message", `2 run(s) of schedule "0 2 * * *" weren't started`, event.Message)
+}
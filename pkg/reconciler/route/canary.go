@@ -0,0 +1,308 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package route
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// errorRateFunc returns the percentage of requests to an App that failed with
+// a server error during the window.
+type errorRateFunc func(ctx context.Context, namespace, appName string, window time.Duration) (float64, error)
+
+// errErrorRatePending is returned while an App's error rate is being fetched
+// in the background.
+var errErrorRatePending = errors.New("error rate query is in progress")
+
+// errorRatePollInterval is how long to wait before checking whether a
+// background error rate query finished.
+const errorRatePollInterval = 5 * time.Second
+
+// canaryInput holds the observed state used to advance a canary.
+type canaryInput struct {
+	// canary is the desired canary from the Route's spec.
+	canary *v1alpha1.RouteCanary
+	// previous is the canary status from the last reconciliation.
+	previous *v1alpha1.RouteCanaryStatus
+	// app is the canary App, nil if it doesn't exist.
+	app *v1alpha1.App
+	// bound is true if the canary App is bound to the Route.
+	bound bool
+	// errorRate fetches the App's error rate, nil if it can't be checked.
+	errorRate errorRateFunc
+	// now is the current time.
+	now time.Time
+}
+
+// nextCanaryStatus returns the canary status for the Route and the duration
+// after which the Route should be reconciled again to continue a rollout, zero
+// if no requeue is needed.
+func nextCanaryStatus(ctx context.Context, in canaryInput) (*v1alpha1.RouteCanaryStatus, time.Duration) {
+	if in.canary == nil {
+		return nil, 0
+	}
+
+	status := in.previous.DeepCopy()
+	if status == nil || status.AppName != in.canary.AppName || canaryChanged(status.ObservedCanary, in.canary) {
+		status = &v1alpha1.RouteCanaryStatus{
+			AppName:      in.canary.AppName,
+			Weight:       in.canary.Weight,
+			Phase:        v1alpha1.RouteCanaryPhaseHolding,
+			LastStepTime: &metav1.Time{Time: in.now},
+		}
+		if in.canary.Rollout != nil {
+			status.Phase = v1alpha1.RouteCanaryPhaseProgressing
+		}
+	}
+	status.ObservedCanary = in.canary.DeepCopy()
+
+	rollout := in.canary.Rollout
+	if rollout == nil {
+		status.Phase = v1alpha1.RouteCanaryPhaseHolding
+		status.Weight = in.canary.Weight
+		status.Message = ""
+		if !in.bound {
+			status.Message = fmt.Sprintf("App %q isn't mapped to the Route", in.canary.AppName)
+		}
+		return status, 0
+	}
+
+	switch status.Phase {
+	case v1alpha1.RouteCanaryPhasePromoted, v1alpha1.RouteCanaryPhaseAborted:
+		return status, 0
+	}
+
+	if !in.bound || in.app == nil {
+		status.Message = fmt.Sprintf("Waiting for App %q to be mapped to the Route", in.canary.AppName)
+		return status, rollout.Interval.Duration
+	}
+
+	if cond := in.app.Status.GetCondition(v1alpha1.AppConditionReady); cond != nil && cond.IsFalse() {
+		abortCanary(status, in.now, fmt.Sprintf("Canary App became unhealthy: %s", cond.Message))
+		return status, 0
+	}
+
+	if !in.app.Status.IsReady() {
+		status.Message = "Waiting for the canary App to become ready"
+		return status, rollout.Interval.Duration
+	}
+
+	nextStep := status.LastStepTime.Add(rollout.Interval.Duration)
+	if in.now.Before(nextStep) {
+		return status, nextStep.Sub(in.now)
+	}
+
+	if rollout.MaxErrorPercent != nil && in.errorRate != nil {
+		rate, err := in.errorRate(ctx, in.app.Namespace, in.app.Name, rollout.Interval.Duration)
+		if errors.Is(err, errErrorRatePending) {
+			status.Message = "Checking the canary App's error rate"
+			return status, errorRatePollInterval
+		}
+		if err != nil {
+			status.Message = fmt.Sprintf("Failed to check the canary App's error rate: %v", err)
+			return status, rollout.Interval.Duration
+		}
+
+		if rate > float64(*rollout.MaxErrorPercent) {
+			abortCanary(status, in.now, fmt.Sprintf("Canary App error rate %.2f%% exceeded %d%%", rate, *rollout.MaxErrorPercent))
+			return status, 0
+		}
+	}
+
+	status.Weight += rollout.StepWeight
+	status.LastStepTime = &metav1.Time{Time: in.now}
+	status.Message = ""
+	if status.Weight >= 100 {
+		status.Weight = 100
+		status.Phase = v1alpha1.RouteCanaryPhasePromoted
+		status.Message = "All traffic is sent to the canary App"
+		return status, 0
+	}
+
+	return status, rollout.Interval.Duration
+}
+
+// canaryChanged returns true if the weight or rollout settings of the canary
+// changed since the status was computed. Statuses without an observed canary
+// are kept so upgrading doesn't restart rollouts.
+func canaryChanged(observed, desired *v1alpha1.RouteCanary) bool {
+	if observed == nil {
+		return false
+	}
+
+	return observed.Weight != desired.Weight ||
+		!equality.Semantic.DeepEqual(observed.Rollout, desired.Rollout)
+}
+
+func abortCanary(status *v1alpha1.RouteCanaryStatus, now time.Time, message string) {
+	status.Phase = v1alpha1.RouteCanaryPhaseAborted
+	status.Weight = 0
+	status.LastStepTime = &metav1.Time{Time: now}
+	status.Message = message
+}
+
+// errorRateCache runs error rate queries in the background so reconciling a
+// Route doesn't block on the metrics server. Each App has at most one query in
+// flight, and its result is handed to the next caller that asks for it.
+type errorRateCache struct {
+	mu      sync.Mutex
+	queries map[string]*errorRateQuery
+}
+
+// errorRateQuery is the state of a single background query.
+type errorRateQuery struct {
+	done     bool
+	finished time.Time
+	rate     float64
+	err      error
+}
+
+func newErrorRateCache() *errorRateCache {
+	return &errorRateCache{
+		queries: make(map[string]*errorRateQuery),
+	}
+}
+
+// wrap returns an errorRateFunc that runs fetch in the background and returns
+// errErrorRatePending until the result is available. Results older than the
+// window are discarded so a rollout never steps on stale metrics.
+func (c *errorRateCache) wrap(fetch errorRateFunc) errorRateFunc {
+	return func(_ context.Context, namespace, appName string, window time.Duration) (float64, error) {
+		key := fmt.Sprintf("%s/%s/%s", namespace, appName, window)
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		if query, ok := c.queries[key]; ok {
+			if !query.done {
+				return 0, errErrorRatePending
+			}
+
+			delete(c.queries, key)
+			if time.Since(query.finished) < window {
+				return query.rate, query.err
+			}
+		}
+
+		query := &errorRateQuery{}
+		c.queries[key] = query
+
+		go func() {
+			// The query outlives the reconcile that started it, the client's
+			// timeout bounds it instead.
+			rate, err := fetch(context.Background(), namespace, appName, window)
+
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			query.done = true
+			query.finished = time.Now()
+			query.rate = rate
+			query.err = err
+		}()
+
+		return 0, errErrorRatePending
+	}
+}
+
+// prometheusErrorRate returns an errorRateFunc that queries the Istio request
+// metrics stored in the Prometheus server at the URL.
+func prometheusErrorRate(client *http.Client, prometheusURL string) errorRateFunc {
+	return func(ctx context.Context, namespace, appName string, window time.Duration) (float64, error) {
+		selector := fmt.Sprintf(
+			`reporter="destination",destination_workload_namespace=%q,destination_workload=%q`,
+			namespace,
+			appName,
+		)
+		rangeStr := fmt.Sprintf("%ds", int64(window.Seconds()))
+		query := fmt.Sprintf(
+			`100 * sum(rate(istio_requests_total{%s,response_code=~"5.."}[%s])) / sum(rate(istio_requests_total{%s}[%s]))`,
+			selector, rangeStr, selector, rangeStr,
+		)
+
+		endpoint, err := url.Parse(prometheusURL)
+		if err != nil {
+			return 0, err
+		}
+		endpoint.Path = "/api/v1/query"
+		endpoint.RawQuery = url.Values{"query": []string{query}}.Encode()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+		if err != nil {
+			return 0, err
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return 0, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return 0, fmt.Errorf("unexpected status from Prometheus: %s", resp.Status)
+		}
+
+		var result struct {
+			Status string `json:"status"`
+			Data   struct {
+				Result []struct {
+					Value []interface{} `json:"value"`
+				} `json:"result"`
+			} `json:"data"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return 0, err
+		}
+
+		// No samples means the App didn't receive any requests.
+		if len(result.Data.Result) == 0 {
+			return 0, nil
+		}
+
+		value := result.Data.Result[0].Value
+		if len(value) != 2 {
+			return 0, errors.New("malformed Prometheus sample")
+		}
+
+		str, ok := value[1].(string)
+		if !ok {
+			return 0, errors.New("malformed Prometheus sample")
+		}
+
+		rate, err := strconv.ParseFloat(str, 64)
+		if err != nil {
+			return 0, err
+		}
+
+		// Division by zero returns NaN if there was no traffic.
+		if math.IsNaN(rate) {
+			return 0, nil
+		}
+
+		return rate, nil
+	}
+}
@@ -0,0 +1,449 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package route
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/ptr"
+)
+
+func TestNextCanaryStatus(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, time.June, 1, 12, 0, 0, 0, time.UTC)
+	minuteAgo := &metav1.Time{Time: now.Add(-time.Minute)}
+	secondsAgo := &metav1.Time{Time: now.Add(-10 * time.Second)}
+
+	makeApp := func(status corev1.ConditionStatus) *v1alpha1.App {
+		app := &v1alpha1.App{}
+		app.Name = "canary"
+		app.Namespace = "some-space"
+		app.Status.SetConditions(apis.Conditions{
+			{Type: v1alpha1.AppConditionReady, Status: status, Message: "some-message"},
+		})
+		return app
+	}
+
+	rollout := &v1alpha1.RouteCanaryRollout{
+		StepWeight:      20,
+		Interval:        metav1.Duration{Duration: time.Minute},
+		MaxErrorPercent: ptr.Int32(5),
+	}
+
+	canary := &v1alpha1.RouteCanary{
+		AppName: "canary",
+		Weight:  10,
+		Rollout: rollout,
+	}
+
+	manual := &v1alpha1.RouteCanary{AppName: "canary", Weight: 30}
+
+	progressing := func(weight int32, lastStep *metav1.Time) *v1alpha1.RouteCanaryStatus {
+		return &v1alpha1.RouteCanaryStatus{
+			AppName:        "canary",
+			Weight:         weight,
+			Phase:          v1alpha1.RouteCanaryPhaseProgressing,
+			LastStepTime:   lastStep,
+			ObservedCanary: canary,
+		}
+	}
+
+	errorRate := func(rate float64, err error) errorRateFunc {
+		return func(ctx context.Context, namespace, appName string, window time.Duration) (float64, error) {
+			testutil.AssertEqual(t, "namespace", "some-space", namespace)
+			testutil.AssertEqual(t, "appName", "canary", appName)
+			testutil.AssertEqual(t, "window", time.Minute, window)
+			return rate, err
+		}
+	}
+
+	cases := map[string]struct {
+		in            canaryInput
+		wantStatus    *v1alpha1.RouteCanaryStatus
+		wantRequeueIn time.Duration
+	}{
+		"no canary": {
+			in:         canaryInput{now: now},
+			wantStatus: nil,
+		},
+		"manual canary holds weight": {
+			in: canaryInput{
+				canary: manual,
+				app:    makeApp(corev1.ConditionTrue),
+				bound:  true,
+				now:    now,
+			},
+			wantStatus: &v1alpha1.RouteCanaryStatus{
+				AppName:        "canary",
+				Weight:         30,
+				Phase:          v1alpha1.RouteCanaryPhaseHolding,
+				LastStepTime:   &metav1.Time{Time: now},
+				ObservedCanary: manual,
+			},
+		},
+		"manual canary not mapped": {
+			in: canaryInput{
+				canary: manual,
+				now:    now,
+			},
+			wantStatus: &v1alpha1.RouteCanaryStatus{
+				AppName:        "canary",
+				Weight:         30,
+				Phase:          v1alpha1.RouteCanaryPhaseHolding,
+				LastStepTime:   &metav1.Time{Time: now},
+				Message:        `App "canary" isn't mapped to the Route`,
+				ObservedCanary: manual,
+			},
+		},
+		"rollout starts at spec weight": {
+			in: canaryInput{
+				canary: canary,
+				app:    makeApp(corev1.ConditionTrue),
+				bound:  true,
+				now:    now,
+			},
+			wantStatus:    progressing(10, &metav1.Time{Time: now}),
+			wantRequeueIn: time.Minute,
+		},
+		"rollout waits for interval": {
+			in: canaryInput{
+				canary:   canary,
+				previous: progressing(30, secondsAgo),
+				app:      makeApp(corev1.ConditionTrue),
+				bound:    true,
+				now:      now,
+			},
+			wantStatus:    progressing(30, secondsAgo),
+			wantRequeueIn: 50 * time.Second,
+		},
+		"rollout steps weight": {
+			in: canaryInput{
+				canary:    canary,
+				previous:  progressing(30, minuteAgo),
+				app:       makeApp(corev1.ConditionTrue),
+				bound:     true,
+				errorRate: errorRate(1, nil),
+				now:       now,
+			},
+			wantStatus:    progressing(50, &metav1.Time{Time: now}),
+			wantRequeueIn: time.Minute,
+		},
+		"rollout promotes": {
+			in: canaryInput{
+				canary:   canary,
+				previous: progressing(90, minuteAgo),
+				app:      makeApp(corev1.ConditionTrue),
+				bound:    true,
+				now:      now,
+			},
+			wantStatus: &v1alpha1.RouteCanaryStatus{
+				AppName:        "canary",
+				Weight:         100,
+				Phase:          v1alpha1.RouteCanaryPhasePromoted,
+				LastStepTime:   &metav1.Time{Time: now},
+				Message:        "All traffic is sent to the canary App",
+				ObservedCanary: canary,
+			},
+		},
+		"promoted rollout is final": {
+			in: canaryInput{
+				canary: canary,
+				previous: &v1alpha1.RouteCanaryStatus{
+					AppName:      "canary",
+					Weight:       100,
+					Phase:        v1alpha1.RouteCanaryPhasePromoted,
+					LastStepTime: minuteAgo,
+				},
+				app:   makeApp(corev1.ConditionFalse),
+				bound: true,
+				now:   now,
+			},
+			wantStatus: &v1alpha1.RouteCanaryStatus{
+				AppName:        "canary",
+				Weight:         100,
+				Phase:          v1alpha1.RouteCanaryPhasePromoted,
+				LastStepTime:   minuteAgo,
+				ObservedCanary: canary,
+			},
+		},
+		"canary change restarts rollout": {
+			in: canaryInput{
+				canary: canary,
+				previous: &v1alpha1.RouteCanaryStatus{
+					AppName:        "canary",
+					Weight:         0,
+					Phase:          v1alpha1.RouteCanaryPhaseAborted,
+					LastStepTime:   minuteAgo,
+					ObservedCanary: &v1alpha1.RouteCanary{AppName: "canary", Weight: 5, Rollout: rollout},
+				},
+				app:   makeApp(corev1.ConditionTrue),
+				bound: true,
+				now:   now,
+			},
+			wantStatus:    progressing(10, &metav1.Time{Time: now}),
+			wantRequeueIn: time.Minute,
+		},
+		"unchanged canary keeps aborted rollout": {
+			in: canaryInput{
+				canary: canary,
+				previous: &v1alpha1.RouteCanaryStatus{
+					AppName:        "canary",
+					Weight:         0,
+					Phase:          v1alpha1.RouteCanaryPhaseAborted,
+					LastStepTime:   minuteAgo,
+					ObservedCanary: canary,
+				},
+				app:   makeApp(corev1.ConditionTrue),
+				bound: true,
+				now:   now,
+			},
+			wantStatus: &v1alpha1.RouteCanaryStatus{
+				AppName:        "canary",
+				Weight:         0,
+				Phase:          v1alpha1.RouteCanaryPhaseAborted,
+				LastStepTime:   minuteAgo,
+				ObservedCanary: canary,
+			},
+		},
+		"unhealthy app aborts": {
+			in: canaryInput{
+				canary:   canary,
+				previous: progressing(30, secondsAgo),
+				app:      makeApp(corev1.ConditionFalse),
+				bound:    true,
+				now:      now,
+			},
+			wantStatus: &v1alpha1.RouteCanaryStatus{
+				AppName:        "canary",
+				Weight:         0,
+				Phase:          v1alpha1.RouteCanaryPhaseAborted,
+				LastStepTime:   &metav1.Time{Time: now},
+				Message:        "Canary App became unhealthy: some-message",
+				ObservedCanary: canary,
+			},
+		},
+		"unready app waits": {
+			in: canaryInput{
+				canary:   canary,
+				previous: progressing(30, minuteAgo),
+				app:      makeApp(corev1.ConditionUnknown),
+				bound:    true,
+				now:      now,
+			},
+			wantStatus: &v1alpha1.RouteCanaryStatus{
+				AppName:        "canary",
+				Weight:         30,
+				Phase:          v1alpha1.RouteCanaryPhaseProgressing,
+				LastStepTime:   minuteAgo,
+				Message:        "Waiting for the canary App to become ready",
+				ObservedCanary: canary,
+			},
+			wantRequeueIn: time.Minute,
+		},
+		"unmapped app waits": {
+			in: canaryInput{
+				canary:   canary,
+				previous: progressing(30, minuteAgo),
+				now:      now,
+			},
+			wantStatus: &v1alpha1.RouteCanaryStatus{
+				AppName:        "canary",
+				Weight:         30,
+				Phase:          v1alpha1.RouteCanaryPhaseProgressing,
+				LastStepTime:   minuteAgo,
+				Message:        `Waiting for App "canary" to be mapped to the Route`,
+				ObservedCanary: canary,
+			},
+			wantRequeueIn: time.Minute,
+		},
+		"high error rate aborts": {
+			in: canaryInput{
+				canary:    canary,
+				previous:  progressing(30, minuteAgo),
+				app:       makeApp(corev1.ConditionTrue),
+				bound:     true,
+				errorRate: errorRate(12.5, nil),
+				now:       now,
+			},
+			wantStatus: &v1alpha1.RouteCanaryStatus{
+				AppName:        "canary",
+				Weight:         0,
+				Phase:          v1alpha1.RouteCanaryPhaseAborted,
+				LastStepTime:   &metav1.Time{Time: now},
+				Message:        "Canary App error rate 12.50% exceeded 5%",
+				ObservedCanary: canary,
+			},
+		},
+		"error rate check fails": {
+			in: canaryInput{
+				canary:    canary,
+				previous:  progressing(30, minuteAgo),
+				app:       makeApp(corev1.ConditionTrue),
+				bound:     true,
+				errorRate: errorRate(0, errors.New("some-error")),
+				now:       now,
+			},
+			wantStatus: &v1alpha1.RouteCanaryStatus{
+				AppName:        "canary",
+				Weight:         30,
+				Phase:          v1alpha1.RouteCanaryPhaseProgressing,
+				LastStepTime:   minuteAgo,
+				Message:        "Failed to check the canary App's error rate: some-error",
+				ObservedCanary: canary,
+			},
+			wantRequeueIn: time.Minute,
+		},
+		"error rate check pending": {
+			in: canaryInput{
+				canary:    canary,
+				previous:  progressing(30, minuteAgo),
+				app:       makeApp(corev1.ConditionTrue),
+				bound:     true,
+				errorRate: errorRate(0, errErrorRatePending),
+				now:       now,
+			},
+			wantStatus: &v1alpha1.RouteCanaryStatus{
+				AppName:        "canary",
+				Weight:         30,
+				Phase:          v1alpha1.RouteCanaryPhaseProgressing,
+				LastStepTime:   minuteAgo,
+				Message:        "Checking the canary App's error rate",
+				ObservedCanary: canary,
+			},
+			wantRequeueIn: errorRatePollInterval,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			gotStatus, gotRequeueIn := nextCanaryStatus(context.Background(), tc.in)
+			testutil.AssertEqual(t, "status", tc.wantStatus, gotStatus)
+			testutil.AssertEqual(t, "requeueIn", tc.wantRequeueIn, gotRequeueIn)
+		})
+	}
+}
+
+func TestErrorRateCache(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	calls := make(chan struct{}, 10)
+	fetch := func(ctx context.Context, namespace, appName string, window time.Duration) (float64, error) {
+		calls <- struct{}{}
+		<-release
+		return 2.5, nil
+	}
+
+	errorRate := newErrorRateCache().wrap(fetch)
+	getRate := func() (float64, error) {
+		return errorRate(context.Background(), "some-space", "canary", time.Minute)
+	}
+
+	// The first call starts the query without waiting for it.
+	_, err := getRate()
+	testutil.AssertErrorsEqual(t, errErrorRatePending, err)
+	<-calls
+
+	// Calls while the query is in flight don't start another.
+	_, err = getRate()
+	testutil.AssertErrorsEqual(t, errErrorRatePending, err)
+
+	close(release)
+
+	var rate float64
+	for {
+		rate, err = getRate()
+		if err != errErrorRatePending {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	testutil.AssertNil(t, "err", err)
+	testutil.AssertEqual(t, "rate", 2.5, rate)
+	testutil.AssertEqual(t, "extra queries", 0, len(calls))
+
+	// The result is only returned once, the next call starts a new query.
+	_, err = getRate()
+	testutil.AssertErrorsEqual(t, errErrorRatePending, err)
+	<-calls
+}
+
+func TestPrometheusErrorRate(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		status   int
+		body     string
+		wantRate float64
+		wantErr  error
+	}{
+		"rate": {
+			status:   http.StatusOK,
+			body:     `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1654084800,"2.5"]}]}}`,
+			wantRate: 2.5,
+		},
+		"no traffic": {
+			status:   http.StatusOK,
+			body:     `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+			wantRate: 0,
+		},
+		"no requests": {
+			status:   http.StatusOK,
+			body:     `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1654084800,"NaN"]}]}}`,
+			wantRate: 0,
+		},
+		"server error": {
+			status:  http.StatusInternalServerError,
+			wantErr: errors.New("unexpected status from Prometheus: 500 Internal Server Error"),
+		},
+		"malformed sample": {
+			status:  http.StatusOK,
+			body:    `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[]}]}}`,
+			wantErr: errors.New("malformed Prometheus sample"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				testutil.AssertEqual(t, "path", "/api/v1/query", r.URL.Path)
+				testutil.AssertContainsAll(t, r.URL.Query().Get("query"), []string{
+					`destination_workload_namespace="some-space"`,
+					`destination_workload="canary"`,
+					`[60s]`,
+				})
+				w.WriteHeader(tc.status)
+				fmt.Fprint(w, tc.body)
+			}))
+			defer server.Close()
+
+			rate, err := prometheusErrorRate(server.Client(), server.URL)(context.Background(), "some-space", "canary", time.Minute)
+			testutil.AssertErrorsEqual(t, tc.wantErr, err)
+			testutil.AssertEqual(t, "rate", tc.wantRate, rate)
+		})
+	}
+}
@@ -25,28 +25,53 @@ import (
 	routeinformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/route"
 	serviceinstancebindinginformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/serviceinstancebinding"
 	spaceinformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/space"
+	kflisters "github.com/google/kf/v2/pkg/client/kf/listers/kf/v1alpha1"
 	networkingclient "github.com/google/kf/v2/pkg/client/networking/injection/client"
+	envoyfilterinformer "github.com/google/kf/v2/pkg/client/networking/injection/informers/networking/v1alpha3/envoyfilter"
+	gatewayinformer "github.com/google/kf/v2/pkg/client/networking/injection/informers/networking/v1alpha3/gateway"
+	serviceentryinformer "github.com/google/kf/v2/pkg/client/networking/injection/informers/networking/v1alpha3/serviceentry"
 	virtualserviceinformer "github.com/google/kf/v2/pkg/client/networking/injection/informers/networking/v1alpha3/virtualservice"
 	"github.com/google/kf/v2/pkg/reconciler"
 	"github.com/google/kf/v2/pkg/reconciler/reconcilerutil"
 	"github.com/google/kf/v2/pkg/reconciler/route/resources"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	types "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/cache"
+	serviceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/service"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 )
 
+// SharedSpaceIndex is the name of the Route informer index holding the Spaces
+// Routes are shared with.
+const SharedSpaceIndex = "sharedSpace"
+
+// IndexBySharedSpace is a cache.IndexFunc that indexes Routes by the Spaces
+// they're shared with.
+func IndexBySharedSpace(obj interface{}) ([]string, error) {
+	route, ok := obj.(*v1alpha1.Route)
+	if !ok {
+		return nil, nil
+	}
+	return route.Spec.SharedSpaces, nil
+}
+
 // NewController creates a new controller capable of reconciling Kf Routes.
 func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
 	logger := reconciler.NewControllerLogger(ctx, "routes.kf.dev")
 
 	// Get informers off context
 	vsInformer := virtualserviceinformer.Get(ctx)
+	gatewayInformer := gatewayinformer.Get(ctx)
+	serviceEntryInformer := serviceentryinformer.Get(ctx)
+	envoyFilterInformer := envoyfilterinformer.Get(ctx)
 	routeInformer := routeinformer.Get(ctx)
 	appInformer := appinformer.Get(ctx)
 	spaceInformer := spaceinformer.Get(ctx)
 	serviceInstanceBindingInformer := serviceinstancebindinginformer.Get(ctx)
+	serviceInformer := serviceinformer.Get(ctx)
 
 	// Setting up ConfigMap receivers
 	kfConfigStore := config.NewStore(logger.Named("kf-config-store"))
@@ -59,9 +84,14 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		spaceLister:                  spaceInformer.Lister(),
 		routeLister:                  routeInformer.Lister(),
 		virtualServiceLister:         vsInformer.Lister(),
+		gatewayLister:                gatewayInformer.Lister(),
+		serviceEntryLister:           serviceEntryInformer.Lister(),
+		envoyFilterLister:            envoyFilterInformer.Lister(),
 		networkingClientSet:          networkingclient.Get(ctx),
 		serviceInstanceBindingLister: serviceInstanceBindingInformer.Lister(),
+		serviceLister:                serviceInformer.Lister(),
 		kfConfigStore:                kfConfigStore,
+		canaryErrorRates:             newErrorRateCache(),
 	}
 
 	impl := controller.NewContext(ctx, c, controller.ControllerOptions{
@@ -79,8 +109,29 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		controller.HandleAll(enqueue),
 	)
 
+	if err := routeInformer.Informer().AddIndexers(cache.Indexers{
+		SharedSpaceIndex: IndexBySharedSpace,
+	}); err != nil {
+		logger.Panicf("adding Route indexer: %v", err)
+	}
+
+	appInformer.Informer().AddEventHandler(
+		controller.HandleAll(EnqueueRoutesSharedWithApp(routeInformer.Informer().GetIndexer(), impl.EnqueueKey)),
+	)
+
+	routeInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: enqueue,
+		// Enqueue the old Route too so Spaces it's no longer shared with
+		// start serving their own Routes again.
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			enqueue(oldObj)
+			enqueue(newObj)
+		},
+		DeleteFunc: enqueue,
+	})
+
 	routeInformer.Informer().AddEventHandler(
-		controller.HandleAll(enqueue),
+		controller.HandleAll(EnqueueRoutesReservingPort(routeInformer.Lister(), impl.EnqueueKey)),
 	)
 
 	vsInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
@@ -88,6 +139,21 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		Handler:    controller.HandleAll(EnqueueRoutesOfVirtualService(enqueue)),
 	})
 
+	gatewayInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: FilterGatewayManagedByKf(),
+		Handler:    controller.HandleAll(enqueue),
+	})
+
+	serviceEntryInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: FilterServiceEntryManagedByKf(),
+		Handler:    controller.HandleAll(enqueue),
+	})
+
+	envoyFilterInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: FilterEnvoyFilterManagedByKf(),
+		Handler:    controller.HandleAll(enqueue),
+	})
+
 	serviceInstanceBindingInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 		// Accept all service instance bindings that bind a service to a route
 		FilterFunc: func(obj interface{}) bool {
@@ -125,6 +191,21 @@ func BuildEnqueuer(enqueue func(types.NamespacedName)) func(interface{}) error {
 				Namespace: r.GetNamespace(),
 				Name:      r.Spec.RouteSpecFields.Domain,
 			})
+			// Spaces the Route is shared with stop serving their own copy.
+			for _, space := range r.Spec.SharedSpaces {
+				enqueue(types.NamespacedName{
+					Namespace: space,
+					Name:      r.Spec.RouteSpecFields.Domain,
+				})
+			}
+			// The Space that shared the Route starts serving it once it's
+			// accepted.
+			if r.Spec.SharedFrom != "" {
+				enqueue(types.NamespacedName{
+					Namespace: r.Spec.SharedFrom,
+					Name:      r.Spec.RouteSpecFields.Domain,
+				})
+			}
 		case *networking.VirtualService:
 			if domain, ok := r.Annotations[resources.DomainAnnotation]; ok {
 				enqueue(types.NamespacedName{
@@ -132,6 +213,31 @@ func BuildEnqueuer(enqueue func(types.NamespacedName)) func(interface{}) error {
 					Name:      domain,
 				})
 			}
+		case *networking.Gateway:
+			if domain, ok := r.Annotations[resources.DomainAnnotation]; ok {
+				enqueue(types.NamespacedName{
+					Namespace: r.GetNamespace(),
+					Name:      domain,
+				})
+			}
+		case *networking.ServiceEntry:
+			if domain, ok := r.Annotations[resources.DomainAnnotation]; ok {
+				enqueue(types.NamespacedName{
+					Namespace: r.GetNamespace(),
+					Name:      domain,
+				})
+			}
+		case *networking.EnvoyFilter:
+			// EnvoyFilters live in the ingress gateway's Namespace, the
+			// annotation holds the Space of their Routes.
+			domain, hasDomain := r.Annotations[resources.DomainAnnotation]
+			space, hasSpace := r.Annotations[resources.SpaceAnnotation]
+			if hasDomain && hasSpace {
+				enqueue(types.NamespacedName{
+					Namespace: space,
+					Name:      domain,
+				})
+			}
 		case *v1alpha1.ServiceInstanceBinding:
 			routeSpecFields := r.Spec.Route
 			if routeSpecFields != nil {
@@ -163,6 +269,52 @@ func FilterVSManagedByKf() func(obj interface{}) bool {
 	}
 }
 
+// FilterGatewayManagedByKf makes it simple to create FilterFunc's for use with
+// cache.FilteringResourceEventHandler that filter based on the
+// "app.kubernetes.io/managed-by": "kf" label and if the type is a Gateway.
+func FilterGatewayManagedByKf() func(obj interface{}) bool {
+	return func(obj interface{}) bool {
+		if object, ok := obj.(metav1.Object); ok {
+			if "kf" == object.GetLabels()[v1alpha1.ManagedByLabel] {
+				_, ok := obj.(*networking.Gateway)
+				return ok
+			}
+		}
+		return false
+	}
+}
+
+// FilterServiceEntryManagedByKf makes it simple to create FilterFunc's for use
+// with cache.FilteringResourceEventHandler that filter based on the
+// "app.kubernetes.io/managed-by": "kf" label and if the type is a ServiceEntry.
+func FilterServiceEntryManagedByKf() func(obj interface{}) bool {
+	return func(obj interface{}) bool {
+		if object, ok := obj.(metav1.Object); ok {
+			if "kf" == object.GetLabels()[v1alpha1.ManagedByLabel] {
+				_, ok := obj.(*networking.ServiceEntry)
+				return ok
+			}
+		}
+		return false
+	}
+}
+
+// FilterEnvoyFilterManagedByKf makes it simple to create FilterFunc's for use
+// with cache.FilteringResourceEventHandler that filter based on the
+// "app.kubernetes.io/managed-by": "kf" label and if the type is an
+// EnvoyFilter.
+func FilterEnvoyFilterManagedByKf() func(obj interface{}) bool {
+	return func(obj interface{}) bool {
+		if object, ok := obj.(metav1.Object); ok {
+			if "kf" == object.GetLabels()[v1alpha1.ManagedByLabel] {
+				_, ok := obj.(*networking.EnvoyFilter)
+				return ok
+			}
+		}
+		return false
+	}
+}
+
 // EnqueueRoutesOfVirtualService will find the corresponding routes for the
 // VirtualService.  It will Enqueue a key for each one. We aren't able to use
 // EnqueueControllerOf (as other components do), because a VirtualService is
@@ -181,3 +333,78 @@ func EnqueueRoutesOfVirtualService(enqueue func(interface{})) func(obj interface
 		return
 	}
 }
+
+// EnqueueRoutesSharedWithApp enqueues the domains of Routes in other Spaces
+// that are shared with the App's Space and bound to the App, so the
+// VirtualService that serves them picks up the App's bindings. The indexer
+// must have the SharedSpaceIndex.
+func EnqueueRoutesSharedWithApp(
+	routeIndexer cache.Indexer,
+	enqueue func(types.NamespacedName),
+) func(obj interface{}) {
+	return func(obj interface{}) {
+		app, ok := obj.(*v1alpha1.App)
+		if !ok {
+			return
+		}
+
+		bound := sets.NewString()
+		for _, binding := range app.Status.Routes {
+			bound.Insert(binding.Source.String())
+		}
+
+		if bound.Len() == 0 {
+			return
+		}
+
+		objs, err := routeIndexer.ByIndex(SharedSpaceIndex, app.Namespace)
+		if err != nil {
+			return
+		}
+
+		for _, obj := range objs {
+			route, ok := obj.(*v1alpha1.Route)
+			if !ok || route.Namespace == app.Namespace {
+				continue
+			}
+
+			if bound.Has(route.Spec.RouteSpecFields.String()) {
+				enqueue(types.NamespacedName{
+					Namespace: route.Namespace,
+					Name:      route.Spec.RouteSpecFields.Domain,
+				})
+			}
+		}
+	}
+}
+
+// EnqueueRoutesReservingPort returns a function that enqueues the domains of
+// Routes in other Spaces reserving the same TCP port as a Route so they pick
+// up the port when the Space holding it releases it.
+func EnqueueRoutesReservingPort(
+	routeLister kflisters.RouteLister,
+	enqueue func(types.NamespacedName),
+) func(obj interface{}) {
+	return func(obj interface{}) {
+		changed, ok := obj.(*v1alpha1.Route)
+		if !ok || !changed.Spec.IsTCP() {
+			return
+		}
+
+		routes, err := routeLister.List(labels.Everything())
+		if err != nil {
+			return
+		}
+
+		for _, route := range routes {
+			if route.Namespace == changed.Namespace || route.Spec.Port != changed.Spec.Port {
+				continue
+			}
+
+			enqueue(types.NamespacedName{
+				Namespace: route.Namespace,
+				Name:      route.Spec.RouteSpecFields.Domain,
+			})
+		}
+	}
+}
@@ -20,6 +20,7 @@ import (
 
 	v1alpha1 "github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
 	networking "github.com/google/kf/v2/pkg/apis/networking/v1alpha3"
+	kflisters "github.com/google/kf/v2/pkg/client/kf/listers/kf/v1alpha1"
 	"github.com/google/kf/v2/pkg/kf/testutil"
 	"github.com/google/kf/v2/pkg/reconciler/route/resources"
 	corev1 "k8s.io/api/core/v1"
@@ -78,6 +79,76 @@ func TestBuildEnqueuer(t *testing.T) {
 				{Namespace: "some-namespace", Name: "some-domain"},
 			},
 		},
+		"shared route": {
+			obj: &v1alpha1.Route{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "some-namespace"},
+				Spec: v1alpha1.RouteSpec{
+					RouteSpecFields: v1alpha1.RouteSpecFields{
+						Domain: "some-domain",
+					},
+					SharedSpaces: []string{"other-namespace"},
+				},
+			},
+			wantEnqueued: []types.NamespacedName{
+				{Namespace: "some-namespace", Name: "some-domain"},
+				{Namespace: "other-namespace", Name: "some-domain"},
+			},
+		},
+		"route accepting a share": {
+			obj: &v1alpha1.Route{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "some-namespace"},
+				Spec: v1alpha1.RouteSpec{
+					RouteSpecFields: v1alpha1.RouteSpecFields{
+						Domain: "some-domain",
+					},
+					SharedFrom: "owner-namespace",
+				},
+			},
+			wantEnqueued: []types.NamespacedName{
+				{Namespace: "some-namespace", Name: "some-domain"},
+				{Namespace: "owner-namespace", Name: "some-domain"},
+			},
+		},
+		"gateway": {
+			obj: &networking.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "some-namespace",
+					Annotations: map[string]string{
+						resources.DomainAnnotation: "tcp.example.com",
+					},
+				},
+			},
+			wantEnqueued: []types.NamespacedName{
+				{Namespace: "some-namespace", Name: "tcp.example.com"},
+			},
+		},
+		"serviceentry": {
+			obj: &networking.ServiceEntry{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "some-namespace",
+					Annotations: map[string]string{
+						resources.DomainAnnotation: "apps.internal",
+					},
+				},
+			},
+			wantEnqueued: []types.NamespacedName{
+				{Namespace: "some-namespace", Name: "apps.internal"},
+			},
+		},
+		"envoyfilter": {
+			obj: &networking.EnvoyFilter{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "istio-system",
+					Annotations: map[string]string{
+						resources.DomainAnnotation: "example.com",
+						resources.SpaceAnnotation:  "some-namespace",
+					},
+				},
+			},
+			wantEnqueued: []types.NamespacedName{
+				{Namespace: "some-namespace", Name: "example.com"},
+			},
+		},
 		"unhandled type": {
 			wantErr: errors.New("unexpected type: int"),
 			obj:     99,
@@ -130,6 +201,78 @@ func TestFilterVSManagedByKf(t *testing.T) {
 	testutil.AssertEqual(t, "correct everything", true, f(buildVS("kf")))
 }
 
+func TestFilterGatewayManagedByKf(t *testing.T) {
+	t.Parallel()
+
+	buildGateway := func(managedBy string) *networking.Gateway {
+		return &networking.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{
+					v1alpha1.ManagedByLabel: managedBy,
+				},
+			},
+		}
+	}
+
+	f := FilterGatewayManagedByKf()
+	testutil.AssertEqual(t, "non metav1.Object", false, f(99))
+	testutil.AssertEqual(t, "wrong managed-by label", false, f(buildGateway("not-kf")))
+	testutil.AssertEqual(t, "correct label, wrong type", false, f(&networking.VirtualService{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{v1alpha1.ManagedByLabel: "kf"},
+		},
+	}))
+	testutil.AssertEqual(t, "correct everything", true, f(buildGateway("kf")))
+}
+
+func TestFilterServiceEntryManagedByKf(t *testing.T) {
+	t.Parallel()
+
+	buildServiceEntry := func(managedBy string) *networking.ServiceEntry {
+		return &networking.ServiceEntry{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{
+					v1alpha1.ManagedByLabel: managedBy,
+				},
+			},
+		}
+	}
+
+	f := FilterServiceEntryManagedByKf()
+	testutil.AssertEqual(t, "non metav1.Object", false, f(99))
+	testutil.AssertEqual(t, "wrong managed-by label", false, f(buildServiceEntry("not-kf")))
+	testutil.AssertEqual(t, "correct label, wrong type", false, f(&networking.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{v1alpha1.ManagedByLabel: "kf"},
+		},
+	}))
+	testutil.AssertEqual(t, "correct everything", true, f(buildServiceEntry("kf")))
+}
+
+func TestFilterEnvoyFilterManagedByKf(t *testing.T) {
+	t.Parallel()
+
+	buildEnvoyFilter := func(managedBy string) *networking.EnvoyFilter {
+		return &networking.EnvoyFilter{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{
+					v1alpha1.ManagedByLabel: managedBy,
+				},
+			},
+		}
+	}
+
+	f := FilterEnvoyFilterManagedByKf()
+	testutil.AssertEqual(t, "non metav1.Object", false, f(99))
+	testutil.AssertEqual(t, "wrong managed-by label", false, f(buildEnvoyFilter("not-kf")))
+	testutil.AssertEqual(t, "correct label, wrong type", false, f(&networking.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{v1alpha1.ManagedByLabel: "kf"},
+		},
+	}))
+	testutil.AssertEqual(t, "correct everything", true, f(buildEnvoyFilter("kf")))
+}
+
 func TestEnqueueRoutesOfVirtualService(t *testing.T) {
 	t.Parallel()
 
@@ -170,6 +313,146 @@ func TestEnqueueRoutesOfVirtualService(t *testing.T) {
 	}
 }
 
+func TestEnqueueRoutesSharedWithApp(t *testing.T) {
+	t.Parallel()
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{
+		SharedSpaceIndex: IndexBySharedSpace,
+	})
+	for _, route := range []*v1alpha1.Route{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "owner-space", Name: "shared"},
+			Spec: v1alpha1.RouteSpec{
+				RouteSpecFields: v1alpha1.RouteSpecFields{Hostname: "app", Domain: "example.com"},
+				SharedSpaces:    []string{"app-space"},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "owner-space", Name: "not-shared"},
+			Spec: v1alpha1.RouteSpec{
+				RouteSpecFields: v1alpha1.RouteSpecFields{Hostname: "app", Domain: "other.example.com"},
+			},
+		},
+	} {
+		indexer.Add(route)
+	}
+
+	makeApp := func(domains ...string) *v1alpha1.App {
+		app := &v1alpha1.App{}
+		app.Namespace = "app-space"
+		for _, domain := range domains {
+			app.Status.Routes = append(app.Status.Routes, v1alpha1.AppRouteStatus{
+				QualifiedRouteBinding: v1alpha1.QualifiedRouteBinding{
+					Source: v1alpha1.RouteSpecFields{Hostname: "app", Domain: domain, Path: "/"},
+				},
+			})
+		}
+		return app
+	}
+
+	cases := map[string]struct {
+		obj          interface{}
+		wantEnqueued []types.NamespacedName
+	}{
+		"bound to shared route": {
+			obj: makeApp("example.com"),
+			wantEnqueued: []types.NamespacedName{
+				{Namespace: "owner-space", Name: "example.com"},
+			},
+		},
+		"bound to route that isn't shared": {
+			obj: makeApp("other.example.com"),
+		},
+		"no routes": {
+			obj: makeApp(),
+		},
+		"handle non Apps": {
+			obj: 99,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			var gotEnqueued []types.NamespacedName
+			f := EnqueueRoutesSharedWithApp(indexer, func(key types.NamespacedName) {
+				gotEnqueued = append(gotEnqueued, key)
+			})
+			f(tc.obj)
+			testutil.AssertEqual(t, "enqueued", tc.wantEnqueued, gotEnqueued)
+		})
+	}
+}
+
+func TestEnqueueRoutesReservingPort(t *testing.T) {
+	t.Parallel()
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, route := range []*v1alpha1.Route{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "other-space", Name: "same-port"},
+			Spec: v1alpha1.RouteSpec{
+				RouteSpecFields: v1alpha1.RouteSpecFields{Domain: "tcp.example.com", Port: 1024},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "other-space", Name: "other-port"},
+			Spec: v1alpha1.RouteSpec{
+				RouteSpecFields: v1alpha1.RouteSpecFields{Domain: "tcp.example.com", Port: 1025},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "my-space", Name: "same-space"},
+			Spec: v1alpha1.RouteSpec{
+				RouteSpecFields: v1alpha1.RouteSpecFields{Domain: "tcp.example.com", Port: 1024},
+			},
+		},
+	} {
+		indexer.Add(route)
+	}
+	routeLister := kflisters.NewRouteLister(indexer)
+
+	makeRoute := func(port int32) *v1alpha1.Route {
+		return &v1alpha1.Route{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "my-space", Name: "changed"},
+			Spec: v1alpha1.RouteSpec{
+				RouteSpecFields: v1alpha1.RouteSpecFields{Domain: "tcp.example.com", Port: port},
+			},
+		}
+	}
+
+	cases := map[string]struct {
+		obj          interface{}
+		wantEnqueued []types.NamespacedName
+	}{
+		"port reserved in another Space": {
+			obj: makeRoute(1024),
+			wantEnqueued: []types.NamespacedName{
+				{Namespace: "other-space", Name: "tcp.example.com"},
+			},
+		},
+		"port not reserved in another Space": {
+			obj: makeRoute(1030),
+		},
+		"HTTP route": {
+			obj: makeRoute(0),
+		},
+		"handle non Routes": {
+			obj: 99,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			var gotEnqueued []types.NamespacedName
+			f := EnqueueRoutesReservingPort(routeLister, func(key types.NamespacedName) {
+				gotEnqueued = append(gotEnqueued, key)
+			})
+			f(tc.obj)
+			testutil.AssertEqual(t, "enqueued", tc.wantEnqueued, gotEnqueued)
+		})
+	}
+}
+
 // TestEncodeDecodeKey ensures the behavior of cache.SplitMetaNamespaceKey
 // and types.NamespacedName are compatible with domains.
 func TestEncodeDecodeKey(t *testing.T) {
@@ -167,6 +167,20 @@ func (mr *FakeKfAlpha1InterfaceMockRecorder) ServiceInstanceBindings(arg0 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServiceInstanceBindings", reflect.TypeOf((*FakeKfAlpha1Interface)(nil).ServiceInstanceBindings), arg0)
 }
 
+// ServiceInstanceShares mocks base method.
+func (m *FakeKfAlpha1Interface) ServiceInstanceShares(arg0 string) v1alpha10.ServiceInstanceShareInterface {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ServiceInstanceShares", arg0)
+	ret0, _ := ret[0].(v1alpha10.ServiceInstanceShareInterface)
+	return ret0
+}
+
+// ServiceInstanceShares indicates an expected call of ServiceInstanceShares.
+func (mr *FakeKfAlpha1InterfaceMockRecorder) ServiceInstanceShares(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServiceInstanceShares", reflect.TypeOf((*FakeKfAlpha1Interface)(nil).ServiceInstanceShares), arg0)
+}
+
 // ServiceInstances mocks base method.
 func (m *FakeKfAlpha1Interface) ServiceInstances(arg0 string) v1alpha10.ServiceInstanceInterface {
 	m.ctrl.T.Helper()
@@ -237,6 +251,34 @@ func (mr *FakeKfAlpha1InterfaceMockRecorder) Tasks(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tasks", reflect.TypeOf((*FakeKfAlpha1Interface)(nil).Tasks), arg0)
 }
 
+// WorkflowRuns mocks base method.
+func (m *FakeKfAlpha1Interface) WorkflowRuns(arg0 string) v1alpha10.WorkflowRunInterface {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowRuns", arg0)
+	ret0, _ := ret[0].(v1alpha10.WorkflowRunInterface)
+	return ret0
+}
+
+// WorkflowRuns indicates an expected call of WorkflowRuns.
+func (mr *FakeKfAlpha1InterfaceMockRecorder) WorkflowRuns(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRuns", reflect.TypeOf((*FakeKfAlpha1Interface)(nil).WorkflowRuns), arg0)
+}
+
+// Workflows mocks base method.
+func (m *FakeKfAlpha1Interface) Workflows(arg0 string) v1alpha10.WorkflowInterface {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Workflows", arg0)
+	ret0, _ := ret[0].(v1alpha10.WorkflowInterface)
+	return ret0
+}
+
+// Workflows indicates an expected call of Workflows.
+func (mr *FakeKfAlpha1InterfaceMockRecorder) Workflows(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Workflows", reflect.TypeOf((*FakeKfAlpha1Interface)(nil).Workflows), arg0)
+}
+
 // FakeRouteInterface is a mock of RouteInterface interface.
 type FakeRouteInterface struct {
 	ctrl     *gomock.Controller
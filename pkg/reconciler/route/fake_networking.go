@@ -14,7 +14,7 @@
 //
 
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/google/kf/v2/pkg/client/networking/clientset/versioned/typed/networking/v1alpha3 (interfaces: NetworkingV1alpha3Interface,VirtualServiceInterface)
+// Source: github.com/google/kf/v2/pkg/client/networking/clientset/versioned/typed/networking/v1alpha3 (interfaces: NetworkingV1alpha3Interface,VirtualServiceInterface,GatewayInterface,ServiceEntryInterface,EnvoyFilterInterface)
 
 // Package route is a generated GoMock package.
 package route
@@ -55,6 +55,48 @@ func (m *FakeNetworking) EXPECT() *FakeNetworkingMockRecorder {
 	return m.recorder
 }
 
+// DestinationRules mocks base method.
+func (m *FakeNetworking) DestinationRules(arg0 string) v1alpha30.DestinationRuleInterface {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DestinationRules", arg0)
+	ret0, _ := ret[0].(v1alpha30.DestinationRuleInterface)
+	return ret0
+}
+
+// DestinationRules indicates an expected call of DestinationRules.
+func (mr *FakeNetworkingMockRecorder) DestinationRules(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DestinationRules", reflect.TypeOf((*FakeNetworking)(nil).DestinationRules), arg0)
+}
+
+// EnvoyFilters mocks base method.
+func (m *FakeNetworking) EnvoyFilters(arg0 string) v1alpha30.EnvoyFilterInterface {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnvoyFilters", arg0)
+	ret0, _ := ret[0].(v1alpha30.EnvoyFilterInterface)
+	return ret0
+}
+
+// EnvoyFilters indicates an expected call of EnvoyFilters.
+func (mr *FakeNetworkingMockRecorder) EnvoyFilters(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnvoyFilters", reflect.TypeOf((*FakeNetworking)(nil).EnvoyFilters), arg0)
+}
+
+// Gateways mocks base method.
+func (m *FakeNetworking) Gateways(arg0 string) v1alpha30.GatewayInterface {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Gateways", arg0)
+	ret0, _ := ret[0].(v1alpha30.GatewayInterface)
+	return ret0
+}
+
+// Gateways indicates an expected call of Gateways.
+func (mr *FakeNetworkingMockRecorder) Gateways(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Gateways", reflect.TypeOf((*FakeNetworking)(nil).Gateways), arg0)
+}
+
 // RESTClient mocks base method.
 func (m *FakeNetworking) RESTClient() rest.Interface {
 	m.ctrl.T.Helper()
@@ -242,3 +284,441 @@ func (mr *FakeVirtualServiceInterfaceMockRecorder) Watch(arg0, arg1 interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*FakeVirtualServiceInterface)(nil).Watch), arg0, arg1)
 }
+
+// FakeGatewayInterface is a mock of GatewayInterface interface.
+type FakeGatewayInterface struct {
+	ctrl     *gomock.Controller
+	recorder *FakeGatewayInterfaceMockRecorder
+}
+
+// FakeGatewayInterfaceMockRecorder is the mock recorder for FakeGatewayInterface.
+type FakeGatewayInterfaceMockRecorder struct {
+	mock *FakeGatewayInterface
+}
+
+// NewFakeGatewayInterface creates a new mock instance.
+func NewFakeGatewayInterface(ctrl *gomock.Controller) *FakeGatewayInterface {
+	mock := &FakeGatewayInterface{ctrl: ctrl}
+	mock.recorder = &FakeGatewayInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *FakeGatewayInterface) EXPECT() *FakeGatewayInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *FakeGatewayInterface) Create(arg0 context.Context, arg1 *v1alpha3.Gateway, arg2 v1.CreateOptions) (*v1alpha3.Gateway, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1alpha3.Gateway)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *FakeGatewayInterfaceMockRecorder) Create(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*FakeGatewayInterface)(nil).Create), arg0, arg1, arg2)
+}
+
+// Delete mocks base method.
+func (m *FakeGatewayInterface) Delete(arg0 context.Context, arg1 string, arg2 v1.DeleteOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *FakeGatewayInterfaceMockRecorder) Delete(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*FakeGatewayInterface)(nil).Delete), arg0, arg1, arg2)
+}
+
+// DeleteCollection mocks base method.
+func (m *FakeGatewayInterface) DeleteCollection(arg0 context.Context, arg1 v1.DeleteOptions, arg2 v1.ListOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollection", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCollection indicates an expected call of DeleteCollection.
+func (mr *FakeGatewayInterfaceMockRecorder) DeleteCollection(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollection", reflect.TypeOf((*FakeGatewayInterface)(nil).DeleteCollection), arg0, arg1, arg2)
+}
+
+// Get mocks base method.
+func (m *FakeGatewayInterface) Get(arg0 context.Context, arg1 string, arg2 v1.GetOptions) (*v1alpha3.Gateway, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1alpha3.Gateway)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *FakeGatewayInterfaceMockRecorder) Get(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*FakeGatewayInterface)(nil).Get), arg0, arg1, arg2)
+}
+
+// List mocks base method.
+func (m *FakeGatewayInterface) List(arg0 context.Context, arg1 v1.ListOptions) (*v1alpha3.GatewayList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].(*v1alpha3.GatewayList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *FakeGatewayInterfaceMockRecorder) List(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*FakeGatewayInterface)(nil).List), arg0, arg1)
+}
+
+// Patch mocks base method.
+func (m *FakeGatewayInterface) Patch(arg0 context.Context, arg1 string, arg2 types.PatchType, arg3 []byte, arg4 v1.PatchOptions, arg5 ...string) (*v1alpha3.Gateway, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2, arg3, arg4}
+	for _, a := range arg5 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Patch", varargs...)
+	ret0, _ := ret[0].(*v1alpha3.Gateway)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Patch indicates an expected call of Patch.
+func (mr *FakeGatewayInterfaceMockRecorder) Patch(arg0, arg1, arg2, arg3, arg4 interface{}, arg5 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2, arg3, arg4}, arg5...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*FakeGatewayInterface)(nil).Patch), varargs...)
+}
+
+// Update mocks base method.
+func (m *FakeGatewayInterface) Update(arg0 context.Context, arg1 *v1alpha3.Gateway, arg2 v1.UpdateOptions) (*v1alpha3.Gateway, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1alpha3.Gateway)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *FakeGatewayInterfaceMockRecorder) Update(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*FakeGatewayInterface)(nil).Update), arg0, arg1, arg2)
+}
+
+// Watch mocks base method.
+func (m *FakeGatewayInterface) Watch(arg0 context.Context, arg1 v1.ListOptions) (watch.Interface, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Watch", arg0, arg1)
+	ret0, _ := ret[0].(watch.Interface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Watch indicates an expected call of Watch.
+func (mr *FakeGatewayInterfaceMockRecorder) Watch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*FakeGatewayInterface)(nil).Watch), arg0, arg1)
+}
+
+// FakeServiceEntryInterface is a mock of ServiceEntryInterface interface.
+type FakeServiceEntryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *FakeServiceEntryInterfaceMockRecorder
+}
+
+// FakeServiceEntryInterfaceMockRecorder is the mock recorder for FakeServiceEntryInterface.
+type FakeServiceEntryInterfaceMockRecorder struct {
+	mock *FakeServiceEntryInterface
+}
+
+// NewFakeServiceEntryInterface creates a new mock instance.
+func NewFakeServiceEntryInterface(ctrl *gomock.Controller) *FakeServiceEntryInterface {
+	mock := &FakeServiceEntryInterface{ctrl: ctrl}
+	mock.recorder = &FakeServiceEntryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *FakeServiceEntryInterface) EXPECT() *FakeServiceEntryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *FakeServiceEntryInterface) Create(arg0 context.Context, arg1 *v1alpha3.ServiceEntry, arg2 v1.CreateOptions) (*v1alpha3.ServiceEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1alpha3.ServiceEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *FakeServiceEntryInterfaceMockRecorder) Create(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*FakeServiceEntryInterface)(nil).Create), arg0, arg1, arg2)
+}
+
+// Delete mocks base method.
+func (m *FakeServiceEntryInterface) Delete(arg0 context.Context, arg1 string, arg2 v1.DeleteOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *FakeServiceEntryInterfaceMockRecorder) Delete(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*FakeServiceEntryInterface)(nil).Delete), arg0, arg1, arg2)
+}
+
+// DeleteCollection mocks base method.
+func (m *FakeServiceEntryInterface) DeleteCollection(arg0 context.Context, arg1 v1.DeleteOptions, arg2 v1.ListOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollection", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCollection indicates an expected call of DeleteCollection.
+func (mr *FakeServiceEntryInterfaceMockRecorder) DeleteCollection(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollection", reflect.TypeOf((*FakeServiceEntryInterface)(nil).DeleteCollection), arg0, arg1, arg2)
+}
+
+// Get mocks base method.
+func (m *FakeServiceEntryInterface) Get(arg0 context.Context, arg1 string, arg2 v1.GetOptions) (*v1alpha3.ServiceEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1alpha3.ServiceEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *FakeServiceEntryInterfaceMockRecorder) Get(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*FakeServiceEntryInterface)(nil).Get), arg0, arg1, arg2)
+}
+
+// List mocks base method.
+func (m *FakeServiceEntryInterface) List(arg0 context.Context, arg1 v1.ListOptions) (*v1alpha3.ServiceEntryList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].(*v1alpha3.ServiceEntryList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *FakeServiceEntryInterfaceMockRecorder) List(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*FakeServiceEntryInterface)(nil).List), arg0, arg1)
+}
+
+// Patch mocks base method.
+func (m *FakeServiceEntryInterface) Patch(arg0 context.Context, arg1 string, arg2 types.PatchType, arg3 []byte, arg4 v1.PatchOptions, arg5 ...string) (*v1alpha3.ServiceEntry, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2, arg3, arg4}
+	for _, a := range arg5 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Patch", varargs...)
+	ret0, _ := ret[0].(*v1alpha3.ServiceEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Patch indicates an expected call of Patch.
+func (mr *FakeServiceEntryInterfaceMockRecorder) Patch(arg0, arg1, arg2, arg3, arg4 interface{}, arg5 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2, arg3, arg4}, arg5...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*FakeServiceEntryInterface)(nil).Patch), varargs...)
+}
+
+// Update mocks base method.
+func (m *FakeServiceEntryInterface) Update(arg0 context.Context, arg1 *v1alpha3.ServiceEntry, arg2 v1.UpdateOptions) (*v1alpha3.ServiceEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1alpha3.ServiceEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *FakeServiceEntryInterfaceMockRecorder) Update(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*FakeServiceEntryInterface)(nil).Update), arg0, arg1, arg2)
+}
+
+// Watch mocks base method.
+func (m *FakeServiceEntryInterface) Watch(arg0 context.Context, arg1 v1.ListOptions) (watch.Interface, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Watch", arg0, arg1)
+	ret0, _ := ret[0].(watch.Interface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Watch indicates an expected call of Watch.
+func (mr *FakeServiceEntryInterfaceMockRecorder) Watch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*FakeServiceEntryInterface)(nil).Watch), arg0, arg1)
+}
+
+// FakeEnvoyFilterInterface is a mock of EnvoyFilterInterface interface.
+type FakeEnvoyFilterInterface struct {
+	ctrl     *gomock.Controller
+	recorder *FakeEnvoyFilterInterfaceMockRecorder
+}
+
+// FakeEnvoyFilterInterfaceMockRecorder is the mock recorder for FakeEnvoyFilterInterface.
+type FakeEnvoyFilterInterfaceMockRecorder struct {
+	mock *FakeEnvoyFilterInterface
+}
+
+// NewFakeEnvoyFilterInterface creates a new mock instance.
+func NewFakeEnvoyFilterInterface(ctrl *gomock.Controller) *FakeEnvoyFilterInterface {
+	mock := &FakeEnvoyFilterInterface{ctrl: ctrl}
+	mock.recorder = &FakeEnvoyFilterInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *FakeEnvoyFilterInterface) EXPECT() *FakeEnvoyFilterInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *FakeEnvoyFilterInterface) Create(arg0 context.Context, arg1 *v1alpha3.EnvoyFilter, arg2 v1.CreateOptions) (*v1alpha3.EnvoyFilter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1alpha3.EnvoyFilter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *FakeEnvoyFilterInterfaceMockRecorder) Create(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*FakeEnvoyFilterInterface)(nil).Create), arg0, arg1, arg2)
+}
+
+// Delete mocks base method.
+func (m *FakeEnvoyFilterInterface) Delete(arg0 context.Context, arg1 string, arg2 v1.DeleteOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *FakeEnvoyFilterInterfaceMockRecorder) Delete(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*FakeEnvoyFilterInterface)(nil).Delete), arg0, arg1, arg2)
+}
+
+// DeleteCollection mocks base method.
+func (m *FakeEnvoyFilterInterface) DeleteCollection(arg0 context.Context, arg1 v1.DeleteOptions, arg2 v1.ListOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollection", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCollection indicates an expected call of DeleteCollection.
+func (mr *FakeEnvoyFilterInterfaceMockRecorder) DeleteCollection(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollection", reflect.TypeOf((*FakeEnvoyFilterInterface)(nil).DeleteCollection), arg0, arg1, arg2)
+}
+
+// Get mocks base method.
+func (m *FakeEnvoyFilterInterface) Get(arg0 context.Context, arg1 string, arg2 v1.GetOptions) (*v1alpha3.EnvoyFilter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1alpha3.EnvoyFilter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *FakeEnvoyFilterInterfaceMockRecorder) Get(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*FakeEnvoyFilterInterface)(nil).Get), arg0, arg1, arg2)
+}
+
+// List mocks base method.
+func (m *FakeEnvoyFilterInterface) List(arg0 context.Context, arg1 v1.ListOptions) (*v1alpha3.EnvoyFilterList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].(*v1alpha3.EnvoyFilterList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *FakeEnvoyFilterInterfaceMockRecorder) List(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*FakeEnvoyFilterInterface)(nil).List), arg0, arg1)
+}
+
+// Patch mocks base method.
+func (m *FakeEnvoyFilterInterface) Patch(arg0 context.Context, arg1 string, arg2 types.PatchType, arg3 []byte, arg4 v1.PatchOptions, arg5 ...string) (*v1alpha3.EnvoyFilter, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2, arg3, arg4}
+	for _, a := range arg5 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Patch", varargs...)
+	ret0, _ := ret[0].(*v1alpha3.EnvoyFilter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Patch indicates an expected call of Patch.
+func (mr *FakeEnvoyFilterInterfaceMockRecorder) Patch(arg0, arg1, arg2, arg3, arg4 interface{}, arg5 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2, arg3, arg4}, arg5...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*FakeEnvoyFilterInterface)(nil).Patch), varargs...)
+}
+
+// Update mocks base method.
+func (m *FakeEnvoyFilterInterface) Update(arg0 context.Context, arg1 *v1alpha3.EnvoyFilter, arg2 v1.UpdateOptions) (*v1alpha3.EnvoyFilter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1alpha3.EnvoyFilter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *FakeEnvoyFilterInterfaceMockRecorder) Update(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*FakeEnvoyFilterInterface)(nil).Update), arg0, arg1, arg2)
+}
+
+// Watch mocks base method.
+func (m *FakeEnvoyFilterInterface) Watch(arg0 context.Context, arg1 v1.ListOptions) (watch.Interface, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Watch", arg0, arg1)
+	ret0, _ := ret[0].(watch.Interface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Watch indicates an expected call of Watch.
+func (mr *FakeEnvoyFilterInterfaceMockRecorder) Watch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*FakeEnvoyFilterInterface)(nil).Watch), arg0, arg1)
+}
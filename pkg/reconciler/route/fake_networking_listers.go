@@ -14,7 +14,7 @@
 //
 
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/google/kf/v2/pkg/client/networking/listers/networking/v1alpha3 (interfaces: VirtualServiceLister,VirtualServiceNamespaceLister)
+// Source: github.com/google/kf/v2/pkg/client/networking/listers/networking/v1alpha3 (interfaces: VirtualServiceLister,VirtualServiceNamespaceLister,GatewayLister,GatewayNamespaceLister,ServiceEntryLister,ServiceEntryNamespaceLister,EnvoyFilterLister,EnvoyFilterNamespaceLister)
 
 // Package route is a generated GoMock package.
 package route
@@ -132,3 +132,318 @@ func (mr *FakeVirtualServiceNamespaceListerMockRecorder) List(arg0 interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*FakeVirtualServiceNamespaceLister)(nil).List), arg0)
 }
+
+// FakeGatewayLister is a mock of GatewayLister interface.
+type FakeGatewayLister struct {
+	ctrl     *gomock.Controller
+	recorder *FakeGatewayListerMockRecorder
+}
+
+// FakeGatewayListerMockRecorder is the mock recorder for FakeGatewayLister.
+type FakeGatewayListerMockRecorder struct {
+	mock *FakeGatewayLister
+}
+
+// NewFakeGatewayLister creates a new mock instance.
+func NewFakeGatewayLister(ctrl *gomock.Controller) *FakeGatewayLister {
+	mock := &FakeGatewayLister{ctrl: ctrl}
+	mock.recorder = &FakeGatewayListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *FakeGatewayLister) EXPECT() *FakeGatewayListerMockRecorder {
+	return m.recorder
+}
+
+// Gateways mocks base method.
+func (m *FakeGatewayLister) Gateways(arg0 string) v1alpha30.GatewayNamespaceLister {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Gateways", arg0)
+	ret0, _ := ret[0].(v1alpha30.GatewayNamespaceLister)
+	return ret0
+}
+
+// Gateways indicates an expected call of Gateways.
+func (mr *FakeGatewayListerMockRecorder) Gateways(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Gateways", reflect.TypeOf((*FakeGatewayLister)(nil).Gateways), arg0)
+}
+
+// List mocks base method.
+func (m *FakeGatewayLister) List(arg0 labels.Selector) ([]*v1alpha3.Gateway, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0)
+	ret0, _ := ret[0].([]*v1alpha3.Gateway)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *FakeGatewayListerMockRecorder) List(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*FakeGatewayLister)(nil).List), arg0)
+}
+
+// FakeGatewayNamespaceLister is a mock of GatewayNamespaceLister interface.
+type FakeGatewayNamespaceLister struct {
+	ctrl     *gomock.Controller
+	recorder *FakeGatewayNamespaceListerMockRecorder
+}
+
+// FakeGatewayNamespaceListerMockRecorder is the mock recorder for FakeGatewayNamespaceLister.
+type FakeGatewayNamespaceListerMockRecorder struct {
+	mock *FakeGatewayNamespaceLister
+}
+
+// NewFakeGatewayNamespaceLister creates a new mock instance.
+func NewFakeGatewayNamespaceLister(ctrl *gomock.Controller) *FakeGatewayNamespaceLister {
+	mock := &FakeGatewayNamespaceLister{ctrl: ctrl}
+	mock.recorder = &FakeGatewayNamespaceListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *FakeGatewayNamespaceLister) EXPECT() *FakeGatewayNamespaceListerMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *FakeGatewayNamespaceLister) Get(arg0 string) (*v1alpha3.Gateway, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(*v1alpha3.Gateway)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *FakeGatewayNamespaceListerMockRecorder) Get(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*FakeGatewayNamespaceLister)(nil).Get), arg0)
+}
+
+// List mocks base method.
+func (m *FakeGatewayNamespaceLister) List(arg0 labels.Selector) ([]*v1alpha3.Gateway, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0)
+	ret0, _ := ret[0].([]*v1alpha3.Gateway)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *FakeGatewayNamespaceListerMockRecorder) List(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*FakeGatewayNamespaceLister)(nil).List), arg0)
+}
+
+// FakeServiceEntryLister is a mock of ServiceEntryLister interface.
+type FakeServiceEntryLister struct {
+	ctrl     *gomock.Controller
+	recorder *FakeServiceEntryListerMockRecorder
+}
+
+// FakeServiceEntryListerMockRecorder is the mock recorder for FakeServiceEntryLister.
+type FakeServiceEntryListerMockRecorder struct {
+	mock *FakeServiceEntryLister
+}
+
+// NewFakeServiceEntryLister creates a new mock instance.
+func NewFakeServiceEntryLister(ctrl *gomock.Controller) *FakeServiceEntryLister {
+	mock := &FakeServiceEntryLister{ctrl: ctrl}
+	mock.recorder = &FakeServiceEntryListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *FakeServiceEntryLister) EXPECT() *FakeServiceEntryListerMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *FakeServiceEntryLister) List(arg0 labels.Selector) ([]*v1alpha3.ServiceEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0)
+	ret0, _ := ret[0].([]*v1alpha3.ServiceEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *FakeServiceEntryListerMockRecorder) List(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*FakeServiceEntryLister)(nil).List), arg0)
+}
+
+// ServiceEntries mocks base method.
+func (m *FakeServiceEntryLister) ServiceEntries(arg0 string) v1alpha30.ServiceEntryNamespaceLister {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ServiceEntries", arg0)
+	ret0, _ := ret[0].(v1alpha30.ServiceEntryNamespaceLister)
+	return ret0
+}
+
+// ServiceEntries indicates an expected call of ServiceEntries.
+func (mr *FakeServiceEntryListerMockRecorder) ServiceEntries(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServiceEntries", reflect.TypeOf((*FakeServiceEntryLister)(nil).ServiceEntries), arg0)
+}
+
+// FakeServiceEntryNamespaceLister is a mock of ServiceEntryNamespaceLister interface.
+type FakeServiceEntryNamespaceLister struct {
+	ctrl     *gomock.Controller
+	recorder *FakeServiceEntryNamespaceListerMockRecorder
+}
+
+// FakeServiceEntryNamespaceListerMockRecorder is the mock recorder for FakeServiceEntryNamespaceLister.
+type FakeServiceEntryNamespaceListerMockRecorder struct {
+	mock *FakeServiceEntryNamespaceLister
+}
+
+// NewFakeServiceEntryNamespaceLister creates a new mock instance.
+func NewFakeServiceEntryNamespaceLister(ctrl *gomock.Controller) *FakeServiceEntryNamespaceLister {
+	mock := &FakeServiceEntryNamespaceLister{ctrl: ctrl}
+	mock.recorder = &FakeServiceEntryNamespaceListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *FakeServiceEntryNamespaceLister) EXPECT() *FakeServiceEntryNamespaceListerMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *FakeServiceEntryNamespaceLister) Get(arg0 string) (*v1alpha3.ServiceEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(*v1alpha3.ServiceEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *FakeServiceEntryNamespaceListerMockRecorder) Get(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*FakeServiceEntryNamespaceLister)(nil).Get), arg0)
+}
+
+// List mocks base method.
+func (m *FakeServiceEntryNamespaceLister) List(arg0 labels.Selector) ([]*v1alpha3.ServiceEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0)
+	ret0, _ := ret[0].([]*v1alpha3.ServiceEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *FakeServiceEntryNamespaceListerMockRecorder) List(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*FakeServiceEntryNamespaceLister)(nil).List), arg0)
+}
+
+// FakeEnvoyFilterLister is a mock of EnvoyFilterLister interface.
+type FakeEnvoyFilterLister struct {
+	ctrl     *gomock.Controller
+	recorder *FakeEnvoyFilterListerMockRecorder
+}
+
+// FakeEnvoyFilterListerMockRecorder is the mock recorder for FakeEnvoyFilterLister.
+type FakeEnvoyFilterListerMockRecorder struct {
+	mock *FakeEnvoyFilterLister
+}
+
+// NewFakeEnvoyFilterLister creates a new mock instance.
+func NewFakeEnvoyFilterLister(ctrl *gomock.Controller) *FakeEnvoyFilterLister {
+	mock := &FakeEnvoyFilterLister{ctrl: ctrl}
+	mock.recorder = &FakeEnvoyFilterListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *FakeEnvoyFilterLister) EXPECT() *FakeEnvoyFilterListerMockRecorder {
+	return m.recorder
+}
+
+// EnvoyFilters mocks base method.
+func (m *FakeEnvoyFilterLister) EnvoyFilters(arg0 string) v1alpha30.EnvoyFilterNamespaceLister {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnvoyFilters", arg0)
+	ret0, _ := ret[0].(v1alpha30.EnvoyFilterNamespaceLister)
+	return ret0
+}
+
+// EnvoyFilters indicates an expected call of EnvoyFilters.
+func (mr *FakeEnvoyFilterListerMockRecorder) EnvoyFilters(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnvoyFilters", reflect.TypeOf((*FakeEnvoyFilterLister)(nil).EnvoyFilters), arg0)
+}
+
+// List mocks base method.
+func (m *FakeEnvoyFilterLister) List(arg0 labels.Selector) ([]*v1alpha3.EnvoyFilter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0)
+	ret0, _ := ret[0].([]*v1alpha3.EnvoyFilter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *FakeEnvoyFilterListerMockRecorder) List(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*FakeEnvoyFilterLister)(nil).List), arg0)
+}
+
+// FakeEnvoyFilterNamespaceLister is a mock of EnvoyFilterNamespaceLister interface.
+type FakeEnvoyFilterNamespaceLister struct {
+	ctrl     *gomock.Controller
+	recorder *FakeEnvoyFilterNamespaceListerMockRecorder
+}
+
+// FakeEnvoyFilterNamespaceListerMockRecorder is the mock recorder for FakeEnvoyFilterNamespaceLister.
+type FakeEnvoyFilterNamespaceListerMockRecorder struct {
+	mock *FakeEnvoyFilterNamespaceLister
+}
+
+// NewFakeEnvoyFilterNamespaceLister creates a new mock instance.
+func NewFakeEnvoyFilterNamespaceLister(ctrl *gomock.Controller) *FakeEnvoyFilterNamespaceLister {
+	mock := &FakeEnvoyFilterNamespaceLister{ctrl: ctrl}
+	mock.recorder = &FakeEnvoyFilterNamespaceListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *FakeEnvoyFilterNamespaceLister) EXPECT() *FakeEnvoyFilterNamespaceListerMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *FakeEnvoyFilterNamespaceLister) Get(arg0 string) (*v1alpha3.EnvoyFilter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(*v1alpha3.EnvoyFilter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *FakeEnvoyFilterNamespaceListerMockRecorder) Get(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*FakeEnvoyFilterNamespaceLister)(nil).Get), arg0)
+}
+
+// List mocks base method.
+func (m *FakeEnvoyFilterNamespaceLister) List(arg0 labels.Selector) ([]*v1alpha3.EnvoyFilter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0)
+	ret0, _ := ret[0].([]*v1alpha3.EnvoyFilter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *FakeEnvoyFilterNamespaceListerMockRecorder) List(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*FakeEnvoyFilterNamespaceLister)(nil).List), arg0)
+}
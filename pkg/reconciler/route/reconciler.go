@@ -17,7 +17,9 @@ package route
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"sort"
+	"time"
 
 	"github.com/google/kf/v2/pkg/apis/kf/config"
 	kfconfig "github.com/google/kf/v2/pkg/apis/kf/config"
@@ -27,18 +29,26 @@ import (
 	networkingclientset "github.com/google/kf/v2/pkg/client/networking/clientset/versioned"
 	networkinglisters "github.com/google/kf/v2/pkg/client/networking/listers/networking/v1alpha3"
 	"github.com/google/kf/v2/pkg/reconciler"
+	appresources "github.com/google/kf/v2/pkg/reconciler/app/resources"
 	"github.com/google/kf/v2/pkg/reconciler/route/resources"
+	"github.com/google/kf/v2/pkg/system"
 	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/version"
+	v1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
 	pkgreconciler "knative.dev/pkg/reconciler"
 )
 
+// canaryMetricsClient is used to fetch the error rates of canary Apps.
+var canaryMetricsClient = &http.Client{Timeout: 10 * time.Second}
+
 // Reconciler reconciles a Route object with the K8s cluster.
 type Reconciler struct {
 	*reconciler.Base
@@ -46,12 +56,20 @@ type Reconciler struct {
 	// listers index properties about resources
 	routeLister                  kflisters.RouteLister
 	virtualServiceLister         networkinglisters.VirtualServiceLister
+	gatewayLister                networkinglisters.GatewayLister
+	serviceEntryLister           networkinglisters.ServiceEntryLister
+	envoyFilterLister            networkinglisters.EnvoyFilterLister
 	networkingClientSet          networkingclientset.Interface
 	appLister                    kflisters.AppLister
 	spaceLister                  kflisters.SpaceLister
 	serviceInstanceBindingLister kflisters.ServiceInstanceBindingLister
+	serviceLister                v1listers.ServiceLister
 
 	kfConfigStore pkgreconciler.ConfigStore
+
+	// canaryErrorRates fetches the error rates of canary Apps in the
+	// background.
+	canaryErrorRates *errorRateCache
 }
 
 // Check that our Reconciler implements controller.Reconciler
@@ -133,6 +151,48 @@ func (r *Reconciler) ApplyChanges(
 	}
 	logger = logger.With(zap.Reflect("routes", routes))
 
+	// Routes shared into this Space by another one are served by the
+	// VirtualService of the Space that owns them, the Routes here only
+	// track the bindings of local Apps. Shares only apply once the local
+	// Route accepts them so other Spaces can't take over its hostname.
+	allRoutes, err := r.routeLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+
+	sharedIntoSpace := sets.NewString()
+	for _, other := range allRoutes {
+		if other.Namespace != namespace && other.Spec.Domain == domain && other.Spec.IsSharedWith(namespace) &&
+			shareAccepted(allRoutes, namespace, other) {
+			sharedIntoSpace.Insert(other.Spec.RouteSpecFields.String())
+		}
+	}
+
+	// TCP ports are opened on ingress gateways every Space shares, so Routes
+	// reserving a port another Space holds on the same gateway aren't served.
+	portKeys := r.newTCPPortKeys()
+	portHolders := tcpPortHolders(allRoutes, portKeys)
+	conflictingPorts := make(map[string]string)
+
+	servedRoutes := []*v1alpha1.Route{}
+	for _, route := range routes {
+		rsfString := route.Spec.RouteSpecFields.String()
+		if key, ok := portKeys.keyFor(route); ok {
+			if holder, held := portHolders[key]; held && holder != namespace {
+				conflictingPorts[rsfString] = holder
+				continue
+			}
+		}
+
+		if !sharedIntoSpace.Has(rsfString) {
+			servedRoutes = append(servedRoutes, route)
+		}
+	}
+	logger = logger.With(
+		zap.Reflect("sharedIntoSpace", sharedIntoSpace.List()),
+		zap.Reflect("conflictingPorts", conflictingPorts),
+	)
+
 	// Fetch Apps that are bound to the Routes.
 	apps, err := r.appLister.
 		Apps(namespace).
@@ -144,16 +204,71 @@ func (r *Reconciler) ApplyChanges(
 	// appBindings is a map of RouteSpecFields strings to bound App destinations.
 	// Keys are strings to normalize differences between equal RouteSpecFields (e.g. Path being "" or "/")
 	appBindings := make(map[string]resources.RouteBindingSlice)
+	// activatorApps are the Apps with an idle timeout, their traffic goes
+	// through the activator so they can be scaled up from zero.
+	activatorApps := sets.NewString()
+	// grpcDestinations are the destinations serving gRPC, keyed by
+	// resources.MakeDestinationKey.
+	grpcDestinations := sets.NewString()
+	// instanceApps are the Apps that can have requests sent to a specific
+	// instance mapped to the number of instances they can have.
+	instanceApps := make(map[string]int32)
 	for _, app := range apps {
 		a := app.DeepCopy()
 		a.SetDefaults(ctx)
 
+		if a.Spec.Instances.IdleTimeout != nil {
+			activatorApps.Insert(a.Name)
+		}
+
+		if a.Spec.InstanceRouting {
+			instanceApps[appresources.ServiceName(a)] = a.Spec.Instances.MaxReplicas()
+		}
+
 		for _, binding := range a.Status.Routes {
 			// Add all routes that have the same domain as the one being reconciled.
 			// Don't reconcile bindings that are orphaned to prevent infinite loops.
 			if binding.Source.Domain == domain && binding.Status != v1alpha1.RouteBindingStatusOrphaned {
 				rsfString := binding.Source.String()
 				appBindings[rsfString] = append(appBindings[rsfString], binding.Destination)
+
+				if appresources.ServicePortProtocol(a, binding.Destination.Port) == appresources.PortProtocolGRPC {
+					grpcDestinations.Insert(resources.MakeDestinationKey(binding.Destination.ServiceName, binding.Destination.Port))
+				}
+			}
+		}
+	}
+
+	// Add Apps in the Spaces that accepted the Routes shared with them,
+	// they're addressed by the fully qualified name of their Service.
+	for _, route := range servedRoutes {
+		rsfString := route.Spec.RouteSpecFields.String()
+		for _, sharedSpace := range route.Spec.SharedSpaces {
+			if !shareAccepted(allRoutes, sharedSpace, route) {
+				continue
+			}
+
+			sharedApps, err := r.appLister.
+				Apps(sharedSpace).
+				List(labels.Everything())
+			if err != nil {
+				return err
+			}
+
+			for _, app := range sharedApps {
+				for _, binding := range app.Status.Routes {
+					if binding.Source.String() != rsfString || binding.Status == v1alpha1.RouteBindingStatusOrphaned {
+						continue
+					}
+
+					destination := binding.Destination
+					destination.ServiceName = resources.MakeSharedServiceHost(app.Namespace, destination.ServiceName)
+					appBindings[rsfString] = append(appBindings[rsfString], destination)
+
+					if appresources.ServicePortProtocol(app, destination.Port) == appresources.PortProtocolGRPC {
+						grpcDestinations.Insert(resources.MakeDestinationKey(destination.ServiceName, destination.Port))
+					}
+				}
 			}
 		}
 	}
@@ -165,6 +280,65 @@ func (r *Reconciler) ApplyChanges(
 		appBindings[rsf] = appDestinations
 	}
 
+	// boundDestinations keeps the destinations as they're declared on the
+	// Apps for the Route statuses, Apps compare their bindings with them to
+	// check they propagated.
+	boundDestinations := make(map[string]resources.RouteBindingSlice)
+	for rsf, appDestinations := range appBindings {
+		boundDestinations[rsf] = appDestinations
+	}
+
+	// Advance canaries and apply their weights before building the
+	// VirtualService so it contains the traffic split.
+	var errorRate errorRateFunc
+	if configDefaults.RouteCanaryPrometheusURL != "" {
+		errorRate = r.canaryErrorRates.wrap(prometheusErrorRate(canaryMetricsClient, configDefaults.RouteCanaryPrometheusURL))
+	}
+
+	now := time.Now()
+	canaryStatuses := make(map[string]*v1alpha1.RouteCanaryStatus)
+	var requeueAfter time.Duration
+	for _, route := range routes {
+		if route.Spec.Canary == nil {
+			continue
+		}
+
+		rsfString := route.Spec.RouteSpecFields.String()
+		destinations := appBindings[rsfString]
+
+		var canaryApp *v1alpha1.App
+		for _, app := range apps {
+			if app.Name == route.Spec.Canary.AppName {
+				canaryApp = app
+				break
+			}
+		}
+
+		bound := false
+		for _, dest := range destinations {
+			if dest.ServiceName == route.Spec.Canary.AppName {
+				bound = true
+				break
+			}
+		}
+
+		canaryStatus, requeue := nextCanaryStatus(ctx, canaryInput{
+			canary:    route.Spec.Canary,
+			previous:  route.Status.Canary,
+			app:       canaryApp,
+			bound:     bound,
+			errorRate: errorRate,
+			now:       now,
+		})
+		canaryStatuses[rsfString] = canaryStatus
+		appBindings[rsfString] = resources.ApplyCanaryWeight(destinations, canaryStatus.AppName, canaryStatus.Weight)
+
+		if requeue > 0 && (requeueAfter == 0 || requeue < requeueAfter) {
+			requeueAfter = requeue
+		}
+	}
+	logger = logger.With(zap.Reflect("canaryStatuses", canaryStatuses))
+
 	// Fetch route services that are bound to the Routes with the same domain.
 	serviceBindings, err := r.serviceInstanceBindingLister.
 		ServiceInstanceBindings(namespace).
@@ -197,11 +371,14 @@ func (r *Reconciler) ApplyChanges(
 		logging.WithLogger(ctx, logger),
 		namespace,
 		domain,
-		routes,
+		servedRoutes,
 		appBindings,
 		routeServiceBindings,
 		spaceDomain,
 		configDefaults,
+		activatorApps,
+		grpcDestinations,
+		instanceApps,
 	)
 
 	// Used if the reconciler should fail based on the conditions of the Routes
@@ -230,9 +407,17 @@ func (r *Reconciler) ApplyChanges(
 		toReconcile.Status.PropagateRouteSpecFields(origRoute.Spec.RouteSpecFields)
 
 		rsfString := toReconcile.Spec.RouteSpecFields.String()
-		toReconcile.Status.PropagateBindings(appBindings[rsfString])
+		toReconcile.Status.PropagateBindings(boundDestinations[rsfString])
+		toReconcile.Status.PropagateMirrors(boundDestinations[rsfString])
 		toReconcile.Status.PropagateRouteServiceBinding(routeServiceBindings[rsfString])
 		toReconcile.Status.PropagateSpaceDomain(spaceDomain)
+		toReconcile.Status.PropagatePortHolder(conflictingPorts[rsfString])
+		if policy := toReconcile.Spec.Policy; policy != nil && policy.RateLimit != nil {
+			toReconcile.Status.PropagateRateLimit(rateLimitSupport(configDefaults))
+		} else {
+			toReconcile.Status.PropagateRateLimit(nil)
+		}
+		toReconcile.Status.Canary = canaryStatuses[rsfString]
 
 		// If we didn't change anything then don't call updateStatus.
 		// This is important because the copy we loaded from the
@@ -258,6 +443,13 @@ func (r *Reconciler) ApplyChanges(
 	if sErr != nil {
 		return fmt.Errorf("Error occurred while reconciling VirtualService: %s", sErr.Error())
 	}
+
+	if exitErr == nil && requeueAfter > 0 {
+		// Reconcile again when the next canary rollout step is due or the
+		// error rate query may have finished.
+		return controller.NewRequeueAfter(requeueAfter)
+	}
+
 	return exitErr
 }
 
@@ -270,9 +462,26 @@ func (r *Reconciler) reconcileVirtualService(
 	routeServiceBindings map[string][]v1alpha1.RouteServiceDestination,
 	spaceDomain *v1alpha1.SpaceDomain,
 	configDefaults *kfconfig.DefaultsConfig,
+	activatorApps sets.String,
+	grpcDestinations sets.String,
+	instanceApps map[string]int32,
 ) (*networking.VirtualService, error) {
 	logger := logging.FromContext(ctx)
 
+	// Reconcile the Gateway first so the ports exist before the VS routes
+	// traffic through them.
+	if err := r.reconcileGateway(ctx, namespace, domain, routes, spaceDomain); err != nil {
+		return nil, err
+	}
+
+	if err := r.reconcileServiceEntry(ctx, namespace, domain, routes, spaceDomain); err != nil {
+		return nil, err
+	}
+
+	if err := r.reconcileEnvoyFilters(ctx, namespace, domain, routes, spaceDomain, configDefaults); err != nil {
+		return nil, err
+	}
+
 	// If the domain isn't permitted or there are no routes, the VS should be
 	// removed. It will be removed anyway if there are no routes by the Kubernetes
 	// GC, however we'll do it anyway for consistency.
@@ -297,6 +506,9 @@ func (r *Reconciler) reconcileVirtualService(
 		routeServiceBindings,
 		spaceDomain,
 		configDefaults,
+		activatorApps,
+		grpcDestinations,
+		instanceApps,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("configuring: %v", err)
@@ -348,11 +560,15 @@ func (r *Reconciler) update(
 		Append("spec.gateways", desired.Spec.Gateways, actual.Spec.Gateways).
 		Append("spec.hosts", desired.Spec.Hosts, actual.Spec.Hosts)
 
-	if len(desired.Spec.Http) == len(actual.Spec.Http) {
+	if len(desired.Spec.Http) == len(actual.Spec.Http) && len(desired.Spec.Tcp) == len(actual.Spec.Tcp) {
 		for i, http := range desired.Spec.Http {
 			semanticEquality.Append(fmt.Sprintf("spec.http[%d]", i), http, actual.Spec.Http[i])
 		}
 
+		for i, tcp := range desired.Spec.Tcp {
+			semanticEquality.Append(fmt.Sprintf("spec.tcp[%d]", i), tcp, actual.Spec.Tcp[i])
+		}
+
 		if semanticEquality.IsSemanticallyEqual() {
 			return actual, nil
 		}
@@ -366,8 +582,9 @@ func (r *Reconciler) update(
 	existing.Annotations = desired.Annotations
 	existing.OwnerReferences = desired.OwnerReferences
 
-	// Set HTTP Routes, Hosts and Gateways
+	// Set HTTP Routes, TCP Routes, Hosts and Gateways
 	existing.Spec.Http = desired.Spec.Http
+	existing.Spec.Tcp = desired.Spec.Tcp
 	existing.Spec.Hosts = desired.Spec.Hosts
 	existing.Spec.Gateways = desired.Spec.Gateways
 
@@ -376,3 +593,513 @@ func (r *Reconciler) update(
 		VirtualServices(existing.GetNamespace()).
 		Update(ctx, existing, metav1.UpdateOptions{})
 }
+
+// reconcileGateway opens the ports reserved by TCP Routes on the ingress
+// gateway that serves the domain. Gateways for domains that no longer reserve
+// ports are removed.
+func (r *Reconciler) reconcileGateway(
+	ctx context.Context,
+	namespace string,
+	domain string,
+	routes []*v1alpha1.Route,
+	spaceDomain *v1alpha1.SpaceDomain,
+) error {
+	logger := logging.FromContext(ctx)
+	gatewayName := resources.MakeGatewayName(domain)
+
+	if spaceDomain == nil || !spaceDomain.IsTCP() || len(routes) == 0 {
+		actual, err := r.gatewayLister.
+			Gateways(namespace).
+			Get(gatewayName)
+		if errors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return err
+		} else if actual.GetDeletionTimestamp() != nil || actual.Labels[v1alpha1.ManagedByLabel] != "kf" {
+			// Don't remove Gateways Kf didn't create.
+			return nil
+		}
+
+		logger.Info("Deleting Gateway because the domain doesn't reserve TCP ports")
+		err = r.networkingClientSet.
+			NetworkingV1alpha3().
+			Gateways(namespace).
+			Delete(ctx, gatewayName, metav1.DeleteOptions{})
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	// The generated Gateway attaches to the same ingress pods as the Gateway
+	// configured for the domain.
+	selector, err := r.ingressSelector(spaceDomain)
+	if err != nil {
+		return err
+	}
+
+	desired, err := resources.MakeGateway(routes, selector)
+	if err != nil {
+		return fmt.Errorf("configuring Gateway: %v", err)
+	}
+
+	actual, err := r.gatewayLister.
+		Gateways(namespace).
+		Get(desired.Name)
+	if errors.IsNotFound(err) {
+		_, err = r.networkingClientSet.
+			NetworkingV1alpha3().
+			Gateways(namespace).
+			Create(ctx, desired, metav1.CreateOptions{})
+		return err
+	} else if err != nil {
+		return err
+	} else if actual.GetDeletionTimestamp() != nil {
+		return nil
+	}
+
+	semanticEquality := reconciler.NewSemanticEqualityBuilder(logger, "Gateway").
+		Append("metadata.labels", desired.ObjectMeta.Labels, actual.ObjectMeta.Labels).
+		Append("metadata.ownerReferences", desired.ObjectMeta.OwnerReferences, actual.ObjectMeta.OwnerReferences).
+		Append("spec.selector", desired.Spec.Selector, actual.Spec.Selector)
+
+	if len(desired.Spec.Servers) == len(actual.Spec.Servers) {
+		for i, server := range desired.Spec.Servers {
+			semanticEquality.Append(fmt.Sprintf("spec.servers[%d]", i), server, actual.Spec.Servers[i])
+		}
+
+		if semanticEquality.IsSemanticallyEqual() {
+			return nil
+		}
+	}
+
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+	existing.Labels = desired.Labels
+	existing.Annotations = desired.Annotations
+	existing.OwnerReferences = desired.OwnerReferences
+	existing.Spec.Selector = desired.Spec.Selector
+	existing.Spec.Servers = desired.Spec.Servers
+
+	_, err = r.networkingClientSet.
+		NetworkingV1alpha3().
+		Gateways(namespace).
+		Update(ctx, existing, metav1.UpdateOptions{})
+	return err
+}
+
+// ingressSelector returns the selector of the ingress pods that serve the
+// domain.
+func (r *Reconciler) ingressSelector(spaceDomain *v1alpha1.SpaceDomain) (map[string]string, error) {
+	ingressNamespace, ingressName, err := cache.SplitMetaNamespaceKey(spaceDomain.GatewayName)
+	if err != nil {
+		return nil, err
+	}
+	ingress, err := r.gatewayLister.
+		Gateways(ingressNamespace).
+		Get(ingressName)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get Gateway %q for the domain: %v", spaceDomain.GatewayName, err)
+	}
+
+	return ingress.Spec.Selector, nil
+}
+
+// minRateLimitIstioVersion is the first version of Istio whose Envoy creates
+// token buckets for each client of a rate limited Route.
+var minRateLimitIstioVersion = version.MustParseGeneric("1.26.0")
+
+// rateLimitSupport returns an error if the ingress gateways can't enforce Route
+// rate limits. Older versions of Envoy reject the rate limit configuration,
+// which would break the gateway's other routes.
+func rateLimitSupport(configDefaults *kfconfig.DefaultsConfig) error {
+	if configDefaults.RouteIstioVersion == "" {
+		return fmt.Errorf("rate limits need Istio %s or later, set routeIstioVersion in config-defaults to the version running the ingress gateways", minRateLimitIstioVersion)
+	}
+
+	istioVersion, err := version.ParseGeneric(configDefaults.RouteIstioVersion)
+	if err != nil {
+		return fmt.Errorf("invalid routeIstioVersion in config-defaults: %v", err)
+	}
+
+	if !istioVersion.AtLeast(minRateLimitIstioVersion) {
+		return fmt.Errorf("rate limits need Istio %s or later, the ingress gateways run %s", minRateLimitIstioVersion, istioVersion)
+	}
+
+	return nil
+}
+
+// reconcileEnvoyFilters configures the rate limits and maintenance messages of
+// Routes on the ingress gateway that serves the domain. Each Route gets its
+// own EnvoyFilter, created in the ingress gateway's Namespace because Istio
+// ignores EnvoyFilters in other Namespaces for its workloads. EnvoyFilters of
+// Routes that were deleted or don't need one anymore are removed.
+func (r *Reconciler) reconcileEnvoyFilters(
+	ctx context.Context,
+	namespace string,
+	domain string,
+	routes []*v1alpha1.Route,
+	spaceDomain *v1alpha1.SpaceDomain,
+	configDefaults *kfconfig.DefaultsConfig,
+) error {
+	logger := logging.FromContext(ctx)
+
+	ingressNamespace, ingressErr := system.GetClusterIngressNamespace(r.serviceLister)
+
+	desired := make(map[string]*networking.EnvoyFilter)
+	// Traffic on internal domains doesn't go through the ingress gateway.
+	if spaceDomain != nil && !spaceDomain.IsInternal() {
+		settings := resources.EnvoyFilterSettings{
+			RateLimits: rateLimitSupport(configDefaults) == nil,
+		}
+		if trustedHops := configDefaults.RouteRateLimitTrustedHops; trustedHops != nil {
+			settings.TrustedHops = *trustedHops
+		}
+
+		var (
+			space    *v1alpha1.Space
+			selector map[string]string
+		)
+		for _, route := range routes {
+			if !resources.NeedsEnvoyFilter(route, settings) {
+				continue
+			}
+
+			if space == nil {
+				if ingressErr != nil {
+					return fmt.Errorf("finding ingress gateway Namespace: %v", ingressErr)
+				}
+
+				var err error
+				if selector, err = r.ingressSelector(spaceDomain); err != nil {
+					return err
+				}
+				if space, err = r.spaceLister.Get(namespace); err != nil {
+					return err
+				}
+			}
+
+			envoyFilter, err := resources.MakeEnvoyFilter(space, route, ingressNamespace, selector, settings)
+			if err != nil {
+				return fmt.Errorf("configuring EnvoyFilter: %v", err)
+			}
+			desired[envoyFilter.Name] = envoyFilter
+		}
+	}
+
+	if ingressErr != nil {
+		// Without an ingress gateway there's nowhere the EnvoyFilters could
+		// have been created.
+		return nil
+	}
+
+	existing, err := r.envoyFilterLister.
+		EnvoyFilters(ingressNamespace).
+		List(labels.SelectorFromSet(labels.Set{
+			v1alpha1.ManagedByLabel: "kf",
+			v1alpha1.ComponentLabel: "envoyfilter",
+		}))
+	if err != nil {
+		return err
+	}
+
+	for _, actual := range existing {
+		if actual.Annotations[resources.SpaceAnnotation] != namespace ||
+			actual.Annotations[resources.DomainAnnotation] != domain {
+			continue
+		}
+
+		if _, ok := desired[actual.Name]; ok || actual.GetDeletionTimestamp() != nil {
+			continue
+		}
+
+		logger.Infof("Deleting EnvoyFilter %q because its Route was deleted or has no rate limit or maintenance message", actual.Name)
+		err := r.networkingClientSet.
+			NetworkingV1alpha3().
+			EnvoyFilters(ingressNamespace).
+			Delete(ctx, actual.Name, metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	var names []string
+	for name := range desired {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := r.reconcileEnvoyFilter(ctx, desired[name]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reconcileEnvoyFilter creates or updates an EnvoyFilter.
+func (r *Reconciler) reconcileEnvoyFilter(
+	ctx context.Context,
+	desired *networking.EnvoyFilter,
+) error {
+	logger := logging.FromContext(ctx)
+
+	actual, err := r.envoyFilterLister.
+		EnvoyFilters(desired.Namespace).
+		Get(desired.Name)
+	if errors.IsNotFound(err) {
+		_, err = r.networkingClientSet.
+			NetworkingV1alpha3().
+			EnvoyFilters(desired.Namespace).
+			Create(ctx, desired, metav1.CreateOptions{})
+		return err
+	} else if err != nil {
+		return err
+	} else if actual.GetDeletionTimestamp() != nil {
+		return nil
+	}
+
+	semanticEquality := reconciler.NewSemanticEqualityBuilder(logger, "EnvoyFilter").
+		Append("metadata.labels", desired.ObjectMeta.Labels, actual.ObjectMeta.Labels).
+		Append("metadata.annotations", desired.ObjectMeta.Annotations, actual.ObjectMeta.Annotations).
+		Append("metadata.ownerReferences", desired.ObjectMeta.OwnerReferences, actual.ObjectMeta.OwnerReferences).
+		Append("spec.workloadSelector", desired.Spec.WorkloadSelector, actual.Spec.WorkloadSelector)
+
+	if len(desired.Spec.ConfigPatches) == len(actual.Spec.ConfigPatches) {
+		for i, patch := range desired.Spec.ConfigPatches {
+			semanticEquality.Append(fmt.Sprintf("spec.configPatches[%d]", i), patch, actual.Spec.ConfigPatches[i])
+		}
+
+		if semanticEquality.IsSemanticallyEqual() {
+			return nil
+		}
+	}
+
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+	existing.Labels = desired.Labels
+	existing.Annotations = desired.Annotations
+	existing.OwnerReferences = desired.OwnerReferences
+	existing.Spec.WorkloadSelector = desired.Spec.WorkloadSelector
+	existing.Spec.ConfigPatches = desired.Spec.ConfigPatches
+
+	_, err = r.networkingClientSet.
+		NetworkingV1alpha3().
+		EnvoyFilters(desired.Namespace).
+		Update(ctx, existing, metav1.UpdateOptions{})
+	return err
+}
+
+// reconcileServiceEntry registers the hosts of Routes on an internal domain
+// with the mesh so they can be resolved by Apps in the cluster. ServiceEntries
+// for domains that aren't internal or have no hosts are removed.
+func (r *Reconciler) reconcileServiceEntry(
+	ctx context.Context,
+	namespace string,
+	domain string,
+	routes []*v1alpha1.Route,
+	spaceDomain *v1alpha1.SpaceDomain,
+) error {
+	logger := logging.FromContext(ctx)
+
+	var desired *networking.ServiceEntry
+	if spaceDomain != nil && spaceDomain.IsInternal() && len(routes) > 0 {
+		var err error
+		desired, err = resources.MakeServiceEntry(routes)
+		if err != nil {
+			return fmt.Errorf("configuring ServiceEntry: %v", err)
+		}
+	}
+
+	if desired == nil || len(desired.Spec.Hosts) == 0 {
+		serviceEntryName := resources.MakeServiceEntryName(domain)
+		actual, err := r.serviceEntryLister.
+			ServiceEntries(namespace).
+			Get(serviceEntryName)
+		if errors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return err
+		} else if actual.GetDeletionTimestamp() != nil || actual.Labels[v1alpha1.ManagedByLabel] != "kf" {
+			// Don't remove ServiceEntries Kf didn't create.
+			return nil
+		}
+
+		logger.Info("Deleting ServiceEntry because the domain has no internal hosts")
+		err = r.networkingClientSet.
+			NetworkingV1alpha3().
+			ServiceEntries(namespace).
+			Delete(ctx, serviceEntryName, metav1.DeleteOptions{})
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	actual, err := r.serviceEntryLister.
+		ServiceEntries(namespace).
+		Get(desired.Name)
+	if errors.IsNotFound(err) {
+		_, err = r.networkingClientSet.
+			NetworkingV1alpha3().
+			ServiceEntries(namespace).
+			Create(ctx, desired, metav1.CreateOptions{})
+		return err
+	} else if err != nil {
+		return err
+	} else if actual.GetDeletionTimestamp() != nil {
+		return nil
+	}
+
+	semanticEquality := reconciler.NewSemanticEqualityBuilder(logger, "ServiceEntry").
+		Append("metadata.labels", desired.ObjectMeta.Labels, actual.ObjectMeta.Labels).
+		Append("metadata.ownerReferences", desired.ObjectMeta.OwnerReferences, actual.ObjectMeta.OwnerReferences).
+		Append("spec.hosts", desired.Spec.Hosts, actual.Spec.Hosts).
+		Append("spec.exportTo", desired.Spec.ExportTo, actual.Spec.ExportTo).
+		Append("spec.location", desired.Spec.Location, actual.Spec.Location).
+		Append("spec.resolution", desired.Spec.Resolution, actual.Spec.Resolution)
+
+	if len(desired.Spec.Ports) == len(actual.Spec.Ports) {
+		for i, port := range desired.Spec.Ports {
+			semanticEquality.Append(fmt.Sprintf("spec.ports[%d]", i), port, actual.Spec.Ports[i])
+		}
+
+		if semanticEquality.IsSemanticallyEqual() {
+			return nil
+		}
+	}
+
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+	existing.Labels = desired.Labels
+	existing.Annotations = desired.Annotations
+	existing.OwnerReferences = desired.OwnerReferences
+	existing.Spec.Hosts = desired.Spec.Hosts
+	existing.Spec.ExportTo = desired.Spec.ExportTo
+	existing.Spec.Ports = desired.Spec.Ports
+	existing.Spec.Location = desired.Spec.Location
+	existing.Spec.Resolution = desired.Spec.Resolution
+
+	_, err = r.networkingClientSet.
+		NetworkingV1alpha3().
+		ServiceEntries(namespace).
+		Update(ctx, existing, metav1.UpdateOptions{})
+	return err
+}
+
+// shareAccepted returns true if the given Space has a Route with the same
+// fields as the shared Route that accepts being served by the shared Route's
+// Space.
+func shareAccepted(allRoutes []*v1alpha1.Route, space string, shared *v1alpha1.Route) bool {
+	rsfString := shared.Spec.RouteSpecFields.String()
+	for _, route := range allRoutes {
+		if route.Namespace == space &&
+			route.Spec.RouteSpecFields.String() == rsfString &&
+			route.Spec.AcceptsShareFrom(shared.Namespace) {
+			return true
+		}
+	}
+	return false
+}
+
+// tcpPortKey identifies a port on the ingress gateway pods matching a
+// selector.
+type tcpPortKey struct {
+	selector string
+	port     int32
+}
+
+// tcpPortKeys finds the ingress gateway pods the ports of TCP Routes are
+// opened on. Selectors are cached by Gateway so Routes on domains that share
+// a Gateway only look it up once.
+type tcpPortKeys struct {
+	r         *Reconciler
+	selectors map[string]*string
+}
+
+func (r *Reconciler) newTCPPortKeys() *tcpPortKeys {
+	return &tcpPortKeys{
+		r:         r,
+		selectors: make(map[string]*string),
+	}
+}
+
+// keyFor returns the port a TCP Route reserves on its ingress gateway. It
+// returns false if the Route isn't a TCP Route or its domain's ingress
+// gateway can't be found.
+func (k *tcpPortKeys) keyFor(route *v1alpha1.Route) (tcpPortKey, bool) {
+	if !route.Spec.IsTCP() {
+		return tcpPortKey{}, false
+	}
+
+	space, err := k.r.spaceLister.Get(route.Namespace)
+	if err != nil {
+		return tcpPortKey{}, false
+	}
+
+	var spaceDomain *v1alpha1.SpaceDomain
+	for i, sd := range space.Status.NetworkConfig.Domains {
+		if sd.Domain == route.Spec.Domain {
+			spaceDomain = &space.Status.NetworkConfig.Domains[i]
+			break
+		}
+	}
+	if spaceDomain == nil {
+		return tcpPortKey{}, false
+	}
+
+	selector, ok := k.selectors[spaceDomain.GatewayName]
+	if !ok {
+		if labelSet, err := k.r.ingressSelector(spaceDomain); err == nil {
+			str := labels.Set(labelSet).String()
+			selector = &str
+		}
+		k.selectors[spaceDomain.GatewayName] = selector
+	}
+	if selector == nil {
+		return tcpPortKey{}, false
+	}
+
+	return tcpPortKey{selector: *selector, port: route.Spec.Port}, true
+}
+
+// tcpPortHolders returns the Space holding each port reserved by the TCP
+// Routes on each ingress gateway. The oldest Route reserving a port holds it
+// so the Space that reserved it first keeps it when another Space tries to
+// reserve it.
+func tcpPortHolders(routes []*v1alpha1.Route, portKeys *tcpPortKeys) map[tcpPortKey]string {
+	oldest := make(map[tcpPortKey]*v1alpha1.Route)
+	for _, route := range routes {
+		if route.GetDeletionTimestamp() != nil {
+			continue
+		}
+
+		key, ok := portKeys.keyFor(route)
+		if !ok {
+			continue
+		}
+
+		if held, ok := oldest[key]; !ok || routeCreatedBefore(route, held) {
+			oldest[key] = route
+		}
+	}
+
+	holders := make(map[tcpPortKey]string)
+	for key, route := range oldest {
+		holders[key] = route.Namespace
+	}
+	return holders
+}
+
+// routeCreatedBefore returns true if a was created before b, ties are broken
+// by the namespace and name so the order is deterministic.
+func routeCreatedBefore(a, b *v1alpha1.Route) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	return a.Name < b.Name
+}
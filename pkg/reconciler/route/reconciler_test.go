@@ -26,22 +26,26 @@ import (
 	"github.com/google/kf/v2/pkg/apis/networking/v1alpha3"
 	"github.com/google/kf/v2/pkg/kf/testutil"
 	"github.com/google/kf/v2/pkg/reconciler"
+	"github.com/google/kf/v2/pkg/reconciler/route/resources"
 	istio "istio.io/api/networking/v1alpha3"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	types "k8s.io/apimachinery/pkg/types"
+	v1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/apis"
 	pkgreconciler "knative.dev/pkg/reconciler"
 )
 
 //go:generate mockgen --package=route --copyright_file ../../kf/internal/tools/option-builder/LICENSE_HEADER --destination=fake_listers.go --mock_names=RouteLister=FakeRouteLister,RouteNamespaceLister=FakeRouteNamespaceLister,AppLister=FakeAppLister,AppNamespaceLister=FakeAppNamespaceLister,SpaceLister=FakeSpaceLister,ServiceInstanceBindingLister=FakeServiceInstanceBindingLister,ServiceInstanceBindingNamespaceLister=FakeServiceInstanceBindingNamespaceLister github.com/google/kf/v2/pkg/client/kf/listers/kf/v1alpha1 RouteLister,RouteNamespaceLister,AppLister,AppNamespaceLister,SpaceLister,ServiceInstanceBindingLister,ServiceInstanceBindingNamespaceLister
 //go:generate mockgen --package=route --copyright_file ../../kf/internal/tools/option-builder/LICENSE_HEADER --destination=fake_corev1_listers.go --mock_names=NamespaceLister=FakeNamespaceLister k8s.io/client-go/listers/core/v1 NamespaceLister
 //go:generate mockgen --package=route --copyright_file ../../kf/internal/tools/option-builder/LICENSE_HEADER --destination=fake_networking_client.go --mock_names=Interface=FakeNetworkingClient github.com/google/kf/v2/pkg/client/networking/clientset/versioned Interface
-//go:generate mockgen --package=route --copyright_file ../../kf/internal/tools/option-builder/LICENSE_HEADER --destination=fake_networking.go --mock_names=NetworkingV1alpha3Interface=FakeNetworking,VirtualServiceInterface=FakeVirtualServiceInterface github.com/google/kf/v2/pkg/client/networking/clientset/versioned/typed/networking/v1alpha3 NetworkingV1alpha3Interface,VirtualServiceInterface
+//go:generate mockgen --package=route --copyright_file ../../kf/internal/tools/option-builder/LICENSE_HEADER --destination=fake_networking.go --mock_names=NetworkingV1alpha3Interface=FakeNetworking,VirtualServiceInterface=FakeVirtualServiceInterface,GatewayInterface=FakeGatewayInterface,ServiceEntryInterface=FakeServiceEntryInterface,EnvoyFilterInterface=FakeEnvoyFilterInterface github.com/google/kf/v2/pkg/client/networking/clientset/versioned/typed/networking/v1alpha3 NetworkingV1alpha3Interface,VirtualServiceInterface,GatewayInterface,ServiceEntryInterface,EnvoyFilterInterface
 //go:generate mockgen --package=route --copyright_file ../../kf/internal/tools/option-builder/LICENSE_HEADER --destination=fake_kf.go --mock_names=Interface=FakeKfInterface github.com/google/kf/v2/pkg/client/kf/clientset/versioned Interface
 //go:generate mockgen --package=route --copyright_file ../../kf/internal/tools/option-builder/LICENSE_HEADER --destination=fake_kf_v1alpha1.go --mock_names=KfV1alpha1Interface=FakeKfAlpha1Interface,RouteInterface=FakeRouteInterface github.com/google/kf/v2/pkg/client/kf/clientset/versioned/typed/kf/v1alpha1 KfV1alpha1Interface,RouteInterface
-//go:generate mockgen --package=route --copyright_file ../../kf/internal/tools/option-builder/LICENSE_HEADER --destination=fake_networking_listers.go --mock_names=VirtualServiceLister=FakeVirtualServiceLister,VirtualServiceNamespaceLister=FakeVirtualServiceNamespaceLister github.com/google/kf/v2/pkg/client/networking/listers/networking/v1alpha3 VirtualServiceLister,VirtualServiceNamespaceLister
+//go:generate mockgen --package=route --copyright_file ../../kf/internal/tools/option-builder/LICENSE_HEADER --destination=fake_networking_listers.go --mock_names=VirtualServiceLister=FakeVirtualServiceLister,VirtualServiceNamespaceLister=FakeVirtualServiceNamespaceLister,GatewayLister=FakeGatewayLister,GatewayNamespaceLister=FakeGatewayNamespaceLister,ServiceEntryLister=FakeServiceEntryLister,ServiceEntryNamespaceLister=FakeServiceEntryNamespaceLister,EnvoyFilterLister=FakeEnvoyFilterLister,EnvoyFilterNamespaceLister=FakeEnvoyFilterNamespaceLister github.com/google/kf/v2/pkg/client/networking/listers/networking/v1alpha3 VirtualServiceLister,VirtualServiceNamespaceLister,GatewayLister,GatewayNamespaceLister,ServiceEntryLister,ServiceEntryNamespaceLister,EnvoyFilterLister,EnvoyFilterNamespaceLister
 
 type testConfigStore struct {
 	config *config.DefaultsConfig
@@ -53,6 +57,20 @@ func (t *testConfigStore) ToContext(ctx context.Context) context.Context {
 
 var _ pkgreconciler.ConfigStore = (*testConfigStore)(nil)
 
+// ingressServiceLister returns a ServiceLister with the ingress gateway's
+// Service in the istio-system Namespace.
+func ingressServiceLister() v1listers.ServiceLister {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	indexer.Add(&corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "istio-ingressgateway",
+			Namespace: "istio-system",
+			Labels:    map[string]string{"istio": "ingressgateway"},
+		},
+	})
+	return v1listers.NewServiceLister(indexer)
+}
+
 func TestReconciler_Reconcile_badKey(t *testing.T) {
 	t.Parallel()
 
@@ -85,65 +103,940 @@ func TestReconciler_Reconcile_namespaceIsTerminating(t *testing.T) {
 		Name:      "some-domain",
 	}
 
-	testutil.AssertNil(t, "err", r.Reconcile(context.Background(), nn.String()))
-}
+	testutil.AssertNil(t, "err", r.Reconcile(context.Background(), nn.String()))
+}
+
+func TestReconciler_Reconcile_ApplyChanges(t *testing.T) {
+	t.Parallel()
+
+	type fakes struct {
+		fsl    *FakeSpaceLister
+		fri    *FakeRouteInterface
+		frl    *FakeRouteLister
+		frnl   *FakeRouteNamespaceLister
+		fvsi   *FakeVirtualServiceInterface
+		fanl   *FakeAppNamespaceLister
+		fvsnl  *FakeVirtualServiceNamespaceLister
+		fsibl  *FakeServiceInstanceBindingLister
+		fsibnl *FakeServiceInstanceBindingNamespaceLister
+		fgwi   *FakeGatewayInterface
+		fgwnl  *FakeGatewayNamespaceLister
+		fsei   *FakeServiceEntryInterface
+		fsenl  *FakeServiceEntryNamespaceLister
+		fefi   *FakeEnvoyFilterInterface
+		fefnl  *FakeEnvoyFilterNamespaceLister
+	}
+
+	expectRouteListCall := func(frl *FakeRouteLister, frnl *FakeRouteNamespaceLister) {
+		frl.EXPECT().
+			Routes(gomock.Any()).
+			Return(frnl)
+	}
+
+	goodDomain := "example.com"
+
+	goodSpace := v1alpha1.Space{}
+	goodSpace.Status.NetworkConfig.Domains = []v1alpha1.SpaceDomain{
+		{Domain: goodDomain},
+	}
+	expectGoodSpace := func(fsl *FakeSpaceLister) {
+		fsl.EXPECT().
+			Get(gomock.Any()).
+			Return(&goodSpace, nil).
+			AnyTimes()
+	}
+
+	goodRoute := v1alpha1.Route{
+		Spec: v1alpha1.RouteSpec{
+			RouteSpecFields: v1alpha1.RouteSpecFields{
+				Domain: goodDomain,
+			},
+		},
+	}
+
+	expectGoodRoute := func(frnl *FakeRouteNamespaceLister) {
+		frnl.EXPECT().
+			List(gomock.Any()).
+			Return([]*v1alpha1.Route{
+				&goodRoute,
+			}, nil)
+	}
+
+	tcpDomain := "tcp.example.com"
+
+	tcpSpace := v1alpha1.Space{}
+	tcpSpace.Status.NetworkConfig.Domains = []v1alpha1.SpaceDomain{
+		{
+			Domain:      tcpDomain,
+			GatewayName: "kf/external-gateway",
+			RouterGroup: &v1alpha1.RouterGroup{
+				Type:            v1alpha1.RouterGroupTypeTCP,
+				ReservablePorts: "1024-1033",
+			},
+		},
+	}
+	expectTCPSpace := func(fsl *FakeSpaceLister) {
+		fsl.EXPECT().
+			Get(gomock.Any()).
+			Return(&tcpSpace, nil).
+			AnyTimes()
+	}
+
+	expectTCPRoute := func(frnl *FakeRouteNamespaceLister) {
+		frnl.EXPECT().
+			List(gomock.Any()).
+			Return([]*v1alpha1.Route{
+				{
+					Spec: v1alpha1.RouteSpec{
+						RouteSpecFields: v1alpha1.RouteSpecFields{
+							Domain: tcpDomain,
+							Port:   1024,
+						},
+					},
+				},
+			}, nil)
+	}
+
+	// expectTCPPortHolder sets up a Route in my-space reserving port 1024 on
+	// the external gateway and an older Route in other-space reserving the
+	// same port on the given gateway.
+	expectTCPPortHolder := func(t *testing.T, f fakes, holderGateway string) {
+		otherSpace := v1alpha1.Space{}
+		otherSpace.Status.NetworkConfig.Domains = []v1alpha1.SpaceDomain{
+			{
+				Domain:      "tcp.other.example.com",
+				GatewayName: "kf/" + holderGateway,
+				RouterGroup: &v1alpha1.RouterGroup{
+					Type:            v1alpha1.RouterGroupTypeTCP,
+					ReservablePorts: "1024-1033",
+				},
+			},
+		}
+		f.fsl.EXPECT().
+			Get("other-space").
+			Return(&otherSpace, nil).
+			AnyTimes()
+		expectTCPSpace(f.fsl)
+		expectRouteListCall(f.frl, f.frnl)
+		expectTCPRoute(f.frnl)
+
+		f.fgwnl.EXPECT().
+			Get("external-gateway").
+			Return(&v1alpha3.Gateway{
+				Spec: istio.Gateway{
+					Selector: map[string]string{"istio": "ingressgateway"},
+				},
+			}, nil).
+			AnyTimes()
+		f.fgwnl.EXPECT().
+			Get("internal-gateway").
+			Return(&v1alpha3.Gateway{
+				Spec: istio.Gateway{
+					Selector: map[string]string{"istio": "internal-ingressgateway"},
+				},
+			}, nil).
+			AnyTimes()
+
+		holder := &v1alpha1.Route{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "other-space",
+				Name:              "holder",
+				CreationTimestamp: metav1.Unix(0, 0),
+			},
+			Spec: v1alpha1.RouteSpec{
+				RouteSpecFields: v1alpha1.RouteSpecFields{
+					Domain: "tcp.other.example.com",
+					Port:   1024,
+				},
+			},
+		}
+		mine := &v1alpha1.Route{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "my-space",
+				CreationTimestamp: metav1.Unix(100, 0),
+			},
+			Spec: v1alpha1.RouteSpec{
+				RouteSpecFields: v1alpha1.RouteSpecFields{
+					Domain: tcpDomain,
+					Port:   1024,
+				},
+			},
+		}
+		f.frl.EXPECT().
+			List(gomock.Any()).
+			Return([]*v1alpha1.Route{mine, holder}, nil)
+
+		f.fanl.EXPECT().
+			List(gomock.Any()).
+			Return(nil, nil)
+
+		f.fsibnl.EXPECT().
+			List(gomock.Any()).
+			Return(nil, nil)
+	}
+
+	internalDomain := "apps.internal"
+
+	internalSpace := v1alpha1.Space{}
+	internalSpace.Status.NetworkConfig.Domains = []v1alpha1.SpaceDomain{
+		{
+			Domain:      internalDomain,
+			GatewayName: "kf/internal-gateway",
+			Internal:    true,
+		},
+	}
+
+	testCases := map[string]struct {
+		ExpectedErr  error
+		Setup        func(t *testing.T, f fakes)
+		Domain       string
+		Namespace    string
+		IstioVersion string
+	}{
+		"internal domain creates ServiceEntry": {
+			Domain: internalDomain,
+			Setup: func(t *testing.T, f fakes) {
+				f.fsl.EXPECT().
+					Get(gomock.Any()).
+					Return(&internalSpace, nil)
+				expectRouteListCall(f.frl, f.frnl)
+				f.frnl.EXPECT().
+					List(gomock.Any()).
+					Return([]*v1alpha1.Route{
+						{
+							Spec: v1alpha1.RouteSpec{
+								RouteSpecFields: v1alpha1.RouteSpecFields{
+									Hostname: "api",
+									Domain:   internalDomain,
+								},
+							},
+						},
+					}, nil)
+
+				f.fanl.EXPECT().
+					List(gomock.Any()).
+					Return(nil, nil)
+
+				f.fsibnl.EXPECT().
+					List(gomock.Any()).
+					Return(nil, nil)
+
+				f.fsei.EXPECT().
+					Create(gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(ctx context.Context, se *v1alpha3.ServiceEntry, opts metav1.CreateOptions) {
+						testutil.AssertEqual(t, "hosts", []string{"api.apps.internal"}, se.Spec.Hosts)
+					})
+
+				f.fvsnl.EXPECT().
+					Get(gomock.Any()).
+					Return(nil, apierrors.NewNotFound(v1alpha3.Resource("VirtualService"), "VirtualService"))
+
+				f.fvsi.EXPECT().
+					Create(gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(ctx context.Context, vs *v1alpha3.VirtualService, opts metav1.CreateOptions) {
+						testutil.AssertEqual(t, "gateways", 0, len(vs.Spec.Gateways))
+					})
+
+				f.fri.EXPECT().
+					UpdateStatus(gomock.Any(), gomock.Any(), gomock.Any())
+			},
+		},
+		"external domain deletes generated ServiceEntry": {
+			Domain: goodDomain,
+			Setup: func(t *testing.T, f fakes) {
+				expectGoodSpace(f.fsl)
+				expectRouteListCall(f.frl, f.frnl)
+				expectGoodRoute(f.frnl)
+
+				f.fanl.EXPECT().
+					List(gomock.Any()).
+					Return(nil, nil)
+
+				f.fsibnl.EXPECT().
+					List(gomock.Any()).
+					Return(nil, nil)
+
+				f.fsenl.EXPECT().
+					Get(gomock.Any()).
+					Return(&v1alpha3.ServiceEntry{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{v1alpha1.ManagedByLabel: "kf"},
+						},
+					}, nil)
+
+				f.fsei.EXPECT().
+					Delete(gomock.Any(), gomock.Any(), gomock.Any())
+
+				f.fvsnl.EXPECT().
+					Get(gomock.Any()).
+					Return(nil, apierrors.NewNotFound(v1alpha3.Resource("VirtualService"), "VirtualService"))
+
+				f.fvsi.EXPECT().
+					Create(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, nil)
+
+				f.fri.EXPECT().
+					UpdateStatus(gomock.Any(), gomock.Any(), gomock.Any())
+			},
+		},
+		"rate limited route creates EnvoyFilter": {
+			Domain:       goodDomain,
+			IstioVersion: "1.26.2",
+			Setup: func(t *testing.T, f fakes) {
+				expectGoodSpace(f.fsl)
+				expectRouteListCall(f.frl, f.frnl)
+				f.frnl.EXPECT().
+					List(gomock.Any()).
+					Return([]*v1alpha1.Route{
+						{
+							ObjectMeta: metav1.ObjectMeta{Name: "api-example-com"},
+							Spec: v1alpha1.RouteSpec{
+								RouteSpecFields: v1alpha1.RouteSpecFields{
+									Hostname: "api",
+									Domain:   goodDomain,
+								},
+								Policy: &v1alpha1.RoutePolicy{
+									RateLimit: &v1alpha1.RouteRateLimit{RequestsPerSecond: 10},
+								},
+							},
+						},
+					}, nil)
+
+				f.fanl.EXPECT().
+					List(gomock.Any()).
+					Return(nil, nil)
+
+				f.fsibnl.EXPECT().
+					List(gomock.Any()).
+					Return(nil, nil)
+
+				// The ingress Gateway for the domain.
+				f.fgwnl.EXPECT().
+					Get(gomock.Any()).
+					Return(&v1alpha3.Gateway{
+						Spec: istio.Gateway{
+							Selector: map[string]string{"istio": "ingressgateway"},
+						},
+					}, nil).
+					AnyTimes()
+
+				f.fefi.EXPECT().
+					Create(gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(ctx context.Context, ef *v1alpha3.EnvoyFilter, opts metav1.CreateOptions) {
+						testutil.AssertEqual(t, "namespace", "istio-system", ef.Namespace)
+						testutil.AssertEqual(t, "selector", map[string]string{"istio": "ingressgateway"}, ef.Spec.WorkloadSelector.Labels)
+						testutil.AssertEqual(t, "route", "api-example-com", ef.Annotations[resources.RouteAnnotation])
+						// The rate limit filter and the route's limits.
+						testutil.AssertEqual(t, "patches", 2, len(ef.Spec.ConfigPatches))
+					})
+
+				f.fvsnl.EXPECT().
+					Get(gomock.Any()).
+					Return(nil, apierrors.NewNotFound(v1alpha3.Resource("VirtualService"), "VirtualService"))
+
+				f.fvsi.EXPECT().
+					Create(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, nil)
+
+				f.fri.EXPECT().
+					UpdateStatus(gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(ctx context.Context, route *v1alpha1.Route, opts metav1.UpdateOptions) {
+						testutil.AssertEqual(t, "RateLimitReady", (*apis.Condition)(nil), route.Status.GetCondition(v1alpha1.RouteConditionRateLimitReady))
+					})
+			},
+		},
+		"rate limited route on older Istio isn't enforced": {
+			Domain:       goodDomain,
+			IstioVersion: "1.25.3",
+			Setup: func(t *testing.T, f fakes) {
+				expectGoodSpace(f.fsl)
+				expectRouteListCall(f.frl, f.frnl)
+				f.frnl.EXPECT().
+					List(gomock.Any()).
+					Return([]*v1alpha1.Route{
+						{
+							Spec: v1alpha1.RouteSpec{
+								RouteSpecFields: v1alpha1.RouteSpecFields{
+									Hostname: "api",
+									Domain:   goodDomain,
+								},
+								Policy: &v1alpha1.RoutePolicy{
+									RateLimit: &v1alpha1.RouteRateLimit{RequestsPerSecond: 10},
+								},
+							},
+						},
+					}, nil)
+
+				f.fanl.EXPECT().
+					List(gomock.Any()).
+					Return(nil, nil)
+
+				f.fsibnl.EXPECT().
+					List(gomock.Any()).
+					Return(nil, nil)
+
+				// No EnvoyFilter is created, older versions of Envoy
+				// reject the rate limit.
+
+				f.fvsnl.EXPECT().
+					Get(gomock.Any()).
+					Return(nil, apierrors.NewNotFound(v1alpha3.Resource("VirtualService"), "VirtualService"))
+
+				f.fvsi.EXPECT().
+					Create(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, nil)
+
+				f.fri.EXPECT().
+					UpdateStatus(gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(ctx context.Context, route *v1alpha1.Route, opts metav1.UpdateOptions) {
+						condition := route.Status.GetCondition(v1alpha1.RouteConditionRateLimitReady)
+						testutil.AssertEqual(t, "RateLimitReady", corev1.ConditionFalse, condition.Status)
+						testutil.AssertEqual(t, "message", "rate limits need Istio 1.26.0 or later, the ingress gateways run 1.25.3", condition.Message)
+					})
+			},
+		},
+		"route in maintenance creates EnvoyFilter with the message": {
+			Domain: goodDomain,
+			Setup: func(t *testing.T, f fakes) {
+				expectGoodSpace(f.fsl)
+				expectRouteListCall(f.frl, f.frnl)
+				f.frnl.EXPECT().
+					List(gomock.Any()).
+					Return([]*v1alpha1.Route{
+						{
+							Spec: v1alpha1.RouteSpec{
+								RouteSpecFields: v1alpha1.RouteSpecFields{
+									Hostname: "api",
+									Domain:   goodDomain,
+								},
+								Maintenance: &v1alpha1.RouteMaintenance{
+									Message: "Database migration",
+								},
+							},
+						},
+					}, nil)
+
+				f.fanl.EXPECT().
+					List(gomock.Any()).
+					Return(nil, nil)
+
+				f.fsibnl.EXPECT().
+					List(gomock.Any()).
+					Return(nil, nil)
+
+				// The ingress Gateway for the domain.
+				f.fgwnl.EXPECT().
+					Get(gomock.Any()).
+					Return(&v1alpha3.Gateway{
+						Spec: istio.Gateway{
+							Selector: map[string]string{"istio": "ingressgateway"},
+						},
+					}, nil).
+					AnyTimes()
+
+				f.fefi.EXPECT().
+					Create(gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(ctx context.Context, ef *v1alpha3.EnvoyFilter, opts metav1.CreateOptions) {
+						testutil.AssertEqual(t, "patches", 1, len(ef.Spec.ConfigPatches))
+						directResponse := ef.Spec.ConfigPatches[0].Patch.Value.AsMap()["direct_response"]
+						testutil.AssertEqual(t, "direct_response", map[string]interface{}{
+							"status": float64(503),
+							"body":   map[string]interface{}{"inline_string": "Database migration"},
+						}, directResponse)
+					})
+
+				f.fvsnl.EXPECT().
+					Get(gomock.Any()).
+					Return(nil, apierrors.NewNotFound(v1alpha3.Resource("VirtualService"), "VirtualService"))
+
+				f.fvsi.EXPECT().
+					Create(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, nil)
+
+				f.fri.EXPECT().
+					UpdateStatus(gomock.Any(), gomock.Any(), gomock.Any())
+			},
+		},
+		"route without rate limit deletes generated EnvoyFilter": {
+			Domain:    goodDomain,
+			Namespace: "some-namespace",
+			Setup: func(t *testing.T, f fakes) {
+				expectGoodSpace(f.fsl)
+				expectRouteListCall(f.frl, f.frnl)
+				expectGoodRoute(f.frnl)
+
+				f.fanl.EXPECT().
+					List(gomock.Any()).
+					Return(nil, nil)
+
+				f.fsibnl.EXPECT().
+					List(gomock.Any()).
+					Return(nil, nil)
+
+				envoyFilter := func(name, space, domain string) *v1alpha3.EnvoyFilter {
+					return &v1alpha3.EnvoyFilter{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:   name,
+							Labels: map[string]string{v1alpha1.ManagedByLabel: "kf"},
+							Annotations: map[string]string{
+								resources.SpaceAnnotation:  space,
+								resources.DomainAnnotation: domain,
+							},
+						},
+					}
+				}
+
+				f.fefnl.EXPECT().
+					List(gomock.Any()).
+					Return([]*v1alpha3.EnvoyFilter{
+						envoyFilter("deleted-route", "some-namespace", goodDomain),
+						// EnvoyFilters of other Spaces and domains are
+						// reconciled with their Routes.
+						envoyFilter("other-space", "other-namespace", goodDomain),
+						envoyFilter("other-domain", "some-namespace", "other.example.com"),
+					}, nil)
+
+				f.fefi.EXPECT().
+					Delete(gomock.Any(), "deleted-route", gomock.Any())
+
+				f.fvsnl.EXPECT().
+					Get(gomock.Any()).
+					Return(nil, apierrors.NewNotFound(v1alpha3.Resource("VirtualService"), "VirtualService"))
+
+				f.fvsi.EXPECT().
+					Create(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, nil)
+
+				f.fri.EXPECT().
+					UpdateStatus(gomock.Any(), gomock.Any(), gomock.Any())
+			},
+		},
+		"status keeps declared bindings": {
+			Domain: goodDomain,
+			Setup: func(t *testing.T, f fakes) {
+				expectGoodSpace(f.fsl)
+				expectRouteListCall(f.frl, f.frnl)
+				f.frnl.EXPECT().
+					List(gomock.Any()).
+					Return([]*v1alpha1.Route{
+						{
+							Spec: v1alpha1.RouteSpec{
+								RouteSpecFields: v1alpha1.RouteSpecFields{
+									Domain: goodDomain,
+								},
+								Canary: &v1alpha1.RouteCanary{
+									AppName: "app-2",
+									Weight:  10,
+								},
+							},
+						},
+					}, nil)
+
+				makeApp := func(name string, mirrorPercent int32) *v1alpha1.App {
+					app := &v1alpha1.App{}
+					app.Name = name
+					app.Status.Routes = []v1alpha1.AppRouteStatus{
+						{
+							QualifiedRouteBinding: v1alpha1.QualifiedRouteBinding{
+								Source: v1alpha1.RouteSpecFields{
+									Domain: goodDomain,
+									Path:   "/",
+								},
+								Destination: v1alpha1.RouteDestination{
+									ServiceName:   name,
+									Port:          80,
+									Weight:        1,
+									MirrorPercent: mirrorPercent,
+								},
+							},
+						},
+					}
+					return app
+				}
+
+				f.fanl.EXPECT().
+					List(gomock.Any()).
+					Return([]*v1alpha1.App{
+						makeApp("app-1", 0),
+						makeApp("app-2", 0),
+						makeApp("shadow", 20),
+					}, nil)
+
+				f.fsibnl.EXPECT().
+					List(gomock.Any()).
+					Return(nil, nil)
+
+				f.fvsnl.EXPECT().
+					Get(gomock.Any()).
+					Return(nil, apierrors.NewNotFound(v1alpha3.Resource("VirtualService"), "VirtualService"))
+
+				f.fvsi.EXPECT().
+					Create(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, vs *v1alpha3.VirtualService, opts metav1.CreateOptions) (*v1alpha3.VirtualService, error) {
+						httpRoute := vs.Spec.Http[len(vs.Spec.Http)-1]
+						testutil.AssertEqual(t, "app-1 weight", int32(90), httpRoute.Route[0].Weight)
+						testutil.AssertEqual(t, "app-2 weight", int32(10), httpRoute.Route[1].Weight)
+						testutil.AssertEqual(t, "mirror", "shadow", httpRoute.Mirror.Host)
+						return vs, nil
+					})
+
+				f.fri.EXPECT().
+					UpdateStatus(gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(ctx context.Context, route *v1alpha1.Route, opts metav1.UpdateOptions) {
+						testutil.AssertEqual(t, "bindings", []v1alpha1.RouteDestination{
+							{ServiceName: "app-1", Port: 80, Weight: 1},
+							{ServiceName: "app-2", Port: 80, Weight: 1},
+							{ServiceName: "shadow", Port: 80, Weight: 1, MirrorPercent: 20},
+						}, route.Status.Bindings)
+					})
+			},
+		},
+		"grpc apps get grpc retries": {
+			Domain: goodDomain,
+			Setup: func(t *testing.T, f fakes) {
+				expectGoodSpace(f.fsl)
+				expectRouteListCall(f.frl, f.frnl)
+				f.frnl.EXPECT().
+					List(gomock.Any()).
+					Return([]*v1alpha1.Route{
+						{
+							Spec: v1alpha1.RouteSpec{
+								RouteSpecFields: v1alpha1.RouteSpecFields{
+									Domain: goodDomain,
+								},
+							},
+						},
+					}, nil)
+
+				app := &v1alpha1.App{}
+				app.Name = "grpc-app"
+				app.Spec.Template.Spec.Containers = []corev1.Container{{
+					Ports: []corev1.ContainerPort{{Name: "grpc-9000", ContainerPort: 9000}},
+				}}
+				app.Status.Routes = []v1alpha1.AppRouteStatus{
+					{
+						QualifiedRouteBinding: v1alpha1.QualifiedRouteBinding{
+							Source: v1alpha1.RouteSpecFields{
+								Domain: goodDomain,
+								Path:   "/",
+							},
+							Destination: v1alpha1.RouteDestination{
+								ServiceName: "grpc-app",
+								Port:        80,
+								Weight:      1,
+							},
+						},
+					},
+				}
+
+				f.fanl.EXPECT().
+					List(gomock.Any()).
+					Return([]*v1alpha1.App{app}, nil)
+
+				f.fsibnl.EXPECT().
+					List(gomock.Any()).
+					Return(nil, nil)
+
+				f.fvsnl.EXPECT().
+					Get(gomock.Any()).
+					Return(nil, apierrors.NewNotFound(v1alpha3.Resource("VirtualService"), "VirtualService"))
+
+				f.fvsi.EXPECT().
+					Create(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, vs *v1alpha3.VirtualService, opts metav1.CreateOptions) (*v1alpha3.VirtualService, error) {
+						httpRoute := vs.Spec.Http[len(vs.Spec.Http)-1]
+						testutil.AssertEqual(t, "retryOn", resources.GRPCRetryOn, httpRoute.Retries.RetryOn)
+						return vs, nil
+					})
+
+				f.fri.EXPECT().
+					UpdateStatus(gomock.Any(), gomock.Any(), gomock.Any())
+			},
+		},
+		"shared route includes Apps from other Spaces": {
+			Namespace: "some-namespace",
+			Domain:    goodDomain,
+			Setup: func(t *testing.T, f fakes) {
+				sharedRoute := &v1alpha1.Route{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "some-namespace",
+					},
+					Spec: v1alpha1.RouteSpec{
+						RouteSpecFields: v1alpha1.RouteSpecFields{
+							Domain: goodDomain,
+						},
+						SharedSpaces: []string{"other-space"},
+					},
+				}
+
+				acceptingRoute := &v1alpha1.Route{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "other-space",
+					},
+					Spec: v1alpha1.RouteSpec{
+						RouteSpecFields: v1alpha1.RouteSpecFields{
+							Domain: goodDomain,
+							Path:   "/",
+						},
+						SharedFrom: "some-namespace",
+					},
+				}
+
+				expectGoodSpace(f.fsl)
+				expectRouteListCall(f.frl, f.frnl)
+				f.frnl.EXPECT().
+					List(gomock.Any()).
+					Return([]*v1alpha1.Route{sharedRoute}, nil)
+				f.frl.EXPECT().
+					List(gomock.Any()).
+					Return([]*v1alpha1.Route{sharedRoute, acceptingRoute}, nil)
+
+				makeApp := func(namespace, name string) *v1alpha1.App {
+					app := &v1alpha1.App{}
+					app.Namespace = namespace
+					app.Name = name
+					app.Status.Routes = []v1alpha1.AppRouteStatus{
+						{
+							QualifiedRouteBinding: v1alpha1.QualifiedRouteBinding{
+								Source: v1alpha1.RouteSpecFields{
+									Domain: goodDomain,
+									Path:   "/",
+								},
+								Destination: v1alpha1.RouteDestination{
+									ServiceName: name,
+									Port:        80,
+									Weight:      1,
+								},
+							},
+						},
+					}
+					return app
+				}
+
+				f.fanl.EXPECT().
+					List(gomock.Any()).
+					Return([]*v1alpha1.App{makeApp("some-namespace", "app-1")}, nil)
+				f.fanl.EXPECT().
+					List(gomock.Any()).
+					Return([]*v1alpha1.App{makeApp("other-space", "app-2")}, nil)
+
+				f.fsibnl.EXPECT().
+					List(gomock.Any()).
+					Return(nil, nil)
+
+				f.fvsnl.EXPECT().
+					Get(gomock.Any()).
+					Return(nil, apierrors.NewNotFound(v1alpha3.Resource("VirtualService"), "VirtualService"))
+
+				f.fvsi.EXPECT().
+					Create(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, vs *v1alpha3.VirtualService, opts metav1.CreateOptions) (*v1alpha3.VirtualService, error) {
+						httpRoute := vs.Spec.Http[len(vs.Spec.Http)-1]
+						testutil.AssertEqual(t, "local host", "app-1", httpRoute.Route[0].Destination.Host)
+						testutil.AssertEqual(t, "shared host", "app-2.other-space.svc.cluster.local", httpRoute.Route[1].Destination.Host)
+						return vs, nil
+					})
+
+				f.fri.EXPECT().
+					UpdateStatus(gomock.Any(), gomock.Any(), gomock.Any())
+			},
+		},
+		"shared route without acceptance only includes local Apps": {
+			Namespace: "some-namespace",
+			Domain:    goodDomain,
+			Setup: func(t *testing.T, f fakes) {
+				sharedRoute := &v1alpha1.Route{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "some-namespace",
+					},
+					Spec: v1alpha1.RouteSpec{
+						RouteSpecFields: v1alpha1.RouteSpecFields{
+							Domain: goodDomain,
+						},
+						SharedSpaces: []string{"other-space"},
+					},
+				}
+
+				expectGoodSpace(f.fsl)
+				expectRouteListCall(f.frl, f.frnl)
+				f.frnl.EXPECT().
+					List(gomock.Any()).
+					Return([]*v1alpha1.Route{sharedRoute}, nil)
+				f.frl.EXPECT().
+					List(gomock.Any()).
+					Return([]*v1alpha1.Route{sharedRoute}, nil)
+
+				makeApp := func(namespace, name string) *v1alpha1.App {
+					app := &v1alpha1.App{}
+					app.Namespace = namespace
+					app.Name = name
+					app.Status.Routes = []v1alpha1.AppRouteStatus{
+						{
+							QualifiedRouteBinding: v1alpha1.QualifiedRouteBinding{
+								Source: v1alpha1.RouteSpecFields{
+									Domain: goodDomain,
+									Path:   "/",
+								},
+								Destination: v1alpha1.RouteDestination{
+									ServiceName: name,
+									Port:        80,
+									Weight:      1,
+								},
+							},
+						},
+					}
+					return app
+				}
+
+				f.fanl.EXPECT().
+					List(gomock.Any()).
+					Return([]*v1alpha1.App{makeApp("some-namespace", "app-1")}, nil)
+
+				f.fsibnl.EXPECT().
+					List(gomock.Any()).
+					Return(nil, nil)
+
+				f.fvsnl.EXPECT().
+					Get(gomock.Any()).
+					Return(nil, apierrors.NewNotFound(v1alpha3.Resource("VirtualService"), "VirtualService"))
+
+				f.fvsi.EXPECT().
+					Create(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, vs *v1alpha3.VirtualService, opts metav1.CreateOptions) (*v1alpha3.VirtualService, error) {
+						httpRoute := vs.Spec.Http[len(vs.Spec.Http)-1]
+						testutil.AssertEqual(t, "destinations", 1, len(httpRoute.Route))
+						testutil.AssertEqual(t, "local host", "app-1", httpRoute.Route[0].Destination.Host)
+						return vs, nil
+					})
+
+				f.fri.EXPECT().
+					UpdateStatus(gomock.Any(), gomock.Any(), gomock.Any())
+			},
+		},
+		"route shared from another Space isn't served": {
+			Namespace: "some-namespace",
+			Domain:    goodDomain,
+			Setup: func(t *testing.T, f fakes) {
+				expectGoodSpace(f.fsl)
+				expectRouteListCall(f.frl, f.frnl)
+				f.frnl.EXPECT().
+					List(gomock.Any()).
+					Return([]*v1alpha1.Route{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: "some-namespace",
+							},
+							Spec: v1alpha1.RouteSpec{
+								RouteSpecFields: v1alpha1.RouteSpecFields{
+									Domain: goodDomain,
+								},
+								SharedFrom: "owner-space",
+							},
+						},
+					}, nil)
+				f.frl.EXPECT().
+					List(gomock.Any()).
+					Return([]*v1alpha1.Route{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: "some-namespace",
+							},
+							Spec: v1alpha1.RouteSpec{
+								RouteSpecFields: v1alpha1.RouteSpecFields{
+									Domain: goodDomain,
+									Path:   "/",
+								},
+								SharedFrom: "owner-space",
+							},
+						},
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: "owner-space",
+							},
+							Spec: v1alpha1.RouteSpec{
+								RouteSpecFields: v1alpha1.RouteSpecFields{
+									Domain: goodDomain,
+									Path:   "/",
+								},
+								SharedSpaces: []string{"some-namespace"},
+							},
+						},
+					}, nil)
+
+				f.fanl.EXPECT().
+					List(gomock.Any()).
+					Return(nil, nil)
+
+				f.fsibnl.EXPECT().
+					List(gomock.Any()).
+					Return(nil, nil)
 
-func TestReconciler_Reconcile_ApplyChanges(t *testing.T) {
-	t.Parallel()
+				// The owner Space serves the Route.
+				f.fvsi.EXPECT().
+					Delete(gomock.Any(), gomock.Any(), gomock.Any())
 
-	type fakes struct {
-		fsl    *FakeSpaceLister
-		fri    *FakeRouteInterface
-		frl    *FakeRouteLister
-		frnl   *FakeRouteNamespaceLister
-		fvsi   *FakeVirtualServiceInterface
-		fanl   *FakeAppNamespaceLister
-		fvsnl  *FakeVirtualServiceNamespaceLister
-		fsibl  *FakeServiceInstanceBindingLister
-		fsibnl *FakeServiceInstanceBindingNamespaceLister
-	}
+				f.fri.EXPECT().
+					UpdateStatus(gomock.Any(), gomock.Any(), gomock.Any())
+			},
+		},
+		"route shared without acceptance is still served": {
+			Namespace: "some-namespace",
+			Domain:    goodDomain,
+			Setup: func(t *testing.T, f fakes) {
+				expectGoodSpace(f.fsl)
+				expectRouteListCall(f.frl, f.frnl)
+				f.frnl.EXPECT().
+					List(gomock.Any()).
+					Return([]*v1alpha1.Route{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: "some-namespace",
+							},
+							Spec: v1alpha1.RouteSpec{
+								RouteSpecFields: v1alpha1.RouteSpecFields{
+									Domain: goodDomain,
+								},
+							},
+						},
+					}, nil)
+				f.frl.EXPECT().
+					List(gomock.Any()).
+					Return([]*v1alpha1.Route{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: "owner-space",
+							},
+							Spec: v1alpha1.RouteSpec{
+								RouteSpecFields: v1alpha1.RouteSpecFields{
+									Domain: goodDomain,
+									Path:   "/",
+								},
+								SharedSpaces: []string{"some-namespace"},
+							},
+						},
+					}, nil)
 
-	expectRouteListCall := func(frl *FakeRouteLister, frnl *FakeRouteNamespaceLister) {
-		frl.EXPECT().
-			Routes(gomock.Any()).
-			Return(frnl)
-	}
+				f.fanl.EXPECT().
+					List(gomock.Any()).
+					Return(nil, nil)
 
-	goodDomain := "example.com"
+				f.fsibnl.EXPECT().
+					List(gomock.Any()).
+					Return(nil, nil)
 
-	goodSpace := v1alpha1.Space{}
-	goodSpace.Status.NetworkConfig.Domains = []v1alpha1.SpaceDomain{
-		{Domain: goodDomain},
-	}
-	expectGoodSpace := func(fsl *FakeSpaceLister) {
-		fsl.EXPECT().
-			Get(gomock.Any()).
-			Return(&goodSpace, nil).
-			AnyTimes()
-	}
+				// Another Space can't take over the hostname.
+				f.fvsnl.EXPECT().
+					Get(gomock.Any()).
+					Return(nil, apierrors.NewNotFound(v1alpha3.Resource("VirtualService"), "VirtualService"))
 
-	goodRoute := v1alpha1.Route{
-		Spec: v1alpha1.RouteSpec{
-			RouteSpecFields: v1alpha1.RouteSpecFields{
-				Domain: goodDomain,
+				f.fvsi.EXPECT().
+					Create(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, nil)
+
+				f.fri.EXPECT().
+					UpdateStatus(gomock.Any(), gomock.Any(), gomock.Any())
 			},
 		},
-	}
-
-	expectGoodRoute := func(frnl *FakeRouteNamespaceLister) {
-		frnl.EXPECT().
-			List(gomock.Any()).
-			Return([]*v1alpha1.Route{
-				&goodRoute,
-			}, nil)
-	}
-
-	testCases := map[string]struct {
-		ExpectedErr error
-		Setup       func(t *testing.T, f fakes)
-		Domain      string
-		Namespace   string
-	}{
 		"fetching space fails": {
 			ExpectedErr: errors.New("some-error"),
 			Setup: func(t *testing.T, f fakes) {
@@ -551,6 +1444,161 @@ func TestReconciler_Reconcile_ApplyChanges(t *testing.T) {
 				})
 			},
 		},
+		"tcp domain creates Gateway": {
+			Domain: tcpDomain,
+			Setup: func(t *testing.T, f fakes) {
+				expectTCPSpace(f.fsl)
+				expectRouteListCall(f.frl, f.frnl)
+				expectTCPRoute(f.frnl)
+
+				f.fanl.EXPECT().
+					List(gomock.Any()).
+					Return(nil, nil)
+
+				f.fsibnl.EXPECT().
+					List(gomock.Any()).
+					Return(nil, nil)
+
+				f.fgwnl.EXPECT().
+					Get("external-gateway").
+					Return(&v1alpha3.Gateway{
+						Spec: istio.Gateway{
+							Selector: map[string]string{"istio": "ingressgateway"},
+						},
+					}, nil).
+					AnyTimes()
+
+				f.fgwi.EXPECT().
+					Create(gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(ctx context.Context, gw *v1alpha3.Gateway, opts metav1.CreateOptions) {
+						testutil.AssertEqual(t, "selector", map[string]string{"istio": "ingressgateway"}, gw.Spec.Selector)
+						testutil.AssertEqual(t, "servers", 1, len(gw.Spec.Servers))
+						testutil.AssertEqual(t, "port", uint32(1024), gw.Spec.Servers[0].Port.Number)
+					})
+
+				f.fvsnl.EXPECT().
+					Get(gomock.Any()).
+					Return(nil, apierrors.NewNotFound(v1alpha3.Resource("VirtualService"), "VirtualService"))
+
+				f.fvsi.EXPECT().
+					Create(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, nil)
+
+				f.fri.EXPECT().
+					UpdateStatus(gomock.Any(), gomock.Any(), gomock.Any())
+			},
+		},
+		"tcp port held by another Space isn't served": {
+			Domain:    tcpDomain,
+			Namespace: "my-space",
+			Setup: func(t *testing.T, f fakes) {
+				expectTCPPortHolder(t, f, "external-gateway")
+
+				// No Routes are served so nothing is opened on the gateway.
+				f.fvsi.EXPECT().
+					Delete(gomock.Any(), gomock.Any(), gomock.Any())
+
+				f.fri.EXPECT().
+					UpdateStatus(gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(ctx context.Context, c *v1alpha1.Route, o metav1.UpdateOptions) {
+						cond := c.Status.GetCondition(v1alpha1.RouteConditionSpaceDomainReady)
+						testutil.AssertTrue(t, "SpaceDomainReady false", cond.IsFalse())
+						testutil.AssertContainsAll(t, cond.Message, []string{"PortConflict", `Space "other-space"`})
+					})
+			},
+		},
+		"tcp port held on another ingress gateway is served": {
+			Domain:    tcpDomain,
+			Namespace: "my-space",
+			Setup: func(t *testing.T, f fakes) {
+				expectTCPPortHolder(t, f, "internal-gateway")
+
+				f.fgwi.EXPECT().
+					Create(gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(ctx context.Context, gw *v1alpha3.Gateway, opts metav1.CreateOptions) {
+						testutil.AssertEqual(t, "selector", map[string]string{"istio": "ingressgateway"}, gw.Spec.Selector)
+						testutil.AssertEqual(t, "port", uint32(1024), gw.Spec.Servers[0].Port.Number)
+					})
+
+				f.fvsnl.EXPECT().
+					Get(gomock.Any()).
+					Return(nil, apierrors.NewNotFound(v1alpha3.Resource("VirtualService"), "VirtualService"))
+
+				f.fvsi.EXPECT().
+					Create(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, nil)
+
+				f.fri.EXPECT().
+					UpdateStatus(gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(ctx context.Context, c *v1alpha1.Route, o metav1.UpdateOptions) {
+						cond := c.Status.GetCondition(v1alpha1.RouteConditionSpaceDomainReady)
+						testutil.AssertFalse(t, "SpaceDomainReady false", cond.IsFalse())
+					})
+			},
+		},
+		"tcp domain with missing ingress Gateway fails": {
+			Domain:      tcpDomain,
+			ExpectedErr: errors.New(`Error occurred while reconciling VirtualService: couldn't get Gateway "kf/external-gateway" for the domain: some-error`),
+			Setup: func(t *testing.T, f fakes) {
+				expectTCPSpace(f.fsl)
+				expectRouteListCall(f.frl, f.frnl)
+				expectTCPRoute(f.frnl)
+
+				f.fanl.EXPECT().
+					List(gomock.Any()).
+					Return(nil, nil)
+
+				f.fsibnl.EXPECT().
+					List(gomock.Any()).
+					Return(nil, nil)
+
+				f.fgwnl.EXPECT().
+					Get("external-gateway").
+					Return(nil, errors.New("some-error")).
+					AnyTimes()
+
+				f.fri.EXPECT().
+					UpdateStatus(gomock.Any(), gomock.Any(), gomock.Any())
+			},
+		},
+		"http domain deletes generated Gateway": {
+			Domain: goodDomain,
+			Setup: func(t *testing.T, f fakes) {
+				expectGoodSpace(f.fsl)
+				expectRouteListCall(f.frl, f.frnl)
+				expectGoodRoute(f.frnl)
+
+				f.fanl.EXPECT().
+					List(gomock.Any()).
+					Return(nil, nil)
+
+				f.fsibnl.EXPECT().
+					List(gomock.Any()).
+					Return(nil, nil)
+
+				f.fgwnl.EXPECT().
+					Get(gomock.Any()).
+					Return(&v1alpha3.Gateway{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{v1alpha1.ManagedByLabel: "kf"},
+						},
+					}, nil)
+
+				f.fgwi.EXPECT().
+					Delete(gomock.Any(), gomock.Any(), gomock.Any())
+
+				f.fvsnl.EXPECT().
+					Get(gomock.Any()).
+					Return(nil, apierrors.NewNotFound(v1alpha3.Resource("VirtualService"), "VirtualService"))
+
+				f.fvsi.EXPECT().
+					Create(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, nil)
+
+				f.fri.EXPECT().
+					UpdateStatus(gomock.Any(), gomock.Any(), gomock.Any())
+			},
+		},
 	}
 
 	for tn, tc := range testCases {
@@ -571,6 +1619,15 @@ func TestReconciler_Reconcile_ApplyChanges(t *testing.T) {
 			fakeVirtualServiceNamespaceLister := NewFakeVirtualServiceNamespaceLister(ctrl)
 			fakeServiceInstanceBindingLister := NewFakeServiceInstanceBindingLister(ctrl)
 			fakeServiceInstanceBindingNamespaceLister := NewFakeServiceInstanceBindingNamespaceLister(ctrl)
+			fakeGatewayInterface := NewFakeGatewayInterface(ctrl)
+			fakeGatewayLister := NewFakeGatewayLister(ctrl)
+			fakeGatewayNamespaceLister := NewFakeGatewayNamespaceLister(ctrl)
+			fakeServiceEntryInterface := NewFakeServiceEntryInterface(ctrl)
+			fakeServiceEntryLister := NewFakeServiceEntryLister(ctrl)
+			fakeServiceEntryNamespaceLister := NewFakeServiceEntryNamespaceLister(ctrl)
+			fakeEnvoyFilterInterface := NewFakeEnvoyFilterInterface(ctrl)
+			fakeEnvoyFilterLister := NewFakeEnvoyFilterLister(ctrl)
+			fakeEnvoyFilterNamespaceLister := NewFakeEnvoyFilterNamespaceLister(ctrl)
 
 			fakeVirtualServiceLister.EXPECT().
 				VirtualServices(gomock.Any()).
@@ -592,6 +1649,36 @@ func TestReconciler_Reconcile_ApplyChanges(t *testing.T) {
 				Return(fakeVirtualServiceInterface).
 				AnyTimes()
 
+			fakeNetworking.EXPECT().
+				Gateways(gomock.Any()).
+				Return(fakeGatewayInterface).
+				AnyTimes()
+
+			fakeGatewayLister.EXPECT().
+				Gateways(gomock.Any()).
+				Return(fakeGatewayNamespaceLister).
+				AnyTimes()
+
+			fakeNetworking.EXPECT().
+				ServiceEntries(gomock.Any()).
+				Return(fakeServiceEntryInterface).
+				AnyTimes()
+
+			fakeServiceEntryLister.EXPECT().
+				ServiceEntries(gomock.Any()).
+				Return(fakeServiceEntryNamespaceLister).
+				AnyTimes()
+
+			fakeNetworking.EXPECT().
+				EnvoyFilters(gomock.Any()).
+				Return(fakeEnvoyFilterInterface).
+				AnyTimes()
+
+			fakeEnvoyFilterLister.EXPECT().
+				EnvoyFilters(gomock.Any()).
+				Return(fakeEnvoyFilterNamespaceLister).
+				AnyTimes()
+
 			fakeKfAlpha1Interface.EXPECT().
 				Routes(gomock.Any()).
 				Return(fakeRouteInterface).
@@ -618,9 +1705,46 @@ func TestReconciler_Reconcile_ApplyChanges(t *testing.T) {
 					fsl:    fakeSpaceLister,
 					fsibl:  fakeServiceInstanceBindingLister,
 					fsibnl: fakeServiceInstanceBindingNamespaceLister,
+					fgwi:   fakeGatewayInterface,
+					fgwnl:  fakeGatewayNamespaceLister,
+					fsei:   fakeServiceEntryInterface,
+					fsenl:  fakeServiceEntryNamespaceLister,
+					fefi:   fakeEnvoyFilterInterface,
+					fefnl:  fakeEnvoyFilterNamespaceLister,
 				})
 			}
 
+			// Routes aren't shared from other Spaces unless a test says
+			// otherwise.
+			fakeRouteLister.EXPECT().
+				List(gomock.Any()).
+				Return(nil, nil).
+				AnyTimes()
+
+			// Domains don't have generated Gateways unless a test says otherwise.
+			fakeGatewayNamespaceLister.EXPECT().
+				Get(gomock.Any()).
+				Return(nil, apierrors.NewNotFound(v1alpha3.Resource("Gateway"), "Gateway")).
+				AnyTimes()
+
+			// Domains don't have generated ServiceEntries unless a test says
+			// otherwise.
+			fakeServiceEntryNamespaceLister.EXPECT().
+				Get(gomock.Any()).
+				Return(nil, apierrors.NewNotFound(v1alpha3.Resource("ServiceEntry"), "ServiceEntry")).
+				AnyTimes()
+
+			// Domains don't have generated EnvoyFilters unless a test says
+			// otherwise.
+			fakeEnvoyFilterNamespaceLister.EXPECT().
+				Get(gomock.Any()).
+				Return(nil, apierrors.NewNotFound(v1alpha3.Resource("EnvoyFilter"), "EnvoyFilter")).
+				AnyTimes()
+			fakeEnvoyFilterNamespaceLister.EXPECT().
+				List(gomock.Any()).
+				Return(nil, nil).
+				AnyTimes()
+
 			r := &Reconciler{
 				Base: &reconciler.Base{
 					KfClientSet: fakeKfInterface,
@@ -628,11 +1752,16 @@ func TestReconciler_Reconcile_ApplyChanges(t *testing.T) {
 				networkingClientSet:          fakeNetworkingClient,
 				routeLister:                  fakeRouteLister,
 				virtualServiceLister:         fakeVirtualServiceLister,
+				gatewayLister:                fakeGatewayLister,
+				serviceEntryLister:           fakeServiceEntryLister,
+				envoyFilterLister:            fakeEnvoyFilterLister,
 				appLister:                    fakeAppLister,
 				spaceLister:                  fakeSpaceLister,
 				serviceInstanceBindingLister: fakeServiceInstanceBindingLister,
+				serviceLister:                ingressServiceLister(),
 				kfConfigStore: &testConfigStore{&config.DefaultsConfig{
 					RouteTrackVirtualService: true,
+					RouteIstioVersion:        tc.IstioVersion,
 				}},
 			}
 
@@ -661,6 +1790,12 @@ func TestReconciler_Reconcile_ApplyChanges_NotTrackingVirtualService(t *testing.
 		fvsnl  *FakeVirtualServiceNamespaceLister
 		fsibl  *FakeServiceInstanceBindingLister
 		fsibnl *FakeServiceInstanceBindingNamespaceLister
+		fgwi   *FakeGatewayInterface
+		fgwnl  *FakeGatewayNamespaceLister
+		fsei   *FakeServiceEntryInterface
+		fsenl  *FakeServiceEntryNamespaceLister
+		fefi   *FakeEnvoyFilterInterface
+		fefnl  *FakeEnvoyFilterNamespaceLister
 	}
 
 	expectRouteListCall := func(frl *FakeRouteLister, frnl *FakeRouteNamespaceLister) {
@@ -1001,6 +2136,15 @@ func TestReconciler_Reconcile_ApplyChanges_NotTrackingVirtualService(t *testing.
 			fakeVirtualServiceNamespaceLister := NewFakeVirtualServiceNamespaceLister(ctrl)
 			fakeServiceInstanceBindingLister := NewFakeServiceInstanceBindingLister(ctrl)
 			fakeServiceInstanceBindingNamespaceLister := NewFakeServiceInstanceBindingNamespaceLister(ctrl)
+			fakeGatewayInterface := NewFakeGatewayInterface(ctrl)
+			fakeGatewayLister := NewFakeGatewayLister(ctrl)
+			fakeGatewayNamespaceLister := NewFakeGatewayNamespaceLister(ctrl)
+			fakeServiceEntryInterface := NewFakeServiceEntryInterface(ctrl)
+			fakeServiceEntryLister := NewFakeServiceEntryLister(ctrl)
+			fakeServiceEntryNamespaceLister := NewFakeServiceEntryNamespaceLister(ctrl)
+			fakeEnvoyFilterInterface := NewFakeEnvoyFilterInterface(ctrl)
+			fakeEnvoyFilterLister := NewFakeEnvoyFilterLister(ctrl)
+			fakeEnvoyFilterNamespaceLister := NewFakeEnvoyFilterNamespaceLister(ctrl)
 
 			fakeVirtualServiceLister.EXPECT().
 				VirtualServices(gomock.Any()).
@@ -1022,6 +2166,36 @@ func TestReconciler_Reconcile_ApplyChanges_NotTrackingVirtualService(t *testing.
 				Return(fakeVirtualServiceInterface).
 				AnyTimes()
 
+			fakeNetworking.EXPECT().
+				Gateways(gomock.Any()).
+				Return(fakeGatewayInterface).
+				AnyTimes()
+
+			fakeGatewayLister.EXPECT().
+				Gateways(gomock.Any()).
+				Return(fakeGatewayNamespaceLister).
+				AnyTimes()
+
+			fakeNetworking.EXPECT().
+				ServiceEntries(gomock.Any()).
+				Return(fakeServiceEntryInterface).
+				AnyTimes()
+
+			fakeServiceEntryLister.EXPECT().
+				ServiceEntries(gomock.Any()).
+				Return(fakeServiceEntryNamespaceLister).
+				AnyTimes()
+
+			fakeNetworking.EXPECT().
+				EnvoyFilters(gomock.Any()).
+				Return(fakeEnvoyFilterInterface).
+				AnyTimes()
+
+			fakeEnvoyFilterLister.EXPECT().
+				EnvoyFilters(gomock.Any()).
+				Return(fakeEnvoyFilterNamespaceLister).
+				AnyTimes()
+
 			fakeKfAlpha1Interface.EXPECT().
 				Routes(gomock.Any()).
 				Return(fakeRouteInterface).
@@ -1048,9 +2222,46 @@ func TestReconciler_Reconcile_ApplyChanges_NotTrackingVirtualService(t *testing.
 					fsl:    fakeSpaceLister,
 					fsibl:  fakeServiceInstanceBindingLister,
 					fsibnl: fakeServiceInstanceBindingNamespaceLister,
+					fgwi:   fakeGatewayInterface,
+					fgwnl:  fakeGatewayNamespaceLister,
+					fsei:   fakeServiceEntryInterface,
+					fsenl:  fakeServiceEntryNamespaceLister,
+					fefi:   fakeEnvoyFilterInterface,
+					fefnl:  fakeEnvoyFilterNamespaceLister,
 				})
 			}
 
+			// Routes aren't shared from other Spaces unless a test says
+			// otherwise.
+			fakeRouteLister.EXPECT().
+				List(gomock.Any()).
+				Return(nil, nil).
+				AnyTimes()
+
+			// Domains don't have generated Gateways unless a test says otherwise.
+			fakeGatewayNamespaceLister.EXPECT().
+				Get(gomock.Any()).
+				Return(nil, apierrors.NewNotFound(v1alpha3.Resource("Gateway"), "Gateway")).
+				AnyTimes()
+
+			// Domains don't have generated ServiceEntries unless a test says
+			// otherwise.
+			fakeServiceEntryNamespaceLister.EXPECT().
+				Get(gomock.Any()).
+				Return(nil, apierrors.NewNotFound(v1alpha3.Resource("ServiceEntry"), "ServiceEntry")).
+				AnyTimes()
+
+			// Domains don't have generated EnvoyFilters unless a test says
+			// otherwise.
+			fakeEnvoyFilterNamespaceLister.EXPECT().
+				Get(gomock.Any()).
+				Return(nil, apierrors.NewNotFound(v1alpha3.Resource("EnvoyFilter"), "EnvoyFilter")).
+				AnyTimes()
+			fakeEnvoyFilterNamespaceLister.EXPECT().
+				List(gomock.Any()).
+				Return(nil, nil).
+				AnyTimes()
+
 			r := &Reconciler{
 				Base: &reconciler.Base{
 					KfClientSet: fakeKfInterface,
@@ -1058,9 +2269,13 @@ func TestReconciler_Reconcile_ApplyChanges_NotTrackingVirtualService(t *testing.
 				networkingClientSet:          fakeNetworkingClient,
 				routeLister:                  fakeRouteLister,
 				virtualServiceLister:         fakeVirtualServiceLister,
+				gatewayLister:                fakeGatewayLister,
+				serviceEntryLister:           fakeServiceEntryLister,
+				envoyFilterLister:            fakeEnvoyFilterLister,
 				appLister:                    fakeAppLister,
 				spaceLister:                  fakeSpaceLister,
 				serviceInstanceBindingLister: fakeServiceInstanceBindingLister,
+				serviceLister:                ingressServiceLister(),
 				kfConfigStore: &testConfigStore{&config.DefaultsConfig{
 					RouteTrackVirtualService: false,
 				}},
@@ -1076,3 +2291,37 @@ func TestReconciler_Reconcile_ApplyChanges_NotTrackingVirtualService(t *testing.
 		})
 	}
 }
+
+func TestRateLimitSupport(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		istioVersion string
+		wantErr      error
+	}{
+		"unset": {
+			wantErr: errors.New("rate limits need Istio 1.26.0 or later, set routeIstioVersion in config-defaults to the version running the ingress gateways"),
+		},
+		"invalid": {
+			istioVersion: "latest",
+			wantErr:      errors.New(`invalid routeIstioVersion in config-defaults: could not parse "latest" as version`),
+		},
+		"too old": {
+			istioVersion: "1.18.7-asm.4",
+			wantErr:      errors.New("rate limits need Istio 1.26.0 or later, the ingress gateways run 1.18.7"),
+		},
+		"minimum": {
+			istioVersion: "1.26",
+		},
+		"newer": {
+			istioVersion: "v1.27.1",
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			err := rateLimitSupport(&config.DefaultsConfig{RouteIstioVersion: tc.istioVersion})
+			testutil.AssertErrorsEqual(t, tc.wantErr, err)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"sort"
+)
+
+// ApplyCanaryWeight returns a copy of the destinations with weights that send
+// percent of the traffic to the canary App and the rest to the other Apps.
+// Weights within each group keep their proportions and the result sums to
+// 100. The destinations are returned unchanged if either group is empty.
+// Mirror destinations don't serve traffic so they're kept as-is.
+func ApplyCanaryWeight(destinations RouteBindingSlice, appName string, percent int32) RouteBindingSlice {
+	var canary, stable, mirrors RouteBindingSlice
+	for _, dest := range destinations {
+		if dest.IsMirror() {
+			mirrors = append(mirrors, dest)
+		} else if dest.ServiceName == appName {
+			canary = append(canary, dest)
+		} else {
+			stable = append(stable, dest)
+		}
+	}
+
+	if len(canary) == 0 || len(stable) == 0 {
+		return append(RouteBindingSlice(nil), destinations...)
+	}
+
+	out := append(distributeWeight(canary, percent), distributeWeight(stable, 100-percent)...)
+	out = append(out, mirrors...)
+	sort.Sort(out)
+	return out
+}
+
+// distributeWeight splits total between the destinations proportionally to
+// their weights. Destinations with no weight never receive any remainder.
+func distributeWeight(destinations RouteBindingSlice, total int32) RouteBindingSlice {
+	out := append(RouteBindingSlice(nil), destinations...)
+	sort.Sort(out)
+
+	var sum int64
+	for _, dest := range out {
+		sum += int64(dest.Weight)
+	}
+
+	// Treat all weights as equal if none were set so the share isn't lost.
+	if sum == 0 {
+		for idx := range out {
+			out[idx].Weight = 1
+		}
+		sum = int64(len(out))
+	}
+
+	remainder := total
+	var weighted []int
+	for idx := range out {
+		if out[idx].Weight != 0 {
+			weighted = append(weighted, idx)
+		}
+		weight := int32(int64(total) * int64(out[idx].Weight) / sum) // round down
+		remainder -= weight
+		out[idx].Weight = weight
+	}
+
+	// Rounding loses less than one per weighted destination so a single pass
+	// distributes the remainder.
+	for _, idx := range weighted {
+		if remainder == 0 {
+			break
+		}
+		out[idx].Weight++
+		remainder--
+	}
+
+	return out
+}
@@ -0,0 +1,138 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/kf/v2/pkg/kf/testutil"
+)
+
+func TestApplyCanaryWeight(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		destinations RouteBindingSlice
+		appName      string
+		percent      int32
+		want         RouteBindingSlice
+	}{
+		"canary not bound": {
+			destinations: RouteBindingSlice{makeAppDestination("app-1", 1)},
+			appName:      "canary",
+			percent:      10,
+			want:         RouteBindingSlice{makeAppDestination("app-1", 1)},
+		},
+		"only canary bound": {
+			destinations: RouteBindingSlice{makeAppDestination("canary", 3)},
+			appName:      "canary",
+			percent:      10,
+			want:         RouteBindingSlice{makeAppDestination("canary", 3)},
+		},
+		"single stable app": {
+			destinations: RouteBindingSlice{
+				makeAppDestination("app-1", 5),
+				makeAppDestination("canary", 1),
+			},
+			appName: "canary",
+			percent: 10,
+			want: RouteBindingSlice{
+				makeAppDestination("app-1", 90),
+				makeAppDestination("canary", 10),
+			},
+		},
+		"mirrors keep their weight": {
+			destinations: RouteBindingSlice{
+				makeAppDestination("app-1", 5),
+				makeAppDestination("canary", 1),
+				makeMirrorDestination("shadow", 20),
+			},
+			appName: "canary",
+			percent: 10,
+			want: RouteBindingSlice{
+				makeAppDestination("app-1", 90),
+				makeAppDestination("canary", 10),
+				makeMirrorDestination("shadow", 20),
+			},
+		},
+		"stable apps keep proportions": {
+			destinations: RouteBindingSlice{
+				makeAppDestination("app-1", 1),
+				makeAppDestination("app-2", 2),
+				makeAppDestination("canary", 1),
+			},
+			appName: "canary",
+			percent: 25,
+			want: RouteBindingSlice{
+				makeAppDestination("app-1", 25),
+				makeAppDestination("app-2", 50),
+				makeAppDestination("canary", 25),
+			},
+		},
+		"remainder goes to weighted destinations": {
+			destinations: RouteBindingSlice{
+				makeAppDestination("app-1", 1),
+				makeAppDestination("app-2", 0),
+				makeAppDestination("app-3", 1),
+				makeAppDestination("app-4", 1),
+				makeAppDestination("canary", 1),
+			},
+			appName: "canary",
+			percent: 10,
+			want: RouteBindingSlice{
+				makeAppDestination("app-1", 30),
+				makeAppDestination("app-2", 0),
+				makeAppDestination("app-3", 30),
+				makeAppDestination("app-4", 30),
+				makeAppDestination("canary", 10),
+			},
+		},
+		"aborted canary gets nothing": {
+			destinations: RouteBindingSlice{
+				makeAppDestination("app-1", 1),
+				makeAppDestination("app-2", 1),
+				makeAppDestination("app-3", 1),
+				makeAppDestination("canary", 1),
+			},
+			appName: "canary",
+			percent: 0,
+			want: RouteBindingSlice{
+				makeAppDestination("app-1", 34),
+				makeAppDestination("app-2", 33),
+				makeAppDestination("app-3", 33),
+				makeAppDestination("canary", 0),
+			},
+		},
+		"stable apps without weight": {
+			destinations: RouteBindingSlice{
+				makeAppDestination("app-1", 0),
+				makeAppDestination("canary", 1),
+			},
+			appName: "canary",
+			percent: 40,
+			want: RouteBindingSlice{
+				makeAppDestination("app-1", 60),
+				makeAppDestination("canary", 40),
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got := ApplyCanaryWeight(tc.destinations, tc.appName, tc.percent)
+			testutil.AssertEqual(t, "destinations", tc.want, got)
+		})
+	}
+}
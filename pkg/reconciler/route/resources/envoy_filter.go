@@ -0,0 +1,423 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	kfistio "github.com/google/kf/v2/pkg/apis/networking/v1alpha3"
+	"google.golang.org/protobuf/types/known/structpb"
+	istio "istio.io/api/networking/v1alpha3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/kmeta"
+)
+
+const (
+	// LocalRateLimitFilterName is the name of the Envoy HTTP filter that
+	// enforces Route rate limits.
+	LocalRateLimitFilterName = "envoy.filters.http.local_ratelimit"
+
+	// SpaceAnnotation is the annotation key that holds the Space of Routes
+	// whose resources live in another Namespace.
+	SpaceAnnotation = "kf.dev/space"
+
+	// RouteAnnotation is the annotation key that holds the name of the Route
+	// an EnvoyFilter belongs to.
+	RouteAnnotation = "kf.dev/route"
+
+	// FaultFilterName is the name of the Envoy HTTP filter Istio configures
+	// for the fault injection of VirtualService HTTP Routes.
+	FaultFilterName = "envoy.filters.http.fault"
+
+	httpConnectionManagerName    = "envoy.filters.network.http_connection_manager"
+	httpConnectionManagerTypeURL = "type.googleapis.com/envoy.extensions.filters.network.http_connection_manager.v3.HttpConnectionManager"
+	localRateLimitTypeURL        = "type.googleapis.com/envoy.extensions.filters.http.local_ratelimit.v3.LocalRateLimit"
+	faultTypeURL                 = "type.googleapis.com/envoy.extensions.filters.http.fault.v3.HTTPFault"
+	typedStructTypeURL           = "type.googleapis.com/udpa.type.v1.TypedStruct"
+
+	// rateLimitRouteKey is the descriptor key that identifies the Route a
+	// request was sent to so each Route has its own buckets.
+	rateLimitRouteKey = "route"
+
+	// rateLimitClientKey is the descriptor key used to identify clients
+	// by a request header.
+	rateLimitClientKey = "client"
+
+	// rateLimitMaxClients is the number of client buckets Envoy keeps per
+	// Route, the least recently used bucket is dropped when it's exceeded.
+	rateLimitMaxClients = 10000
+)
+
+// MakeEnvoyFilterName creates the name of the EnvoyFilter that enforces the
+// rate limit and serves the maintenance message of a Route. EnvoyFilters live
+// in the ingress gateway's Namespace so the name includes the Space.
+func MakeEnvoyFilterName(route *v1alpha1.Route) string {
+	return v1alpha1.GenerateName(route.Namespace, route.Name)
+}
+
+// MakeGatewayRouteName creates the name of the VirtualService HTTP Routes of a
+// rate limited Route or a Route in maintenance mode. The EnvoyFilter matches
+// the gateway routes using it.
+func MakeGatewayRouteName(namespace string, rsf v1alpha1.RouteSpecFields) string {
+	return fmt.Sprintf("%s.%s", namespace, v1alpha1.GenerateRouteNameFromFields(rsf))
+}
+
+// EnvoyFilterSettings holds the features of the ingress gateway EnvoyFilters
+// are created for.
+type EnvoyFilterSettings struct {
+	// RateLimits is true if the ingress gateway supports the Envoy features
+	// rate limits need.
+	RateLimits bool
+
+	// TrustedHops is the number of proxies in front of the ingress gateway
+	// that append to the X-Forwarded-For header.
+	TrustedHops int32
+}
+
+// NeedsEnvoyFilter returns true if the Route has a rate limit or maintenance
+// message the ingress gateway has to be configured for.
+func NeedsEnvoyFilter(route *v1alpha1.Route, settings EnvoyFilterSettings) bool {
+	return enforcesRateLimit(route, settings) || servesMaintenanceMessage(route)
+}
+
+func enforcesRateLimit(route *v1alpha1.Route, settings EnvoyFilterSettings) bool {
+	return settings.RateLimits && route.Spec.Policy != nil && route.Spec.Policy.RateLimit != nil
+}
+
+func servesMaintenanceMessage(route *v1alpha1.Route) bool {
+	return route.Spec.Maintenance != nil && route.Spec.Maintenance.Message != ""
+}
+
+// MakeEnvoyFilter creates an EnvoyFilter that configures the rate limit and
+// maintenance response of a Route on the ingress gateway pods matching the
+// selector.
+//
+// Istio only applies EnvoyFilters to workloads in their own Namespace, so
+// it's created in the ingress gateway's Namespace. Kubernetes doesn't allow
+// owners in other Namespaces, the reconciler deletes it with the Route and the
+// Space is its owner in case the whole Space is deleted.
+//
+// Each EnvoyFilter adds its own local rate limit filter to the gateway so it
+// can be removed without affecting other Routes. The filter is a no-op for
+// gateway routes of other Routes.
+func MakeEnvoyFilter(
+	space *v1alpha1.Space,
+	route *v1alpha1.Route,
+	ingressNamespace string,
+	selector map[string]string,
+	settings EnvoyFilterSettings,
+) (*kfistio.EnvoyFilter, error) {
+	name := MakeEnvoyFilterName(route)
+	routeName := MakeGatewayRouteName(route.Namespace, route.Spec.RouteSpecFields)
+
+	if !NeedsEnvoyFilter(route, settings) {
+		return nil, errors.New("route has no rate limit or maintenance message")
+	}
+
+	var patches []*istio.EnvoyFilter_EnvoyConfigObjectPatch
+	if enforcesRateLimit(route, settings) {
+		rateLimit := route.Spec.Policy.RateLimit
+		filterName := fmt.Sprintf("%s.%s", LocalRateLimitFilterName, name)
+
+		if settings.TrustedHops > 0 {
+			patch, err := buildTrustedHopsPatch(settings.TrustedHops)
+			if err != nil {
+				return nil, err
+			}
+			patches = append(patches, patch)
+		}
+
+		filterPatch, err := buildRateLimitFilterPatch(filterName)
+		if err != nil {
+			return nil, err
+		}
+
+		routePatch, err := buildRateLimitPatch(filterName, routeName, rateLimit)
+		if err != nil {
+			return nil, err
+		}
+		patches = append(patches, filterPatch, routePatch)
+	}
+
+	if servesMaintenanceMessage(route) {
+		patch, err := buildMaintenancePatch(routeName, route.Spec.Maintenance)
+		if err != nil {
+			return nil, err
+		}
+		patches = append(patches, patch)
+	}
+
+	return &kfistio.EnvoyFilter{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "networking.istio.io/v1alpha3",
+			Kind:       "EnvoyFilter",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ingressNamespace,
+			Labels: map[string]string{
+				v1alpha1.ManagedByLabel: "kf",
+				v1alpha1.ComponentLabel: "envoyfilter",
+			},
+			Annotations: map[string]string{
+				DomainAnnotation: route.Spec.RouteSpecFields.Domain,
+				SpaceAnnotation:  route.Namespace,
+				RouteAnnotation:  route.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(space),
+			},
+		},
+		Spec: istio.EnvoyFilter{
+			WorkloadSelector: &istio.WorkloadSelector{
+				Labels: selector,
+			},
+			ConfigPatches: patches,
+		},
+	}, nil
+}
+
+// buildTrustedHopsPatch creates a patch that makes the gateway trust the given
+// number of proxies in front of it. Envoy uses the X-Forwarded-For address
+// appended by the outermost trusted proxy as the client's address when rate
+// limiting rather than the address of the proxy that connected to it.
+func buildTrustedHopsPatch(trustedHops int32) (*istio.EnvoyFilter_EnvoyConfigObjectPatch, error) {
+	value, err := structpb.NewStruct(map[string]interface{}{
+		"typed_config": map[string]interface{}{
+			"@type":                httpConnectionManagerTypeURL,
+			"xff_num_trusted_hops": trustedHops,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("building trusted hops: %v", err)
+	}
+
+	return &istio.EnvoyFilter_EnvoyConfigObjectPatch{
+		ApplyTo: istio.EnvoyFilter_NETWORK_FILTER,
+		Match: &istio.EnvoyFilter_EnvoyConfigObjectMatch{
+			Context: istio.EnvoyFilter_GATEWAY,
+			ObjectTypes: &istio.EnvoyFilter_EnvoyConfigObjectMatch_Listener{
+				Listener: &istio.EnvoyFilter_ListenerMatch{
+					FilterChain: &istio.EnvoyFilter_ListenerMatch_FilterChainMatch{
+						Filter: &istio.EnvoyFilter_ListenerMatch_FilterMatch{
+							Name: httpConnectionManagerName,
+						},
+					},
+				},
+			},
+		},
+		Patch: &istio.EnvoyFilter_Patch{
+			Operation: istio.EnvoyFilter_Patch_MERGE,
+			Value:     value,
+		},
+	}, nil
+}
+
+// buildRateLimitFilterPatch creates a patch that adds a local rate limit
+// filter with the given name to the gateway. The filter has no limits of its
+// own, routes configure them.
+func buildRateLimitFilterPatch(filterName string) (*istio.EnvoyFilter_EnvoyConfigObjectPatch, error) {
+	value, err := structpb.NewStruct(map[string]interface{}{
+		"name": filterName,
+		"typed_config": map[string]interface{}{
+			"@type":    typedStructTypeURL,
+			"type_url": localRateLimitTypeURL,
+			"value": map[string]interface{}{
+				"stat_prefix": "http_local_rate_limiter",
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("building rate limit filter %q: %v", filterName, err)
+	}
+
+	return &istio.EnvoyFilter_EnvoyConfigObjectPatch{
+		ApplyTo: istio.EnvoyFilter_HTTP_FILTER,
+		Match: &istio.EnvoyFilter_EnvoyConfigObjectMatch{
+			Context: istio.EnvoyFilter_GATEWAY,
+			ObjectTypes: &istio.EnvoyFilter_EnvoyConfigObjectMatch_Listener{
+				Listener: &istio.EnvoyFilter_ListenerMatch{
+					FilterChain: &istio.EnvoyFilter_ListenerMatch_FilterChainMatch{
+						Filter: &istio.EnvoyFilter_ListenerMatch_FilterMatch{
+							Name: httpConnectionManagerName,
+							SubFilter: &istio.EnvoyFilter_ListenerMatch_SubFilterMatch{
+								Name: "envoy.filters.http.router",
+							},
+						},
+					},
+				},
+			},
+		},
+		Patch: &istio.EnvoyFilter_Patch{
+			Operation: istio.EnvoyFilter_Patch_INSERT_BEFORE,
+			Value:     value,
+		},
+	}, nil
+}
+
+// buildMaintenancePatch creates a patch that makes the gateway routes with the
+// given name respond with the maintenance status and message.
+//
+// The VirtualService aborts requests to Routes in maintenance mode so the
+// status is returned even where the patch doesn't apply. The abort runs in
+// the fault filter before the route's response, so the patch replaces the
+// route's fault configuration with one that doesn't inject faults.
+func buildMaintenancePatch(routeName string, maintenance *v1alpha1.RouteMaintenance) (*istio.EnvoyFilter_EnvoyConfigObjectPatch, error) {
+	status := maintenance.StatusCode
+	if status == 0 {
+		status = v1alpha1.DefaultRouteMaintenanceStatusCode
+	}
+
+	value, err := structpb.NewStruct(map[string]interface{}{
+		"direct_response": map[string]interface{}{
+			"status": status,
+			"body": map[string]interface{}{
+				"inline_string": maintenance.Message,
+			},
+		},
+		"typed_per_filter_config": map[string]interface{}{
+			FaultFilterName: map[string]interface{}{
+				"@type": faultTypeURL,
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("building maintenance response for %q: %v", routeName, err)
+	}
+
+	return &istio.EnvoyFilter_EnvoyConfigObjectPatch{
+		ApplyTo: istio.EnvoyFilter_HTTP_ROUTE,
+		Match:   gatewayRouteMatch(routeName),
+		Patch: &istio.EnvoyFilter_Patch{
+			Operation: istio.EnvoyFilter_Patch_MERGE,
+			Value:     value,
+		},
+	}, nil
+}
+
+// gatewayRouteMatch matches the gateway routes with the given name.
+func gatewayRouteMatch(routeName string) *istio.EnvoyFilter_EnvoyConfigObjectMatch {
+	return &istio.EnvoyFilter_EnvoyConfigObjectMatch{
+		Context: istio.EnvoyFilter_GATEWAY,
+		ObjectTypes: &istio.EnvoyFilter_EnvoyConfigObjectMatch_RouteConfiguration{
+			RouteConfiguration: &istio.EnvoyFilter_RouteConfigurationMatch{
+				Vhost: &istio.EnvoyFilter_RouteConfigurationMatch_VirtualHostMatch{
+					Route: &istio.EnvoyFilter_RouteConfigurationMatch_RouteMatch{
+						Name: routeName,
+					},
+				},
+			},
+		},
+	}
+}
+
+// buildRateLimitPatch creates a patch that adds a local rate limit to the
+// gateway routes with the given name.
+//
+// The route's rate limit actions produce a descriptor with the route's name
+// and the client's IP address or header value. The IP address is the one
+// trusted proxies added to the X-Forwarded-For header, or the address of the
+// connection if there are none. The descriptor's client entry
+// has no value so Envoy creates a token bucket per client, refilled every
+// second. Requests without the client header share the route's default
+// bucket.
+func buildRateLimitPatch(filterName, routeName string, rateLimit *v1alpha1.RouteRateLimit) (*istio.EnvoyFilter_EnvoyConfigObjectPatch, error) {
+	tokenBucket := map[string]interface{}{
+		"max_tokens":      rateLimit.RequestsPerSecond,
+		"tokens_per_fill": rateLimit.RequestsPerSecond,
+		"fill_interval":   "1s",
+	}
+
+	fullyEnabled := func(runtimeKey string) map[string]interface{} {
+		return map[string]interface{}{
+			"runtime_key": runtimeKey,
+			"default_value": map[string]interface{}{
+				"numerator":   100,
+				"denominator": "HUNDRED",
+			},
+		}
+	}
+
+	routeAction := map[string]interface{}{
+		"generic_key": map[string]interface{}{
+			"descriptor_key":   rateLimitRouteKey,
+			"descriptor_value": routeName,
+		},
+	}
+
+	clientKey := "remote_address"
+	clientAction := map[string]interface{}{
+		"remote_address": map[string]interface{}{},
+	}
+	if rateLimit.Header != "" {
+		clientKey = rateLimitClientKey
+		clientAction = map[string]interface{}{
+			"request_headers": map[string]interface{}{
+				"header_name":    rateLimit.Header,
+				"descriptor_key": rateLimitClientKey,
+			},
+		}
+	}
+
+	value, err := structpb.NewStruct(map[string]interface{}{
+		"typed_per_filter_config": map[string]interface{}{
+			filterName: map[string]interface{}{
+				"@type":    typedStructTypeURL,
+				"type_url": localRateLimitTypeURL,
+				"value": map[string]interface{}{
+					"stat_prefix":     "http_local_rate_limiter",
+					"token_bucket":    tokenBucket,
+					"filter_enabled":  fullyEnabled("local_rate_limit_enabled"),
+					"filter_enforced": fullyEnabled("local_rate_limit_enforced"),
+					// Client buckets replace the default bucket rather
+					// than sharing it.
+					"always_consume_default_token_bucket": false,
+					"max_dynamic_descriptors":             rateLimitMaxClients,
+					"descriptors": []interface{}{
+						map[string]interface{}{
+							"entries": []interface{}{
+								map[string]interface{}{"key": rateLimitRouteKey, "value": routeName},
+								// No value, each client gets a bucket.
+								map[string]interface{}{"key": clientKey},
+							},
+							"token_bucket": tokenBucket,
+						},
+					},
+				},
+			},
+		},
+		"route": map[string]interface{}{
+			"rate_limits": []interface{}{
+				map[string]interface{}{
+					"actions": []interface{}{routeAction, clientAction},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("building rate limit for %q: %v", routeName, err)
+	}
+
+	return &istio.EnvoyFilter_EnvoyConfigObjectPatch{
+		ApplyTo: istio.EnvoyFilter_HTTP_ROUTE,
+		Match:   gatewayRouteMatch(routeName),
+		Patch: &istio.EnvoyFilter_Patch{
+			Operation: istio.EnvoyFilter_Patch_MERGE,
+			Value:     value,
+		},
+	}, nil
+}
@@ -0,0 +1,203 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+)
+
+func TestMakeEnvoyFilter(t *testing.T) {
+	t.Parallel()
+
+	selector := map[string]string{"istio": "ingressgateway"}
+	space := &v1alpha1.Space{}
+	space.Name = "some-namespace"
+	rateLimits := EnvoyFilterSettings{RateLimits: true}
+
+	for tn, tc := range map[string]struct {
+		Route     *v1alpha1.Route
+		Settings  EnvoyFilterSettings
+		assertErr error
+	}{
+		"route without rate limit": {
+			Route: makeRouteWithPolicy("other-host", "example.com", "/", "some-namespace", &v1alpha1.RoutePolicy{
+				RequestHeaders: &v1alpha1.RouteHeaderOperations{Remove: []string{"X-Debug"}},
+			}),
+			Settings:  rateLimits,
+			assertErr: errors.New("route has no rate limit or maintenance message"),
+		},
+		"rate limits unsupported": {
+			Route: makeRouteWithPolicy("web", "example.com", "/", "some-namespace", &v1alpha1.RoutePolicy{
+				RateLimit: &v1alpha1.RouteRateLimit{RequestsPerSecond: 10},
+			}),
+			assertErr: errors.New("route has no rate limit or maintenance message"),
+		},
+		"rate limit by client IP": {
+			Route: makeRouteWithPolicy("web", "example.com", "/", "some-namespace", &v1alpha1.RoutePolicy{
+				RateLimit: &v1alpha1.RouteRateLimit{RequestsPerSecond: 10},
+			}),
+			Settings: rateLimits,
+		},
+		"rate limit by client IP behind trusted proxies": {
+			Route: makeRouteWithPolicy("web", "example.com", "/", "some-namespace", &v1alpha1.RoutePolicy{
+				RateLimit: &v1alpha1.RouteRateLimit{RequestsPerSecond: 10},
+			}),
+			Settings: EnvoyFilterSettings{RateLimits: true, TrustedHops: 2},
+		},
+		"rate limit by header": {
+			Route: makeRouteWithPolicy("api", "example.com", "/v1", "some-namespace", &v1alpha1.RoutePolicy{
+				RateLimit: &v1alpha1.RouteRateLimit{RequestsPerSecond: 100, Header: "X-Api-Key"},
+			}),
+			Settings: rateLimits,
+		},
+		"maintenance message": {
+			Route: makeRouteWithMaintenance("web", "example.com", "/", "some-namespace", &v1alpha1.RouteMaintenance{
+				StatusCode: 503,
+				Message:    "Database migration",
+			}),
+		},
+		"maintenance without message": {
+			Route: makeRouteWithMaintenance("api", "example.com", "/v1", "some-namespace", &v1alpha1.RouteMaintenance{
+				StatusCode: 500,
+			}),
+			Settings:  rateLimits,
+			assertErr: errors.New("route has no rate limit or maintenance message"),
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			actual, actualErr := MakeEnvoyFilter(space, tc.Route, "istio-system", selector, tc.Settings)
+			testutil.AssertErrorsEqual(t, tc.assertErr, actualErr)
+			testutil.AssertEqual(t, "needs EnvoyFilter", tc.assertErr == nil, NeedsEnvoyFilter(tc.Route, tc.Settings))
+			if tc.assertErr != nil {
+				return
+			}
+
+			testutil.AssertGoldenJSONContext(t, "envoyfilter", actual, map[string]interface{}{
+				"route":    tc.Route,
+				"settings": tc.Settings,
+			})
+		})
+	}
+}
+
+func TestBuildTrustedHopsPatch(t *testing.T) {
+	t.Parallel()
+
+	patch, err := buildTrustedHopsPatch(2)
+	testutil.AssertNil(t, "err", err)
+
+	// The client address used by rate limits comes from the
+	// X-Forwarded-For entry added by the outermost trusted proxy.
+	testutil.AssertEqual(t, "typed_config", map[string]interface{}{
+		"@type":                "type.googleapis.com/envoy.extensions.filters.network.http_connection_manager.v3.HttpConnectionManager",
+		"xff_num_trusted_hops": float64(2),
+	}, patch.Patch.Value.AsMap()["typed_config"])
+}
+
+func TestBuildMaintenancePatch(t *testing.T) {
+	t.Parallel()
+
+	patch, err := buildMaintenancePatch("some-route", &v1alpha1.RouteMaintenance{
+		StatusCode: 502,
+		Message:    "Database migration",
+	})
+	testutil.AssertNil(t, "err", err)
+	testutil.AssertEqual(t, "route name", "some-route", patch.Match.GetRouteConfiguration().GetVhost().GetRoute().GetName())
+
+	value := patch.Patch.Value.AsMap()
+
+	// The route responds with the message instead of the VirtualService's
+	// abort.
+	testutil.AssertEqual(t, "direct_response", map[string]interface{}{
+		"status": float64(502),
+		"body": map[string]interface{}{
+			"inline_string": "Database migration",
+		},
+	}, value["direct_response"])
+	testutil.AssertEqual(t, "typed_per_filter_config", map[string]interface{}{
+		"envoy.filters.http.fault": map[string]interface{}{
+			"@type": "type.googleapis.com/envoy.extensions.filters.http.fault.v3.HTTPFault",
+		},
+	}, value["typed_per_filter_config"])
+}
+
+func TestBuildRateLimitPatch(t *testing.T) {
+	t.Parallel()
+
+	for tn, tc := range map[string]struct {
+		rateLimit        *v1alpha1.RouteRateLimit
+		wantClientAction map[string]interface{}
+		wantClientKey    string
+	}{
+		"by client IP": {
+			rateLimit: &v1alpha1.RouteRateLimit{RequestsPerSecond: 10},
+			wantClientAction: map[string]interface{}{
+				"remote_address": map[string]interface{}{},
+			},
+			wantClientKey: "remote_address",
+		},
+		"by header": {
+			rateLimit: &v1alpha1.RouteRateLimit{RequestsPerSecond: 10, Header: "X-Api-Key"},
+			wantClientAction: map[string]interface{}{
+				"request_headers": map[string]interface{}{
+					"header_name":    "X-Api-Key",
+					"descriptor_key": "client",
+				},
+			},
+			wantClientKey: "client",
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			patch, err := buildRateLimitPatch("some-filter", "some-route", tc.rateLimit)
+			testutil.AssertNil(t, "err", err)
+
+			value := patch.Patch.Value.AsMap()
+
+			// The route's actions identify the route and the client.
+			rateLimits := value["route"].(map[string]interface{})["rate_limits"].([]interface{})
+			testutil.AssertEqual(t, "rate_limits count", 1, len(rateLimits))
+			testutil.AssertEqual(t, "actions", []interface{}{
+				map[string]interface{}{
+					"generic_key": map[string]interface{}{
+						"descriptor_key":   "route",
+						"descriptor_value": "some-route",
+					},
+				},
+				tc.wantClientAction,
+			}, rateLimits[0].(map[string]interface{})["actions"])
+
+			// The descriptor matches the route by key and value and has a
+			// wildcard client entry so each client gets its own bucket.
+			config := value["typed_per_filter_config"].(map[string]interface{})["some-filter"].(map[string]interface{})["value"].(map[string]interface{})
+			descriptors := config["descriptors"].([]interface{})
+			testutil.AssertEqual(t, "descriptors count", 1, len(descriptors))
+			descriptor := descriptors[0].(map[string]interface{})
+			testutil.AssertEqual(t, "entries", []interface{}{
+				map[string]interface{}{"key": "route", "value": "some-route"},
+				map[string]interface{}{"key": tc.wantClientKey},
+			}, descriptor["entries"])
+			testutil.AssertEqual(t, "token_bucket", map[string]interface{}{
+				"max_tokens":      float64(10),
+				"tokens_per_fill": float64(10),
+				"fill_interval":   "1s",
+			}, descriptor["token_bucket"])
+			testutil.AssertEqual(t, "always_consume_default_token_bucket", false, config["always_consume_default_token_bucket"])
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	kfistio "github.com/google/kf/v2/pkg/apis/networking/v1alpha3"
+	istio "istio.io/api/networking/v1alpha3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MakeGatewayName creates the name of the Gateway that holds the reserved
+// ports for the given TCP domain.
+func MakeGatewayName(domain string) string {
+	return v1alpha1.GenerateName(domain)
+}
+
+// MakeGateway creates a Gateway that opens the ports reserved by TCP Routes on
+// the ingress gateway pods matching the selector.
+func MakeGateway(
+	routes []*v1alpha1.Route,
+	selector map[string]string,
+) (*kfistio.Gateway, error) {
+	if len(routes) == 0 {
+		return nil, errors.New("routes must not be empty")
+	}
+
+	namespace := routes[0].Namespace
+	domain := routes[0].Spec.RouteSpecFields.Domain
+
+	var ports []int
+	seen := make(map[int32]bool)
+	for _, route := range routes {
+		port := route.Spec.Port
+		if port == 0 || seen[port] {
+			continue
+		}
+		seen[port] = true
+		ports = append(ports, int(port))
+	}
+	sort.Ints(ports)
+
+	var servers []*istio.Server
+	for _, port := range ports {
+		servers = append(servers, &istio.Server{
+			Port: &istio.Port{
+				Number:   uint32(port),
+				Name:     fmt.Sprintf("tcp-%d", port),
+				Protocol: "TCP",
+			},
+			// TCP traffic has no host header to match on.
+			Hosts: []string{"*"},
+		})
+	}
+
+	return &kfistio.Gateway{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "networking.istio.io/v1alpha3",
+			Kind:       "Gateway",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      MakeGatewayName(domain),
+			Namespace: namespace,
+			Labels: map[string]string{
+				v1alpha1.ManagedByLabel: "kf",
+				v1alpha1.ComponentLabel: "gateway",
+			},
+			Annotations: map[string]string{
+				DomainAnnotation: domain,
+			},
+			OwnerReferences: makeRouteOwnerReferences(routes),
+		},
+		Spec: istio.Gateway{
+			Selector: selector,
+			Servers:  servers,
+		},
+	}, nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+)
+
+func TestMakeGateway(t *testing.T) {
+	t.Parallel()
+
+	for tn, tc := range map[string]struct {
+		Routes    []*v1alpha1.Route
+		Selector  map[string]string
+		assertErr error
+	}{
+		"empty list of routes": {
+			Routes:    []*v1alpha1.Route{},
+			assertErr: errors.New("routes must not be empty"),
+		},
+		"ports are deduplicated and sorted": {
+			Routes: []*v1alpha1.Route{
+				makeTCPRoute("tcp.example.com", 1026, "some-namespace"),
+				makeTCPRoute("tcp.example.com", 1024, "some-namespace"),
+				makeTCPRoute("tcp.example.com", 1026, "some-namespace"),
+			},
+			Selector: map[string]string{
+				"istio": "ingressgateway",
+			},
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			actual, actualErr := MakeGateway(tc.Routes, tc.Selector)
+			testutil.AssertErrorsEqual(t, tc.assertErr, actualErr)
+			testutil.AssertGoldenJSONContext(t, "gateway", actual, map[string]interface{}{
+				"routes":   tc.Routes,
+				"selector": tc.Selector,
+			})
+		})
+	}
+}
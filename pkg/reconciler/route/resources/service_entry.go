@@ -0,0 +1,88 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"errors"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	kfistio "github.com/google/kf/v2/pkg/apis/networking/v1alpha3"
+	istio "istio.io/api/networking/v1alpha3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// MakeServiceEntryName creates the name of the ServiceEntry that registers
+// the hosts of Routes on the given internal domain.
+func MakeServiceEntryName(domain string) string {
+	return v1alpha1.GenerateName(domain)
+}
+
+// MakeServiceEntry creates a ServiceEntry that registers the hosts of Routes
+// on an internal domain with the mesh. Sidecars with DNS proxying enabled
+// resolve the hosts, and the domain's mesh VirtualService routes the requests
+// to the Apps' Services.
+func MakeServiceEntry(routes []*v1alpha1.Route) (*kfistio.ServiceEntry, error) {
+	if len(routes) == 0 {
+		return nil, errors.New("routes must not be empty")
+	}
+
+	namespace := routes[0].Namespace
+	domain := routes[0].Spec.RouteSpecFields.Domain
+
+	// Wildcard hosts can't be given an address, so only Routes with a
+	// concrete host are registered.
+	hosts := sets.NewString()
+	for _, route := range routes {
+		if route.Spec.RouteSpecFields.IsWildcard() {
+			continue
+		}
+		hosts.Insert(route.Spec.RouteSpecFields.Host())
+	}
+
+	return &kfistio.ServiceEntry{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "networking.istio.io/v1alpha3",
+			Kind:       "ServiceEntry",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      MakeServiceEntryName(domain),
+			Namespace: namespace,
+			Labels: map[string]string{
+				v1alpha1.ManagedByLabel: "kf",
+				v1alpha1.ComponentLabel: "serviceentry",
+			},
+			Annotations: map[string]string{
+				DomainAnnotation: domain,
+			},
+			OwnerReferences: makeRouteOwnerReferences(routes),
+		},
+		Spec: istio.ServiceEntry{
+			Hosts:    hosts.List(),
+			ExportTo: []string{"."},
+			Ports: []*istio.Port{
+				{
+					Number:   80,
+					Name:     "http",
+					Protocol: "HTTP",
+				},
+			},
+			Location: istio.ServiceEntry_MESH_INTERNAL,
+			// Requests are routed by the VirtualService, the entry only
+			// needs an address so the hosts can be resolved.
+			Resolution: istio.ServiceEntry_STATIC,
+		},
+	}, nil
+}
@@ -0,0 +1,54 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+)
+
+func TestMakeServiceEntry(t *testing.T) {
+	t.Parallel()
+
+	for tn, tc := range map[string]struct {
+		Routes    []*v1alpha1.Route
+		assertErr error
+	}{
+		"empty list of routes": {
+			Routes:    []*v1alpha1.Route{},
+			assertErr: errors.New("routes must not be empty"),
+		},
+		"hosts are deduplicated and sorted": {
+			Routes: []*v1alpha1.Route{
+				makeRoute("web", "apps.internal", "/", "some-namespace"),
+				makeRoute("api", "apps.internal", "/v1", "some-namespace"),
+				makeRoute("api", "apps.internal", "/v2", "some-namespace"),
+				makeRoute("", "apps.internal", "/", "some-namespace"),
+				makeRoute("*", "apps.internal", "/", "some-namespace"),
+			},
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			actual, actualErr := MakeServiceEntry(tc.Routes)
+			testutil.AssertErrorsEqual(t, tc.assertErr, actualErr)
+			testutil.AssertGoldenJSONContext(t, "serviceentry", actual, map[string]interface{}{
+				"routes": tc.Routes,
+			})
+		})
+	}
+}
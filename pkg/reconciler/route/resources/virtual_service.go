@@ -25,9 +25,13 @@ import (
 	kfconfig "github.com/google/kf/v2/pkg/apis/kf/config"
 	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
 	kfistio "github.com/google/kf/v2/pkg/apis/networking/v1alpha3"
+	appresources "github.com/google/kf/v2/pkg/reconciler/app/resources"
 	serviceinstance "github.com/google/kf/v2/pkg/reconciler/serviceinstance/resources"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 	istio "istio.io/api/networking/v1alpha3"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 const (
@@ -61,7 +65,21 @@ const (
 
 	// KfInternalIngressGateway is used as a flag to specify the internal routing.
 	// With ASM 1.7 all the internal east-west traffic can use side car proxy and we do not need any additional gateway.
-	KfInternalIngressGateway = "kf/internal-gateway"
+	KfInternalIngressGateway = v1alpha1.KfInternalIngressGateway
+
+	// ActivatorHost is the Service of the activator that receives traffic for
+	// Apps with an idle timeout.
+	ActivatorHost = "activator." + v1alpha1.KfNamespace + ".svc.cluster.local"
+
+	// ActivatorPort is the port the activator's Service listens on.
+	ActivatorPort = 80
+
+	// GRPCRetryAttempts is the number of retries for Routes serving gRPC.
+	GRPCRetryAttempts = 2
+
+	// GRPCRetryOn holds the conditions Routes serving gRPC are retried on.
+	// They match the gRPC status codes that indicate a transient failure.
+	GRPCRetryOn = "connect-failure,refused-stream,unavailable,cancelled,resource-exhausted"
 )
 
 // RouteBindingSlice is a sortable list of v1alpha1.RouteDestination.
@@ -93,13 +111,20 @@ func MakeVirtualServiceName(domain string) string {
 	return v1alpha1.GenerateName(domain)
 }
 
-// MakeVirtualService creates a VirtualService from a Route object.
+// MakeVirtualService creates a VirtualService from a Route object. Traffic for
+// the Apps in activatorApps is sent through the activator. Routes whose
+// destinations are all in grpcDestinations get gRPC retry and timeout
+// defaults. Apps in instanceApps can have requests sent to a specific
+// instance, the value is the number of instances they can have.
 func MakeVirtualService(
 	routes []*v1alpha1.Route,
 	bindings map[string]RouteBindingSlice,
 	routeServiceBindings map[string][]v1alpha1.RouteServiceDestination,
 	spaceDomain *v1alpha1.SpaceDomain,
 	defaultsConfig *kfconfig.DefaultsConfig,
+	activatorApps sets.String,
+	grpcDestinations sets.String,
+	instanceApps map[string]int32,
 ) (*kfistio.VirtualService, error) {
 	if len(routes) == 0 {
 		return nil, errors.New("routes must not be empty")
@@ -116,52 +141,75 @@ func MakeVirtualService(
 	}
 	sort.Sort(rsfs)
 
-	httpRoutes, err := newHTTPRoutesBuilder(rsfs, bindings, routeServiceBindings, defaultsConfig).build()
-	if err != nil {
-		return nil, err
+	var istioVirtualService *istio.VirtualService
+	if spaceDomain.IsTCP() {
+		// TCP traffic can't be matched by host, so the VS is bound to the
+		// Gateway holding the reserved ports and matches on the port instead.
+		istioVirtualService = &istio.VirtualService{
+			Gateways: []string{namespace + "/" + MakeGatewayName(domain)},
+			Hosts:    []string{"*"},
+			Tcp:      buildTCPRoutes(rsfs, bindings),
+		}
+
+		return makeVirtualService(routes, istioVirtualService), nil
 	}
 
-	gatewayName, err := buildGatewayName(spaceDomain.GatewayName)
-	if err != nil {
-		return nil, err
+	// Policies are keyed the same way as bindings so equal RouteSpecFields
+	// share them.
+	policies := make(map[string]*v1alpha1.RoutePolicy)
+	for _, r := range routes {
+		if r.Spec.Policy != nil {
+			policies[r.Spec.RouteSpecFields.String()] = r.Spec.Policy
+		}
 	}
 
-	// Mark all of the Routes as owners so the VS gets deleted if
-	// they are all deleted.
-	//
-	// NOTE that this is NOT marking them as controllers, just as equal owners.
-	var owners []metav1.OwnerReference
-	for _, route := range routes {
-		gvk := route.GetGroupVersionKind()
-		owners = append(owners, metav1.OwnerReference{
-			APIVersion: gvk.GroupVersion().String(),
-			Kind:       gvk.Kind,
-			UID:        route.GetUID(),
-			Name:       route.GetName(),
-		})
+	// Routes in maintenance mode don't forward requests to their Apps.
+	maintenance := make(map[string]*v1alpha1.RouteMaintenance)
+	for _, r := range routes {
+		if r.Spec.Maintenance != nil {
+			maintenance[r.Spec.RouteSpecFields.String()] = r.Spec.Maintenance
+		}
 	}
 
-	sort.Slice(owners, func(i, j int) bool {
-		return owners[i].Name < owners[j].Name
-	})
+	httpRoutes, err := newHTTPRoutesBuilder(namespace, rsfs, bindings, routeServiceBindings, policies, maintenance, defaultsConfig, activatorApps, grpcDestinations, instanceApps).build()
+	if err != nil {
+		return nil, err
+	}
 
 	hosts := []string{"*." + domain, domain} // Value of Hosts can be hostname.example.com or example.com since hostname is optional.
-	istioVirtualService := istio.VirtualService{}
-	if gatewayName == "" {
-		istioVirtualService = istio.VirtualService{
+	if spaceDomain.IsInternal() {
+		// Internal domains are routed by the sidecars in the mesh rather than
+		// an ingress gateway.
+		istioVirtualService = &istio.VirtualService{
 			Hosts: hosts,
 			Http:  httpRoutes,
 		}
 	} else {
-		istioVirtualService = istio.VirtualService{
-			Gateways: []string{gatewayName},
+		gateways := []string{spaceDomain.GatewayName}
+		if spaceDomain.TLS != nil {
+			// The Space reconciler creates a Gateway in the kf Namespace that
+			// terminates HTTPS for the domain.
+			gateways = append(gateways, v1alpha1.KfNamespace+"/"+spaceDomain.TLSGatewayName(namespace))
+		}
+
+		istioVirtualService = &istio.VirtualService{
+			Gateways: gateways,
 			Hosts:    hosts,
 			Http:     httpRoutes,
 		}
 	}
 
+	return makeVirtualService(routes, istioVirtualService), nil
+}
+
+// makeVirtualService wraps the spec with the metadata shared by every
+// VirtualService Kf creates for a domain.
+func makeVirtualService(routes []*v1alpha1.Route, spec *istio.VirtualService) *kfistio.VirtualService {
+	namespace := routes[0].Namespace
+	domain := routes[0].Spec.RouteSpecFields.Domain
+
 	// Configuring the VirtualService based on the spec definition: https://istio.io/latest/docs/reference/config/networking/virtual-service/
-	return &kfistio.VirtualService{
+	vs := &kfistio.VirtualService{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "networking.istio.io/v1alpha3",
 			Kind:       "VirtualService",
@@ -176,44 +224,183 @@ func MakeVirtualService(
 			Annotations: map[string]string{
 				DomainAnnotation: domain,
 			},
-			OwnerReferences: owners,
+			OwnerReferences: makeRouteOwnerReferences(routes),
 		},
-		Spec: istioVirtualService,
-	}, nil
+	}
+	// The spec is a protobuf message so it's cloned rather than copied.
+	spec.DeepCopyInto(&vs.Spec)
+
+	return vs
 }
 
-// Checks the gatewayName specified.
-// For gatewayName=kf/internal-gateway use the internal service mesh and do not use any gateway.
-func buildGatewayName(gatewayName string) (string, error) {
-	if KfInternalIngressGateway == gatewayName {
-		return "", nil
+// makeRouteOwnerReferences marks all of the Routes as owners so the generated
+// object gets deleted if they are all deleted.
+//
+// NOTE that this is NOT marking them as controllers, just as equal owners.
+func makeRouteOwnerReferences(routes []*v1alpha1.Route) []metav1.OwnerReference {
+	var owners []metav1.OwnerReference
+	for _, route := range routes {
+		gvk := route.GetGroupVersionKind()
+		owners = append(owners, metav1.OwnerReference{
+			APIVersion: gvk.GroupVersion().String(),
+			Kind:       gvk.Kind,
+			UID:        route.GetUID(),
+			Name:       route.GetName(),
+		})
+	}
+
+	sort.Slice(owners, func(i, j int) bool {
+		return owners[i].Name < owners[j].Name
+	})
+
+	return owners
+}
+
+// buildTCPRoutes creates a TCP route for every port reserved on the domain.
+// Ports without running Apps are sent to an invalid host so connections are
+// refused because TCP has no equivalent of the 404 used for HTTP routes.
+func buildTCPRoutes(routes v1alpha1.RouteSpecFieldsSlice, bindings map[string]RouteBindingSlice) []*istio.TCPRoute {
+	var tcpRoutes []*istio.TCPRoute
+	for _, rsf := range routes {
+		if !rsf.IsTCP() {
+			continue
+		}
+
+		var destinations []*istio.RouteDestination
+		for _, binding := range normalizeRouteWeights(bindings[rsf.String()]) {
+			destinations = append(destinations, &istio.RouteDestination{
+				Destination: &istio.Destination{
+					Host: binding.ServiceName,
+					Port: &istio.PortSelector{
+						Number: uint32(binding.Port),
+					},
+				},
+				Weight: binding.Weight,
+			})
+		}
+
+		if len(destinations) == 0 {
+			destinations = []*istio.RouteDestination{
+				{
+					Destination: &istio.Destination{
+						Host: "null.invalid",
+					},
+					Weight: 100,
+				},
+			}
+		}
+
+		tcpRoutes = append(tcpRoutes, &istio.TCPRoute{
+			Match: []*istio.L4MatchAttributes{
+				{Port: uint32(rsf.Port)},
+			},
+			Route: destinations,
+		})
 	}
-	return gatewayName, nil
+
+	return tcpRoutes
 }
 
 type httpRoutesBuilder struct {
+	namespace            string
 	routes               v1alpha1.RouteSpecFieldsSlice
 	appBindings          map[string]RouteBindingSlice
 	routeServiceBindings map[string][]v1alpha1.RouteServiceDestination
+	policies             map[string]*v1alpha1.RoutePolicy
+	maintenance          map[string]*v1alpha1.RouteMaintenance
 	defaultConfig        *kfconfig.DefaultsConfig
+	activatorApps        sets.String
+	grpcDestinations     sets.String
+	instanceApps         map[string]int32
 }
 
 func newHTTPRoutesBuilder(
+	namespace string,
 	routes v1alpha1.RouteSpecFieldsSlice,
 	appBindings map[string]RouteBindingSlice,
 	routeServiceBindings map[string][]v1alpha1.RouteServiceDestination,
+	policies map[string]*v1alpha1.RoutePolicy,
+	maintenance map[string]*v1alpha1.RouteMaintenance,
 	defaultConfig *kfconfig.DefaultsConfig,
+	activatorApps sets.String,
+	grpcDestinations sets.String,
+	instanceApps map[string]int32,
 ) *httpRoutesBuilder {
 	return &httpRoutesBuilder{
+		namespace:            namespace,
 		routes:               routes,
 		appBindings:          appBindings,
 		routeServiceBindings: routeServiceBindings,
+		policies:             policies,
+		maintenance:          maintenance,
 		defaultConfig:        defaultConfig,
+		activatorApps:        activatorApps,
+		grpcDestinations:     grpcDestinations,
+		instanceApps:         instanceApps,
 	}
 }
 
+// routeBindingSliceFor returns the destinations that serve traffic for the
+// route.
 func (hb *httpRoutesBuilder) routeBindingSliceFor(rsf v1alpha1.RouteSpecFields) RouteBindingSlice {
-	return hb.appBindings[rsf.String()]
+	var serving RouteBindingSlice
+	for _, binding := range hb.appBindings[rsf.String()] {
+		if !binding.IsMirror() {
+			serving = append(serving, binding)
+		}
+	}
+	return serving
+}
+
+// mirrorFor returns the destination traffic for the route is copied to, if
+// any. Istio supports a single mirror per HTTP route so the first destination
+// by name is used, the Route's MirrorReady condition reports the others.
+func (hb *httpRoutesBuilder) mirrorFor(rsf v1alpha1.RouteSpecFields) *v1alpha1.RouteDestination {
+	var mirrors RouteBindingSlice
+	for _, binding := range hb.appBindings[rsf.String()] {
+		if binding.IsMirror() {
+			mirrors = append(mirrors, binding)
+		}
+	}
+
+	if len(mirrors) == 0 {
+		return nil
+	}
+
+	sort.Sort(mirrors)
+	return &mirrors[0]
+}
+
+// servesGRPC returns true if all the destinations serve gRPC.
+func (hb *httpRoutesBuilder) servesGRPC(destinations RouteBindingSlice) bool {
+	if len(destinations) == 0 {
+		return false
+	}
+
+	for _, destination := range destinations {
+		if !hb.grpcDestinations.Has(MakeDestinationKey(destination.ServiceName, destination.Port)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// applyGRPCDefaults configures an HTTP Route for gRPC traffic. Calls are
+// retried on gRPC status codes that are safe to retry, and the route timeout
+// is disabled so streaming calls aren't cut off; gRPC clients set their own
+// deadlines with the grpc-timeout header. The Route's policy overrides both.
+func (hb *httpRoutesBuilder) applyGRPCDefaults(httpRoute *istio.HTTPRoute) {
+	httpRoute.Timeout = durationpb.New(0)
+
+	if hb.defaultConfig != nil && hb.defaultConfig.RouteDisableRetries {
+		return
+	}
+
+	httpRoute.Retries = &istio.HTTPRetry{
+		Attempts: GRPCRetryAttempts,
+		RetryOn:  GRPCRetryOn,
+	}
 }
 
 func (hb *httpRoutesBuilder) routeServiceDestinationsFor(rsf v1alpha1.RouteSpecFields) []v1alpha1.RouteServiceDestination {
@@ -226,6 +413,12 @@ func (hb *httpRoutesBuilder) build() ([]*istio.HTTPRoute, error) {
 	var httpRoutes []*istio.HTTPRoute
 
 	for _, rsf := range hb.routes {
+		// TCP Routes can't be served by HTTP domains, the Route reports the
+		// invalid domain in its status.
+		if rsf.IsTCP() {
+			continue
+		}
+
 		rsfRoutes, err := hb.buildRoutesFor(rsf)
 		if err != nil {
 			return nil, err
@@ -233,9 +426,14 @@ func (hb *httpRoutesBuilder) build() ([]*istio.HTTPRoute, error) {
 		httpRoutes = append(httpRoutes, rsfRoutes...)
 	}
 
-	// If specified, disable retries per route.
+	// If specified, disable retries per route unless the Route's policy
+	// configures them.
 	if hb.defaultConfig != nil && hb.defaultConfig.RouteDisableRetries {
 		for i := range httpRoutes {
+			if httpRoutes[i].Retries != nil {
+				continue
+			}
+
 			httpRoutes[i].Retries = &istio.HTTPRetry{
 				Attempts: 0,
 			}
@@ -258,6 +456,19 @@ func (hb *httpRoutesBuilder) buildRoutesFor(rsf v1alpha1.RouteSpecFields) ([]*is
 		return nil, err
 	}
 
+	// Routes in maintenance mode respond to every request, including ones
+	// for specific Apps or coming back from a route service, with the
+	// maintenance status.
+	// The route is named so the domain's EnvoyFilter can respond with the
+	// maintenance message.
+	if maintenance := hb.maintenance[rsf.String()]; maintenance != nil {
+		httpRoute := buildAbortHTTPRoute(pathMatchers, maintenance.StatusCode)
+		if maintenance.Message != "" {
+			httpRoute.Name = MakeGatewayRouteName(hb.namespace, rsf)
+		}
+		return []*istio.HTTPRoute{httpRoute}, nil
+	}
+
 	if len(appDestinations) == 0 {
 		// no apps bound to this path, return http route with default for path
 		rsfHTTPRoutes = append(rsfHTTPRoutes, buildDefaultHTTPRoute(*pathMatchers))
@@ -276,12 +487,32 @@ func (hb *httpRoutesBuilder) buildRoutesFor(rsf v1alpha1.RouteSpecFields) ([]*is
 
 		rsfHTTPRoutes = append(rsfHTTPRoutes, appHeaderHTTPRoutes...)
 		rsfHTTPRoutes = append(rsfHTTPRoutes, normalizedHTTPRoute)
+
+		if hb.servesGRPC(appDestinations) {
+			for _, httpRoute := range rsfHTTPRoutes {
+				if httpRoute.Fault == nil {
+					hb.applyGRPCDefaults(httpRoute)
+				}
+			}
+		}
+
+		// Apply the Route's policy to the HTTP Routes that reach Apps.
+		// Requests sent to the route service get the policy when they come
+		// back through the gateway.
+		if policy := hb.policies[rsf.String()]; policy != nil {
+			for _, httpRoute := range rsfHTTPRoutes {
+				if httpRoute.Fault == nil {
+					applyRoutePolicy(httpRoute, policy)
+				}
+			}
+		}
 	}
 
 	// If there is a route service bound to this route, add header match rules to each HTTP route.
 	// Then add an HTTP Route that directs to the route service and adds the CF route service headers.
 	// Note: There should only be one route service per route, but we handle the case where multiple are bound.
 	// The last (most recent) route service is used for the VS definition, and the RouteServiceReady condition for the Route is set to False in the reconciler.
+	var routeServiceHTTPRoute *istio.HTTPRoute
 	routeServices := hb.routeServiceDestinationsFor(rsf)
 	if len(routeServices) > 0 {
 		routeService := routeServices[len(routeServices)-1]
@@ -297,10 +528,26 @@ func (hb *httpRoutesBuilder) buildRoutesFor(rsf v1alpha1.RouteSpecFields) ([]*is
 		}
 
 		// Add HTTP route for directing request to route service
-		routeServiceHTTPRoute := buildRouteServiceHTTPRoute(rsf, &origPathMatchers, routeService)
+		routeServiceHTTPRoute = buildRouteServiceHTTPRoute(rsf, &origPathMatchers, routeService)
 		rsfHTTPRoutes = append(rsfHTTPRoutes, routeServiceHTTPRoute)
 	}
 
+	// Name the HTTP Routes that requests reach first so the rate limit in
+	// the domain's EnvoyFilter applies to them. Requests coming back from a
+	// route service aren't counted twice.
+	if policy := hb.policies[rsf.String()]; policy != nil && policy.RateLimit != nil {
+		name := MakeGatewayRouteName(hb.namespace, rsf)
+		if routeServiceHTTPRoute != nil {
+			routeServiceHTTPRoute.Name = name
+		} else {
+			for _, httpRoute := range rsfHTTPRoutes {
+				if httpRoute.Fault == nil {
+					httpRoute.Name = name
+				}
+			}
+		}
+	}
+
 	return rsfHTTPRoutes, nil
 }
 
@@ -308,9 +555,18 @@ func (hb *httpRoutesBuilder) buildRoutesFor(rsf v1alpha1.RouteSpecFields) ([]*is
 // Even when there are multiple apps mapped to a route,
 // Kf always directs to the requested app if the request contains the header "x-kf-app": [appname]
 // If a route service is bound to the route, then request should first be processed by the route service (indicated by the headers), then be directed to the app.
+// Apps with instance routing also get an HTTPRoute per instance matching the header "x-kf-app-instance": [appname]:[index].
 func (hb *httpRoutesBuilder) buildAppHeaderHTTPRoutes(rsf v1alpha1.RouteSpecFields) ([]*istio.HTTPRoute, error) {
 	appHeaderRoutes := []*istio.HTTPRoute{}
 	for _, binding := range hb.routeBindingSliceFor(rsf) {
+		if binding.Weight > 0 {
+			instanceRoutes, err := hb.buildInstanceHeaderHTTPRoutes(rsf, binding)
+			if err != nil {
+				return nil, err
+			}
+			appHeaderRoutes = append(appHeaderRoutes, instanceRoutes...)
+		}
+
 		httpRoute := &istio.HTTPRoute{}
 		pathAppMatchers, err := hb.buildPathAppMatchers(rsf, binding.ServiceName)
 		if err != nil {
@@ -323,15 +579,7 @@ func (hb *httpRoutesBuilder) buildAppHeaderHTTPRoutes(rsf v1alpha1.RouteSpecFiel
 			httpRoute = &istio.HTTPRoute{
 				Match: []*istio.HTTPMatchRequest{pathAppMatchers},
 				Route: []*istio.HTTPRouteDestination{
-					{
-						Destination: &istio.Destination{
-							Host: binding.ServiceName,
-							Port: &istio.PortSelector{
-								Number: uint32(binding.Port),
-							},
-						},
-						Weight: 100,
-					},
+					hb.buildRouteDestination(binding, 100),
 				},
 			}
 		}
@@ -340,6 +588,45 @@ func (hb *httpRoutesBuilder) buildAppHeaderHTTPRoutes(rsf v1alpha1.RouteSpecFiel
 	return appHeaderRoutes, nil
 }
 
+// buildInstanceHeaderHTTPRoutes creates an HTTPRoute for each instance of an
+// App with instance routing. Requests go straight to the instance's subset in
+// the App's DestinationRule.
+func (hb *httpRoutesBuilder) buildInstanceHeaderHTTPRoutes(rsf v1alpha1.RouteSpecFields, binding v1alpha1.RouteDestination) ([]*istio.HTTPRoute, error) {
+	var instanceRoutes []*istio.HTTPRoute
+	for i := int32(0); i < hb.instanceApps[binding.ServiceName]; i++ {
+		matchers, err := hb.buildPathMatchers(rsf)
+		if err != nil {
+			return nil, err
+		}
+
+		// matchers.Headers takes lower-case HTTP headers
+		matchers.Headers = map[string]*istio.StringMatch{
+			strings.ToLower(v1alpha1.InstanceHeader): {
+				MatchType: &istio.StringMatch_Exact{
+					Exact: fmt.Sprintf("%s:%d", binding.ServiceName, i),
+				},
+			},
+		}
+
+		instanceRoutes = append(instanceRoutes, &istio.HTTPRoute{
+			Match: []*istio.HTTPMatchRequest{matchers},
+			Route: []*istio.HTTPRouteDestination{
+				{
+					Destination: &istio.Destination{
+						Host: binding.ServiceName,
+						Port: &istio.PortSelector{
+							Number: uint32(binding.Port),
+						},
+						Subset: appresources.InstanceSubsetName(i),
+					},
+					Weight: 100,
+				},
+			},
+		})
+	}
+	return instanceRoutes, nil
+}
+
 // buildNormalizedHTTPRoute creates an HTTP Route for the app binding(s) on the route, without the `x-kf-app` header match rule.
 // It normalizes the weights defined on the app route binding(s) to percentages and splits traffic among multiple apps.
 func (hb *httpRoutesBuilder) buildNormalizedHTTPRoute(pathMatchers *istio.HTTPMatchRequest, rsf v1alpha1.RouteSpecFields) *istio.HTTPRoute {
@@ -351,7 +638,19 @@ func (hb *httpRoutesBuilder) buildNormalizedHTTPRoute(pathMatchers *istio.HTTPMa
 	} else {
 		httpRoute = &istio.HTTPRoute{
 			Match: []*istio.HTTPMatchRequest{pathMatchers},
-			Route: buildRouteDestinations(normalizedBindings),
+			Route: hb.buildRouteDestinations(normalizedBindings),
+		}
+
+		// Only live traffic is mirrored, requests for a specific App using
+		// the `x-kf-app` header aren't.
+		if mirror := hb.mirrorFor(rsf); mirror != nil {
+			httpRoute.Mirror = &istio.Destination{
+				Host: mirror.ServiceName,
+				Port: &istio.PortSelector{
+					Number: uint32(mirror.Port),
+				},
+			}
+			httpRoute.MirrorPercentage = &istio.Percent{Value: float64(mirror.MirrorPercent)}
 		}
 	}
 	return httpRoute
@@ -383,26 +682,63 @@ func buildRouteServiceHTTPRoute(rsf v1alpha1.RouteSpecFields, pathMatchers *isti
 // Hostname + domain + path combos with bound app(s) have a custom route destination for each path.
 // The request is sent directly to the Service for that app.
 // If there are multiple apps bound to a route, the traffic is split uniformly across the apps.
-func buildRouteDestinations(normalizedRouteBindings RouteBindingSlice) []*istio.HTTPRouteDestination {
+func (hb *httpRoutesBuilder) buildRouteDestinations(normalizedRouteBindings RouteBindingSlice) []*istio.HTTPRouteDestination {
 	routeDestinations := []*istio.HTTPRouteDestination{}
 
 	// the bindings should be sorted at this point, but rather than panic
 	// if they're not, we'll just sort again for safety
 	sort.Sort(normalizedRouteBindings)
 	for _, binding := range normalizedRouteBindings {
-		routeDestination := &istio.HTTPRouteDestination{
+		routeDestinations = append(routeDestinations, hb.buildRouteDestination(binding, binding.Weight))
+	}
+
+	return routeDestinations
+}
+
+// buildRouteDestination creates the destination for traffic sent to an App.
+// Apps with an idle timeout receive traffic through the activator so it can
+// record requests and hold them while the App scales up from zero.
+func (hb *httpRoutesBuilder) buildRouteDestination(binding v1alpha1.RouteDestination, weight int32) *istio.HTTPRouteDestination {
+	if hb.activatorApps.Has(binding.ServiceName) {
+		return &istio.HTTPRouteDestination{
 			Destination: &istio.Destination{
-				Host: binding.ServiceName,
+				Host: ActivatorHost,
 				Port: &istio.PortSelector{
-					Number: uint32(binding.Port),
+					Number: ActivatorPort,
+				},
+			},
+			Weight: weight,
+			Headers: &istio.Headers{
+				Request: &istio.Headers_HeaderOperations{
+					Set: map[string]string{
+						v1alpha1.ActivatorTargetHeader: fmt.Sprintf("%s/%s:%d", hb.namespace, binding.ServiceName, binding.Port),
+					},
 				},
 			},
-			Weight: int32(binding.Weight),
 		}
-		routeDestinations = append(routeDestinations, routeDestination)
 	}
 
-	return routeDestinations
+	return &istio.HTTPRouteDestination{
+		Destination: &istio.Destination{
+			Host: binding.ServiceName,
+			Port: &istio.PortSelector{
+				Number: uint32(binding.Port),
+			},
+		},
+		Weight: weight,
+	}
+}
+
+// MakeDestinationKey creates a key identifying the Service port traffic is
+// sent to.
+func MakeDestinationKey(serviceName string, port int32) string {
+	return fmt.Sprintf("%s:%d", serviceName, port)
+}
+
+// MakeSharedServiceHost creates the host of the Service for an App in a
+// Space a Route is shared with.
+func MakeSharedServiceHost(namespace, serviceName string) string {
+	return fmt.Sprintf("%s.%s.svc.cluster.local", serviceName, namespace)
 }
 
 // normalizeRouteWeights generates integer percentages for route weights that sum to 100, and returns
@@ -563,13 +899,19 @@ func buildRouteServiceHeaderValues(rsf v1alpha1.RouteSpecFields) map[string]stri
 
 // buildDefaultHTTPRoute creates a default route that returns a 404 for a given matcher.
 func buildDefaultHTTPRoute(matchers istio.HTTPMatchRequest) *istio.HTTPRoute {
+	return buildAbortHTTPRoute(&matchers, http.StatusNotFound)
+}
+
+// buildAbortHTTPRoute creates a route that returns the status for a given
+// matcher.
+func buildAbortHTTPRoute(matchers *istio.HTTPMatchRequest, status int32) *istio.HTTPRoute {
 	return &istio.HTTPRoute{
-		Match: []*istio.HTTPMatchRequest{&matchers},
+		Match: []*istio.HTTPMatchRequest{matchers},
 		Fault: &istio.HTTPFaultInjection{
 			Abort: &istio.HTTPFaultInjection_Abort{
 				Percentage: &istio.Percent{Value: 100},
 				ErrorType: &istio.HTTPFaultInjection_Abort_HttpStatus{
-					HttpStatus: http.StatusNotFound,
+					HttpStatus: status,
 				},
 			},
 		},
@@ -584,3 +926,67 @@ func buildDefaultHTTPRoute(matchers istio.HTTPMatchRequest) *istio.HTTPRoute {
 		},
 	}
 }
+
+// applyRoutePolicy sets the traffic settings of the policy on the HTTPRoute.
+func applyRoutePolicy(httpRoute *istio.HTTPRoute, policy *v1alpha1.RoutePolicy) {
+	if policy.Timeout != nil {
+		httpRoute.Timeout = durationpb.New(policy.Timeout.Duration)
+	}
+
+	if retries := policy.Retries; retries != nil {
+		httpRoute.Retries = &istio.HTTPRetry{
+			Attempts: retries.Attempts,
+			RetryOn:  strings.Join(retries.RetryOn, ","),
+		}
+
+		if retries.PerTryTimeout != nil {
+			httpRoute.Retries.PerTryTimeout = durationpb.New(retries.PerTryTimeout.Duration)
+		}
+	}
+
+	if cors := policy.CORS; cors != nil {
+		corsPolicy := &istio.CorsPolicy{
+			AllowMethods:  cors.AllowMethods,
+			AllowHeaders:  cors.AllowHeaders,
+			ExposeHeaders: cors.ExposeHeaders,
+		}
+
+		for _, origin := range cors.AllowOrigins {
+			match := &istio.StringMatch{}
+			if origin == "*" {
+				match.MatchType = &istio.StringMatch_Regex{Regex: ".*"}
+			} else {
+				match.MatchType = &istio.StringMatch_Exact{Exact: origin}
+			}
+			corsPolicy.AllowOrigins = append(corsPolicy.AllowOrigins, match)
+		}
+
+		if cors.MaxAge != nil {
+			corsPolicy.MaxAge = durationpb.New(cors.MaxAge.Duration)
+		}
+
+		if cors.AllowCredentials {
+			corsPolicy.AllowCredentials = wrapperspb.Bool(true)
+		}
+
+		httpRoute.CorsPolicy = corsPolicy
+	}
+
+	if policy.RequestHeaders != nil || policy.ResponseHeaders != nil {
+		httpRoute.Headers = &istio.Headers{
+			Request:  buildHeaderOperations(policy.RequestHeaders),
+			Response: buildHeaderOperations(policy.ResponseHeaders),
+		}
+	}
+}
+
+func buildHeaderOperations(ops *v1alpha1.RouteHeaderOperations) *istio.Headers_HeaderOperations {
+	if ops == nil {
+		return nil
+	}
+
+	return &istio.Headers_HeaderOperations{
+		Add:    ops.Add,
+		Remove: ops.Remove,
+	}
+}
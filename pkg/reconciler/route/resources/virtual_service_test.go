@@ -18,11 +18,13 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	kfconfig "github.com/google/kf/v2/pkg/apis/kf/config"
 	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
 	"github.com/google/kf/v2/pkg/kf/testutil"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 func makeRouteSpecFields(host, domain, path string) v1alpha1.RouteSpecFields {
@@ -68,6 +70,12 @@ func makeAppDestinationWithPort(appName string, weight, port int32) v1alpha1.Rou
 	}
 }
 
+func makeMirrorDestination(appName string, percent int32) v1alpha1.RouteDestination {
+	dest := makeAppDestination(appName, 1)
+	dest.MirrorPercent = percent
+	return dest
+}
+
 func makeRouteServiceDestination(name, scheme, host, path string) v1alpha1.RouteServiceDestination {
 	return v1alpha1.RouteServiceDestination{
 		Name: name,
@@ -101,6 +109,35 @@ func makeRoute(host, domain, path, namespace string) *v1alpha1.Route {
 	}
 }
 
+func makeRouteWithPolicy(host, domain, path, namespace string, policy *v1alpha1.RoutePolicy) *v1alpha1.Route {
+	route := makeRoute(host, domain, path, namespace)
+	route.Spec.Policy = policy
+	return route
+}
+
+func makeRouteWithMaintenance(host, domain, path, namespace string, maintenance *v1alpha1.RouteMaintenance) *v1alpha1.Route {
+	route := makeRoute(host, domain, path, namespace)
+	route.Spec.Maintenance = maintenance
+	return route
+}
+
+func makeTCPRoute(domain string, port int32, namespace string) *v1alpha1.Route {
+	rsf := v1alpha1.RouteSpecFields{
+		Domain: domain,
+		Port:   port,
+	}
+
+	return &v1alpha1.Route{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      v1alpha1.GenerateRouteNameFromFields(rsf),
+			Namespace: namespace,
+		},
+		Spec: v1alpha1.RouteSpec{
+			RouteSpecFields: rsf,
+		},
+	}
+}
+
 func TestMakeVirtualService(t *testing.T) {
 	t.Parallel()
 
@@ -110,6 +147,9 @@ func TestMakeVirtualService(t *testing.T) {
 		RouteServiceBindings map[string][]v1alpha1.RouteServiceDestination
 		SpaceDomain          v1alpha1.SpaceDomain
 		DefaultsConfig       kfconfig.DefaultsConfig
+		ActivatorApps        []string
+		GRPCDestinations     []string
+		InstanceApps         map[string]int32
 		assertErr            error
 	}{
 		"empty list of routes": {
@@ -298,6 +338,116 @@ func TestMakeVirtualService(t *testing.T) {
 				GatewayName: "kf/internal-gateway",
 			},
 		},
+		"internal domain": {
+			Routes: []*v1alpha1.Route{
+				makeRoute("api", "apps.internal", "/", "some-namespace"),
+			},
+			Bindings: map[string]RouteBindingSlice{
+				makeRouteSpecFieldsStr("api", "apps.internal", "/"): []v1alpha1.RouteDestination{
+					makeAppDestination("some-app", 1),
+				},
+			},
+			SpaceDomain: v1alpha1.SpaceDomain{
+				Domain:   "apps.internal",
+				Internal: true,
+			},
+		},
+		"tls domain": {
+			Routes: []*v1alpha1.Route{
+				makeRoute("some-host", "example.com", "/", "some-namespace"),
+			},
+			Bindings: map[string]RouteBindingSlice{
+				makeRouteSpecFieldsStr("some-host", "example.com", "/"): []v1alpha1.RouteDestination{
+					makeAppDestination("some-app", 1),
+				},
+			},
+			SpaceDomain: v1alpha1.SpaceDomain{
+				Domain:      "example.com",
+				GatewayName: "kf/external-gateway",
+				TLS: &v1alpha1.SpaceDomainTLS{
+					IssuerName: "letsencrypt",
+				},
+			},
+		},
+		"idle timeout apps use activator": {
+			Routes: []*v1alpha1.Route{
+				makeRoute("some-host", "example.com", "/", "some-namespace"),
+			},
+			Bindings: map[string]RouteBindingSlice{
+				makeRouteSpecFieldsStr("some-host", "example.com", "/"): []v1alpha1.RouteDestination{
+					makeAppDestination("idle-app", 1),
+					makeAppDestination("some-app", 1),
+				},
+			},
+			SpaceDomain: v1alpha1.SpaceDomain{
+				Domain:      "example.com",
+				GatewayName: "kf/external-gateway",
+			},
+			ActivatorApps: []string{"idle-app"},
+		},
+		"grpc apps get grpc defaults": {
+			Routes: []*v1alpha1.Route{
+				makeRoute("grpc-host", "example.com", "/", "some-namespace"),
+				makeRouteWithPolicy("policy-host", "example.com", "/", "some-namespace", &v1alpha1.RoutePolicy{
+					Timeout: &metav1.Duration{Duration: 30 * time.Second},
+				}),
+				makeRoute("mixed-host", "example.com", "/", "some-namespace"),
+			},
+			Bindings: map[string]RouteBindingSlice{
+				makeRouteSpecFieldsStr("grpc-host", "example.com", "/"): []v1alpha1.RouteDestination{
+					makeAppDestination("grpc-app", 1),
+				},
+				makeRouteSpecFieldsStr("policy-host", "example.com", "/"): []v1alpha1.RouteDestination{
+					makeAppDestination("grpc-app", 1),
+				},
+				makeRouteSpecFieldsStr("mixed-host", "example.com", "/"): []v1alpha1.RouteDestination{
+					makeAppDestination("grpc-app", 1),
+					makeAppDestination("http-app", 1),
+				},
+			},
+			SpaceDomain: v1alpha1.SpaceDomain{
+				Domain:      "example.com",
+				GatewayName: "kf/external-gateway",
+			},
+			GRPCDestinations: []string{MakeDestinationKey("grpc-app", v1alpha1.DefaultRouteDestinationPort)},
+		},
+		"grpc apps without retries": {
+			Routes: []*v1alpha1.Route{
+				makeRoute("grpc-host", "example.com", "/", "some-namespace"),
+			},
+			Bindings: map[string]RouteBindingSlice{
+				makeRouteSpecFieldsStr("grpc-host", "example.com", "/"): []v1alpha1.RouteDestination{
+					makeAppDestination("grpc-app", 1),
+				},
+			},
+			SpaceDomain: v1alpha1.SpaceDomain{
+				Domain:      "example.com",
+				GatewayName: "kf/external-gateway",
+			},
+			DefaultsConfig: kfconfig.DefaultsConfig{
+				RouteDisableRetries: true,
+			},
+			GRPCDestinations: []string{MakeDestinationKey("grpc-app", v1alpha1.DefaultRouteDestinationPort)},
+		},
+		"instance routing apps get instance routes": {
+			Routes: []*v1alpha1.Route{
+				makeRoute("some-host", "example.com", "/", "some-namespace"),
+			},
+			Bindings: map[string]RouteBindingSlice{
+				makeRouteSpecFieldsStr("some-host", "example.com", "/"): []v1alpha1.RouteDestination{
+					makeAppDestination("sticky-app", 1),
+					makeAppDestination("stopped-app", 0),
+				},
+			},
+			SpaceDomain: v1alpha1.SpaceDomain{
+				Domain:      "example.com",
+				GatewayName: "kf/external-gateway",
+			},
+			InstanceApps: map[string]int32{
+				"sticky-app":  2,
+				"stopped-app": 2,
+			},
+		},
 		"no retries applies everywhere": {
 			Routes: []*v1alpha1.Route{
 				makeRoute("some-host", "example.com", "/some-path", "some-namespace"),
@@ -347,6 +497,175 @@ func TestMakeVirtualService(t *testing.T) {
 				RouteHostIgnoringPort: true,
 			},
 		},
+		"rate limited routes": {
+			Routes: []*v1alpha1.Route{
+				makeRouteWithPolicy("some-host", "example.com", "/some-path", "some-namespace", &v1alpha1.RoutePolicy{
+					RateLimit: &v1alpha1.RouteRateLimit{RequestsPerSecond: 10},
+				}),
+				makeRouteWithPolicy("other-host", "example.com", "", "some-namespace", &v1alpha1.RoutePolicy{
+					RateLimit: &v1alpha1.RouteRateLimit{RequestsPerSecond: 10, Header: "X-Api-Key"},
+				}),
+			},
+			Bindings: map[string]RouteBindingSlice{
+				makeRouteSpecFieldsStr("some-host", "example.com", "/some-path"): []v1alpha1.RouteDestination{
+					makeAppDestination("app-1", 1),
+				},
+				makeRouteSpecFieldsStr("other-host", "example.com", ""): []v1alpha1.RouteDestination{
+					makeAppDestination("app-2", 1),
+				},
+			},
+			RouteServiceBindings: map[string][]v1alpha1.RouteServiceDestination{
+				makeRouteSpecFieldsStr("other-host", "example.com", ""): {
+					makeRouteServiceDestination("some-route-svc", "http", "some-route-service.com", ""),
+				},
+			},
+			SpaceDomain: v1alpha1.SpaceDomain{
+				Domain:      "example.com",
+				GatewayName: "kf/some-gateway",
+			},
+		},
+		"route policy": {
+			Routes: []*v1alpha1.Route{
+				makeRouteWithPolicy("some-host", "example.com", "/some-path", "some-namespace", &v1alpha1.RoutePolicy{
+					Timeout: &metav1.Duration{Duration: 30 * time.Second},
+					Retries: &v1alpha1.RouteRetryPolicy{
+						Attempts:      3,
+						PerTryTimeout: &metav1.Duration{Duration: 5 * time.Second},
+						RetryOn:       []string{"5xx", "connect-failure"},
+					},
+					CORS: &v1alpha1.RouteCORSPolicy{
+						AllowOrigins:     []string{"*", "https://example.com"},
+						AllowMethods:     []string{"GET", "POST"},
+						AllowHeaders:     []string{"Content-Type"},
+						ExposeHeaders:    []string{"X-Request-Id"},
+						MaxAge:           &metav1.Duration{Duration: time.Hour},
+						AllowCredentials: true,
+					},
+					RequestHeaders: &v1alpha1.RouteHeaderOperations{
+						Add:    map[string]string{"X-Env": "prod"},
+						Remove: []string{"X-Debug"},
+					},
+					ResponseHeaders: &v1alpha1.RouteHeaderOperations{
+						Remove: []string{"Server"},
+					},
+				}),
+				makeRoute("other-host", "example.com", "", "some-namespace"),
+			},
+			Bindings: map[string]RouteBindingSlice{
+				makeRouteSpecFieldsStr("some-host", "example.com", "/some-path"): []v1alpha1.RouteDestination{
+					makeAppDestination("app-1", 1),
+				},
+				makeRouteSpecFieldsStr("other-host", "example.com", ""): []v1alpha1.RouteDestination{
+					makeAppDestination("app-2", 1),
+				},
+			},
+			SpaceDomain: v1alpha1.SpaceDomain{
+				Domain:      "example.com",
+				GatewayName: "kf/some-gateway",
+			},
+			DefaultsConfig: kfconfig.DefaultsConfig{
+				RouteDisableRetries: true,
+			},
+		},
+		"mirrored traffic": {
+			Routes: []*v1alpha1.Route{
+				makeRoute("some-host", "example.com", "", "some-namespace"),
+			},
+			Bindings: map[string]RouteBindingSlice{
+				makeRouteSpecFieldsStr("some-host", "example.com", ""): []v1alpha1.RouteDestination{
+					makeAppDestination("app-1", 1),
+					makeMirrorDestination("shadow-b", 50),
+					makeMirrorDestination("shadow-a", 20),
+				},
+			},
+			SpaceDomain: v1alpha1.SpaceDomain{
+				Domain:      "example.com",
+				GatewayName: "kf/some-gateway",
+			},
+		},
+		"mirror without serving apps": {
+			Routes: []*v1alpha1.Route{
+				makeRoute("some-host", "example.com", "", "some-namespace"),
+			},
+			Bindings: map[string]RouteBindingSlice{
+				makeRouteSpecFieldsStr("some-host", "example.com", ""): []v1alpha1.RouteDestination{
+					makeMirrorDestination("shadow", 20),
+				},
+			},
+			SpaceDomain: v1alpha1.SpaceDomain{
+				Domain:      "example.com",
+				GatewayName: "kf/some-gateway",
+			},
+		},
+		"route in maintenance mode": {
+			Routes: []*v1alpha1.Route{
+				makeRouteWithMaintenance("some-host", "example.com", "", "some-namespace", &v1alpha1.RouteMaintenance{
+					StatusCode: 503,
+					Message:    "Database migration",
+				}),
+				makeRoute("other-host", "example.com", "", "some-namespace"),
+			},
+			Bindings: map[string]RouteBindingSlice{
+				makeRouteSpecFieldsStr("some-host", "example.com", ""): []v1alpha1.RouteDestination{
+					makeAppDestination("app-1", 1),
+					makeAppDestination("app-2", 1),
+				},
+				makeRouteSpecFieldsStr("other-host", "example.com", ""): []v1alpha1.RouteDestination{
+					makeAppDestination("app-2", 1),
+				},
+			},
+			RouteServiceBindings: map[string][]v1alpha1.RouteServiceDestination{
+				makeRouteSpecFieldsStr("some-host", "example.com", ""): {
+					makeRouteServiceDestination("auth-service", "https", "auth.example.com", ""),
+				},
+			},
+			SpaceDomain: v1alpha1.SpaceDomain{
+				Domain:      "example.com",
+				GatewayName: "kf/some-gateway",
+			},
+		},
+		"tcp routes": {
+			Routes: []*v1alpha1.Route{
+				makeTCPRoute("tcp.example.com", 1025, "some-namespace"),
+				makeTCPRoute("tcp.example.com", 1024, "some-namespace"),
+				makeTCPRoute("tcp.example.com", 1026, "some-namespace"),
+			},
+			Bindings: map[string]RouteBindingSlice{
+				makeTCPRoute("tcp.example.com", 1024, "").Spec.String(): []v1alpha1.RouteDestination{
+					makeAppDestinationWithPort("mqtt-broker", 1, 1883),
+				},
+				makeTCPRoute("tcp.example.com", 1025, "").Spec.String(): []v1alpha1.RouteDestination{
+					makeAppDestinationWithPort("smtp-relay-a", 1, 2525),
+					makeAppDestinationWithPort("smtp-relay-b", 1, 2525),
+				},
+			},
+			SpaceDomain: v1alpha1.SpaceDomain{
+				Domain:      "tcp.example.com",
+				GatewayName: "kf/external-gateway",
+				RouterGroup: &v1alpha1.RouterGroup{
+					Type:            v1alpha1.RouterGroupTypeTCP,
+					ReservablePorts: "1024-1033",
+				},
+			},
+		},
+		"tcp routes on http domain are skipped": {
+			Routes: []*v1alpha1.Route{
+				makeRoute("some-host", "example.com", "", "some-namespace"),
+				makeTCPRoute("example.com", 1024, "some-namespace"),
+			},
+			Bindings: map[string]RouteBindingSlice{
+				makeRouteSpecFieldsStr("some-host", "example.com", ""): []v1alpha1.RouteDestination{
+					makeAppDestination("app-1", 1),
+				},
+				makeTCPRoute("example.com", 1024, "").Spec.String(): []v1alpha1.RouteDestination{
+					makeAppDestination("app-1", 1),
+				},
+			},
+			SpaceDomain: v1alpha1.SpaceDomain{
+				Domain:      "example.com",
+				GatewayName: "kf/some-gateway",
+			},
+		},
 	} {
 		t.Run(tn, func(t *testing.T) {
 			actualVS, actualErr := MakeVirtualService(
@@ -355,14 +674,27 @@ func TestMakeVirtualService(t *testing.T) {
 				tc.RouteServiceBindings,
 				&tc.SpaceDomain,
 				&tc.DefaultsConfig,
+				sets.NewString(tc.ActivatorApps...),
+				sets.NewString(tc.GRPCDestinations...),
+				tc.InstanceApps,
 			)
 			testutil.AssertErrorsEqual(t, tc.assertErr, actualErr)
-			testutil.AssertGoldenJSONContext(t, "virtualservice", actualVS, map[string]interface{}{
+			goldenContext := map[string]interface{}{
 				"routes":               tc.Routes,
 				"routeBindings":        convertBindingsForContext(tc.Routes, tc.Bindings),
 				"routeServiceBindings": convertRouteServiceBindingsForContext(tc.Routes, tc.RouteServiceBindings),
 				"spaceDomain":          tc.SpaceDomain,
-			})
+			}
+			if len(tc.ActivatorApps) > 0 {
+				goldenContext["activatorApps"] = tc.ActivatorApps
+			}
+			if len(tc.GRPCDestinations) > 0 {
+				goldenContext["grpcDestinations"] = tc.GRPCDestinations
+			}
+			if len(tc.InstanceApps) > 0 {
+				goldenContext["instanceApps"] = tc.InstanceApps
+			}
+			testutil.AssertGoldenJSONContext(t, "virtualservice", actualVS, goldenContext)
 
 			// If the VS already passed the above tests, check against those structs
 			// as golden to ensure it's valid. Don't check against the .golden files
@@ -57,13 +57,14 @@ func NewServiceCatalogBase(ctx context.Context, cmw configmap.Watcher) *ServiceC
 	}
 }
 
-// GetInstanceForBinding returns a ServiceInstance that the binding belongs to.
+// GetInstanceForBinding returns a ServiceInstance that the binding belongs to,
+// it may be in another Space if it's shared.
 func (scb *ServiceCatalogBase) GetInstanceForBinding(
 	binding *v1alpha1.ServiceInstanceBinding,
 ) (*v1alpha1.ServiceInstance, error) {
 	instanceName := binding.Spec.InstanceRef.Name
 	return scb.KfServiceInstanceLister.
-		ServiceInstances(binding.Namespace).
+		ServiceInstances(binding.InstanceNamespace()).
 		Get(instanceName)
 }
 
@@ -111,3 +112,29 @@ func (scb *ServiceCatalogBase) GetClientForServiceInstance(serviceInstance *v1al
 
 	return osbutil.NewClient(brokerCreds)
 }
+
+// GetPlanForInstance returns the plan from the broker's catalog that the
+// ServiceInstance was provisioned with. The returned plan is nil if the broker
+// no longer advertises it.
+func (scb *ServiceCatalogBase) GetPlanForInstance(
+	instance *v1alpha1.ServiceInstance,
+) (*v1alpha1.ServicePlan, error) {
+	broker, err := scb.GetBrokerForInstance(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, offering := range broker.GetServiceOfferings() {
+		if offering.UID != instance.Spec.OSB.ClassUID {
+			continue
+		}
+
+		for i := range offering.Plans {
+			if offering.Plans[i].UID == instance.Spec.OSB.PlanUID {
+				return &offering.Plans[i], nil
+			}
+		}
+	}
+
+	return nil, nil
+}
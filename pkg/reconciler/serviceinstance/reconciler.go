@@ -24,6 +24,7 @@ import (
 	"github.com/google/kf/v2/pkg/apis/kf/config"
 	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
 	kflisters "github.com/google/kf/v2/pkg/client/kf/listers/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/internal/osbutil"
 	"github.com/google/kf/v2/pkg/reconciler"
 	"github.com/google/kf/v2/pkg/reconciler/reconcilerutil"
 	"github.com/google/kf/v2/pkg/reconciler/serviceinstance/resources"
@@ -236,6 +237,30 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, serviceinstance *v1alpha1
 		}
 	}
 
+	// Reject parameters that don't match the plan's schema before they're
+	// sent to the broker. The Secret is re-checked on every reconcile until
+	// the instance is provisioned so fixing it clears the error.
+	{
+		logger.Debug("reconciling params schema")
+		condition := serviceinstance.Status.ParamsValidCondition()
+
+		if serviceinstance.IsKfBrokered() && serviceinstance.Status.OSBStatus.IsBlank() {
+			plan, err := r.GetPlanForInstance(serviceinstance)
+			if err != nil {
+				return condition.MarkReconciliationError("GettingPlan", err)
+			}
+			if plan != nil && plan.Schemas != nil {
+				params := paramsSecret.Data[v1alpha1.ServiceInstanceParamsSecretKey]
+				if err := osbutil.ValidateParameters(plan.Schemas.InstanceCreate, params); err != nil {
+					condition.MarkFalse("InvalidParameters", "%v", err)
+					return nil
+				}
+			}
+		}
+
+		condition.MarkSuccess()
+	}
+
 	switch {
 	case serviceinstance.IsUserProvided() && !serviceinstance.IsRouteService():
 		// User-provided services do not have an additional backing resource unless they are a route service.
@@ -558,12 +583,13 @@ func (r *Reconciler) updateStatus(ctx context.Context, desired *v1alpha1.Service
 }
 
 func (r *Reconciler) serviceBindingExistsForServiceInstance(serviceinstance *v1alpha1.ServiceInstance) (bool, error) {
-	bindings, err := r.KfServiceInstanceBindingLister.ServiceInstanceBindings(serviceinstance.Namespace).List(labels.Everything())
+	// Bindings can be in any Space the ServiceInstance is shared with.
+	bindings, err := r.KfServiceInstanceBindingLister.List(labels.Everything())
 	if err != nil {
 		return false, err
 	}
 	for _, binding := range bindings {
-		if binding.Spec.InstanceRef.Name == serviceinstance.Name {
+		if binding.InstanceNamespace() == serviceinstance.Namespace && binding.Spec.InstanceRef.Name == serviceinstance.Name {
 			return true, nil
 		}
 	}
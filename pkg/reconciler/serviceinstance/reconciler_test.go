@@ -0,0 +1,100 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serviceinstance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	kflisters "github.com/google/kf/v2/pkg/client/kf/listers/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	"github.com/google/kf/v2/pkg/reconciler/reconcilertesting"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// newTestReconciler creates a Reconciler backed by the broker with the
+// instance's Space and parameters. The instance's plan publishes the given
+// schemas.
+func newTestReconciler(t *testing.T, broker *reconcilertesting.FakeBroker, schemas *v1alpha1.ServicePlanSchemas, instance *v1alpha1.ServiceInstance) *Reconciler {
+	t.Helper()
+
+	base, _ := reconcilertesting.NewServiceCatalogBase(t, broker, schemas,
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-instance-params",
+				Namespace: "my-space",
+				OwnerReferences: []metav1.OwnerReference{
+					*metav1.NewControllerRef(instance, v1alpha1.SchemeGroupVersion.WithKind("ServiceInstance")),
+				},
+			},
+			Data: map[string][]byte{v1alpha1.ServiceInstanceParamsSecretKey: []byte("{}")},
+		},
+	)
+	space := &v1alpha1.Space{ObjectMeta: metav1.ObjectMeta{Name: "my-space"}}
+
+	return &Reconciler{
+		ServiceCatalogBase: base,
+		spaceLister:        kflisters.NewSpaceLister(reconcilertesting.NewIndexer(t, space)),
+	}
+}
+
+func TestReconciler_ApplyChanges_paramsSchema(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		schema          string
+		wantValid       corev1.ConditionStatus
+		wantProvisioned []string
+	}{
+		"params rejected by schema": {
+			schema:    `{"type":"object","required":["tier"]}`,
+			wantValid: corev1.ConditionFalse,
+		},
+		"params accepted by schema": {
+			schema:          `{"type":"object","properties":{"tier":{"type":"string"}}}`,
+			wantValid:       corev1.ConditionTrue,
+			wantProvisioned: []string{"instance-uid"},
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+
+			broker := reconcilertesting.NewFakeBroker(t)
+			instance := reconcilertesting.TestInstance()
+			instance.Spec.ParametersFrom = corev1.LocalObjectReference{Name: "my-instance-params"}
+			schemas := &v1alpha1.ServicePlanSchemas{
+				InstanceCreate: &runtime.RawExtension{Raw: []byte(tc.schema)},
+			}
+			r := newTestReconciler(t, broker, schemas, instance)
+
+			err := r.ApplyChanges(context.Background(), instance)
+			testutil.AssertNil(t, "err", err)
+			testutil.AssertEqual(t, "provisioned", tc.wantProvisioned, broker.Provisioned())
+
+			condition := instance.Status.GetCondition(v1alpha1.ServiceInstanceConditionParamsValidReady)
+			testutil.AssertEqual(t, "params valid", tc.wantValid, condition.Status)
+			if tc.wantValid == corev1.ConditionFalse {
+				testutil.AssertEqual(t, "reason", "InvalidParameters", condition.Reason)
+				testutil.AssertTrue(t, "pending provision", instance.Status.BackingResourceCondition().IsPending())
+			}
+		})
+	}
+}
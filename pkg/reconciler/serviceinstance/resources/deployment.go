@@ -32,6 +32,13 @@ import (
 const (
 	RouteServiceProxyUserPort     = int32(8080)
 	RouteServiceProxyUserPortName = "http" // user port name must start with the protocol (http)
+
+	// RouteServiceProxyAdminPort serves the proxy's health and metrics
+	// endpoints, it isn't exposed by the Service.
+	RouteServiceProxyAdminPort     = int32(8081)
+	RouteServiceProxyAdminPortName = "http-admin"
+	RouteServiceProxyHealthPath    = "/healthz"
+	RouteServiceProxyMetricsPath   = "/metrics"
 )
 
 var (
@@ -93,6 +100,9 @@ func MakeDeployment(serviceInstance *v1alpha1.ServiceInstance, cfg *config.Confi
 					Annotations: map[string]string{
 						"sidecar.istio.io/inject":                          "true",
 						"traffic.sidecar.istio.io/includeOutboundIPRanges": "*",
+						"prometheus.io/scrape":                             "true",
+						"prometheus.io/port":                               fmt.Sprint(RouteServiceProxyAdminPort),
+						"prometheus.io/path":                               RouteServiceProxyMetricsPath,
 					},
 				},
 				Spec: makePodSpec(*serviceInstance, configDefaults),
@@ -125,6 +135,10 @@ func makePodSpec(serviceInstance v1alpha1.ServiceInstance, configDefaults *confi
 			Name:          RouteServiceProxyUserPortName,
 			ContainerPort: RouteServiceProxyUserPort,
 			Protocol:      corev1.ProtocolTCP,
+		}, {
+			Name:          RouteServiceProxyAdminPortName,
+			ContainerPort: RouteServiceProxyAdminPort,
+			Protocol:      corev1.ProtocolTCP,
 		}},
 		TerminationMessagePath:   corev1.TerminationMessagePathDefault,
 		TerminationMessagePolicy: corev1.TerminationMessageReadFile,
@@ -139,22 +153,54 @@ func makePodSpec(serviceInstance v1alpha1.ServiceInstance, configDefaults *confi
 		Name:  "PORT",
 		Value: fmt.Sprint(RouteServiceProxyUserPort),
 	}
-	userContainer.Env = []corev1.EnvVar{routeServiceURLEnvVar, portEnvVar}
+	adminPortEnvVar := corev1.EnvVar{
+		Name:  "ADMIN_PORT",
+		Value: fmt.Sprint(RouteServiceProxyAdminPort),
+	}
+	userContainer.Env = []corev1.EnvVar{routeServiceURLEnvVar, portEnvVar, adminPortEnvVar}
+
+	// Optional TLS and timeout settings, the proxy uses its own defaults for
+	// any that aren't set.
+	if configDefaults.RouteServiceProxyCABundle != "" {
+		userContainer.Env = append(userContainer.Env, corev1.EnvVar{
+			Name:  "ROUTE_SERVICE_CA_BUNDLE",
+			Value: configDefaults.RouteServiceProxyCABundle,
+		})
+	}
+	if configDefaults.RouteServiceProxyInsecureSkipVerify {
+		userContainer.Env = append(userContainer.Env, corev1.EnvVar{
+			Name:  "ROUTE_SERVICE_INSECURE_SKIP_VERIFY",
+			Value: "true",
+		})
+	}
+	if configDefaults.RouteServiceProxyRequestTimeout != "" {
+		userContainer.Env = append(userContainer.Env, corev1.EnvVar{
+			Name:  "REQUEST_TIMEOUT",
+			Value: configDefaults.RouteServiceProxyRequestTimeout,
+		})
+	}
+	if configDefaults.RouteServiceProxyIdleTimeout != "" {
+		userContainer.Env = append(userContainer.Env, corev1.EnvVar{
+			Name:  "IDLE_TIMEOUT",
+			Value: configDefaults.RouteServiceProxyIdleTimeout,
+		})
+	}
 
 	// Explicitly disable stdin and tty allocation
 	userContainer.Stdin = false
 	userContainer.TTY = false
 
-	// Set liveness and readiness probe TCP ports
-	tcpProbe := corev1.Probe{
+	// Set liveness and readiness probes to the proxy's health endpoint
+	healthProbe := corev1.Probe{
 		ProbeHandler: corev1.ProbeHandler{
-			TCPSocket: &corev1.TCPSocketAction{
-				Port: intstr.FromInt(int(RouteServiceProxyUserPort)),
+			HTTPGet: &corev1.HTTPGetAction{
+				Path: RouteServiceProxyHealthPath,
+				Port: intstr.FromInt(int(RouteServiceProxyAdminPort)),
 			},
 		},
 	}
-	userContainer.LivenessProbe = &tcpProbe
-	userContainer.ReadinessProbe = &tcpProbe
+	userContainer.LivenessProbe = &healthProbe
+	userContainer.ReadinessProbe = &healthProbe
 	spec.Containers = []corev1.Container{userContainer}
 
 	// Populate default pod spec
@@ -70,6 +70,16 @@ func TestMakeDeployment(t *testing.T) {
 				RouteServiceProxyImage: "gcr.io/fake/proxy/image",
 			}),
 		},
+		"proxy TLS and timeouts": {
+			serviceInstance: happyServiceInstance,
+			cfg: config.CreateConfigForTest(&config.DefaultsConfig{
+				RouteServiceProxyImage:              "gcr.io/fake/proxy/image",
+				RouteServiceProxyCABundle:           "-----BEGIN CERTIFICATE-----\nfake\n-----END CERTIFICATE-----\n",
+				RouteServiceProxyInsecureSkipVerify: true,
+				RouteServiceProxyRequestTimeout:     "30s",
+				RouteServiceProxyIdleTimeout:        "2m",
+			}),
+		},
 	} {
 		t.Run(tn, func(t *testing.T) {
 			actualDeployment, actualErr := MakeDeployment(tc.serviceInstance, tc.cfg)
@@ -17,11 +17,13 @@ package serviceinstancebinding
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
 	appinformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/app"
 	serviceinstanceinformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/serviceinstance"
 	kfservicebindinginformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/serviceinstancebinding"
+	serviceinstanceshareinformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/serviceinstanceshare"
 	spaceinformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/space"
 	kflisters "github.com/google/kf/v2/pkg/client/kf/listers/kf/v1alpha1"
 	"github.com/google/kf/v2/pkg/reconciler"
@@ -44,6 +46,7 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 	serviceBindingInformer := kfservicebindinginformer.Get(ctx)
 	serviceInstanceInformer := serviceinstanceinformer.Get(ctx)
 	spaceInformer := spaceinformer.Get(ctx)
+	serviceInstanceShareInformer := serviceinstanceshareinformer.Get(ctx)
 	secretInformer := secretinformer.Get(ctx)
 
 	// Create reconciler
@@ -51,6 +54,8 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		ServiceCatalogBase: reconciler.NewServiceCatalogBase(ctx, cmw),
 		spaceLister:        spaceInformer.Lister(),
 		appLister:          appInformer.Lister(),
+
+		serviceInstanceShareLister: serviceInstanceShareInformer.Lister(),
 	}
 
 	impl := controller.NewContext(ctx, c, controller.ControllerOptions{
@@ -61,8 +66,9 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 
 	logger.Info("Setting up event handlers")
 
-	// Watch for changes in sub-resources so we can sync accordingly
-	serviceBindingInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+	// Watch for changes in bindings and resync them so we can poll async
+	// operations.
+	watchBindings(serviceBindingInformer.Informer(), impl.Enqueue)
 
 	// Watch for changes in ServiceInstances to reconcile bindings when
 	// ServiceInstances become Ready.
@@ -74,6 +80,12 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		DeleteFunc: nil,
 	})
 
+	// Watch for changes in ServiceInstanceShares so bindings in the target
+	// Space get or lose access to shared ServiceInstances.
+	serviceInstanceShareInformer.Informer().AddEventHandler(controller.HandleAll(
+		reconciler.LogEnqueueError(logger,
+			enqueueBindingsForShare(impl.Enqueue, serviceBindingInformer.Lister()))))
+
 	// Watch for changes in Secrets that are owned by Service Instances so we
 	// can propagate the changes to credentials.
 	secretInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
@@ -97,6 +109,19 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 	return impl
 }
 
+// asyncPollPeriod is the longest time a binding goes without being
+// reconciled, it bounds how long async operations go unpolled.
+const asyncPollPeriod = 1 * time.Minute
+
+// watchBindings enqueues bindings when they change and resyncs all bindings
+// at least once every asyncPollPeriod.
+func watchBindings(informer cache.SharedIndexInformer, enqueue func(interface{})) {
+	informer.AddEventHandlerWithResyncPeriod(
+		controller.HandleAll(enqueue),
+		asyncPollPeriod,
+	)
+}
+
 // enqueueBindingsForService enqueues all ServiceInstanceBindings for the given ServiceInstance.
 func enqueueBindingsForService(
 	enqueue func(interface{}),
@@ -108,15 +133,42 @@ func enqueueBindingsForService(
 			return nil
 		}
 
+		// Bindings can be in any Space the ServiceInstance is shared with.
+		bindings, err := serviceBindingLister.List(labels.Everything())
+		if err != nil {
+			return fmt.Errorf("failed to list bindings: %s", err)
+		}
+
+		for _, binding := range bindings {
+			if binding.InstanceNamespace() == service.Namespace && binding.Spec.InstanceRef.Name == service.Name {
+				enqueue(binding)
+			}
+		}
+		return nil
+	}
+}
+
+// enqueueBindingsForShare enqueues the ServiceInstanceBindings in the target
+// Space of a ServiceInstanceShare that use the shared ServiceInstance.
+func enqueueBindingsForShare(
+	enqueue func(interface{}),
+	serviceBindingLister kflisters.ServiceInstanceBindingLister,
+) func(obj interface{}) error {
+	return func(obj interface{}) error {
+		share, ok := obj.(*v1alpha1.ServiceInstanceShare)
+		if !ok {
+			return nil
+		}
+
 		bindings, err := serviceBindingLister.
-			ServiceInstanceBindings(service.Namespace).
+			ServiceInstanceBindings(share.Spec.TargetSpace).
 			List(labels.Everything())
 		if err != nil {
 			return fmt.Errorf("failed to list bindings: %s", err)
 		}
 
 		for _, binding := range bindings {
-			if binding.Spec.InstanceRef.Name == service.Name {
+			if binding.InstanceNamespace() == share.Namespace && binding.Spec.InstanceRef.Name == share.Spec.InstanceRef.Name {
 				enqueue(binding)
 			}
 		}
@@ -141,21 +193,19 @@ func enqueueServiceInstanceSecrets(
 			return nil
 		}
 
-		bindings, err := serviceBindingLister.
-			ServiceInstanceBindings(secret.GetNamespace()).
-			List(labels.Everything())
+		// Bindings can be in any Space the ServiceInstance is shared with.
+		bindings, err := serviceBindingLister.List(labels.Everything())
 		if err != nil {
 			return fmt.Errorf("failed to get ServiceInstance Bindings (%s): %v", secret.GetNamespace(), err)
 		}
 
 		for _, binding := range bindings {
-			if binding.Spec.InstanceRef.Name != serviceInstance.Name {
+			if binding.InstanceNamespace() != secret.GetNamespace() || binding.Spec.InstanceRef.Name != serviceInstance.Name {
 				continue
 			}
 
-			// Found the corresponding Binding for the Service Instance.
+			// Found a corresponding Binding for the Service Instance.
 			enqueue(binding)
-			return nil
 		}
 
 		return nil
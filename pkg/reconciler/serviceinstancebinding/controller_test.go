@@ -0,0 +1,55 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serviceinstancebinding
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	"k8s.io/client-go/tools/cache"
+)
+
+// recordingInformer records the event handler registered on it.
+type recordingInformer struct {
+	cache.SharedIndexInformer
+
+	handler      cache.ResourceEventHandler
+	resyncPeriod time.Duration
+}
+
+func (i *recordingInformer) AddEventHandlerWithResyncPeriod(handler cache.ResourceEventHandler, resyncPeriod time.Duration) {
+	i.handler = handler
+	i.resyncPeriod = resyncPeriod
+}
+
+func TestWatchBindings(t *testing.T) {
+	t.Parallel()
+
+	informer := &recordingInformer{}
+	var enqueued []interface{}
+	watchBindings(informer, func(obj interface{}) {
+		enqueued = append(enqueued, obj)
+	})
+
+	testutil.AssertEqual(t, "resync period", asyncPollPeriod, informer.resyncPeriod)
+	testutil.AssertTrue(t, "resyncs at least once a minute", informer.resyncPeriod > 0 && informer.resyncPeriod <= time.Minute)
+
+	// Resyncs are delivered as updates where nothing changed, they must still
+	// enqueue the binding so in-flight operations get polled.
+	binding := boundBinding()
+	informer.handler.OnUpdate(binding, binding)
+	testutil.AssertEqual(t, "enqueued", []interface{}{binding}, enqueued)
+}
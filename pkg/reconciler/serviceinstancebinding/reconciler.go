@@ -22,6 +22,7 @@ import (
 
 	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
 	kflisters "github.com/google/kf/v2/pkg/client/kf/listers/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/internal/osbutil"
 	"github.com/google/kf/v2/pkg/reconciler"
 	"github.com/google/kf/v2/pkg/reconciler/reconcilerutil"
 	"github.com/google/kf/v2/pkg/reconciler/serviceinstancebinding/resources"
@@ -31,6 +32,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	v1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"knative.dev/pkg/controller"
@@ -43,8 +45,9 @@ const serviceInstanceBindingFinalizer = "serviceinstancebinding.kf.dev"
 type Reconciler struct {
 	*reconciler.ServiceCatalogBase
 
-	spaceLister kflisters.SpaceLister
-	appLister   kflisters.AppLister
+	spaceLister                kflisters.SpaceLister
+	appLister                  kflisters.AppLister
+	serviceInstanceShareLister kflisters.ServiceInstanceShareLister
 
 	persistentVolumeClaimLister v1listers.PersistentVolumeClaimLister
 }
@@ -195,6 +198,26 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, binding *v1alpha1.Service
 
 	// Check service instance
 	condition := binding.Status.ServiceInstanceCondition()
+
+	// Service instances from other Spaces can only be used while they're
+	// shared with the binding's Space.
+	if binding.IsSharedInstanceBinding() {
+		shares, err := r.serviceInstanceShareLister.
+			ServiceInstanceShares(binding.InstanceNamespace()).
+			List(labels.SelectorFromSet(v1alpha1.ServiceInstanceShareLabels(binding.Spec.InstanceRef.Name, binding.Namespace)))
+		if err != nil {
+			return condition.MarkReconciliationError("getting service instance shares", err)
+		}
+
+		if len(shares) == 0 {
+			condition.MarkFalse(
+				"NotShared",
+				"Service instance %q in Space %q isn't shared with Space %q",
+				binding.Spec.InstanceRef.Name, binding.InstanceNamespace(), binding.Namespace)
+			return nil
+		}
+	}
+
 	serviceInstance, err := r.GetInstanceForBinding(binding)
 	if err != nil {
 		return condition.MarkReconciliationError("getting service instance", err)
@@ -219,10 +242,38 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, binding *v1alpha1.Service
 	// Propagate volume status from referenced service instance to the binding.
 	binding.Status.PropagateVolumeStatus(serviceInstance, paramsSecret)
 
+	// Reject parameters that don't match the plan's schema before they're
+	// sent to the broker. The Secret is re-checked on every reconcile until
+	// the credentials are bound so fixing it clears the error.
+	{
+		logger.Debug("reconciling params schema")
+		condition := binding.Status.ParamsValidCondition()
+
+		if serviceInstance.IsKfBrokered() &&
+			(binding.Status.OSBStatus.IsBlank() || binding.IsRotationRequested()) {
+			plan, err := r.GetPlanForInstance(serviceInstance)
+			if err != nil {
+				return condition.MarkReconciliationError("GettingPlan", err)
+			}
+			if plan != nil && plan.Schemas != nil {
+				params := paramsSecret.Data[v1alpha1.ServiceInstanceBindingParamsSecretKey]
+				if err := osbutil.ValidateParameters(plan.Schemas.BindingCreate, params); err != nil {
+					condition.MarkFalse("InvalidParameters", "%v", err)
+					return nil
+				}
+			}
+		}
+
+		condition.MarkSuccess()
+	}
+
 	switch {
 	// UserProvidedService and VolumeService don't have backing resources.
 	case serviceInstance.HasNoBackingResources():
 		binding.Status.MarkBackingResourceReady()
+		// Credentials are copied from the instance so there's nothing to
+		// rotate.
+		binding.Status.RotateRequests = binding.Spec.RotateRequests
 	case serviceInstance.IsLegacyBrokered():
 		// If the instance is legacy brokered, don't reconcile it but do leave
 		// a message.
@@ -233,15 +284,33 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, binding *v1alpha1.Service
 		return nil
 	case serviceInstance.IsKfBrokered():
 		condition := binding.Status.BackingResourceCondition()
+
+		// Bind new credentials under a new ID if the user requested a
+		// rotation. Only one rotation is done at a time so the previous
+		// credentials can always be unbound.
+		if binding.IsRotationRequested() &&
+			binding.Status.OSBStatus.Bound != nil &&
+			binding.Status.PreviousBindingID == "" {
+			binding.Status.PropagateRotationStarted(
+				binding.Spec.RotateRequests,
+				binding.OSBBindingID(),
+				binding.RotatedBindingID(),
+			)
+		}
+
 		// If the instance has already been actuated, don't try again.
-		if !condition.IsPending() {
+		if !condition.IsPending() && !binding.Status.IsRotating() {
 			break
 		}
 
-		// If the resource isn't making progress, terminate it:
-		if timeoutErr := condition.ErrorIfTimeout(time.Duration(binding.Spec.ProgressDeadlineSeconds) * time.Second); timeoutErr != nil {
-			binding.Status.PropagateBindStatus(nil, timeoutErr)
-			break
+		// If the resource isn't making progress, terminate it. Rotations keep
+		// the ready condition of the previous credentials so they can't be
+		// timed out.
+		if condition.IsPending() {
+			if timeoutErr := condition.ErrorIfTimeout(time.Duration(binding.Spec.ProgressDeadlineSeconds) * time.Second); timeoutErr != nil {
+				binding.Status.PropagateBindStatus(nil, timeoutErr)
+				break
+			}
 		}
 
 		osbClient, err := r.GetClientForServiceInstance(serviceInstance)
@@ -270,9 +339,9 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, binding *v1alpha1.Service
 
 			// If the response wasn't async, write the secret now.
 			if err == nil && !response.Async {
-				r.createBindingSecretAndUpdateStatus(ctx, binding, func() (*corev1.Secret, error) {
-					return resources.MakeCredentialsForOSBService(binding, response.Credentials)
-				})
+				if err := r.createOSBBindingSecretAndUpdateStatus(ctx, binding, response.Credentials); err != nil {
+					return err
+				}
 			}
 		}
 
@@ -324,9 +393,10 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, binding *v1alpha1.Service
 
 			// If secret is already created, don't poll the OSB resource
 			// again.
-			if binding.Status.CredentialsSecretRef.Name != "" {
+			if binding.Status.CredentialsSecretRef.Name != "" &&
+				!binding.Status.IsWaitingForRotatedCredentials() {
 				logger.Info("Secret already exists")
-				return nil
+				break
 			}
 
 			osbClient, oerr := r.GetClientForServiceInstance(serviceInstance)
@@ -340,14 +410,54 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, binding *v1alpha1.Service
 			}
 
 			// XXX: Handle additional binding types here.
-			r.createBindingSecretAndUpdateStatus(ctx, binding, func() (*corev1.Secret, error) {
-				return resources.MakeCredentialsForOSBService(binding, bindingCreds.Credentials)
-			})
+			if err := r.createOSBBindingSecretAndUpdateStatus(ctx, binding, bindingCreds.Credentials); err != nil {
+				return err
+			}
 		default:
 			return fmt.Errorf("ServiceType can't be determined for service instance %s", serviceInstance.Name)
 		}
 	}
 
+	// Unbind credentials replaced by a rotation
+	if serviceInstance.IsKfBrokered() && binding.Status.PreviousBindingID != "" {
+		return r.unbindPreviousBinding(ctx, binding, serviceInstance)
+	}
+
+	return nil
+}
+
+// unbindPreviousBinding unbinds the credentials replaced by a rotation once the
+// new credentials are available and the grace period has passed.
+func (r *Reconciler) unbindPreviousBinding(
+	ctx context.Context,
+	binding *v1alpha1.ServiceInstanceBinding,
+	serviceInstance *v1alpha1.ServiceInstance,
+) error {
+	logger := logging.FromContext(ctx)
+
+	// Keep the previous credentials until the new ones can be used.
+	if binding.Status.IsWaitingForRotatedCredentials() {
+		logger.Info("Waiting for rotated credentials, exiting early")
+		return nil
+	}
+
+	if remaining := time.Until(binding.Status.PreviousBindingUnbindAfter.Time); remaining > 0 {
+		logger.Infof("Unbinding previous credentials in %v", remaining)
+		return controller.NewRequeueAfter(remaining)
+	}
+
+	osbClient, err := r.GetClientForServiceInstance(serviceInstance)
+	if err != nil {
+		return err
+	}
+
+	response, err := osbClient.Unbind(resources.MakeOSBUnbindPreviousRequest(serviceInstance, binding))
+	binding.Status.PropagatePreviousUnbindStatus(response, err)
+	if binding.Status.PreviousBindingID != "" {
+		logger.Warnw("Failed to unbind previous credentials", zap.Error(err))
+		return err
+	}
+
 	return nil
 }
 
@@ -393,6 +503,23 @@ func (r *Reconciler) createBindingSecretAndUpdateStatus(ctx context.Context, bin
 	return nil
 }
 
+// createOSBBindingSecretAndUpdateStatus writes the credentials returned by a
+// broker. If the binding is being rotated, the grace period for the replaced
+// credentials starts once the new ones are written.
+func (r *Reconciler) createOSBBindingSecretAndUpdateStatus(ctx context.Context, binding *v1alpha1.ServiceInstanceBinding, credentials map[string]interface{}) error {
+	if err := r.createBindingSecretAndUpdateStatus(ctx, binding, func() (*corev1.Secret, error) {
+		return resources.MakeCredentialsForOSBService(binding, credentials)
+	}); err != nil {
+		return err
+	}
+
+	if binding.Status.IsWaitingForRotatedCredentials() {
+		binding.Status.PropagateRotatedCredentialsWritten(metav1.NewTime(time.Now().Add(binding.RotationGracePeriod())))
+	}
+
+	return nil
+}
+
 // deleteServiceBinding handles any logic for cleaning up resources; it returns
 // true once the resource can be finalized.
 func (r *Reconciler) deleteServiceBinding(ctx context.Context, binding *v1alpha1.ServiceInstanceBinding) (done bool) {
@@ -414,6 +541,41 @@ func (r *Reconciler) deleteServiceBinding(ctx context.Context, binding *v1alpha1
 		return true
 	}
 
+	// Credentials replaced by a rotation are only unbound after a grace period,
+	// clean them up first so they aren't leaked.
+	if binding.Status.PreviousBindingID != "" {
+		osbClient, err := r.GetClientForServiceInstance(serviceInstance)
+		if err != nil {
+			condition.MarkReconciliationError("InstantiatingClient", err)
+			return false
+		}
+
+		response, err := osbClient.Unbind(resources.MakeOSBUnbindPreviousRequest(serviceInstance, binding))
+		binding.Status.PropagatePreviousUnbindStatus(response, err)
+		if binding.Status.PreviousBindingID != "" {
+			condition.MarkReconciliationError("UnbindingPrevious", err)
+			return false
+		}
+	}
+
+	// Bindings deleted while an asynchronous bind is in flight need to finish
+	// binding before they can be unbound.
+	if state := binding.Status.OSBStatus.Binding; state != nil {
+		if timeoutErr := condition.ErrorIfTimeout(time.Duration(binding.Spec.ProgressDeadlineSeconds) * time.Second); timeoutErr != nil {
+			binding.Status.PropagateBindStatus(nil, timeoutErr)
+		} else {
+			osbClient, err := r.GetClientForServiceInstance(serviceInstance)
+			if err != nil {
+				condition.MarkReconciliationError("InstantiatingClient", err)
+				return false
+			}
+
+			request := resources.MakeOSBBindingLastOperationRequest(serviceInstance, binding, state.OperationKey)
+			response, err := osbClient.PollBindingLastOperation(request)
+			binding.Status.PropagateBindLastOperationStatus(response, err)
+		}
+	}
+
 	// If provisioning failed or the resource is already unbound,
 	// expect the broker to have cleaned things up.
 	if binding.Status.OSBStatus.BindFailed != nil ||
@@ -0,0 +1,422 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serviceinstancebinding
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	kflisters "github.com/google/kf/v2/pkg/client/kf/listers/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	"github.com/google/kf/v2/pkg/reconciler/reconcilertesting"
+	"github.com/google/kf/v2/pkg/reconciler/serviceinstancebinding/resources"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+)
+
+// readyInstance returns the shared test instance after it was provisioned.
+func readyInstance() *v1alpha1.ServiceInstance {
+	instance := reconcilertesting.TestInstance()
+	instance.Status.Conditions = []apis.Condition{
+		{Type: v1alpha1.ServiceInstanceConditionReady, Status: corev1.ConditionTrue},
+	}
+	return instance
+}
+
+// boundBinding returns a binding whose credentials were bound with the
+// binding's UID.
+func boundBinding() *v1alpha1.ServiceInstanceBinding {
+	binding := &v1alpha1.ServiceInstanceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-binding",
+			Namespace: "my-space",
+			UID:       "binding-uid",
+		},
+		Spec: v1alpha1.ServiceInstanceBindingSpec{
+			BindingType: v1alpha1.BindingType{
+				App: &v1alpha1.AppRef{Name: "my-app"},
+			},
+			InstanceRef:    corev1.LocalObjectReference{Name: "my-instance"},
+			ParametersFrom: corev1.LocalObjectReference{Name: "my-binding-params"},
+		},
+	}
+	binding.Status.InitializeConditions()
+	binding.Status.BackingResourceCondition().MarkSuccess()
+	binding.Status.OSBStatus = v1alpha1.BindingOSBStatus{Bound: &v1alpha1.OSBState{}}
+	binding.Status.CredentialsSecretRef.Name = resources.BindingCredentialsSecretName(binding)
+	binding.Status.CredentialsSecretCondition().MarkSuccess()
+	return binding
+}
+
+// newTestReconciler creates a Reconciler backed by the broker with the
+// binding's instance, parameters and credentials. The instance's plan
+// publishes the given schemas.
+func newTestReconciler(t *testing.T, broker *reconcilertesting.FakeBroker, schemas *v1alpha1.ServicePlanSchemas, instance *v1alpha1.ServiceInstance, binding *v1alpha1.ServiceInstanceBinding) (*Reconciler, *k8sfake.Clientset) {
+	t.Helper()
+
+	base, kubeClient := reconcilertesting.NewServiceCatalogBase(t, broker, schemas,
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-binding-params", Namespace: "my-space"},
+			Data:       map[string][]byte{v1alpha1.ServiceInstanceBindingParamsSecretKey: []byte("{}")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: resources.BindingCredentialsSecretName(binding), Namespace: "my-space"},
+			Data:       map[string][]byte{"password": []byte(`"binding-uid"`)},
+		},
+	)
+	base.KfServiceInstanceLister = kflisters.NewServiceInstanceLister(reconcilertesting.NewIndexer(t, instance))
+
+	return &Reconciler{ServiceCatalogBase: base}, kubeClient
+}
+
+func TestReconciler_ApplyChanges_rotate(t *testing.T) {
+	t.Parallel()
+
+	broker := reconcilertesting.NewFakeBroker(t)
+	binding := boundBinding()
+	binding.Spec.RotateRequests = 1
+	r, kubeClient := newTestReconciler(t, broker, nil, readyInstance(), binding)
+
+	err := r.ApplyChanges(context.Background(), binding)
+
+	// The previous credentials are unbound after the grace period.
+	isRequeue, requeueAfter := controller.IsRequeueKey(err)
+	testutil.AssertTrue(t, "requeued", isRequeue)
+	testutil.AssertTrue(t, "requeued within grace period", requeueAfter <= binding.RotationGracePeriod())
+
+	rotatedID := binding.RotatedBindingID()
+	bound, unbound := broker.Bindings()
+	testutil.AssertEqual(t, "bound", []string{rotatedID}, bound)
+	testutil.AssertEqual(t, "unbound", []string(nil), unbound)
+
+	testutil.AssertEqual(t, "binding ID", rotatedID, binding.Status.BindingID)
+	testutil.AssertEqual(t, "previous binding ID", "binding-uid", binding.Status.PreviousBindingID)
+	testutil.AssertEqual(t, "rotate requests", 1, binding.Status.RotateRequests)
+	testutil.AssertNotNil(t, "unbind after", binding.Status.PreviousBindingUnbindAfter)
+	testutil.AssertTrue(t, "ready", binding.Status.IsReady())
+
+	secret, err := kubeClient.CoreV1().
+		Secrets("my-space").
+		Get(context.Background(), resources.BindingCredentialsSecretName(binding), metav1.GetOptions{})
+	testutil.AssertNil(t, "secret err", err)
+	testutil.AssertEqual(t, "password", `"`+rotatedID+`"`, string(secret.Data["password"]))
+}
+
+func TestReconciler_ApplyChanges_rotationFailed(t *testing.T) {
+	t.Parallel()
+
+	broker := reconcilertesting.NewFakeBroker(t)
+	binding := boundBinding()
+	binding.Spec.RotateRequests = 1
+	broker.FailBind[binding.RotatedBindingID()] = true
+	r, kubeClient := newTestReconciler(t, broker, nil, readyInstance(), binding)
+
+	err := r.ApplyChanges(context.Background(), binding)
+	testutil.AssertNil(t, "err", err)
+
+	// The binding keeps serving the previous credentials, which aren't unbound.
+	bound, unbound := broker.Bindings()
+	testutil.AssertEqual(t, "bound", []string(nil), bound)
+	testutil.AssertEqual(t, "unbound", []string(nil), unbound)
+	testutil.AssertEqual(t, "binding ID", "binding-uid", binding.Status.BindingID)
+	testutil.AssertEqual(t, "previous binding ID", "", binding.Status.PreviousBindingID)
+	testutil.AssertNotNil(t, "bound status", binding.Status.OSBStatus.Bound)
+	testutil.AssertTrue(t, "ready", binding.Status.IsReady())
+
+	rotated := binding.Status.GetCondition(v1alpha1.ServiceInstanceBindingConditionCredentialsRotated)
+	testutil.AssertEqual(t, "rotated", corev1.ConditionFalse, rotated.Status)
+	testutil.AssertEqual(t, "rotated reason", "RotationFailed", rotated.Reason)
+
+	secret, err := kubeClient.CoreV1().
+		Secrets("my-space").
+		Get(context.Background(), resources.BindingCredentialsSecretName(binding), metav1.GetOptions{})
+	testutil.AssertNil(t, "secret err", err)
+	testutil.AssertEqual(t, "password", `"binding-uid"`, string(secret.Data["password"]))
+
+	// Requesting the rotation again binds new credentials.
+	binding.Spec.RotateRequests = 2
+	err = r.ApplyChanges(context.Background(), binding)
+	isRequeue, _ := controller.IsRequeueKey(err)
+	testutil.AssertTrue(t, "requeued", isRequeue)
+
+	bound, _ = broker.Bindings()
+	testutil.AssertEqual(t, "bound after retry", []string{binding.RotatedBindingID()}, bound)
+	testutil.AssertEqual(t, "previous binding ID after retry", "binding-uid", binding.Status.PreviousBindingID)
+	testutil.AssertEqual(
+		t,
+		"rotated after retry",
+		corev1.ConditionTrue,
+		binding.Status.GetCondition(v1alpha1.ServiceInstanceBindingConditionCredentialsRotated).Status,
+	)
+}
+
+func TestReconciler_ApplyChanges_paramsSchema(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		schema    string
+		wantValid corev1.ConditionStatus
+		wantBound []string
+	}{
+		"params rejected by schema": {
+			schema:    `{"type":"object","required":["role"]}`,
+			wantValid: corev1.ConditionFalse,
+		},
+		"params accepted by schema": {
+			schema:    `{"type":"object","properties":{"role":{"type":"string"}}}`,
+			wantValid: corev1.ConditionTrue,
+			wantBound: []string{"binding-uid"},
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+
+			broker := reconcilertesting.NewFakeBroker(t)
+			binding := boundBinding()
+			binding.Status = v1alpha1.ServiceInstanceBindingStatus{}
+			schemas := &v1alpha1.ServicePlanSchemas{
+				BindingCreate: &runtime.RawExtension{Raw: []byte(tc.schema)},
+			}
+			r, _ := newTestReconciler(t, broker, schemas, readyInstance(), binding)
+
+			err := r.ApplyChanges(context.Background(), binding)
+			testutil.AssertNil(t, "err", err)
+
+			bound, _ := broker.Bindings()
+			testutil.AssertEqual(t, "bound", tc.wantBound, bound)
+
+			condition := binding.Status.GetCondition(v1alpha1.ServiceInstanceBindingConditionParamsValidReady)
+			testutil.AssertEqual(t, "params valid", tc.wantValid, condition.Status)
+			if tc.wantValid == corev1.ConditionFalse {
+				testutil.AssertEqual(t, "reason", "InvalidParameters", condition.Reason)
+				testutil.AssertTrue(t, "pending bind", binding.Status.BackingResourceCondition().IsPending())
+			}
+		})
+	}
+}
+
+func TestReconciler_ApplyChanges_gracePeriodUnbind(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		unbindAfter time.Time
+		wantUnbound []string
+		wantRequeue bool
+	}{
+		"grace period not over": {
+			unbindAfter: time.Now().Add(time.Hour),
+			wantRequeue: true,
+		},
+		"grace period over": {
+			unbindAfter: time.Now().Add(-time.Second),
+			wantUnbound: []string{"binding-uid"},
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+
+			broker := reconcilertesting.NewFakeBroker(t)
+			binding := boundBinding()
+			binding.Spec.RotateRequests = 1
+			binding.Status.RotateRequests = 1
+			binding.Status.BindingID = "rotated-id"
+			binding.Status.PreviousBindingID = "binding-uid"
+			binding.Status.PreviousBindingUnbindAfter = &metav1.Time{Time: tc.unbindAfter}
+			r, _ := newTestReconciler(t, broker, nil, readyInstance(), binding)
+
+			err := r.ApplyChanges(context.Background(), binding)
+			isRequeue, _ := controller.IsRequeueKey(err)
+			testutil.AssertEqual(t, "requeued", tc.wantRequeue, isRequeue)
+			if !tc.wantRequeue {
+				testutil.AssertNil(t, "err", err)
+			}
+
+			bound, unbound := broker.Bindings()
+			testutil.AssertEqual(t, "bound", []string(nil), bound)
+			testutil.AssertEqual(t, "unbound", tc.wantUnbound, unbound)
+			testutil.AssertEqual(t, "previous binding cleared", !tc.wantRequeue, binding.Status.PreviousBindingID == "")
+		})
+	}
+}
+
+func TestReconciler_deleteServiceBinding_duringRotation(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		failPreviousUnbind bool
+		wantDone           bool
+		wantUnbound        []string
+	}{
+		"both credentials are unbound": {
+			wantDone:    true,
+			wantUnbound: []string{"binding-uid", "rotated-id"},
+		},
+		"previous credentials fail to unbind": {
+			failPreviousUnbind: true,
+			wantDone:           false,
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+
+			broker := reconcilertesting.NewFakeBroker(t)
+			broker.FailUnbind["binding-uid"] = tc.failPreviousUnbind
+
+			binding := boundBinding()
+			binding.Spec.RotateRequests = 1
+			binding.Status.RotateRequests = 1
+			binding.Status.BindingID = "rotated-id"
+			binding.Status.PreviousBindingID = "binding-uid"
+			binding.Status.PreviousBindingUnbindAfter = &metav1.Time{Time: time.Now().Add(time.Hour)}
+			r, _ := newTestReconciler(t, broker, nil, readyInstance(), binding)
+
+			done := r.deleteServiceBinding(context.Background(), binding)
+			testutil.AssertEqual(t, "done", tc.wantDone, done)
+
+			_, unbound := broker.Bindings()
+			testutil.AssertEqual(t, "unbound", tc.wantUnbound, unbound)
+			testutil.AssertEqual(t, "previous binding cleared", !tc.failPreviousUnbind, binding.Status.PreviousBindingID == "")
+		})
+	}
+}
+
+func TestReconciler_deleteServiceBinding_asyncUnbind(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		state        string
+		wantDone     bool
+		wantOSBState func(v1alpha1.BindingOSBStatus) bool
+	}{
+		"unbind in progress": {
+			state:    "in progress",
+			wantDone: false,
+			wantOSBState: func(s v1alpha1.BindingOSBStatus) bool {
+				return s.Unbinding != nil
+			},
+		},
+		"unbind succeeded": {
+			state:    "succeeded",
+			wantDone: true,
+			wantOSBState: func(s v1alpha1.BindingOSBStatus) bool {
+				return s.Unbound != nil
+			},
+		},
+		"unbind failed": {
+			state:    "failed",
+			wantDone: false,
+			wantOSBState: func(s v1alpha1.BindingOSBStatus) bool {
+				return s.UnbindFailed != nil
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+
+			broker := reconcilertesting.NewFakeBroker(t)
+			broker.AsyncUnbind = true
+			broker.LastOperationState = tc.state
+
+			binding := boundBinding()
+			r, _ := newTestReconciler(t, broker, nil, readyInstance(), binding)
+
+			done := r.deleteServiceBinding(context.Background(), binding)
+			testutil.AssertEqual(t, "done", tc.wantDone, done)
+
+			_, unbound := broker.Bindings()
+			testutil.AssertEqual(t, "unbound", []string{"binding-uid"}, unbound)
+			testutil.AssertEqual(t, "polled", []string{"unbind-binding-uid"}, broker.PolledOperations())
+			testutil.AssertTrue(t, "OSB status", tc.wantOSBState(binding.Status.OSBStatus))
+		})
+	}
+}
+
+func TestReconciler_deleteServiceBinding_asyncBind(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		state          string
+		transitionTime time.Time
+		wantDone       bool
+		wantPolled     []string
+		wantUnbound    []string
+	}{
+		"bind in progress": {
+			state:          "in progress",
+			transitionTime: time.Now(),
+			wantDone:       false,
+			wantPolled:     []string{"bind-op"},
+		},
+		"bind succeeded": {
+			state:          "succeeded",
+			transitionTime: time.Now(),
+			wantDone:       true,
+			wantPolled:     []string{"bind-op"},
+			wantUnbound:    []string{"binding-uid"},
+		},
+		"bind past progress deadline": {
+			state:          "in progress",
+			transitionTime: time.Now().Add(-time.Hour),
+			wantDone:       true,
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+
+			broker := reconcilertesting.NewFakeBroker(t)
+			broker.LastOperationState = tc.state
+
+			operationKey := "bind-op"
+			binding := boundBinding()
+			binding.Spec.ProgressDeadlineSeconds = 60
+			binding.Status.BackingResourceCondition().MarkUnknown("BindingAsync", "operation is pending")
+			for i := range binding.Status.Conditions {
+				binding.Status.Conditions[i].LastTransitionTime.Inner = metav1.NewTime(tc.transitionTime)
+			}
+			binding.Status.OSBStatus = v1alpha1.BindingOSBStatus{
+				Binding: &v1alpha1.OSBState{OperationKey: &operationKey},
+			}
+			r, _ := newTestReconciler(t, broker, nil, readyInstance(), binding)
+
+			done := r.deleteServiceBinding(context.Background(), binding)
+			testutil.AssertEqual(t, "done", tc.wantDone, done)
+
+			_, unbound := broker.Bindings()
+			testutil.AssertEqual(t, "polled", tc.wantPolled, broker.PolledOperations())
+			testutil.AssertEqual(t, "unbound", tc.wantUnbound, unbound)
+		})
+	}
+}
@@ -32,10 +32,26 @@ func MakeOSBUnbindRequest(
 	serviceInstance *v1alpha1.ServiceInstance,
 	binding *v1alpha1.ServiceInstanceBinding,
 ) *osbclient.UnbindRequest {
+	return makeOSBUnbindRequest(serviceInstance, binding.OSBBindingID())
+}
+
+// MakeOSBUnbindPreviousRequest creates an UnbindRequest for the credentials
+// a binding had before it was rotated.
+func MakeOSBUnbindPreviousRequest(
+	serviceInstance *v1alpha1.ServiceInstance,
+	binding *v1alpha1.ServiceInstanceBinding,
+) *osbclient.UnbindRequest {
+	return makeOSBUnbindRequest(serviceInstance, binding.Status.PreviousBindingID)
+}
+
+func makeOSBUnbindRequest(
+	serviceInstance *v1alpha1.ServiceInstance,
+	bindingID string,
+) *osbclient.UnbindRequest {
 
 	return &osbclient.UnbindRequest{
 		InstanceID:        fmt.Sprintf("%s", serviceInstance.UID),
-		BindingID:         fmt.Sprintf("%s", binding.UID),
+		BindingID:         bindingID,
 		AcceptsIncomplete: true,
 		ServiceID:         serviceInstance.Spec.OSB.ClassUID,
 		PlanID:            serviceInstance.Spec.OSB.PlanUID,
@@ -55,7 +71,7 @@ func MakeOSBBindingLastOperationRequest(
 
 	return &osbclient.BindingLastOperationRequest{
 		InstanceID:   fmt.Sprintf("%s", serviceInstance.UID),
-		BindingID:    fmt.Sprintf("%s", binding.UID),
+		BindingID:    binding.OSBBindingID(),
 		ServiceID:    ptr.String(serviceInstance.Spec.OSB.ClassUID),
 		PlanID:       ptr.String(serviceInstance.Spec.OSB.PlanUID),
 		OperationKey: (*osbclient.OperationKey)(operationKey),
@@ -91,7 +107,7 @@ func MakeOSBBindRequest(
 		// Use the UID for tracibility and to ensure duplicate requests (if any)
 		// only get provisioned once.
 		InstanceID:        fmt.Sprintf("%s", serviceInstance.UID),
-		BindingID:         fmt.Sprintf("%s", binding.UID),
+		BindingID:         binding.OSBBindingID(),
 		AcceptsIncomplete: true,
 		ServiceID:         serviceInstance.Spec.OSB.ClassUID,
 		PlanID:            serviceInstance.Spec.OSB.PlanUID,
@@ -112,6 +128,6 @@ func MakeOSBGetBindingRequest(
 
 	return &osbclient.GetBindingRequest{
 		InstanceID: fmt.Sprintf("%s", serviceInstance.UID),
-		BindingID:  fmt.Sprintf("%s", binding.UID),
+		BindingID:  binding.OSBBindingID(),
 	}
 }
@@ -45,6 +45,14 @@ func fakeServiceInstanceBinding() *v1alpha1.ServiceInstanceBinding {
 	return binding
 }
 
+func fakeRotatedServiceInstanceBinding() *v1alpha1.ServiceInstanceBinding {
+	binding := fakeServiceInstanceBinding()
+	binding.Status.PreviousBindingID = string(binding.UID)
+	binding.Status.BindingID = "33333333-3333-3333-3333-333333333333"
+
+	return binding
+}
+
 func TestMakeOSBUnbindRequest(t *testing.T) {
 	t.Parallel()
 
@@ -56,6 +64,10 @@ func TestMakeOSBUnbindRequest(t *testing.T) {
 			serviceInstance: fakeServiceInstance(),
 			binding:         fakeServiceInstanceBinding(),
 		},
+		"rotated": {
+			serviceInstance: fakeServiceInstance(),
+			binding:         fakeRotatedServiceInstanceBinding(),
+		},
 	}
 
 	for tn, tc := range cases {
@@ -70,6 +82,17 @@ func TestMakeOSBUnbindRequest(t *testing.T) {
 	}
 }
 
+func TestMakeOSBUnbindPreviousRequest(t *testing.T) {
+	t.Parallel()
+
+	req := MakeOSBUnbindPreviousRequest(fakeServiceInstance(), fakeRotatedServiceInstanceBinding())
+
+	testutil.AssertEqual(t, "InstanceID", "00000000-0000-0000-0000-000008675309", req.InstanceID)
+	testutil.AssertEqual(t, "BindingID", "22222222-2222-2222-2222-222222222222", req.BindingID)
+	testutil.AssertEqual(t, "ServiceID", "class-uid", req.ServiceID)
+	testutil.AssertEqual(t, "PlanID", "plan-uid", req.PlanID)
+}
+
 func TestMakeOSBBindingLastOperationRequest(t *testing.T) {
 	t.Parallel()
 
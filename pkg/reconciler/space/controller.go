@@ -25,6 +25,8 @@ import (
 	spaceinformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/space"
 	kflisters "github.com/google/kf/v2/pkg/client/kf/listers/kf/v1alpha1"
 	networkpolicyinformer "github.com/google/kf/v2/pkg/client/kube/injection/informers/networking/v1/networkpolicy"
+	networkingclient "github.com/google/kf/v2/pkg/client/networking/injection/client"
+	gatewayinformer "github.com/google/kf/v2/pkg/client/networking/injection/informers/networking/v1alpha3/gateway"
 	"github.com/google/kf/v2/pkg/reconciler"
 	"github.com/google/kf/v2/pkg/reconciler/build/config"
 	"github.com/google/kf/v2/pkg/reconciler/reconcilerutil"
@@ -69,6 +71,7 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 	clusterRoleInformer := clusterroleinformer.Get(ctx)
 	clusterRoleBindingInformer := clusterrolebindinginformer.Get(ctx)
 	configMapInformer := configmapinformer.Get(ctx)
+	gatewayInformer := gatewayinformer.Get(ctx)
 
 	// Dynamic client.
 	dynamicClient := dynamicclient.Get(ctx)
@@ -84,6 +87,10 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 	gsaPolicyInformer := dynamicInformer.ForResource(*gsaPoliciesGVR)
 	go gsaPolicyInformer.Informer().Run(ctx.Done())
 
+	// cert-manager Certificates are only accessed with the client because
+	// they live in the ingress gateway's Namespace.
+	certificatesGVR, _ := schema.ParseResourceArg("certificates.v1.cert-manager.io")
+
 	// Create reconciler
 	c := &Reconciler{
 		Base:                     reconciler.NewBase(ctx, cmw),
@@ -99,7 +106,10 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		clusterRoleBindingLister: clusterRoleBindingInformer.Lister(),
 		gsaPolicyLister:          gsaPolicyInformer.Lister(),
 		configMapLister:          configMapInformer.Lister(),
+		gatewayLister:            gatewayInformer.Lister(),
 		iamClientSet:             dynamicClient.Resource(*gsaPoliciesGVR),
+		certificateClientSet:     dynamicClient.Resource(*certificatesGVR),
+		networkingClientSet:      networkingclient.Get(ctx),
 	}
 
 	impl := controller.NewContext(ctx, c, controller.ControllerOptions{
@@ -122,6 +132,7 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		rolebindingInformer.Informer(),
 		clusterRoleInformer.Informer(),
 		clusterRoleBindingInformer.Informer(),
+		gatewayInformer.Informer(),
 	} {
 		informer.AddEventHandler(cache.FilteringResourceEventHandler{
 			FilterFunc: controller.FilterControllerGVK(v1alpha1.SchemeGroupVersion.WithKind("Space")),
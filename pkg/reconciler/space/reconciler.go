@@ -25,6 +25,8 @@ import (
 	"github.com/google/kf/v2/pkg/apis/networking"
 	kflisters "github.com/google/kf/v2/pkg/client/kf/listers/kf/v1alpha1"
 	networkingv1listers "github.com/google/kf/v2/pkg/client/kube/listers/networking/v1"
+	networkingclientset "github.com/google/kf/v2/pkg/client/networking/clientset/versioned"
+	networkinglisters "github.com/google/kf/v2/pkg/client/networking/listers/networking/v1alpha3"
 	"github.com/google/kf/v2/pkg/reconciler"
 	"github.com/google/kf/v2/pkg/reconciler/build/config"
 	"github.com/google/kf/v2/pkg/reconciler/reconcilerutil"
@@ -79,8 +81,11 @@ type Reconciler struct {
 	clusterRoleBindingLister rbacv1listers.ClusterRoleBindingLister
 	gsaPolicyLister          cache.GenericLister
 	configMapLister          v1listers.ConfigMapLister
+	gatewayLister            networkinglisters.GatewayLister
 
-	iamClientSet dynamic.NamespaceableResourceInterface
+	iamClientSet         dynamic.NamespaceableResourceInterface
+	certificateClientSet dynamic.NamespaceableResourceInterface
+	networkingClientSet  networkingclientset.Interface
 }
 
 // Check that our Reconciler implements controller.Reconciler
@@ -209,6 +214,10 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, space *v1alpha1.Space) er
 		space.Status.PropagateRuntimeConfigStatus(space.Spec.RuntimeConfig, kfconfig.FromContext(ctx))
 	}
 
+	// The network config status is rebuilt below, remember if the Space had
+	// TLS domains so Certificates they requested are still cleaned up.
+	hadTLSDomains := len(space.Status.NetworkConfig.Certificates) > 0
+
 	{
 		logger.Debug("updating network config")
 		space.Status.PropagateNetworkConfigStatus(space.Spec.NetworkConfig, kfconfig.FromContext(ctx), space.Name)
@@ -599,6 +608,22 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, space *v1alpha1.Space) er
 		return err
 	}
 
+	// Sync TLS Gateways and Certificates
+	{
+		logger.Debug("reconciling domain TLS")
+		condition := space.Status.DomainTLSCondition()
+		resync, err := r.reconcileDomainTLS(ctx, space, hadTLSDomains)
+		if err != nil {
+			return condition.MarkReconciliationError("synchronizing", err)
+		}
+
+		// Certificates are renewed outside of Kf so their expiry needs to be
+		// checked periodically.
+		if resync > 0 {
+			return controller.NewRequeueAfter(resync)
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,167 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	kfistio "github.com/google/kf/v2/pkg/apis/networking/v1alpha3"
+	"github.com/google/kf/v2/pkg/kf/dynamicutils"
+	istio "istio.io/api/networking/v1alpha3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"knative.dev/pkg/kmeta"
+)
+
+const (
+	// TLSGatewayComponent is the component label value of Gateways serving
+	// HTTPS traffic for Space domains.
+	TLSGatewayComponent = "tls-gateway"
+
+	// CertificateComponent is the component label value of cert-manager
+	// Certificates requested for Space domains.
+	CertificateComponent = "certificate"
+)
+
+// MakeTLSGateway creates a Gateway in the kf Namespace that terminates HTTPS
+// traffic for the domain on the ingress gateway pods matching the selector.
+func MakeTLSGateway(
+	space *v1alpha1.Space,
+	domain *v1alpha1.SpaceDomain,
+	selector map[string]string,
+) (*kfistio.Gateway, error) {
+	if domain.TLS == nil {
+		return nil, errors.New("domain doesn't have TLS configured")
+	}
+
+	return &kfistio.Gateway{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "networking.istio.io/v1alpha3",
+			Kind:       "Gateway",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      domain.TLSGatewayName(space.Name),
+			Namespace: v1alpha1.KfNamespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(space),
+			},
+			Labels: map[string]string{
+				managedByLabel:          "kf",
+				v1alpha1.ComponentLabel: TLSGatewayComponent,
+			},
+		},
+		Spec: istio.Gateway{
+			Selector: selector,
+			Servers: []*istio.Server{
+				{
+					Port: &istio.Port{
+						Number:   443,
+						Name:     "https",
+						Protocol: "HTTPS",
+					},
+					Hosts: []string{domain.Domain, "*." + domain.Domain},
+					Tls: &istio.ServerTLSSettings{
+						Mode:           istio.ServerTLSSettings_SIMPLE,
+						CredentialName: domain.TLSSecretName(space.Name),
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// MakeCertificate creates a cert-manager Certificate that stores a certificate
+// for the domain and its subdomains in the domain's TLS Secret. The
+// Certificate must be in the same Namespace as the ingress gateway.
+func MakeCertificate(
+	space *v1alpha1.Space,
+	domain *v1alpha1.SpaceDomain,
+	namespace string,
+) (*unstructured.Unstructured, error) {
+	if domain.TLS == nil || domain.TLS.IssuerName == "" {
+		return nil, errors.New("domain doesn't have a certificate issuer")
+	}
+
+	secretName := domain.TLSSecretName(space.Name)
+
+	certificate := dynamicutils.NewUnstructured(
+		map[string]interface{}{
+			"kind":               "Certificate",
+			"apiVersion":         "cert-manager.io/v1",
+			"metadata.name":      secretName,
+			"metadata.namespace": namespace,
+			"spec.secretName":    secretName,
+			// NOTE: The type needs to be []interface{} instead of []string
+			// because the value back from K8s will have this type.
+			"spec.dnsNames": []interface{}{
+				domain.Domain,
+				"*." + domain.Domain,
+			},
+			"spec.issuerRef.name":  domain.TLS.IssuerName,
+			"spec.issuerRef.kind":  "ClusterIssuer",
+			"spec.issuerRef.group": "cert-manager.io",
+		},
+	)
+
+	certificate.SetLabels(map[string]string{
+		managedByLabel:          "kf",
+		v1alpha1.ComponentLabel: CertificateComponent,
+	})
+	certificate.SetOwnerReferences([]metav1.OwnerReference{
+		*kmeta.NewControllerRef(space),
+	})
+
+	return certificate, nil
+}
+
+// MakeDomainCertificate reads the certificate for the domain from its TLS
+// Secret. A nil Secret means the Secret doesn't exist yet.
+func MakeDomainCertificate(
+	space *v1alpha1.Space,
+	domain *v1alpha1.SpaceDomain,
+	secret *corev1.Secret,
+) v1alpha1.SpaceDomainCertificate {
+	out := v1alpha1.SpaceDomainCertificate{
+		Domain:     domain.Domain,
+		SecretName: domain.TLSSecretName(space.Name),
+	}
+
+	if secret == nil {
+		out.Message = "Secret doesn't exist"
+		if domain.TLS.IssuerName != "" {
+			out.Message = "Waiting for the certificate to be issued"
+		}
+		return out
+	}
+
+	block, _ := pem.Decode(secret.Data[corev1.TLSCertKey])
+	if block == nil {
+		out.Message = "Secret doesn't contain a PEM encoded certificate"
+		return out
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		out.Message = "Couldn't parse the certificate: " + err.Error()
+		return out
+	}
+
+	out.NotAfter = &metav1.Time{Time: cert.NotAfter}
+	return out
+}
@@ -0,0 +1,227 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func tlsTestSpace() *v1alpha1.Space {
+	space := &v1alpha1.Space{}
+	space.Name = "some-space"
+	space.UID = "some-uid"
+	return space
+}
+
+func TestMakeTLSGateway(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		domain v1alpha1.SpaceDomain
+	}{
+		"secret": {
+			domain: v1alpha1.SpaceDomain{
+				Domain:      "example.com",
+				GatewayName: v1alpha1.KfExternalIngressGateway,
+				TLS: &v1alpha1.SpaceDomainTLS{
+					SecretName: "example-com-tls",
+				},
+			},
+		},
+		"issuer": {
+			domain: v1alpha1.SpaceDomain{
+				Domain:      "example.com",
+				GatewayName: v1alpha1.KfExternalIngressGateway,
+				TLS: &v1alpha1.SpaceDomainTLS{
+					IssuerName: "letsencrypt",
+				},
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			gateway, err := MakeTLSGateway(tlsTestSpace(), &tc.domain, map[string]string{
+				"istio": "ingressgateway",
+			})
+			testutil.AssertNil(t, "err", err)
+
+			testutil.AssertGoldenJSONContext(t, "gateway", gateway, map[string]interface{}{
+				"domain": tc.domain,
+			})
+		})
+	}
+}
+
+func TestMakeTLSGateway_noTLS(t *testing.T) {
+	t.Parallel()
+
+	_, err := MakeTLSGateway(tlsTestSpace(), &v1alpha1.SpaceDomain{Domain: "example.com"}, nil)
+	testutil.AssertErrorsEqual(t, errors.New("domain doesn't have TLS configured"), err)
+}
+
+func TestMakeCertificate(t *testing.T) {
+	t.Parallel()
+
+	domain := &v1alpha1.SpaceDomain{
+		Domain: "example.com",
+		TLS: &v1alpha1.SpaceDomainTLS{
+			IssuerName: "letsencrypt",
+		},
+	}
+
+	certificate, err := MakeCertificate(tlsTestSpace(), domain, "istio-system")
+	testutil.AssertErrorsEqual(t, nil, err)
+
+	secretName := domain.TLSSecretName("some-space")
+
+	// ObjectMeta
+	testutil.AssertUnstructuredEqual(t, "kind", "Certificate", certificate)
+	testutil.AssertUnstructuredEqual(t, "apiVersion", "cert-manager.io/v1", certificate)
+	testutil.AssertUnstructuredEqual(t, "metadata.name", secretName, certificate)
+	testutil.AssertUnstructuredEqual(t, "metadata.namespace", "istio-system", certificate)
+	testutil.AssertEqual(t, "labels", map[string]string{
+		managedByLabel:          "kf",
+		v1alpha1.ComponentLabel: CertificateComponent,
+	}, certificate.GetLabels())
+	testutil.AssertTrue(t, "controlled by Space", metav1.IsControlledBy(certificate, tlsTestSpace()))
+
+	// Spec
+	testutil.AssertUnstructuredEqual(t, "spec.secretName", secretName, certificate)
+	testutil.AssertUnstructuredEqual(t, "spec.dnsNames", []interface{}{"example.com", "*.example.com"}, certificate)
+	testutil.AssertUnstructuredEqual(t, "spec.issuerRef", map[string]interface{}{
+		"name":  "letsencrypt",
+		"kind":  "ClusterIssuer",
+		"group": "cert-manager.io",
+	}, certificate)
+}
+
+func TestMakeCertificate_noIssuer(t *testing.T) {
+	t.Parallel()
+
+	domain := &v1alpha1.SpaceDomain{
+		Domain: "example.com",
+		TLS: &v1alpha1.SpaceDomainTLS{
+			SecretName: "example-com-tls",
+		},
+	}
+
+	_, err := MakeCertificate(tlsTestSpace(), domain, "istio-system")
+	testutil.AssertErrorsEqual(t, errors.New("domain doesn't have a certificate issuer"), err)
+}
+
+func TestMakeDomainCertificate(t *testing.T) {
+	t.Parallel()
+
+	notAfter := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	certPEM := selfSignedCertPEM(t, notAfter)
+
+	secretDomain := &v1alpha1.SpaceDomain{
+		Domain: "example.com",
+		TLS:    &v1alpha1.SpaceDomainTLS{SecretName: "example-com-tls"},
+	}
+	issuerDomain := &v1alpha1.SpaceDomain{
+		Domain: "example.com",
+		TLS:    &v1alpha1.SpaceDomainTLS{IssuerName: "letsencrypt"},
+	}
+
+	cases := map[string]struct {
+		domain   *v1alpha1.SpaceDomain
+		secret   *corev1.Secret
+		expected v1alpha1.SpaceDomainCertificate
+	}{
+		"missing secret": {
+			domain: secretDomain,
+			expected: v1alpha1.SpaceDomainCertificate{
+				Domain:     "example.com",
+				SecretName: "example-com-tls",
+				Message:    "Secret doesn't exist",
+			},
+		},
+		"pending issuer": {
+			domain: issuerDomain,
+			expected: v1alpha1.SpaceDomainCertificate{
+				Domain:     "example.com",
+				SecretName: issuerDomain.TLSSecretName("some-space"),
+				Message:    "Waiting for the certificate to be issued",
+			},
+		},
+		"invalid certificate": {
+			domain: secretDomain,
+			secret: &corev1.Secret{
+				Data: map[string][]byte{
+					corev1.TLSCertKey: []byte("not-a-cert"),
+				},
+			},
+			expected: v1alpha1.SpaceDomainCertificate{
+				Domain:     "example.com",
+				SecretName: "example-com-tls",
+				Message:    "Secret doesn't contain a PEM encoded certificate",
+			},
+		},
+		"valid certificate": {
+			domain: secretDomain,
+			secret: &corev1.Secret{
+				Data: map[string][]byte{
+					corev1.TLSCertKey: certPEM,
+				},
+			},
+			expected: v1alpha1.SpaceDomainCertificate{
+				Domain:     "example.com",
+				SecretName: "example-com-tls",
+				NotAfter:   &metav1.Time{Time: notAfter},
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			actual := MakeDomainCertificate(tlsTestSpace(), tc.domain, tc.secret)
+			testutil.AssertEqual(t, "certificate", tc.expected, actual)
+		})
+	}
+}
+
+func selfSignedCertPEM(t *testing.T, notAfter time.Time) []byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	testutil.AssertNil(t, "key err", err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		DNSNames:     []string{"example.com"},
+		NotBefore:    notAfter.Add(-24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	testutil.AssertNil(t, "cert err", err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
@@ -0,0 +1,337 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	kfistio "github.com/google/kf/v2/pkg/apis/networking/v1alpha3"
+	"github.com/google/kf/v2/pkg/reconciler"
+	"github.com/google/kf/v2/pkg/reconciler/space/resources"
+	"github.com/google/kf/v2/pkg/system"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/logging"
+)
+
+const (
+	// certificatePendingResync is how often Spaces waiting for a certificate
+	// to be issued are checked.
+	certificatePendingResync = time.Minute
+
+	// certificateResync is how often Spaces with TLS domains are checked so
+	// certificate expiry stays up to date after renewals.
+	certificateResync = time.Hour
+)
+
+// reconcileDomainTLS creates a Gateway server for each of the Space's domains
+// with TLS configured, requests certificates from cert-manager for domains
+// with an issuer and reports the certificates in the Space's status. It
+// returns how long to wait before checking the certificates again, or zero if
+// the Space has no TLS domains. hadTLSDomains reports whether the Space's
+// previous status recorded any certificates.
+func (r *Reconciler) reconcileDomainTLS(ctx context.Context, space *v1alpha1.Space, hadTLSDomains bool) (time.Duration, error) {
+	var (
+		desiredGateways     = sets.NewString()
+		desiredCertificates = sets.NewString()
+		certificates        []v1alpha1.SpaceDomainCertificate
+		ingressNamespace    string
+	)
+
+	for i := range space.Status.NetworkConfig.Domains {
+		domain := &space.Status.NetworkConfig.Domains[i]
+		if domain.TLS == nil {
+			continue
+		}
+
+		if ingressNamespace == "" {
+			namespace, err := system.GetClusterIngressNamespace(r.serviceLister)
+			if err != nil {
+				return 0, fmt.Errorf("finding ingress gateway Namespace: %v", err)
+			}
+			ingressNamespace = namespace
+		}
+
+		desiredGateways.Insert(domain.TLSGatewayName(space.Name))
+		if err := r.reconcileTLSGateway(ctx, space, domain); err != nil {
+			return 0, fmt.Errorf("reconciling Gateway for domain %q: %v", domain.Domain, err)
+		}
+
+		if domain.TLS.IssuerName != "" {
+			desiredCertificates.Insert(domain.TLSSecretName(space.Name))
+			if err := r.reconcileCertificate(ctx, space, domain, ingressNamespace); err != nil {
+				return 0, fmt.Errorf("reconciling Certificate for domain %q: %v", domain.Domain, err)
+			}
+		}
+
+		secret, err := r.SecretLister.
+			Secrets(ingressNamespace).
+			Get(domain.TLSSecretName(space.Name))
+		switch {
+		case apierrs.IsNotFound(err):
+			secret = nil
+		case err != nil:
+			return 0, fmt.Errorf("getting TLS Secret for domain %q: %v", domain.Domain, err)
+		}
+
+		certificates = append(certificates, resources.MakeDomainCertificate(space, domain, secret))
+	}
+
+	if err := r.cleanupTLSGateways(ctx, space, desiredGateways); err != nil {
+		return 0, err
+	}
+
+	// Certificates aren't backed by an informer, so they're only listed if the
+	// Space has or had TLS domains that could have requested them.
+	if len(certificates) > 0 || hadTLSDomains {
+		if err := r.cleanupCertificates(ctx, space, desiredCertificates); err != nil {
+			return 0, err
+		}
+	}
+
+	space.Status.PropagateDomainCertificates(certificates)
+
+	switch {
+	case len(certificates) == 0:
+		return 0, nil
+	case space.Status.DomainTLSCondition().IsPending():
+		return certificatePendingResync, nil
+	default:
+		return certificateResync, nil
+	}
+}
+
+func (r *Reconciler) reconcileTLSGateway(
+	ctx context.Context,
+	space *v1alpha1.Space,
+	domain *v1alpha1.SpaceDomain,
+) error {
+	logger := logging.FromContext(ctx)
+
+	// The generated Gateway attaches to the same ingress pods as the Gateway
+	// configured for the domain.
+	ingressNamespace, ingressName, err := cache.SplitMetaNamespaceKey(domain.GatewayName)
+	if err != nil {
+		return err
+	}
+	ingress, err := r.gatewayLister.
+		Gateways(ingressNamespace).
+		Get(ingressName)
+	if err != nil {
+		return fmt.Errorf("couldn't get Gateway %q for the domain: %v", domain.GatewayName, err)
+	}
+
+	desired, err := resources.MakeTLSGateway(space, domain, ingress.Spec.Selector)
+	if err != nil {
+		return err
+	}
+
+	actual, err := r.gatewayLister.
+		Gateways(desired.Namespace).
+		Get(desired.Name)
+	if apierrs.IsNotFound(err) {
+		_, err = r.networkingClientSet.
+			NetworkingV1alpha3().
+			Gateways(desired.Namespace).
+			Create(ctx, desired, metav1.CreateOptions{})
+		return err
+	} else if err != nil {
+		return err
+	} else if !metav1.IsControlledBy(actual, space) {
+		return fmt.Errorf("Gateway %q is not owned by Space %q", actual.Name, space.Name)
+	} else if actual.GetDeletionTimestamp() != nil {
+		return nil
+	}
+
+	semanticEquality := reconciler.NewSemanticEqualityBuilder(logger, "Gateway").
+		Append("metadata.labels", desired.ObjectMeta.Labels, actual.ObjectMeta.Labels).
+		Append("spec.selector", desired.Spec.Selector, actual.Spec.Selector)
+
+	if len(desired.Spec.Servers) == len(actual.Spec.Servers) {
+		for i, server := range desired.Spec.Servers {
+			semanticEquality.Append(fmt.Sprintf("spec.servers[%d]", i), server, actual.Spec.Servers[i])
+		}
+
+		if semanticEquality.IsSemanticallyEqual() {
+			return nil
+		}
+	}
+
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+	existing.Labels = desired.Labels
+	existing.Spec.Selector = desired.Spec.Selector
+	existing.Spec.Servers = desired.Spec.Servers
+
+	_, err = r.networkingClientSet.
+		NetworkingV1alpha3().
+		Gateways(existing.Namespace).
+		Update(ctx, existing, metav1.UpdateOptions{})
+	return err
+}
+
+func (r *Reconciler) reconcileCertificate(
+	ctx context.Context,
+	space *v1alpha1.Space,
+	domain *v1alpha1.SpaceDomain,
+	namespace string,
+) error {
+	logger := logging.FromContext(ctx)
+
+	desired, err := resources.MakeCertificate(space, domain, namespace)
+	if err != nil {
+		return err
+	}
+
+	actual, err := r.certificateClientSet.
+		Namespace(namespace).
+		Get(ctx, desired.GetName(), metav1.GetOptions{})
+	if apierrs.IsNotFound(err) {
+		_, err = r.certificateClientSet.
+			Namespace(namespace).
+			Create(ctx, desired, metav1.CreateOptions{})
+		return err
+	} else if err != nil {
+		return err
+	} else if !metav1.IsControlledBy(actual, space) {
+		return fmt.Errorf("Certificate %q is not owned by Space %q", actual.GetName(), space.Name)
+	}
+
+	builder := reconciler.NewUnstructuredSemanticEqualityBuilder(logger, "Certificate").
+		Append("metadata.labels", desired, actual).
+		Append("spec", desired, actual)
+
+	if builder.IsSemanticallyEqual() {
+		return nil
+	}
+
+	existing := actual.DeepCopy()
+	builder.Transform(existing)
+	_, err = r.certificateClientSet.
+		Namespace(namespace).
+		Update(ctx, existing, metav1.UpdateOptions{})
+	return err
+}
+
+// cleanupTLSGateways removes Gateways the Space created for domains that no
+// longer have TLS configured.
+func (r *Reconciler) cleanupTLSGateways(
+	ctx context.Context,
+	space *v1alpha1.Space,
+	desired sets.String,
+) error {
+	logger := logging.FromContext(ctx)
+
+	gateways, err := r.gatewayLister.
+		Gateways(v1alpha1.KfNamespace).
+		List(tlsSelector(resources.TLSGatewayComponent))
+	if err != nil {
+		return fmt.Errorf("listing TLS Gateways: %v", err)
+	}
+
+	for _, gateway := range staleTLSObjects(space, desired, gatewaysToObjects(gateways)) {
+		logger.Infof("Deleting Gateway %q because its domain no longer has TLS configured", gateway.GetName())
+		if err := r.networkingClientSet.
+			NetworkingV1alpha3().
+			Gateways(gateway.GetNamespace()).
+			Delete(ctx, gateway.GetName(), metav1.DeleteOptions{}); err != nil && !apierrs.IsNotFound(err) {
+			return fmt.Errorf("deleting Gateway %q: %v", gateway.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+// cleanupCertificates removes Certificates the Space requested for domains
+// that no longer have an issuer.
+func (r *Reconciler) cleanupCertificates(
+	ctx context.Context,
+	space *v1alpha1.Space,
+	desired sets.String,
+) error {
+	logger := logging.FromContext(ctx)
+
+	namespace, err := system.GetClusterIngressNamespace(r.serviceLister)
+	if err != nil {
+		// Without an ingress gateway there's nowhere Certificates could have
+		// been created.
+		return nil
+	}
+
+	list, err := r.certificateClientSet.
+		Namespace(namespace).
+		List(ctx, metav1.ListOptions{
+			LabelSelector: tlsSelector(resources.CertificateComponent).String(),
+		})
+	if apierrs.IsNotFound(err) {
+		// cert-manager isn't installed.
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("listing Certificates: %v", err)
+	}
+
+	var objects []metav1.Object
+	for i := range list.Items {
+		objects = append(objects, &list.Items[i])
+	}
+
+	for _, certificate := range staleTLSObjects(space, desired, objects) {
+		logger.Infof("Deleting Certificate %q because its domain no longer has an issuer", certificate.GetName())
+		if err := r.certificateClientSet.
+			Namespace(namespace).
+			Delete(ctx, certificate.GetName(), metav1.DeleteOptions{}); err != nil && !apierrs.IsNotFound(err) {
+			return fmt.Errorf("deleting Certificate %q: %v", certificate.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+func tlsSelector(component string) labels.Selector {
+	return labels.SelectorFromSet(labels.Set{
+		v1alpha1.ManagedByLabel: "kf",
+		v1alpha1.ComponentLabel: component,
+	})
+}
+
+func gatewaysToObjects(gateways []*kfistio.Gateway) []metav1.Object {
+	var out []metav1.Object
+	for _, gateway := range gateways {
+		out = append(out, gateway)
+	}
+	return out
+}
+
+// staleTLSObjects returns the objects controlled by the Space that aren't in
+// the desired set.
+func staleTLSObjects(space *v1alpha1.Space, desired sets.String, objects []metav1.Object) []metav1.Object {
+	var out []metav1.Object
+	for _, obj := range objects {
+		if !metav1.IsControlledBy(obj, space) {
+			continue
+		}
+		if obj.GetDeletionTimestamp() != nil || desired.Has(obj.GetName()) {
+			continue
+		}
+		out = append(out, obj)
+	}
+	return out
+}
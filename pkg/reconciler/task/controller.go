@@ -21,6 +21,7 @@ import (
 	"github.com/google/kf/v2/pkg/apis/kf/config"
 	kfv1alpha1 "github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
 	appinformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/app"
+	buildinformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/build"
 	"github.com/google/kf/v2/pkg/reconciler/reconcilerutil"
 
 	spaceinformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/space"
@@ -39,6 +40,7 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 
 	// Get informers off context.
 	appInformer := appinformer.Get(ctx)
+	buildInformer := buildinformer.Get(ctx)
 	spaceInformer := spaceinformer.Get(ctx)
 	taskInformer := taskinformer.Get(ctx)
 	taskRunInformer := taskruninformer.Get(ctx)
@@ -50,6 +52,7 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 	c := &Reconciler{
 		Base:          reconciler.NewBase(ctx, cmw),
 		appLister:     appInformer.Lister(),
+		buildLister:   buildInformer.Lister(),
 		spaceLister:   spaceInformer.Lister(),
 		taskLister:    taskLister,
 		taskRunLister: taskRunInformer.Lister(),
@@ -74,6 +77,9 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 	configStore.WatchConfigs(cmw)
 	c.configStore = configStore
 
+	c.notifier = reconcilerutil.NewTaskNotifier(configStore)
+	c.notifier.Enqueue = impl.EnqueueKey
+
 	logger.Info("Setting up event handlers")
 
 	taskInformer.Informer().AddEventHandlerWithResyncPeriod(
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
@@ -27,19 +28,35 @@ import (
 	"github.com/google/kf/v2/pkg/dockerutil"
 	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/tools/cache"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
 
 	"github.com/google/kf/v2/pkg/reconciler"
+	"github.com/google/kf/v2/pkg/reconciler/reconcilerutil"
 	"github.com/google/kf/v2/pkg/reconciler/task/resources"
 	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	taskrunclient "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/typed/pipeline/v1beta1"
 	tektonListers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1beta1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
+// buildPollInterval is how often Tasks waiting for a Build check whether it
+// finished.
+const buildPollInterval = 15 * time.Second
+
+// missingBuildDeadline is how long after a Task is created a Build it
+// references that can't be found is waited for before the Task fails.
+const missingBuildDeadline = 2 * time.Minute
+
+// notificationDeadline is how long after a Task finishes sending its
+// notifications is retried before giving up.
+const notificationDeadline = time.Hour
+
 // Reconciler reconciles a Task object with the K8s cluster.
 type Reconciler struct {
 	*reconciler.Base
@@ -48,10 +65,12 @@ type Reconciler struct {
 
 	// listers index properties about resources
 	appLister     kflisters.AppLister
+	buildLister   kflisters.BuildLister
 	spaceLister   kflisters.SpaceLister
 	taskLister    kflisters.TaskLister
 	taskRunLister tektonListers.TaskRunLister
 	configStore   *config.Store
+	notifier      *reconcilerutil.TaskNotifier
 }
 
 // Check that our Reconciler implements controller.Reconciler
@@ -139,6 +158,55 @@ func (r *Reconciler) reconcileTask(
 	return reconcileErr
 }
 
+// maybeNotify sends the notifications of a finished Task once. They're sent
+// in the background and the Task is reconciled again to record the result.
+// Failed notifications are retried until the notificationDeadline passes, the
+// ones that were already delivered aren't sent again.
+func (r *Reconciler) maybeNotify(ctx context.Context, task *v1alpha1.Task) error {
+	if task.Status.NotificationsSent || len(task.Spec.Notifications) == 0 {
+		return nil
+	}
+
+	logger := logging.FromContext(ctx)
+
+	key := types.NamespacedName{Namespace: task.Namespace, Name: task.Name}
+	result, done := r.notifier.Deliver(key, undeliveredNotifications(task), reconcilerutil.NewTaskFinishedEvent(task))
+	if !done {
+		return nil
+	}
+
+	task.Status.DeliveredNotifications = append(task.Status.DeliveredNotifications, result.Delivered...)
+	err := result.Err
+	if err == nil {
+		task.Status.NotificationsSent = true
+		return nil
+	}
+
+	finished := task.Status.GetCondition(v1alpha1.TaskConditionSucceeded)
+	if finished != nil && time.Since(finished.LastTransitionTime.Inner.Time) > notificationDeadline {
+		logger.Warnw("Giving up sending Task notifications", zap.Error(err))
+		task.Status.NotificationsSent = true
+		return nil
+	}
+
+	logger.Warnw("Failed to send Task notifications", zap.Error(err))
+	return err
+}
+
+// undeliveredNotifications returns the notifications of the Task that weren't
+// delivered yet.
+func undeliveredNotifications(task *v1alpha1.Task) []v1alpha1.TaskNotification {
+	delivered := sets.NewString(task.Status.DeliveredNotifications...)
+
+	var out []v1alpha1.TaskNotification
+	for _, notification := range task.Spec.Notifications {
+		if !delivered.Has(notification.URL) {
+			out = append(out, notification)
+		}
+	}
+	return out
+}
+
 // ApplyChanges updates the linked resources in the cluster with the current
 // status of the Task.
 func (r *Reconciler) ApplyChanges(ctx context.Context, task *v1alpha1.Task, namespace string) error {
@@ -147,7 +215,7 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, task *v1alpha1.Task, name
 
 	// Tasks only get run once regardless of success or failure status.
 	if v1alpha1.IsStatusFinal(task.Status.Status) {
-		return nil
+		return r.maybeNotify(ctx, task)
 	}
 
 	// Ensure Kf Space exists to prevent Kf objects from being created in namespaces that's not a Kf Space.
@@ -217,11 +285,29 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, task *v1alpha1.Task, name
 		// controller will go and try to fetch the image. This will fail if
 		// the user didn't install the controller to be able to read from the
 		// container registry (which is likely).
-		containerCommand, err := r.fetchContainerCommand(app)
+		// The image is resolved once so retries and indexed runs all run the
+		// same image even if the App is updated in the meantime.
+		if task.Status.Image == "" {
+			image, pending, err := r.resolveImage(task, app)
+			switch {
+			case err != nil:
+				return taskRunCondition.MarkTemplateError(err)
+			case pending:
+				taskRunCondition.MarkUnknown("BuildPending", "Waiting for Build %q to finish", task.Spec.BuildRef.Name)
+				return controller.NewRequeueAfter(buildPollInterval)
+			}
+			task.Status.Image = image
+		}
+
+		containerCommand, err := r.fetchContainerCommand(task.Status.Image)
 		if err != nil {
 			return taskRunCondition.MarkReconciliationError("fetching container command", err)
 		}
 
+		if task.Spec.IsIndexed() {
+			return r.reconcileIndexedTaskRuns(ctx, task, app, space, configDefaults, containerCommand)
+		}
+
 		desiredTaskRun, err := resources.MakeTaskRun(configDefaults, task, app, space, containerCommand)
 		if err != nil {
 			return taskRunCondition.MarkTemplateError(err)
@@ -249,11 +335,102 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, task *v1alpha1.Task, name
 		}
 
 		task.Status.PropagateTaskStatus(actual)
+
+		// Failed runs are retried with a new TaskRun once the backoff has
+		// passed.
+		if resources.ShouldRetry(task, actual) {
+			retry := task.Status.Retries + 1
+			delay := task.Spec.RetryDelay(retry) - time.Since(actual.Status.CompletionTime.Time)
+			if delay > 0 {
+				task.Status.MarkRetrying(retry, delay)
+				return controller.NewRequeueAfter(delay)
+			}
+
+			task.Status.Retries = retry
+			task.Status.MarkRetrying(retry, 0)
+			return controller.NewRequeueImmediately()
+		}
+	}
+
+	return nil
+}
+
+// reconcileIndexedTaskRuns starts a TaskRun for each index of a Task with
+// multiple completions, up to its parallelism, and aggregates their status.
+func (r *Reconciler) reconcileIndexedTaskRuns(
+	ctx context.Context,
+	task *v1alpha1.Task,
+	app *v1alpha1.App,
+	space *v1alpha1.Space,
+	configDefaults *config.DefaultsConfig,
+	containerCommand []string,
+) error {
+	logger := logging.FromContext(ctx)
+	taskRunCondition := task.Status.TaskRunCondition()
+
+	taskRuns, err := r.listIndexedTaskRuns(task)
+	if err != nil {
+		return taskRunCondition.MarkReconciliationError("listing TaskRuns", err)
+	}
+
+	plan := resources.PlanIndexes(task, taskRuns, time.Now())
+
+	for _, run := range plan.Start {
+		desiredTaskRun, err := resources.MakeIndexedTaskRun(configDefaults, task, app, space, containerCommand, run.Index, run.Retry)
+		if err != nil {
+			return taskRunCondition.MarkTemplateError(err)
+		}
+
+		logger.Debugf("creating TaskRun %q", desiredTaskRun.Name)
+		if _, err := r.tektonClient.
+			TaskRuns(desiredTaskRun.Namespace).
+			Create(ctx, desiredTaskRun, metav1.CreateOptions{}); err != nil && !errors.IsAlreadyExists(err) {
+			return taskRunCondition.MarkReconciliationError("creating", err)
+		}
+	}
+
+	if task.Spec.Terminated {
+		for _, actual := range plan.Active {
+			if actual.Spec.Status == tektonv1beta1.TaskRunSpecStatusCancelled {
+				continue
+			}
+
+			existing := actual.DeepCopy()
+			existing.Spec.Status = tektonv1beta1.TaskRunSpecStatusCancelled
+			if _, err := r.tektonClient.TaskRuns(existing.Namespace).Update(ctx, existing, metav1.UpdateOptions{}); err != nil {
+				return taskRunCondition.MarkReconciliationError("cancelling", err)
+			}
+		}
+	}
+
+	task.Status.PropagateIndexStatus(task.Spec.Completions, task.Spec.Terminated)
+
+	if plan.RequeueAfter > 0 {
+		return controller.NewRequeueAfter(plan.RequeueAfter)
 	}
 
 	return nil
 }
 
+// listIndexedTaskRuns gets the TaskRuns of all indexes of the Task.
+func (r *Reconciler) listIndexedTaskRuns(task *v1alpha1.Task) ([]*tektonv1beta1.TaskRun, error) {
+	selector := labels.SelectorFromSet(labels.Set{
+		v1alpha1.TaskNameLabel: task.Name,
+	})
+	taskRuns, err := r.taskRunLister.TaskRuns(task.Namespace).List(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	var owned []*tektonv1beta1.TaskRun
+	for _, taskRun := range taskRuns {
+		if metav1.IsControlledBy(taskRun, task) {
+			owned = append(owned, taskRun)
+		}
+	}
+	return owned, nil
+}
+
 // reconcileTaskRun syncs the existing TaskRun to the desired TaskRun.
 func (r *Reconciler) reconcileTaskRun(ctx context.Context, desired, actual *tektonv1beta1.TaskRun) (*tektonv1beta1.TaskRun, error) {
 	logger := logging.FromContext(ctx)
@@ -296,8 +473,31 @@ func (r *Reconciler) updateStatus(ctx context.Context, namespace string, desired
 	return r.KfClientSet.KfV1alpha1().Tasks(namespace).UpdateStatus(ctx, existing, metav1.UpdateOptions{})
 }
 
-func (r *Reconciler) fetchContainerCommand(app *v1alpha1.App) ([]string, error) {
-	imageRef, err := name.ParseReference(app.Status.Image, name.WeakValidation)
+// resolveImage gets the container image the Task runs, see
+// resources.TaskImage. A Build that can't be found is treated as pending
+// because it may not have reached the informer cache yet, the Task fails if
+// it still can't be found after the missingBuildDeadline.
+func (r *Reconciler) resolveImage(task *v1alpha1.Task, app *v1alpha1.App) (string, bool, error) {
+	var build *v1alpha1.Build
+	if task.Spec.BuildRef != nil {
+		var err error
+		build, err = r.buildLister.Builds(task.Namespace).Get(task.Spec.BuildRef.Name)
+		switch {
+		case errors.IsNotFound(err):
+			if time.Since(task.CreationTimestamp.Time) < missingBuildDeadline {
+				return "", true, nil
+			}
+			return "", false, fmt.Errorf("Build %q not found", task.Spec.BuildRef.Name)
+		case err != nil:
+			return "", false, err
+		}
+	}
+
+	return resources.TaskImage(task, app, build)
+}
+
+func (r *Reconciler) fetchContainerCommand(image string) ([]string, error) {
+	imageRef, err := name.ParseReference(image, name.WeakValidation)
 	if err != nil {
 		return nil, err
 	}
@@ -320,26 +520,39 @@ func (r *Reconciler) fetchContainerCommand(app *v1alpha1.App) ([]string, error)
 func (r *Reconciler) maybeCleanupTaskRunPod(ctx context.Context, task *v1alpha1.Task) {
 	logger := logging.FromContext(ctx)
 
-	taskRun, err := r.taskRunLister.
-		TaskRuns(task.Namespace).
-		Get(resources.TaskRunName(task))
-	if err != nil {
-		logger.Errorf("Couldn't get TaskRun: %v", err)
-		return
-	}
+	var taskRuns []*tektonv1beta1.TaskRun
+	if task.Spec.IsIndexed() {
+		indexed, err := r.listIndexedTaskRuns(task)
+		if err != nil {
+			logger.Errorf("Couldn't list TaskRuns: %v", err)
+			return
+		}
+		taskRuns = indexed
+	} else {
+		taskRun, err := r.taskRunLister.
+			TaskRuns(task.Namespace).
+			Get(resources.TaskRunName(task))
+		if err != nil {
+			logger.Errorf("Couldn't get TaskRun: %v", err)
+			return
+		}
 
-	// Don't modify TaskRuns unless they belong to the Task.
-	if !metav1.IsControlledBy(taskRun, task) {
-		return
+		// Don't modify TaskRuns unless they belong to the Task.
+		if !metav1.IsControlledBy(taskRun, task) {
+			return
+		}
+		taskRuns = append(taskRuns, taskRun)
 	}
 
-	// Tekton no longer terminates sidecars unless they're explicitly added
-	// to the TaskSpec. We need to terminate them so the Pods don't end up
-	// with one or two sidecars running indefinitely.
-	// https://github.com/tektoncd/pipeline/issues/4731
-	if err := r.CleanupCompletedTaskRunSidecars(ctx, taskRun); err != nil {
-		// Cleaning up sidecars should be best-effort because it frees up resources from
-		// completed TaskRuns, it's fine to retry again later.
-		logger.Errorf("Couldn't clean up sidecars: %v", err)
+	for _, taskRun := range taskRuns {
+		// Tekton no longer terminates sidecars unless they're explicitly added
+		// to the TaskSpec. We need to terminate them so the Pods don't end up
+		// with one or two sidecars running indefinitely.
+		// https://github.com/tektoncd/pipeline/issues/4731
+		if err := r.CleanupCompletedTaskRunSidecars(ctx, taskRun); err != nil {
+			// Cleaning up sidecars should be best-effort because it frees up resources from
+			// completed TaskRuns, it's fine to retry again later.
+			logger.Errorf("Couldn't clean up sidecars: %v", err)
+		}
 	}
 }
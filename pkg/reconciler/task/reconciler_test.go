@@ -0,0 +1,229 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	kflisters "github.com/google/kf/v2/pkg/client/kf/listers/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	"github.com/google/kf/v2/pkg/reconciler/reconcilerutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/apis"
+)
+
+// notificationServer records the notifications it gets and fails the ones
+// sent to /fail while failing is set.
+type notificationServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	failing  bool
+	requests map[string]int
+}
+
+func newNotificationServer(t *testing.T) *notificationServer {
+	s := &notificationServer{failing: true, requests: make(map[string]int)}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.requests[r.URL.Path]++
+		if r.URL.Path == "/fail" && s.failing {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *notificationServer) setFailing(failing bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failing = failing
+}
+
+func (s *notificationServer) requestCount(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[path]
+}
+
+func failedTask(server *notificationServer, finished time.Time) *v1alpha1.Task {
+	task := &v1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-task",
+			Namespace: "my-space",
+			UID:       "task-uid",
+		},
+		Spec: v1alpha1.TaskSpec{
+			AppRef: corev1.LocalObjectReference{Name: "my-app"},
+			Notifications: []v1alpha1.TaskNotification{
+				{URL: server.URL + "/ok"},
+				{URL: server.URL + "/fail"},
+			},
+		},
+	}
+	task.Status.Conditions = []apis.Condition{{
+		Type:               v1alpha1.TaskConditionSucceeded,
+		Status:             corev1.ConditionFalse,
+		Reason:             "Failed",
+		LastTransitionTime: apis.VolatileTime{Inner: metav1.NewTime(finished)},
+	}}
+	return task
+}
+
+// applyAndWait reconciles the finished Task, waits for the notifications it
+// started to be delivered and reconciles it again to collect the result.
+func applyAndWait(t *testing.T, r *Reconciler, enqueued chan types.NamespacedName, task *v1alpha1.Task) error {
+	t.Helper()
+
+	testutil.AssertNil(t, "starting delivery", r.ApplyChanges(context.Background(), task, task.Namespace))
+	testutil.AssertFalse(t, "sent before delivery finished", task.Status.NotificationsSent)
+
+	select {
+	case key := <-enqueued:
+		testutil.AssertEqual(t, "enqueued key", types.NamespacedName{Namespace: "my-space", Name: "my-task"}, key)
+	case <-time.After(10 * time.Second):
+		t.Fatal("delivery never finished")
+	}
+
+	return r.ApplyChanges(context.Background(), task, task.Namespace)
+}
+
+func TestReconciler_ApplyChanges_notifications(t *testing.T) {
+	t.Parallel()
+
+	t.Run("failed targets are retried alone", func(t *testing.T) {
+		t.Parallel()
+
+		server := newNotificationServer(t)
+		enqueued := make(chan types.NamespacedName, 1)
+		r := &Reconciler{
+			notifier: &reconcilerutil.TaskNotifier{
+				Client:  server.Client(),
+				Enqueue: func(key types.NamespacedName) { enqueued <- key },
+			},
+		}
+		task := failedTask(server, time.Now())
+
+		err := applyAndWait(t, r, enqueued, task)
+		testutil.AssertNotNil(t, "err", err)
+		testutil.AssertFalse(t, "sent", task.Status.NotificationsSent)
+		testutil.AssertEqual(t, "delivered", []string{server.URL + "/ok"}, task.Status.DeliveredNotifications)
+
+		server.setFailing(false)
+		err = applyAndWait(t, r, enqueued, task)
+		testutil.AssertNil(t, "err", err)
+		testutil.AssertTrue(t, "sent", task.Status.NotificationsSent)
+		testutil.AssertEqual(t, "delivered", []string{server.URL + "/ok", server.URL + "/fail"}, task.Status.DeliveredNotifications)
+		testutil.AssertEqual(t, "ok requests", 1, server.requestCount("/ok"))
+		testutil.AssertEqual(t, "fail requests", 2, server.requestCount("/fail"))
+
+		// Sent notifications aren't delivered again.
+		testutil.AssertNil(t, "err", r.ApplyChanges(context.Background(), task, task.Namespace))
+		testutil.AssertEqual(t, "ok requests", 1, server.requestCount("/ok"))
+	})
+
+	t.Run("gives up after the deadline", func(t *testing.T) {
+		t.Parallel()
+
+		server := newNotificationServer(t)
+		enqueued := make(chan types.NamespacedName, 1)
+		r := &Reconciler{
+			notifier: &reconcilerutil.TaskNotifier{
+				Client:  server.Client(),
+				Enqueue: func(key types.NamespacedName) { enqueued <- key },
+			},
+		}
+		task := failedTask(server, time.Now().Add(-2*notificationDeadline))
+
+		err := applyAndWait(t, r, enqueued, task)
+		testutil.AssertNil(t, "err", err)
+		testutil.AssertTrue(t, "sent", task.Status.NotificationsSent)
+		testutil.AssertEqual(t, "delivered", []string{server.URL + "/ok"}, task.Status.DeliveredNotifications)
+	})
+
+	t.Run("no notifications", func(t *testing.T) {
+		t.Parallel()
+
+		task := failedTask(newNotificationServer(t), time.Now())
+		task.Spec.Notifications = nil
+
+		r := &Reconciler{notifier: &reconcilerutil.TaskNotifier{}}
+		testutil.AssertNil(t, "err", r.ApplyChanges(context.Background(), task, task.Namespace))
+		testutil.AssertFalse(t, "sent", task.Status.NotificationsSent)
+	})
+}
+
+func TestReconciler_resolveImage_missingBuild(t *testing.T) {
+	t.Parallel()
+
+	r := &Reconciler{
+		buildLister: kflisters.NewBuildLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})),
+	}
+	app := &v1alpha1.App{ObjectMeta: metav1.ObjectMeta{Name: "my-app", Namespace: "my-space"}}
+
+	cases := map[string]struct {
+		created     time.Time
+		wantPending bool
+		wantErr     error
+	}{
+		"recently created Task waits for the Build": {
+			created:     time.Now(),
+			wantPending: true,
+		},
+		"Task past the deadline fails": {
+			created: time.Now().Add(-2 * missingBuildDeadline),
+			wantErr: errors.New(`Build "my-build" not found`),
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+
+			task := &v1alpha1.Task{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "my-task",
+					Namespace:         "my-space",
+					CreationTimestamp: metav1.NewTime(tc.created),
+				},
+				Spec: v1alpha1.TaskSpec{
+					AppRef:   corev1.LocalObjectReference{Name: "my-app"},
+					BuildRef: &corev1.LocalObjectReference{Name: "my-build"},
+				},
+			}
+
+			image, pending, err := r.resolveImage(task, app)
+			testutil.AssertErrorsEqual(t, tc.wantErr, err)
+			testutil.AssertEqual(t, "pending", tc.wantPending, pending)
+			testutil.AssertEqual(t, "image", "", image)
+		})
+	}
+}
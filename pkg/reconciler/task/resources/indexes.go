@@ -0,0 +1,225 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/kf/v2/pkg/apis/kf/config"
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+// IndexedTaskRunName gets the name of the TaskRun for a run of an index of a
+// Kf Task with multiple completions. Each retry gets its own TaskRun, names
+// for Tasks with long names are shortened so they stay valid.
+func IndexedTaskRunName(task *v1alpha1.Task, index, retry int32) string {
+	indexStr := strconv.Itoa(int(index))
+	if retry > 0 {
+		return v1alpha1.GenerateName(task.Name, "index", indexStr, "retry", strconv.Itoa(int(retry)))
+	}
+	return v1alpha1.GenerateName(task.Name, "index", indexStr)
+}
+
+// MakeIndexedTaskRun creates the TaskRun for a run of an index of a Kf Task
+// with multiple completions. The index is passed to the App in the
+// KF_TASK_INDEX and CF_INSTANCE_INDEX environment variables.
+func MakeIndexedTaskRun(
+	cfg *config.DefaultsConfig,
+	task *v1alpha1.Task,
+	app *v1alpha1.App,
+	space *v1alpha1.Space,
+	containerCommand []string,
+	index int32,
+	retry int32,
+) (*tektonv1beta1.TaskRun, error) {
+	taskRun, err := MakeTaskRun(cfg, task, app, space, containerCommand)
+	if err != nil {
+		return nil, err
+	}
+
+	taskRun.Name = IndexedTaskRunName(task, index, retry)
+	taskRun.Labels[v1alpha1.TaskIndexLabel] = fmt.Sprint(index)
+
+	userContainer := &taskRun.Spec.TaskSpec.Steps[0]
+	userContainer.Env = append(userContainer.Env,
+		corev1.EnvVar{Name: v1alpha1.TaskIndexEnvVar, Value: fmt.Sprint(index)},
+		corev1.EnvVar{Name: "CF_INSTANCE_INDEX", Value: fmt.Sprintf("$(%s)", v1alpha1.TaskIndexEnvVar)},
+	)
+
+	return taskRun, nil
+}
+
+// IndexRun identifies a run of an index of a Task.
+type IndexRun struct {
+	Index int32
+	Retry int32
+}
+
+// IndexPlan is what needs to be done to progress the indexed runs of a Task.
+type IndexPlan struct {
+	// Start holds the runs to start, lowest index first.
+	Start []IndexRun
+
+	// Active holds the latest TaskRuns of the indexes that haven't completed.
+	Active []*tektonv1beta1.TaskRun
+
+	// RequeueAfter is how long until the next failed index can be retried,
+	// zero if none is waiting.
+	RequeueAfter time.Duration
+}
+
+// PlanIndexes updates the status of a Task with multiple completions from the
+// TaskRuns of its indexes and finds the runs to start. Indexes are started
+// lowest first up to the Task's parallelism, failed indexes are retried
+// separately. No new indexes are started once one failed for good or the Task
+// was terminated.
+func PlanIndexes(task *v1alpha1.Task, taskRuns []*tektonv1beta1.TaskRun, now time.Time) IndexPlan {
+	attempts := make(map[int32]int32)
+	for _, taskRun := range taskRuns {
+		if index, err := taskRunIndex(taskRun); err == nil {
+			attempts[index]++
+		}
+	}
+
+	latest := make(map[int32]*tektonv1beta1.TaskRun)
+	for _, taskRun := range taskRuns {
+		index, err := taskRunIndex(taskRun)
+		if err == nil && taskRun.Name == IndexedTaskRunName(task, index, attempts[index]-1) {
+			latest[index] = taskRun
+		}
+	}
+
+	status := &task.Status
+	status.ActiveIndexes = 0
+	status.SucceededIndexes = 0
+	status.FailedIndexes = 0
+	status.Retries = 0
+	status.ExitCode = nil
+	status.TerminationReason = ""
+
+	var plan IndexPlan
+	var pending []IndexRun
+	var completionTime *metav1.Time
+	for index := int32(0); index < task.Spec.Completions; index++ {
+		taskRun, ok := latest[index]
+		if !ok {
+			pending = append(pending, IndexRun{Index: index})
+			continue
+		}
+
+		retries := attempts[index] - 1
+		status.Retries += retries
+		if start := taskRun.Status.StartTime; start != nil && (status.StartTime == nil || start.Before(status.StartTime)) {
+			status.StartTime = start
+		}
+
+		if taskRun.Status.CompletionTime == nil {
+			status.ActiveIndexes++
+			plan.Active = append(plan.Active, taskRun)
+			continue
+		}
+
+		if completionTime == nil || completionTime.Before(taskRun.Status.CompletionTime) {
+			completionTime = taskRun.Status.CompletionTime
+		}
+
+		cond := taskRun.Status.GetCondition(apis.ConditionSucceeded)
+		if cond.IsTrue() {
+			status.SucceededIndexes++
+			continue
+		}
+
+		if indexShouldRetry(task, retries, cond) {
+			delay := task.Spec.RetryDelay(retries+1) - now.Sub(taskRun.Status.CompletionTime.Time)
+			if delay <= 0 {
+				pending = append(pending, IndexRun{Index: index, Retry: retries + 1})
+				continue
+			}
+
+			status.ActiveIndexes++
+			if plan.RequeueAfter == 0 || delay < plan.RequeueAfter {
+				plan.RequeueAfter = delay
+			}
+			continue
+		}
+
+		status.FailedIndexes++
+		if status.ExitCode == nil {
+			status.ExitCode, status.TerminationReason = exitStatus(taskRun, cond)
+		}
+	}
+
+	if !task.Spec.Terminated && status.FailedIndexes == 0 {
+		for _, run := range pending {
+			if status.ActiveIndexes >= task.Spec.MaxParallel() {
+				break
+			}
+			plan.Start = append(plan.Start, run)
+			status.ActiveIndexes++
+		}
+	}
+
+	status.CompletionTime = nil
+	status.Duration = nil
+	if status.ActiveIndexes == 0 && completionTime != nil {
+		status.CompletionTime = completionTime
+		if status.StartTime != nil {
+			status.Duration = &metav1.Duration{
+				Duration: status.CompletionTime.Time.Sub(status.StartTime.Time),
+			}
+		}
+	}
+
+	return plan
+}
+
+func taskRunIndex(taskRun *tektonv1beta1.TaskRun) (int32, error) {
+	index, err := strconv.ParseInt(taskRun.Labels[v1alpha1.TaskIndexLabel], 10, 32)
+	return int32(index), err
+}
+
+func indexShouldRetry(task *v1alpha1.Task, retries int32, cond *apis.Condition) bool {
+	return !task.Spec.Terminated &&
+		retries < task.Spec.Retries &&
+		cond != nil &&
+		cond.Reason != string(tektonv1beta1.TaskRunReasonCancelled)
+}
+
+// exitStatus gets the exit code and termination reason of a failed TaskRun
+// the same way as TaskStatus.PropagateTaskStatus does for single runs.
+func exitStatus(taskRun *tektonv1beta1.TaskRun, cond *apis.Condition) (*int32, string) {
+	var exitCode *int32
+	var reason string
+	for _, step := range taskRun.Status.Steps {
+		if step.Terminated != nil {
+			code := step.Terminated.ExitCode
+			exitCode = &code
+			reason = step.Terminated.Reason
+			break
+		}
+	}
+
+	if cond != nil && cond.Reason == string(tektonv1beta1.TaskRunReasonTimedOut) {
+		reason = v1alpha1.TaskTerminationReasonDeadlineExceeded
+	}
+
+	return exitCode, reason
+}
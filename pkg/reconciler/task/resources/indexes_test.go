@@ -0,0 +1,214 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/kf/v2/pkg/apis/kf/config"
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/ptr"
+)
+
+func ExampleIndexedTaskRunName() {
+	task := &v1alpha1.Task{}
+	task.Name = "my-task"
+
+	fmt.Println(IndexedTaskRunName(task, 3, 0))
+	fmt.Println(IndexedTaskRunName(task, 3, 2))
+
+	// Output: my-task-index-3
+	// my-task-index-3-retry-2
+}
+
+func TestIndexedTaskRunName_longName(t *testing.T) {
+	task := &v1alpha1.Task{}
+	task.Name = strings.Repeat("a", validation.DNS1123LabelMaxLength)
+
+	names := sets.NewString()
+	for index := int32(0); index < 3; index++ {
+		for retry := int32(0); retry < 3; retry++ {
+			name := IndexedTaskRunName(task, index, retry)
+
+			testutil.AssertEqual(t, "errors", []string(nil), validation.IsDNS1123Label(name))
+			names.Insert(name)
+		}
+	}
+	testutil.AssertEqual(t, "unique names", 9, names.Len())
+}
+
+func ExampleMakeIndexedTaskRun() {
+	cfg := config.BuiltinDefaultsConfig()
+	task, app := exampleCustomTask()
+	task.Spec.Completions = 4
+
+	taskRun, err := MakeIndexedTaskRun(cfg, task, app, exampleSpace(), []string{"some-command"}, 2, 1)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println("Name:", taskRun.Name)
+	fmt.Println("Task:", taskRun.Labels[v1alpha1.TaskNameLabel])
+	fmt.Println("Index:", taskRun.Labels[v1alpha1.TaskIndexLabel])
+	for _, env := range taskRun.Spec.TaskSpec.Steps[0].Env {
+		if env.Name == v1alpha1.TaskIndexEnvVar || env.Name == "CF_INSTANCE_INDEX" {
+			fmt.Printf("%s=%s\n", env.Name, env.Value)
+		}
+	}
+
+	// Output: Name: my-task-index-2-retry-1
+	// Task: my-task
+	// Index: 2
+	// KF_TASK_INDEX=2
+	// CF_INSTANCE_INDEX=$(KF_TASK_INDEX)
+}
+
+func TestPlanIndexes(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(10000, 0)
+
+	makeTask := func(completions, parallelism, retries int32) *v1alpha1.Task {
+		task := &v1alpha1.Task{}
+		task.Name = "my-task"
+		task.Spec.Completions = completions
+		task.Spec.Parallelism = parallelism
+		task.Spec.Retries = retries
+		task.Spec.RetryBackoff = &metav1.Duration{Duration: time.Minute}
+		return task
+	}
+
+	makeTaskRun := func(task *v1alpha1.Task, index, retry int32, status corev1.ConditionStatus, completedAgo time.Duration) *tektonv1beta1.TaskRun {
+		tr := &tektonv1beta1.TaskRun{}
+		tr.Name = IndexedTaskRunName(task, index, retry)
+		tr.Labels = map[string]string{v1alpha1.TaskIndexLabel: fmt.Sprint(index)}
+		tr.Status.StartTime = &metav1.Time{Time: now.Add(-time.Hour)}
+		tr.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: status})
+		if status != corev1.ConditionUnknown {
+			tr.Status.CompletionTime = &metav1.Time{Time: now.Add(-completedAgo)}
+		}
+		if status == corev1.ConditionFalse {
+			tr.Status.Steps = []tektonv1beta1.StepState{{
+				ContainerState: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{ExitCode: 3, Reason: "Error"},
+				},
+			}}
+		}
+		return tr
+	}
+
+	cases := map[string]struct {
+		task          *v1alpha1.Task
+		taskRuns      func(task *v1alpha1.Task) []*tektonv1beta1.TaskRun
+		wantStart     []IndexRun
+		wantActive    int32
+		wantSucceeded int32
+		wantFailed    int32
+		wantExitCode  *int32
+		wantRequeue   time.Duration
+		wantCompleted bool
+	}{
+		"new Task starts up to parallelism": {
+			task:       makeTask(5, 2, 0),
+			taskRuns:   func(*v1alpha1.Task) []*tektonv1beta1.TaskRun { return nil },
+			wantStart:  []IndexRun{{Index: 0}, {Index: 1}},
+			wantActive: 2,
+		},
+		"starts next index when one succeeds": {
+			task: makeTask(3, 2, 0),
+			taskRuns: func(task *v1alpha1.Task) []*tektonv1beta1.TaskRun {
+				return []*tektonv1beta1.TaskRun{
+					makeTaskRun(task, 0, 0, corev1.ConditionTrue, time.Minute),
+					makeTaskRun(task, 1, 0, corev1.ConditionUnknown, 0),
+				}
+			},
+			wantStart:     []IndexRun{{Index: 2}},
+			wantActive:    2,
+			wantSucceeded: 1,
+		},
+		"all succeeded": {
+			task: makeTask(2, 0, 0),
+			taskRuns: func(task *v1alpha1.Task) []*tektonv1beta1.TaskRun {
+				return []*tektonv1beta1.TaskRun{
+					makeTaskRun(task, 0, 0, corev1.ConditionTrue, time.Minute),
+					makeTaskRun(task, 1, 0, corev1.ConditionTrue, time.Minute),
+				}
+			},
+			wantSucceeded: 2,
+			wantCompleted: true,
+		},
+		"failed index waits for backoff": {
+			task: makeTask(2, 0, 1),
+			taskRuns: func(task *v1alpha1.Task) []*tektonv1beta1.TaskRun {
+				return []*tektonv1beta1.TaskRun{
+					makeTaskRun(task, 0, 0, corev1.ConditionFalse, 20*time.Second),
+					makeTaskRun(task, 1, 0, corev1.ConditionTrue, time.Minute),
+				}
+			},
+			wantActive:    1,
+			wantSucceeded: 1,
+			wantRequeue:   40 * time.Second,
+		},
+		"failed index is retried after backoff": {
+			task: makeTask(2, 0, 1),
+			taskRuns: func(task *v1alpha1.Task) []*tektonv1beta1.TaskRun {
+				return []*tektonv1beta1.TaskRun{
+					makeTaskRun(task, 0, 0, corev1.ConditionFalse, 2*time.Minute),
+					makeTaskRun(task, 1, 0, corev1.ConditionTrue, time.Minute),
+				}
+			},
+			wantStart:     []IndexRun{{Index: 0, Retry: 1}},
+			wantActive:    1,
+			wantSucceeded: 1,
+		},
+		"failure stops new indexes": {
+			task: makeTask(3, 1, 1),
+			taskRuns: func(task *v1alpha1.Task) []*tektonv1beta1.TaskRun {
+				return []*tektonv1beta1.TaskRun{
+					makeTaskRun(task, 0, 0, corev1.ConditionFalse, 2*time.Minute),
+					makeTaskRun(task, 0, 1, corev1.ConditionFalse, time.Minute),
+				}
+			},
+			wantFailed:    1,
+			wantExitCode:  ptr.Int32(3),
+			wantCompleted: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			plan := PlanIndexes(tc.task, tc.taskRuns(tc.task), now)
+			status := tc.task.Status
+
+			testutil.AssertEqual(t, "start", tc.wantStart, plan.Start)
+			testutil.AssertEqual(t, "requeue", tc.wantRequeue, plan.RequeueAfter)
+			testutil.AssertEqual(t, "active", tc.wantActive, status.ActiveIndexes)
+			testutil.AssertEqual(t, "succeeded", tc.wantSucceeded, status.SucceededIndexes)
+			testutil.AssertEqual(t, "failed", tc.wantFailed, status.FailedIndexes)
+			testutil.AssertEqual(t, "exitCode", tc.wantExitCode, status.ExitCode)
+			testutil.AssertEqual(t, "completed", tc.wantCompleted, status.CompletionTime != nil)
+		})
+	}
+}
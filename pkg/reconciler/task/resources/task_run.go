@@ -15,6 +15,8 @@
 package resources
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -26,6 +28,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
 	"knative.dev/pkg/kmeta"
 	"knative.dev/pkg/ptr"
 )
@@ -34,8 +37,13 @@ const (
 	tektonPipelineTaskLabel = "tekton.dev/pipelineTask"
 )
 
-// TaskRunName gets the name of a TaskRun for a Kf Task.
+// TaskRunName gets the name of the TaskRun for the latest run of a Kf Task.
+// Each retry gets its own TaskRun, names of retries of Tasks with long names
+// are shortened so they stay valid.
 func TaskRunName(task *v1alpha1.Task) string {
+	if task.Status.Retries > 0 {
+		return v1alpha1.GenerateName(task.Name, "retry", strconv.Itoa(int(task.Status.Retries)))
+	}
 	return task.Name
 }
 
@@ -54,6 +62,7 @@ func makeObjectMeta(name string, task *v1alpha1.Task, app *v1alpha1.App) metav1.
 			map[string]string{
 				v1alpha1.ManagedByLabel:     "kf",
 				v1alpha1.NetworkPolicyLabel: v1alpha1.NetworkPolicyApp,
+				v1alpha1.TaskNameLabel:      task.Name,
 
 				// NOTE: This label is used by the CLI to find relevant pods
 				// for logging.
@@ -104,6 +113,12 @@ func MakeTaskRun(
 		}
 	}
 
+	// The Task's own timeout overrides the default, zero means infinite
+	// like for Tekton.
+	if task.Spec.Timeout != nil {
+		taskRun.Spec.Timeout = &metav1.Duration{Duration: task.Spec.Timeout.Duration}
+	}
+
 	if !cfg.TaskDisableVolumeMounts && len(app.Status.Volumes) > 0 {
 		userContainer := &taskRun.Spec.TaskSpec.Steps[0]
 		// mapfs for volumes needs the extra permission.
@@ -146,6 +161,48 @@ func MakeTaskRun(
 	return taskRun, nil
 }
 
+// TaskImage gets the container image the Task runs: its own image, the image
+// of the Build it references or the App's current image. The Build must be
+// given if the Task references one. pending is true if the Build hasn't
+// finished yet.
+func TaskImage(task *v1alpha1.Task, app *v1alpha1.App, build *v1alpha1.Build) (image string, pending bool, err error) {
+	switch {
+	case task.Spec.Image != "":
+		return task.Spec.Image, false, nil
+
+	case task.Spec.BuildRef != nil:
+		if build == nil || !metav1.IsControlledBy(build, app) {
+			return "", false, fmt.Errorf("Build %q doesn't belong to App %q", task.Spec.BuildRef.Name, app.Name)
+		}
+
+		cond := build.Status.GetCondition(v1alpha1.BuildConditionSucceeded)
+		switch {
+		case cond.IsTrue():
+			return build.Status.Image, false, nil
+		case cond.IsFalse():
+			return "", false, fmt.Errorf("Build %q failed: %s", build.Name, cond.Message)
+		default:
+			return "", true, nil
+		}
+
+	default:
+		return app.Status.Image, false, nil
+	}
+}
+
+// ShouldRetry returns true if the TaskRun for the latest run of the Task
+// failed and the Task has retries left. Cancelled Tasks aren't retried.
+func ShouldRetry(task *v1alpha1.Task, taskRun *tektonv1beta1.TaskRun) bool {
+	if task.Spec.Terminated ||
+		task.Status.Retries >= task.Spec.Retries ||
+		taskRun.Status.CompletionTime == nil {
+		return false
+	}
+
+	cond := taskRun.Status.GetCondition(apis.ConditionSucceeded)
+	return cond.IsFalse() && cond.Reason != string(tektonv1beta1.TaskRunReasonCancelled)
+}
+
 func getUserContainer(
 	task *v1alpha1.Task,
 	app *v1alpha1.App,
@@ -164,6 +221,9 @@ func getUserContainer(
 	userContainer := &spec.Containers[0]
 	userContainer.Name = v1alpha1.DefaultUserContainerName
 	userContainer.Image = app.Status.Image
+	if task.Status.Image != "" {
+		userContainer.Image = task.Status.Image
+	}
 
 	// Inherit environment variables from App.
 	containerEnv := []corev1.EnvVar{}
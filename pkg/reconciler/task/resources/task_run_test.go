@@ -15,14 +15,23 @@
 package resources
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/smithy-go/ptr"
 	"github.com/google/kf/v2/pkg/apis/kf/config"
 	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
 	"github.com/google/kf/v2/pkg/kf/testutil"
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/kmeta"
 )
 
 func ExampleTaskRunName() {
@@ -31,7 +40,26 @@ func ExampleTaskRunName() {
 
 	fmt.Println(TaskRunName(task))
 
+	task.Status.Retries = 2
+	fmt.Println(TaskRunName(task))
+
 	// Output: my-task
+	// my-task-retry-2
+}
+
+func TestTaskRunName_longName(t *testing.T) {
+	task := &v1alpha1.Task{}
+	task.Name = strings.Repeat("a", validation.DNS1123LabelMaxLength)
+
+	names := sets.NewString()
+	for retries := int32(0); retries < 3; retries++ {
+		task.Status.Retries = retries
+		name := TaskRunName(task)
+
+		testutil.AssertEqual(t, "errors", []string(nil), validation.IsDNS1123Label(name))
+		names.Insert(name)
+	}
+	testutil.AssertEqual(t, "unique names", 3, names.Len())
 }
 
 func exampleCustomTask() (*v1alpha1.Task, *v1alpha1.App) {
@@ -83,7 +111,7 @@ func ExampleMakeTaskRun_verifyTaskRunSetup() {
 	fmt.Println("OwnerReferences Count:", len(taskRun.OwnerReferences))
 
 	// Output: Name: my-task
-	// Label Count: 5
+	// Label Count: 6
 	// Managed By: kf
 	// NetworkPolicy: app
 	// Service account: my-sa
@@ -208,6 +236,26 @@ func ExampleMakeTaskRun_verifyTaskRunCancelled() {
 	// Output: TaskRun status: TaskRunCancelled
 }
 
+func ExampleMakeTaskRun_verifyResolvedImageIsUsed() {
+	cfg := config.BuiltinDefaultsConfig()
+
+	task, app := exampleCustomTask()
+	app.Status.Image = "gcr.io/my-app:current"
+	task.Status.Image = "gcr.io/my-app:next"
+
+	space := exampleSpace()
+
+	taskRun, err := MakeTaskRun(cfg, task, app, space, []string{"some-command"})
+
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println("Image:", taskRun.Spec.TaskSpec.Steps[0].Image)
+
+	// Output: Image: gcr.io/my-app:next
+}
+
 func TestMakeTaskRun(t *testing.T) {
 	cases := map[string]struct {
 		cfg              *config.DefaultsConfig
@@ -252,6 +300,20 @@ func TestMakeTaskRun(t *testing.T) {
 			app:   &v1alpha1.App{},
 			space: &v1alpha1.Space{},
 		},
+		"task timeout overrides default": {
+			cfg: func() *config.DefaultsConfig {
+				cfg := config.BuiltinDefaultsConfig()
+				cfg.TaskDefaultTimeoutMinutes = ptr.Int32(5)
+				return cfg
+			}(),
+			task: &v1alpha1.Task{
+				Spec: v1alpha1.TaskSpec{
+					Timeout: &metav1.Duration{Duration: 90 * time.Second},
+				},
+			},
+			app:   &v1alpha1.App{},
+			space: &v1alpha1.Space{},
+		},
 		"NFS volumes disabled": {
 			cfg: func() *config.DefaultsConfig {
 				cfg := config.BuiltinDefaultsConfig()
@@ -347,3 +409,138 @@ func TestMakeTaskRun(t *testing.T) {
 		})
 	}
 }
+
+func TestShouldRetry(t *testing.T) {
+	completion := metav1.Now()
+
+	makeTaskRun := func(status corev1.ConditionStatus, reason string) *tektonv1beta1.TaskRun {
+		tr := &tektonv1beta1.TaskRun{}
+		tr.Status.CompletionTime = &completion
+		tr.Status.SetCondition(&apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: status,
+			Reason: reason,
+		})
+		return tr
+	}
+
+	makeTask := func(retries, retried int32) *v1alpha1.Task {
+		task := &v1alpha1.Task{}
+		task.Spec.Retries = retries
+		task.Status.Retries = retried
+		return task
+	}
+
+	cases := map[string]struct {
+		task    *v1alpha1.Task
+		taskRun *tektonv1beta1.TaskRun
+		want    bool
+	}{
+		"failed with retries left": {
+			task:    makeTask(2, 1),
+			taskRun: makeTaskRun(corev1.ConditionFalse, "Failed"),
+			want:    true,
+		},
+		"timed out with retries left": {
+			task:    makeTask(1, 0),
+			taskRun: makeTaskRun(corev1.ConditionFalse, string(tektonv1beta1.TaskRunReasonTimedOut)),
+			want:    true,
+		},
+		"no retries left": {
+			task:    makeTask(2, 2),
+			taskRun: makeTaskRun(corev1.ConditionFalse, "Failed"),
+		},
+		"succeeded": {
+			task:    makeTask(2, 0),
+			taskRun: makeTaskRun(corev1.ConditionTrue, "Succeeded"),
+		},
+		"cancelled": {
+			task:    makeTask(2, 0),
+			taskRun: makeTaskRun(corev1.ConditionFalse, string(tektonv1beta1.TaskRunReasonCancelled)),
+		},
+		"still running": {
+			task:    makeTask(2, 0),
+			taskRun: &tektonv1beta1.TaskRun{},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			testutil.AssertEqual(t, "ShouldRetry", tc.want, ShouldRetry(tc.task, tc.taskRun))
+		})
+	}
+}
+
+func TestTaskImage(t *testing.T) {
+	app := &v1alpha1.App{}
+	app.Name = "my-app"
+	app.UID = "app-uid"
+	app.Status.Image = "gcr.io/my-app:current"
+
+	makeBuild := func(owner *v1alpha1.App, status corev1.ConditionStatus) *v1alpha1.Build {
+		build := &v1alpha1.Build{}
+		build.Name = "my-build"
+		build.OwnerReferences = []metav1.OwnerReference{*kmeta.NewControllerRef(owner)}
+		build.Status.Image = "gcr.io/my-app:next"
+		build.Status.Conditions = []apis.Condition{
+			{Type: v1alpha1.BuildConditionSucceeded, Status: status, Message: "some message"},
+		}
+		return build
+	}
+
+	otherApp := app.DeepCopy()
+	otherApp.Name = "other-app"
+	otherApp.UID = "other-uid"
+
+	buildTask := &v1alpha1.Task{}
+	buildTask.Spec.BuildRef = &corev1.LocalObjectReference{Name: "my-build"}
+
+	cases := map[string]struct {
+		task        *v1alpha1.Task
+		build       *v1alpha1.Build
+		wantImage   string
+		wantPending bool
+		wantErr     error
+	}{
+		"App image by default": {
+			task:      &v1alpha1.Task{},
+			wantImage: "gcr.io/my-app:current",
+		},
+		"image override": {
+			task: &v1alpha1.Task{
+				Spec: v1alpha1.TaskSpec{Image: "gcr.io/migrations:v2"},
+			},
+			wantImage: "gcr.io/migrations:v2",
+		},
+		"succeeded Build": {
+			task:      buildTask,
+			build:     makeBuild(app, corev1.ConditionTrue),
+			wantImage: "gcr.io/my-app:next",
+		},
+		"pending Build": {
+			task:        buildTask,
+			build:       makeBuild(app, corev1.ConditionUnknown),
+			wantPending: true,
+		},
+		"failed Build": {
+			task:    buildTask,
+			build:   makeBuild(app, corev1.ConditionFalse),
+			wantErr: errors.New(`Build "my-build" failed: some message`),
+		},
+		"Build of another App": {
+			task:    buildTask,
+			build:   makeBuild(otherApp, corev1.ConditionTrue),
+			wantErr: errors.New(`Build "my-build" doesn't belong to App "my-app"`),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			image, pending, err := TaskImage(tc.task, app, tc.build)
+
+			testutil.AssertErrorsEqual(t, tc.wantErr, err)
+			testutil.AssertEqual(t, "image", tc.wantImage, image)
+			testutil.AssertEqual(t, "pending", tc.wantPending, pending)
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/google/kf/v2/pkg/apis/kf/config"
 	"github.com/google/kf/v2/pkg/reconciler"
 	"github.com/google/kf/v2/pkg/reconciler/reconcilerutil"
 	"knative.dev/pkg/configmap"
@@ -42,6 +43,13 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		taskLister:         taskInformer.Lister(),
 	}
 
+	logger.Info("Setting up ConfigMap receivers")
+	// The config is only read when notifications are sent so updates don't
+	// need a resync.
+	configStore := config.NewStore(logger.Named("kf-config-store"))
+	configStore.WatchConfigs(cmw)
+	c.notifier = reconcilerutil.NewTaskNotifier(configStore)
+
 	impl := controller.NewContext(ctx, c, controller.ControllerOptions{
 		WorkQueueName: "taskschedules",
 		Logger:        logger,
@@ -19,11 +19,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
 	"time"
 
 	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
 	kflisters "github.com/google/kf/v2/pkg/client/kf/listers/kf/v1alpha1"
 	"github.com/google/kf/v2/pkg/reconciler"
+	"github.com/google/kf/v2/pkg/reconciler/reconcilerutil"
 	"github.com/google/kf/v2/pkg/reconciler/taskschedule/resources"
 	werrors "github.com/pkg/errors"
 	"github.com/robfig/cron/v3"
@@ -48,6 +50,7 @@ type Reconciler struct {
 	spaceLister        kflisters.SpaceLister
 	taskScheduleLister kflisters.TaskScheduleLister
 	taskLister         kflisters.TaskLister
+	notifier           *reconcilerutil.TaskNotifier
 }
 
 // Check that our Reconciler implements controller.Reconciler
@@ -181,6 +184,17 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, ts *v1alpha1.TaskSchedule
 		}
 	}
 
+	for _, task := range tasksOverHistoryLimits(ts, tasks) {
+		logger.Infof("Deleting Task %q, it's over the TaskSchedule's history limit", task.Name)
+		if err := r.KfClientSet.
+			KfV1alpha1().
+			Tasks(task.GetNamespace()).
+			Delete(ctx, task.Name, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			logger.Warnw("Failed to delete Task", zap.Error(err))
+			return err
+		}
+	}
+
 	// Skip reconciliation if TaskSchedule is suspended.
 	if !ts.Spec.Suspend {
 		return r.scheduleTask(ctx, ts)
@@ -191,18 +205,34 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, ts *v1alpha1.TaskSchedule
 
 func (r *Reconciler) scheduleTask(ctx context.Context, ts *v1alpha1.TaskSchedule) error {
 	logger := logging.FromContext(ctx)
-	sched, err := cron.ParseStandard(ts.Spec.Schedule)
+	sched, err := ts.Spec.ParseSchedule()
 	if err != nil {
 		ts.Status.MarkScheduleError(werrors.Wrap(err, "Failed to parse schedule"))
 		return err
 	}
 
-	scheduledTime, err := getNextScheduleTime(*ts, time.Now(), sched)
+	times, err := getNextScheduleTime(*ts, time.Now(), sched)
 	if err != nil {
 		ts.Status.MarkScheduleError(werrors.Wrap(err, "Failed to find next schedule time"))
 		return err
 	}
 
+	if times.missed > maxMissedRuns {
+		logger.Warnf("Too many missed runs (%d > %d) of schedule %q, set or decrease startingDeadlineSeconds or check clock skew", times.missed, maxMissedRuns, ts.Spec.Schedule)
+	}
+
+	if times.missed > 0 {
+		logger.Warnf("Skipping %d missed run(s) of schedule %q, the latest was at %s", times.missed, ts.Spec.Schedule, times.lastMissed)
+		ts.Status.MissedRuns += times.missed
+		ts.Status.LastMissedTime = &metav1.Time{Time: *times.lastMissed}
+
+		// Missed runs are only counted once so failed notifications aren't
+		// retried.
+		event := reconcilerutil.NewMissedRunsEvent(ts, times.missed, *times.lastMissed)
+		r.notifier.NotifyInBackground(logger, ts.Spec.TaskTemplate.Notifications, event)
+	}
+
+	scheduledTime := times.next
 	if scheduledTime == nil {
 		// There have been no missed execution times since last run.
 		return nil
@@ -279,7 +309,26 @@ func (r *Reconciler) terminateTask(ctx context.Context, namespace, name string)
 	return err
 }
 
-func getNextScheduleTime(ts v1alpha1.TaskSchedule, now time.Time, schedule cron.Schedule) (*time.Time, error) {
+// maxMissedRuns is the number of missed runs to walk before the rest are
+// estimated from the interval between runs, matching the limit CronJobs use.
+const maxMissedRuns = 100
+
+// scheduleTimes holds the runs of a schedule that are due.
+type scheduleTimes struct {
+	// next is the scheduled time of the run to start, nil if none is due.
+	next *time.Time
+
+	// missed is the number of due runs that won't be started.
+	missed int64
+
+	// lastMissed is the scheduled time of the latest missed run.
+	lastMissed *time.Time
+}
+
+// getNextScheduleTime finds the runs that were due between the last handled
+// run and now. Only the latest run is started, earlier ones are missed. The
+// latest run is missed too if it's past the TaskSchedule's starting deadline.
+func getNextScheduleTime(ts v1alpha1.TaskSchedule, now time.Time, schedule cron.Schedule) (scheduleTimes, error) {
 	var earliestTime time.Time
 	if ts.Status.LastScheduleTime != nil {
 		earliestTime = ts.Status.LastScheduleTime.Time
@@ -290,33 +339,94 @@ func getNextScheduleTime(ts v1alpha1.TaskSchedule, now time.Time, schedule cron.
 		earliestTime = ts.ObjectMeta.CreationTimestamp.Time
 	}
 
+	// Missed runs have already been counted.
+	if lastMissed := ts.Status.LastMissedTime; lastMissed != nil && lastMissed.Time.After(earliestTime) {
+		earliestTime = lastMissed.Time
+	}
+
+	var times scheduleTimes
 	if earliestTime.After(now) {
 		// Nothing to start
-		return nil, nil
+		return times, nil
 	}
 
-	t1 := schedule.Next(earliestTime)
-	t2 := schedule.Next(t1)
+	// Walk the schedule rather than dividing by the interval so irregular
+	// schedules and daylight saving time changes are handled. After
+	// maxMissedRuns the rest are skipped using the interval so a schedule that
+	// hasn't run in a long time can't stall the reconciler.
+	skipped := false
+	for t := schedule.Next(earliestTime); !t.After(now); t = schedule.Next(t) {
+		if t.IsZero() {
+			return scheduleTimes{}, fmt.Errorf("schedule has no upcoming times")
+		}
 
-	// If now is before the first schedule time, return nil because there isn't a schedule time before now.
-	if now.Before(t1) {
-		return nil, nil
+		if times.next != nil {
+			times.missed++
+			times.lastMissed = times.next
+		}
+
+		scheduled := t
+		times.next = &scheduled
+
+		if times.missed >= maxMissedRuns && !skipped {
+			skipped = true
+			if interval := schedule.Next(t).Sub(t); interval > 0 {
+				if skip := int64(now.Sub(t)/interval) - 1; skip > 0 {
+					lastMissed := t.Add(time.Duration(skip-1) * interval)
+					t = t.Add(time.Duration(skip) * interval)
+					scheduled := t
+					times.missed += skip
+					times.lastMissed = &lastMissed
+					times.next = &scheduled
+				}
+			}
+		}
 	}
 
-	// If now is between the first and the second schedule times, the most the recent schedule time is the first one.
-	if now.Before(t2) {
-		return &t1, nil
+	if deadline, ok := ts.Spec.StartingDeadline(); ok && times.next != nil && now.Sub(*times.next) > deadline {
+		times.missed++
+		times.lastMissed = times.next
+		times.next = nil
+	}
+
+	return times, nil
+}
+
+// tasksOverHistoryLimits returns the oldest finished Tasks of the TaskSchedule
+// that are over its successful and failed history limits.
+func tasksOverHistoryLimits(ts *v1alpha1.TaskSchedule, tasks []*v1alpha1.Task) []*v1alpha1.Task {
+	var succeeded, failed []*v1alpha1.Task
+	for _, task := range tasks {
+		if task.GetDeletionTimestamp() != nil || !isTaskFinished(task) {
+			continue
+		}
+
+		if task.Status.GetCondition(v1alpha1.TaskConditionSucceeded).IsTrue() {
+			succeeded = append(succeeded, task)
+		} else {
+			failed = append(failed, task)
+		}
 	}
 
-	secondsBetweenTwoSchedules := int64(t2.Sub(t1).Round(time.Second).Seconds())
-	if secondsBetweenTwoSchedules < 1 {
-		return nil, fmt.Errorf("time difference between two schedules less than 1 second")
+	return append(
+		oldestOverLimit(succeeded, ts.Spec.SuccessfulHistoryLimit),
+		oldestOverLimit(failed, ts.Spec.FailedHistoryLimit)...,
+	)
+}
+
+func oldestOverLimit(tasks []*v1alpha1.Task, limit *int32) []*v1alpha1.Task {
+	if limit == nil || len(tasks) <= int(*limit) {
+		return nil
 	}
-	secondsElapsed := int64(now.Sub(t1).Seconds())
-	numberOfMissedSchedules := (secondsElapsed / secondsBetweenTwoSchedules) + 1
-	next := time.Unix(t1.Unix()+((numberOfMissedSchedules-1)*secondsBetweenTwoSchedules), 0).UTC()
 
-	return &next, nil
+	sort.Slice(tasks, func(i, j int) bool {
+		if !tasks[i].CreationTimestamp.Equal(&tasks[j].CreationTimestamp) {
+			return tasks[i].CreationTimestamp.Before(&tasks[j].CreationTimestamp)
+		}
+		return tasks[i].Name < tasks[j].Name
+	})
+
+	return tasks[:len(tasks)-int(*limit)]
 }
 
 func (r *Reconciler) updateStatus(ctx context.Context, desired *v1alpha1.TaskSchedule) (*v1alpha1.TaskSchedule, error) {
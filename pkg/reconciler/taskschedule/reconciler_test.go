@@ -15,28 +15,42 @@
 package taskschedule
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	kffake "github.com/google/kf/v2/pkg/client/kf/clientset/versioned/fake"
+	kflisters "github.com/google/kf/v2/pkg/client/kf/listers/kf/v1alpha1"
 	"github.com/google/kf/v2/pkg/kf/testutil"
+	"github.com/google/kf/v2/pkg/reconciler"
+	"github.com/google/kf/v2/pkg/reconciler/reconcilerutil"
 	cron "github.com/robfig/cron/v3"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/ptr"
 )
 
 func TestGetNextScheduleTime(t *testing.T) {
 	t.Parallel()
 	type args struct {
-		earliestTime *time.Time
-		now          time.Time
-		schedule     string
+		earliestTime     *time.Time
+		now              time.Time
+		schedule         string
+		startingDeadline *int64
 	}
 	tests := []struct {
-		name         string
-		args         args
-		expectedTime *time.Time
-		wantErr      bool
+		name               string
+		args               args
+		expectedTime       *time.Time
+		expectedMissed     int64
+		expectedLastMissed *time.Time
+		wantErr            bool
 	}{
 		{
 			name: "now before next schedule",
@@ -63,7 +77,41 @@ func TestGetNextScheduleTime(t *testing.T) {
 				now:          *deltaTimeAfterTopOfTheHour(time.Minute * 301),
 				schedule:     "0 * * * *",
 			},
-			expectedTime: deltaTimeAfterTopOfTheHour(time.Minute * 300),
+			expectedTime:   deltaTimeAfterTopOfTheHour(time.Minute * 300),
+			expectedMissed: 4,
+		},
+		{
+			name: "too many missed schedules",
+			args: args{
+				earliestTime: deltaTimeAfterTopOfTheHour(time.Second * 10),
+				now:          *deltaTimeAfterTopOfTheHour(time.Hour*1000 + time.Minute),
+				schedule:     "0 * * * *",
+			},
+			expectedTime:       deltaTimeAfterTopOfTheHour(time.Hour * 1000),
+			expectedMissed:     999,
+			expectedLastMissed: deltaTimeAfterTopOfTheHour(time.Hour * 999),
+		},
+		{
+			name: "latest schedule within starting deadline",
+			args: args{
+				earliestTime:     deltaTimeAfterTopOfTheHour(time.Second * 10),
+				now:              *deltaTimeAfterTopOfTheHour(time.Minute * 121),
+				schedule:         "0 * * * *",
+				startingDeadline: ptr.Int64(120),
+			},
+			expectedTime:   deltaTimeAfterTopOfTheHour(time.Minute * 120),
+			expectedMissed: 1,
+		},
+		{
+			name: "latest schedule past starting deadline",
+			args: args{
+				earliestTime:     deltaTimeAfterTopOfTheHour(time.Second * 10),
+				now:              *deltaTimeAfterTopOfTheHour(time.Minute * 125),
+				schedule:         "0 * * * *",
+				startingDeadline: ptr.Int64(120),
+			},
+			expectedTime:   nil,
+			expectedMissed: 2,
 		},
 		{
 			name: "rogue cronjob",
@@ -82,7 +130,10 @@ func TestGetNextScheduleTime(t *testing.T) {
 			if err != nil {
 				t.Errorf("error setting up the test, %s", err)
 			}
-			gotTime, err := getNextScheduleTime(v1alpha1.TaskSchedule{
+			got, err := getNextScheduleTime(v1alpha1.TaskSchedule{
+				Spec: v1alpha1.TaskScheduleSpec{
+					StartingDeadlineSeconds: tt.args.startingDeadline,
+				},
 				Status: v1alpha1.TaskScheduleStatus{
 					TaskScheduleStatusFields: v1alpha1.TaskScheduleStatusFields{
 						LastScheduleTime: &metav1.Time{
@@ -100,12 +151,20 @@ func TestGetNextScheduleTime(t *testing.T) {
 			if !tt.wantErr && err != nil {
 				t.Error("getNextScheduleTime() got error when none expected")
 			}
+			gotTime := got.next
 			if gotTime == nil && tt.expectedTime != nil {
 				t.Errorf("getNextScheduleTime() got nil, want %v", tt.expectedTime)
 			}
 			if gotTime != nil && tt.expectedTime != nil && !gotTime.Equal(*tt.expectedTime) {
 				t.Errorf("getNextScheduleTime() got = %v, want %v", gotTime, tt.expectedTime)
 			}
+			if gotTime != nil && tt.expectedTime == nil {
+				t.Errorf("getNextScheduleTime() got = %v, want nil", gotTime)
+			}
+			testutil.AssertEqual(t, "missed", tt.expectedMissed, got.missed)
+			if tt.expectedLastMissed != nil {
+				testutil.AssertEqual(t, "lastMissed", *tt.expectedLastMissed, *got.lastMissed)
+			}
 		})
 	}
 }
@@ -215,6 +274,65 @@ func TestRemoveFromActive(t *testing.T) {
 	}
 }
 
+func TestTasksOverHistoryLimits(t *testing.T) {
+	t.Parallel()
+
+	makeTask := func(name string, age time.Duration, status corev1.ConditionStatus) *v1alpha1.Task {
+		task := &v1alpha1.Task{}
+		task.Name = name
+		task.CreationTimestamp = metav1.NewTime(topOfTheHour().Add(-age))
+		task.Status.Conditions = []apis.Condition{
+			{Type: v1alpha1.TaskConditionSucceeded, Status: status},
+		}
+		return task
+	}
+
+	tasks := []*v1alpha1.Task{
+		makeTask("succeeded-new", time.Minute, corev1.ConditionTrue),
+		makeTask("succeeded-old", time.Hour, corev1.ConditionTrue),
+		makeTask("succeeded-oldest", 2*time.Hour, corev1.ConditionTrue),
+		makeTask("failed-new", time.Minute, corev1.ConditionFalse),
+		makeTask("failed-old", time.Hour, corev1.ConditionFalse),
+		makeTask("running", 3*time.Hour, corev1.ConditionUnknown),
+	}
+
+	tests := []struct {
+		name            string
+		successfulLimit *int32
+		failedLimit     *int32
+		expected        []string
+	}{
+		{
+			name:     "no limits",
+			expected: nil,
+		},
+		{
+			name:            "oldest over limits",
+			successfulLimit: ptr.Int32(1),
+			failedLimit:     ptr.Int32(1),
+			expected:        []string{"succeeded-oldest", "succeeded-old", "failed-old"},
+		},
+		{
+			name:        "zero limit",
+			failedLimit: ptr.Int32(0),
+			expected:    []string{"failed-old", "failed-new"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := makeTaskSchedule()
+			ts.Spec.SuccessfulHistoryLimit = tc.successfulLimit
+			ts.Spec.FailedHistoryLimit = tc.failedLimit
+
+			var actual []string
+			for _, task := range tasksOverHistoryLimits(&ts, append([]*v1alpha1.Task{}, tasks...)) {
+				actual = append(actual, task.Name)
+			}
+			testutil.AssertEqual(t, "deleted", tc.expected, actual)
+		})
+	}
+}
+
 func topOfTheHour() *time.Time {
 	T1, err := time.Parse(time.RFC3339, "2016-05-19T10:00:00Z")
 	if err != nil {
@@ -231,3 +349,52 @@ func deltaTimeAfterTopOfTheHour(duration time.Duration) *time.Time {
 	t := T1.Add(duration)
 	return &t
 }
+
+func TestReconciler_scheduleTask_missedRunNotifications(t *testing.T) {
+	t.Parallel()
+
+	events := make(chan reconcilerutil.TaskNotificationEvent, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event reconcilerutil.TaskNotificationEvent
+		testutil.AssertNil(t, "decode err", json.NewDecoder(r.Body).Decode(&event))
+		events <- event
+	}))
+	defer server.Close()
+
+	client := kffake.NewSimpleClientset()
+	r := &Reconciler{
+		Base:       &reconciler.Base{KfClientSet: client},
+		taskLister: kflisters.NewTaskLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})),
+		notifier:   &reconcilerutil.TaskNotifier{Client: server.Client()},
+	}
+
+	ts := &v1alpha1.TaskSchedule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "billing",
+			Namespace: "my-space",
+		},
+		Spec: v1alpha1.TaskScheduleSpec{
+			Schedule: "* * * * *",
+			TaskTemplate: v1alpha1.TaskSpec{
+				AppRef: corev1.LocalObjectReference{Name: "billing-app"},
+				Notifications: []v1alpha1.TaskNotification{
+					{URL: server.URL},
+				},
+			},
+		},
+	}
+	ts.Status.LastScheduleTime = &metav1.Time{Time: time.Now().Add(-10 * time.Minute)}
+
+	testutil.AssertNil(t, "err", r.scheduleTask(context.Background(), ts))
+	testutil.AssertTrue(t, "missed runs counted", ts.Status.MissedRuns > 0)
+	testutil.AssertEqual(t, "active", 1, len(ts.Status.Active))
+
+	select {
+	case event := <-events:
+		testutil.AssertEqual(t, "event", v1alpha1.TaskNotificationMissed, event.Event)
+		testutil.AssertEqual(t, "taskSchedule", "billing", event.TaskSchedule)
+		testutil.AssertEqual(t, "missedRuns", ts.Status.MissedRuns, event.MissedRuns)
+	case <-time.After(10 * time.Second):
+		t.Fatal("missed run notification was never sent")
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflowrun
+
+import (
+	"context"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/reconciler"
+	"github.com/google/kf/v2/pkg/reconciler/reconcilerutil"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+
+	spaceinformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/space"
+	taskinformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/task"
+	taskscheduleinformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/taskschedule"
+	workflowruninformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/workflowrun"
+)
+
+// NewController creates a new controller capable of reconciling Kf
+// WorkflowRuns.
+func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+	logger := reconciler.NewControllerLogger(ctx, "workflowruns.kf.dev")
+
+	spaceInformer := spaceinformer.Get(ctx)
+	workflowRunInformer := workflowruninformer.Get(ctx)
+	taskInformer := taskinformer.Get(ctx)
+	taskScheduleInformer := taskscheduleinformer.Get(ctx)
+
+	c := &Reconciler{
+		Base:               reconciler.NewBase(ctx, cmw),
+		spaceLister:        spaceInformer.Lister(),
+		workflowRunLister:  workflowRunInformer.Lister(),
+		taskLister:         taskInformer.Lister(),
+		taskScheduleLister: taskScheduleInformer.Lister(),
+	}
+
+	impl := controller.NewContext(ctx, c, controller.ControllerOptions{
+		WorkQueueName: "workflowruns",
+		Logger:        logger,
+		Reporter:      &reconcilerutil.StructuredStatsReporter{Logger: logger},
+	})
+
+	logger.Info("Setting up event handlers")
+
+	workflowRunInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+
+	// Each change to a step's Task may allow the next steps to start.
+	taskInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.Filter(v1alpha1.SchemeGroupVersion.WithKind("WorkflowRun")),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
+	return impl
+}
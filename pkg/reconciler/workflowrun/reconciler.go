@@ -0,0 +1,295 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflowrun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	kflisters "github.com/google/kf/v2/pkg/client/kf/listers/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/reconciler"
+	"github.com/google/kf/v2/pkg/reconciler/workflowrun/resources"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+// Reconciler reconciles a WorkflowRun object with the K8s cluster.
+type Reconciler struct {
+	*reconciler.Base
+
+	spaceLister        kflisters.SpaceLister
+	workflowRunLister  kflisters.WorkflowRunLister
+	taskLister         kflisters.TaskLister
+	taskScheduleLister kflisters.TaskScheduleLister
+}
+
+// Check that our Reconciler implements controller.Reconciler
+var _ controller.Reconciler = (*Reconciler)(nil)
+
+// Reconcile is called by knative/pkg when a new event is observed by one of the
+// watchers in the controller.
+func (r *Reconciler) Reconcile(ctx context.Context, key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	return r.reconcileWorkflowRun(
+		logging.WithLogger(ctx,
+			logging.FromContext(ctx).With("namespace", namespace)),
+		namespace,
+		name,
+	)
+}
+
+func (r *Reconciler) reconcileWorkflowRun(ctx context.Context, namespace, name string) error {
+	logger := logging.FromContext(ctx)
+
+	original, err := r.workflowRunLister.WorkflowRuns(namespace).Get(name)
+	switch {
+	case apierrs.IsNotFound(err):
+		logger.Info("resource no longer exists")
+		return nil
+	case err != nil:
+		return err
+	case original.GetDeletionTimestamp() != nil:
+		logger.Info("resource deletion requested")
+		toUpdate := original.DeepCopy()
+		toUpdate.Status.PropagateTerminatingStatus()
+		if _, uErr := r.updateStatus(ctx, toUpdate); uErr != nil {
+			logger.Warnw("Failed to update WorkflowRun status", zap.Error(uErr))
+			return uErr
+		}
+		return nil
+	case v1alpha1.IsStatusFinal(original.Status.Status):
+		// Finished runs never start new Tasks.
+		return nil
+	}
+
+	if r.IsNamespaceTerminating(namespace) {
+		logger.Info("namespace is terminating, skipping reconciliation")
+		return nil
+	}
+
+	// Don't modify the informers copy
+	toReconcile := original.DeepCopy()
+
+	// ALWAYS update the ObservedGenration: "If the primary resource your
+	// controller is reconciling supports ObservedGeneration in its status, make
+	// sure you correctly set it to metadata.Generation whenever the values
+	// between the two fields mismatches."
+	// https://github.com/kubernetes/community/blob/master/contributors/devel/sig-api-machinery/controllers.md
+	toReconcile.Status.ObservedGeneration = toReconcile.Generation
+
+	// Reconcile this copy of the service and then write back any status
+	// updates regardless of whether the reconciliation errored out.
+	reconcileErr := r.ApplyChanges(ctx, toReconcile)
+	if reconcileErr != nil {
+		logger.Debugf("WorkflowRun reconcilerErr is not empty: %+v", reconcileErr)
+	}
+	if equality.Semantic.DeepEqual(original.Status, toReconcile.Status) {
+		// If we didn't change anything then don't call updateStatus.
+		// This is important because the copy we loaded from the informer's
+		// cache may be stale and we don't want to overwrite a prior update
+		// to status with this stale state.
+
+	} else if _, uErr := r.updateStatus(ctx, toReconcile); uErr != nil {
+		logger.Warnw("Failed to update WorkflowRun status", zap.Error(uErr))
+		return uErr
+	}
+
+	return reconcileErr
+}
+
+// ApplyChanges starts the Tasks for steps of the WorkflowRun whose
+// dependencies completed and updates the status of the WorkflowRun from the
+// Tasks it created.
+func (r *Reconciler) ApplyChanges(ctx context.Context, run *v1alpha1.WorkflowRun) error {
+	logger := logging.FromContext(ctx)
+	run.Status.InitializeConditions()
+
+	// Ensure Kf Space exists to prevent Kf objects from being created in namespaces that's not a Kf Space.
+	if _, err := r.spaceLister.Get(run.GetNamespace()); err != nil {
+		run.Status.MarkSpaceUnhealthy("GettingSpace", err.Error())
+		return err
+	}
+	run.Status.MarkSpaceHealthy()
+
+	if run.Status.StartTime == nil {
+		run.Status.StartTime = &metav1.Time{Time: time.Now()}
+	}
+	run.Status.InitializeSteps(run.Spec.Steps)
+
+	tasks, err := r.getChildren(run)
+	if err != nil {
+		logger.Warnw("Failed to get child tasks", zap.Error(err))
+		return err
+	}
+	propagateTasks(run, tasks)
+
+	if run.Spec.Terminated {
+		for _, task := range tasksToTerminate(tasks) {
+			logger.Infof("Terminating Task %q, the WorkflowRun was terminated", task.Name)
+			if err := r.terminateTask(ctx, task.GetNamespace(), task.Name); err != nil && !apierrs.IsNotFound(err) {
+				logger.Warnw("Failed to terminate Task", zap.Error(err))
+				return err
+			}
+		}
+		resources.SkipPendingSteps(run, "WorkflowRun was terminated")
+	} else {
+		for _, step := range resources.PlanSteps(run) {
+			if err := r.startStep(ctx, run, step); err != nil {
+				return err
+			}
+		}
+	}
+
+	run.Status.PropagateStepStatus(run.Spec.Terminated, metav1.Time{Time: time.Now()})
+	return nil
+}
+
+// startStep creates the Task for a step of the WorkflowRun. Steps that
+// reference a missing job fail rather than blocking the run.
+func (r *Reconciler) startStep(ctx context.Context, run *v1alpha1.WorkflowRun, step v1alpha1.WorkflowStep) error {
+	logger := logging.FromContext(ctx)
+	status := run.Status.StepStatus(step.Name)
+
+	template, err := r.stepTemplate(run.GetNamespace(), step)
+	if err != nil {
+		status.Phase = v1alpha1.WorkflowStepFailed
+		status.Message = err.Error()
+		return nil
+	}
+
+	desiredTask := resources.MakeTask(run, &step, template)
+	logger.Infof("Creating Task %q for step %q", desiredTask.Name, step.Name)
+	_, err = r.KfClientSet.
+		KfV1alpha1().
+		Tasks(desiredTask.GetNamespace()).
+		Create(ctx, desiredTask, metav1.CreateOptions{})
+	switch {
+	case apierrs.IsAlreadyExists(err):
+		logger.Warnf("Task %q already exists", desiredTask.Name)
+	case err != nil:
+		logger.Warnw("Failed to create Task", zap.Error(err))
+		return err
+	}
+
+	status.Phase = v1alpha1.WorkflowStepRunning
+	status.TaskName = desiredTask.Name
+	status.Message = ""
+	return nil
+}
+
+// stepTemplate gets the Task template of the step, either inline or from the
+// job it references.
+func (r *Reconciler) stepTemplate(namespace string, step v1alpha1.WorkflowStep) (v1alpha1.TaskSpec, error) {
+	if step.TaskTemplate != nil {
+		return *step.TaskTemplate, nil
+	}
+
+	if step.JobRef == nil {
+		return v1alpha1.TaskSpec{}, fmt.Errorf("step %q has no job or Task template", step.Name)
+	}
+
+	job, err := r.taskScheduleLister.TaskSchedules(namespace).Get(step.JobRef.Name)
+	if err != nil {
+		return v1alpha1.TaskSpec{}, fmt.Errorf("couldn't get job %q: %v", step.JobRef.Name, err)
+	}
+
+	return job.Spec.TaskTemplate, nil
+}
+
+func (r *Reconciler) getChildren(run *v1alpha1.WorkflowRun) ([]*v1alpha1.Task, error) {
+	req, err := labels.NewRequirement(resources.OwningWorkflowRun, selection.Equals, []string{run.Name})
+	if err != nil {
+		return nil, err
+	}
+	selector := labels.NewSelector().Add(*req)
+	return r.taskLister.Tasks(run.GetNamespace()).List(selector)
+}
+
+func (r *Reconciler) terminateTask(ctx context.Context, namespace, name string) error {
+	mergePatch := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"terminated": true,
+		},
+	}
+	patch, err := json.Marshal(mergePatch)
+	if err != nil {
+		return err
+	}
+	_, err = r.KfClientSet.KfV1alpha1().
+		Tasks(namespace).
+		Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+func (r *Reconciler) updateStatus(ctx context.Context, desired *v1alpha1.WorkflowRun) (*v1alpha1.WorkflowRun, error) {
+	actual, err := r.workflowRunLister.WorkflowRuns(desired.GetNamespace()).Get(desired.Name)
+	if err != nil {
+		return nil, err
+	}
+	// If there's nothing to update, just return.
+	if reflect.DeepEqual(actual.Status, desired.Status) {
+		return actual, nil
+	}
+
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+	existing.Status = desired.Status
+
+	return r.KfClientSet.KfV1alpha1().
+		WorkflowRuns(existing.GetNamespace()).
+		UpdateStatus(ctx, existing, metav1.UpdateOptions{})
+}
+
+// propagateTasks updates the status of each step from the Task created for
+// it.
+func propagateTasks(run *v1alpha1.WorkflowRun, tasks []*v1alpha1.Task) {
+	for _, task := range tasks {
+		status := run.Status.StepStatus(task.Labels[resources.WorkflowStepLabel])
+		if status == nil {
+			continue
+		}
+
+		status.PropagateTaskStatus(task)
+	}
+}
+
+// tasksToTerminate returns the Tasks that are still running and haven't been
+// asked to terminate yet.
+func tasksToTerminate(tasks []*v1alpha1.Task) []*v1alpha1.Task {
+	var out []*v1alpha1.Task
+	for _, task := range tasks {
+		if task.Spec.Terminated || v1alpha1.IsStatusFinal(task.Status.Status) {
+			continue
+		}
+		out = append(out, task)
+	}
+	return out
+}
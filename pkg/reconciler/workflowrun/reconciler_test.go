@@ -0,0 +1,309 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflowrun
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	kffake "github.com/google/kf/v2/pkg/client/kf/clientset/versioned/fake"
+	kflisters "github.com/google/kf/v2/pkg/client/kf/listers/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	"github.com/google/kf/v2/pkg/reconciler"
+	"github.com/google/kf/v2/pkg/reconciler/reconcilertesting"
+	"github.com/google/kf/v2/pkg/reconciler/workflowrun/resources"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ktesting "k8s.io/client-go/testing"
+	"knative.dev/pkg/apis"
+)
+
+func makeTask(step string, status corev1.ConditionStatus) *v1alpha1.Task {
+	task := &v1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "run-" + step,
+			Labels: map[string]string{
+				resources.WorkflowStepLabel: step,
+			},
+		},
+	}
+	task.Status.Conditions = []apis.Condition{
+		{Type: v1alpha1.TaskConditionSucceeded, Status: status, Message: "some message"},
+	}
+	return task
+}
+
+func TestPropagateTasks(t *testing.T) {
+	t.Parallel()
+
+	run := &v1alpha1.WorkflowRun{}
+	run.Status.Steps = []v1alpha1.WorkflowStepStatus{
+		{Name: "extract", Phase: v1alpha1.WorkflowStepRunning},
+		{Name: "transform", Phase: v1alpha1.WorkflowStepRunning},
+		{Name: "load", Phase: v1alpha1.WorkflowStepRunning},
+		{Name: "report", Phase: v1alpha1.WorkflowStepPending},
+	}
+
+	propagateTasks(run, []*v1alpha1.Task{
+		makeTask("extract", corev1.ConditionTrue),
+		makeTask("transform", corev1.ConditionFalse),
+		makeTask("load", corev1.ConditionUnknown),
+		makeTask("removed-step", corev1.ConditionTrue),
+	})
+
+	testutil.AssertEqual(t, "steps", []v1alpha1.WorkflowStepStatus{
+		{Name: "extract", Phase: v1alpha1.WorkflowStepSucceeded, TaskName: "run-extract"},
+		{Name: "transform", Phase: v1alpha1.WorkflowStepFailed, TaskName: "run-transform", Message: "some message"},
+		{Name: "load", Phase: v1alpha1.WorkflowStepRunning, TaskName: "run-load"},
+		{Name: "report", Phase: v1alpha1.WorkflowStepPending},
+	}, run.Status.Steps)
+}
+
+func TestTasksToTerminate(t *testing.T) {
+	t.Parallel()
+
+	terminated := makeTask("terminated", corev1.ConditionUnknown)
+	terminated.Spec.Terminated = true
+
+	got := tasksToTerminate([]*v1alpha1.Task{
+		makeTask("succeeded", corev1.ConditionTrue),
+		makeTask("failed", corev1.ConditionFalse),
+		makeTask("running", corev1.ConditionUnknown),
+		terminated,
+	})
+
+	var names []string
+	for _, task := range got {
+		names = append(names, task.Name)
+	}
+	testutil.AssertEqual(t, "tasks", []string{"run-running"}, names)
+}
+
+func makeRun(steps ...v1alpha1.WorkflowStep) *v1alpha1.WorkflowRun {
+	return &v1alpha1.WorkflowRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "run",
+			Namespace: "my-space",
+		},
+		Spec: v1alpha1.WorkflowRunSpec{
+			Steps: steps,
+		},
+	}
+}
+
+func TestReconciler_ApplyChanges(t *testing.T) {
+	t.Parallel()
+
+	space := &v1alpha1.Space{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-space"},
+	}
+	template := &v1alpha1.TaskSpec{
+		AppRef:  corev1.LocalObjectReference{Name: "my-app"},
+		Command: "bin/run",
+	}
+	extract := v1alpha1.WorkflowStep{Name: "extract", TaskTemplate: template}
+	load := v1alpha1.WorkflowStep{Name: "load", TaskTemplate: template, DependsOn: []string{"extract"}}
+
+	withNamespace := func(task *v1alpha1.Task) *v1alpha1.Task {
+		task.Namespace = "my-space"
+		task.Labels[resources.OwningWorkflowRun] = "run"
+		return task
+	}
+
+	cases := map[string]struct {
+		run     *v1alpha1.WorkflowRun
+		spaces  []runtime.Object
+		tasks   []runtime.Object
+		wantErr error
+
+		wantSteps   []v1alpha1.WorkflowStepStatus
+		wantActions []string
+		wantSpace   corev1.ConditionStatus
+	}{
+		"missing Space": {
+			run:     makeRun(extract),
+			wantErr: apierrs.NewNotFound(v1alpha1.Resource("space"), "my-space"),
+
+			wantSpace: corev1.ConditionFalse,
+		},
+		"starts steps without dependencies": {
+			run:    makeRun(extract, load),
+			spaces: []runtime.Object{space},
+
+			wantSteps: []v1alpha1.WorkflowStepStatus{
+				{Name: "extract", Phase: v1alpha1.WorkflowStepRunning, TaskName: "run-extract"},
+				{Name: "load", Phase: v1alpha1.WorkflowStepPending},
+			},
+			wantActions: []string{"create tasks run-extract"},
+			wantSpace:   corev1.ConditionTrue,
+		},
+		"starts steps once their dependencies succeed": {
+			run:    makeRun(extract, load),
+			spaces: []runtime.Object{space},
+			tasks: []runtime.Object{
+				withNamespace(makeTask("extract", corev1.ConditionTrue)),
+			},
+
+			wantSteps: []v1alpha1.WorkflowStepStatus{
+				{Name: "extract", Phase: v1alpha1.WorkflowStepSucceeded, TaskName: "run-extract"},
+				{Name: "load", Phase: v1alpha1.WorkflowStepRunning, TaskName: "run-load"},
+			},
+			wantActions: []string{"create tasks run-load"},
+			wantSpace:   corev1.ConditionTrue,
+		},
+		"terminated run terminates running Tasks": {
+			run: func() *v1alpha1.WorkflowRun {
+				run := makeRun(extract, load)
+				run.Spec.Terminated = true
+				return run
+			}(),
+			spaces: []runtime.Object{space},
+			tasks: []runtime.Object{
+				withNamespace(makeTask("extract", corev1.ConditionUnknown)),
+			},
+
+			wantSteps: []v1alpha1.WorkflowStepStatus{
+				{Name: "extract", Phase: v1alpha1.WorkflowStepRunning, TaskName: "run-extract"},
+				{Name: "load", Phase: v1alpha1.WorkflowStepSkipped, Message: "WorkflowRun was terminated"},
+			},
+			wantActions: []string{"patch tasks run-extract"},
+			wantSpace:   corev1.ConditionTrue,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			client := kffake.NewSimpleClientset(tc.tasks...)
+			r := &Reconciler{
+				Base:        &reconciler.Base{KfClientSet: client},
+				spaceLister: kflisters.NewSpaceLister(reconcilertesting.NewIndexer(t, tc.spaces...)),
+				taskLister:  kflisters.NewTaskLister(reconcilertesting.NewIndexer(t, tc.tasks...)),
+			}
+
+			run := tc.run.DeepCopy()
+			err := r.ApplyChanges(context.Background(), run)
+			testutil.AssertErrorsEqual(t, tc.wantErr, err)
+			testutil.AssertEqual(t, "steps", tc.wantSteps, run.Status.Steps)
+			testutil.AssertEqual(t, "space condition", tc.wantSpace, run.Status.GetCondition(v1alpha1.WorkflowRunConditionSpaceReady).Status)
+			testutil.AssertEqual(t, "actions", tc.wantActions, actionSummaries(client.Actions()))
+		})
+	}
+}
+
+func TestReconciler_startStep(t *testing.T) {
+	t.Parallel()
+
+	job := &v1alpha1.TaskSchedule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "extract-job",
+			Namespace: "my-space",
+		},
+		Spec: v1alpha1.TaskScheduleSpec{
+			TaskTemplate: v1alpha1.TaskSpec{
+				AppRef:  corev1.LocalObjectReference{Name: "my-app"},
+				Command: "bin/extract",
+			},
+		},
+	}
+	existing := &v1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "run-extract",
+			Namespace: "my-space",
+		},
+	}
+
+	cases := map[string]struct {
+		step    v1alpha1.WorkflowStep
+		objects []runtime.Object
+
+		wantStatus  v1alpha1.WorkflowStepStatus
+		wantCommand string
+	}{
+		"creates the Task from the job": {
+			step:    v1alpha1.WorkflowStep{Name: "extract", JobRef: &corev1.LocalObjectReference{Name: "extract-job"}},
+			objects: []runtime.Object{job},
+
+			wantStatus:  v1alpha1.WorkflowStepStatus{Name: "extract", Phase: v1alpha1.WorkflowStepRunning, TaskName: "run-extract"},
+			wantCommand: "bin/extract",
+		},
+		"existing Task is reused": {
+			step:    v1alpha1.WorkflowStep{Name: "extract", JobRef: &corev1.LocalObjectReference{Name: "extract-job"}},
+			objects: []runtime.Object{job, existing},
+
+			wantStatus: v1alpha1.WorkflowStepStatus{Name: "extract", Phase: v1alpha1.WorkflowStepRunning, TaskName: "run-extract"},
+		},
+		"missing job fails the step": {
+			step: v1alpha1.WorkflowStep{Name: "extract", JobRef: &corev1.LocalObjectReference{Name: "extract-job"}},
+
+			wantStatus: v1alpha1.WorkflowStepStatus{
+				Name:    "extract",
+				Phase:   v1alpha1.WorkflowStepFailed,
+				Message: `couldn't get job "extract-job": taskschedule.kf.dev "extract-job" not found`,
+			},
+		},
+		"step without a template fails": {
+			step: v1alpha1.WorkflowStep{Name: "extract"},
+
+			wantStatus: v1alpha1.WorkflowStepStatus{
+				Name:    "extract",
+				Phase:   v1alpha1.WorkflowStepFailed,
+				Message: `step "extract" has no job or Task template`,
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			client := kffake.NewSimpleClientset(tc.objects...)
+			r := &Reconciler{
+				Base:               &reconciler.Base{KfClientSet: client},
+				taskScheduleLister: kflisters.NewTaskScheduleLister(reconcilertesting.NewIndexer(t, tc.objects...)),
+			}
+
+			run := makeRun(tc.step)
+			run.Status.InitializeSteps(run.Spec.Steps)
+
+			err := r.startStep(context.Background(), run, tc.step)
+			testutil.AssertNil(t, "err", err)
+			testutil.AssertEqual(t, "status", tc.wantStatus, run.Status.Steps[0])
+
+			if tc.wantCommand != "" {
+				task, err := client.KfV1alpha1().Tasks("my-space").Get(context.Background(), "run-extract", metav1.GetOptions{})
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "command", tc.wantCommand, task.Spec.Command)
+				testutil.AssertEqual(t, "step label", "extract", task.Labels[resources.WorkflowStepLabel])
+			}
+		})
+	}
+}
+
+// actionSummaries summarizes the mutating actions taken by a fake client as
+// "verb resource name".
+func actionSummaries(actions []ktesting.Action) []string {
+	var out []string
+	for _, action := range actions {
+		switch a := action.(type) {
+		case ktesting.CreateAction:
+			obj := a.GetObject().(metav1.Object)
+			out = append(out, a.GetVerb()+" "+a.GetResource().Resource+" "+obj.GetName())
+		case ktesting.PatchAction:
+			out = append(out, a.GetVerb()+" "+a.GetResource().Resource+" "+a.GetName())
+		}
+	}
+	return out
+}
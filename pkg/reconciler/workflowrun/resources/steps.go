@@ -0,0 +1,91 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+)
+
+// PlanSteps finds the pending steps of the WorkflowRun whose dependencies
+// have completed. Steps whose RunIf policy doesn't match the result of their
+// dependencies are marked as skipped, which may complete the dependencies of
+// other steps. The steps that should start are returned.
+func PlanSteps(run *v1alpha1.WorkflowRun) []v1alpha1.WorkflowStep {
+	var toStart []v1alpha1.WorkflowStep
+	starting := make(map[string]bool)
+
+	for changed := true; changed; {
+		changed = false
+
+		for _, step := range run.Spec.Steps {
+			status := run.Status.StepStatus(step.Name)
+			if status == nil || status.Phase != v1alpha1.WorkflowStepPending || starting[step.Name] {
+				continue
+			}
+
+			ready, shouldRun, reason := evaluateDependencies(run, step)
+			switch {
+			case !ready:
+				continue
+			case shouldRun:
+				starting[step.Name] = true
+				toStart = append(toStart, step)
+			default:
+				status.Phase = v1alpha1.WorkflowStepSkipped
+				status.Message = reason
+				changed = true
+			}
+		}
+	}
+
+	return toStart
+}
+
+// SkipPendingSteps marks all steps of the WorkflowRun that haven't started as
+// skipped.
+func SkipPendingSteps(run *v1alpha1.WorkflowRun, reason string) {
+	for i := range run.Status.Steps {
+		if run.Status.Steps[i].Phase == v1alpha1.WorkflowStepPending {
+			run.Status.Steps[i].Phase = v1alpha1.WorkflowStepSkipped
+			run.Status.Steps[i].Message = reason
+		}
+	}
+}
+
+// evaluateDependencies checks whether all dependencies of the step completed
+// and if so, whether the step should run according to its RunIf policy. If
+// the step shouldn't run, the reason is returned.
+func evaluateDependencies(run *v1alpha1.WorkflowRun, step v1alpha1.WorkflowStep) (ready, shouldRun bool, reason string) {
+	allSucceeded := true
+	anyFailed := false
+	for _, dep := range step.DependsOn {
+		status := run.Status.StepStatus(dep)
+		if status == nil || !status.IsCompleted() {
+			return false, false, ""
+		}
+
+		allSucceeded = allSucceeded && status.Phase == v1alpha1.WorkflowStepSucceeded
+		anyFailed = anyFailed || status.Phase == v1alpha1.WorkflowStepFailed
+	}
+
+	switch step.RunIf {
+	case v1alpha1.WorkflowStepRunIfFailed:
+		return true, anyFailed, "No dependency failed"
+	case v1alpha1.WorkflowStepRunIfCompleted:
+		return true, true, ""
+	default:
+		return true, allSucceeded, "Not all dependencies succeeded"
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+)
+
+func TestPlanSteps(t *testing.T) {
+	t.Parallel()
+
+	step := func(name, runIf string, dependsOn ...string) v1alpha1.WorkflowStep {
+		return v1alpha1.WorkflowStep{Name: name, RunIf: runIf, DependsOn: dependsOn}
+	}
+
+	etl := []v1alpha1.WorkflowStep{
+		step("extract", v1alpha1.WorkflowStepRunIfSucceeded),
+		step("transform-a", v1alpha1.WorkflowStepRunIfSucceeded, "extract"),
+		step("transform-b", v1alpha1.WorkflowStepRunIfSucceeded, "extract"),
+		step("load", v1alpha1.WorkflowStepRunIfSucceeded, "transform-a", "transform-b"),
+		step("on-failure", v1alpha1.WorkflowStepRunIfFailed, "transform-a", "transform-b"),
+		step("report", v1alpha1.WorkflowStepRunIfCompleted, "load"),
+	}
+
+	cases := map[string]struct {
+		phases      map[string]string
+		wantStart   []string
+		wantSkipped []string
+	}{
+		"new run starts roots": {
+			wantStart: []string{"extract"},
+		},
+		"fan out": {
+			phases: map[string]string{
+				"extract": v1alpha1.WorkflowStepSucceeded,
+			},
+			wantStart: []string{"transform-a", "transform-b"},
+		},
+		"waits for all dependencies": {
+			phases: map[string]string{
+				"extract":     v1alpha1.WorkflowStepSucceeded,
+				"transform-a": v1alpha1.WorkflowStepSucceeded,
+				"transform-b": v1alpha1.WorkflowStepRunning,
+			},
+		},
+		"fan in skips failure handler": {
+			phases: map[string]string{
+				"extract":     v1alpha1.WorkflowStepSucceeded,
+				"transform-a": v1alpha1.WorkflowStepSucceeded,
+				"transform-b": v1alpha1.WorkflowStepSucceeded,
+			},
+			wantStart:   []string{"load"},
+			wantSkipped: []string{"on-failure"},
+		},
+		"failure runs handler and cascades skips": {
+			phases: map[string]string{
+				"extract":     v1alpha1.WorkflowStepSucceeded,
+				"transform-a": v1alpha1.WorkflowStepFailed,
+				"transform-b": v1alpha1.WorkflowStepSucceeded,
+			},
+			wantStart:   []string{"on-failure", "report"},
+			wantSkipped: []string{"load"},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			run := &v1alpha1.WorkflowRun{}
+			run.Spec.Steps = etl
+			run.Status.InitializeSteps(etl)
+			for name, phase := range tc.phases {
+				run.Status.StepStatus(name).Phase = phase
+			}
+
+			var started []string
+			for _, step := range PlanSteps(run) {
+				started = append(started, step.Name)
+			}
+
+			var skipped []string
+			for _, status := range run.Status.Steps {
+				if status.Phase == v1alpha1.WorkflowStepSkipped {
+					skipped = append(skipped, status.Name)
+				}
+			}
+
+			testutil.AssertEqual(t, "started", tc.wantStart, started)
+			testutil.AssertEqual(t, "skipped", tc.wantSkipped, skipped)
+		})
+	}
+}
+
+func TestSkipPendingSteps(t *testing.T) {
+	t.Parallel()
+
+	run := &v1alpha1.WorkflowRun{}
+	run.Status.Steps = []v1alpha1.WorkflowStepStatus{
+		{Name: "extract", Phase: v1alpha1.WorkflowStepRunning},
+		{Name: "load", Phase: v1alpha1.WorkflowStepPending},
+	}
+
+	SkipPendingSteps(run, "Terminated")
+
+	testutil.AssertEqual(t, "steps", []v1alpha1.WorkflowStepStatus{
+		{Name: "extract", Phase: v1alpha1.WorkflowStepRunning},
+		{Name: "load", Phase: v1alpha1.WorkflowStepSkipped, Message: "Terminated"},
+	}, run.Status.Steps)
+}
@@ -0,0 +1,99 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/kmeta"
+)
+
+const (
+	// OwningWorkflow is the label holding the name of the Workflow a
+	// WorkflowRun was created from.
+	OwningWorkflow = "workflows.kf.dev/owner"
+
+	// OwningWorkflowRun is the label holding the name of the WorkflowRun a
+	// Task was created for.
+	OwningWorkflowRun = "workflowruns.kf.dev/owner"
+
+	// WorkflowStepLabel is the label holding the name of the step a Task was
+	// created for.
+	WorkflowStepLabel = "workflowruns.kf.dev/step"
+)
+
+// TaskName gets the name of the Task for a step of the WorkflowRun. Steps
+// only run once per WorkflowRun so the name is used as a lock to prevent
+// making the same Task twice.
+func TaskName(run *v1alpha1.WorkflowRun, step *v1alpha1.WorkflowStep) string {
+	return v1alpha1.GenerateName(run.Name, step.Name)
+}
+
+// MakeTask creates the Task for a step of the WorkflowRun from the template
+// the step references.
+func MakeTask(run *v1alpha1.WorkflowRun, step *v1alpha1.WorkflowStep, template v1alpha1.TaskSpec) *v1alpha1.Task {
+	task := &v1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      TaskName(run, step),
+			Namespace: run.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(run),
+			},
+			Labels: map[string]string{
+				v1alpha1.ManagedByLabel: "kf",
+				// Add owning WorkflowRun labels as it is not possible to
+				// filter lists based on metadata.ownerReferences.
+				OwningWorkflowRun: run.Name,
+				WorkflowStepLabel: step.Name,
+			},
+		},
+		Spec: *template.DeepCopy(),
+	}
+
+	if task.Spec.DisplayName == "" {
+		task.Spec.DisplayName = task.Name
+	}
+
+	return task
+}
+
+// MakeWorkflowRun creates a run of the Workflow.
+func MakeWorkflowRun(workflow *v1alpha1.Workflow) *v1alpha1.WorkflowRun {
+	run := &v1alpha1.WorkflowRun{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: workflow.Name + "-",
+			Namespace:    workflow.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(workflow),
+			},
+			Labels: map[string]string{
+				v1alpha1.ManagedByLabel: "kf",
+				OwningWorkflow:          workflow.Name,
+			},
+		},
+		Spec: v1alpha1.WorkflowRunSpec{
+			WorkflowRef: corev1.LocalObjectReference{
+				Name: workflow.Name,
+			},
+		},
+	}
+
+	for _, step := range workflow.Spec.Steps {
+		run.Spec.Steps = append(run.Spec.Steps, *step.DeepCopy())
+	}
+
+	return run
+}
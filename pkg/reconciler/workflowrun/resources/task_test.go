@@ -0,0 +1,92 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func ExampleMakeTask() {
+	run := &v1alpha1.WorkflowRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "etl-abcde",
+			Namespace: "my-space",
+		},
+	}
+	step := &v1alpha1.WorkflowStep{Name: "extract"}
+	template := v1alpha1.TaskSpec{
+		AppRef:  corev1.LocalObjectReference{Name: "my-app"},
+		Command: "bin/extract",
+	}
+
+	task := MakeTask(run, step, template)
+	fmt.Println("Name:", task.Name)
+	fmt.Println("Display name:", task.Spec.DisplayName)
+	fmt.Println("Owner:", task.OwnerReferences[0].Name)
+	fmt.Println("Run label:", task.Labels[OwningWorkflowRun])
+	fmt.Println("Step label:", task.Labels[WorkflowStepLabel])
+	fmt.Println("Command:", task.Spec.Command)
+
+	// Output: Name: etl-abcde-extract
+	// Display name: etl-abcde-extract
+	// Owner: etl-abcde
+	// Run label: etl-abcde
+	// Step label: extract
+	// Command: bin/extract
+}
+
+func ExampleMakeWorkflowRun() {
+	workflow := &v1alpha1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "etl",
+			Namespace: "my-space",
+		},
+		Spec: v1alpha1.WorkflowSpec{
+			Steps: []v1alpha1.WorkflowStep{
+				{Name: "extract", JobRef: &corev1.LocalObjectReference{Name: "extract-job"}},
+				{Name: "load", JobRef: &corev1.LocalObjectReference{Name: "load-job"}, DependsOn: []string{"extract"}},
+			},
+		},
+	}
+
+	run := MakeWorkflowRun(workflow)
+	fmt.Println("Generate name:", run.GenerateName)
+	fmt.Println("Workflow:", run.Spec.WorkflowRef.Name)
+	fmt.Println("Workflow label:", run.Labels[OwningWorkflow])
+	fmt.Println("Steps:", len(run.Spec.Steps))
+
+	// Output: Generate name: etl-
+	// Workflow: etl
+	// Workflow label: etl
+	// Steps: 2
+}
+
+func ExampleTaskName_long() {
+	run := &v1alpha1.WorkflowRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "extract-transform-load-nightly-warehouse-refresh-abcde",
+		},
+	}
+	step := &v1alpha1.WorkflowStep{Name: "rebuild-materialized-views"}
+
+	name := TaskName(run, step)
+	fmt.Println("Length:", len(name))
+
+	// Output: Length: 63
+}
@@ -61,6 +61,22 @@ func GetClusterIngresses(lister ServiceLister) ([]corev1.LoadBalancerIngress, er
 	return out, nil
 }
 
+// GetClusterIngressNamespace returns the Namespace of the first service
+// matching the ClusterIngressSelector. Gateway credentials must be stored in
+// this Namespace.
+func GetClusterIngressNamespace(lister ServiceLister) (string, error) {
+	services, err := lister.List(ClusterIngressSelector())
+	if err != nil {
+		return "", err
+	}
+
+	if len(services) == 0 {
+		return "", errors.New("no ingress gateway services were found")
+	}
+
+	return services[0].Namespace, nil
+}
+
 // ExtractProxyIngressFromList is a utility function that extracts a single
 // routable ingress from the list.
 func ExtractProxyIngressFromList(ingresses []corev1.LoadBalancerIngress) (string, error) {
@@ -20,6 +20,7 @@ import (
 
 	"github.com/google/kf/v2/pkg/kf/testutil"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
 )
 
@@ -115,6 +116,42 @@ func TestGetClusterIngresses(t *testing.T) {
 	}
 }
 
+func TestGetClusterIngressNamespace(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		lister          fakeServiceLister
+		expectNamespace string
+		expectErr       error
+	}{
+		"server-error": {
+			lister: fakeServiceLister{
+				err: errors.New("some-server-error"),
+			},
+			expectErr: errors.New("some-server-error"),
+		},
+		"no services": {
+			expectErr: errors.New("no ingress gateway services were found"),
+		},
+		"service found": {
+			lister: fakeServiceLister{
+				services: []*corev1.Service{
+					{ObjectMeta: metav1.ObjectMeta{Namespace: "istio-gateways"}},
+				},
+			},
+			expectNamespace: "istio-gateways",
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			namespace, actualErr := GetClusterIngressNamespace(&tc.lister)
+			testutil.AssertErrorsEqual(t, tc.expectErr, actualErr)
+			testutil.AssertEqual(t, "namespace", tc.expectNamespace, namespace)
+		})
+	}
+}
+
 func TestExtractProxyIngressFromList(t *testing.T) {
 	cases := map[string]struct {
 		ingresses []corev1.LoadBalancerIngress